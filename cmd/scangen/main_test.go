@@ -0,0 +1,26 @@
+package main
+
+import (
+	"bytes"
+	"os"
+	"path/filepath"
+	"testing"
+)
+
+func TestGeneratedModelsUpToDate(t *testing.T) {
+	dir := filepath.Join("..", "..", "pkg", "models")
+
+	want, err := generate(dir)
+	if err != nil {
+		t.Fatalf("generate: %v", err)
+	}
+
+	got, err := os.ReadFile(filepath.Join(dir, outputName))
+	if err != nil {
+		t.Fatalf("read %s: %v", outputName, err)
+	}
+
+	if !bytes.Equal(got, want) {
+		t.Errorf("%s is out of date; run go generate ./pkg/models", outputName)
+	}
+}
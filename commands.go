@@ -0,0 +1,150 @@
+package main
+
+import (
+	"database/sql"
+	"encoding/json"
+	"errors"
+	"flag"
+	"fmt"
+	"io"
+	"net/url"
+	"os"
+	"path/filepath"
+	"strings"
+
+	models "github.com/Sea-Shell/gogear-api/pkg/models"
+	utils "github.com/Sea-Shell/gogear-api/pkg/utils"
+)
+
+// runCommand runs a maintenance subcommand against the database instead of serving the API.
+func runCommand(db *sql.DB, args []string, stdin io.Reader, stdout io.Writer) error {
+	switch args[0] {
+	case "import":
+		return importCommand(db, args[1:], stdin, stdout)
+	case "export":
+		return exportCommand(db, args[1:], stdout)
+	default:
+		return fmt.Errorf("unknown command %q", args[0])
+	}
+}
+
+// importCommand bulk imports gear from a CSV, JSON, JSON lines or YAML file, or stdin when the file is "-",
+// and prints the import report.
+func importCommand(db *sql.DB, args []string, stdin io.Reader, stdout io.Writer) error {
+	flags := flag.NewFlagSet("import", flag.ContinueOnError)
+	format := flags.String("format", "", "Input format, csv, json, jsonl or yaml. Defaults to the file extension")
+	dryRun := flags.Bool("dry-run", false, "Validate and report without committing")
+	createManufacturers := flags.Bool("create-manufacturers", false, "Create manufacturers that do not exist yet")
+	onDuplicate := flags.String("on-duplicate", utils.OnDuplicateSkip, "What to do with gear that already exists: skip, update or error")
+	flags.Usage = func() {
+		fmt.Fprintln(flags.Output(), "Usage: gogear-api [-config file] import [flags] <file.csv|file.json|file.jsonl|file.yaml|->")
+		flags.PrintDefaults()
+	}
+
+	if err := flags.Parse(args); err != nil {
+		return err
+	}
+	if flags.NArg() != 1 {
+		flags.Usage()
+		return errors.New("expected exactly one input file")
+	}
+
+	path := flags.Arg(0)
+	input := stdin
+	if path != "-" {
+		file, err := os.Open(path)
+		if err != nil {
+			return err
+		}
+		defer file.Close()
+		input = file
+	}
+
+	if *format == "" {
+		*format = strings.TrimPrefix(strings.ToLower(filepath.Ext(path)), ".")
+	}
+
+	report, err := utils.ImportGear(db, input, *format, models.GearImportOptions{
+		DryRun:              *dryRun,
+		CreateManufacturers: *createManufacturers,
+		OnDuplicate:         *onDuplicate,
+	})
+	if err != nil {
+		return err
+	}
+
+	encoder := json.NewEncoder(stdout)
+	encoder.SetIndent("", "  ")
+	if err := encoder.Encode(report); err != nil {
+		return err
+	}
+
+	if report.Errors > 0 {
+		return fmt.Errorf("%d rows failed, nothing was imported", report.Errors)
+	}
+	return nil
+}
+
+// filterFlag collects repeated -filter key=value flags as query values.
+type filterFlag url.Values
+
+func (f filterFlag) String() string {
+	return url.Values(f).Encode()
+}
+
+func (f filterFlag) Set(value string) error {
+	key, filterValue, ok := strings.Cut(value, "=")
+	if !ok || key == "" {
+		return fmt.Errorf("filter %q must be key=value", value)
+	}
+	url.Values(f).Add(key, filterValue)
+	return nil
+}
+
+// exportCommand writes the gear catalog as CSV, JSON lines or YAML to a file, or stdout
+// when no file is given. Filters take the same names as the gear list query parameters.
+func exportCommand(db *sql.DB, args []string, stdout io.Writer) error {
+	filters := filterFlag{}
+
+	flags := flag.NewFlagSet("export", flag.ContinueOnError)
+	format := flags.String("format", "", "Output format, csv, jsonl or yaml. Defaults to the file extension, or jsonl")
+	output := flags.String("o", "", "Output file. Defaults to stdout")
+	flags.Var(filters, "filter", "Filter as key=value, e.g. category=3 or gear_status=true. Repeatable")
+	flags.Usage = func() {
+		fmt.Fprintln(flags.Output(), "Usage: gogear-api [-config file] export [flags]")
+		flags.PrintDefaults()
+	}
+
+	if err := flags.Parse(args); err != nil {
+		return err
+	}
+	if flags.NArg() != 0 {
+		flags.Usage()
+		return errors.New("unexpected arguments")
+	}
+
+	if *format == "" {
+		*format = strings.TrimPrefix(strings.ToLower(filepath.Ext(*output)), ".")
+		if *format == "" {
+			*format = utils.ExportFormatJSONL
+		}
+	}
+	if _, ok := utils.ExportContentTypes[*format]; !ok {
+		return fmt.Errorf("invalid format %q, use csv, jsonl or yaml", *format)
+	}
+
+	if *output == "" {
+		return utils.ExportGear(db, url.Values(filters), *format, stdout)
+	}
+
+	file, err := os.Create(*output)
+	if err != nil {
+		return err
+	}
+
+	if err := utils.ExportGear(db, url.Values(filters), *format, file); err != nil {
+		file.Close()
+		return err
+	}
+	return file.Close()
+}
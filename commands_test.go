@@ -0,0 +1,111 @@
+package main
+
+import (
+	"bytes"
+	"encoding/json"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	models "github.com/Sea-Shell/gogear-api/pkg/models"
+)
+
+func TestImportCommand(t *testing.T) {
+	db := tempDB(t)
+	runMigrate(t, db, migrationsPath(t))
+
+	for _, statement := range []string{
+		`INSERT INTO gear_top_category (topCategoryId, topCategoryName) VALUES (1, 'Kitchen')`,
+		`INSERT INTO gear_category (categoryId, categoryTopCategoryId, categoryName) VALUES (1, 1, 'Stoves')`,
+	} {
+		if _, err := db.Exec(statement); err != nil {
+			t.Fatalf("seed: %v", err)
+		}
+	}
+
+	path := filepath.Join(t.TempDir(), "gear.json")
+	document := `[{"gear_name": "PocketRocket 2", "top_category": "Kitchen", "category": "Stoves", "manufacturer": "MSR", "gear_weight": 73}]`
+	if err := os.WriteFile(path, []byte(document), 0o600); err != nil {
+		t.Fatalf("write input: %v", err)
+	}
+
+	var out bytes.Buffer
+	err := runCommand(db, []string{"import", path}, nil, &out)
+	if err == nil || !strings.Contains(err.Error(), "1 rows failed") {
+		t.Fatalf("import without -create-manufacturers: err = %v", err)
+	}
+
+	out.Reset()
+	if err := runCommand(db, []string{"import", "-create-manufacturers", path}, nil, &out); err != nil {
+		t.Fatalf("import: %v — output: %s", err, out.String())
+	}
+
+	var report models.GearImportReport
+	if err := json.Unmarshal(out.Bytes(), &report); err != nil {
+		t.Fatalf("unmarshal report: %v", err)
+	}
+	if !report.Committed || report.Created != 1 {
+		t.Errorf("report = %+v", report)
+	}
+
+	out.Reset()
+	stdin := strings.NewReader("gear_name,top_category,category,manufacturer\nPocketRocket 2,Kitchen,Stoves,MSR\n")
+	if err := runCommand(db, []string{"import", "-format", "csv", "-dry-run", "-"}, stdin, &out); err != nil {
+		t.Fatalf("import from stdin: %v", err)
+	}
+	if err := json.Unmarshal(out.Bytes(), &report); err != nil || report.Skipped != 1 {
+		t.Errorf("stdin report = %+v, %v", report, err)
+	}
+
+	if err := runCommand(db, []string{"frobnicate"}, nil, &out); err == nil {
+		t.Error("expected error for unknown command")
+	}
+}
+
+func TestExportCommand(t *testing.T) {
+	db := tempDB(t)
+	runMigrate(t, db, migrationsPath(t))
+
+	for _, statement := range []string{
+		`INSERT INTO gear_top_category (topCategoryId, topCategoryName, topCategoryIcon) VALUES (1, 'Kitchen', '')`,
+		`INSERT INTO gear_category (categoryId, categoryTopCategoryId, categoryName) VALUES (1, 1, 'Stoves')`,
+		`INSERT INTO manufacture (manufactureId, manufactureName) VALUES (1, 'MSR')`,
+		`INSERT INTO gear (gearTopCategoryId, gearCategoryId, gearManufactureId, gearIsContainer, gearName, gearSizeDefinition,
+            gearWeight, gearHeight, gearLength, gearWidth, gearStatus)
+            VALUES (1, 1, 1, 0, 'PocketRocket 2', '', 73, 0, 0, 0, 1), (1, 1, 1, 1, 'Titan Kettle', '', 120, 0, 0, 0, 1)`,
+	} {
+		if _, err := db.Exec(statement); err != nil {
+			t.Fatalf("seed: %v", err)
+		}
+	}
+
+	var out bytes.Buffer
+	if err := runCommand(db, []string{"export", "-filter", "gear_is_container=true"}, nil, &out); err != nil {
+		t.Fatalf("export to stdout: %v", err)
+	}
+	if lines := strings.Split(strings.TrimSpace(out.String()), "\n"); len(lines) != 1 || !strings.Contains(lines[0], "Titan Kettle") {
+		t.Errorf("filtered export = %q", out.String())
+	}
+
+	path := filepath.Join(t.TempDir(), "gear.csv")
+	if err := runCommand(db, []string{"export", "-o", path}, nil, &out); err != nil {
+		t.Fatalf("export to file: %v", err)
+	}
+
+	out.Reset()
+	if err := runCommand(db, []string{"import", "-dry-run", path}, nil, &out); err != nil {
+		t.Fatalf("import exported file: %v — output: %s", err, out.String())
+	}
+	var report models.GearImportReport
+	if err := json.Unmarshal(out.Bytes(), &report); err != nil || report.Skipped != 2 {
+		t.Errorf("import report = %+v, %v", report, err)
+	}
+
+	if err := runCommand(db, []string{"export", "-filter", "category"}, nil, &out); err == nil {
+		t.Error("expected error for malformed filter")
+	}
+	if err := runCommand(db, []string{"export", "-format", "xml"}, nil, &out); err == nil {
+		t.Error("expected error for unknown format")
+	}
+}
@@ -100,6 +100,32 @@ const docTemplate = `{
                         "name": "limit",
                         "in": "query"
                     },
+                    {
+                        "type": "string",
+                        "description": "Field to sort by, json or db field name",
+                        "name": "sort",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "default": "asc",
+                        "description": "Sort direction, asc or desc",
+                        "name": "order",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "Opaque cursor from next_cursor or prev_cursor, replaces page",
+                        "name": "cursor",
+                        "in": "query"
+                    },
+                    {
+                        "type": "boolean",
+                        "default": true,
+                        "description": "Include total item count",
+                        "name": "count",
+                        "in": "query"
+                    },
                     {
                         "type": "array",
                         "items": {
@@ -152,14 +178,14 @@ const docTemplate = `{
                 }
             }
         },
-        "/api/v1/category/{category}/delete": {
-            "delete": {
+        "/api/v1/category/reorder": {
+            "post": {
                 "security": [
                     {
                         "BearerAuth": []
                     }
                 ],
-                "description": "Delete category with corresponding ID value. Requires a JWT issued with the admin audience.",
+                "description": "Set the order of the children of parent_id, of the categories directly below top_category_id, or of the top categories when neither is given. order must list every one of them exactly once. Requires a JWT issued with the admin audience.",
                 "consumes": [
                     "application/json"
                 ],
@@ -169,14 +195,16 @@ const docTemplate = `{
                 "tags": [
                     "Category"
                 ],
-                "summary": "Delete category with ID",
+                "summary": "Reorder categories",
                 "parameters": [
                     {
-                        "type": "integer",
-                        "description": "Unique ID of category you want to update",
-                        "name": "category",
-                        "in": "path",
-                        "required": true
+                        "description": "Request body",
+                        "name": "request",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/models.CategoryReorder"
+                        }
                     }
                 ],
                 "responses": {
@@ -195,14 +223,14 @@ const docTemplate = `{
                 }
             }
         },
-        "/api/v1/category/{category}/get": {
+        "/api/v1/category/tree": {
             "get": {
                 "security": [
                     {
                         "BearerAuth": []
                     }
                 ],
-                "description": "Get category spessific to ID",
+                "description": "Get every top category with its categories nested below it, siblings in their sort order. gear_count includes the gear of all categories below.",
                 "consumes": [
                     "application/json"
                 ],
@@ -212,21 +240,15 @@ const docTemplate = `{
                 "tags": [
                     "Category"
                 ],
-                "summary": "Get category with ID",
-                "parameters": [
-                    {
-                        "type": "integer",
-                        "description": "Unique ID of category you want to get",
-                        "name": "category",
-                        "in": "path",
-                        "required": true
-                    }
-                ],
+                "summary": "Get category tree",
                 "responses": {
                     "200": {
-                        "description": "desc",
+                        "description": "OK",
                         "schema": {
-                            "$ref": "#/definitions/models.GearCategory"
+                            "type": "array",
+                            "items": {
+                                "$ref": "#/definitions/models.CategoryTree"
+                            }
                         }
                     },
                     "default": {
@@ -238,14 +260,14 @@ const docTemplate = `{
                 }
             }
         },
-        "/api/v1/category/{category}/update": {
-            "post": {
+        "/api/v1/category/{category}/delete": {
+            "delete": {
                 "security": [
                     {
                         "BearerAuth": []
                     }
                 ],
-                "description": "Update category identified by ID. Requires a JWT issued with the admin audience.",
+                "description": "Delete category with corresponding ID value. Gear in the category must be moved first, or moved to reassign_to as part of the delete; otherwise the delete is refused with 409 and the gear count. Categories below it move up to its parent. Requires a JWT issued with the admin audience.",
                 "consumes": [
                     "application/json"
                 ],
@@ -255,23 +277,20 @@ const docTemplate = `{
                 "tags": [
                     "Category"
                 ],
-                "summary": "Update category with ID",
+                "summary": "Delete category with ID",
                 "parameters": [
                     {
                         "type": "integer",
                         "description": "Unique ID of category you want to update",
-                        "name": "categoryID",
+                        "name": "category",
                         "in": "path",
                         "required": true
                     },
                     {
-                        "description": "Request body",
-                        "name": "request",
-                        "in": "body",
-                        "required": true,
-                        "schema": {
-                            "$ref": "#/definitions/models.GearCategory"
-                        }
+                        "type": "integer",
+                        "description": "Category to move the gear to before deleting",
+                        "name": "reassign_to",
+                        "in": "query"
                     }
                 ],
                 "responses": {
@@ -281,6 +300,12 @@ const docTemplate = `{
                             "$ref": "#/definitions/models.Status"
                         }
                     },
+                    "409": {
+                        "description": "the category is still in use",
+                        "schema": {
+                            "$ref": "#/definitions/models.DependentsError"
+                        }
+                    },
                     "default": {
                         "description": "",
                         "schema": {
@@ -290,14 +315,14 @@ const docTemplate = `{
                 }
             }
         },
-        "/api/v1/container/insert": {
-            "put": {
+        "/api/v1/category/{category}/get": {
+            "get": {
                 "security": [
                     {
                         "BearerAuth": []
                     }
                 ],
-                "description": "Insert user registered gear with corresponding values",
+                "description": "Get category spessific to ID",
                 "consumes": [
                     "application/json"
                 ],
@@ -305,38 +330,42 @@ const docTemplate = `{
                     "application/json"
                 ],
                 "tags": [
-                    "User container"
+                    "Category"
                 ],
-                "summary": "Insert user registered gear",
+                "summary": "Get category with ID",
                 "parameters": [
                     {
-                        "description": "query params",
-                        "name": "request",
-                        "in": "body",
-                        "required": true,
-                        "schema": {
-                            "$ref": "#/definitions/models.UserContainerNoID"
-                        }
+                        "type": "integer",
+                        "description": "Unique ID of category you want to get",
+                        "name": "category",
+                        "in": "path",
+                        "required": true
                     }
                 ],
                 "responses": {
                     "200": {
-                        "description": "status: success when all goes well",
+                        "description": "desc",
                         "schema": {
-                            "$ref": "#/definitions/models.Status"
+                            "$ref": "#/definitions/models.GearCategory"
+                        }
+                    },
+                    "default": {
+                        "description": "",
+                        "schema": {
+                            "$ref": "#/definitions/models.Error"
                         }
                     }
                 }
             }
         },
-        "/api/v1/container/{container}/delete": {
-            "delete": {
+        "/api/v1/category/{category}/move": {
+            "post": {
                 "security": [
                     {
                         "BearerAuth": []
                     }
                 ],
-                "description": "Delete user container content with corresponding ID value",
+                "description": "Move a category and everything below it under parent_id, or directly below top_category_id, at position among its new siblings (last when omitted). The gear in the moved categories follows their new top category. Requires a JWT issued with the admin audience.",
                 "consumes": [
                     "application/json"
                 ],
@@ -344,16 +373,25 @@ const docTemplate = `{
                     "application/json"
                 ],
                 "tags": [
-                    "User container"
+                    "Category"
                 ],
-                "summary": "Delete user container content with ID",
+                "summary": "Move category",
                 "parameters": [
                     {
                         "type": "integer",
-                        "description": "Unique ID of userGear you want to update",
-                        "name": "container",
+                        "description": "Unique ID of category you want to move",
+                        "name": "category",
                         "in": "path",
                         "required": true
+                    },
+                    {
+                        "description": "Request body",
+                        "name": "request",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/models.CategoryMove"
+                        }
                     }
                 ],
                 "responses": {
@@ -372,14 +410,14 @@ const docTemplate = `{
                 }
             }
         },
-        "/api/v1/container/{container}/list": {
+        "/api/v1/category/{category}/revision/diff": {
             "get": {
                 "security": [
                     {
                         "BearerAuth": []
                     }
                 ],
-                "description": "List all items inside a container registered to a user",
+                "description": "Get the fields that differ from one revision of a category to another, with their values in both. Without to, the revision is compared with the newest one. Requires a JWT issued with the admin audience.",
                 "consumes": [
                     "application/json"
                 ],
@@ -387,65 +425,73 @@ const docTemplate = `{
                     "application/json"
                 ],
                 "tags": [
-                    "User container"
+                    "Revisions"
                 ],
-                "summary": "List all items inside a container",
+                "summary": "Diff category revisions",
                 "parameters": [
                     {
                         "type": "integer",
-                        "default": 1,
-                        "description": "Page number",
-                        "name": "page",
-                        "in": "query"
+                        "description": "Unique ID of category",
+                        "name": "category",
+                        "in": "path",
+                        "required": true
                     },
                     {
                         "type": "integer",
-                        "default": 30,
-                        "description": "Number of items per page",
-                        "name": "limit",
-                        "in": "query"
+                        "description": "Revision to compare from",
+                        "name": "from",
+                        "in": "query",
+                        "required": true
                     },
                     {
                         "type": "integer",
-                        "description": "Unique ID of userGear you want to update",
-                        "name": "container",
-                        "in": "path",
-                        "required": true
+                        "description": "Revision to compare to, the newest by default",
+                        "name": "to",
+                        "in": "query"
                     }
                 ],
                 "responses": {
                     "200": {
                         "description": "OK",
                         "schema": {
-                            "allOf": [
-                                {
-                                    "$ref": "#/definitions/models.ResponsePayload"
-                                },
-                                {
-                                    "type": "object",
-                                    "properties": {
-                                        "items": {
-                                            "type": "array",
-                                            "items": {
-                                                "$ref": "#/definitions/models.FullGear"
-                                            }
-                                        }
-                                    }
-                                }
-                            ]
+                            "$ref": "#/definitions/models.RevisionDiff"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/models.Error"
+                        }
+                    },
+                    "403": {
+                        "description": "Forbidden",
+                        "schema": {
+                            "$ref": "#/definitions/models.Error"
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "$ref": "#/definitions/models.Error"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/models.Error"
                         }
                     }
                 }
             }
         },
-        "/api/v1/gear/insert": {
-            "put": {
+        "/api/v1/category/{category}/revision/list": {
+            "get": {
                 "security": [
                     {
                         "BearerAuth": []
                     }
                 ],
-                "description": "Insert new gear with corresponding values",
+                "description": "Get every revision of a category, newest first. Each holds the tracked values after a create, an update or a revert: category_name, category_parent_id and category_top_category_id. Requires a JWT issued with the admin audience.",
                 "consumes": [
                     "application/json"
                 ],
@@ -453,25 +499,26 @@ const docTemplate = `{
                     "application/json"
                 ],
                 "tags": [
-                    "Gear"
+                    "Revisions"
                 ],
-                "summary": "Insert new gear",
+                "summary": "List category revisions",
                 "parameters": [
                     {
-                        "description": "query params",
-                        "name": "request",
-                        "in": "body",
-                        "required": true,
-                        "schema": {
-                            "$ref": "#/definitions/models.Gear"
-                        }
+                        "type": "integer",
+                        "description": "Unique ID of category",
+                        "name": "category",
+                        "in": "path",
+                        "required": true
                     }
                 ],
                 "responses": {
                     "200": {
-                        "description": "status: success when all goes well",
+                        "description": "OK",
                         "schema": {
-                            "$ref": "#/definitions/models.Gear"
+                            "type": "array",
+                            "items": {
+                                "$ref": "#/definitions/models.CatalogRevision"
+                            }
                         }
                     },
                     "default": {
@@ -483,14 +530,14 @@ const docTemplate = `{
                 }
             }
         },
-        "/api/v1/gear/list": {
-            "get": {
+        "/api/v1/category/{category}/revision/{revision}/revert": {
+            "post": {
                 "security": [
                     {
                         "BearerAuth": []
                     }
                 ],
-                "description": "Get a list of gear items",
+                "description": "Write the values of an earlier revision back to a category. The revert is recorded as a new revision and the history in between is kept. A category that has moved returns to its former parent, or its former top category when it had none, along with everything below it. Requires a JWT issued with the admin audience.",
                 "consumes": [
                     "application/json"
                 ],
@@ -498,84 +545,52 @@ const docTemplate = `{
                     "application/json"
                 ],
                 "tags": [
-                    "Gear"
+                    "Revisions"
                 ],
-                "summary": "List gear",
+                "summary": "Revert category to revision",
                 "parameters": [
                     {
                         "type": "integer",
-                        "default": 1,
-                        "description": "Page number",
-                        "name": "page",
-                        "in": "query"
-                    },
-                    {
-                        "type": "integer",
-                        "default": 30,
-                        "description": "Number of items per page",
-                        "name": "limit",
-                        "in": "query"
-                    },
-                    {
-                        "type": "string",
-                        "description": "Gear category",
+                        "description": "Unique ID of category",
                         "name": "category",
-                        "in": "query"
-                    },
-                    {
-                        "type": "string",
-                        "description": "Top gear category",
-                        "name": "topCategory",
-                        "in": "query"
-                    },
-                    {
-                        "type": "string",
-                        "description": "Gear manufacturer",
-                        "name": "manufacturer",
-                        "in": "query"
-                    },
-                    {
-                        "type": "array",
-                        "items": {
-                            "type": "string"
-                        },
-                        "collectionFormat": "multi",
-                        "description": "string collection",
-                        "name": "collection",
-                        "in": "query"
+                        "in": "path",
+                        "required": true
                     },
                     {
-                        "type": "boolean",
-                        "default": false,
-                        "description": "string collection",
-                        "name": "container",
-                        "in": "query"
+                        "type": "integer",
+                        "description": "Number of the revision to restore",
+                        "name": "revision",
+                        "in": "path",
+                        "required": true
                     }
                 ],
                 "responses": {
                     "200": {
                         "description": "OK",
                         "schema": {
-                            "allOf": [
-                                {
-                                    "$ref": "#/definitions/models.ResponsePayload"
-                                },
-                                {
-                                    "type": "object",
-                                    "properties": {
-                                        "items": {
-                                            "type": "array",
-                                            "items": {
-                                                "$ref": "#/definitions/models.GearListItem"
-                                            }
-                                        }
-                                    }
-                                }
-                            ]
+                            "$ref": "#/definitions/models.CatalogRevision"
                         }
                     },
-                    "default": {
-                        "description": "",
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/models.Error"
+                        }
+                    },
+                    "403": {
+                        "description": "Forbidden",
+                        "schema": {
+                            "$ref": "#/definitions/models.Error"
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "$ref": "#/definitions/models.Error"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
                         "schema": {
                             "$ref": "#/definitions/models.Error"
                         }
@@ -583,14 +598,14 @@ const docTemplate = `{
                 }
             }
         },
-        "/api/v1/gear/search": {
-            "get": {
+        "/api/v1/category/{category}/spec/insert": {
+            "put": {
                 "security": [
                     {
                         "BearerAuth": []
                     }
                 ],
-                "description": "Get a list of gear items based on search patterns",
+                "description": "Add a typed specification attribute to a category. type is number, enum, bool or text; enums list their options. Names are unique per category. Requires a JWT issued with the admin audience.",
                 "consumes": [
                     "application/json"
                 ],
@@ -598,63 +613,60 @@ const docTemplate = `{
                     "application/json"
                 ],
                 "tags": [
-                    "Gear"
+                    "Category"
                 ],
-                "summary": "Search for gear",
+                "summary": "Insert category spec",
                 "parameters": [
                     {
                         "type": "integer",
-                        "default": 1,
-                        "description": "Page number",
-                        "name": "page",
-                        "in": "query"
-                    },
-                    {
-                        "type": "integer",
-                        "default": 30,
-                        "description": "Number of items per page",
-                        "name": "limit",
-                        "in": "query"
-                    },
-                    {
-                        "type": "string",
-                        "description": "String to search for",
-                        "name": "searchString",
-                        "in": "query",
+                        "description": "Category ID",
+                        "name": "category",
+                        "in": "path",
                         "required": true
                     },
                     {
-                        "type": "string",
-                        "description": "Type of search method. valid choices are: startswith, contains, endswith",
-                        "name": "searchType",
-                        "in": "query",
-                        "required": true
+                        "description": "Attribute",
+                        "name": "body",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/models.SpecAttributeNoID"
+                        }
                     }
                 ],
                 "responses": {
-                    "200": {
-                        "description": "OK",
+                    "201": {
+                        "description": "Created",
                         "schema": {
-                            "allOf": [
-                                {
-                                    "$ref": "#/definitions/models.ResponsePayload"
-                                },
-                                {
-                                    "type": "object",
-                                    "properties": {
-                                        "items": {
-                                            "type": "array",
-                                            "items": {
-                                                "$ref": "#/definitions/models.GearListItem"
-                                            }
-                                        }
-                                    }
-                                }
-                            ]
+                            "$ref": "#/definitions/models.SpecAttribute"
                         }
                     },
-                    "default": {
-                        "description": "",
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/models.Error"
+                        }
+                    },
+                    "403": {
+                        "description": "Forbidden",
+                        "schema": {
+                            "$ref": "#/definitions/models.Error"
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "$ref": "#/definitions/models.Error"
+                        }
+                    },
+                    "409": {
+                        "description": "Conflict",
+                        "schema": {
+                            "$ref": "#/definitions/models.Error"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
                         "schema": {
                             "$ref": "#/definitions/models.Error"
                         }
@@ -662,42 +674,54 @@ const docTemplate = `{
                 }
             }
         },
-        "/api/v1/gear/{gear}/delete": {
-            "delete": {
+        "/api/v1/category/{category}/spec/list": {
+            "get": {
                 "security": [
                     {
                         "BearerAuth": []
                     }
                 ],
-                "description": "Delete gear with corresponding ID value",
-                "consumes": [
-                    "application/json"
-                ],
+                "description": "List the typed specification attributes gear in a category can carry, by name",
                 "produces": [
                     "application/json"
                 ],
                 "tags": [
-                    "Gear"
+                    "Category"
                 ],
-                "summary": "Delete gear with ID",
+                "summary": "List category specs",
                 "parameters": [
                     {
                         "type": "integer",
-                        "description": "Unique ID of gear you want to delete",
-                        "name": "gear",
+                        "description": "Category ID",
+                        "name": "category",
                         "in": "path",
                         "required": true
                     }
                 ],
                 "responses": {
                     "200": {
-                        "description": "status: success when all goes well",
+                        "description": "OK",
                         "schema": {
-                            "$ref": "#/definitions/models.Status"
+                            "type": "array",
+                            "items": {
+                                "$ref": "#/definitions/models.SpecAttribute"
+                            }
                         }
                     },
-                    "default": {
-                        "description": "",
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/models.Error"
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "$ref": "#/definitions/models.Error"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
                         "schema": {
                             "$ref": "#/definitions/models.Error"
                         }
@@ -705,42 +729,64 @@ const docTemplate = `{
                 }
             }
         },
-        "/api/v1/gear/{gear}/get": {
-            "get": {
+        "/api/v1/category/{category}/spec/{attribute}/delete": {
+            "delete": {
                 "security": [
                     {
                         "BearerAuth": []
                     }
                 ],
-                "description": "Get gear spessific to ID",
-                "consumes": [
-                    "application/json"
-                ],
+                "description": "Delete a specification attribute of a category together with the values gear has for it. Requires a JWT issued with the admin audience.",
                 "produces": [
                     "application/json"
                 ],
                 "tags": [
-                    "Gear"
+                    "Category"
                 ],
-                "summary": "Get gear with ID",
+                "summary": "Delete category spec",
                 "parameters": [
                     {
                         "type": "integer",
-                        "description": "Unique ID of Gear you want to get",
-                        "name": "gear",
+                        "description": "Category ID",
+                        "name": "category",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "type": "integer",
+                        "description": "Attribute ID",
+                        "name": "attribute",
                         "in": "path",
                         "required": true
                     }
                 ],
                 "responses": {
                     "200": {
-                        "description": "desc",
+                        "description": "OK",
                         "schema": {
-                            "$ref": "#/definitions/models.FullGear"
+                            "$ref": "#/definitions/models.Status"
                         }
                     },
-                    "default": {
-                        "description": "",
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/models.Error"
+                        }
+                    },
+                    "403": {
+                        "description": "Forbidden",
+                        "schema": {
+                            "$ref": "#/definitions/models.Error"
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "$ref": "#/definitions/models.Error"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
                         "schema": {
                             "$ref": "#/definitions/models.Error"
                         }
@@ -748,14 +794,14 @@ const docTemplate = `{
                 }
             }
         },
-        "/api/v1/gear/{gear}/update": {
+        "/api/v1/category/{category}/spec/{attribute}/update": {
             "post": {
                 "security": [
                     {
                         "BearerAuth": []
                     }
                 ],
-                "description": "Update gear identified by ID",
+                "description": "Update a specification attribute of a category. The type cannot change while gear has values for the attribute, and enum options cannot drop a value in use. Requires a JWT issued with the admin audience.",
                 "consumes": [
                     "application/json"
                 ],
@@ -763,36 +809,67 @@ const docTemplate = `{
                     "application/json"
                 ],
                 "tags": [
-                    "Gear"
+                    "Category"
                 ],
-                "summary": "Update gear with ID",
+                "summary": "Update category spec",
                 "parameters": [
                     {
                         "type": "integer",
-                        "description": "Unique ID of Gear you want to get",
-                        "name": "gear",
+                        "description": "Category ID",
+                        "name": "category",
                         "in": "path",
                         "required": true
                     },
                     {
-                        "description": "query params",
-                        "name": "request",
+                        "type": "integer",
+                        "description": "Attribute ID",
+                        "name": "attribute",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "description": "Attribute",
+                        "name": "body",
                         "in": "body",
                         "required": true,
                         "schema": {
-                            "$ref": "#/definitions/models.Gear"
+                            "$ref": "#/definitions/models.SpecAttributeNoID"
                         }
                     }
                 ],
                 "responses": {
                     "200": {
-                        "description": "status: success when all goes well",
+                        "description": "OK",
                         "schema": {
-                            "$ref": "#/definitions/models.Status"
+                            "$ref": "#/definitions/models.SpecAttribute"
                         }
                     },
-                    "default": {
-                        "description": "",
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/models.Error"
+                        }
+                    },
+                    "403": {
+                        "description": "Forbidden",
+                        "schema": {
+                            "$ref": "#/definitions/models.Error"
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "$ref": "#/definitions/models.Error"
+                        }
+                    },
+                    "409": {
+                        "description": "Conflict",
+                        "schema": {
+                            "$ref": "#/definitions/models.Error"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
                         "schema": {
                             "$ref": "#/definitions/models.Error"
                         }
@@ -800,14 +877,14 @@ const docTemplate = `{
                 }
             }
         },
-        "/api/v1/loadout/insert": {
-            "put": {
+        "/api/v1/category/{category}/translation/list": {
+            "get": {
                 "security": [
                     {
                         "BearerAuth": []
                     }
                 ],
-                "description": "Create a new loadout for the authenticated user",
+                "description": "Get the translations of a category's name, one per locale. Requires a JWT issued with the admin audience.",
                 "consumes": [
                     "application/json"
                 ],
@@ -815,72 +892,30 @@ const docTemplate = `{
                     "application/json"
                 ],
                 "tags": [
-                    "Loadouts"
+                    "Translations"
                 ],
-                "summary": "Insert loadout",
+                "summary": "List category translations",
                 "parameters": [
                     {
-                        "description": "Loadout data",
-                        "name": "request",
-                        "in": "body",
-                        "required": true,
-                        "schema": {
-                            "$ref": "#/definitions/models.LoadoutNoID"
-                        }
-                    }
-                ],
-                "responses": {
-                    "201": {
-                        "description": "Created",
-                        "schema": {
-                            "$ref": "#/definitions/models.Loadout"
-                        }
-                    },
-                    "400": {
-                        "description": "Bad Request",
-                        "schema": {
-                            "$ref": "#/definitions/models.Error"
-                        }
-                    },
-                    "500": {
-                        "description": "Internal Server Error",
-                        "schema": {
-                            "$ref": "#/definitions/models.Error"
-                        }
-                    }
-                }
-            }
-        },
-        "/api/v1/loadout/list": {
-            "get": {
-                "security": [
-                    {
-                        "BearerAuth": []
+                        "type": "integer",
+                        "description": "Unique ID of category",
+                        "name": "category",
+                        "in": "path",
+                        "required": true
                     }
                 ],
-                "description": "Get all loadouts for the authenticated user",
-                "consumes": [
-                    "application/json"
-                ],
-                "produces": [
-                    "application/json"
-                ],
-                "tags": [
-                    "Loadouts"
-                ],
-                "summary": "List loadouts",
                 "responses": {
                     "200": {
                         "description": "OK",
                         "schema": {
                             "type": "array",
                             "items": {
-                                "$ref": "#/definitions/models.Loadout"
+                                "$ref": "#/definitions/models.Translation"
                             }
                         }
                     },
-                    "500": {
-                        "description": "Internal Server Error",
+                    "default": {
+                        "description": "",
                         "schema": {
                             "$ref": "#/definitions/models.Error"
                         }
@@ -888,14 +923,14 @@ const docTemplate = `{
                 }
             }
         },
-        "/api/v1/loadout/{loadout}/delete": {
+        "/api/v1/category/{category}/translation/{locale}/delete": {
             "delete": {
                 "security": [
                     {
                         "BearerAuth": []
                     }
                 ],
-                "description": "Delete a loadout by ID",
+                "description": "Remove the translation of a category in a locale, so it falls back to the default locale. Requires a JWT issued with the admin audience.",
                 "consumes": [
                     "application/json"
                 ],
@@ -903,63 +938,21 @@ const docTemplate = `{
                     "application/json"
                 ],
                 "tags": [
-                    "Loadouts"
+                    "Translations"
                 ],
-                "summary": "Delete loadout",
+                "summary": "Delete category translation",
                 "parameters": [
                     {
                         "type": "integer",
-                        "description": "Loadout ID",
-                        "name": "loadout",
+                        "description": "Unique ID of category",
+                        "name": "category",
                         "in": "path",
                         "required": true
-                    }
-                ],
-                "responses": {
-                    "200": {
-                        "description": "OK",
-                        "schema": {
-                            "$ref": "#/definitions/models.Status"
-                        }
-                    },
-                    "403": {
-                        "description": "Forbidden",
-                        "schema": {
-                            "$ref": "#/definitions/models.Error"
-                        }
                     },
-                    "404": {
-                        "description": "Not Found",
-                        "schema": {
-                            "$ref": "#/definitions/models.Error"
-                        }
-                    }
-                }
-            }
-        },
-        "/api/v1/loadout/{loadout}/get": {
-            "get": {
-                "security": [
-                    {
-                        "BearerAuth": []
-                    }
-                ],
-                "description": "Get a single loadout by ID",
-                "consumes": [
-                    "application/json"
-                ],
-                "produces": [
-                    "application/json"
-                ],
-                "tags": [
-                    "Loadouts"
-                ],
-                "summary": "Get loadout",
-                "parameters": [
                     {
-                        "type": "integer",
-                        "description": "Loadout ID",
-                        "name": "loadout",
+                        "type": "string",
+                        "description": "Language tag such as nb",
+                        "name": "locale",
                         "in": "path",
                         "required": true
                     }
@@ -968,11 +961,11 @@ const docTemplate = `{
                     "200": {
                         "description": "OK",
                         "schema": {
-                            "$ref": "#/definitions/models.Loadout"
+                            "$ref": "#/definitions/models.Status"
                         }
                     },
-                    "404": {
-                        "description": "Not Found",
+                    "default": {
+                        "description": "",
                         "schema": {
                             "$ref": "#/definitions/models.Error"
                         }
@@ -980,14 +973,14 @@ const docTemplate = `{
                 }
             }
         },
-        "/api/v1/loadout/{loadout}/import": {
+        "/api/v1/category/{category}/translation/{locale}/update": {
             "post": {
                 "security": [
                     {
                         "BearerAuth": []
                     }
                 ],
-                "description": "Import gear items into a loadout",
+                "description": "Set the name of a category in a locale, replacing an earlier translation. The only field is category_name, which is required. Requires a JWT issued with the admin audience.",
                 "consumes": [
                     "application/json"
                 ],
@@ -995,39 +988,39 @@ const docTemplate = `{
                     "application/json"
                 ],
                 "tags": [
-                    "Loadouts"
+                    "Translations"
                 ],
-                "summary": "Import gear into loadout",
+                "summary": "Update category translation",
                 "parameters": [
                     {
                         "type": "integer",
-                        "description": "Loadout ID",
-                        "name": "loadout",
+                        "description": "Unique ID of category",
+                        "name": "category",
                         "in": "path",
                         "required": true
                     },
                     {
-                        "description": "Gear IDs to import",
+                        "type": "string",
+                        "description": "Language tag such as nb",
+                        "name": "locale",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "description": "Translated fields",
                         "name": "request",
                         "in": "body",
                         "required": true,
                         "schema": {
-                            "type": "object",
-                            "additionalProperties": {
-                                "type": "array",
-                                "items": {
-                                    "type": "integer",
-                                    "format": "int64"
-                                }
-                            }
+                            "$ref": "#/definitions/models.TranslationUpdate"
                         }
                     }
                 ],
                 "responses": {
-                    "201": {
-                        "description": "Created",
+                    "200": {
+                        "description": "OK",
                         "schema": {
-                            "$ref": "#/definitions/models.Status"
+                            "$ref": "#/definitions/models.Translation"
                         }
                     },
                     "400": {
@@ -1057,14 +1050,14 @@ const docTemplate = `{
                 }
             }
         },
-        "/api/v1/loadout/{loadout}/item/insert": {
-            "put": {
+        "/api/v1/category/{category}/update": {
+            "post": {
                 "security": [
                     {
                         "BearerAuth": []
                     }
                 ],
-                "description": "Add gear to a loadout",
+                "description": "Update category identified by ID. Requires a JWT issued with the admin audience.",
                 "consumes": [
                     "application/json"
                 ],
@@ -1072,54 +1065,36 @@ const docTemplate = `{
                     "application/json"
                 ],
                 "tags": [
-                    "Loadouts"
+                    "Category"
                 ],
-                "summary": "Insert loadout item",
+                "summary": "Update category with ID",
                 "parameters": [
                     {
                         "type": "integer",
-                        "description": "Loadout ID",
-                        "name": "loadout",
+                        "description": "Unique ID of category you want to update",
+                        "name": "categoryID",
                         "in": "path",
                         "required": true
                     },
                     {
-                        "description": "Item data",
-                        "name": "body",
+                        "description": "Request body",
+                        "name": "request",
                         "in": "body",
                         "required": true,
                         "schema": {
-                            "$ref": "#/definitions/models.LoadoutItemNoID"
+                            "$ref": "#/definitions/models.GearCategory"
                         }
                     }
                 ],
                 "responses": {
-                    "201": {
-                        "description": "Created",
-                        "schema": {
-                            "$ref": "#/definitions/models.LoadoutItem"
-                        }
-                    },
-                    "400": {
-                        "description": "Bad Request",
-                        "schema": {
-                            "$ref": "#/definitions/models.Error"
-                        }
-                    },
-                    "403": {
-                        "description": "Forbidden",
-                        "schema": {
-                            "$ref": "#/definitions/models.Error"
-                        }
-                    },
-                    "404": {
-                        "description": "Not Found",
+                    "200": {
+                        "description": "status: success when all goes well",
                         "schema": {
-                            "$ref": "#/definitions/models.Error"
+                            "$ref": "#/definitions/models.Status"
                         }
                     },
-                    "500": {
-                        "description": "Internal Server Error",
+                    "default": {
+                        "description": "",
                         "schema": {
                             "$ref": "#/definitions/models.Error"
                         }
@@ -1127,14 +1102,14 @@ const docTemplate = `{
                 }
             }
         },
-        "/api/v1/loadout/{loadout}/item/list": {
-            "get": {
+        "/api/v1/container/insert": {
+            "put": {
                 "security": [
                     {
                         "BearerAuth": []
                     }
                 ],
-                "description": "List all items in a loadout",
+                "description": "Insert user registered gear with corresponding values",
                 "consumes": [
                     "application/json"
                 ],
@@ -1142,57 +1117,38 @@ const docTemplate = `{
                     "application/json"
                 ],
                 "tags": [
-                    "Loadouts"
+                    "User container"
                 ],
-                "summary": "List loadout items",
+                "summary": "Insert user registered gear",
                 "parameters": [
                     {
-                        "type": "integer",
-                        "description": "Loadout ID",
-                        "name": "loadout",
-                        "in": "path",
-                        "required": true
+                        "description": "query params",
+                        "name": "request",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/models.UserContainerNoID"
+                        }
                     }
                 ],
                 "responses": {
                     "200": {
-                        "description": "OK",
-                        "schema": {
-                            "type": "array",
-                            "items": {
-                                "$ref": "#/definitions/models.LoadoutItem"
-                            }
-                        }
-                    },
-                    "400": {
-                        "description": "Bad Request",
-                        "schema": {
-                            "$ref": "#/definitions/models.Error"
-                        }
-                    },
-                    "404": {
-                        "description": "Not Found",
-                        "schema": {
-                            "$ref": "#/definitions/models.Error"
-                        }
-                    },
-                    "500": {
-                        "description": "Internal Server Error",
+                        "description": "status: success when all goes well",
                         "schema": {
-                            "$ref": "#/definitions/models.Error"
+                            "$ref": "#/definitions/models.Status"
                         }
                     }
                 }
             }
         },
-        "/api/v1/loadout/{loadout}/item/{item}/delete": {
+        "/api/v1/container/{container}/delete": {
             "delete": {
                 "security": [
                     {
                         "BearerAuth": []
                     }
                 ],
-                "description": "Remove an item from a loadout",
+                "description": "Delete user container content with corresponding ID value",
                 "consumes": [
                     "application/json"
                 ],
@@ -1200,52 +1156,27 @@ const docTemplate = `{
                     "application/json"
                 ],
                 "tags": [
-                    "Loadouts"
+                    "User container"
                 ],
-                "summary": "Delete loadout item",
+                "summary": "Delete user container content with ID",
                 "parameters": [
                     {
                         "type": "integer",
-                        "description": "Loadout ID",
-                        "name": "loadout",
-                        "in": "path",
-                        "required": true
-                    },
-                    {
-                        "type": "integer",
-                        "description": "Item ID",
-                        "name": "item",
+                        "description": "Unique ID of userGear you want to update",
+                        "name": "container",
                         "in": "path",
                         "required": true
                     }
                 ],
                 "responses": {
                     "200": {
-                        "description": "OK",
+                        "description": "status: success when all goes well",
                         "schema": {
                             "$ref": "#/definitions/models.Status"
                         }
                     },
-                    "400": {
-                        "description": "Bad Request",
-                        "schema": {
-                            "$ref": "#/definitions/models.Error"
-                        }
-                    },
-                    "403": {
-                        "description": "Forbidden",
-                        "schema": {
-                            "$ref": "#/definitions/models.Error"
-                        }
-                    },
-                    "404": {
-                        "description": "Not Found",
-                        "schema": {
-                            "$ref": "#/definitions/models.Error"
-                        }
-                    },
-                    "500": {
-                        "description": "Internal Server Error",
+                    "default": {
+                        "description": "",
                         "schema": {
                             "$ref": "#/definitions/models.Error"
                         }
@@ -1253,14 +1184,14 @@ const docTemplate = `{
                 }
             }
         },
-        "/api/v1/loadout/{loadout}/item/{item}/update": {
-            "post": {
+        "/api/v1/container/{container}/list": {
+            "get": {
                 "security": [
                     {
                         "BearerAuth": []
                     }
                 ],
-                "description": "Update a loadout item",
+                "description": "List all items inside a container registered to a user",
                 "consumes": [
                     "application/json"
                 ],
@@ -1268,76 +1199,97 @@ const docTemplate = `{
                     "application/json"
                 ],
                 "tags": [
-                    "Loadouts"
+                    "User container"
                 ],
-                "summary": "Update loadout item",
+                "summary": "List all items inside a container",
                 "parameters": [
                     {
                         "type": "integer",
-                        "description": "Loadout ID",
-                        "name": "loadout",
-                        "in": "path",
-                        "required": true
+                        "default": 1,
+                        "description": "Page number",
+                        "name": "page",
+                        "in": "query"
                     },
                     {
                         "type": "integer",
-                        "description": "Item ID",
-                        "name": "item",
+                        "default": 30,
+                        "description": "Number of items per page",
+                        "name": "limit",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "Field to sort by, json or db field name",
+                        "name": "sort",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "default": "asc",
+                        "description": "Sort direction, asc or desc",
+                        "name": "order",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "Opaque cursor from next_cursor or prev_cursor, replaces page",
+                        "name": "cursor",
+                        "in": "query"
+                    },
+                    {
+                        "type": "boolean",
+                        "default": true,
+                        "description": "Include total item count",
+                        "name": "count",
+                        "in": "query"
+                    },
+                    {
+                        "type": "integer",
+                        "description": "Unique ID of userGear you want to update",
+                        "name": "container",
                         "in": "path",
                         "required": true
                     },
                     {
-                        "description": "Update data",
-                        "name": "body",
-                        "in": "body",
-                        "required": true,
-                        "schema": {
-                            "$ref": "#/definitions/models.LoadoutItemUpdate"
-                        }
+                        "type": "string",
+                        "description": "Also give measurements in metric or imperial units, overriding the user's preference",
+                        "name": "units",
+                        "in": "query"
                     }
                 ],
                 "responses": {
                     "200": {
                         "description": "OK",
                         "schema": {
-                            "$ref": "#/definitions/models.Status"
-                        }
-                    },
-                    "400": {
-                        "description": "Bad Request",
-                        "schema": {
-                            "$ref": "#/definitions/models.Error"
-                        }
-                    },
-                    "403": {
-                        "description": "Forbidden",
-                        "schema": {
-                            "$ref": "#/definitions/models.Error"
-                        }
-                    },
-                    "404": {
-                        "description": "Not Found",
-                        "schema": {
-                            "$ref": "#/definitions/models.Error"
-                        }
-                    },
-                    "500": {
-                        "description": "Internal Server Error",
-                        "schema": {
-                            "$ref": "#/definitions/models.Error"
+                            "allOf": [
+                                {
+                                    "$ref": "#/definitions/models.ResponsePayload"
+                                },
+                                {
+                                    "type": "object",
+                                    "properties": {
+                                        "items": {
+                                            "type": "array",
+                                            "items": {
+                                                "$ref": "#/definitions/models.FullGear"
+                                            }
+                                        }
+                                    }
+                                }
+                            ]
                         }
                     }
                 }
             }
         },
-        "/api/v1/loadout/{loadout}/update": {
-            "post": {
+        "/api/v1/gear/compare": {
+            "get": {
                 "security": [
                     {
                         "BearerAuth": []
                     }
                 ],
-                "description": "Update an existing loadout",
+                "description": "Get the requested gear aligned field by field in the requested order: manufacturer, category, weight, dimensions, rating, ownership count and the spec attributes of their categories. Numeric values carry the delta against the lightest gear, and fields with a preferred direction mark the best values. Merged gear IDs resolve to the gear they were merged into.",
                 "consumes": [
                     "application/json"
                 ],
@@ -1345,42 +1297,27 @@ const docTemplate = `{
                     "application/json"
                 ],
                 "tags": [
-                    "Loadouts"
+                    "Gear"
                 ],
-                "summary": "Update loadout",
+                "summary": "Compare gear side by side",
                 "parameters": [
                     {
-                        "type": "integer",
-                        "description": "Loadout ID",
-                        "name": "loadout",
-                        "in": "path",
+                        "type": "string",
+                        "description": "Comma separated gear IDs, between 2 and 10",
+                        "name": "ids",
+                        "in": "query",
                         "required": true
-                    },
-                    {
-                        "description": "Updated loadout data",
-                        "name": "request",
-                        "in": "body",
-                        "required": true,
-                        "schema": {
-                            "$ref": "#/definitions/models.LoadoutUpdate"
-                        }
                     }
                 ],
                 "responses": {
                     "200": {
                         "description": "OK",
                         "schema": {
-                            "$ref": "#/definitions/models.Status"
-                        }
-                    },
-                    "403": {
-                        "description": "Forbidden",
-                        "schema": {
-                            "$ref": "#/definitions/models.Error"
+                            "$ref": "#/definitions/models.GearComparison"
                         }
                     },
-                    "404": {
-                        "description": "Not Found",
+                    "default": {
+                        "description": "",
                         "schema": {
                             "$ref": "#/definitions/models.Error"
                         }
@@ -1388,14 +1325,14 @@ const docTemplate = `{
                 }
             }
         },
-        "/api/v1/manufacture/insert": {
-            "put": {
+        "/api/v1/gear/duplicate/list": {
+            "get": {
                 "security": [
                     {
                         "BearerAuth": []
                     }
                 ],
-                "description": "Insert new manufacture with corresponding values",
+                "description": "Get pairs of gear that likely describe the same product, best match first. The score combines the similarity of the names with the manufacturer name and capacity spelling normalized away, a shared manufacturer and similar weight. The gear with more registrations is given first as the suggested merge winner. Requires a JWT issued with the admin audience.",
                 "consumes": [
                     "application/json"
                 ],
@@ -1403,25 +1340,34 @@ const docTemplate = `{
                     "application/json"
                 ],
                 "tags": [
-                    "Manufacture"
+                    "Gear moderation"
                 ],
-                "summary": "Insert new manufacture",
+                "summary": "List likely duplicate gear",
                 "parameters": [
                     {
-                        "description": "query params",
-                        "name": "request",
-                        "in": "body",
-                        "required": true,
-                        "schema": {
-                            "$ref": "#/definitions/models.Manufacture"
-                        }
+                        "type": "number",
+                        "default": 0.75,
+                        "description": "Lowest score to list, between 0 and 1",
+                        "name": "min_score",
+                        "in": "query"
+                    },
+                    {
+                        "maximum": 500,
+                        "type": "integer",
+                        "default": 50,
+                        "description": "Maximum number of pairs",
+                        "name": "limit",
+                        "in": "query"
                     }
                 ],
                 "responses": {
                     "200": {
-                        "description": "status: success when all goes well",
+                        "description": "OK",
                         "schema": {
-                            "$ref": "#/definitions/models.Manufacture"
+                            "type": "array",
+                            "items": {
+                                "$ref": "#/definitions/models.GearDuplicate"
+                            }
                         }
                     },
                     "default": {
@@ -1433,49 +1379,53 @@ const docTemplate = `{
                 }
             }
         },
-        "/api/v1/manufacture/list": {
+        "/api/v1/gear/export": {
             "get": {
                 "security": [
                     {
                         "BearerAuth": []
                     }
                 ],
-                "description": "Get a list of manufacturers",
-                "consumes": [
-                    "application/json"
-                ],
+                "description": "Stream every gear item, with manufacturer and category names, in gear ID order. Takes the same filters as the gear list. The output can be sent back to the bulk import with the same format. Requires a JWT issued with the admin audience.",
                 "produces": [
-                    "application/json"
+                    "text/csv",
+                    "application/x-ndjson",
+                    "application/yaml"
                 ],
                 "tags": [
-                    "Manufacture"
+                    "Gear"
                 ],
-                "summary": "List manufacture",
+                "summary": "Export gear catalog",
                 "parameters": [
+                    {
+                        "type": "string",
+                        "default": "jsonl",
+                        "description": "csv, jsonl or yaml",
+                        "name": "format",
+                        "in": "query"
+                    },
                     {
                         "type": "integer",
-                        "default": 1,
-                        "description": "Page number",
-                        "name": "page",
+                        "description": "Category ID",
+                        "name": "category",
                         "in": "query"
                     },
                     {
                         "type": "integer",
-                        "default": 30,
-                        "description": "Number of items per page",
-                        "name": "limit",
+                        "description": "Top category ID",
+                        "name": "topCategory",
                         "in": "query"
                     },
                     {
-                        "type": "string",
-                        "description": "search by manufacturename (this is case insensitive and wildcard)",
-                        "name": "manufacture",
+                        "type": "integer",
+                        "description": "Manufacturer ID",
+                        "name": "manufacturer",
                         "in": "query"
                     },
                     {
-                        "type": "string",
-                        "description": "search by manufactures full name (this is case insensitive and wildcard)",
-                        "name": "manufacturename",
+                        "type": "boolean",
+                        "description": "Only containers, or only non-containers",
+                        "name": "container",
                         "in": "query"
                     }
                 ],
@@ -1483,22 +1433,10 @@ const docTemplate = `{
                     "200": {
                         "description": "OK",
                         "schema": {
-                            "allOf": [
-                                {
-                                    "$ref": "#/definitions/models.ResponsePayload"
-                                },
-                                {
-                                    "type": "object",
-                                    "properties": {
-                                        "items": {
-                                            "type": "array",
-                                            "items": {
-                                                "$ref": "#/definitions/models.Manufacture"
-                                            }
-                                        }
-                                    }
-                                }
-                            ]
+                            "type": "array",
+                            "items": {
+                                "$ref": "#/definitions/models.FullGear"
+                            }
                         }
                     },
                     "default": {
@@ -1510,38 +1448,79 @@ const docTemplate = `{
                 }
             }
         },
-        "/api/v1/manufacture/{manufacture}/delete": {
-            "delete": {
+        "/api/v1/gear/import": {
+            "post": {
                 "security": [
                     {
                         "BearerAuth": []
                     }
                 ],
-                "description": "Delete manufacture with corresponding ID value",
+                "description": "Import gear rows that reference categories and manufacturers by name, in one transaction. Send a JSON array of rows, JSON lines, a YAML sequence, or CSV with a header row of the same field names. The output of the gear export is accepted as is. Without gear_status, new gear goes into the catalog and existing gear keeps its status. Nothing is committed on a dry run or when any row fails; the per-row report says what was, or would be, created, updated or skipped. Requires a JWT issued with the admin audience.",
                 "consumes": [
-                    "application/json"
+                    "application/json",
+                    "text/csv",
+                    "application/x-ndjson",
+                    "application/yaml"
                 ],
                 "produces": [
                     "application/json"
                 ],
                 "tags": [
-                    "Manufacture"
+                    "Gear"
                 ],
-                "summary": "Delete manufacture with ID",
+                "summary": "Bulk import gear",
                 "parameters": [
                     {
-                        "type": "integer",
-                        "description": "Unique ID of manufacture you want to update",
-                        "name": "manufacture",
-                        "in": "path",
-                        "required": true
+                        "type": "string",
+                        "description": "csv, json, jsonl or yaml. Defaults from the Content-Type, json otherwise",
+                        "name": "format",
+                        "in": "query"
+                    },
+                    {
+                        "type": "boolean",
+                        "default": false,
+                        "description": "Validate and report without committing",
+                        "name": "dry_run",
+                        "in": "query"
+                    },
+                    {
+                        "type": "boolean",
+                        "default": false,
+                        "description": "Create manufacturers that do not exist yet",
+                        "name": "create_manufacturers",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "default": "skip",
+                        "description": "What to do with gear that already exists: skip, update or error",
+                        "name": "on_duplicate",
+                        "in": "query"
+                    },
+                    {
+                        "description": "Rows to import",
+                        "name": "request",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "type": "array",
+                            "items": {
+                                "$ref": "#/definitions/models.GearImportRow"
+                            }
+                        }
                     }
                 ],
                 "responses": {
                     "200": {
-                        "description": "status: success when all goes well",
+                        "description": "OK",
                         "schema": {
-                            "$ref": "#/definitions/models.Status"
+                            "$ref": "#/definitions/models.GearImportReport"
+                        }
+                    },
+                    "422": {
+                        "description": "Some rows failed and nothing was committed",
+                        "schema": {
+                            "$ref": "#/definitions/models.GearImportReport"
                         }
                     },
                     "default": {
@@ -1553,14 +1532,14 @@ const docTemplate = `{
                 }
             }
         },
-        "/api/v1/manufacture/{manufacture}/get": {
-            "get": {
+        "/api/v1/gear/insert": {
+            "put": {
                 "security": [
                     {
                         "BearerAuth": []
                     }
                 ],
-                "description": "Get manufacture spessific to ID",
+                "description": "Insert new gear with corresponding values. Weights are in grams and dimensions in millimetres, or given as {\"value\": 2.1, \"unit\": \"kg\"}. Gear inserted by non-admins is pending review and only visible to them until an admin approves it.",
                 "consumes": [
                     "application/json"
                 ],
@@ -1568,23 +1547,25 @@ const docTemplate = `{
                     "application/json"
                 ],
                 "tags": [
-                    "Manufacture"
+                    "Gear"
                 ],
-                "summary": "Get manufacture by ID",
+                "summary": "Insert new gear",
                 "parameters": [
                     {
-                        "type": "integer",
-                        "description": "Unique ID of manufacture you want to get",
-                        "name": "manufacture",
-                        "in": "path",
-                        "required": true
+                        "description": "query params",
+                        "name": "request",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/models.Gear"
+                        }
                     }
                 ],
                 "responses": {
                     "200": {
-                        "description": "desc",
+                        "description": "status: success when all goes well",
                         "schema": {
-                            "$ref": "#/definitions/models.Manufacture"
+                            "$ref": "#/definitions/models.Gear"
                         }
                     },
                     "default": {
@@ -1596,14 +1577,14 @@ const docTemplate = `{
                 }
             }
         },
-        "/api/v1/manufacture/{manufacture}/update": {
-            "post": {
+        "/api/v1/gear/list": {
+            "get": {
                 "security": [
                     {
                         "BearerAuth": []
                     }
                 ],
-                "description": "Update manufacture identified by ID",
+                "description": "Get a list of gear items. gear_rating_average and gear_review_count summarize the visible reviews; sort by gear_rating_average to list the best rated first with order desc.",
                 "consumes": [
                     "application/json"
                 ],
@@ -1611,139 +1592,133 @@ const docTemplate = `{
                     "application/json"
                 ],
                 "tags": [
-                    "Manufacture"
+                    "Gear"
                 ],
-                "summary": "Update manufacture with ID",
+                "summary": "List gear",
                 "parameters": [
                     {
                         "type": "integer",
-                        "description": "Unique ID of manufacture you want to update",
-                        "name": "manufacture",
-                        "in": "path",
-                        "required": true
+                        "default": 1,
+                        "description": "Page number",
+                        "name": "page",
+                        "in": "query"
                     },
                     {
-                        "description": "query params",
-                        "name": "request",
-                        "in": "body",
-                        "required": true,
-                        "schema": {
-                            "$ref": "#/definitions/models.Manufacture"
-                        }
-                    }
-                ],
-                "responses": {
-                    "200": {
-                        "description": "status: success when all goes well",
-                        "schema": {
-                            "$ref": "#/definitions/models.Status"
-                        }
+                        "type": "integer",
+                        "default": 30,
+                        "description": "Number of items per page",
+                        "name": "limit",
+                        "in": "query"
                     },
-                    "default": {
-                        "description": "",
-                        "schema": {
-                            "$ref": "#/definitions/models.Error"
-                        }
-                    }
-                }
-            }
-        },
-        "/api/v1/public/loadout/{slug}": {
-            "get": {
-                "description": "Get a public loadout by slug. Only returns loadouts with loadout_is_public=true. No authentication required.",
-                "consumes": [
-                    "application/json"
-                ],
-                "produces": [
-                    "application/json"
-                ],
-                "tags": [
-                    "Loadouts"
-                ],
-                "summary": "Get public loadout",
-                "parameters": [
                     {
                         "type": "string",
-                        "description": "Loadout slug",
-                        "name": "slug",
-                        "in": "path",
-                        "required": true
-                    }
-                ],
-                "responses": {
-                    "200": {
-                        "description": "OK",
-                        "schema": {
-                            "$ref": "#/definitions/models.LoadoutPublic"
-                        }
+                        "description": "Field to sort by, json or db field name",
+                        "name": "sort",
+                        "in": "query"
                     },
-                    "400": {
-                        "description": "Bad Request",
-                        "schema": {
-                            "$ref": "#/definitions/models.Error"
-                        }
+                    {
+                        "type": "string",
+                        "default": "asc",
+                        "description": "Sort direction, asc or desc",
+                        "name": "order",
+                        "in": "query"
                     },
-                    "404": {
-                        "description": "Not Found",
-                        "schema": {
-                            "$ref": "#/definitions/models.Error"
-                        }
+                    {
+                        "type": "string",
+                        "description": "Opaque cursor from next_cursor or prev_cursor, replaces page",
+                        "name": "cursor",
+                        "in": "query"
+                    },
+                    {
+                        "type": "boolean",
+                        "default": true,
+                        "description": "Include total item count",
+                        "name": "count",
+                        "in": "query"
                     },
-                    "500": {
-                        "description": "Internal Server Error",
-                        "schema": {
-                            "$ref": "#/definitions/models.Error"
-                        }
-                    }
-                }
-            }
-        },
-        "/api/v1/public/loadout/{slug}/items": {
-            "get": {
-                "description": "List items for a public loadout by slug. No authentication required.",
-                "consumes": [
-                    "application/json"
-                ],
-                "produces": [
-                    "application/json"
-                ],
-                "tags": [
-                    "Loadouts"
-                ],
-                "summary": "List public loadout items",
-                "parameters": [
                     {
                         "type": "string",
-                        "description": "Loadout slug",
-                        "name": "slug",
-                        "in": "path",
-                        "required": true
+                        "description": "Gear category, including the categories below it",
+                        "name": "category",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "Top gear category",
+                        "name": "topCategory",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "Gear manufacturer",
+                        "name": "manufacturer",
+                        "in": "query"
+                    },
+                    {
+                        "type": "array",
+                        "items": {
+                            "type": "string"
+                        },
+                        "collectionFormat": "multi",
+                        "description": "string collection",
+                        "name": "collection",
+                        "in": "query"
+                    },
+                    {
+                        "type": "boolean",
+                        "default": false,
+                        "description": "string collection",
+                        "name": "container",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "Spec filter: spec.name=value (repeat for any of several values), spec.name!=value, or spec.name\u003e=, \u003c=, \u003e, \u003c for numbers",
+                        "name": "spec.{name}",
+                        "in": "query"
+                    },
+                    {
+                        "type": "array",
+                        "items": {
+                            "type": "string"
+                        },
+                        "collectionFormat": "multi",
+                        "description": "Catalog tags",
+                        "name": "tag",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "default": "any",
+                        "description": "Match gear with any or all of the tags",
+                        "name": "tag_match",
+                        "in": "query"
                     }
                 ],
                 "responses": {
                     "200": {
                         "description": "OK",
                         "schema": {
-                            "type": "array",
-                            "items": {
-                                "$ref": "#/definitions/models.LoadoutItem"
-                            }
-                        }
-                    },
-                    "400": {
-                        "description": "Bad Request",
-                        "schema": {
-                            "$ref": "#/definitions/models.Error"
-                        }
-                    },
-                    "404": {
-                        "description": "Not Found",
-                        "schema": {
-                            "$ref": "#/definitions/models.Error"
+                            "allOf": [
+                                {
+                                    "$ref": "#/definitions/models.ResponsePayload"
+                                },
+                                {
+                                    "type": "object",
+                                    "properties": {
+                                        "items": {
+                                            "type": "array",
+                                            "items": {
+                                                "$ref": "#/definitions/models.GearListItem"
+                                            }
+                                        }
+                                    }
+                                }
+                            ]
                         }
                     },
-                    "500": {
-                        "description": "Internal Server Error",
+                    "default": {
+                        "description": "",
                         "schema": {
                             "$ref": "#/definitions/models.Error"
                         }
@@ -1751,14 +1726,14 @@ const docTemplate = `{
                 }
             }
         },
-        "/api/v1/topCategory/insert": {
-            "put": {
+        "/api/v1/gear/recommendation/list": {
+            "get": {
                 "security": [
                     {
                         "BearerAuth": []
                     }
                 ],
-                "description": "Insert new top category with corresponding values",
+                "description": "Get catalog gear the caller does not own yet, scored by how often it is registered or packed together with the gear the caller registered, strongest first. because_gear_ids lists the caller's gear that led to each recommendation. Gear in a category the caller already owns gear in is only recommended when several of the caller's gear point to it. The co-ownership counts are cached and recomputed every few minutes.",
                 "consumes": [
                     "application/json"
                 ],
@@ -1766,25 +1741,33 @@ const docTemplate = `{
                     "application/json"
                 ],
                 "tags": [
-                    "Top Category"
+                    "Gear statistics"
                 ],
-                "summary": "Insert new top category",
+                "summary": "List gear recommended for you",
                 "parameters": [
                     {
-                        "description": "Request body",
-                        "name": "request",
-                        "in": "body",
-                        "required": true,
-                        "schema": {
-                            "$ref": "#/definitions/models.GearTopCategory"
-                        }
+                        "type": "integer",
+                        "description": "Gear category, including the categories below it",
+                        "name": "category",
+                        "in": "query"
+                    },
+                    {
+                        "maximum": 50,
+                        "type": "integer",
+                        "default": 10,
+                        "description": "Maximum number of gear",
+                        "name": "limit",
+                        "in": "query"
                     }
                 ],
                 "responses": {
                     "200": {
-                        "description": "status: success when all goes well",
+                        "description": "OK",
                         "schema": {
-                            "$ref": "#/definitions/models.Status"
+                            "type": "array",
+                            "items": {
+                                "$ref": "#/definitions/models.GearRecommendation"
+                            }
                         }
                     },
                     "default": {
@@ -1796,14 +1779,14 @@ const docTemplate = `{
                 }
             }
         },
-        "/api/v1/topCategory/list": {
+        "/api/v1/gear/review/list": {
             "get": {
                 "security": [
                     {
                         "BearerAuth": []
                     }
                 ],
-                "description": "Get a list of top category items",
+                "description": "Get the gear submitted by non-admins, oldest first. Only pending submissions are listed unless review_status says otherwise. Requires a JWT issued with the admin audience.",
                 "consumes": [
                     "application/json"
                 ],
@@ -1811,9 +1794,9 @@ const docTemplate = `{
                     "application/json"
                 ],
                 "tags": [
-                    "Top Category"
+                    "Gear moderation"
                 ],
-                "summary": "List top categories",
+                "summary": "List gear review queue",
                 "parameters": [
                     {
                         "type": "integer",
@@ -1830,13 +1813,37 @@ const docTemplate = `{
                         "in": "query"
                     },
                     {
-                        "type": "array",
-                        "items": {
-                            "type": "integer"
-                        },
-                        "collectionFormat": "multi",
-                        "description": "top categories",
-                        "name": "topCategory",
+                        "type": "string",
+                        "default": "gear_submitted_at",
+                        "description": "Field to sort by, json or db field name",
+                        "name": "sort",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "default": "asc",
+                        "description": "Sort direction, asc or desc",
+                        "name": "order",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "Opaque cursor from next_cursor or prev_cursor, replaces page",
+                        "name": "cursor",
+                        "in": "query"
+                    },
+                    {
+                        "type": "boolean",
+                        "default": true,
+                        "description": "Include total item count",
+                        "name": "count",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "default": "pending",
+                        "description": "pending, approved, rejected or all",
+                        "name": "review_status",
                         "in": "query"
                     }
                 ],
@@ -1854,7 +1861,7 @@ const docTemplate = `{
                                         "items": {
                                             "type": "array",
                                             "items": {
-                                                "$ref": "#/definitions/models.GearTopCategory"
+                                                "$ref": "#/definitions/models.GearSubmission"
                                             }
                                         }
                                     }
@@ -1871,14 +1878,14 @@ const docTemplate = `{
                 }
             }
         },
-        "/api/v1/topCategory/{topCategory}/delete": {
-            "delete": {
+        "/api/v1/gear/search": {
+            "get": {
                 "security": [
                     {
                         "BearerAuth": []
                     }
                 ],
-                "description": "Delete topCategory with corresponding ID value",
+                "description": "Get a list of gear items based on search patterns. The default fulltext search matches every word as a prefix of the gear, manufacturer, category or size names, ranked by relevance with the matches highlighted in search_snippet.",
                 "consumes": [
                     "application/json"
                 ],
@@ -1886,23 +1893,109 @@ const docTemplate = `{
                     "application/json"
                 ],
                 "tags": [
-                    "Top Category"
+                    "Gear"
                 ],
-                "summary": "Delete topCategory with ID",
+                "summary": "Search for gear",
                 "parameters": [
                     {
                         "type": "integer",
-                        "description": "Unique ID of topCategory you want to update",
-                        "name": "topCategory",
-                        "in": "path",
+                        "default": 1,
+                        "description": "Page number",
+                        "name": "page",
+                        "in": "query"
+                    },
+                    {
+                        "type": "integer",
+                        "default": 30,
+                        "description": "Number of items per page",
+                        "name": "limit",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "Field to sort by, json or db field name. Fulltext searches default to search_rank",
+                        "name": "sort",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "default": "asc",
+                        "description": "Sort direction, asc or desc",
+                        "name": "order",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "Opaque cursor from next_cursor or prev_cursor, replaces page",
+                        "name": "cursor",
+                        "in": "query"
+                    },
+                    {
+                        "type": "boolean",
+                        "default": true,
+                        "description": "Include total item count",
+                        "name": "count",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "String to search for",
+                        "name": "searchString",
+                        "in": "query",
                         "required": true
+                    },
+                    {
+                        "type": "string",
+                        "default": "fulltext",
+                        "description": "Type of search method. valid choices are: fulltext, startswith, contains, endswith",
+                        "name": "searchType",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "Gear category, including the categories below it",
+                        "name": "category",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "Top gear category",
+                        "name": "topCategory",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "Gear manufacturer",
+                        "name": "manufacturer",
+                        "in": "query"
+                    },
+                    {
+                        "type": "boolean",
+                        "description": "Only containers, or only non-containers",
+                        "name": "container",
+                        "in": "query"
                     }
                 ],
                 "responses": {
                     "200": {
-                        "description": "status: success when all goes well",
+                        "description": "OK",
                         "schema": {
-                            "$ref": "#/definitions/models.Status"
+                            "allOf": [
+                                {
+                                    "$ref": "#/definitions/models.ResponsePayload"
+                                },
+                                {
+                                    "type": "object",
+                                    "properties": {
+                                        "items": {
+                                            "type": "array",
+                                            "items": {
+                                                "$ref": "#/definitions/models.GearSearchItem"
+                                            }
+                                        }
+                                    }
+                                }
+                            ]
                         }
                     },
                     "default": {
@@ -1914,14 +2007,14 @@ const docTemplate = `{
                 }
             }
         },
-        "/api/v1/topCategory/{topCategory}/get": {
+        "/api/v1/gear/stats/owned": {
             "get": {
                 "security": [
                     {
                         "BearerAuth": []
                     }
                 ],
-                "description": "Get top category spessific to ID",
+                "description": "Get catalog gear ranked by the number of users who registered it, overall or in a category and the categories below it. count is the number of owners. The statistics are cached and refreshed every few minutes; Last-Modified tells when they were computed.",
                 "consumes": [
                     "application/json"
                 ],
@@ -1929,23 +2022,33 @@ const docTemplate = `{
                     "application/json"
                 ],
                 "tags": [
-                    "Top Category"
+                    "Gear statistics"
                 ],
-                "summary": "Get top category with ID",
+                "summary": "List the most owned gear",
                 "parameters": [
                     {
                         "type": "integer",
-                        "description": "Unique ID of top category you want to get",
-                        "name": "topCategoryID",
-                        "in": "path",
-                        "required": true
+                        "description": "Gear category, including the categories below it",
+                        "name": "category",
+                        "in": "query"
+                    },
+                    {
+                        "maximum": 100,
+                        "type": "integer",
+                        "default": 10,
+                        "description": "Maximum number of gear",
+                        "name": "limit",
+                        "in": "query"
                     }
                 ],
                 "responses": {
                     "200": {
                         "description": "OK",
                         "schema": {
-                            "$ref": "#/definitions/models.GearTopCategory"
+                            "type": "array",
+                            "items": {
+                                "$ref": "#/definitions/models.GearRanking"
+                            }
                         }
                     },
                     "default": {
@@ -1957,14 +2060,14 @@ const docTemplate = `{
                 }
             }
         },
-        "/api/v1/topCategory/{topCategory}/update": {
-            "post": {
+        "/api/v1/gear/stats/recent": {
+            "get": {
                 "security": [
                     {
                         "BearerAuth": []
                     }
                 ],
-                "description": "Update top category identified by ID",
+                "description": "Get the gear most recently added to the catalog, newest first, overall or in a category and the categories below it. Approved submissions count from their approval. Gear added before addition times were recorded comes last, without added_at. The list is cached and refreshed every few minutes; Last-Modified tells when it was computed.",
                 "consumes": [
                     "application/json"
                 ],
@@ -1972,32 +2075,33 @@ const docTemplate = `{
                     "application/json"
                 ],
                 "tags": [
-                    "Top Category"
+                    "Gear statistics"
                 ],
-                "summary": "Update top category with ID",
+                "summary": "List recently added gear",
                 "parameters": [
                     {
                         "type": "integer",
-                        "description": "Unique ID of top category you want to update",
-                        "name": "topCategoryID",
-                        "in": "path",
-                        "required": true
+                        "description": "Gear category, including the categories below it",
+                        "name": "category",
+                        "in": "query"
                     },
                     {
-                        "description": "Request body",
-                        "name": "request",
-                        "in": "body",
-                        "required": true,
-                        "schema": {
-                            "$ref": "#/definitions/models.GearTopCategory"
-                        }
+                        "maximum": 100,
+                        "type": "integer",
+                        "default": 10,
+                        "description": "Maximum number of gear",
+                        "name": "limit",
+                        "in": "query"
                     }
                 ],
                 "responses": {
                     "200": {
-                        "description": "status: success when all goes well",
+                        "description": "OK",
                         "schema": {
-                            "$ref": "#/definitions/models.Status"
+                            "type": "array",
+                            "items": {
+                                "$ref": "#/definitions/models.GearRecentlyAdded"
+                            }
                         }
                     },
                     "default": {
@@ -2009,14 +2113,14 @@ const docTemplate = `{
                 }
             }
         },
-        "/api/v1/usergear/insert": {
-            "put": {
+        "/api/v1/gear/stats/used": {
+            "get": {
                 "security": [
                     {
                         "BearerAuth": []
                     }
                 ],
-                "description": "Insert user registered gear with corresponding values",
+                "description": "Get catalog gear ranked by the number of public loadouts it is packed in, overall or in a category and the categories below it. count is the number of public loadouts. The statistics are cached and refreshed every few minutes; Last-Modified tells when they were computed.",
                 "consumes": [
                     "application/json"
                 ],
@@ -2024,38 +2128,52 @@ const docTemplate = `{
                     "application/json"
                 ],
                 "tags": [
-                    "User gear"
+                    "Gear statistics"
                 ],
-                "summary": "Insert user registered gear",
+                "summary": "List the gear most used in public loadouts",
                 "parameters": [
                     {
-                        "description": "query params",
-                        "name": "request",
-                        "in": "body",
-                        "required": true,
-                        "schema": {
-                            "$ref": "#/definitions/models.UserGearLinkNoID"
-                        }
+                        "type": "integer",
+                        "description": "Gear category, including the categories below it",
+                        "name": "category",
+                        "in": "query"
+                    },
+                    {
+                        "maximum": 100,
+                        "type": "integer",
+                        "default": 10,
+                        "description": "Maximum number of gear",
+                        "name": "limit",
+                        "in": "query"
                     }
                 ],
                 "responses": {
                     "200": {
-                        "description": "status: success when all goes well",
+                        "description": "OK",
                         "schema": {
-                            "$ref": "#/definitions/models.Status"
+                            "type": "array",
+                            "items": {
+                                "$ref": "#/definitions/models.GearRanking"
+                            }
+                        }
+                    },
+                    "default": {
+                        "description": "",
+                        "schema": {
+                            "$ref": "#/definitions/models.Error"
                         }
                     }
                 }
             }
         },
-        "/api/v1/usergear/registration/{usergear}/delete": {
-            "delete": {
+        "/api/v1/gear/submission/list": {
+            "get": {
                 "security": [
                     {
                         "BearerAuth": []
                     }
                 ],
-                "description": "Delete userGear with corresponding ID value",
+                "description": "Get the gear the current user submitted to the catalog with its review status and the reason given by the reviewer, oldest first",
                 "consumes": [
                     "application/json"
                 ],
@@ -2063,23 +2181,79 @@ const docTemplate = `{
                     "application/json"
                 ],
                 "tags": [
-                    "User gear"
+                    "Gear moderation"
                 ],
-                "summary": "Delete userGear with ID",
+                "summary": "List own gear submissions",
                 "parameters": [
                     {
                         "type": "integer",
-                        "description": "Unique ID of userGear you want to update",
-                        "name": "userGear",
-                        "in": "path",
-                        "required": true
+                        "default": 1,
+                        "description": "Page number",
+                        "name": "page",
+                        "in": "query"
+                    },
+                    {
+                        "type": "integer",
+                        "default": 30,
+                        "description": "Number of items per page",
+                        "name": "limit",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "default": "gear_submitted_at",
+                        "description": "Field to sort by, json or db field name",
+                        "name": "sort",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "default": "asc",
+                        "description": "Sort direction, asc or desc",
+                        "name": "order",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "Opaque cursor from next_cursor or prev_cursor, replaces page",
+                        "name": "cursor",
+                        "in": "query"
+                    },
+                    {
+                        "type": "boolean",
+                        "default": true,
+                        "description": "Include total item count",
+                        "name": "count",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "default": "all",
+                        "description": "pending, approved, rejected or all",
+                        "name": "review_status",
+                        "in": "query"
                     }
                 ],
                 "responses": {
                     "200": {
-                        "description": "status: success when all goes well",
+                        "description": "OK",
                         "schema": {
-                            "$ref": "#/definitions/models.Status"
+                            "allOf": [
+                                {
+                                    "$ref": "#/definitions/models.ResponsePayload"
+                                },
+                                {
+                                    "type": "object",
+                                    "properties": {
+                                        "items": {
+                                            "type": "array",
+                                            "items": {
+                                                "$ref": "#/definitions/models.GearSubmission"
+                                            }
+                                        }
+                                    }
+                                }
+                            ]
                         }
                     },
                     "default": {
@@ -2091,14 +2265,14 @@ const docTemplate = `{
                 }
             }
         },
-        "/api/v1/usergear/registration/{usergear}/get": {
+        "/api/v1/gear/suggest": {
             "get": {
                 "security": [
                     {
                         "BearerAuth": []
                     }
                 ],
-                "description": "Get user registeredgear spessific to ID",
+                "description": "Get typo-tolerant autocomplete suggestions across gear, manufacturers and categories, best match first",
                 "consumes": [
                     "application/json"
                 ],
@@ -2106,36 +2280,53 @@ const docTemplate = `{
                     "application/json"
                 ],
                 "tags": [
-                    "User gear"
+                    "Gear"
                 ],
-                "summary": "Get user registered gear with ID",
+                "summary": "Suggest gear",
                 "parameters": [
                     {
-                        "type": "integer",
-                        "description": "Unique ID of user registered gear you want to get",
-                        "name": "usergear",
-                        "in": "path",
+                        "type": "string",
+                        "description": "Partial or misspelled name",
+                        "name": "q",
+                        "in": "query",
                         "required": true
+                    },
+                    {
+                        "maximum": 25,
+                        "type": "integer",
+                        "default": 8,
+                        "description": "Maximum number of suggestions",
+                        "name": "limit",
+                        "in": "query"
                     }
                 ],
                 "responses": {
                     "200": {
-                        "description": "desc",
+                        "description": "OK",
                         "schema": {
-                            "$ref": "#/definitions/models.UserGear"
+                            "type": "array",
+                            "items": {
+                                "$ref": "#/definitions/models.Suggestion"
+                            }
+                        }
+                    },
+                    "default": {
+                        "description": "",
+                        "schema": {
+                            "$ref": "#/definitions/models.Error"
                         }
                     }
                 }
             }
         },
-        "/api/v1/usergear/registration/{usergear}/update": {
-            "post": {
+        "/api/v1/gear/{gear}/delete": {
+            "delete": {
                 "security": [
                     {
                         "BearerAuth": []
                     }
                 ],
-                "description": "Update user registered gear identified by ID",
+                "description": "Delete gear with corresponding ID value",
                 "consumes": [
                     "application/json"
                 ],
@@ -2143,25 +2334,16 @@ const docTemplate = `{
                     "application/json"
                 ],
                 "tags": [
-                    "User gear"
+                    "Gear"
                 ],
-                "summary": "Update user registered gear with ID",
+                "summary": "Delete gear with ID",
                 "parameters": [
                     {
                         "type": "integer",
-                        "description": "Unique ID of user registered gear you want to get",
-                        "name": "usergear",
+                        "description": "Unique ID of gear you want to delete",
+                        "name": "gear",
                         "in": "path",
                         "required": true
-                    },
-                    {
-                        "description": "query params",
-                        "name": "request",
-                        "in": "body",
-                        "required": true,
-                        "schema": {
-                            "$ref": "#/definitions/models.UserGearLink"
-                        }
                     }
                 ],
                 "responses": {
@@ -2170,18 +2352,24 @@ const docTemplate = `{
                         "schema": {
                             "$ref": "#/definitions/models.Status"
                         }
+                    },
+                    "default": {
+                        "description": "",
+                        "schema": {
+                            "$ref": "#/definitions/models.Error"
+                        }
                     }
                 }
             }
         },
-        "/api/v1/usergear/{user}/list": {
+        "/api/v1/gear/{gear}/get": {
             "get": {
                 "security": [
                     {
                         "BearerAuth": []
                     }
                 ],
-                "description": "Get a list a users gear",
+                "description": "Get gear spessific to ID. The ID of gear merged into other gear gives the gear it was merged into.",
                 "consumes": [
                     "application/json"
                 ],
@@ -2189,89 +2377,29 @@ const docTemplate = `{
                     "application/json"
                 ],
                 "tags": [
-                    "User gear"
+                    "Gear"
                 ],
-                "summary": "List users gear",
+                "summary": "Get gear with ID",
                 "parameters": [
                     {
                         "type": "integer",
-                        "description": "Unique ID of user you want to get the Gear of",
-                        "name": "user",
+                        "description": "Unique ID of Gear you want to get",
+                        "name": "gear",
                         "in": "path",
                         "required": true
                     },
-                    {
-                        "type": "integer",
-                        "default": 1,
-                        "description": "Page number",
-                        "name": "page",
-                        "in": "query"
-                    },
-                    {
-                        "type": "integer",
-                        "default": 30,
-                        "description": "Number of items per page",
-                        "name": "limit",
-                        "in": "query"
-                    },
-                    {
-                        "type": "array",
-                        "items": {
-                            "type": "integer"
-                        },
-                        "collectionFormat": "multi",
-                        "description": "top categories",
-                        "name": "topCategory",
-                        "in": "query"
-                    },
-                    {
-                        "type": "array",
-                        "items": {
-                            "type": "integer"
-                        },
-                        "collectionFormat": "multi",
-                        "description": "sub categories",
-                        "name": "category",
-                        "in": "query"
-                    },
-                    {
-                        "type": "array",
-                        "items": {
-                            "type": "integer"
-                        },
-                        "collectionFormat": "multi",
-                        "description": "manufacturers",
-                        "name": "manufacture",
-                        "in": "query"
-                    },
                     {
                         "type": "string",
-                        "default": "all",
-                        "description": "show container gear only. valid values are true, false, all",
-                        "name": "container",
+                        "description": "Also give measurements in metric or imperial units, overriding the user's preference",
+                        "name": "units",
                         "in": "query"
                     }
                 ],
                 "responses": {
                     "200": {
-                        "description": "OK",
+                        "description": "desc",
                         "schema": {
-                            "allOf": [
-                                {
-                                    "$ref": "#/definitions/models.ResponsePayload"
-                                },
-                                {
-                                    "type": "object",
-                                    "properties": {
-                                        "items": {
-                                            "type": "array",
-                                            "items": {
-                                                "$ref": "#/definitions/models.UserGear"
-                                            }
-                                        }
-                                    }
-                                }
-                            ]
+                            "$ref": "#/definitions/models.FullGear"
                         }
                     },
                     "default": {
@@ -2283,127 +2411,54 @@ const docTemplate = `{
                 }
             }
         },
-        "/api/v1/users/insert": {
-            "put": {
+        "/api/v1/gear/{gear}/image/list": {
+            "get": {
                 "security": [
                     {
                         "BearerAuth": []
                     }
                 ],
-                "description": "Insert new user with corresponding values",
-                "consumes": [
-                    "application/json"
-                ],
+                "description": "List the images of a piece of gear, oldest first. The first image is the one shown as image_url on the gear.",
                 "produces": [
                     "application/json"
                 ],
                 "tags": [
-                    "User"
+                    "Gear"
                 ],
-                "summary": "Insert new user",
+                "summary": "List gear images",
                 "parameters": [
                     {
-                        "description": "query params",
-                        "name": "request",
-                        "in": "body",
-                        "required": true,
-                        "schema": {
-                            "$ref": "#/definitions/models.UserWithPass"
-                        }
+                        "type": "integer",
+                        "description": "Gear ID",
+                        "name": "gear",
+                        "in": "path",
+                        "required": true
                     }
                 ],
                 "responses": {
                     "200": {
-                        "description": "status: success when all goes well",
+                        "description": "OK",
                         "schema": {
-                            "$ref": "#/definitions/models.Status"
+                            "type": "array",
+                            "items": {
+                                "$ref": "#/definitions/models.Image"
+                            }
                         }
                     },
-                    "default": {
-                        "description": "",
+                    "400": {
+                        "description": "Bad Request",
                         "schema": {
                             "$ref": "#/definitions/models.Error"
                         }
-                    }
-                }
-            }
-        },
-        "/api/v1/users/list": {
-            "get": {
-                "security": [
-                    {
-                        "BearerAuth": []
-                    }
-                ],
-                "description": "Get a list of user items",
-                "consumes": [
-                    "application/json"
-                ],
-                "produces": [
-                    "application/json"
-                ],
-                "tags": [
-                    "User"
-                ],
-                "summary": "List user",
-                "parameters": [
-                    {
-                        "type": "integer",
-                        "default": 1,
-                        "description": "Page number",
-                        "name": "page",
-                        "in": "query"
-                    },
-                    {
-                        "type": "integer",
-                        "default": 30,
-                        "description": "Number of items per page",
-                        "name": "limit",
-                        "in": "query"
-                    },
-                    {
-                        "type": "string",
-                        "description": "search by user's username (this is case insensitive and wildcard)",
-                        "name": "user",
-                        "in": "query"
-                    },
-                    {
-                        "type": "string",
-                        "description": "search by users full name (this is case insensitive and wildcard)",
-                        "name": "username",
-                        "in": "query"
                     },
-                    {
-                        "type": "string",
-                        "description": "search by users email (this is case insensitive and wildcard)",
-                        "name": "email",
-                        "in": "query"
-                    }
-                ],
-                "responses": {
-                    "200": {
-                        "description": "OK",
+                    "404": {
+                        "description": "Not Found",
                         "schema": {
-                            "allOf": [
-                                {
-                                    "$ref": "#/definitions/models.ResponsePayload"
-                                },
-                                {
-                                    "type": "object",
-                                    "properties": {
-                                        "items": {
-                                            "type": "array",
-                                            "items": {
-                                                "$ref": "#/definitions/models.User"
-                                            }
-                                        }
-                                    }
-                                }
-                            ]
+                            "$ref": "#/definitions/models.Error"
                         }
                     },
-                    "default": {
-                        "description": "",
+                    "500": {
+                        "description": "Internal Server Error",
                         "schema": {
                             "$ref": "#/definitions/models.Error"
                         }
@@ -2411,42 +2466,79 @@ const docTemplate = `{
                 }
             }
         },
-        "/api/v1/users/{user}/delete": {
-            "delete": {
+        "/api/v1/gear/{gear}/image/upload": {
+            "put": {
                 "security": [
                     {
                         "BearerAuth": []
                     }
                 ],
-                "description": "Delete user with corresponding ID value",
+                "description": "Upload a JPEG, PNG or GIF of a piece of gear as the multipart field image. The type is sniffed from the content and a thumbnail is generated. Uploads are limited to storage.max-upload-bytes, 10 MiB by default. Requires a JWT issued with the admin audience.",
                 "consumes": [
-                    "application/json"
+                    "multipart/form-data"
                 ],
                 "produces": [
                     "application/json"
                 ],
                 "tags": [
-                    "User"
+                    "Gear"
                 ],
-                "summary": "Delete user with ID",
+                "summary": "Upload gear image",
                 "parameters": [
                     {
                         "type": "integer",
-                        "description": "Unique ID of user you want to update",
-                        "name": "user",
+                        "description": "Gear ID",
+                        "name": "gear",
                         "in": "path",
                         "required": true
+                    },
+                    {
+                        "type": "file",
+                        "description": "Image file",
+                        "name": "image",
+                        "in": "formData",
+                        "required": true
                     }
                 ],
                 "responses": {
-                    "200": {
-                        "description": "status: success when all goes well",
+                    "201": {
+                        "description": "Created",
                         "schema": {
-                            "$ref": "#/definitions/models.Status"
+                            "$ref": "#/definitions/models.Image"
                         }
                     },
-                    "default": {
-                        "description": "",
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/models.Error"
+                        }
+                    },
+                    "403": {
+                        "description": "Forbidden",
+                        "schema": {
+                            "$ref": "#/definitions/models.Error"
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "$ref": "#/definitions/models.Error"
+                        }
+                    },
+                    "413": {
+                        "description": "Request Entity Too Large",
+                        "schema": {
+                            "$ref": "#/definitions/models.Error"
+                        }
+                    },
+                    "415": {
+                        "description": "Unsupported Media Type",
+                        "schema": {
+                            "$ref": "#/definitions/models.Error"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
                         "schema": {
                             "$ref": "#/definitions/models.Error"
                         }
@@ -2454,42 +2546,64 @@ const docTemplate = `{
                 }
             }
         },
-        "/api/v1/users/{user}/get": {
-            "get": {
+        "/api/v1/gear/{gear}/image/{image}/delete": {
+            "delete": {
                 "security": [
                     {
                         "BearerAuth": []
                     }
                 ],
-                "description": "Get user spessific to ID",
-                "consumes": [
-                    "application/json"
-                ],
+                "description": "Delete an image of a piece of gear and its files. Requires a JWT issued with the admin audience.",
                 "produces": [
                     "application/json"
                 ],
                 "tags": [
-                    "User"
+                    "Gear"
                 ],
-                "summary": "Get user with ID",
+                "summary": "Delete gear image",
                 "parameters": [
                     {
                         "type": "integer",
-                        "description": "Unique ID of user you want to get",
-                        "name": "user",
+                        "description": "Gear ID",
+                        "name": "gear",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "type": "integer",
+                        "description": "Image ID",
+                        "name": "image",
                         "in": "path",
                         "required": true
                     }
                 ],
                 "responses": {
                     "200": {
-                        "description": "desc",
+                        "description": "OK",
                         "schema": {
-                            "$ref": "#/definitions/models.User"
+                            "$ref": "#/definitions/models.Status"
                         }
                     },
-                    "default": {
-                        "description": "",
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/models.Error"
+                        }
+                    },
+                    "403": {
+                        "description": "Forbidden",
+                        "schema": {
+                            "$ref": "#/definitions/models.Error"
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "$ref": "#/definitions/models.Error"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
                         "schema": {
                             "$ref": "#/definitions/models.Error"
                         }
@@ -2497,14 +2611,14 @@ const docTemplate = `{
                 }
             }
         },
-        "/api/v1/users/{user}/update": {
+        "/api/v1/gear/{gear}/merge": {
             "post": {
                 "security": [
                     {
                         "BearerAuth": []
                     }
                 ],
-                "description": "Update user identified by ID",
+                "description": "Fold the gear in the path into winner_id in one transaction. Registrations, loadout items, variants, images, tags, reviews and spec values move to the winner, the weights of affected loadouts are recalculated and the merged ID keeps resolving to the winner. Requires a JWT issued with the admin audience.",
                 "consumes": [
                     "application/json"
                 ],
@@ -2512,32 +2626,32 @@ const docTemplate = `{
                     "application/json"
                 ],
                 "tags": [
-                    "User"
+                    "Gear moderation"
                 ],
-                "summary": "Update user with ID",
+                "summary": "Merge duplicate gear",
                 "parameters": [
                     {
                         "type": "integer",
-                        "description": "Unique ID of user you want to update",
-                        "name": "user",
+                        "description": "Unique ID of the duplicate gear to merge away",
+                        "name": "gear",
                         "in": "path",
                         "required": true
                     },
                     {
-                        "description": "query params",
+                        "description": "Gear to keep",
                         "name": "request",
                         "in": "body",
                         "required": true,
                         "schema": {
-                            "$ref": "#/definitions/models.User"
+                            "$ref": "#/definitions/models.GearMerge"
                         }
                     }
                 ],
                 "responses": {
                     "200": {
-                        "description": "status: success when all goes well",
+                        "description": "OK",
                         "schema": {
-                            "$ref": "#/definitions/models.Status"
+                            "$ref": "#/definitions/models.GearMergeResult"
                         }
                     },
                     "default": {
@@ -2549,9 +2663,14 @@ const docTemplate = `{
                 }
             }
         },
-        "/auth/google/callback": {
+        "/api/v1/gear/{gear}/recommendation/list": {
             "get": {
-                "description": "Validates the Google credential and returns a JWT for subsequent API calls",
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "Get \"users who own this also own\" recommendations: catalog gear registered by the same users or packed in the same loadouts as the gear, strongest first. Gear the caller already owns and gear in the same category as the gear are left out. The co-ownership counts are cached and recomputed every few minutes.",
                 "consumes": [
                     "application/json"
                 ],
@@ -2559,28 +2678,29 @@ const docTemplate = `{
                     "application/json"
                 ],
                 "tags": [
-                    "Auth"
+                    "Gear statistics"
                 ],
-                "summary": "Google OAuth callback",
+                "summary": "List gear owned together with a gear",
                 "parameters": [
                     {
-                        "description": "Callback payload",
-                        "name": "request",
-                        "in": "body",
-                        "schema": {
-                            "$ref": "#/definitions/endpoints.googleCallbackRequest"
-                        }
+                        "type": "integer",
+                        "description": "Unique ID of gear",
+                        "name": "gear",
+                        "in": "path",
+                        "required": true
                     },
                     {
-                        "type": "string",
-                        "description": "Authorization code",
-                        "name": "code",
+                        "type": "integer",
+                        "description": "Gear category, including the categories below it",
+                        "name": "category",
                         "in": "query"
                     },
                     {
-                        "type": "string",
-                        "description": "Google ID token",
-                        "name": "id_token",
+                        "maximum": 50,
+                        "type": "integer",
+                        "default": 10,
+                        "description": "Maximum number of gear",
+                        "name": "limit",
                         "in": "query"
                     }
                 ],
@@ -2588,32 +2708,29 @@ const docTemplate = `{
                     "200": {
                         "description": "OK",
                         "schema": {
-                            "type": "object",
-                            "additionalProperties": true
-                        }
-                    },
-                    "400": {
-                        "description": "Bad Request",
-                        "schema": {
-                            "$ref": "#/definitions/models.Error"
-                        }
-                    },
-                    "401": {
-                        "description": "Unauthorized",
-                        "schema": {
-                            "$ref": "#/definitions/models.Error"
+                            "type": "array",
+                            "items": {
+                                "$ref": "#/definitions/models.GearRecommendation"
+                            }
                         }
                     },
-                    "500": {
-                        "description": "Internal Server Error",
+                    "default": {
+                        "description": "",
                         "schema": {
                             "$ref": "#/definitions/models.Error"
                         }
                     }
                 }
-            },
+            }
+        },
+        "/api/v1/gear/{gear}/review": {
             "post": {
-                "description": "Validates the Google credential and returns a JWT for subsequent API calls",
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "Approve a gear submission into the catalog, or reject it with a reason shown to the submitter. Admins edit submissions with the gear update route before deciding. Requires a JWT issued with the admin audience.",
                 "consumes": [
                     "application/json"
                 ],
@@ -2621,53 +2738,36 @@ const docTemplate = `{
                     "application/json"
                 ],
                 "tags": [
-                    "Auth"
+                    "Gear moderation"
                 ],
-                "summary": "Google OAuth callback",
+                "summary": "Review gear submission",
                 "parameters": [
                     {
-                        "description": "Callback payload",
+                        "type": "integer",
+                        "description": "Unique ID of gear you want to review",
+                        "name": "gear",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "description": "Decision: approve or reject",
                         "name": "request",
                         "in": "body",
+                        "required": true,
                         "schema": {
-                            "$ref": "#/definitions/endpoints.googleCallbackRequest"
+                            "$ref": "#/definitions/models.GearReviewDecision"
                         }
-                    },
-                    {
-                        "type": "string",
-                        "description": "Authorization code",
-                        "name": "code",
-                        "in": "query"
-                    },
-                    {
-                        "type": "string",
-                        "description": "Google ID token",
-                        "name": "id_token",
-                        "in": "query"
                     }
                 ],
                 "responses": {
                     "200": {
                         "description": "OK",
                         "schema": {
-                            "type": "object",
-                            "additionalProperties": true
-                        }
-                    },
-                    "400": {
-                        "description": "Bad Request",
-                        "schema": {
-                            "$ref": "#/definitions/models.Error"
-                        }
-                    },
-                    "401": {
-                        "description": "Unauthorized",
-                        "schema": {
-                            "$ref": "#/definitions/models.Error"
+                            "$ref": "#/definitions/models.GearSubmission"
                         }
                     },
-                    "500": {
-                        "description": "Internal Server Error",
+                    "default": {
+                        "description": "",
                         "schema": {
                             "$ref": "#/definitions/models.Error"
                         }
@@ -2675,31 +2775,69 @@ const docTemplate = `{
                 }
             }
         },
-        "/auth/refresh": {
-            "post": {
+        "/api/v1/gear/{gear}/review/insert": {
+            "put": {
                 "security": [
                     {
                         "BearerAuth": []
                     }
                 ],
-                "description": "Exchanges a valid GoGear JWT for a new token with a fresh expiry",
+                "description": "Rate a piece of gear from 1 to 5, optionally with a text. Only users who have registered the gear can review it, once per gear.",
+                "consumes": [
+                    "application/json"
+                ],
                 "produces": [
                     "application/json"
                 ],
                 "tags": [
-                    "Auth"
+                    "Gear reviews"
+                ],
+                "summary": "Insert gear review",
+                "parameters": [
+                    {
+                        "type": "integer",
+                        "description": "Gear ID",
+                        "name": "gear",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "description": "Rating and text",
+                        "name": "body",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/models.GearReviewNoID"
+                        }
+                    }
                 ],
-                "summary": "Refresh service token",
                 "responses": {
-                    "200": {
-                        "description": "OK",
+                    "201": {
+                        "description": "Created",
                         "schema": {
-                            "type": "object",
-                            "additionalProperties": true
+                            "$ref": "#/definitions/models.GearReview"
                         }
                     },
-                    "401": {
-                        "description": "Unauthorized",
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/models.Error"
+                        }
+                    },
+                    "403": {
+                        "description": "Forbidden",
+                        "schema": {
+                            "$ref": "#/definitions/models.Error"
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "$ref": "#/definitions/models.Error"
+                        }
+                    },
+                    "409": {
+                        "description": "Conflict",
                         "schema": {
                             "$ref": "#/definitions/models.Error"
                         }
@@ -2713,9 +2851,14 @@ const docTemplate = `{
                 }
             }
         },
-        "/health": {
+        "/api/v1/gear/{gear}/review/list": {
             "get": {
-                "description": "Get health status of application",
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "List the reviews owners left on a piece of gear. Reviews hidden by an admin are only listed for admins and their author.",
                 "consumes": [
                     "application/json"
                 ],
@@ -2723,433 +2866,6759 @@ const docTemplate = `{
                     "application/json"
                 ],
                 "tags": [
-                    "Health"
+                    "Gear reviews"
                 ],
-                "summary": "Get application health",
-                "responses": {
-                    "200": {
-                        "description": "desc",
-                        "schema": {
-                            "$ref": "#/definitions/models.Health"
-                        }
-                    }
-                }
+                "summary": "List gear reviews",
+                "parameters": [
+                    {
+                        "type": "integer",
+                        "description": "Gear ID",
+                        "name": "gear",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "type": "integer",
+                        "default": 1,
+                        "description": "Page number",
+                        "name": "page",
+                        "in": "query"
+                    },
+                    {
+                        "type": "integer",
+                        "default": 30,
+                        "description": "Number of items per page",
+                        "name": "limit",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "default": "created_at",
+                        "description": "Field to sort by, json or db field name",
+                        "name": "sort",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "default": "asc",
+                        "description": "Sort direction, asc or desc",
+                        "name": "order",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "Opaque cursor from next_cursor or prev_cursor, replaces page",
+                        "name": "cursor",
+                        "in": "query"
+                    },
+                    {
+                        "type": "boolean",
+                        "default": true,
+                        "description": "Include total item count",
+                        "name": "count",
+                        "in": "query"
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "allOf": [
+                                {
+                                    "$ref": "#/definitions/models.ResponsePayload"
+                                },
+                                {
+                                    "type": "object",
+                                    "properties": {
+                                        "items": {
+                                            "type": "array",
+                                            "items": {
+                                                "$ref": "#/definitions/models.GearReview"
+                                            }
+                                        }
+                                    }
+                                }
+                            ]
+                        }
+                    },
+                    "default": {
+                        "description": "",
+                        "schema": {
+                            "$ref": "#/definitions/models.Error"
+                        }
+                    }
+                }
+            }
+        },
+        "/api/v1/gear/{gear}/review/{review}/delete": {
+            "delete": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "Delete your own review. Admins can delete any review.",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Gear reviews"
+                ],
+                "summary": "Delete gear review",
+                "parameters": [
+                    {
+                        "type": "integer",
+                        "description": "Gear ID",
+                        "name": "gear",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "type": "integer",
+                        "description": "Review ID",
+                        "name": "review",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/models.Status"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/models.Error"
+                        }
+                    },
+                    "403": {
+                        "description": "Forbidden",
+                        "schema": {
+                            "$ref": "#/definitions/models.Error"
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "$ref": "#/definitions/models.Error"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/models.Error"
+                        }
+                    }
+                }
+            }
+        },
+        "/api/v1/gear/{gear}/review/{review}/hide": {
+            "post": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "Hide a review from other users and from the gear's rating, or show it again with hidden false. The reason is shown to the author. Requires a JWT issued with the admin audience.",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Gear reviews"
+                ],
+                "summary": "Hide gear review",
+                "parameters": [
+                    {
+                        "type": "integer",
+                        "description": "Gear ID",
+                        "name": "gear",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "type": "integer",
+                        "description": "Review ID",
+                        "name": "review",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "description": "Whether to hide the review, and why",
+                        "name": "body",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/models.GearReviewHide"
+                        }
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/models.GearReview"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/models.Error"
+                        }
+                    },
+                    "403": {
+                        "description": "Forbidden",
+                        "schema": {
+                            "$ref": "#/definitions/models.Error"
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "$ref": "#/definitions/models.Error"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/models.Error"
+                        }
+                    }
+                }
+            }
+        },
+        "/api/v1/gear/{gear}/review/{review}/update": {
+            "post": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "Change the rating and text of your own review",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Gear reviews"
+                ],
+                "summary": "Update gear review",
+                "parameters": [
+                    {
+                        "type": "integer",
+                        "description": "Gear ID",
+                        "name": "gear",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "type": "integer",
+                        "description": "Review ID",
+                        "name": "review",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "description": "Rating and text",
+                        "name": "body",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/models.GearReviewNoID"
+                        }
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/models.GearReview"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/models.Error"
+                        }
+                    },
+                    "403": {
+                        "description": "Forbidden",
+                        "schema": {
+                            "$ref": "#/definitions/models.Error"
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "$ref": "#/definitions/models.Error"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/models.Error"
+                        }
+                    }
+                }
+            }
+        },
+        "/api/v1/gear/{gear}/revision/diff": {
+            "get": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "Get the fields that differ from one revision of a gear to another, with their values in both. Without to, the revision is compared with the newest one. Requires a JWT issued with the admin audience.",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Revisions"
+                ],
+                "summary": "Diff gear revisions",
+                "parameters": [
+                    {
+                        "type": "integer",
+                        "description": "Unique ID of gear",
+                        "name": "gear",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "type": "integer",
+                        "description": "Revision to compare from",
+                        "name": "from",
+                        "in": "query",
+                        "required": true
+                    },
+                    {
+                        "type": "integer",
+                        "description": "Revision to compare to, the newest by default",
+                        "name": "to",
+                        "in": "query"
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/models.RevisionDiff"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/models.Error"
+                        }
+                    },
+                    "403": {
+                        "description": "Forbidden",
+                        "schema": {
+                            "$ref": "#/definitions/models.Error"
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "$ref": "#/definitions/models.Error"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/models.Error"
+                        }
+                    }
+                }
+            }
+        },
+        "/api/v1/gear/{gear}/revision/list": {
+            "get": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "Get every revision of a gear, newest first. Each holds the tracked values after a create, an update or a revert: gear_category_id, gear_manufacture_id, gear_is_container, gear_name, gear_size_definition and the weight and dimensions. Requires a JWT issued with the admin audience.",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Revisions"
+                ],
+                "summary": "List gear revisions",
+                "parameters": [
+                    {
+                        "type": "integer",
+                        "description": "Unique ID of gear",
+                        "name": "gear",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "type": "array",
+                            "items": {
+                                "$ref": "#/definitions/models.CatalogRevision"
+                            }
+                        }
+                    },
+                    "default": {
+                        "description": "",
+                        "schema": {
+                            "$ref": "#/definitions/models.Error"
+                        }
+                    }
+                }
+            }
+        },
+        "/api/v1/gear/{gear}/revision/{revision}/revert": {
+            "post": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "Write the values of an earlier revision back to a gear. The revert is recorded as a new revision and the history in between is kept. The gear's category and manufacturer must still exist. Requires a JWT issued with the admin audience.",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Revisions"
+                ],
+                "summary": "Revert gear to revision",
+                "parameters": [
+                    {
+                        "type": "integer",
+                        "description": "Unique ID of gear",
+                        "name": "gear",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "type": "integer",
+                        "description": "Number of the revision to restore",
+                        "name": "revision",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/models.CatalogRevision"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/models.Error"
+                        }
+                    },
+                    "403": {
+                        "description": "Forbidden",
+                        "schema": {
+                            "$ref": "#/definitions/models.Error"
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "$ref": "#/definitions/models.Error"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/models.Error"
+                        }
+                    }
+                }
+            }
+        },
+        "/api/v1/gear/{gear}/tag/insert": {
+            "put": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "Add catalog tags to a piece of gear. Tags are lower-cased and may use letters, digits, spaces, - and _. Requires a JWT issued with the admin audience.",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Tags"
+                ],
+                "summary": "Add gear tags",
+                "parameters": [
+                    {
+                        "type": "integer",
+                        "description": "Gear ID",
+                        "name": "gear",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "description": "Tags to add",
+                        "name": "body",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/models.TagInput"
+                        }
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "type": "array",
+                            "items": {
+                                "type": "string"
+                            }
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/models.Error"
+                        }
+                    },
+                    "403": {
+                        "description": "Forbidden",
+                        "schema": {
+                            "$ref": "#/definitions/models.Error"
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "$ref": "#/definitions/models.Error"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/models.Error"
+                        }
+                    }
+                }
+            }
+        },
+        "/api/v1/gear/{gear}/tag/list": {
+            "get": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "List the catalog tags of a piece of gear",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Tags"
+                ],
+                "summary": "List gear tags",
+                "parameters": [
+                    {
+                        "type": "integer",
+                        "description": "Gear ID",
+                        "name": "gear",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "type": "array",
+                            "items": {
+                                "type": "string"
+                            }
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/models.Error"
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "$ref": "#/definitions/models.Error"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/models.Error"
+                        }
+                    }
+                }
+            }
+        },
+        "/api/v1/gear/{gear}/tag/{tag}/delete": {
+            "delete": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "Remove a catalog tag from a piece of gear. Requires a JWT issued with the admin audience.",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Tags"
+                ],
+                "summary": "Remove gear tag",
+                "parameters": [
+                    {
+                        "type": "integer",
+                        "description": "Gear ID",
+                        "name": "gear",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "type": "string",
+                        "description": "Tag",
+                        "name": "tag",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/models.Status"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/models.Error"
+                        }
+                    },
+                    "403": {
+                        "description": "Forbidden",
+                        "schema": {
+                            "$ref": "#/definitions/models.Error"
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "$ref": "#/definitions/models.Error"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/models.Error"
+                        }
+                    }
+                }
+            }
+        },
+        "/api/v1/gear/{gear}/translation/list": {
+            "get": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "Get the translations of a gear's name and size definition, one per locale. Requires a JWT issued with the admin audience.",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Translations"
+                ],
+                "summary": "List gear translations",
+                "parameters": [
+                    {
+                        "type": "integer",
+                        "description": "Unique ID of gear",
+                        "name": "gear",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "type": "array",
+                            "items": {
+                                "$ref": "#/definitions/models.Translation"
+                            }
+                        }
+                    },
+                    "default": {
+                        "description": "",
+                        "schema": {
+                            "$ref": "#/definitions/models.Error"
+                        }
+                    }
+                }
+            }
+        },
+        "/api/v1/gear/{gear}/translation/{locale}/delete": {
+            "delete": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "Remove the translation of a gear in a locale, so it falls back to the default locale. Requires a JWT issued with the admin audience.",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Translations"
+                ],
+                "summary": "Delete gear translation",
+                "parameters": [
+                    {
+                        "type": "integer",
+                        "description": "Unique ID of gear",
+                        "name": "gear",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "type": "string",
+                        "description": "Language tag such as nb",
+                        "name": "locale",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/models.Status"
+                        }
+                    },
+                    "default": {
+                        "description": "",
+                        "schema": {
+                            "$ref": "#/definitions/models.Error"
+                        }
+                    }
+                }
+            }
+        },
+        "/api/v1/gear/{gear}/translation/{locale}/update": {
+            "post": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "Set the name and size definition of a gear in a locale, replacing an earlier translation. Fields are gear_name and gear_size_definition; a field left out falls back to the default locale. Requires a JWT issued with the admin audience.",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Translations"
+                ],
+                "summary": "Update gear translation",
+                "parameters": [
+                    {
+                        "type": "integer",
+                        "description": "Unique ID of gear",
+                        "name": "gear",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "type": "string",
+                        "description": "Language tag such as nb",
+                        "name": "locale",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "description": "Translated fields",
+                        "name": "request",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/models.TranslationUpdate"
+                        }
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/models.Translation"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/models.Error"
+                        }
+                    },
+                    "403": {
+                        "description": "Forbidden",
+                        "schema": {
+                            "$ref": "#/definitions/models.Error"
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "$ref": "#/definitions/models.Error"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/models.Error"
+                        }
+                    }
+                }
+            }
+        },
+        "/api/v1/gear/{gear}/update": {
+            "post": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "Update gear identified by ID. Weights are in grams and dimensions in millimetres, or given as {\"value\": 2.1, \"unit\": \"kg\"}. Non-admins can only edit their own submissions before approval, which sends them back for review. gear_status is kept unless an admin sets it.",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Gear"
+                ],
+                "summary": "Update gear with ID",
+                "parameters": [
+                    {
+                        "type": "integer",
+                        "description": "Unique ID of Gear you want to get",
+                        "name": "gear",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "description": "query params",
+                        "name": "request",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/models.Gear"
+                        }
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "status: success when all goes well",
+                        "schema": {
+                            "$ref": "#/definitions/models.Status"
+                        }
+                    },
+                    "default": {
+                        "description": "",
+                        "schema": {
+                            "$ref": "#/definitions/models.Error"
+                        }
+                    }
+                }
+            }
+        },
+        "/api/v1/gear/{gear}/variant/insert": {
+            "put": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "Add a size variant to a piece of gear. Variant names are unique per gear. Weights are in grams and dimensions in millimetres, or given as {\"value\": 2.1, \"unit\": \"kg\"}. Requires a JWT issued with the admin audience.",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Gear"
+                ],
+                "summary": "Insert gear variant",
+                "parameters": [
+                    {
+                        "type": "integer",
+                        "description": "Gear ID",
+                        "name": "gear",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "description": "Variant data",
+                        "name": "body",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/models.GearVariantNoID"
+                        }
+                    }
+                ],
+                "responses": {
+                    "201": {
+                        "description": "Created",
+                        "schema": {
+                            "$ref": "#/definitions/models.GearVariant"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/models.Error"
+                        }
+                    },
+                    "403": {
+                        "description": "Forbidden",
+                        "schema": {
+                            "$ref": "#/definitions/models.Error"
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "$ref": "#/definitions/models.Error"
+                        }
+                    },
+                    "409": {
+                        "description": "Conflict",
+                        "schema": {
+                            "$ref": "#/definitions/models.Error"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/models.Error"
+                        }
+                    }
+                }
+            }
+        },
+        "/api/v1/gear/{gear}/variant/list": {
+            "get": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "List the size variants of a piece of gear, lightest first",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Gear"
+                ],
+                "summary": "List gear variants",
+                "parameters": [
+                    {
+                        "type": "integer",
+                        "description": "Gear ID",
+                        "name": "gear",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "type": "string",
+                        "description": "Also give measurements in metric or imperial units, overriding the user's preference",
+                        "name": "units",
+                        "in": "query"
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "type": "array",
+                            "items": {
+                                "$ref": "#/definitions/models.GearVariant"
+                            }
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/models.Error"
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "$ref": "#/definitions/models.Error"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/models.Error"
+                        }
+                    }
+                }
+            }
+        },
+        "/api/v1/gear/{gear}/variant/{variant}/delete": {
+            "delete": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "Remove a size variant of a piece of gear. Registrations and loadout items using it fall back to the gear itself, and loadouts are reweighed. Requires a JWT issued with the admin audience.",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Gear"
+                ],
+                "summary": "Delete gear variant",
+                "parameters": [
+                    {
+                        "type": "integer",
+                        "description": "Gear ID",
+                        "name": "gear",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "type": "integer",
+                        "description": "Variant ID",
+                        "name": "variant",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/models.Status"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/models.Error"
+                        }
+                    },
+                    "403": {
+                        "description": "Forbidden",
+                        "schema": {
+                            "$ref": "#/definitions/models.Error"
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "$ref": "#/definitions/models.Error"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/models.Error"
+                        }
+                    }
+                }
+            }
+        },
+        "/api/v1/gear/{gear}/variant/{variant}/get": {
+            "get": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "Get one size variant of a piece of gear",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Gear"
+                ],
+                "summary": "Get gear variant",
+                "parameters": [
+                    {
+                        "type": "integer",
+                        "description": "Gear ID",
+                        "name": "gear",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "type": "integer",
+                        "description": "Variant ID",
+                        "name": "variant",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "type": "string",
+                        "description": "Also give measurements in metric or imperial units, overriding the user's preference",
+                        "name": "units",
+                        "in": "query"
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/models.GearVariant"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/models.Error"
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "$ref": "#/definitions/models.Error"
+                        }
+                    }
+                }
+            }
+        },
+        "/api/v1/gear/{gear}/variant/{variant}/update": {
+            "post": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "Update a size variant of a piece of gear. Loadouts using the variant are reweighed. Requires a JWT issued with the admin audience.",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Gear"
+                ],
+                "summary": "Update gear variant",
+                "parameters": [
+                    {
+                        "type": "integer",
+                        "description": "Gear ID",
+                        "name": "gear",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "type": "integer",
+                        "description": "Variant ID",
+                        "name": "variant",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "description": "Variant data",
+                        "name": "body",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/models.GearVariantNoID"
+                        }
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/models.Status"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/models.Error"
+                        }
+                    },
+                    "403": {
+                        "description": "Forbidden",
+                        "schema": {
+                            "$ref": "#/definitions/models.Error"
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "$ref": "#/definitions/models.Error"
+                        }
+                    },
+                    "409": {
+                        "description": "Conflict",
+                        "schema": {
+                            "$ref": "#/definitions/models.Error"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/models.Error"
+                        }
+                    }
+                }
+            }
+        },
+        "/api/v1/loadout/insert": {
+            "put": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "Create a new loadout for the authenticated user",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Loadouts"
+                ],
+                "summary": "Insert loadout",
+                "parameters": [
+                    {
+                        "description": "Loadout data",
+                        "name": "request",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/models.LoadoutNoID"
+                        }
+                    }
+                ],
+                "responses": {
+                    "201": {
+                        "description": "Created",
+                        "schema": {
+                            "$ref": "#/definitions/models.Loadout"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/models.Error"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/models.Error"
+                        }
+                    }
+                }
+            }
+        },
+        "/api/v1/loadout/list": {
+            "get": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "Get the loadouts of the authenticated user, sorted by name unless another field is given",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Loadouts"
+                ],
+                "summary": "List loadouts",
+                "parameters": [
+                    {
+                        "type": "integer",
+                        "default": 1,
+                        "description": "Page number",
+                        "name": "page",
+                        "in": "query"
+                    },
+                    {
+                        "type": "integer",
+                        "default": 30,
+                        "description": "Number of items per page",
+                        "name": "limit",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "default": "loadout_name",
+                        "description": "Field to sort by, json or db field name",
+                        "name": "sort",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "default": "asc",
+                        "description": "Sort direction, asc or desc",
+                        "name": "order",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "Opaque cursor from next_cursor or prev_cursor, replaces page",
+                        "name": "cursor",
+                        "in": "query"
+                    },
+                    {
+                        "type": "boolean",
+                        "default": true,
+                        "description": "Include total item count",
+                        "name": "count",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "Also give total_weight in metric or imperial units, overriding the user's preference",
+                        "name": "units",
+                        "in": "query"
+                    },
+                    {
+                        "type": "array",
+                        "items": {
+                            "type": "string"
+                        },
+                        "collectionFormat": "multi",
+                        "description": "Loadout tags",
+                        "name": "tag",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "default": "any",
+                        "description": "Match loadouts with any or all of the tags",
+                        "name": "tag_match",
+                        "in": "query"
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "allOf": [
+                                {
+                                    "$ref": "#/definitions/models.ResponsePayload"
+                                },
+                                {
+                                    "type": "object",
+                                    "properties": {
+                                        "items": {
+                                            "type": "array",
+                                            "items": {
+                                                "$ref": "#/definitions/models.Loadout"
+                                            }
+                                        }
+                                    }
+                                }
+                            ]
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/models.Error"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/models.Error"
+                        }
+                    }
+                }
+            }
+        },
+        "/api/v1/loadout/{loadout}/alternative/list": {
+            "get": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "For each item in a loadout, suggest catalog gear in the same category that is lighter than the item, biggest saving first. Each suggestion has the saving for one of the item and for its whole quantity, and the loadout's total weight after swapping it in. Gear without a known weight is not suggested.",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Loadouts"
+                ],
+                "summary": "List lighter alternatives for loadout items",
+                "parameters": [
+                    {
+                        "type": "integer",
+                        "description": "Loadout ID",
+                        "name": "loadout",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "maximum": 20,
+                        "type": "integer",
+                        "default": 5,
+                        "description": "Maximum number of suggestions per item",
+                        "name": "limit",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "Also give measurements in metric or imperial units, overriding the user's preference",
+                        "name": "units",
+                        "in": "query"
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "type": "array",
+                            "items": {
+                                "$ref": "#/definitions/models.LoadoutItemAlternatives"
+                            }
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/models.Error"
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "$ref": "#/definitions/models.Error"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/models.Error"
+                        }
+                    }
+                }
+            }
+        },
+        "/api/v1/loadout/{loadout}/delete": {
+            "delete": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "Delete a loadout by ID",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Loadouts"
+                ],
+                "summary": "Delete loadout",
+                "parameters": [
+                    {
+                        "type": "integer",
+                        "description": "Loadout ID",
+                        "name": "loadout",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/models.Status"
+                        }
+                    },
+                    "403": {
+                        "description": "Forbidden",
+                        "schema": {
+                            "$ref": "#/definitions/models.Error"
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "$ref": "#/definitions/models.Error"
+                        }
+                    }
+                }
+            }
+        },
+        "/api/v1/loadout/{loadout}/get": {
+            "get": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "Get a single loadout by ID",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Loadouts"
+                ],
+                "summary": "Get loadout",
+                "parameters": [
+                    {
+                        "type": "integer",
+                        "description": "Loadout ID",
+                        "name": "loadout",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "type": "string",
+                        "description": "Also give total_weight in metric or imperial units, overriding the user's preference",
+                        "name": "units",
+                        "in": "query"
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/models.Loadout"
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "$ref": "#/definitions/models.Error"
+                        }
+                    }
+                }
+            }
+        },
+        "/api/v1/loadout/{loadout}/import": {
+            "post": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "Import gear items into a loadout",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Loadouts"
+                ],
+                "summary": "Import gear into loadout",
+                "parameters": [
+                    {
+                        "type": "integer",
+                        "description": "Loadout ID",
+                        "name": "loadout",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "description": "Gear IDs to import",
+                        "name": "request",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": {
+                                "type": "array",
+                                "items": {
+                                    "type": "integer",
+                                    "format": "int64"
+                                }
+                            }
+                        }
+                    }
+                ],
+                "responses": {
+                    "201": {
+                        "description": "Created",
+                        "schema": {
+                            "$ref": "#/definitions/models.Status"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/models.Error"
+                        }
+                    },
+                    "403": {
+                        "description": "Forbidden",
+                        "schema": {
+                            "$ref": "#/definitions/models.Error"
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "$ref": "#/definitions/models.Error"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/models.Error"
+                        }
+                    }
+                }
+            }
+        },
+        "/api/v1/loadout/{loadout}/item/insert": {
+            "put": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "Add gear to a loadout",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Loadouts"
+                ],
+                "summary": "Insert loadout item",
+                "parameters": [
+                    {
+                        "type": "integer",
+                        "description": "Loadout ID",
+                        "name": "loadout",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "description": "Item data",
+                        "name": "body",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/models.LoadoutItemNoID"
+                        }
+                    }
+                ],
+                "responses": {
+                    "201": {
+                        "description": "Created",
+                        "schema": {
+                            "$ref": "#/definitions/models.LoadoutItem"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/models.Error"
+                        }
+                    },
+                    "403": {
+                        "description": "Forbidden",
+                        "schema": {
+                            "$ref": "#/definitions/models.Error"
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "$ref": "#/definitions/models.Error"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/models.Error"
+                        }
+                    }
+                }
+            }
+        },
+        "/api/v1/loadout/{loadout}/item/list": {
+            "get": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "List the items in a loadout, in the order they were added unless another field is given",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Loadouts"
+                ],
+                "summary": "List loadout items",
+                "parameters": [
+                    {
+                        "type": "integer",
+                        "description": "Loadout ID",
+                        "name": "loadout",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "type": "integer",
+                        "default": 1,
+                        "description": "Page number",
+                        "name": "page",
+                        "in": "query"
+                    },
+                    {
+                        "type": "integer",
+                        "default": 30,
+                        "description": "Number of items per page",
+                        "name": "limit",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "default": "loadout_item_id",
+                        "description": "Field to sort by, json or db field name",
+                        "name": "sort",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "default": "asc",
+                        "description": "Sort direction, asc or desc",
+                        "name": "order",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "Opaque cursor from next_cursor or prev_cursor, replaces page",
+                        "name": "cursor",
+                        "in": "query"
+                    },
+                    {
+                        "type": "boolean",
+                        "default": true,
+                        "description": "Include total item count",
+                        "name": "count",
+                        "in": "query"
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "allOf": [
+                                {
+                                    "$ref": "#/definitions/models.ResponsePayload"
+                                },
+                                {
+                                    "type": "object",
+                                    "properties": {
+                                        "items": {
+                                            "type": "array",
+                                            "items": {
+                                                "$ref": "#/definitions/models.LoadoutItem"
+                                            }
+                                        }
+                                    }
+                                }
+                            ]
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/models.Error"
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "$ref": "#/definitions/models.Error"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/models.Error"
+                        }
+                    }
+                }
+            }
+        },
+        "/api/v1/loadout/{loadout}/item/{item}/delete": {
+            "delete": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "Remove an item from a loadout",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Loadouts"
+                ],
+                "summary": "Delete loadout item",
+                "parameters": [
+                    {
+                        "type": "integer",
+                        "description": "Loadout ID",
+                        "name": "loadout",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "type": "integer",
+                        "description": "Item ID",
+                        "name": "item",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/models.Status"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/models.Error"
+                        }
+                    },
+                    "403": {
+                        "description": "Forbidden",
+                        "schema": {
+                            "$ref": "#/definitions/models.Error"
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "$ref": "#/definitions/models.Error"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/models.Error"
+                        }
+                    }
+                }
+            }
+        },
+        "/api/v1/loadout/{loadout}/item/{item}/swap": {
+            "post": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "Replace the gear of a loadout item, keeping its quantity and notes, and recalculate the loadout's total weight. variant_id picks a variant of the new gear; leaving it out uses the gear itself.",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Loadouts"
+                ],
+                "summary": "Swap loadout item",
+                "parameters": [
+                    {
+                        "type": "integer",
+                        "description": "Loadout ID",
+                        "name": "loadout",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "type": "integer",
+                        "description": "Item ID",
+                        "name": "item",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "description": "Gear to swap in",
+                        "name": "body",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/models.LoadoutItemSwap"
+                        }
+                    },
+                    {
+                        "type": "string",
+                        "description": "Also give measurements in metric or imperial units, overriding the user's preference",
+                        "name": "units",
+                        "in": "query"
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/models.LoadoutItemSwapResult"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/models.Error"
+                        }
+                    },
+                    "403": {
+                        "description": "Forbidden",
+                        "schema": {
+                            "$ref": "#/definitions/models.Error"
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "$ref": "#/definitions/models.Error"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/models.Error"
+                        }
+                    }
+                }
+            }
+        },
+        "/api/v1/loadout/{loadout}/item/{item}/update": {
+            "post": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "Update a loadout item. Leaving out variant_id keeps the current variant; null clears it.",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Loadouts"
+                ],
+                "summary": "Update loadout item",
+                "parameters": [
+                    {
+                        "type": "integer",
+                        "description": "Loadout ID",
+                        "name": "loadout",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "type": "integer",
+                        "description": "Item ID",
+                        "name": "item",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "description": "Update data",
+                        "name": "body",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/models.LoadoutItemUpdate"
+                        }
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/models.Status"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/models.Error"
+                        }
+                    },
+                    "403": {
+                        "description": "Forbidden",
+                        "schema": {
+                            "$ref": "#/definitions/models.Error"
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "$ref": "#/definitions/models.Error"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/models.Error"
+                        }
+                    }
+                }
+            }
+        },
+        "/api/v1/loadout/{loadout}/tag/insert": {
+            "put": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "Add private tags to one of your loadouts. Tags are lower-cased and may use letters, digits, spaces, - and _.",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Tags"
+                ],
+                "summary": "Add loadout tags",
+                "parameters": [
+                    {
+                        "type": "integer",
+                        "description": "Loadout ID",
+                        "name": "loadout",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "description": "Tags to add",
+                        "name": "body",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/models.TagInput"
+                        }
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "type": "array",
+                            "items": {
+                                "type": "string"
+                            }
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/models.Error"
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "$ref": "#/definitions/models.Error"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/models.Error"
+                        }
+                    }
+                }
+            }
+        },
+        "/api/v1/loadout/{loadout}/tag/list": {
+            "get": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "List the private tags of one of your loadouts",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Tags"
+                ],
+                "summary": "List loadout tags",
+                "parameters": [
+                    {
+                        "type": "integer",
+                        "description": "Loadout ID",
+                        "name": "loadout",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "type": "array",
+                            "items": {
+                                "type": "string"
+                            }
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/models.Error"
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "$ref": "#/definitions/models.Error"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/models.Error"
+                        }
+                    }
+                }
+            }
+        },
+        "/api/v1/loadout/{loadout}/tag/{tag}/delete": {
+            "delete": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "Remove a private tag from one of your loadouts",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Tags"
+                ],
+                "summary": "Remove loadout tag",
+                "parameters": [
+                    {
+                        "type": "integer",
+                        "description": "Loadout ID",
+                        "name": "loadout",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "type": "string",
+                        "description": "Tag",
+                        "name": "tag",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/models.Status"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/models.Error"
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "$ref": "#/definitions/models.Error"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/models.Error"
+                        }
+                    }
+                }
+            }
+        },
+        "/api/v1/loadout/{loadout}/update": {
+            "post": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "Update an existing loadout",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Loadouts"
+                ],
+                "summary": "Update loadout",
+                "parameters": [
+                    {
+                        "type": "integer",
+                        "description": "Loadout ID",
+                        "name": "loadout",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "description": "Updated loadout data",
+                        "name": "request",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/models.LoadoutUpdate"
+                        }
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/models.Status"
+                        }
+                    },
+                    "403": {
+                        "description": "Forbidden",
+                        "schema": {
+                            "$ref": "#/definitions/models.Error"
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "$ref": "#/definitions/models.Error"
+                        }
+                    }
+                }
+            }
+        },
+        "/api/v1/manufacture/insert": {
+            "put": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "Insert new manufacture with corresponding values. manufacture_website and manufacture_logo_url are http(s) URLs and manufacture_country an ISO 3166-1 alpha-2 code.",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Manufacture"
+                ],
+                "summary": "Insert new manufacture",
+                "parameters": [
+                    {
+                        "description": "query params",
+                        "name": "request",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/models.Manufacture"
+                        }
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "status: success when all goes well",
+                        "schema": {
+                            "$ref": "#/definitions/models.Manufacture"
+                        }
+                    },
+                    "default": {
+                        "description": "",
+                        "schema": {
+                            "$ref": "#/definitions/models.Error"
+                        }
+                    }
+                }
+            }
+        },
+        "/api/v1/manufacture/list": {
+            "get": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "Get a list of manufacturers with their catalog stats. Besides the profile fields, the list can be sorted by gear_count, owner_count, lightest_weight and heaviest_weight.",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Manufacture"
+                ],
+                "summary": "List manufacture",
+                "parameters": [
+                    {
+                        "type": "integer",
+                        "default": 1,
+                        "description": "Page number",
+                        "name": "page",
+                        "in": "query"
+                    },
+                    {
+                        "type": "integer",
+                        "default": 30,
+                        "description": "Number of items per page",
+                        "name": "limit",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "Field to sort by, json or db field name",
+                        "name": "sort",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "default": "asc",
+                        "description": "Sort direction, asc or desc",
+                        "name": "order",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "Opaque cursor from next_cursor or prev_cursor, replaces page",
+                        "name": "cursor",
+                        "in": "query"
+                    },
+                    {
+                        "type": "boolean",
+                        "default": true,
+                        "description": "Include total item count",
+                        "name": "count",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "search by manufacturename (this is case insensitive and wildcard)",
+                        "name": "manufacture",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "search by manufactures full name (this is case insensitive and wildcard)",
+                        "name": "manufacturename",
+                        "in": "query"
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "allOf": [
+                                {
+                                    "$ref": "#/definitions/models.ResponsePayload"
+                                },
+                                {
+                                    "type": "object",
+                                    "properties": {
+                                        "items": {
+                                            "type": "array",
+                                            "items": {
+                                                "$ref": "#/definitions/models.ManufactureListItem"
+                                            }
+                                        }
+                                    }
+                                }
+                            ]
+                        }
+                    },
+                    "default": {
+                        "description": "",
+                        "schema": {
+                            "$ref": "#/definitions/models.Error"
+                        }
+                    }
+                }
+            }
+        },
+        "/api/v1/manufacture/{manufacture}/delete": {
+            "delete": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "Delete manufacture with corresponding ID value. Its gear must be moved first, or moved to reassign_to as part of the delete; otherwise the delete is refused with 409 and the gear count.",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Manufacture"
+                ],
+                "summary": "Delete manufacture with ID",
+                "parameters": [
+                    {
+                        "type": "integer",
+                        "description": "Unique ID of manufacture you want to update",
+                        "name": "manufacture",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "type": "integer",
+                        "description": "Manufacture to move the gear to before deleting",
+                        "name": "reassign_to",
+                        "in": "query"
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "status: success when all goes well",
+                        "schema": {
+                            "$ref": "#/definitions/models.Status"
+                        }
+                    },
+                    "409": {
+                        "description": "the manufacture is still in use",
+                        "schema": {
+                            "$ref": "#/definitions/models.DependentsError"
+                        }
+                    },
+                    "default": {
+                        "description": "",
+                        "schema": {
+                            "$ref": "#/definitions/models.Error"
+                        }
+                    }
+                }
+            }
+        },
+        "/api/v1/manufacture/{manufacture}/details": {
+            "get": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "Get a manufacturer profile with stats aggregated from the catalog: gear count per top category, the lightest and heaviest gear, and how many users own its gear",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Manufacture"
+                ],
+                "summary": "Get manufacture details",
+                "parameters": [
+                    {
+                        "type": "integer",
+                        "description": "Unique ID of manufacture you want to get",
+                        "name": "manufacture",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/models.ManufactureDetail"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/models.Error"
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "$ref": "#/definitions/models.Error"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/models.Error"
+                        }
+                    }
+                }
+            }
+        },
+        "/api/v1/manufacture/{manufacture}/get": {
+            "get": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "Get manufacture spessific to ID",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Manufacture"
+                ],
+                "summary": "Get manufacture by ID",
+                "parameters": [
+                    {
+                        "type": "integer",
+                        "description": "Unique ID of manufacture you want to get",
+                        "name": "manufacture",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "desc",
+                        "schema": {
+                            "$ref": "#/definitions/models.Manufacture"
+                        }
+                    },
+                    "default": {
+                        "description": "",
+                        "schema": {
+                            "$ref": "#/definitions/models.Error"
+                        }
+                    }
+                }
+            }
+        },
+        "/api/v1/manufacture/{manufacture}/revision/diff": {
+            "get": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "Get the fields that differ from one revision of a manufacturer to another, with their values in both. Without to, the revision is compared with the newest one. Requires a JWT issued with the admin audience.",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Revisions"
+                ],
+                "summary": "Diff manufacturer revisions",
+                "parameters": [
+                    {
+                        "type": "integer",
+                        "description": "Unique ID of manufacturer",
+                        "name": "manufacture",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "type": "integer",
+                        "description": "Revision to compare from",
+                        "name": "from",
+                        "in": "query",
+                        "required": true
+                    },
+                    {
+                        "type": "integer",
+                        "description": "Revision to compare to, the newest by default",
+                        "name": "to",
+                        "in": "query"
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/models.RevisionDiff"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/models.Error"
+                        }
+                    },
+                    "403": {
+                        "description": "Forbidden",
+                        "schema": {
+                            "$ref": "#/definitions/models.Error"
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "$ref": "#/definitions/models.Error"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/models.Error"
+                        }
+                    }
+                }
+            }
+        },
+        "/api/v1/manufacture/{manufacture}/revision/list": {
+            "get": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "Get every revision of a manufacturer, newest first. Each holds the tracked values after a create, an update or a revert: the manufacturer's name and profile fields. Requires a JWT issued with the admin audience.",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Revisions"
+                ],
+                "summary": "List manufacturer revisions",
+                "parameters": [
+                    {
+                        "type": "integer",
+                        "description": "Unique ID of manufacturer",
+                        "name": "manufacture",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "type": "array",
+                            "items": {
+                                "$ref": "#/definitions/models.CatalogRevision"
+                            }
+                        }
+                    },
+                    "default": {
+                        "description": "",
+                        "schema": {
+                            "$ref": "#/definitions/models.Error"
+                        }
+                    }
+                }
+            }
+        },
+        "/api/v1/manufacture/{manufacture}/revision/{revision}/revert": {
+            "post": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "Write the values of an earlier revision back to a manufacturer. The revert is recorded as a new revision and the history in between is kept. Requires a JWT issued with the admin audience.",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Revisions"
+                ],
+                "summary": "Revert manufacturer to revision",
+                "parameters": [
+                    {
+                        "type": "integer",
+                        "description": "Unique ID of manufacturer",
+                        "name": "manufacture",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "type": "integer",
+                        "description": "Number of the revision to restore",
+                        "name": "revision",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/models.CatalogRevision"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/models.Error"
+                        }
+                    },
+                    "403": {
+                        "description": "Forbidden",
+                        "schema": {
+                            "$ref": "#/definitions/models.Error"
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "$ref": "#/definitions/models.Error"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/models.Error"
+                        }
+                    }
+                }
+            }
+        },
+        "/api/v1/manufacture/{manufacture}/update": {
+            "post": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "Update manufacture identified by ID. Fields left out keep their value. manufacture_website and manufacture_logo_url are http(s) URLs and manufacture_country an ISO 3166-1 alpha-2 code.",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Manufacture"
+                ],
+                "summary": "Update manufacture with ID",
+                "parameters": [
+                    {
+                        "type": "integer",
+                        "description": "Unique ID of manufacture you want to update",
+                        "name": "manufacture",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "description": "query params",
+                        "name": "request",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/models.Manufacture"
+                        }
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "status: success when all goes well",
+                        "schema": {
+                            "$ref": "#/definitions/models.Status"
+                        }
+                    },
+                    "default": {
+                        "description": "",
+                        "schema": {
+                            "$ref": "#/definitions/models.Error"
+                        }
+                    }
+                }
+            }
+        },
+        "/api/v1/public/loadout/{slug}": {
+            "get": {
+                "description": "Get a public loadout by slug. Only returns loadouts with loadout_is_public=true. No authentication required.",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Loadouts"
+                ],
+                "summary": "Get public loadout",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Loadout slug",
+                        "name": "slug",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "type": "string",
+                        "description": "Also give total_weight in metric or imperial units",
+                        "name": "units",
+                        "in": "query"
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/models.LoadoutPublic"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/models.Error"
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "$ref": "#/definitions/models.Error"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/models.Error"
+                        }
+                    }
+                }
+            }
+        },
+        "/api/v1/public/loadout/{slug}/items": {
+            "get": {
+                "description": "List items for a public loadout by slug. No authentication required.",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Loadouts"
+                ],
+                "summary": "List public loadout items",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Loadout slug",
+                        "name": "slug",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "type": "array",
+                            "items": {
+                                "$ref": "#/definitions/models.LoadoutItem"
+                            }
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/models.Error"
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "$ref": "#/definitions/models.Error"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/models.Error"
+                        }
+                    }
+                }
+            }
+        },
+        "/api/v1/tag/suggest": {
+            "get": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "Autocomplete a tag prefix from the catalog gear tags and your own registration and loadout tags, most used first",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Tags"
+                ],
+                "summary": "Suggest tags",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Tag prefix",
+                        "name": "q",
+                        "in": "query"
+                    },
+                    {
+                        "type": "integer",
+                        "default": 8,
+                        "description": "Maximum number of suggestions",
+                        "name": "limit",
+                        "in": "query"
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "type": "array",
+                            "items": {
+                                "$ref": "#/definitions/models.TagSuggestion"
+                            }
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/models.Error"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/models.Error"
+                        }
+                    }
+                }
+            }
+        },
+        "/api/v1/topCategory/insert": {
+            "put": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "Insert new top category with corresponding values",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Top Category"
+                ],
+                "summary": "Insert new top category",
+                "parameters": [
+                    {
+                        "description": "Request body",
+                        "name": "request",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/models.GearTopCategory"
+                        }
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "status: success when all goes well",
+                        "schema": {
+                            "$ref": "#/definitions/models.Status"
+                        }
+                    },
+                    "default": {
+                        "description": "",
+                        "schema": {
+                            "$ref": "#/definitions/models.Error"
+                        }
+                    }
+                }
+            }
+        },
+        "/api/v1/topCategory/list": {
+            "get": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "Get a list of top category items",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Top Category"
+                ],
+                "summary": "List top categories",
+                "parameters": [
+                    {
+                        "type": "integer",
+                        "default": 1,
+                        "description": "Page number",
+                        "name": "page",
+                        "in": "query"
+                    },
+                    {
+                        "type": "integer",
+                        "default": 30,
+                        "description": "Number of items per page",
+                        "name": "limit",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "Field to sort by, json or db field name",
+                        "name": "sort",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "default": "asc",
+                        "description": "Sort direction, asc or desc",
+                        "name": "order",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "Opaque cursor from next_cursor or prev_cursor, replaces page",
+                        "name": "cursor",
+                        "in": "query"
+                    },
+                    {
+                        "type": "boolean",
+                        "default": true,
+                        "description": "Include total item count",
+                        "name": "count",
+                        "in": "query"
+                    },
+                    {
+                        "type": "array",
+                        "items": {
+                            "type": "integer"
+                        },
+                        "collectionFormat": "multi",
+                        "description": "top categories",
+                        "name": "topCategory",
+                        "in": "query"
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "allOf": [
+                                {
+                                    "$ref": "#/definitions/models.ResponsePayload"
+                                },
+                                {
+                                    "type": "object",
+                                    "properties": {
+                                        "items": {
+                                            "type": "array",
+                                            "items": {
+                                                "$ref": "#/definitions/models.GearTopCategory"
+                                            }
+                                        }
+                                    }
+                                }
+                            ]
+                        }
+                    },
+                    "default": {
+                        "description": "",
+                        "schema": {
+                            "$ref": "#/definitions/models.Error"
+                        }
+                    }
+                }
+            }
+        },
+        "/api/v1/topCategory/{topCategory}/delete": {
+            "delete": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "Delete topCategory with corresponding ID value. Its categories and gear must be moved first, or moved to reassign_to as part of the delete; otherwise the delete is refused with 409 and their counts. Moved categories keep their subtrees.",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Top Category"
+                ],
+                "summary": "Delete topCategory with ID",
+                "parameters": [
+                    {
+                        "type": "integer",
+                        "description": "Unique ID of topCategory you want to update",
+                        "name": "topCategory",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "type": "integer",
+                        "description": "Top category to move the categories and gear to before deleting",
+                        "name": "reassign_to",
+                        "in": "query"
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "status: success when all goes well",
+                        "schema": {
+                            "$ref": "#/definitions/models.Status"
+                        }
+                    },
+                    "409": {
+                        "description": "the top category is still in use",
+                        "schema": {
+                            "$ref": "#/definitions/models.DependentsError"
+                        }
+                    },
+                    "default": {
+                        "description": "",
+                        "schema": {
+                            "$ref": "#/definitions/models.Error"
+                        }
+                    }
+                }
+            }
+        },
+        "/api/v1/topCategory/{topCategory}/get": {
+            "get": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "Get top category spessific to ID",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Top Category"
+                ],
+                "summary": "Get top category with ID",
+                "parameters": [
+                    {
+                        "type": "integer",
+                        "description": "Unique ID of top category you want to get",
+                        "name": "topCategoryID",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/models.GearTopCategory"
+                        }
+                    },
+                    "default": {
+                        "description": "",
+                        "schema": {
+                            "$ref": "#/definitions/models.Error"
+                        }
+                    }
+                }
+            }
+        },
+        "/api/v1/topCategory/{topCategory}/revision/diff": {
+            "get": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "Get the fields that differ from one revision of a top category to another, with their values in both. Without to, the revision is compared with the newest one. Requires a JWT issued with the admin audience.",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Revisions"
+                ],
+                "summary": "Diff top category revisions",
+                "parameters": [
+                    {
+                        "type": "integer",
+                        "description": "Unique ID of top category",
+                        "name": "topCategory",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "type": "integer",
+                        "description": "Revision to compare from",
+                        "name": "from",
+                        "in": "query",
+                        "required": true
+                    },
+                    {
+                        "type": "integer",
+                        "description": "Revision to compare to, the newest by default",
+                        "name": "to",
+                        "in": "query"
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/models.RevisionDiff"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/models.Error"
+                        }
+                    },
+                    "403": {
+                        "description": "Forbidden",
+                        "schema": {
+                            "$ref": "#/definitions/models.Error"
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "$ref": "#/definitions/models.Error"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/models.Error"
+                        }
+                    }
+                }
+            }
+        },
+        "/api/v1/topCategory/{topCategory}/revision/list": {
+            "get": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "Get every revision of a top category, newest first. Each holds the tracked values after a create, an update or a revert: top_category_name and top_category_icon. Requires a JWT issued with the admin audience.",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Revisions"
+                ],
+                "summary": "List top category revisions",
+                "parameters": [
+                    {
+                        "type": "integer",
+                        "description": "Unique ID of top category",
+                        "name": "topCategory",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "type": "array",
+                            "items": {
+                                "$ref": "#/definitions/models.CatalogRevision"
+                            }
+                        }
+                    },
+                    "default": {
+                        "description": "",
+                        "schema": {
+                            "$ref": "#/definitions/models.Error"
+                        }
+                    }
+                }
+            }
+        },
+        "/api/v1/topCategory/{topCategory}/revision/{revision}/revert": {
+            "post": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "Write the values of an earlier revision back to a top category. The revert is recorded as a new revision and the history in between is kept. Requires a JWT issued with the admin audience.",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Revisions"
+                ],
+                "summary": "Revert top category to revision",
+                "parameters": [
+                    {
+                        "type": "integer",
+                        "description": "Unique ID of top category",
+                        "name": "topCategory",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "type": "integer",
+                        "description": "Number of the revision to restore",
+                        "name": "revision",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/models.CatalogRevision"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/models.Error"
+                        }
+                    },
+                    "403": {
+                        "description": "Forbidden",
+                        "schema": {
+                            "$ref": "#/definitions/models.Error"
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "$ref": "#/definitions/models.Error"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/models.Error"
+                        }
+                    }
+                }
+            }
+        },
+        "/api/v1/topCategory/{topCategory}/translation/list": {
+            "get": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "Get the translations of a top category's name, one per locale. Requires a JWT issued with the admin audience.",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Translations"
+                ],
+                "summary": "List top category translations",
+                "parameters": [
+                    {
+                        "type": "integer",
+                        "description": "Unique ID of top category",
+                        "name": "topCategory",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "type": "array",
+                            "items": {
+                                "$ref": "#/definitions/models.Translation"
+                            }
+                        }
+                    },
+                    "default": {
+                        "description": "",
+                        "schema": {
+                            "$ref": "#/definitions/models.Error"
+                        }
+                    }
+                }
+            }
+        },
+        "/api/v1/topCategory/{topCategory}/translation/{locale}/delete": {
+            "delete": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "Remove the translation of a top category in a locale, so it falls back to the default locale. Requires a JWT issued with the admin audience.",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Translations"
+                ],
+                "summary": "Delete top category translation",
+                "parameters": [
+                    {
+                        "type": "integer",
+                        "description": "Unique ID of top category",
+                        "name": "topCategory",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "type": "string",
+                        "description": "Language tag such as nb",
+                        "name": "locale",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/models.Status"
+                        }
+                    },
+                    "default": {
+                        "description": "",
+                        "schema": {
+                            "$ref": "#/definitions/models.Error"
+                        }
+                    }
+                }
+            }
+        },
+        "/api/v1/topCategory/{topCategory}/translation/{locale}/update": {
+            "post": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "Set the name of a top category in a locale, replacing an earlier translation. The only field is top_category_name, which is required. Requires a JWT issued with the admin audience.",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Translations"
+                ],
+                "summary": "Update top category translation",
+                "parameters": [
+                    {
+                        "type": "integer",
+                        "description": "Unique ID of top category",
+                        "name": "topCategory",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "type": "string",
+                        "description": "Language tag such as nb",
+                        "name": "locale",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "description": "Translated fields",
+                        "name": "request",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/models.TranslationUpdate"
+                        }
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/models.Translation"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/models.Error"
+                        }
+                    },
+                    "403": {
+                        "description": "Forbidden",
+                        "schema": {
+                            "$ref": "#/definitions/models.Error"
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "$ref": "#/definitions/models.Error"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/models.Error"
+                        }
+                    }
+                }
+            }
+        },
+        "/api/v1/topCategory/{topCategory}/update": {
+            "post": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "Update top category identified by ID",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Top Category"
+                ],
+                "summary": "Update top category with ID",
+                "parameters": [
+                    {
+                        "type": "integer",
+                        "description": "Unique ID of top category you want to update",
+                        "name": "topCategoryID",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "description": "Request body",
+                        "name": "request",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/models.GearTopCategory"
+                        }
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "status: success when all goes well",
+                        "schema": {
+                            "$ref": "#/definitions/models.Status"
+                        }
+                    },
+                    "default": {
+                        "description": "",
+                        "schema": {
+                            "$ref": "#/definitions/models.Error"
+                        }
+                    }
+                }
+            }
+        },
+        "/api/v1/translation/missing/list": {
+            "get": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "Get, for each locale, the gear, categories and top categories that have text in the default locale without a translation, with the fields that are missing. Gear is only reported once it is in the catalog. Without locale, every configured locale is reported, or every locale with translations when none are configured. Requires a JWT issued with the admin audience.",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Translations"
+                ],
+                "summary": "List missing translations",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Only report this locale",
+                        "name": "locale",
+                        "in": "query"
+                    },
+                    {
+                        "type": "array",
+                        "items": {
+                            "type": "string"
+                        },
+                        "collectionFormat": "multi",
+                        "description": "Only report these kinds: gear, category or top_category",
+                        "name": "kind",
+                        "in": "query"
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "type": "array",
+                            "items": {
+                                "$ref": "#/definitions/models.MissingTranslationReport"
+                            }
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/models.Error"
+                        }
+                    },
+                    "403": {
+                        "description": "Forbidden",
+                        "schema": {
+                            "$ref": "#/definitions/models.Error"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/models.Error"
+                        }
+                    }
+                }
+            }
+        },
+        "/api/v1/usergear/insert": {
+            "put": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "Insert user registered gear with corresponding values",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "User gear"
+                ],
+                "summary": "Insert user registered gear",
+                "parameters": [
+                    {
+                        "description": "query params",
+                        "name": "request",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/models.UserGearLinkNoID"
+                        }
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "status: success when all goes well",
+                        "schema": {
+                            "$ref": "#/definitions/models.Status"
+                        }
+                    }
+                }
+            }
+        },
+        "/api/v1/usergear/registration/{usergear}/delete": {
+            "delete": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "Delete userGear with corresponding ID value",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "User gear"
+                ],
+                "summary": "Delete userGear with ID",
+                "parameters": [
+                    {
+                        "type": "integer",
+                        "description": "Unique ID of userGear you want to update",
+                        "name": "userGear",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "status: success when all goes well",
+                        "schema": {
+                            "$ref": "#/definitions/models.Status"
+                        }
+                    },
+                    "default": {
+                        "description": "",
+                        "schema": {
+                            "$ref": "#/definitions/models.Error"
+                        }
+                    }
+                }
+            }
+        },
+        "/api/v1/usergear/registration/{usergear}/get": {
+            "get": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "Get user registeredgear spessific to ID. Only the owner and admins can see a registration.",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "User gear"
+                ],
+                "summary": "Get user registered gear with ID",
+                "parameters": [
+                    {
+                        "type": "integer",
+                        "description": "Unique ID of user registered gear you want to get",
+                        "name": "usergear",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "type": "string",
+                        "description": "Also give measurements in metric or imperial units, overriding the user's preference",
+                        "name": "units",
+                        "in": "query"
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "desc",
+                        "schema": {
+                            "$ref": "#/definitions/models.UserGear"
+                        }
+                    }
+                }
+            }
+        },
+        "/api/v1/usergear/registration/{usergear}/image/list": {
+            "get": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "List the photos of a registered item, oldest first. The first image is the one shown as image_url on the registration. Only the owner and admins can list them.",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "User gear"
+                ],
+                "summary": "List user gear images",
+                "parameters": [
+                    {
+                        "type": "integer",
+                        "description": "User gear registration ID",
+                        "name": "usergear",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "type": "array",
+                            "items": {
+                                "$ref": "#/definitions/models.Image"
+                            }
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/models.Error"
+                        }
+                    },
+                    "403": {
+                        "description": "Forbidden",
+                        "schema": {
+                            "$ref": "#/definitions/models.Error"
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "$ref": "#/definitions/models.Error"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/models.Error"
+                        }
+                    }
+                }
+            }
+        },
+        "/api/v1/usergear/registration/{usergear}/image/upload": {
+            "put": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "Upload a JPEG, PNG or GIF of a registered item as the multipart field image. The type is sniffed from the content and a thumbnail is generated. Uploads are limited to storage.max-upload-bytes, 10 MiB by default. Only the registration's owner or an admin may upload.",
+                "consumes": [
+                    "multipart/form-data"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "User gear"
+                ],
+                "summary": "Upload user gear image",
+                "parameters": [
+                    {
+                        "type": "integer",
+                        "description": "User gear registration ID",
+                        "name": "usergear",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "type": "file",
+                        "description": "Image file",
+                        "name": "image",
+                        "in": "formData",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "201": {
+                        "description": "Created",
+                        "schema": {
+                            "$ref": "#/definitions/models.Image"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/models.Error"
+                        }
+                    },
+                    "403": {
+                        "description": "Forbidden",
+                        "schema": {
+                            "$ref": "#/definitions/models.Error"
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "$ref": "#/definitions/models.Error"
+                        }
+                    },
+                    "413": {
+                        "description": "Request Entity Too Large",
+                        "schema": {
+                            "$ref": "#/definitions/models.Error"
+                        }
+                    },
+                    "415": {
+                        "description": "Unsupported Media Type",
+                        "schema": {
+                            "$ref": "#/definitions/models.Error"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/models.Error"
+                        }
+                    }
+                }
+            }
+        },
+        "/api/v1/usergear/registration/{usergear}/image/{image}/delete": {
+            "delete": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "Delete a photo of a registered item and its files. Only the registration's owner or an admin may delete.",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "User gear"
+                ],
+                "summary": "Delete user gear image",
+                "parameters": [
+                    {
+                        "type": "integer",
+                        "description": "User gear registration ID",
+                        "name": "usergear",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "type": "integer",
+                        "description": "Image ID",
+                        "name": "image",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/models.Status"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/models.Error"
+                        }
+                    },
+                    "403": {
+                        "description": "Forbidden",
+                        "schema": {
+                            "$ref": "#/definitions/models.Error"
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "$ref": "#/definitions/models.Error"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/models.Error"
+                        }
+                    }
+                }
+            }
+        },
+        "/api/v1/usergear/registration/{usergear}/tag/insert": {
+            "put": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "Add private tags to one of your gear registrations. Tags are lower-cased and may use letters, digits, spaces, - and _.",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Tags"
+                ],
+                "summary": "Add registration tags",
+                "parameters": [
+                    {
+                        "type": "integer",
+                        "description": "Registration ID",
+                        "name": "usergear",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "description": "Tags to add",
+                        "name": "body",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/models.TagInput"
+                        }
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "type": "array",
+                            "items": {
+                                "type": "string"
+                            }
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/models.Error"
+                        }
+                    },
+                    "403": {
+                        "description": "Forbidden",
+                        "schema": {
+                            "$ref": "#/definitions/models.Error"
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "$ref": "#/definitions/models.Error"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/models.Error"
+                        }
+                    }
+                }
+            }
+        },
+        "/api/v1/usergear/registration/{usergear}/tag/list": {
+            "get": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "List the private tags of one of your gear registrations",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Tags"
+                ],
+                "summary": "List registration tags",
+                "parameters": [
+                    {
+                        "type": "integer",
+                        "description": "Registration ID",
+                        "name": "usergear",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "type": "array",
+                            "items": {
+                                "type": "string"
+                            }
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/models.Error"
+                        }
+                    },
+                    "403": {
+                        "description": "Forbidden",
+                        "schema": {
+                            "$ref": "#/definitions/models.Error"
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "$ref": "#/definitions/models.Error"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/models.Error"
+                        }
+                    }
+                }
+            }
+        },
+        "/api/v1/usergear/registration/{usergear}/tag/{tag}/delete": {
+            "delete": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "Remove a private tag from one of your gear registrations",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Tags"
+                ],
+                "summary": "Remove registration tag",
+                "parameters": [
+                    {
+                        "type": "integer",
+                        "description": "Registration ID",
+                        "name": "usergear",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "type": "string",
+                        "description": "Tag",
+                        "name": "tag",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/models.Status"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/models.Error"
+                        }
+                    },
+                    "403": {
+                        "description": "Forbidden",
+                        "schema": {
+                            "$ref": "#/definitions/models.Error"
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "$ref": "#/definitions/models.Error"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/models.Error"
+                        }
+                    }
+                }
+            }
+        },
+        "/api/v1/usergear/registration/{usergear}/update": {
+            "post": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "Update user registered gear identified by ID. Fields left out keep their value. purchase_date is YYYY-MM-DD, purchase_currency a three letter ISO 4217 code required with purchase_price, and condition one of new, like_new, good, fair or poor.",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "User gear"
+                ],
+                "summary": "Update user registered gear with ID",
+                "parameters": [
+                    {
+                        "type": "integer",
+                        "description": "Unique ID of user registered gear you want to get",
+                        "name": "usergear",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "description": "query params",
+                        "name": "request",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/models.UserGearLink"
+                        }
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "status: success when all goes well",
+                        "schema": {
+                            "$ref": "#/definitions/models.Status"
+                        }
+                    }
+                }
+            }
+        },
+        "/api/v1/usergear/{user}/list": {
+            "get": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "Get a list a users gear. Any field can be sorted on, including purchase_date, purchase_price and condition.",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "User gear"
+                ],
+                "summary": "List users gear",
+                "parameters": [
+                    {
+                        "type": "integer",
+                        "description": "Unique ID of user you want to get the Gear of",
+                        "name": "user",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "type": "integer",
+                        "default": 1,
+                        "description": "Page number",
+                        "name": "page",
+                        "in": "query"
+                    },
+                    {
+                        "type": "integer",
+                        "default": 30,
+                        "description": "Number of items per page",
+                        "name": "limit",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "Field to sort by, json or db field name",
+                        "name": "sort",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "default": "asc",
+                        "description": "Sort direction, asc or desc",
+                        "name": "order",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "Opaque cursor from next_cursor or prev_cursor, replaces page",
+                        "name": "cursor",
+                        "in": "query"
+                    },
+                    {
+                        "type": "boolean",
+                        "default": true,
+                        "description": "Include total item count",
+                        "name": "count",
+                        "in": "query"
+                    },
+                    {
+                        "type": "array",
+                        "items": {
+                            "type": "integer"
+                        },
+                        "collectionFormat": "multi",
+                        "description": "top categories",
+                        "name": "topCategory",
+                        "in": "query"
+                    },
+                    {
+                        "type": "array",
+                        "items": {
+                            "type": "integer"
+                        },
+                        "collectionFormat": "multi",
+                        "description": "sub categories, including the categories below them",
+                        "name": "category",
+                        "in": "query"
+                    },
+                    {
+                        "type": "array",
+                        "items": {
+                            "type": "integer"
+                        },
+                        "collectionFormat": "multi",
+                        "description": "manufacturers",
+                        "name": "manufacture",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "default": "all",
+                        "description": "show container gear only. valid values are true, false, all",
+                        "name": "container",
+                        "in": "query"
+                    },
+                    {
+                        "type": "array",
+                        "items": {
+                            "type": "string"
+                        },
+                        "collectionFormat": "multi",
+                        "description": "Item conditions: new, like_new, good, fair or poor",
+                        "name": "condition",
+                        "in": "query"
+                    },
+                    {
+                        "type": "array",
+                        "items": {
+                            "type": "string"
+                        },
+                        "collectionFormat": "multi",
+                        "description": "Purchase currencies",
+                        "name": "purchase_currency",
+                        "in": "query"
+                    },
+                    {
+                        "type": "array",
+                        "items": {
+                            "type": "string"
+                        },
+                        "collectionFormat": "multi",
+                        "description": "Retailers",
+                        "name": "retailer",
+                        "in": "query"
+                    },
+                    {
+                        "type": "array",
+                        "items": {
+                            "type": "string"
+                        },
+                        "collectionFormat": "multi",
+                        "description": "Colours",
+                        "name": "colour",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "Purchased on or after this YYYY-MM-DD date",
+                        "name": "purchased_after",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "Purchased on or before this YYYY-MM-DD date",
+                        "name": "purchased_before",
+                        "in": "query"
+                    },
+                    {
+                        "type": "number",
+                        "description": "Minimum purchase price",
+                        "name": "min_price",
+                        "in": "query"
+                    },
+                    {
+                        "type": "number",
+                        "description": "Maximum purchase price",
+                        "name": "max_price",
+                        "in": "query"
+                    },
+                    {
+                        "type": "array",
+                        "items": {
+                            "type": "string"
+                        },
+                        "collectionFormat": "multi",
+                        "description": "Registration tags",
+                        "name": "tag",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "default": "any",
+                        "description": "Match registrations with any or all of the tags",
+                        "name": "tag_match",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "Also give measurements in metric or imperial units, overriding the user's preference",
+                        "name": "units",
+                        "in": "query"
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "allOf": [
+                                {
+                                    "$ref": "#/definitions/models.ResponsePayload"
+                                },
+                                {
+                                    "type": "object",
+                                    "properties": {
+                                        "items": {
+                                            "type": "array",
+                                            "items": {
+                                                "$ref": "#/definitions/models.UserGear"
+                                            }
+                                        }
+                                    }
+                                }
+                            ]
+                        }
+                    },
+                    "default": {
+                        "description": "",
+                        "schema": {
+                            "$ref": "#/definitions/models.Error"
+                        }
+                    }
+                }
+            }
+        },
+        "/api/v1/users/insert": {
+            "put": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "Insert new user with corresponding values",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "User"
+                ],
+                "summary": "Insert new user",
+                "parameters": [
+                    {
+                        "description": "query params",
+                        "name": "request",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/models.UserWithPass"
+                        }
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "status: success when all goes well",
+                        "schema": {
+                            "$ref": "#/definitions/models.Status"
+                        }
+                    },
+                    "default": {
+                        "description": "",
+                        "schema": {
+                            "$ref": "#/definitions/models.Error"
+                        }
+                    }
+                }
+            }
+        },
+        "/api/v1/users/list": {
+            "get": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "Get a list of user items",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "User"
+                ],
+                "summary": "List user",
+                "parameters": [
+                    {
+                        "type": "integer",
+                        "default": 1,
+                        "description": "Page number",
+                        "name": "page",
+                        "in": "query"
+                    },
+                    {
+                        "type": "integer",
+                        "default": 30,
+                        "description": "Number of items per page",
+                        "name": "limit",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "Field to sort by, json or db field name",
+                        "name": "sort",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "default": "asc",
+                        "description": "Sort direction, asc or desc",
+                        "name": "order",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "Opaque cursor from next_cursor or prev_cursor, replaces page",
+                        "name": "cursor",
+                        "in": "query"
+                    },
+                    {
+                        "type": "boolean",
+                        "default": true,
+                        "description": "Include total item count",
+                        "name": "count",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "search by user's username (this is case insensitive and wildcard)",
+                        "name": "user",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "search by users full name (this is case insensitive and wildcard)",
+                        "name": "username",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "search by users email (this is case insensitive and wildcard)",
+                        "name": "email",
+                        "in": "query"
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "allOf": [
+                                {
+                                    "$ref": "#/definitions/models.ResponsePayload"
+                                },
+                                {
+                                    "type": "object",
+                                    "properties": {
+                                        "items": {
+                                            "type": "array",
+                                            "items": {
+                                                "$ref": "#/definitions/models.User"
+                                            }
+                                        }
+                                    }
+                                }
+                            ]
+                        }
+                    },
+                    "default": {
+                        "description": "",
+                        "schema": {
+                            "$ref": "#/definitions/models.Error"
+                        }
+                    }
+                }
+            }
+        },
+        "/api/v1/users/preferences/get": {
+            "get": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "Get the authenticated user's preferences. unit_system is metric, imperial or null for canonical grams and millimetres only. locale is the language catalog names are translated to, or null to follow the Accept-Language header.",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "User"
+                ],
+                "summary": "Get user preferences",
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/models.UserPreferences"
+                        }
+                    },
+                    "default": {
+                        "description": "",
+                        "schema": {
+                            "$ref": "#/definitions/models.Error"
+                        }
+                    }
+                }
+            }
+        },
+        "/api/v1/users/preferences/update": {
+            "post": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "Set the authenticated user's preferences. unit_system is metric or imperial, or null to clear it; measured responses then include a measurements object in that system unless the request sets units. locale is a language tag such as nb; catalog names are then translated to it instead of following the Accept-Language header. It must be one of the configured locales, if any are.",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "User"
+                ],
+                "summary": "Update user preferences",
+                "parameters": [
+                    {
+                        "description": "Preferences; user_id is taken from the token",
+                        "name": "request",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/models.UserPreferences"
+                        }
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/models.UserPreferences"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/models.Error"
+                        }
+                    },
+                    "default": {
+                        "description": "",
+                        "schema": {
+                            "$ref": "#/definitions/models.Error"
+                        }
+                    }
+                }
+            }
+        },
+        "/api/v1/users/{user}/delete": {
+            "delete": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "Delete user with corresponding ID value",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "User"
+                ],
+                "summary": "Delete user with ID",
+                "parameters": [
+                    {
+                        "type": "integer",
+                        "description": "Unique ID of user you want to update",
+                        "name": "user",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "status: success when all goes well",
+                        "schema": {
+                            "$ref": "#/definitions/models.Status"
+                        }
+                    },
+                    "default": {
+                        "description": "",
+                        "schema": {
+                            "$ref": "#/definitions/models.Error"
+                        }
+                    }
+                }
+            }
+        },
+        "/api/v1/users/{user}/get": {
+            "get": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "Get user spessific to ID",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "User"
+                ],
+                "summary": "Get user with ID",
+                "parameters": [
+                    {
+                        "type": "integer",
+                        "description": "Unique ID of user you want to get",
+                        "name": "user",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "desc",
+                        "schema": {
+                            "$ref": "#/definitions/models.User"
+                        }
+                    },
+                    "default": {
+                        "description": "",
+                        "schema": {
+                            "$ref": "#/definitions/models.Error"
+                        }
+                    }
+                }
+            }
+        },
+        "/api/v1/users/{user}/update": {
+            "post": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "Update user identified by ID",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "User"
+                ],
+                "summary": "Update user with ID",
+                "parameters": [
+                    {
+                        "type": "integer",
+                        "description": "Unique ID of user you want to update",
+                        "name": "user",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "description": "query params",
+                        "name": "request",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/models.User"
+                        }
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "status: success when all goes well",
+                        "schema": {
+                            "$ref": "#/definitions/models.Status"
+                        }
+                    },
+                    "default": {
+                        "description": "",
+                        "schema": {
+                            "$ref": "#/definitions/models.Error"
+                        }
+                    }
+                }
+            }
+        },
+        "/auth/google/callback": {
+            "get": {
+                "description": "Validates the Google credential and returns a JWT for subsequent API calls",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Auth"
+                ],
+                "summary": "Google OAuth callback",
+                "parameters": [
+                    {
+                        "description": "Callback payload",
+                        "name": "request",
+                        "in": "body",
+                        "schema": {
+                            "$ref": "#/definitions/endpoints.googleCallbackRequest"
+                        }
+                    },
+                    {
+                        "type": "string",
+                        "description": "Authorization code",
+                        "name": "code",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "Google ID token",
+                        "name": "id_token",
+                        "in": "query"
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": true
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/models.Error"
+                        }
+                    },
+                    "401": {
+                        "description": "Unauthorized",
+                        "schema": {
+                            "$ref": "#/definitions/models.Error"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/models.Error"
+                        }
+                    }
+                }
+            },
+            "post": {
+                "description": "Validates the Google credential and returns a JWT for subsequent API calls",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Auth"
+                ],
+                "summary": "Google OAuth callback",
+                "parameters": [
+                    {
+                        "description": "Callback payload",
+                        "name": "request",
+                        "in": "body",
+                        "schema": {
+                            "$ref": "#/definitions/endpoints.googleCallbackRequest"
+                        }
+                    },
+                    {
+                        "type": "string",
+                        "description": "Authorization code",
+                        "name": "code",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "Google ID token",
+                        "name": "id_token",
+                        "in": "query"
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": true
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/models.Error"
+                        }
+                    },
+                    "401": {
+                        "description": "Unauthorized",
+                        "schema": {
+                            "$ref": "#/definitions/models.Error"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/models.Error"
+                        }
+                    }
+                }
+            }
+        },
+        "/auth/refresh": {
+            "post": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "Exchanges a valid GoGear JWT for a new token with a fresh expiry",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Auth"
+                ],
+                "summary": "Refresh service token",
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": true
+                        }
+                    },
+                    "401": {
+                        "description": "Unauthorized",
+                        "schema": {
+                            "$ref": "#/definitions/models.Error"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/models.Error"
+                        }
+                    }
+                }
+            }
+        },
+        "/health": {
+            "get": {
+                "description": "Get health status of application",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Health"
+                ],
+                "summary": "Get application health",
+                "responses": {
+                    "200": {
+                        "description": "desc",
+                        "schema": {
+                            "$ref": "#/definitions/models.Health"
+                        }
+                    }
+                }
+            }
+        }
+    },
+    "definitions": {
+        "endpoints.googleCallbackRequest": {
+            "type": "object",
+            "properties": {
+                "code": {
+                    "type": "string"
+                },
+                "credential": {
+                    "type": "string"
+                },
+                "id_token": {
+                    "type": "string"
+                }
+            }
+        },
+        "models.CatalogRevision": {
+            "type": "object",
+            "properties": {
+                "action": {
+                    "type": "string"
+                },
+                "created_at": {
+                    "type": "string"
+                },
+                "data": {
+                    "type": "object",
+                    "additionalProperties": true
+                },
+                "entity_id": {
+                    "type": "integer"
+                },
+                "kind": {
+                    "type": "string"
+                },
+                "restored_revision": {
+                    "type": "integer"
+                },
+                "revision": {
+                    "type": "integer"
+                }
+            }
+        },
+        "models.CategoryMove": {
+            "type": "object",
+            "properties": {
+                "parent_id": {
+                    "type": "integer"
+                },
+                "position": {
+                    "type": "integer"
+                },
+                "top_category_id": {
+                    "type": "integer"
+                }
+            }
+        },
+        "models.CategoryReorder": {
+            "type": "object",
+            "properties": {
+                "order": {
+                    "type": "array",
+                    "items": {
+                        "type": "integer"
+                    }
+                },
+                "parent_id": {
+                    "type": "integer"
+                },
+                "top_category_id": {
+                    "type": "integer"
+                }
+            }
+        },
+        "models.CategoryTree": {
+            "type": "object",
+            "properties": {
+                "children": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/models.CategoryTreeNode"
+                    }
+                },
+                "gear_count": {
+                    "type": "integer"
+                },
+                "top_category_icon": {
+                    "type": "string"
+                },
+                "top_category_id": {
+                    "type": "integer"
+                },
+                "top_category_name": {
+                    "type": "string"
+                },
+                "top_category_sort_order": {
+                    "type": "integer"
+                }
+            }
+        },
+        "models.CategoryTreeNode": {
+            "type": "object",
+            "properties": {
+                "category_id": {
+                    "type": "integer"
+                },
+                "category_name": {
+                    "type": "string"
+                },
+                "category_parent_id": {
+                    "type": "integer"
+                },
+                "category_sort_order": {
+                    "type": "integer"
+                },
+                "category_top_category_id": {
+                    "type": "integer"
+                },
+                "children": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/models.CategoryTreeNode"
+                    }
+                },
+                "gear_count": {
+                    "type": "integer"
+                }
+            }
+        },
+        "models.DependentsError": {
+            "type": "object",
+            "properties": {
+                "category_count": {
+                    "type": "integer"
+                },
+                "error": {
+                    "type": "string"
+                },
+                "gear_count": {
+                    "type": "integer"
+                }
+            }
+        },
+        "models.Error": {
+            "type": "object",
+            "properties": {
+                "error": {
+                    "type": "string"
+                }
+            }
+        },
+        "models.FullGear": {
+            "type": "object",
+            "properties": {
+                "category_id": {
+                    "type": "integer"
+                },
+                "category_name": {
+                    "type": "string"
+                },
+                "category_top_category_id": {
+                    "type": "integer"
+                },
+                "gear_category_id": {
+                    "type": "integer"
+                },
+                "gear_height": {
+                    "type": "integer"
+                },
+                "gear_id": {
+                    "type": "integer"
+                },
+                "gear_is_container": {
+                    "type": "boolean"
+                },
+                "gear_length": {
+                    "type": "integer"
+                },
+                "gear_manufacture_id": {
+                    "type": "integer"
+                },
+                "gear_name": {
+                    "type": "string"
+                },
+                "gear_rating_average": {
+                    "type": "number"
+                },
+                "gear_review_count": {
+                    "type": "integer"
+                },
+                "gear_size_definition": {
+                    "type": "string"
+                },
+                "gear_status": {
+                    "type": "boolean"
+                },
+                "gear_top_category_id": {
+                    "type": "integer"
+                },
+                "gear_weight": {
+                    "type": "integer"
+                },
+                "gear_width": {
+                    "type": "integer"
+                },
+                "image_url": {
+                    "type": "string"
+                },
+                "manufacture_id": {
+                    "type": "integer"
+                },
+                "manufacture_name": {
+                    "type": "string"
+                },
+                "measurements": {
+                    "$ref": "#/definitions/models.Measurements"
+                },
+                "specs": {
+                    "$ref": "#/definitions/models.Specs"
+                },
+                "tags": {
+                    "type": "array",
+                    "items": {
+                        "type": "string"
+                    }
+                },
+                "thumbnail_url": {
+                    "type": "string"
+                },
+                "top_category_icon": {
+                    "type": "string"
+                },
+                "top_category_id": {
+                    "type": "integer"
+                },
+                "top_category_name": {
+                    "type": "string"
+                }
+            }
+        },
+        "models.Gear": {
+            "type": "object",
+            "properties": {
+                "gear_category_id": {
+                    "type": "integer"
+                },
+                "gear_height": {
+                    "type": "integer"
+                },
+                "gear_id": {
+                    "type": "integer"
+                },
+                "gear_is_container": {
+                    "type": "boolean"
+                },
+                "gear_length": {
+                    "type": "integer"
+                },
+                "gear_manufacture_id": {
+                    "type": "integer"
+                },
+                "gear_name": {
+                    "type": "string"
+                },
+                "gear_size_definition": {
+                    "type": "string"
+                },
+                "gear_status": {
+                    "type": "boolean"
+                },
+                "gear_top_category_id": {
+                    "type": "integer"
+                },
+                "gear_weight": {
+                    "type": "integer"
+                },
+                "gear_width": {
+                    "type": "integer"
+                },
+                "specs": {
+                    "$ref": "#/definitions/models.Specs"
+                }
+            }
+        },
+        "models.GearAlternative": {
+            "type": "object",
+            "properties": {
+                "gear": {
+                    "$ref": "#/definitions/models.GearListItem"
+                },
+                "measurements": {
+                    "$ref": "#/definitions/models.Measurements"
+                },
+                "new_total_weight": {
+                    "type": "integer"
+                },
+                "total_weight_saving": {
+                    "type": "integer"
+                },
+                "weight": {
+                    "type": "integer"
+                },
+                "weight_saving": {
+                    "type": "integer"
+                }
+            }
+        },
+        "models.GearCategory": {
+            "type": "object",
+            "properties": {
+                "category_id": {
+                    "type": "integer"
+                },
+                "category_name": {
+                    "type": "string"
+                },
+                "category_parent_id": {
+                    "type": "integer"
+                },
+                "category_top_category_id": {
+                    "type": "integer"
+                }
+            }
+        },
+        "models.GearComparison": {
+            "type": "object",
+            "properties": {
+                "fields": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/models.GearComparisonField"
+                    }
+                },
+                "gear": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/models.GearComparisonItem"
+                    }
+                },
+                "lightest_gear_id": {
+                    "type": "integer"
+                }
+            }
+        },
+        "models.GearComparisonField": {
+            "type": "object",
+            "properties": {
+                "better": {
+                    "type": "string"
+                },
+                "key": {
+                    "type": "string"
+                },
+                "label": {
+                    "type": "string"
+                },
+                "type": {
+                    "type": "string"
+                },
+                "unit": {
+                    "type": "string"
+                },
+                "values": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/models.GearComparisonValue"
+                    }
+                }
+            }
+        },
+        "models.GearComparisonItem": {
+            "type": "object",
+            "properties": {
+                "category_name": {
+                    "type": "string"
+                },
+                "gear_category_id": {
+                    "type": "integer"
+                },
+                "gear_height": {
+                    "type": "integer"
+                },
+                "gear_id": {
+                    "type": "integer"
+                },
+                "gear_length": {
+                    "type": "integer"
+                },
+                "gear_name": {
+                    "type": "string"
+                },
+                "gear_rating_average": {
+                    "type": "number"
+                },
+                "gear_review_count": {
+                    "type": "integer"
+                },
+                "gear_top_category_id": {
+                    "type": "integer"
+                },
+                "gear_weight": {
+                    "type": "integer"
+                },
+                "gear_width": {
+                    "type": "integer"
+                },
+                "image_url": {
+                    "type": "string"
+                },
+                "manufacture_name": {
+                    "type": "string"
+                },
+                "owner_count": {
+                    "type": "integer"
+                },
+                "thumbnail_url": {
+                    "type": "string"
+                },
+                "top_category_name": {
+                    "type": "string"
+                }
+            }
+        },
+        "models.GearComparisonValue": {
+            "type": "object",
+            "properties": {
+                "best": {
+                    "type": "boolean"
+                },
+                "delta": {
+                    "type": "number"
+                },
+                "value": {}
+            }
+        },
+        "models.GearDuplicate": {
+            "type": "object",
+            "properties": {
+                "duplicate": {
+                    "$ref": "#/definitions/models.GearDuplicateCandidate"
+                },
+                "gear": {
+                    "$ref": "#/definitions/models.GearDuplicateCandidate"
+                },
+                "score": {
+                    "type": "number"
+                }
+            }
+        },
+        "models.GearDuplicateCandidate": {
+            "type": "object",
+            "properties": {
+                "gear_id": {
+                    "type": "integer"
+                },
+                "gear_manufacture_id": {
+                    "type": "integer"
+                },
+                "gear_name": {
+                    "type": "string"
+                },
+                "gear_status": {
+                    "type": "boolean"
+                },
+                "gear_weight": {
+                    "type": "integer"
+                },
+                "manufacture_name": {
+                    "type": "string"
+                },
+                "registration_count": {
+                    "type": "integer"
+                }
+            }
+        },
+        "models.GearImportReport": {
+            "type": "object",
+            "properties": {
+                "committed": {
+                    "type": "boolean"
+                },
+                "created": {
+                    "type": "integer"
+                },
+                "created_manufacturers": {
+                    "type": "array",
+                    "items": {
+                        "type": "string"
+                    }
+                },
+                "dry_run": {
+                    "type": "boolean"
+                },
+                "errors": {
+                    "type": "integer"
+                },
+                "rows": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/models.GearImportRowResult"
+                    }
+                },
+                "skipped": {
+                    "type": "integer"
+                },
+                "updated": {
+                    "type": "integer"
+                }
+            }
+        },
+        "models.GearImportRow": {
+            "type": "object",
+            "properties": {
+                "category": {
+                    "type": "string"
+                },
+                "gear_height": {
+                    "type": "integer"
+                },
+                "gear_is_container": {
+                    "type": "boolean"
+                },
+                "gear_length": {
+                    "type": "integer"
+                },
+                "gear_name": {
+                    "type": "string"
+                },
+                "gear_size_definition": {
+                    "type": "string"
+                },
+                "gear_status": {
+                    "type": "boolean"
+                },
+                "gear_weight": {
+                    "type": "integer"
+                },
+                "gear_width": {
+                    "type": "integer"
+                },
+                "manufacturer": {
+                    "type": "string"
+                },
+                "top_category": {
+                    "type": "string"
+                }
+            }
+        },
+        "models.GearImportRowResult": {
+            "type": "object",
+            "properties": {
+                "action": {
+                    "type": "string",
+                    "enum": [
+                        "created",
+                        "updated",
+                        "skipped",
+                        "error"
+                    ]
+                },
+                "error": {
+                    "type": "string"
+                },
+                "gear_id": {
+                    "type": "integer"
+                },
+                "gear_name": {
+                    "type": "string"
+                },
+                "row": {
+                    "type": "integer"
+                }
+            }
+        },
+        "models.GearListItem": {
+            "type": "object",
+            "properties": {
+                "category_id": {
+                    "type": "integer"
+                },
+                "category_name": {
+                    "type": "string"
+                },
+                "category_top_category_id": {
+                    "type": "integer"
+                },
+                "gear_category_id": {
+                    "type": "integer"
+                },
+                "gear_id": {
+                    "type": "integer"
+                },
+                "gear_is_container": {
+                    "type": "boolean"
+                },
+                "gear_manufacture_id": {
+                    "type": "integer"
+                },
+                "gear_name": {
+                    "type": "string"
+                },
+                "gear_rating_average": {
+                    "type": "number"
+                },
+                "gear_review_count": {
+                    "type": "integer"
+                },
+                "gear_size_definition": {
+                    "type": "string"
+                },
+                "gear_top_category_id": {
+                    "type": "integer"
+                },
+                "image_url": {
+                    "type": "string"
+                },
+                "manufacture_id": {
+                    "type": "integer"
+                },
+                "manufacture_name": {
+                    "type": "string"
+                },
+                "thumbnail_url": {
+                    "type": "string"
+                },
+                "top_category_icon": {
+                    "type": "string"
+                },
+                "top_category_id": {
+                    "type": "integer"
+                },
+                "top_category_name": {
+                    "type": "string"
+                }
+            }
+        },
+        "models.GearMerge": {
+            "type": "object",
+            "properties": {
+                "winner_id": {
+                    "type": "integer"
+                }
+            }
+        },
+        "models.GearMergeResult": {
+            "type": "object",
+            "properties": {
+                "loadout_ids": {
+                    "type": "array",
+                    "items": {
+                        "type": "integer"
+                    }
+                },
+                "loadout_items_moved": {
+                    "type": "integer"
+                },
+                "loser_id": {
+                    "type": "integer"
+                },
+                "registrations_moved": {
+                    "type": "integer"
+                },
+                "winner_id": {
+                    "type": "integer"
+                }
+            }
+        },
+        "models.GearRanking": {
+            "type": "object",
+            "properties": {
+                "count": {
+                    "type": "integer"
+                },
+                "gear": {
+                    "$ref": "#/definitions/models.GearListItem"
+                },
+                "rank": {
+                    "type": "integer"
+                }
+            }
+        },
+        "models.GearRecentlyAdded": {
+            "type": "object",
+            "properties": {
+                "added_at": {
+                    "type": "string"
+                },
+                "gear": {
+                    "$ref": "#/definitions/models.GearListItem"
+                }
             }
-        }
-    },
-    "definitions": {
-        "endpoints.googleCallbackRequest": {
+        },
+        "models.GearRecommendation": {
+            "type": "object",
+            "properties": {
+                "because_gear_ids": {
+                    "type": "array",
+                    "items": {
+                        "type": "integer"
+                    }
+                },
+                "count": {
+                    "type": "integer"
+                },
+                "gear": {
+                    "$ref": "#/definitions/models.GearListItem"
+                },
+                "score": {
+                    "type": "number"
+                }
+            }
+        },
+        "models.GearReview": {
+            "type": "object",
+            "properties": {
+                "created_at": {
+                    "type": "string"
+                },
+                "gear_id": {
+                    "type": "integer"
+                },
+                "review_hidden": {
+                    "type": "boolean"
+                },
+                "review_hidden_reason": {
+                    "type": "string"
+                },
+                "review_id": {
+                    "type": "integer"
+                },
+                "review_rating": {
+                    "type": "integer"
+                },
+                "review_text": {
+                    "type": "string"
+                },
+                "updated_at": {
+                    "type": "string"
+                },
+                "user_id": {
+                    "type": "integer"
+                },
+                "user_name": {
+                    "type": "string"
+                }
+            }
+        },
+        "models.GearReviewDecision": {
+            "type": "object",
+            "properties": {
+                "decision": {
+                    "type": "string"
+                },
+                "reason": {
+                    "type": "string"
+                }
+            }
+        },
+        "models.GearReviewHide": {
+            "type": "object",
+            "properties": {
+                "hidden": {
+                    "type": "boolean"
+                },
+                "reason": {
+                    "type": "string"
+                }
+            }
+        },
+        "models.GearReviewNoID": {
+            "type": "object",
+            "properties": {
+                "review_rating": {
+                    "type": "integer"
+                },
+                "review_text": {
+                    "type": "string"
+                }
+            }
+        },
+        "models.GearSearchItem": {
+            "type": "object",
+            "properties": {
+                "category_id": {
+                    "type": "integer"
+                },
+                "category_name": {
+                    "type": "string"
+                },
+                "category_top_category_id": {
+                    "type": "integer"
+                },
+                "gear_category_id": {
+                    "type": "integer"
+                },
+                "gear_id": {
+                    "type": "integer"
+                },
+                "gear_is_container": {
+                    "type": "boolean"
+                },
+                "gear_manufacture_id": {
+                    "type": "integer"
+                },
+                "gear_name": {
+                    "type": "string"
+                },
+                "gear_size_definition": {
+                    "type": "string"
+                },
+                "gear_top_category_id": {
+                    "type": "integer"
+                },
+                "manufacture_id": {
+                    "type": "integer"
+                },
+                "manufacture_name": {
+                    "type": "string"
+                },
+                "search_rank": {
+                    "type": "number"
+                },
+                "search_snippet": {
+                    "type": "string"
+                },
+                "top_category_icon": {
+                    "type": "string"
+                },
+                "top_category_id": {
+                    "type": "integer"
+                },
+                "top_category_name": {
+                    "type": "string"
+                }
+            }
+        },
+        "models.GearSubmission": {
+            "type": "object",
+            "properties": {
+                "category_name": {
+                    "type": "string"
+                },
+                "gear_category_id": {
+                    "type": "integer"
+                },
+                "gear_id": {
+                    "type": "integer"
+                },
+                "gear_manufacture_id": {
+                    "type": "integer"
+                },
+                "gear_name": {
+                    "type": "string"
+                },
+                "gear_review_reason": {
+                    "type": "string"
+                },
+                "gear_review_status": {
+                    "type": "string"
+                },
+                "gear_reviewed_at": {
+                    "type": "string"
+                },
+                "gear_reviewed_by": {
+                    "type": "integer"
+                },
+                "gear_status": {
+                    "type": "boolean"
+                },
+                "gear_submitted_at": {
+                    "type": "string"
+                },
+                "gear_submitted_by": {
+                    "type": "integer"
+                },
+                "manufacture_name": {
+                    "type": "string"
+                },
+                "submitter_name": {
+                    "type": "string"
+                }
+            }
+        },
+        "models.GearTopCategory": {
+            "type": "object",
+            "properties": {
+                "top_category_icon": {
+                    "type": "string"
+                },
+                "top_category_id": {
+                    "type": "integer"
+                },
+                "top_category_name": {
+                    "type": "string"
+                }
+            }
+        },
+        "models.GearVariant": {
+            "type": "object",
+            "properties": {
+                "gear_id": {
+                    "type": "integer"
+                },
+                "measurements": {
+                    "$ref": "#/definitions/models.Measurements"
+                },
+                "variant_height": {
+                    "type": "integer"
+                },
+                "variant_id": {
+                    "type": "integer"
+                },
+                "variant_length": {
+                    "type": "integer"
+                },
+                "variant_name": {
+                    "type": "string"
+                },
+                "variant_weight": {
+                    "type": "integer"
+                },
+                "variant_width": {
+                    "type": "integer"
+                }
+            }
+        },
+        "models.GearVariantNoID": {
+            "type": "object",
+            "properties": {
+                "variant_height": {
+                    "type": "integer"
+                },
+                "variant_length": {
+                    "type": "integer"
+                },
+                "variant_name": {
+                    "type": "string"
+                },
+                "variant_weight": {
+                    "type": "integer"
+                },
+                "variant_width": {
+                    "type": "integer"
+                }
+            }
+        },
+        "models.Health": {
+            "type": "object",
+            "properties": {
+                "documentation": {
+                    "type": "string"
+                },
+                "name": {
+                    "type": "string"
+                },
+                "status": {
+                    "type": "string"
+                },
+                "updated": {
+                    "type": "string"
+                }
+            }
+        },
+        "models.Image": {
+            "type": "object",
+            "properties": {
+                "content_type": {
+                    "type": "string"
+                },
+                "created_at": {
+                    "type": "string"
+                },
+                "gear_id": {
+                    "type": "integer"
+                },
+                "image_height": {
+                    "type": "integer"
+                },
+                "image_id": {
+                    "type": "integer"
+                },
+                "image_size": {
+                    "type": "integer"
+                },
+                "image_url": {
+                    "type": "string"
+                },
+                "image_width": {
+                    "type": "integer"
+                },
+                "thumbnail_url": {
+                    "type": "string"
+                },
+                "uploaded_by": {
+                    "type": "integer"
+                },
+                "usergear_registration_id": {
+                    "type": "integer"
+                }
+            }
+        },
+        "models.Loadout": {
             "type": "object",
             "properties": {
-                "code": {
+                "created_at": {
                     "type": "string"
                 },
-                "credential": {
+                "loadout_description": {
                     "type": "string"
                 },
-                "id_token": {
+                "loadout_id": {
+                    "type": "integer"
+                },
+                "loadout_is_public": {
+                    "type": "boolean"
+                },
+                "loadout_name": {
                     "type": "string"
+                },
+                "loadout_slug": {
+                    "type": "string"
+                },
+                "measurements": {
+                    "$ref": "#/definitions/models.Measurements"
+                },
+                "tags": {
+                    "type": "array",
+                    "items": {
+                        "type": "string"
+                    }
+                },
+                "total_weight": {
+                    "type": "integer"
+                },
+                "updated_at": {
+                    "type": "string"
+                },
+                "user_id": {
+                    "type": "integer"
                 }
             }
         },
-        "models.Error": {
+        "models.LoadoutItem": {
             "type": "object",
             "properties": {
-                "error": {
+                "gear_id": {
+                    "type": "integer"
+                },
+                "loadout_id": {
+                    "type": "integer"
+                },
+                "loadout_item_id": {
+                    "type": "integer"
+                },
+                "notes": {
                     "type": "string"
+                },
+                "quantity": {
+                    "type": "integer"
+                },
+                "variant_id": {
+                    "type": "integer"
                 }
             }
         },
-        "models.FullGear": {
+        "models.LoadoutItemAlternatives": {
             "type": "object",
             "properties": {
-                "category_id": {
+                "alternatives": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/models.GearAlternative"
+                    }
+                },
+                "gear_id": {
                     "type": "integer"
                 },
-                "category_name": {
+                "gear_name": {
                     "type": "string"
                 },
-                "category_top_category_id": {
+                "item_weight": {
                     "type": "integer"
                 },
-                "gear_category_id": {
+                "loadout_item_id": {
                     "type": "integer"
                 },
-                "gear_height": {
+                "measurements": {
+                    "$ref": "#/definitions/models.Measurements"
+                },
+                "quantity": {
                     "type": "integer"
                 },
+                "variant_id": {
+                    "type": "integer"
+                }
+            }
+        },
+        "models.LoadoutItemNoID": {
+            "type": "object",
+            "properties": {
                 "gear_id": {
                     "type": "integer"
                 },
-                "gear_is_container": {
-                    "type": "boolean"
+                "loadout_id": {
+                    "type": "integer"
                 },
-                "gear_length": {
+                "notes": {
+                    "type": "string"
+                },
+                "quantity": {
                     "type": "integer"
                 },
-                "gear_manufacture_id": {
+                "variant_id": {
+                    "type": "integer"
+                }
+            }
+        },
+        "models.LoadoutItemSwap": {
+            "type": "object",
+            "properties": {
+                "gear_id": {
                     "type": "integer"
                 },
-                "gear_name": {
-                    "type": "string"
+                "variant_id": {
+                    "type": "integer"
+                }
+            }
+        },
+        "models.LoadoutItemSwapResult": {
+            "type": "object",
+            "properties": {
+                "gear_id": {
+                    "type": "integer"
                 },
-                "gear_size_definition": {
-                    "type": "string"
+                "loadout_item_id": {
+                    "type": "integer"
                 },
-                "gear_status": {
-                    "type": "boolean"
+                "measurements": {
+                    "$ref": "#/definitions/models.Measurements"
                 },
-                "gear_top_category_id": {
+                "previous_total_weight": {
                     "type": "integer"
                 },
-                "gear_weight": {
+                "total_weight": {
                     "type": "integer"
                 },
-                "gear_width": {
+                "variant_id": {
                     "type": "integer"
                 },
-                "manufacture_id": {
+                "weight_change": {
+                    "type": "integer"
+                }
+            }
+        },
+        "models.LoadoutItemUpdate": {
+            "type": "object",
+            "properties": {
+                "loadout_item_id": {
                     "type": "integer"
                 },
-                "manufacture_name": {
+                "notes": {
                     "type": "string"
                 },
-                "top_category_icon": {
-                    "type": "string"
+                "quantity": {
+                    "type": "integer"
                 },
-                "top_category_id": {
+                "variant_id": {
                     "type": "integer"
+                }
+            }
+        },
+        "models.LoadoutNoID": {
+            "type": "object",
+            "properties": {
+                "loadout_description": {
+                    "type": "string"
                 },
-                "top_category_name": {
+                "loadout_is_public": {
+                    "type": "boolean"
+                },
+                "loadout_name": {
+                    "type": "string"
+                },
+                "loadout_slug": {
                     "type": "string"
+                },
+                "user_id": {
+                    "type": "integer"
                 }
             }
         },
-        "models.Gear": {
+        "models.LoadoutPublic": {
             "type": "object",
             "properties": {
-                "gear_category_id": {
+                "created_at": {
+                    "type": "string"
+                },
+                "loadout_description": {
+                    "type": "string"
+                },
+                "loadout_id": {
                     "type": "integer"
                 },
-                "gear_height": {
+                "loadout_name": {
+                    "type": "string"
+                },
+                "loadout_slug": {
+                    "type": "string"
+                },
+                "measurements": {
+                    "$ref": "#/definitions/models.Measurements"
+                },
+                "total_weight": {
                     "type": "integer"
                 },
-                "gear_id": {
+                "updated_at": {
+                    "type": "string"
+                }
+            }
+        },
+        "models.LoadoutUpdate": {
+            "type": "object",
+            "properties": {
+                "loadout_description": {
+                    "type": "string"
+                },
+                "loadout_id": {
                     "type": "integer"
                 },
-                "gear_is_container": {
+                "loadout_is_public": {
                     "type": "boolean"
                 },
-                "gear_length": {
+                "loadout_name": {
+                    "type": "string"
+                },
+                "loadout_slug": {
+                    "type": "string"
+                }
+            }
+        },
+        "models.Manufacture": {
+            "type": "object",
+            "properties": {
+                "manufacture_country": {
+                    "type": "string"
+                },
+                "manufacture_description": {
+                    "type": "string"
+                },
+                "manufacture_founded_year": {
                     "type": "integer"
                 },
-                "gear_manufacture_id": {
+                "manufacture_id": {
                     "type": "integer"
                 },
-                "gear_name": {
+                "manufacture_logo_url": {
                     "type": "string"
                 },
-                "gear_size_definition": {
+                "manufacture_name": {
                     "type": "string"
                 },
-                "gear_status": {
-                    "type": "boolean"
-                },
-                "gear_top_category_id": {
+                "manufacture_website": {
+                    "type": "string"
+                }
+            }
+        },
+        "models.ManufactureCategoryCount": {
+            "type": "object",
+            "properties": {
+                "gear_count": {
                     "type": "integer"
                 },
-                "gear_weight": {
+                "top_category_id": {
                     "type": "integer"
                 },
-                "gear_width": {
-                    "type": "integer"
+                "top_category_name": {
+                    "type": "string"
                 }
             }
         },
-        "models.GearCategory": {
+        "models.ManufactureDetail": {
             "type": "object",
             "properties": {
-                "category_id": {
+                "gear_count": {
                     "type": "integer"
                 },
-                "category_name": {
+                "heaviest_gear": {
+                    "$ref": "#/definitions/models.ManufactureGear"
+                },
+                "lightest_gear": {
+                    "$ref": "#/definitions/models.ManufactureGear"
+                },
+                "manufacture_country": {
                     "type": "string"
                 },
-                "category_top_category_id": {
+                "manufacture_description": {
+                    "type": "string"
+                },
+                "manufacture_founded_year": {
+                    "type": "integer"
+                },
+                "manufacture_id": {
+                    "type": "integer"
+                },
+                "manufacture_logo_url": {
+                    "type": "string"
+                },
+                "manufacture_name": {
+                    "type": "string"
+                },
+                "manufacture_website": {
+                    "type": "string"
+                },
+                "owner_count": {
                     "type": "integer"
+                },
+                "top_categories": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/models.ManufactureCategoryCount"
+                    }
                 }
             }
         },
-        "models.GearListItem": {
+        "models.ManufactureGear": {
             "type": "object",
             "properties": {
-                "category_id": {
+                "gear_id": {
                     "type": "integer"
                 },
-                "category_name": {
+                "gear_name": {
                     "type": "string"
                 },
-                "category_top_category_id": {
+                "gear_weight": {
                     "type": "integer"
-                },
-                "gear_category_id": {
+                }
+            }
+        },
+        "models.ManufactureListItem": {
+            "type": "object",
+            "properties": {
+                "gear_count": {
                     "type": "integer"
                 },
-                "gear_id": {
+                "heaviest_weight": {
                     "type": "integer"
                 },
-                "gear_is_container": {
-                    "type": "boolean"
-                },
-                "gear_manufacture_id": {
+                "lightest_weight": {
                     "type": "integer"
                 },
-                "gear_name": {
+                "manufacture_country": {
                     "type": "string"
                 },
-                "gear_size_definition": {
+                "manufacture_description": {
                     "type": "string"
                 },
-                "gear_top_category_id": {
+                "manufacture_founded_year": {
                     "type": "integer"
                 },
                 "manufacture_id": {
                     "type": "integer"
                 },
+                "manufacture_logo_url": {
+                    "type": "string"
+                },
                 "manufacture_name": {
                     "type": "string"
                 },
-                "top_category_icon": {
+                "manufacture_website": {
                     "type": "string"
                 },
-                "top_category_id": {
-                    "type": "integer"
+                "measurements": {
+                    "$ref": "#/definitions/models.Measurements"
                 },
-                "top_category_name": {
-                    "type": "string"
+                "owner_count": {
+                    "type": "integer"
                 }
             }
         },
-        "models.GearTopCategory": {
+        "models.Measurement": {
             "type": "object",
             "properties": {
-                "top_category_icon": {
+                "unit": {
                     "type": "string"
                 },
-                "top_category_id": {
-                    "type": "integer"
-                },
-                "top_category_name": {
-                    "type": "string"
+                "value": {
+                    "type": "number"
                 }
             }
         },
-        "models.Health": {
+        "models.Measurements": {
+            "type": "object",
+            "additionalProperties": {
+                "$ref": "#/definitions/models.Measurement"
+            }
+        },
+        "models.MissingTranslation": {
             "type": "object",
             "properties": {
-                "documentation": {
-                    "type": "string"
+                "id": {
+                    "type": "integer"
                 },
-                "name": {
+                "kind": {
                     "type": "string"
                 },
-                "status": {
-                    "type": "string"
+                "missing_fields": {
+                    "type": "array",
+                    "items": {
+                        "type": "string"
+                    }
                 },
-                "updated": {
+                "name": {
                     "type": "string"
                 }
             }
         },
-        "models.Loadout": {
+        "models.MissingTranslationReport": {
             "type": "object",
             "properties": {
-                "created_at": {
+                "locale": {
                     "type": "string"
                 },
-                "loadout_description": {
-                    "type": "string"
+                "missing": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/models.MissingTranslation"
+                    }
                 },
-                "loadout_id": {
+                "total": {
+                    "type": "integer"
+                }
+            }
+        },
+        "models.ResponsePayload": {
+            "type": "object",
+            "properties": {
+                "current_page": {
                     "type": "integer"
                 },
-                "loadout_is_public": {
-                    "type": "boolean"
+                "item_limit": {
+                    "type": "integer"
                 },
-                "loadout_name": {
+                "items": {},
+                "next_cursor": {
                     "type": "string"
                 },
-                "loadout_slug": {
+                "next_page": {
                     "type": "string"
                 },
-                "total_weight": {
-                    "type": "integer"
+                "prev_cursor": {
+                    "type": "string"
                 },
-                "updated_at": {
+                "prev_page": {
                     "type": "string"
                 },
-                "user_id": {
+                "total_item_count": {
+                    "type": "integer"
+                },
+                "total_pages": {
                     "type": "integer"
                 }
             }
         },
-        "models.LoadoutItem": {
+        "models.RevisionChange": {
             "type": "object",
             "properties": {
-                "gear_id": {
-                    "type": "integer"
-                },
-                "loadout_id": {
-                    "type": "integer"
-                },
-                "loadout_item_id": {
-                    "type": "integer"
-                },
-                "notes": {
+                "field": {
                     "type": "string"
                 },
-                "quantity": {
-                    "type": "integer"
-                }
+                "from": {},
+                "to": {}
             }
         },
-        "models.LoadoutItemNoID": {
+        "models.RevisionDiff": {
             "type": "object",
             "properties": {
-                "gear_id": {
+                "changes": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/models.RevisionChange"
+                    }
+                },
+                "entity_id": {
                     "type": "integer"
                 },
-                "loadout_id": {
+                "from": {
                     "type": "integer"
                 },
-                "notes": {
+                "kind": {
                     "type": "string"
                 },
-                "quantity": {
+                "to": {
                     "type": "integer"
                 }
             }
         },
-        "models.LoadoutItemUpdate": {
+        "models.SpecAttribute": {
             "type": "object",
             "properties": {
-                "loadout_item_id": {
+                "attribute_id": {
                     "type": "integer"
                 },
-                "notes": {
+                "category_id": {
+                    "type": "integer"
+                },
+                "label": {
                     "type": "string"
                 },
-                "quantity": {
-                    "type": "integer"
-                }
-            }
-        },
-        "models.LoadoutNoID": {
-            "type": "object",
-            "properties": {
-                "loadout_description": {
+                "name": {
                     "type": "string"
                 },
-                "loadout_is_public": {
+                "options": {
+                    "type": "array",
+                    "items": {
+                        "type": "string"
+                    }
+                },
+                "required": {
                     "type": "boolean"
                 },
-                "loadout_name": {
+                "type": {
                     "type": "string"
                 },
-                "loadout_slug": {
+                "unit": {
                     "type": "string"
-                },
-                "user_id": {
-                    "type": "integer"
                 }
             }
         },
-        "models.LoadoutPublic": {
+        "models.SpecAttributeNoID": {
             "type": "object",
             "properties": {
-                "created_at": {
+                "label": {
                     "type": "string"
                 },
-                "loadout_description": {
+                "name": {
                     "type": "string"
                 },
-                "loadout_id": {
-                    "type": "integer"
+                "options": {
+                    "type": "array",
+                    "items": {
+                        "type": "string"
+                    }
                 },
-                "loadout_name": {
-                    "type": "string"
+                "required": {
+                    "type": "boolean"
                 },
-                "loadout_slug": {
+                "type": {
                     "type": "string"
                 },
-                "total_weight": {
-                    "type": "integer"
-                },
-                "updated_at": {
+                "unit": {
                     "type": "string"
                 }
             }
         },
-        "models.LoadoutUpdate": {
+        "models.Specs": {
+            "type": "object",
+            "additionalProperties": true
+        },
+        "models.Status": {
             "type": "object",
             "properties": {
-                "loadout_description": {
+                "status": {
+                    "type": "string"
+                }
+            }
+        },
+        "models.Suggestion": {
+            "type": "object",
+            "properties": {
+                "detail": {
                     "type": "string"
                 },
-                "loadout_id": {
+                "id": {
                     "type": "integer"
                 },
-                "loadout_is_public": {
-                    "type": "boolean"
-                },
-                "loadout_name": {
+                "name": {
                     "type": "string"
                 },
-                "loadout_slug": {
+                "score": {
+                    "type": "number"
+                },
+                "type": {
                     "type": "string"
                 }
             }
         },
-        "models.Manufacture": {
+        "models.TagInput": {
             "type": "object",
             "properties": {
-                "manufacture_id": {
+                "tags": {
+                    "type": "array",
+                    "items": {
+                        "type": "string"
+                    }
+                }
+            }
+        },
+        "models.TagSuggestion": {
+            "type": "object",
+            "properties": {
+                "count": {
                     "type": "integer"
                 },
-                "manufacture_name": {
+                "tag": {
                     "type": "string"
                 }
             }
         },
-        "models.ResponsePayload": {
+        "models.Translation": {
             "type": "object",
             "properties": {
-                "current_page": {
-                    "type": "integer"
-                },
-                "item_limit": {
-                    "type": "integer"
+                "fields": {
+                    "type": "object",
+                    "additionalProperties": {
+                        "type": "string"
+                    }
                 },
-                "items": {},
-                "next_page": {
+                "locale": {
                     "type": "string"
                 },
-                "prev_page": {
+                "updated_at": {
                     "type": "string"
-                },
-                "total_item_count": {
-                    "type": "integer"
-                },
-                "total_pages": {
-                    "type": "integer"
                 }
             }
         },
-        "models.Status": {
+        "models.TranslationUpdate": {
             "type": "object",
             "properties": {
-                "status": {
-                    "type": "string"
+                "fields": {
+                    "type": "object",
+                    "additionalProperties": {
+                        "type": "string"
+                    }
                 }
             }
         },
@@ -3196,6 +9665,12 @@ const docTemplate = `{
                 "category_top_category_id": {
                     "type": "integer"
                 },
+                "colour": {
+                    "type": "string"
+                },
+                "condition": {
+                    "type": "string"
+                },
                 "container_link_id": {
                     "type": "integer"
                 },
@@ -3211,6 +9686,9 @@ const docTemplate = `{
                 "gear_id": {
                     "type": "integer"
                 },
+                "gear_image_url": {
+                    "type": "string"
+                },
                 "gear_is_container": {
                     "type": "boolean"
                 },
@@ -3226,6 +9704,9 @@ const docTemplate = `{
                 "gear_status": {
                     "type": "boolean"
                 },
+                "gear_thumbnail_url": {
+                    "type": "string"
+                },
                 "gear_top_category_id": {
                     "type": "integer"
                 },
@@ -3235,6 +9716,9 @@ const docTemplate = `{
                 "gear_width": {
                     "type": "integer"
                 },
+                "image_url": {
+                    "type": "string"
+                },
                 "manufacture_id": {
                     "type": "integer"
                 },
@@ -3244,6 +9728,36 @@ const docTemplate = `{
                 "max_container_weight": {
                     "type": "integer"
                 },
+                "measurements": {
+                    "$ref": "#/definitions/models.Measurements"
+                },
+                "notes": {
+                    "type": "string"
+                },
+                "purchase_currency": {
+                    "type": "string"
+                },
+                "purchase_date": {
+                    "type": "string"
+                },
+                "purchase_price": {
+                    "type": "number"
+                },
+                "retailer": {
+                    "type": "string"
+                },
+                "serial_number": {
+                    "type": "string"
+                },
+                "tags": {
+                    "type": "array",
+                    "items": {
+                        "type": "string"
+                    }
+                },
+                "thumbnail_url": {
+                    "type": "string"
+                },
                 "top_category_icon": {
                     "type": "string"
                 },
@@ -3270,15 +9784,57 @@ const docTemplate = `{
                 },
                 "usergear_user_id": {
                     "type": "integer"
+                },
+                "variant_height": {
+                    "type": "integer"
+                },
+                "variant_id": {
+                    "type": "integer"
+                },
+                "variant_length": {
+                    "type": "integer"
+                },
+                "variant_name": {
+                    "type": "string"
+                },
+                "variant_weight": {
+                    "type": "integer"
+                },
+                "variant_width": {
+                    "type": "integer"
                 }
             }
         },
         "models.UserGearLink": {
             "type": "object",
             "properties": {
+                "colour": {
+                    "type": "string"
+                },
+                "condition": {
+                    "type": "string"
+                },
                 "max_container_weight": {
                     "type": "integer"
                 },
+                "notes": {
+                    "type": "string"
+                },
+                "purchase_currency": {
+                    "type": "string"
+                },
+                "purchase_date": {
+                    "type": "string"
+                },
+                "purchase_price": {
+                    "type": "number"
+                },
+                "retailer": {
+                    "type": "string"
+                },
+                "serial_number": {
+                    "type": "string"
+                },
                 "usergear_gear_id": {
                     "type": "integer"
                 },
@@ -3287,20 +9843,64 @@ const docTemplate = `{
                 },
                 "usergear_user_id": {
                     "type": "integer"
+                },
+                "variant_id": {
+                    "type": "integer"
                 }
             }
         },
         "models.UserGearLinkNoID": {
             "type": "object",
             "properties": {
+                "colour": {
+                    "type": "string"
+                },
+                "condition": {
+                    "type": "string"
+                },
                 "max_container_weight": {
                     "type": "integer"
                 },
+                "notes": {
+                    "type": "string"
+                },
+                "purchase_currency": {
+                    "type": "string"
+                },
+                "purchase_date": {
+                    "type": "string"
+                },
+                "purchase_price": {
+                    "type": "number"
+                },
+                "retailer": {
+                    "type": "string"
+                },
+                "serial_number": {
+                    "type": "string"
+                },
                 "usergear_gear_id": {
                     "type": "integer"
                 },
                 "usergear_user_id": {
                     "type": "integer"
+                },
+                "variant_id": {
+                    "type": "integer"
+                }
+            }
+        },
+        "models.UserPreferences": {
+            "type": "object",
+            "properties": {
+                "locale": {
+                    "type": "string"
+                },
+                "unit_system": {
+                    "type": "string"
+                },
+                "user_id": {
+                    "type": "integer"
                 }
             }
         },
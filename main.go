@@ -7,6 +7,7 @@ import (
 	"fmt"
 	"log"
 	"net/http"
+	"os"
 	"strings"
 
 	endpoints "github.com/Sea-Shell/gogear-api/pkg/api"
@@ -79,6 +80,34 @@ func databaseMiddleware(db *sql.DB) gin.HandlerFunc {
 	}
 }
 
+func suggestIndexMiddleware(index *utils.SuggestIndex) gin.HandlerFunc {
+	return func(c *gin.Context) {
+		c.Set("suggest_index", index)
+		c.Next()
+	}
+}
+
+func gearStatsMiddleware(stats *utils.GearStats) gin.HandlerFunc {
+	return func(c *gin.Context) {
+		c.Set("gear_stats", stats)
+		c.Next()
+	}
+}
+
+func gearRecommenderMiddleware(recommender *utils.GearRecommender) gin.HandlerFunc {
+	return func(c *gin.Context) {
+		c.Set("gear_recommender", recommender)
+		c.Next()
+	}
+}
+
+func storageMiddleware(storage utils.Storage) gin.HandlerFunc {
+	return func(c *gin.Context) {
+		c.Set("storage", storage)
+		c.Next()
+	}
+}
+
 func configMiddleware(config *models.Config) gin.HandlerFunc {
 	return func(c *gin.Context) {
 		c.Set("config", config)
@@ -163,9 +192,25 @@ func main() {
 		log.Fatalf("Failed to ensure user_gear_registrations.maxContainerWeight exists: %v", err)
 	}
 
+	// The FTS5 gear index lives outside the migrations since FTS5 is a build-time option.
+	hasSearchIndex, err := utils.EnsureGearSearchIndex(db)
+	if err != nil {
+		log.Fatalf("Failed to ensure gear search index exists: %v", err)
+	}
+	if !hasSearchIndex {
+		log.Warn("SQLite was built without FTS5 (build tag sqlite_fts5); gear search falls back to LIKE matching")
+	}
+
 	log.Infoln("Connected to database")
 	defer db.Close()
 
+	if flag.NArg() > 0 {
+		if err := runCommand(db, flag.Args(), os.Stdin, os.Stdout); err != nil {
+			log.Fatalf("%s: %v", flag.Arg(0), err)
+		}
+		return
+	}
+
 	docs.SwaggerInfo.Title = "GoGear API"
 	docs.SwaggerInfo.Description = "This is the API of GoGear."
 	docs.SwaggerInfo.Host = config.General.Hostname
@@ -188,6 +233,11 @@ func main() {
 
 	router.Use(LogRequestsMiddleware(log))
 	router.Use(databaseMiddleware(db))
+
+	suggestIndex := utils.NewSuggestIndex()
+	router.Use(suggestIndexMiddleware(suggestIndex))
+	router.Use(gearStatsMiddleware(utils.NewGearStats()))
+	router.Use(gearRecommenderMiddleware(utils.NewGearRecommender()))
 	googleConf = &oauth2.Config{
 		ClientID:     config.Auth.GoogleClientID,
 		ClientSecret: config.Auth.GoogleClientSecret,
@@ -206,6 +256,17 @@ func main() {
 	router.Use(configMiddleware(config))
 	router.Use(oauthConfigMiddleware(googleConf))
 
+	storage, err := utils.NewStorage(config.Storage)
+	if err != nil {
+		log.Fatalf("Failed to set up image storage: %v", err)
+	}
+	router.Use(storageMiddleware(storage))
+
+	// Local uploads are served by the API itself unless the base URL points elsewhere.
+	if local, ok := storage.(*utils.LocalStorage); ok && strings.HasPrefix(local.BaseURL(), "/") {
+		router.StaticFS(local.BaseURL(), gin.Dir(local.Root(), false))
+	}
+
 	// API v1
 	swagger := router.Group("/swagger")
 	v1 := router.Group("/api/v1")
@@ -221,6 +282,11 @@ func main() {
 	userGearGroup := v1.Group("/usergear")
 	containerGroup := v1.Group("/container")
 
+	// Catalog writes make the suggestion index reload on its next lookup.
+	gearGroup.Use(suggestIndex.InvalidateOnWrite())
+	categoryGroup.Use(suggestIndex.InvalidateOnWrite())
+	manufactureGroup.Use(suggestIndex.InvalidateOnWrite())
+
 	// The routes
 	router.GET("/health", endpoints.ReturnHealth)
 
@@ -240,13 +306,51 @@ func main() {
 	userGroup.POST("/:user/update", endpoints.UpdateUser)
 	userGroup.DELETE("/:user/delete", endpoints.DeleteUser)
 	userGroup.PUT("/insert", endpoints.InsertUser)
+	userGroup.GET("/preferences/get", endpoints.GetUserPreferences)
+	userGroup.POST("/preferences/update", endpoints.UpdateUserPreferences)
 	// userGroup.POST("/setpassword", endpoints.SetUserPassword)
 
 	// Gear endpoints
 	gearGroup.GET("/list", endpoints.ListGear)
 	gearGroup.GET("/search", endpoints.SearchGear)
+	gearGroup.GET("/suggest", endpoints.SuggestGear)
+	gearGroup.GET("/compare", endpoints.CompareGear)
+	gearGroup.GET("/stats/owned", endpoints.ListMostOwnedGear)
+	gearGroup.GET("/stats/used", endpoints.ListMostUsedGear)
+	gearGroup.GET("/stats/recent", endpoints.ListRecentlyAddedGear)
+	gearGroup.GET("/recommendation/list", endpoints.ListMyGearRecommendations)
+	gearGroup.POST("/import", endpoints.ImportGear)
+	gearGroup.GET("/export", endpoints.ExportGear)
+	gearGroup.GET("/review/list", endpoints.ListGearReviewQueue)
+	gearGroup.GET("/submission/list", endpoints.ListGearSubmissions)
+	gearGroup.GET("/duplicate/list", endpoints.ListGearDuplicates)
+	gearGroup.GET("/:gear/variant/list", endpoints.ListGearVariants)
+	gearGroup.PUT("/:gear/variant/insert", endpoints.InsertGearVariant)
+	gearGroup.GET("/:gear/variant/:variant/get", endpoints.GetGearVariant)
+	gearGroup.POST("/:gear/variant/:variant/update", endpoints.UpdateGearVariant)
+	gearGroup.DELETE("/:gear/variant/:variant/delete", endpoints.DeleteGearVariant)
+	gearGroup.GET("/:gear/image/list", endpoints.ListGearImages)
+	gearGroup.PUT("/:gear/image/upload", endpoints.UploadGearImage)
+	gearGroup.DELETE("/:gear/image/:image/delete", endpoints.DeleteGearImage)
+	gearGroup.GET("/:gear/review/list", endpoints.ListGearReviews)
+	gearGroup.PUT("/:gear/review/insert", endpoints.InsertGearReview)
+	gearGroup.POST("/:gear/review/:review/update", endpoints.UpdateGearReview)
+	gearGroup.DELETE("/:gear/review/:review/delete", endpoints.DeleteGearReview)
+	gearGroup.POST("/:gear/review/:review/hide", endpoints.HideGearReview)
+	gearGroup.GET("/:gear/tag/list", endpoints.ListGearTags)
+	gearGroup.GET("/:gear/recommendation/list", endpoints.ListGearRecommendations)
+	gearGroup.PUT("/:gear/tag/insert", endpoints.InsertGearTags)
+	gearGroup.DELETE("/:gear/tag/:tag/delete", endpoints.DeleteGearTag)
+	gearGroup.GET("/:gear/translation/list", endpoints.ListGearTranslations)
+	gearGroup.POST("/:gear/translation/:locale/update", endpoints.UpdateGearTranslation)
+	gearGroup.DELETE("/:gear/translation/:locale/delete", endpoints.DeleteGearTranslation)
+	gearGroup.GET("/:gear/revision/list", endpoints.ListGearRevisions)
+	gearGroup.GET("/:gear/revision/diff", endpoints.DiffGearRevisions)
+	gearGroup.POST("/:gear/revision/:revision/revert", endpoints.RevertGearRevision)
 	gearGroup.GET("/:gear/get", endpoints.GetGear)
 	gearGroup.POST("/:gear/update", endpoints.UpdateGear)
+	gearGroup.POST("/:gear/review", endpoints.ReviewGearSubmission)
+	gearGroup.POST("/:gear/merge", endpoints.MergeGear)
 	gearGroup.DELETE("/:gear/delete", endpoints.DeleteGear)
 	gearGroup.PUT("/insert", endpoints.InsertGear)
 
@@ -255,6 +359,12 @@ func main() {
 	userGearGroup.GET("/registration/:usergear/get", endpoints.GetUserGear)
 	userGearGroup.POST("/registration/:usergear/update", endpoints.UpdateUserGear)
 	userGearGroup.DELETE("/registration/:usergear/delete", endpoints.DeleteUserGearRegistration)
+	userGearGroup.GET("/registration/:usergear/image/list", endpoints.ListUserGearImages)
+	userGearGroup.PUT("/registration/:usergear/image/upload", endpoints.UploadUserGearImage)
+	userGearGroup.DELETE("/registration/:usergear/image/:image/delete", endpoints.DeleteUserGearImage)
+	userGearGroup.GET("/registration/:usergear/tag/list", endpoints.ListUserGearTags)
+	userGearGroup.PUT("/registration/:usergear/tag/insert", endpoints.InsertUserGearTags)
+	userGearGroup.DELETE("/registration/:usergear/tag/:tag/delete", endpoints.DeleteUserGearTag)
 	userGearGroup.PUT("/insert", endpoints.InsertUserGear)
 
 	// Container endpoints
@@ -268,18 +378,41 @@ func main() {
 	topCategoryGroup.POST("/:topCategory/update", endpoints.UpdateTopCategory)
 	topCategoryGroup.DELETE("/:topCategory/delete", endpoints.DeleteTopCategory)
 	topCategoryGroup.PUT("/insert", endpoints.InsertTopCategory)
+	topCategoryGroup.GET("/:topCategory/translation/list", endpoints.ListTopCategoryTranslations)
+	topCategoryGroup.POST("/:topCategory/translation/:locale/update", endpoints.UpdateTopCategoryTranslation)
+	topCategoryGroup.DELETE("/:topCategory/translation/:locale/delete", endpoints.DeleteTopCategoryTranslation)
+	topCategoryGroup.GET("/:topCategory/revision/list", endpoints.ListTopCategoryRevisions)
+	topCategoryGroup.GET("/:topCategory/revision/diff", endpoints.DiffTopCategoryRevisions)
+	topCategoryGroup.POST("/:topCategory/revision/:revision/revert", endpoints.RevertTopCategoryRevision)
 
 	// Category endpoints
 	categoryGroup.GET("/list", endpoints.ListCategory)
+	categoryGroup.GET("/tree", endpoints.GetCategoryTree)
+	categoryGroup.POST("/reorder", endpoints.ReorderCategories)
 	categoryGroup.GET("/:category/get", endpoints.GetCategory)
 	categoryGroup.POST("/:category/update", endpoints.UpdateCategory)
+	categoryGroup.POST("/:category/move", endpoints.MoveCategory)
 	categoryGroup.DELETE("/:category/delete", endpoints.DeleteCategory)
 	categoryGroup.PUT("/insert", endpoints.InsertCategory)
+	categoryGroup.GET("/:category/spec/list", endpoints.ListCategorySpecs)
+	categoryGroup.PUT("/:category/spec/insert", endpoints.InsertCategorySpec)
+	categoryGroup.POST("/:category/spec/:attribute/update", endpoints.UpdateCategorySpec)
+	categoryGroup.DELETE("/:category/spec/:attribute/delete", endpoints.DeleteCategorySpec)
+	categoryGroup.GET("/:category/translation/list", endpoints.ListCategoryTranslations)
+	categoryGroup.POST("/:category/translation/:locale/update", endpoints.UpdateCategoryTranslation)
+	categoryGroup.DELETE("/:category/translation/:locale/delete", endpoints.DeleteCategoryTranslation)
+	categoryGroup.GET("/:category/revision/list", endpoints.ListCategoryRevisions)
+	categoryGroup.GET("/:category/revision/diff", endpoints.DiffCategoryRevisions)
+	categoryGroup.POST("/:category/revision/:revision/revert", endpoints.RevertCategoryRevision)
 
 	// Manufacture endpoints
 	manufactureGroup.GET("/list", endpoints.ListManufacture)
 	manufactureGroup.GET("/:manufacture/get", endpoints.GetManufacture)
+	manufactureGroup.GET("/:manufacture/details", endpoints.GetManufactureDetails)
 	manufactureGroup.POST("/:manufacture/update", endpoints.UpdateManufacture)
+	manufactureGroup.GET("/:manufacture/revision/list", endpoints.ListManufactureRevisions)
+	manufactureGroup.GET("/:manufacture/revision/diff", endpoints.DiffManufactureRevisions)
+	manufactureGroup.POST("/:manufacture/revision/:revision/revert", endpoints.RevertManufactureRevision)
 	manufactureGroup.DELETE("/:manufacture/delete", endpoints.DeleteManufature)
 	manufactureGroup.PUT("/insert", endpoints.InsertManufacture)
 
@@ -297,6 +430,21 @@ func main() {
 	loadoutGroup.GET("/:loadout/item/list", endpoints.ListLoadoutItems)
 	loadoutGroup.POST("/:loadout/item/:item/update", endpoints.UpdateLoadoutItem)
 	loadoutGroup.DELETE("/:loadout/item/:item/delete", endpoints.DeleteLoadoutItem)
+	loadoutGroup.POST("/:loadout/item/:item/swap", endpoints.SwapLoadoutItem)
+	loadoutGroup.GET("/:loadout/alternative/list", endpoints.ListLoadoutAlternatives)
+
+	// Loadout tag endpoints
+	loadoutGroup.GET("/:loadout/tag/list", endpoints.ListLoadoutTags)
+	loadoutGroup.PUT("/:loadout/tag/insert", endpoints.InsertLoadoutTags)
+	loadoutGroup.DELETE("/:loadout/tag/:tag/delete", endpoints.DeleteLoadoutTag)
+
+	// Tag endpoints
+	tagGroup := v1.Group("/tag")
+	tagGroup.GET("/suggest", endpoints.SuggestTags)
+
+	// Translation endpoints
+	translationGroup := v1.Group("/translation")
+	translationGroup.GET("/missing/list", endpoints.ListMissingTranslations)
 
 	// Swagger API documentation
 	swagger.GET("/*any", ginSwagger.WrapHandler(swaggerFiles.Handler))
@@ -102,6 +102,20 @@ func TestMigrationsUp(t *testing.T) {
 		"user_container_registration",
 		"loadouts",
 		"loadout_items",
+		"gear_variants",
+		"user_preferences",
+		"images",
+		"category_spec_attributes",
+		"gear_spec_values",
+		"gear_tags",
+		"user_gear_registration_tags",
+		"loadout_tags",
+		"gear_redirects",
+		"gear_reviews",
+		"top_category_translations",
+		"category_translations",
+		"gear_translations",
+		"catalog_revisions",
 	}
 	for _, table := range expectedTables {
 		err := db.QueryRow("SELECT COUNT(*) FROM sqlite_master WHERE type='table' AND name=?", table).Scan(&count)
@@ -120,8 +134,8 @@ func TestMigrationsUp(t *testing.T) {
 	if err != nil {
 		t.Fatalf("read schema_migrations: %v", err)
 	}
-	if version != 3 {
-		t.Errorf("expected version 3, got %d", version)
+	if version != 17 {
+		t.Errorf("expected version 17, got %d", version)
 	}
 	if dirty {
 		t.Error("expected clean migration")
@@ -145,7 +159,7 @@ func TestMigrationsUpDown(t *testing.T) {
 		t.Fatal("loadouts should exist after up")
 	}
 
-	// Down rolls back ALL migrations (V003, V002, V001)
+	// Down rolls back ALL migrations (V017 … V001)
 	runMigrateDown(t, db, path)
 
 	// After full rollback, baseline tables (V001) are also dropped
@@ -159,6 +173,20 @@ func TestMigrationsUpDown(t *testing.T) {
 		"user_container_registration",
 		"loadouts",
 		"loadout_items",
+		"gear_variants",
+		"user_preferences",
+		"images",
+		"category_spec_attributes",
+		"gear_spec_values",
+		"gear_tags",
+		"user_gear_registration_tags",
+		"loadout_tags",
+		"gear_redirects",
+		"gear_reviews",
+		"top_category_translations",
+		"category_translations",
+		"gear_translations",
+		"catalog_revisions",
 	}
 	for _, table := range allTables {
 		err := db.QueryRow("SELECT COUNT(*) FROM sqlite_master WHERE type='table' AND name=?", table).Scan(&count)
@@ -179,13 +207,13 @@ func TestMigrationsIdempotentUp(t *testing.T) {
 	runMigrate(t, db, path)
 	runMigrate(t, db, path)
 
-	// Should still be at version 3
+	// Should still be at version 17
 	var version int
 	err := db.QueryRow("SELECT version FROM schema_migrations").Scan(&version)
 	if err != nil {
 		t.Fatalf("read schema_migrations: %v", err)
 	}
-	if version != 3 {
-		t.Errorf("expected version 3 after second up, got %d", version)
+	if version != 17 {
+		t.Errorf("expected version 17 after second up, got %d", version)
 	}
 }
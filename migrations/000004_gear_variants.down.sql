@@ -0,0 +1,10 @@
+-- Drop gear_variants table and variant selection
+
+DROP TRIGGER IF EXISTS gear_delete_variants;
+DROP TRIGGER IF EXISTS gear_variants_delete;
+DROP INDEX IF EXISTS idx_loadout_items_variant;
+DROP INDEX IF EXISTS idx_user_gear_registrations_variant;
+ALTER TABLE loadout_items DROP COLUMN variantId;
+ALTER TABLE user_gear_registrations DROP COLUMN variantId;
+DROP INDEX IF EXISTS idx_gear_variants_gear_name;
+DROP TABLE IF EXISTS gear_variants;
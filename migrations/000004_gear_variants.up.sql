@@ -0,0 +1,34 @@
+-- Create gear_variants table for per-size weight and dimensions, and let
+-- registrations and loadout items pick a variant
+
+CREATE TABLE IF NOT EXISTS gear_variants (
+    variantId INTEGER PRIMARY KEY AUTOINCREMENT,
+    gearId INTEGER NOT NULL,
+    variantName TEXT NOT NULL,
+    variantWeight INTEGER NOT NULL DEFAULT 0,
+    variantHeight INTEGER NOT NULL DEFAULT 0,
+    variantLength INTEGER NOT NULL DEFAULT 0,
+    variantWidth INTEGER NOT NULL DEFAULT 0,
+    FOREIGN KEY (gearId) REFERENCES gear(gearId) ON DELETE CASCADE
+);
+
+CREATE UNIQUE INDEX IF NOT EXISTS idx_gear_variants_gear_name ON gear_variants(gearId, variantName);
+
+ALTER TABLE user_gear_registrations ADD COLUMN variantId INTEGER;
+ALTER TABLE loadout_items ADD COLUMN variantId INTEGER;
+
+CREATE INDEX IF NOT EXISTS idx_user_gear_registrations_variant ON user_gear_registrations(variantId);
+CREATE INDEX IF NOT EXISTS idx_loadout_items_variant ON loadout_items(variantId);
+
+-- Added columns cannot be dropped again if they carry a foreign key, so deleted
+-- variants are unset by trigger instead.
+CREATE TRIGGER IF NOT EXISTS gear_variants_delete AFTER DELETE ON gear_variants BEGIN
+    UPDATE user_gear_registrations SET variantId = NULL WHERE variantId = OLD.variantId;
+    UPDATE loadout_items SET variantId = NULL WHERE variantId = OLD.variantId;
+END;
+
+-- Foreign keys are not enforced, so ON DELETE CASCADE does not fire; remove a
+-- deleted gear's variants here, which in turn unsets them through the trigger above.
+CREATE TRIGGER IF NOT EXISTS gear_delete_variants AFTER DELETE ON gear BEGIN
+    DELETE FROM gear_variants WHERE gearId = OLD.gearId;
+END;
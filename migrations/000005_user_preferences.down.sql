@@ -0,0 +1,4 @@
+-- Drop per-user preferences
+
+DROP TRIGGER IF EXISTS users_delete_preferences;
+DROP TABLE IF EXISTS user_preferences;
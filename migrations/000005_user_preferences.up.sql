@@ -0,0 +1,12 @@
+-- Per-user preferences, starting with the unit system responses are converted to.
+-- Gear weights are stored in grams and dimensions in millimetres.
+
+CREATE TABLE IF NOT EXISTS user_preferences (
+    userId INTEGER PRIMARY KEY,
+    unitSystem TEXT,
+    FOREIGN KEY (userId) REFERENCES users(userId) ON DELETE CASCADE
+);
+
+CREATE TRIGGER IF NOT EXISTS users_delete_preferences AFTER DELETE ON users BEGIN
+    DELETE FROM user_preferences WHERE userId = OLD.userId;
+END;
@@ -0,0 +1,7 @@
+-- Drop gear and user gear images
+
+DROP TRIGGER IF EXISTS user_gear_registrations_delete_images;
+DROP TRIGGER IF EXISTS gear_delete_images;
+DROP INDEX IF EXISTS images_registration;
+DROP INDEX IF EXISTS images_gear;
+DROP TABLE IF EXISTS images;
@@ -0,0 +1,31 @@
+-- Photos of catalog gear and of users' registered items. Each row belongs to exactly one
+-- of the two; the files themselves live in the configured storage under imageKey and
+-- thumbnailKey.
+
+CREATE TABLE IF NOT EXISTS images (
+    imageId INTEGER PRIMARY KEY AUTOINCREMENT,
+    gearId INTEGER,
+    userGearRegistrationId INTEGER,
+    imageKey TEXT NOT NULL,
+    thumbnailKey TEXT NOT NULL,
+    contentType TEXT NOT NULL,
+    imageSize INTEGER NOT NULL,
+    imageWidth INTEGER NOT NULL,
+    imageHeight INTEGER NOT NULL,
+    uploadedBy INTEGER NOT NULL,
+    createdAt TEXT NOT NULL DEFAULT CURRENT_TIMESTAMP,
+    CHECK ((gearId IS NULL) <> (userGearRegistrationId IS NULL)),
+    FOREIGN KEY (gearId) REFERENCES gear(gearId) ON DELETE CASCADE,
+    FOREIGN KEY (userGearRegistrationId) REFERENCES user_gear_registrations(userGearRegistrationId) ON DELETE CASCADE
+);
+
+CREATE INDEX IF NOT EXISTS images_gear ON images (gearId, imageId);
+CREATE INDEX IF NOT EXISTS images_registration ON images (userGearRegistrationId, imageId);
+
+CREATE TRIGGER IF NOT EXISTS gear_delete_images AFTER DELETE ON gear BEGIN
+    DELETE FROM images WHERE gearId = OLD.gearId;
+END;
+
+CREATE TRIGGER IF NOT EXISTS user_gear_registrations_delete_images AFTER DELETE ON user_gear_registrations BEGIN
+    DELETE FROM images WHERE userGearRegistrationId = OLD.userGearRegistrationId;
+END;
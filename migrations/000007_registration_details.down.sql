@@ -0,0 +1,11 @@
+-- Drop the ownership details of user gear registrations
+
+DROP INDEX IF EXISTS user_gear_registrations_purchase_date;
+ALTER TABLE user_gear_registrations DROP COLUMN notes;
+ALTER TABLE user_gear_registrations DROP COLUMN colour;
+ALTER TABLE user_gear_registrations DROP COLUMN serialNumber;
+ALTER TABLE user_gear_registrations DROP COLUMN itemCondition;
+ALTER TABLE user_gear_registrations DROP COLUMN retailer;
+ALTER TABLE user_gear_registrations DROP COLUMN purchaseCurrency;
+ALTER TABLE user_gear_registrations DROP COLUMN purchasePrice;
+ALTER TABLE user_gear_registrations DROP COLUMN purchaseDate;
@@ -0,0 +1,14 @@
+-- Optional details about a user's own copy of an item. purchaseDate is YYYY-MM-DD,
+-- purchasePrice is in purchaseCurrency (ISO 4217) and itemCondition is one of new,
+-- like_new, good, fair or poor.
+
+ALTER TABLE user_gear_registrations ADD COLUMN purchaseDate TEXT;
+ALTER TABLE user_gear_registrations ADD COLUMN purchasePrice REAL;
+ALTER TABLE user_gear_registrations ADD COLUMN purchaseCurrency TEXT;
+ALTER TABLE user_gear_registrations ADD COLUMN retailer TEXT;
+ALTER TABLE user_gear_registrations ADD COLUMN itemCondition TEXT;
+ALTER TABLE user_gear_registrations ADD COLUMN serialNumber TEXT;
+ALTER TABLE user_gear_registrations ADD COLUMN colour TEXT;
+ALTER TABLE user_gear_registrations ADD COLUMN notes TEXT;
+
+CREATE INDEX IF NOT EXISTS user_gear_registrations_purchase_date ON user_gear_registrations (userId, purchaseDate);
@@ -0,0 +1,8 @@
+-- Drop category specification schemas and gear spec values
+
+DROP TRIGGER IF EXISTS gear_category_delete_spec_attributes;
+DROP TRIGGER IF EXISTS category_spec_attributes_delete_values;
+DROP TRIGGER IF EXISTS gear_delete_spec_values;
+DROP INDEX IF EXISTS gear_spec_values_attribute;
+DROP TABLE IF EXISTS gear_spec_values;
+DROP TABLE IF EXISTS category_spec_attributes;
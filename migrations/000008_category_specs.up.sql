@@ -0,0 +1,39 @@
+-- Per-category specification schemas and the values gear has for them. Numbers and
+-- booleans (0 or 1) are kept in valueNumber, enum and text values in valueText.
+
+CREATE TABLE IF NOT EXISTS category_spec_attributes (
+    attributeId INTEGER PRIMARY KEY AUTOINCREMENT,
+    categoryId INTEGER NOT NULL,
+    attributeName TEXT NOT NULL,
+    attributeLabel TEXT NOT NULL,
+    attributeType TEXT NOT NULL CHECK (attributeType IN ('number', 'enum', 'bool', 'text')),
+    attributeUnit TEXT,
+    attributeOptions TEXT,
+    attributeRequired INTEGER NOT NULL DEFAULT 0,
+    UNIQUE (categoryId, attributeName),
+    FOREIGN KEY (categoryId) REFERENCES gear_category(categoryId) ON DELETE CASCADE
+);
+
+CREATE TABLE IF NOT EXISTS gear_spec_values (
+    gearId INTEGER NOT NULL,
+    attributeId INTEGER NOT NULL,
+    valueNumber REAL,
+    valueText TEXT,
+    PRIMARY KEY (gearId, attributeId),
+    FOREIGN KEY (gearId) REFERENCES gear(gearId) ON DELETE CASCADE,
+    FOREIGN KEY (attributeId) REFERENCES category_spec_attributes(attributeId) ON DELETE CASCADE
+);
+
+CREATE INDEX IF NOT EXISTS gear_spec_values_attribute ON gear_spec_values (attributeId, valueNumber);
+
+CREATE TRIGGER IF NOT EXISTS gear_delete_spec_values AFTER DELETE ON gear BEGIN
+    DELETE FROM gear_spec_values WHERE gearId = OLD.gearId;
+END;
+
+CREATE TRIGGER IF NOT EXISTS category_spec_attributes_delete_values AFTER DELETE ON category_spec_attributes BEGIN
+    DELETE FROM gear_spec_values WHERE attributeId = OLD.attributeId;
+END;
+
+CREATE TRIGGER IF NOT EXISTS gear_category_delete_spec_attributes AFTER DELETE ON gear_category BEGIN
+    DELETE FROM category_spec_attributes WHERE categoryId = OLD.categoryId;
+END;
@@ -0,0 +1,11 @@
+-- Drop gear, registration and loadout tags
+
+DROP TRIGGER IF EXISTS loadouts_delete_tags;
+DROP TRIGGER IF EXISTS user_gear_registrations_delete_tags;
+DROP TRIGGER IF EXISTS gear_delete_tags;
+DROP INDEX IF EXISTS loadout_tags_name;
+DROP INDEX IF EXISTS user_gear_registration_tags_name;
+DROP INDEX IF EXISTS gear_tags_name;
+DROP TABLE IF EXISTS loadout_tags;
+DROP TABLE IF EXISTS user_gear_registration_tags;
+DROP TABLE IF EXISTS gear_tags;
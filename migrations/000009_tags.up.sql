@@ -0,0 +1,42 @@
+-- Free-form tags. Gear tags are shared catalog tags; registration and loadout tags are
+-- private to the user owning the registration or loadout. Names are stored normalized.
+
+CREATE TABLE IF NOT EXISTS gear_tags (
+    gearId INTEGER NOT NULL,
+    tagName TEXT NOT NULL,
+    createdAt TEXT NOT NULL DEFAULT (strftime('%Y-%m-%dT%H:%M:%fZ', 'now')),
+    PRIMARY KEY (gearId, tagName),
+    FOREIGN KEY (gearId) REFERENCES gear(gearId) ON DELETE CASCADE
+);
+
+CREATE TABLE IF NOT EXISTS user_gear_registration_tags (
+    userGearRegistrationId INTEGER NOT NULL,
+    tagName TEXT NOT NULL,
+    createdAt TEXT NOT NULL DEFAULT (strftime('%Y-%m-%dT%H:%M:%fZ', 'now')),
+    PRIMARY KEY (userGearRegistrationId, tagName),
+    FOREIGN KEY (userGearRegistrationId) REFERENCES user_gear_registrations(userGearRegistrationId) ON DELETE CASCADE
+);
+
+CREATE TABLE IF NOT EXISTS loadout_tags (
+    loadoutId INTEGER NOT NULL,
+    tagName TEXT NOT NULL,
+    createdAt TEXT NOT NULL DEFAULT (strftime('%Y-%m-%dT%H:%M:%fZ', 'now')),
+    PRIMARY KEY (loadoutId, tagName),
+    FOREIGN KEY (loadoutId) REFERENCES loadouts(loadoutId) ON DELETE CASCADE
+);
+
+CREATE INDEX IF NOT EXISTS gear_tags_name ON gear_tags (tagName);
+CREATE INDEX IF NOT EXISTS user_gear_registration_tags_name ON user_gear_registration_tags (tagName);
+CREATE INDEX IF NOT EXISTS loadout_tags_name ON loadout_tags (tagName);
+
+CREATE TRIGGER IF NOT EXISTS gear_delete_tags AFTER DELETE ON gear BEGIN
+    DELETE FROM gear_tags WHERE gearId = OLD.gearId;
+END;
+
+CREATE TRIGGER IF NOT EXISTS user_gear_registrations_delete_tags AFTER DELETE ON user_gear_registrations BEGIN
+    DELETE FROM user_gear_registration_tags WHERE userGearRegistrationId = OLD.userGearRegistrationId;
+END;
+
+CREATE TRIGGER IF NOT EXISTS loadouts_delete_tags AFTER DELETE ON loadouts BEGIN
+    DELETE FROM loadout_tags WHERE loadoutId = OLD.loadoutId;
+END;
@@ -0,0 +1,7 @@
+-- Drop the manufacturer profile details
+
+ALTER TABLE manufacture DROP COLUMN manufactureDescription;
+ALTER TABLE manufacture DROP COLUMN manufactureLogoUrl;
+ALTER TABLE manufacture DROP COLUMN manufactureFoundedYear;
+ALTER TABLE manufacture DROP COLUMN manufactureCountry;
+ALTER TABLE manufacture DROP COLUMN manufactureWebsite;
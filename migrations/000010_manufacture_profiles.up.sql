@@ -0,0 +1,8 @@
+-- Manufacturer profile details. manufactureCountry is an ISO 3166-1 alpha-2 code and
+-- manufactureLogoUrl an absolute http(s) URL.
+
+ALTER TABLE manufacture ADD COLUMN manufactureWebsite TEXT;
+ALTER TABLE manufacture ADD COLUMN manufactureCountry TEXT;
+ALTER TABLE manufacture ADD COLUMN manufactureFoundedYear INTEGER;
+ALTER TABLE manufacture ADD COLUMN manufactureLogoUrl TEXT;
+ALTER TABLE manufacture ADD COLUMN manufactureDescription TEXT;
@@ -0,0 +1,10 @@
+-- Flatten the category tree back to two levels
+
+DROP TRIGGER IF EXISTS gear_category_delete_children;
+DROP TRIGGER IF EXISTS gear_top_category_update;
+DROP TRIGGER IF EXISTS gear_top_category_insert;
+DROP INDEX IF EXISTS gear_category_parent;
+
+ALTER TABLE gear_category DROP COLUMN categorySortOrder;
+ALTER TABLE gear_category DROP COLUMN categoryParentId;
+ALTER TABLE gear_top_category DROP COLUMN topCategorySortOrder;
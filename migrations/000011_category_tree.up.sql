@@ -0,0 +1,33 @@
+-- Categories nest below other categories. Top categories stay the roots of the tree: a
+-- category without categoryParentId sits directly below its top category, and
+-- categoryTopCategoryId always holds the root of its branch. Siblings are ordered by their
+-- sort order, then by name.
+
+ALTER TABLE gear_top_category ADD COLUMN topCategorySortOrder INTEGER NOT NULL DEFAULT 0;
+ALTER TABLE gear_category ADD COLUMN categoryParentId INTEGER;
+ALTER TABLE gear_category ADD COLUMN categorySortOrder INTEGER NOT NULL DEFAULT 0;
+
+CREATE INDEX gear_category_parent ON gear_category (categoryParentId);
+
+-- The top category of gear follows its category; fix rows that drifted apart.
+UPDATE gear SET gearTopCategoryId = (SELECT categoryTopCategoryId FROM gear_category WHERE categoryId = gear.gearCategoryId)
+WHERE EXISTS (SELECT 1 FROM gear_category WHERE categoryId = gear.gearCategoryId);
+
+CREATE TRIGGER gear_top_category_insert AFTER INSERT ON gear
+WHEN EXISTS (SELECT 1 FROM gear_category WHERE categoryId = NEW.gearCategoryId)
+BEGIN
+    UPDATE gear SET gearTopCategoryId = (SELECT categoryTopCategoryId FROM gear_category WHERE categoryId = NEW.gearCategoryId)
+    WHERE gearId = NEW.gearId;
+END;
+
+CREATE TRIGGER gear_top_category_update AFTER UPDATE OF gearTopCategoryId, gearCategoryId ON gear
+WHEN EXISTS (SELECT 1 FROM gear_category WHERE categoryId = NEW.gearCategoryId AND categoryTopCategoryId != NEW.gearTopCategoryId)
+BEGIN
+    UPDATE gear SET gearTopCategoryId = (SELECT categoryTopCategoryId FROM gear_category WHERE categoryId = NEW.gearCategoryId)
+    WHERE gearId = NEW.gearId;
+END;
+
+-- Children of a deleted category move up to its parent.
+CREATE TRIGGER gear_category_delete_children AFTER DELETE ON gear_category BEGIN
+    UPDATE gear_category SET categoryParentId = OLD.categoryParentId WHERE categoryParentId = OLD.categoryId;
+END;
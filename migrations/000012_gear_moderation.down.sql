@@ -0,0 +1,11 @@
+-- Drop gear moderation
+
+DROP INDEX IF EXISTS gear_submitted_by;
+DROP INDEX IF EXISTS gear_review_status;
+
+ALTER TABLE gear DROP COLUMN gearReviewedAt;
+ALTER TABLE gear DROP COLUMN gearReviewedBy;
+ALTER TABLE gear DROP COLUMN gearReviewReason;
+ALTER TABLE gear DROP COLUMN gearReviewStatus;
+ALTER TABLE gear DROP COLUMN gearSubmittedAt;
+ALTER TABLE gear DROP COLUMN gearSubmittedBy;
@@ -0,0 +1,18 @@
+-- Moderation of community gear submissions. gearStatus is true for gear in the shared
+-- catalog. Gear submitted by non-admins starts out pending with gearStatus false and is
+-- only visible to its submitter until an admin approves it; gear without a status counts
+-- as catalog gear. gearReviewStatus is pending, approved or rejected, and
+-- gearReviewReason explains the decision.
+
+ALTER TABLE gear ADD COLUMN gearSubmittedBy INTEGER;
+ALTER TABLE gear ADD COLUMN gearSubmittedAt TEXT;
+ALTER TABLE gear ADD COLUMN gearReviewStatus TEXT NOT NULL DEFAULT 'approved';
+ALTER TABLE gear ADD COLUMN gearReviewReason TEXT;
+ALTER TABLE gear ADD COLUMN gearReviewedBy INTEGER;
+ALTER TABLE gear ADD COLUMN gearReviewedAt TEXT;
+
+CREATE INDEX gear_review_status ON gear (gearReviewStatus);
+CREATE INDEX gear_submitted_by ON gear (gearSubmittedBy);
+
+-- Until now every gear row was part of the catalog.
+UPDATE gear SET gearStatus = 1;
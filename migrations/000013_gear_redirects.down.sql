@@ -0,0 +1,5 @@
+-- Drop gear redirects
+
+DROP TRIGGER IF EXISTS gear_delete_redirects;
+DROP INDEX IF EXISTS gear_redirects_to;
+DROP TABLE IF EXISTS gear_redirects;
@@ -0,0 +1,16 @@
+-- Redirects left behind when duplicate gear is merged. A request for fromGearId is
+-- answered with toGearId, the gear it was merged into.
+
+CREATE TABLE IF NOT EXISTS gear_redirects (
+    fromGearId INTEGER PRIMARY KEY,
+    toGearId INTEGER NOT NULL,
+    mergedBy INTEGER,
+    createdAt TEXT NOT NULL DEFAULT (strftime('%Y-%m-%dT%H:%M:%fZ', 'now'))
+);
+
+CREATE INDEX IF NOT EXISTS gear_redirects_to ON gear_redirects (toGearId);
+
+-- Deleting the gear a redirect points to leaves nothing to resolve to.
+CREATE TRIGGER IF NOT EXISTS gear_delete_redirects AFTER DELETE ON gear BEGIN
+    DELETE FROM gear_redirects WHERE toGearId = OLD.gearId;
+END;
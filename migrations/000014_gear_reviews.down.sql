@@ -0,0 +1,15 @@
+-- Drop gear reviews
+
+DROP TRIGGER IF EXISTS users_delete_reviews;
+DROP TRIGGER IF EXISTS gear_delete_reviews;
+DROP TRIGGER IF EXISTS gear_reviews_delete_rating;
+DROP TRIGGER IF EXISTS gear_reviews_update_rating;
+DROP TRIGGER IF EXISTS gear_reviews_insert_rating;
+DROP INDEX IF EXISTS gear_rating_average;
+
+ALTER TABLE gear DROP COLUMN gearReviewCount;
+ALTER TABLE gear DROP COLUMN gearRatingAverage;
+
+DROP INDEX IF EXISTS gear_reviews_user;
+DROP INDEX IF EXISTS gear_reviews_gear_user;
+DROP TABLE IF EXISTS gear_reviews;
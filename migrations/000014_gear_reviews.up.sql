@@ -0,0 +1,56 @@
+-- Reviews of catalog gear by users who own it: a rating from 1 to 5 and an optional text,
+-- one per user and gear. Admins hide abusive reviews with reviewHidden; hidden reviews do
+-- not count towards the rating. gearRatingAverage and gearReviewCount on gear are kept up
+-- to date by the triggers below so gear lists can show and sort by them.
+
+CREATE TABLE IF NOT EXISTS gear_reviews (
+    reviewId INTEGER PRIMARY KEY AUTOINCREMENT,
+    gearId INTEGER NOT NULL,
+    userId INTEGER NOT NULL,
+    reviewRating INTEGER NOT NULL CHECK (reviewRating BETWEEN 1 AND 5),
+    reviewText TEXT,
+    reviewHidden INTEGER NOT NULL DEFAULT 0,
+    reviewHiddenReason TEXT,
+    reviewHiddenBy INTEGER,
+    createdAt TEXT NOT NULL DEFAULT (strftime('%Y-%m-%dT%H:%M:%fZ', 'now')),
+    updatedAt TEXT NOT NULL DEFAULT (strftime('%Y-%m-%dT%H:%M:%fZ', 'now')),
+    FOREIGN KEY (gearId) REFERENCES gear(gearId) ON DELETE CASCADE,
+    FOREIGN KEY (userId) REFERENCES users(userId) ON DELETE CASCADE
+);
+
+CREATE UNIQUE INDEX IF NOT EXISTS gear_reviews_gear_user ON gear_reviews (gearId, userId);
+CREATE INDEX IF NOT EXISTS gear_reviews_user ON gear_reviews (userId);
+
+ALTER TABLE gear ADD COLUMN gearRatingAverage REAL;
+ALTER TABLE gear ADD COLUMN gearReviewCount INTEGER NOT NULL DEFAULT 0;
+
+CREATE INDEX gear_rating_average ON gear (gearRatingAverage);
+
+CREATE TRIGGER gear_reviews_insert_rating AFTER INSERT ON gear_reviews BEGIN
+    UPDATE gear SET
+        gearRatingAverage = (SELECT AVG(reviewRating) FROM gear_reviews WHERE gearId = NEW.gearId AND reviewHidden = 0),
+        gearReviewCount = (SELECT COUNT(*) FROM gear_reviews WHERE gearId = NEW.gearId AND reviewHidden = 0)
+    WHERE gearId = NEW.gearId;
+END;
+
+CREATE TRIGGER gear_reviews_update_rating AFTER UPDATE OF gearId, reviewRating, reviewHidden ON gear_reviews BEGIN
+    UPDATE gear SET
+        gearRatingAverage = (SELECT AVG(reviewRating) FROM gear_reviews WHERE gear_reviews.gearId = gear.gearId AND reviewHidden = 0),
+        gearReviewCount = (SELECT COUNT(*) FROM gear_reviews WHERE gear_reviews.gearId = gear.gearId AND reviewHidden = 0)
+    WHERE gearId IN (OLD.gearId, NEW.gearId);
+END;
+
+CREATE TRIGGER gear_reviews_delete_rating AFTER DELETE ON gear_reviews BEGIN
+    UPDATE gear SET
+        gearRatingAverage = (SELECT AVG(reviewRating) FROM gear_reviews WHERE gearId = OLD.gearId AND reviewHidden = 0),
+        gearReviewCount = (SELECT COUNT(*) FROM gear_reviews WHERE gearId = OLD.gearId AND reviewHidden = 0)
+    WHERE gearId = OLD.gearId;
+END;
+
+CREATE TRIGGER gear_delete_reviews AFTER DELETE ON gear BEGIN
+    DELETE FROM gear_reviews WHERE gearId = OLD.gearId;
+END;
+
+CREATE TRIGGER users_delete_reviews AFTER DELETE ON users BEGIN
+    DELETE FROM gear_reviews WHERE userId = OLD.userId;
+END;
@@ -0,0 +1,7 @@
+-- Drop the catalog addition time of gear
+
+DROP TRIGGER IF EXISTS gear_update_added_at;
+DROP TRIGGER IF EXISTS gear_insert_added_at;
+DROP INDEX IF EXISTS gear_added_at;
+
+ALTER TABLE gear DROP COLUMN gearAddedAt;
@@ -0,0 +1,19 @@
+-- When gear entered the catalog, for listing recently added gear. It is set once, when
+-- gear is inserted into the catalog or a submission is first approved. Existing approved
+-- submissions use their review time; older gear stays unknown.
+
+ALTER TABLE gear ADD COLUMN gearAddedAt TEXT;
+
+CREATE INDEX gear_added_at ON gear (gearAddedAt);
+
+UPDATE gear SET gearAddedAt = gearReviewedAt WHERE gearStatus IS NOT 0 AND gearReviewedAt IS NOT NULL;
+
+CREATE TRIGGER IF NOT EXISTS gear_insert_added_at AFTER INSERT ON gear
+WHEN NEW.gearStatus IS NOT 0 AND NEW.gearAddedAt IS NULL BEGIN
+    UPDATE gear SET gearAddedAt = strftime('%Y-%m-%dT%H:%M:%fZ', 'now') WHERE gearId = NEW.gearId;
+END;
+
+CREATE TRIGGER IF NOT EXISTS gear_update_added_at AFTER UPDATE OF gearStatus ON gear
+WHEN NEW.gearStatus IS NOT 0 AND NEW.gearAddedAt IS NULL BEGIN
+    UPDATE gear SET gearAddedAt = strftime('%Y-%m-%dT%H:%M:%fZ', 'now') WHERE gearId = NEW.gearId;
+END;
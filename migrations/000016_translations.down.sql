@@ -0,0 +1,11 @@
+-- Drop the translations of catalog text
+
+DROP TRIGGER IF EXISTS gear_delete_translations;
+DROP TRIGGER IF EXISTS gear_category_delete_translations;
+DROP TRIGGER IF EXISTS gear_top_category_delete_translations;
+
+ALTER TABLE user_preferences DROP COLUMN locale;
+
+DROP TABLE IF EXISTS gear_translations;
+DROP TABLE IF EXISTS category_translations;
+DROP TABLE IF EXISTS top_category_translations;
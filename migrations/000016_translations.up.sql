@@ -0,0 +1,50 @@
+-- Translations of catalog text. The columns of gear, gear_category and gear_top_category
+-- hold the default locale; these tables hold the same text in other locales, one row per
+-- entry and locale. Locales are lowercase BCP 47 tags such as nb or nb-no. A NULL gear
+-- column falls back to the default locale.
+
+CREATE TABLE IF NOT EXISTS top_category_translations (
+    topCategoryId INTEGER NOT NULL,
+    locale TEXT NOT NULL,
+    topCategoryName TEXT NOT NULL,
+    updatedAt TEXT NOT NULL DEFAULT (strftime('%Y-%m-%dT%H:%M:%fZ', 'now')),
+    PRIMARY KEY (topCategoryId, locale),
+    FOREIGN KEY (topCategoryId) REFERENCES gear_top_category(topCategoryId) ON DELETE CASCADE
+);
+
+CREATE TABLE IF NOT EXISTS category_translations (
+    categoryId INTEGER NOT NULL,
+    locale TEXT NOT NULL,
+    categoryName TEXT NOT NULL,
+    updatedAt TEXT NOT NULL DEFAULT (strftime('%Y-%m-%dT%H:%M:%fZ', 'now')),
+    PRIMARY KEY (categoryId, locale),
+    FOREIGN KEY (categoryId) REFERENCES gear_category(categoryId) ON DELETE CASCADE
+);
+
+CREATE TABLE IF NOT EXISTS gear_translations (
+    gearId INTEGER NOT NULL,
+    locale TEXT NOT NULL,
+    gearName TEXT,
+    gearSizeDefinition TEXT,
+    updatedAt TEXT NOT NULL DEFAULT (strftime('%Y-%m-%dT%H:%M:%fZ', 'now')),
+    PRIMARY KEY (gearId, locale),
+    FOREIGN KEY (gearId) REFERENCES gear(gearId) ON DELETE CASCADE
+);
+
+CREATE INDEX IF NOT EXISTS top_category_translations_locale ON top_category_translations (locale);
+CREATE INDEX IF NOT EXISTS category_translations_locale ON category_translations (locale);
+CREATE INDEX IF NOT EXISTS gear_translations_locale ON gear_translations (locale);
+
+ALTER TABLE user_preferences ADD COLUMN locale TEXT;
+
+CREATE TRIGGER IF NOT EXISTS gear_top_category_delete_translations AFTER DELETE ON gear_top_category BEGIN
+    DELETE FROM top_category_translations WHERE topCategoryId = OLD.topCategoryId;
+END;
+
+CREATE TRIGGER IF NOT EXISTS gear_category_delete_translations AFTER DELETE ON gear_category BEGIN
+    DELETE FROM category_translations WHERE categoryId = OLD.categoryId;
+END;
+
+CREATE TRIGGER IF NOT EXISTS gear_delete_translations AFTER DELETE ON gear BEGIN
+    DELETE FROM gear_translations WHERE gearId = OLD.gearId;
+END;
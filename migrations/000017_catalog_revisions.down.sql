@@ -0,0 +1,17 @@
+-- Drop the revision history of catalog entries
+
+DROP TRIGGER IF EXISTS manufacture_delete_revisions;
+DROP TRIGGER IF EXISTS manufacture_update_revision;
+DROP TRIGGER IF EXISTS manufacture_insert_revision;
+DROP TRIGGER IF EXISTS gear_top_category_delete_revisions;
+DROP TRIGGER IF EXISTS gear_top_category_update_revision;
+DROP TRIGGER IF EXISTS gear_top_category_insert_revision;
+DROP TRIGGER IF EXISTS gear_category_delete_revisions;
+DROP TRIGGER IF EXISTS gear_category_update_revision;
+DROP TRIGGER IF EXISTS gear_category_insert_revision;
+DROP TRIGGER IF EXISTS gear_delete_revisions;
+DROP TRIGGER IF EXISTS gear_update_revision;
+DROP TRIGGER IF EXISTS gear_insert_revision;
+
+DROP INDEX IF EXISTS catalog_revisions_entity;
+DROP TABLE IF EXISTS catalog_revisions;
@@ -0,0 +1,181 @@
+-- Revision history of catalog entries. Creating gear, a category, a top category or a
+-- manufacturer, and every change to one of their tracked columns, stores the entry's values
+-- as a new revision, numbered from 1 per entry. revisionData is a JSON object keyed by the
+-- json field names of the API. Columns that follow from others or have their own workflow,
+-- such as the top category of gear, sort orders, moderation and review stats, are not
+-- tracked. revisionAction is create, update or revert; a revert stores the values of an
+-- earlier revision again and records its number in revisionRestored. Existing entries start
+-- out with revision 1 holding their current values.
+
+CREATE TABLE IF NOT EXISTS catalog_revisions (
+    revisionId INTEGER PRIMARY KEY AUTOINCREMENT,
+    entityKind TEXT NOT NULL,
+    entityId INTEGER NOT NULL,
+    revisionNumber INTEGER NOT NULL,
+    revisionAction TEXT NOT NULL,
+    revisionData TEXT NOT NULL,
+    revisionRestored INTEGER,
+    revisionCreatedAt TEXT NOT NULL DEFAULT (strftime('%Y-%m-%dT%H:%M:%fZ', 'now'))
+);
+
+CREATE UNIQUE INDEX IF NOT EXISTS catalog_revisions_entity ON catalog_revisions (entityKind, entityId, revisionNumber);
+
+INSERT INTO catalog_revisions (entityKind, entityId, revisionNumber, revisionAction, revisionData)
+SELECT 'gear', gearId, 1, 'create', json_object(
+        'gear_category_id', gearCategoryId,
+        'gear_manufacture_id', gearManufactureId,
+        'gear_is_container', json(CASE WHEN gearIsContainer THEN 'true' ELSE 'false' END),
+        'gear_name', gearName,
+        'gear_size_definition', gearSizeDefinition,
+        'gear_weight', gearWeight,
+        'gear_height', gearHeight,
+        'gear_length', gearLength,
+        'gear_width', gearWidth)
+FROM gear;
+
+INSERT INTO catalog_revisions (entityKind, entityId, revisionNumber, revisionAction, revisionData)
+SELECT 'category', categoryId, 1, 'create', json_object(
+        'category_top_category_id', categoryTopCategoryId,
+        'category_parent_id', categoryParentId,
+        'category_name', categoryName)
+FROM gear_category;
+
+INSERT INTO catalog_revisions (entityKind, entityId, revisionNumber, revisionAction, revisionData)
+SELECT 'top_category', topCategoryId, 1, 'create', json_object(
+        'top_category_name', topCategoryName,
+        'top_category_icon', topCategoryIcon)
+FROM gear_top_category;
+
+INSERT INTO catalog_revisions (entityKind, entityId, revisionNumber, revisionAction, revisionData)
+SELECT 'manufacture', manufactureId, 1, 'create', json_object(
+        'manufacture_name', manufactureName,
+        'manufacture_website', manufactureWebsite,
+        'manufacture_country', manufactureCountry,
+        'manufacture_founded_year', manufactureFoundedYear,
+        'manufacture_logo_url', manufactureLogoUrl,
+        'manufacture_description', manufactureDescription)
+FROM manufacture;
+
+CREATE TRIGGER IF NOT EXISTS gear_insert_revision AFTER INSERT ON gear
+BEGIN
+    INSERT INTO catalog_revisions (entityKind, entityId, revisionNumber, revisionAction, revisionData)
+    VALUES ('gear', NEW.gearId, (SELECT COALESCE(MAX(revisionNumber), 0) + 1 FROM catalog_revisions WHERE entityKind = 'gear' AND entityId = NEW.gearId), 'create', json_object(
+            'gear_category_id', NEW.gearCategoryId,
+            'gear_manufacture_id', NEW.gearManufactureId,
+            'gear_is_container', json(CASE WHEN NEW.gearIsContainer THEN 'true' ELSE 'false' END),
+            'gear_name', NEW.gearName,
+            'gear_size_definition', NEW.gearSizeDefinition,
+            'gear_weight', NEW.gearWeight,
+            'gear_height', NEW.gearHeight,
+            'gear_length', NEW.gearLength,
+            'gear_width', NEW.gearWidth));
+END;
+
+CREATE TRIGGER IF NOT EXISTS gear_update_revision AFTER UPDATE OF gearCategoryId, gearManufactureId, gearIsContainer, gearName, gearSizeDefinition, gearWeight, gearHeight, gearLength, gearWidth ON gear
+WHEN OLD.gearCategoryId IS NOT NEW.gearCategoryId
+    OR OLD.gearManufactureId IS NOT NEW.gearManufactureId
+    OR OLD.gearIsContainer IS NOT NEW.gearIsContainer
+    OR OLD.gearName IS NOT NEW.gearName
+    OR OLD.gearSizeDefinition IS NOT NEW.gearSizeDefinition
+    OR OLD.gearWeight IS NOT NEW.gearWeight
+    OR OLD.gearHeight IS NOT NEW.gearHeight
+    OR OLD.gearLength IS NOT NEW.gearLength
+    OR OLD.gearWidth IS NOT NEW.gearWidth
+BEGIN
+    INSERT INTO catalog_revisions (entityKind, entityId, revisionNumber, revisionAction, revisionData)
+    VALUES ('gear', NEW.gearId, (SELECT COALESCE(MAX(revisionNumber), 0) + 1 FROM catalog_revisions WHERE entityKind = 'gear' AND entityId = NEW.gearId), 'update', json_object(
+            'gear_category_id', NEW.gearCategoryId,
+            'gear_manufacture_id', NEW.gearManufactureId,
+            'gear_is_container', json(CASE WHEN NEW.gearIsContainer THEN 'true' ELSE 'false' END),
+            'gear_name', NEW.gearName,
+            'gear_size_definition', NEW.gearSizeDefinition,
+            'gear_weight', NEW.gearWeight,
+            'gear_height', NEW.gearHeight,
+            'gear_length', NEW.gearLength,
+            'gear_width', NEW.gearWidth));
+END;
+
+CREATE TRIGGER IF NOT EXISTS gear_delete_revisions AFTER DELETE ON gear BEGIN
+    DELETE FROM catalog_revisions WHERE entityKind = 'gear' AND entityId = OLD.gearId;
+END;
+
+CREATE TRIGGER IF NOT EXISTS gear_category_insert_revision AFTER INSERT ON gear_category
+BEGIN
+    INSERT INTO catalog_revisions (entityKind, entityId, revisionNumber, revisionAction, revisionData)
+    VALUES ('category', NEW.categoryId, (SELECT COALESCE(MAX(revisionNumber), 0) + 1 FROM catalog_revisions WHERE entityKind = 'category' AND entityId = NEW.categoryId), 'create', json_object(
+            'category_top_category_id', NEW.categoryTopCategoryId,
+            'category_parent_id', NEW.categoryParentId,
+            'category_name', NEW.categoryName));
+END;
+
+CREATE TRIGGER IF NOT EXISTS gear_category_update_revision AFTER UPDATE OF categoryTopCategoryId, categoryParentId, categoryName ON gear_category
+WHEN OLD.categoryTopCategoryId IS NOT NEW.categoryTopCategoryId
+    OR OLD.categoryParentId IS NOT NEW.categoryParentId
+    OR OLD.categoryName IS NOT NEW.categoryName
+BEGIN
+    INSERT INTO catalog_revisions (entityKind, entityId, revisionNumber, revisionAction, revisionData)
+    VALUES ('category', NEW.categoryId, (SELECT COALESCE(MAX(revisionNumber), 0) + 1 FROM catalog_revisions WHERE entityKind = 'category' AND entityId = NEW.categoryId), 'update', json_object(
+            'category_top_category_id', NEW.categoryTopCategoryId,
+            'category_parent_id', NEW.categoryParentId,
+            'category_name', NEW.categoryName));
+END;
+
+CREATE TRIGGER IF NOT EXISTS gear_category_delete_revisions AFTER DELETE ON gear_category BEGIN
+    DELETE FROM catalog_revisions WHERE entityKind = 'category' AND entityId = OLD.categoryId;
+END;
+
+CREATE TRIGGER IF NOT EXISTS gear_top_category_insert_revision AFTER INSERT ON gear_top_category
+BEGIN
+    INSERT INTO catalog_revisions (entityKind, entityId, revisionNumber, revisionAction, revisionData)
+    VALUES ('top_category', NEW.topCategoryId, (SELECT COALESCE(MAX(revisionNumber), 0) + 1 FROM catalog_revisions WHERE entityKind = 'top_category' AND entityId = NEW.topCategoryId), 'create', json_object(
+            'top_category_name', NEW.topCategoryName,
+            'top_category_icon', NEW.topCategoryIcon));
+END;
+
+CREATE TRIGGER IF NOT EXISTS gear_top_category_update_revision AFTER UPDATE OF topCategoryName, topCategoryIcon ON gear_top_category
+WHEN OLD.topCategoryName IS NOT NEW.topCategoryName
+    OR OLD.topCategoryIcon IS NOT NEW.topCategoryIcon
+BEGIN
+    INSERT INTO catalog_revisions (entityKind, entityId, revisionNumber, revisionAction, revisionData)
+    VALUES ('top_category', NEW.topCategoryId, (SELECT COALESCE(MAX(revisionNumber), 0) + 1 FROM catalog_revisions WHERE entityKind = 'top_category' AND entityId = NEW.topCategoryId), 'update', json_object(
+            'top_category_name', NEW.topCategoryName,
+            'top_category_icon', NEW.topCategoryIcon));
+END;
+
+CREATE TRIGGER IF NOT EXISTS gear_top_category_delete_revisions AFTER DELETE ON gear_top_category BEGIN
+    DELETE FROM catalog_revisions WHERE entityKind = 'top_category' AND entityId = OLD.topCategoryId;
+END;
+
+CREATE TRIGGER IF NOT EXISTS manufacture_insert_revision AFTER INSERT ON manufacture
+BEGIN
+    INSERT INTO catalog_revisions (entityKind, entityId, revisionNumber, revisionAction, revisionData)
+    VALUES ('manufacture', NEW.manufactureId, (SELECT COALESCE(MAX(revisionNumber), 0) + 1 FROM catalog_revisions WHERE entityKind = 'manufacture' AND entityId = NEW.manufactureId), 'create', json_object(
+            'manufacture_name', NEW.manufactureName,
+            'manufacture_website', NEW.manufactureWebsite,
+            'manufacture_country', NEW.manufactureCountry,
+            'manufacture_founded_year', NEW.manufactureFoundedYear,
+            'manufacture_logo_url', NEW.manufactureLogoUrl,
+            'manufacture_description', NEW.manufactureDescription));
+END;
+
+CREATE TRIGGER IF NOT EXISTS manufacture_update_revision AFTER UPDATE OF manufactureName, manufactureWebsite, manufactureCountry, manufactureFoundedYear, manufactureLogoUrl, manufactureDescription ON manufacture
+WHEN OLD.manufactureName IS NOT NEW.manufactureName
+    OR OLD.manufactureWebsite IS NOT NEW.manufactureWebsite
+    OR OLD.manufactureCountry IS NOT NEW.manufactureCountry
+    OR OLD.manufactureFoundedYear IS NOT NEW.manufactureFoundedYear
+    OR OLD.manufactureLogoUrl IS NOT NEW.manufactureLogoUrl
+    OR OLD.manufactureDescription IS NOT NEW.manufactureDescription
+BEGIN
+    INSERT INTO catalog_revisions (entityKind, entityId, revisionNumber, revisionAction, revisionData)
+    VALUES ('manufacture', NEW.manufactureId, (SELECT COALESCE(MAX(revisionNumber), 0) + 1 FROM catalog_revisions WHERE entityKind = 'manufacture' AND entityId = NEW.manufactureId), 'update', json_object(
+            'manufacture_name', NEW.manufactureName,
+            'manufacture_website', NEW.manufactureWebsite,
+            'manufacture_country', NEW.manufactureCountry,
+            'manufacture_founded_year', NEW.manufactureFoundedYear,
+            'manufacture_logo_url', NEW.manufactureLogoUrl,
+            'manufacture_description', NEW.manufactureDescription));
+END;
+
+CREATE TRIGGER IF NOT EXISTS manufacture_delete_revisions AFTER DELETE ON manufacture BEGIN
+    DELETE FROM catalog_revisions WHERE entityKind = 'manufacture' AND entityId = OLD.manufactureId;
+END;
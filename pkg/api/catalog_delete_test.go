@@ -0,0 +1,91 @@
+package endpoints
+
+import (
+	"encoding/json"
+	"net/http"
+	"testing"
+
+	models "github.com/Sea-Shell/gogear-api/pkg/models"
+
+	"github.com/gin-gonic/gin"
+)
+
+func TestCatalogDeleteWithReassignment(t *testing.T) {
+	db, router := setupCatalogTest(t, 1)
+	seedCatalog(t, db)
+	seedCatalogGear(t, db, 1, 1, "Nallo", 2400)
+	seedCatalogGear(t, db, 2, 2, "Tarp", 500)
+	for _, statement := range []string{
+		`INSERT INTO gear_top_category (topCategoryId, topCategoryName) VALUES (2, 'Sleep')`,
+		`INSERT INTO gear_category (categoryId, categoryTopCategoryId, categoryName, categorySortOrder) VALUES (4, 2, 'Pads', 0)`,
+		`INSERT INTO gear_category (categoryId, categoryTopCategoryId, categoryName, categoryParentId) VALUES (5, 1, 'Tunnel tents', 1)`,
+		`INSERT INTO manufacture (manufactureId, manufactureName) VALUES (2, 'Hilleberg AB')`,
+	} {
+		if _, err := db.Exec(statement); err != nil {
+			t.Fatal(err)
+		}
+	}
+
+	v1 := router.Group("/api/v1", testAuthMiddleware(1), func(c *gin.Context) {
+		c.Set("user_is_admin", true)
+		c.Next()
+	})
+	v1.DELETE("/category/:category/delete", DeleteCategory)
+	v1.DELETE("/topCategory/:topCategory/delete", DeleteTopCategory)
+	v1.DELETE("/manufacture/:manufacture/delete", DeleteManufature)
+
+	w := authRequest(t, router, http.MethodDelete, "/api/v1/manufacture/1/delete", "")
+	var refused models.DependentsError
+	if err := json.Unmarshal(w.Body.Bytes(), &refused); err != nil || w.Code != http.StatusConflict || refused.GearCount != 2 {
+		t.Fatalf("DeleteManufature in use: expected 409 with 2 gear, got %d %s", w.Code, w.Body.String())
+	}
+	if w := authRequest(t, router, http.MethodDelete, "/api/v1/manufacture/1/delete?reassign_to=1", ""); w.Code != http.StatusBadRequest {
+		t.Errorf("DeleteManufature onto itself: expected 400, got %d", w.Code)
+	}
+	if w := authRequest(t, router, http.MethodDelete, "/api/v1/manufacture/1/delete?reassign_to=99", ""); w.Code != http.StatusBadRequest {
+		t.Errorf("DeleteManufature onto missing target: expected 400, got %d", w.Code)
+	}
+	if w := authRequest(t, router, http.MethodDelete, "/api/v1/manufacture/1/delete?reassign_to=2", ""); w.Code != http.StatusOK {
+		t.Fatalf("DeleteManufature with reassign_to: %d %s", w.Code, w.Body.String())
+	}
+	var moved int
+	if err := db.QueryRow(`SELECT COUNT(*) FROM gear WHERE gearManufactureId = 2`).Scan(&moved); err != nil || moved != 2 {
+		t.Errorf("DeleteManufature: expected gear moved to manufacture 2, got %d (%v)", moved, err)
+	}
+	if w := authRequest(t, router, http.MethodDelete, "/api/v1/manufacture/1/delete", ""); w.Code != http.StatusNotFound {
+		t.Errorf("DeleteManufature twice: expected 404, got %d", w.Code)
+	}
+
+	if w := authRequest(t, router, http.MethodDelete, "/api/v1/category/2/delete", ""); w.Code != http.StatusConflict {
+		t.Errorf("DeleteCategory in use: expected 409, got %d", w.Code)
+	}
+	if w := authRequest(t, router, http.MethodDelete, "/api/v1/category/2/delete?reassign_to=4", ""); w.Code != http.StatusOK {
+		t.Fatalf("DeleteCategory with reassign_to: %d %s", w.Code, w.Body.String())
+	}
+	var category, top int64
+	if err := db.QueryRow(`SELECT gearCategoryId, gearTopCategoryId FROM gear WHERE gearId = 2`).Scan(&category, &top); err != nil || category != 4 || top != 2 {
+		t.Errorf("DeleteCategory: gear should follow into category 4 of top 2, got %d/%d (%v)", category, top, err)
+	}
+	if w := authRequest(t, router, http.MethodDelete, "/api/v1/category/3/delete", ""); w.Code != http.StatusOK {
+		t.Errorf("DeleteCategory unused: expected 200, got %d", w.Code)
+	}
+
+	w = authRequest(t, router, http.MethodDelete, "/api/v1/topCategory/1/delete", "")
+	if err := json.Unmarshal(w.Body.Bytes(), &refused); err != nil || w.Code != http.StatusConflict || refused.GearCount != 1 || refused.CategoryCount != 2 {
+		t.Fatalf("DeleteTopCategory in use: expected 409 with 1 gear and 2 categories, got %d %s", w.Code, w.Body.String())
+	}
+	if w := authRequest(t, router, http.MethodDelete, "/api/v1/topCategory/1/delete?reassign_to=2", ""); w.Code != http.StatusOK {
+		t.Fatalf("DeleteTopCategory with reassign_to: %d %s", w.Code, w.Body.String())
+	}
+	var parent *int64
+	if err := db.QueryRow(`SELECT categoryTopCategoryId, categoryParentId FROM gear_category WHERE categoryId = 5`).Scan(&top, &parent); err != nil || top != 2 || parent == nil || *parent != 1 {
+		t.Errorf("DeleteTopCategory: subcategory should move with its parent, got top %d parent %v (%v)", top, parent, err)
+	}
+	var sortOrder int
+	if err := db.QueryRow(`SELECT gearTopCategoryId FROM gear WHERE gearId = 1`).Scan(&top); err != nil || top != 2 {
+		t.Errorf("DeleteTopCategory: gear should move to top category 2, got %d (%v)", top, err)
+	}
+	if err := db.QueryRow(`SELECT categorySortOrder FROM gear_category WHERE categoryId = 1`).Scan(&sortOrder); err != nil || sortOrder < 1 {
+		t.Errorf("DeleteTopCategory: moved categories should sort after the target's own, got %d (%v)", sortOrder, err)
+	}
+}
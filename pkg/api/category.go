@@ -2,14 +2,12 @@ package endpoints
 
 import (
 	"database/sql"
+	"encoding/json"
+	"errors"
 	"fmt"
 	"io"
-	"math"
 	"net/http"
-	"net/url"
-	"reflect"
 	"strconv"
-	"strings"
 
 	models "github.com/Sea-Shell/gogear-api/pkg/models"
 	utils "github.com/Sea-Shell/gogear-api/pkg/utils"
@@ -54,6 +52,10 @@ func GetCategory(c *gin.Context) {
 		return
 	}
 
+	if !localizeItems(c, log, db, results) {
+		return
+	}
+
 	log.Infof("Successfully fetched %s with ID %s", function, urlParameter)
 	c.IndentedJSON(http.StatusOK, results)
 }
@@ -66,6 +68,10 @@ func GetCategory(c *gin.Context) {
 // @Produce		json
 // @Param			page		query		int		false	"Page number"				default(1)
 // @Param			limit		query		int		false	"Number of items per page"	default(30)
+// @Param			sort		query		string	false	"Field to sort by, json or db field name"
+// @Param			order		query		string	false	"Sort direction, asc or desc"	default(asc)
+// @Param			cursor		query		string	false	"Opaque cursor from next_cursor or prev_cursor, replaces page"
+// @Param			count		query		bool	false	"Include total item count"	default(true)
 // @Param			category	query		[]int	false	"Top category"				collectionFormat(multi)
 // @Param			topCategory	query		[]int	false	"Top gear category"			collectionFormat(multi)
 // @Success		200			{object}	models.ResponsePayload{items=[]models.GearCategory}
@@ -74,10 +80,6 @@ func GetCategory(c *gin.Context) {
 func ListCategory(c *gin.Context) {
 	c.Header("Content-Type", "application/json")
 
-	currentQueryParameters := c.Request.URL.Query()
-
-	page := c.Query("page")
-	limit := c.Query("limit")
 	topCategories := c.QueryArray("topCategory")
 	categories := c.QueryArray("category")
 
@@ -86,39 +88,8 @@ func ListCategory(c *gin.Context) {
 
 	log.Debugf("Request parameters: %#v", c.Request.URL.Query())
 
-	if limit == "" {
-		limit = "30"
-	}
-
-	if page == "" || page == "0" {
-		page = "1"
-	}
-
-	pageInt, err := strconv.Atoi(page)
-	if err != nil {
-		log.Errorf("Error setting page to int: %#v", err)
-		c.IndentedJSON(http.StatusInternalServerError, models.Error{Error: err.Error()})
-		return
-	}
-
-	limitInt, err := strconv.Atoi(limit)
-	if err != nil {
-		log.Errorf("Error setting limit to int: %#v", err)
-		c.IndentedJSON(http.StatusInternalServerError, models.Error{Error: err.Error()})
-		return
-	}
-
-	if pageInt <= 0 {
-		log.Errorf("Error page is less than 0: %#v", err)
-		c.IndentedJSON(http.StatusBadRequest, models.Error{Error: "Invalid page number"})
-		return
-	}
-
-	if limitInt <= 0 {
-		log.Errorf("Error limit is less than 0: %#v", err)
-		c.IndentedJSON(http.StatusBadRequest, models.Error{Error: "Invalid limit number"})
-		return
-	}
+	query := utils.NewListQuery[models.GearCategoryListItem](`gear_category
+    LEFT JOIN gear_top_category ON gear_category.categoryTopCategoryId = gear_top_category.topCategoryId`)
 
 	conditions := []string{}
 	args := []interface{}{}
@@ -133,116 +104,14 @@ func ListCategory(c *gin.Context) {
 		args = append(args, category)
 	}
 
-	whereClause := ""
-	if len(conditions) > 0 {
-		whereClause = " WHERE " + strings.Join(conditions, " OR ")
-	}
-
-	baseCountQuery := "SELECT COUNT(*) FROM gear_category"
-	countQuery := baseCountQuery + whereClause
+	query.WhereAny(conditions, args...)
 
-	var totalCount int
-	err = db.QueryRow(countQuery, args...).Scan(&totalCount)
-	if err != nil {
-		log.Errorf("Error getting GearCount database: %#v", err)
-		c.IndentedJSON(http.StatusInternalServerError, models.Error{Error: err.Error()})
-		return
-	}
-
-	start := strconv.Itoa((pageInt - 1) * limitInt)
-	totalPages := int(math.Ceil(float64(totalCount) / float64(limitInt)))
-
-	startInt, err := strconv.Atoi(start)
-	if err != nil {
-		c.IndentedJSON(http.StatusInternalServerError, models.Error{Error: err.Error()})
+	if err := query.FilterFields(c.Request.URL.Query()); err != nil {
+		respondListQueryError(c, log, err)
 		return
 	}
 
-	var paramCategory models.GearCategoryListItem
-	fields := utils.GetDBFieldNames(reflect.TypeOf(paramCategory))
-
-	baseQuery := fmt.Sprintf(`SELECT %s FROM gear_category
-    LEFT JOIN gear_top_category ON gear_category.categoryTopCategoryId = gear_top_category.topCategoryId`,
-		strings.Join(fields, ", "))
-
-	queryLimit := fmt.Sprintf(" LIMIT %v, %v", startInt, limitInt)
-
-	query := baseQuery + whereClause + queryLimit
-
-	log.Debugf("Query: %s", query)
-
-	rows, err := db.Query(query, args...)
-	if err != nil {
-		log.Errorf("Query error: %#v", err.Error())
-		c.IndentedJSON(http.StatusInternalServerError, models.Error{Error: err.Error()})
-		return
-	}
-	defer rows.Close()
-
-	dest, err := utils.GetScanFields(paramCategory)
-	if err != nil {
-		log.Errorf("Error getting destination arguments: %#v", err)
-		c.IndentedJSON(http.StatusInternalServerError, models.Error{Error: err.Error()})
-		return
-	}
-
-	var gearCategoryList []models.GearCategoryListItem
-
-	for rows.Next() {
-		err = rows.Scan(dest...)
-		if err != nil {
-			if err == sql.ErrNoRows {
-				log.Errorf("No gear found")
-				c.IndentedJSON(http.StatusNotFound, models.Error{Error: "No results"})
-				return
-			}
-			log.Errorf("Scan error: %#v", err)
-			c.IndentedJSON(http.StatusInternalServerError, models.Error{Error: err.Error()})
-			return
-		}
-
-		for i := 0; i < reflect.TypeOf(paramCategory).NumField(); i++ {
-			reflect.ValueOf(&paramCategory).Elem().Field(i).Set(reflect.ValueOf(dest[i]).Elem())
-		}
-
-		gearCategoryList = append(gearCategoryList, paramCategory)
-	}
-
-	if err := rows.Err(); err != nil {
-		log.Errorf("Row iteration error: %#v", err)
-		c.IndentedJSON(http.StatusInternalServerError, models.Error{Error: err.Error()})
-		return
-	}
-
-	payload := models.ResponsePayload{
-		TotalItemCount: totalCount,
-		CurrentPage:    pageInt,
-		ItemLimit:      limitInt,
-		TotalPages:     totalPages,
-		Items:          gearCategoryList,
-	}
-
-	if pageInt < totalPages {
-		currentQueryParameters.Set("page", strconv.Itoa(pageInt+1))
-		nextPage := url.URL{
-			Path:     c.Request.URL.Path,
-			RawQuery: currentQueryParameters.Encode(),
-		}
-		payload.NextPage = new(string)
-		*payload.NextPage = nextPage.String()
-	}
-
-	if pageInt > 1 {
-		currentQueryParameters.Set("page", strconv.Itoa(pageInt-1))
-		prevPage := url.URL{
-			Path:     c.Request.URL.Path,
-			RawQuery: currentQueryParameters.Encode(),
-		}
-		payload.PrevPage = new(string)
-		*payload.PrevPage = prevPage.String()
-	}
-
-	c.IndentedJSON(http.StatusOK, payload)
+	respondList(c, log, db, query)
 }
 
 // @Summary		Update category with ID
@@ -276,6 +145,59 @@ func UpdateCategory(c *gin.Context) {
 		return
 	}
 
+	var target struct {
+		CategoryID            *int64          `json:"category_id"`
+		CategoryTopCategoryID *int64          `json:"category_top_category_id"`
+		CategoryParentID      json.RawMessage `json:"category_parent_id"`
+	}
+	if err := json.Unmarshal(data, &target); err != nil || target.CategoryID == nil {
+		c.JSON(http.StatusBadRequest, models.Error{Error: "category_id is required"})
+		return
+	}
+
+	category, err := utils.GenericGet[models.GearCategory]("gear_category", int(*target.CategoryID), nil, db)
+	if err != nil {
+		respondCategoryTreeError(c, log, err)
+		return
+	}
+	parentID, topCategoryID := category.CategoryParentID, category.CategoryTopCategoryID
+
+	if err := json.Unmarshal(data, category); err != nil {
+		c.JSON(http.StatusBadRequest, models.Error{Error: err.Error()})
+		return
+	}
+	// A new top category without a parent moves the category directly below it.
+	if target.CategoryParentID == nil && target.CategoryTopCategoryID != nil && *target.CategoryTopCategoryID != topCategoryID {
+		category.CategoryParentID = nil
+	}
+
+	parentChanged := (parentID == nil) != (category.CategoryParentID == nil) ||
+		(parentID != nil && *parentID != *category.CategoryParentID)
+	if parentChanged || (category.CategoryParentID == nil && category.CategoryTopCategoryID != topCategoryID) {
+		move := models.CategoryMove{ParentID: category.CategoryParentID}
+		if move.ParentID == nil {
+			move.TopCategoryID = &category.CategoryTopCategoryID
+		}
+		if err := utils.MoveCategory(db, *category.CategoryID, move); err != nil {
+			respondCategoryTreeError(c, log, err)
+			return
+		}
+		moved, err := utils.GenericGet[models.GearCategory]("gear_category", int(*category.CategoryID), nil, db)
+		if err != nil {
+			respondCategoryTreeError(c, log, err)
+			return
+		}
+		topCategoryID = moved.CategoryTopCategoryID
+	}
+	category.CategoryTopCategoryID = topCategoryID
+
+	data, err = json.Marshal(category)
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, models.Error{Error: err.Error()})
+		log.Error(err.Error())
+		return
+	}
+
 	err = utils.GenericUpdate[models.GearCategory]("gear_category", data, db)
 	if err != nil {
 		c.JSON(http.StatusInternalServerError, models.Error{Error: err.Error()})
@@ -316,6 +238,29 @@ func InsertCategory(c *gin.Context) {
 		return
 	}
 
+	var category models.GearCategory
+	if err := json.Unmarshal(data, &category); err != nil {
+		c.JSON(http.StatusBadRequest, models.Error{Error: err.Error()})
+		return
+	}
+
+	move := models.CategoryMove{ParentID: category.CategoryParentID}
+	if move.ParentID == nil {
+		move.TopCategoryID = &category.CategoryTopCategoryID
+	}
+	category.CategoryTopCategoryID, err = utils.ResolveCategoryParent(db, 0, move.ParentID, move.TopCategoryID)
+	if err != nil {
+		respondCategoryTreeError(c, log, err)
+		return
+	}
+
+	data, err = json.Marshal(category)
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, models.Error{Error: err.Error()})
+		log.Error(err.Error())
+		return
+	}
+
 	createdObject, err := utils.GenericInsert[models.GearCategory]("gear_category", data, db)
 	if err != nil {
 		c.JSON(http.StatusInternalServerError, models.Error{Error: err.Error()})
@@ -323,17 +268,25 @@ func InsertCategory(c *gin.Context) {
 		return
 	}
 
+	// Place the new category last among its siblings.
+	if err := utils.MoveCategory(db, *createdObject.CategoryID, move); err != nil {
+		respondCategoryTreeError(c, log, err)
+		return
+	}
+
 	c.JSON(http.StatusOK, createdObject)
 }
 
 // @Summary		Delete category with ID
-// @Description	Delete category with corresponding ID value. Requires a JWT issued with the admin audience.
+// @Description	Delete category with corresponding ID value. Gear in the category must be moved first, or moved to reassign_to as part of the delete; otherwise the delete is refused with 409 and the gear count. Categories below it move up to its parent. Requires a JWT issued with the admin audience.
 // @Security		BearerAuth
 // @Tags			Category
 // @Accept			json
 // @Produce		json
-// @Param			category	path		int				true	"Unique ID of category you want to update"
-// @Success		200			{object}	models.Status	"status: success when all goes well"
+// @Param			category	path		int						true	"Unique ID of category you want to update"
+// @Param			reassign_to	query		int						false	"Category to move the gear to before deleting"
+// @Success		200			{object}	models.Status			"status: success when all goes well"
+// @Failure		409			{object}	models.DependentsError	"the category is still in use"
 // @Failure		default		{object}	models.Error
 // @Router			/api/v1/category/{category}/delete [delete]
 func DeleteCategory(c *gin.Context) {
@@ -357,13 +310,162 @@ func DeleteCategory(c *gin.Context) {
 		return
 	}
 
-	result, err := utils.GenericDelete[models.GearCategory]("gear_category", urlParameter, db)
+	result, err := utils.GenericGet[models.GearCategory]("gear_category", urlParameter, nil, db)
+	if err == sql.ErrNoRows {
+		c.JSON(http.StatusNotFound, models.Error{Error: "Category not found"})
+		return
+	}
 	if err != nil {
 		c.JSON(http.StatusInternalServerError, models.Error{Error: err.Error()})
 		log.Error(err.Error())
 		return
 	}
 
-	log.Infof("success! Category with category_id %v and name %s was deleted", result.CategoryID, result.CategoryName)
-	c.JSON(http.StatusOK, map[string]string{"status": fmt.Sprintf("success! Category with category_id %v and name %s has been deleted", result.CategoryID, result.CategoryName)})
+	moved, ok := deleteReassigning(c, log, db, utils.CategoryDeletion, int64(urlParameter))
+	if !ok {
+		return
+	}
+
+	log.Infof("success! Category with category_id %v and name %s was deleted%s", *result.CategoryID, result.CategoryName, reassignedSummary(moved))
+	c.JSON(http.StatusOK, map[string]string{"status": fmt.Sprintf("success! Category with category_id %v and name %s has been deleted%s", *result.CategoryID, result.CategoryName, reassignedSummary(moved))})
+}
+
+// @Summary		Get category tree
+// @Description	Get every top category with its categories nested below it, siblings in their sort order. gear_count includes the gear of all categories below.
+// @Security		BearerAuth
+// @Tags			Category
+// @Accept			json
+// @Produce		json
+// @Success		200		{array}		models.CategoryTree
+// @Failure		default	{object}	models.Error
+// @Router			/api/v1/category/tree [get]
+func GetCategoryTree(c *gin.Context) {
+	c.Header("Content-Type", "application/json")
+
+	log := c.MustGet("logger").(*zap.SugaredLogger)
+	db := c.MustGet("db").(*sql.DB)
+
+	tree, err := utils.CategoryTree(db)
+	if err != nil {
+		log.Errorf("Unable to build category tree: %#v", err)
+		c.IndentedJSON(http.StatusInternalServerError, models.Error{Error: err.Error()})
+		return
+	}
+
+	topCategories := make([]*models.CategoryTree, len(tree))
+	var categories []*models.CategoryTreeNode
+	var collect func(nodes []models.CategoryTreeNode)
+	collect = func(nodes []models.CategoryTreeNode) {
+		for i := range nodes {
+			categories = append(categories, &nodes[i])
+			collect(nodes[i].Children)
+		}
+	}
+	for i := range tree {
+		topCategories[i] = &tree[i]
+		collect(tree[i].Children)
+	}
+	if !localizeItems(c, log, db, topCategories...) || !localizeItems(c, log, db, categories...) {
+		return
+	}
+
+	c.IndentedJSON(http.StatusOK, tree)
+}
+
+// @Summary		Move category
+// @Description	Move a category and everything below it under parent_id, or directly below top_category_id, at position among its new siblings (last when omitted). The gear in the moved categories follows their new top category. Requires a JWT issued with the admin audience.
+// @Security		BearerAuth
+// @Tags			Category
+// @Accept			json
+// @Produce		json
+// @Param			category	path		int					true	"Unique ID of category you want to move"
+// @Param			request		body		models.CategoryMove	true	"Request body"
+// @Success		200			{object}	models.Status		"status: success when all goes well"
+// @Failure		default		{object}	models.Error
+// @Router			/api/v1/category/{category}/move [post]
+func MoveCategory(c *gin.Context) {
+	c.Header("Content-Type", "application/json")
+
+	log := c.MustGet("logger").(*zap.SugaredLogger)
+	db := c.MustGet("db").(*sql.DB)
+
+	isAdmin, _ := c.Get("user_is_admin")
+	if adminFlag, ok := isAdmin.(bool); !ok || !adminFlag {
+		log.Warn("unauthorized category move attempt without admin privileges")
+		c.AbortWithStatusJSON(http.StatusForbidden, models.Error{Error: "admin privileges required"})
+		return
+	}
+
+	categoryID, err := strconv.ParseInt(c.Param("category"), 10, 64)
+	if err != nil {
+		c.IndentedJSON(http.StatusBadRequest, models.Error{Error: "category must be an integer"})
+		return
+	}
+
+	var move models.CategoryMove
+	if err := c.ShouldBindJSON(&move); err != nil {
+		c.IndentedJSON(http.StatusBadRequest, models.Error{Error: err.Error()})
+		return
+	}
+
+	if err := utils.MoveCategory(db, categoryID, move); err != nil {
+		respondCategoryTreeError(c, log, err)
+		return
+	}
+
+	log.Infof("Moved category %d", categoryID)
+	c.JSON(http.StatusOK, map[string]string{"status": "success"})
+}
+
+// @Summary		Reorder categories
+// @Description	Set the order of the children of parent_id, of the categories directly below top_category_id, or of the top categories when neither is given. order must list every one of them exactly once. Requires a JWT issued with the admin audience.
+// @Security		BearerAuth
+// @Tags			Category
+// @Accept			json
+// @Produce		json
+// @Param			request	body		models.CategoryReorder	true	"Request body"
+// @Success		200		{object}	models.Status			"status: success when all goes well"
+// @Failure		default	{object}	models.Error
+// @Router			/api/v1/category/reorder [post]
+func ReorderCategories(c *gin.Context) {
+	c.Header("Content-Type", "application/json")
+
+	log := c.MustGet("logger").(*zap.SugaredLogger)
+	db := c.MustGet("db").(*sql.DB)
+
+	isAdmin, _ := c.Get("user_is_admin")
+	if adminFlag, ok := isAdmin.(bool); !ok || !adminFlag {
+		log.Warn("unauthorized category reorder attempt without admin privileges")
+		c.AbortWithStatusJSON(http.StatusForbidden, models.Error{Error: "admin privileges required"})
+		return
+	}
+
+	var reorder models.CategoryReorder
+	if err := c.ShouldBindJSON(&reorder); err != nil {
+		c.IndentedJSON(http.StatusBadRequest, models.Error{Error: err.Error()})
+		return
+	}
+
+	if err := utils.ReorderCategories(db, reorder); err != nil {
+		respondCategoryTreeError(c, log, err)
+		return
+	}
+
+	c.JSON(http.StatusOK, map[string]string{"status": "success"})
+}
+
+// respondCategoryTreeError writes 400 for an invalid placement, 404 for a missing
+// category and 500 for anything else.
+func respondCategoryTreeError(c *gin.Context, log *zap.SugaredLogger, err error) {
+	var treeErr *utils.CategoryTreeError
+	switch {
+	case errors.As(err, &treeErr):
+		log.Warnf("Invalid category placement: %s", treeErr.Message)
+		c.IndentedJSON(http.StatusBadRequest, models.Error{Error: treeErr.Message})
+	case errors.Is(err, sql.ErrNoRows):
+		c.IndentedJSON(http.StatusNotFound, models.Error{Error: "Category not found"})
+	default:
+		log.Errorf("Category tree error: %#v", err)
+		c.IndentedJSON(http.StatusInternalServerError, models.Error{Error: err.Error()})
+	}
 }
@@ -0,0 +1,350 @@
+package endpoints
+
+import (
+	"database/sql"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"strconv"
+	"strings"
+
+	models "github.com/Sea-Shell/gogear-api/pkg/models"
+	utils "github.com/Sea-Shell/gogear-api/pkg/utils"
+
+	gin "github.com/gin-gonic/gin"
+	sqlite3 "github.com/mattn/go-sqlite3"
+	zap "go.uber.org/zap"
+)
+
+// ListCategorySpecs lists the specification schema of a category.
+//
+//	@Summary		List category specs
+//	@Description	List the typed specification attributes gear in a category can carry, by name
+//	@Security		BearerAuth
+//	@Tags			Category
+//	@Produce		json
+//	@Param			category	path		int	true	"Category ID"
+//	@Success		200			{array}		models.SpecAttribute
+//	@Failure		400			{object}	models.Error
+//	@Failure		404			{object}	models.Error
+//	@Failure		500			{object}	models.Error
+//	@Router			/api/v1/category/{category}/spec/list [get]
+func ListCategorySpecs(c *gin.Context) {
+	log := c.MustGet("logger").(*zap.SugaredLogger)
+	db := c.MustGet("db").(*sql.DB)
+
+	categoryID, ok := specCategory(c, log, db)
+	if !ok {
+		return
+	}
+
+	schema, err := utils.SpecSchema(db, categoryID)
+	if err != nil {
+		log.Errorf("error listing category specs: %#v", err)
+		c.JSON(http.StatusInternalServerError, models.Error{Error: err.Error()})
+		return
+	}
+
+	c.JSON(http.StatusOK, schema)
+}
+
+// InsertCategorySpec adds an attribute to the specification schema of a category.
+//
+//	@Summary		Insert category spec
+//	@Description	Add a typed specification attribute to a category. type is number, enum, bool or text; enums list their options. Names are unique per category. Requires a JWT issued with the admin audience.
+//	@Security		BearerAuth
+//	@Tags			Category
+//	@Accept			json
+//	@Produce		json
+//	@Param			category	path		int							true	"Category ID"
+//	@Param			body		body		models.SpecAttributeNoID	true	"Attribute"
+//	@Success		201			{object}	models.SpecAttribute
+//	@Failure		400			{object}	models.Error
+//	@Failure		403			{object}	models.Error
+//	@Failure		404			{object}	models.Error
+//	@Failure		409			{object}	models.Error
+//	@Failure		500			{object}	models.Error
+//	@Router			/api/v1/category/{category}/spec/insert [put]
+func InsertCategorySpec(c *gin.Context) {
+	log := c.MustGet("logger").(*zap.SugaredLogger)
+	db := c.MustGet("db").(*sql.DB)
+
+	isAdmin, _ := c.Get("user_is_admin")
+	if adminFlag, ok := isAdmin.(bool); !ok || !adminFlag {
+		log.Warn("unauthorized category spec insert attempt without admin privileges")
+		c.AbortWithStatusJSON(http.StatusForbidden, models.Error{Error: "admin privileges required"})
+		return
+	}
+
+	categoryID, ok := specCategory(c, log, db)
+	if !ok {
+		return
+	}
+
+	input, ok := specAttributeBody(c, log)
+	if !ok {
+		return
+	}
+
+	options, err := utils.EncodeSpecOptions(input.Options)
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, models.Error{Error: err.Error()})
+		return
+	}
+
+	result, err := db.Exec(`INSERT INTO category_spec_attributes
+        (categoryId, attributeName, attributeLabel, attributeType, attributeUnit, attributeOptions, attributeRequired)
+        VALUES (?, ?, ?, ?, ?, ?, ?)`,
+		categoryID, input.Name, input.Label, input.Type, input.Unit, options, input.Required)
+	if err != nil {
+		respondSpecAttributeWriteError(c, log, err)
+		return
+	}
+	attributeID, err := result.LastInsertId()
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, models.Error{Error: err.Error()})
+		return
+	}
+
+	attribute, err := utils.GetSpecAttribute(db, categoryID, attributeID)
+	if err != nil {
+		log.Errorf("error loading category spec: %#v", err)
+		c.JSON(http.StatusInternalServerError, models.Error{Error: err.Error()})
+		return
+	}
+
+	c.JSON(http.StatusCreated, attribute)
+}
+
+// UpdateCategorySpec changes an attribute of the specification schema of a category.
+//
+//	@Summary		Update category spec
+//	@Description	Update a specification attribute of a category. The type cannot change while gear has values for the attribute, and enum options cannot drop a value in use. Requires a JWT issued with the admin audience.
+//	@Security		BearerAuth
+//	@Tags			Category
+//	@Accept			json
+//	@Produce		json
+//	@Param			category	path		int							true	"Category ID"
+//	@Param			attribute	path		int							true	"Attribute ID"
+//	@Param			body		body		models.SpecAttributeNoID	true	"Attribute"
+//	@Success		200			{object}	models.SpecAttribute
+//	@Failure		400			{object}	models.Error
+//	@Failure		403			{object}	models.Error
+//	@Failure		404			{object}	models.Error
+//	@Failure		409			{object}	models.Error
+//	@Failure		500			{object}	models.Error
+//	@Router			/api/v1/category/{category}/spec/{attribute}/update [post]
+func UpdateCategorySpec(c *gin.Context) {
+	log := c.MustGet("logger").(*zap.SugaredLogger)
+	db := c.MustGet("db").(*sql.DB)
+
+	isAdmin, _ := c.Get("user_is_admin")
+	if adminFlag, ok := isAdmin.(bool); !ok || !adminFlag {
+		log.Warn("unauthorized category spec update attempt without admin privileges")
+		c.AbortWithStatusJSON(http.StatusForbidden, models.Error{Error: "admin privileges required"})
+		return
+	}
+
+	categoryID, ok := specCategory(c, log, db)
+	if !ok {
+		return
+	}
+
+	existing, ok := specAttributeFromRoute(c, log, db, categoryID)
+	if !ok {
+		return
+	}
+
+	input, ok := specAttributeBody(c, log)
+	if !ok {
+		return
+	}
+
+	var inUse int
+	if err := db.QueryRow("SELECT COUNT(*) FROM gear_spec_values WHERE attributeId = ?", *existing.AttributeID).Scan(&inUse); err != nil {
+		c.JSON(http.StatusInternalServerError, models.Error{Error: err.Error()})
+		return
+	}
+	if inUse > 0 && input.Type != existing.Type {
+		c.JSON(http.StatusConflict, models.Error{Error: fmt.Sprintf("%d gear have values for %s; its type cannot change", inUse, existing.Name)})
+		return
+	}
+	if inUse > 0 && input.Type == utils.SpecTypeEnum {
+		options := make([]interface{}, 0, len(input.Options)+1)
+		options = append(options, *existing.AttributeID)
+		for _, option := range input.Options {
+			options = append(options, option)
+		}
+		var dropped int
+		err := db.QueryRow(`SELECT COUNT(*) FROM gear_spec_values WHERE attributeId = ? AND valueText NOT IN (`+strings.Repeat("?, ", len(input.Options)-1)+"?"+`)`, options...).Scan(&dropped)
+		if err != nil {
+			c.JSON(http.StatusInternalServerError, models.Error{Error: err.Error()})
+			return
+		}
+		if dropped > 0 {
+			c.JSON(http.StatusConflict, models.Error{Error: fmt.Sprintf("%d gear use an option of %s that would be removed", dropped, existing.Name)})
+			return
+		}
+	}
+
+	options, err := utils.EncodeSpecOptions(input.Options)
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, models.Error{Error: err.Error()})
+		return
+	}
+
+	_, err = db.Exec(`UPDATE category_spec_attributes SET attributeName = ?, attributeLabel = ?, attributeType = ?,
+        attributeUnit = ?, attributeOptions = ?, attributeRequired = ? WHERE attributeId = ?`,
+		input.Name, input.Label, input.Type, input.Unit, options, input.Required, *existing.AttributeID)
+	if err != nil {
+		respondSpecAttributeWriteError(c, log, err)
+		return
+	}
+
+	attribute, err := utils.GetSpecAttribute(db, categoryID, *existing.AttributeID)
+	if err != nil {
+		log.Errorf("error loading category spec: %#v", err)
+		c.JSON(http.StatusInternalServerError, models.Error{Error: err.Error()})
+		return
+	}
+
+	c.JSON(http.StatusOK, attribute)
+}
+
+// DeleteCategorySpec removes an attribute, and the gear values for it, from a category.
+//
+//	@Summary		Delete category spec
+//	@Description	Delete a specification attribute of a category together with the values gear has for it. Requires a JWT issued with the admin audience.
+//	@Security		BearerAuth
+//	@Tags			Category
+//	@Produce		json
+//	@Param			category	path		int	true	"Category ID"
+//	@Param			attribute	path		int	true	"Attribute ID"
+//	@Success		200			{object}	models.Status
+//	@Failure		400			{object}	models.Error
+//	@Failure		403			{object}	models.Error
+//	@Failure		404			{object}	models.Error
+//	@Failure		500			{object}	models.Error
+//	@Router			/api/v1/category/{category}/spec/{attribute}/delete [delete]
+func DeleteCategorySpec(c *gin.Context) {
+	log := c.MustGet("logger").(*zap.SugaredLogger)
+	db := c.MustGet("db").(*sql.DB)
+
+	isAdmin, _ := c.Get("user_is_admin")
+	if adminFlag, ok := isAdmin.(bool); !ok || !adminFlag {
+		log.Warn("unauthorized category spec delete attempt without admin privileges")
+		c.AbortWithStatusJSON(http.StatusForbidden, models.Error{Error: "admin privileges required"})
+		return
+	}
+
+	categoryID, ok := specCategory(c, log, db)
+	if !ok {
+		return
+	}
+
+	attribute, ok := specAttributeFromRoute(c, log, db, categoryID)
+	if !ok {
+		return
+	}
+
+	if _, err := db.Exec("DELETE FROM category_spec_attributes WHERE attributeId = ?", *attribute.AttributeID); err != nil {
+		log.Errorf("error deleting category spec: %#v", err)
+		c.JSON(http.StatusInternalServerError, models.Error{Error: err.Error()})
+		return
+	}
+
+	c.JSON(http.StatusOK, models.Status{Status: fmt.Sprintf("success! Spec %s was deleted", attribute.Name)})
+}
+
+// specCategory parses the category route parameter and checks the category exists.
+func specCategory(c *gin.Context, log *zap.SugaredLogger, db *sql.DB) (int64, bool) {
+	categoryID, err := strconv.ParseInt(c.Param("category"), 10, 64)
+	if err != nil {
+		log.Errorf("invalid category ID: %#v", err)
+		c.JSON(http.StatusBadRequest, models.Error{Error: "invalid category ID"})
+		return 0, false
+	}
+
+	var exists bool
+	if err := db.QueryRow("SELECT EXISTS (SELECT 1 FROM gear_category WHERE categoryId = ?)", categoryID).Scan(&exists); err != nil {
+		log.Errorf("error looking up category: %#v", err)
+		c.JSON(http.StatusInternalServerError, models.Error{Error: err.Error()})
+		return 0, false
+	}
+	if !exists {
+		c.JSON(http.StatusNotFound, models.Error{Error: "Category not found"})
+		return 0, false
+	}
+
+	return categoryID, true
+}
+
+// specAttributeFromRoute loads the attribute route parameter, which must belong to categoryID.
+func specAttributeFromRoute(c *gin.Context, log *zap.SugaredLogger, db *sql.DB, categoryID int64) (*models.SpecAttribute, bool) {
+	attributeID, err := strconv.ParseInt(c.Param("attribute"), 10, 64)
+	if err != nil {
+		log.Errorf("invalid attribute ID: %#v", err)
+		c.JSON(http.StatusBadRequest, models.Error{Error: "invalid attribute ID"})
+		return nil, false
+	}
+
+	attribute, err := utils.GetSpecAttribute(db, categoryID, attributeID)
+	if err == sql.ErrNoRows {
+		c.JSON(http.StatusNotFound, models.Error{Error: "Spec not found"})
+		return nil, false
+	}
+	if err != nil {
+		log.Errorf("error loading category spec: %#v", err)
+		c.JSON(http.StatusInternalServerError, models.Error{Error: err.Error()})
+		return nil, false
+	}
+
+	return attribute, true
+}
+
+// specAttributeBody reads and validates an attribute definition from the request body.
+func specAttributeBody(c *gin.Context, log *zap.SugaredLogger) (*models.SpecAttributeNoID, bool) {
+	data, err := io.ReadAll(c.Request.Body)
+	if err != nil {
+		log.Errorf("error reading request body: %#v", err)
+		c.JSON(http.StatusInternalServerError, models.Error{Error: err.Error()})
+		return nil, false
+	}
+
+	var input models.SpecAttributeNoID
+	if err := json.Unmarshal(data, &input); err != nil {
+		c.JSON(http.StatusBadRequest, models.Error{Error: err.Error()})
+		return nil, false
+	}
+	if err := utils.ValidateSpecAttribute(&input); err != nil {
+		respondSpecError(c, log, err)
+		return nil, false
+	}
+
+	return &input, true
+}
+
+// respondSpecAttributeWriteError reports a duplicate attribute name as a conflict.
+func respondSpecAttributeWriteError(c *gin.Context, log *zap.SugaredLogger, err error) {
+	var sqliteErr sqlite3.Error
+	if errors.As(err, &sqliteErr) && sqliteErr.ExtendedCode == sqlite3.ErrConstraintUnique {
+		c.JSON(http.StatusConflict, models.Error{Error: "the category already has a spec with this name"})
+		return
+	}
+	log.Errorf("error writing category spec: %#v", err)
+	c.JSON(http.StatusInternalServerError, models.Error{Error: err.Error()})
+}
+
+// respondSpecError writes a 400 for spec errors and a 500 for everything else.
+func respondSpecError(c *gin.Context, log *zap.SugaredLogger, err error) {
+	var specErr *utils.SpecError
+	if errors.As(err, &specErr) {
+		log.Warnf("Invalid specs: %s", specErr.Message)
+		c.JSON(http.StatusBadRequest, models.Error{Error: specErr.Message})
+		return
+	}
+	log.Errorf("Unable to handle specs: %#v", err)
+	c.JSON(http.StatusInternalServerError, models.Error{Error: err.Error()})
+}
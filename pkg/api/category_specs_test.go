@@ -0,0 +1,177 @@
+package endpoints
+
+import (
+	"encoding/json"
+	"net/http"
+	"sort"
+	"testing"
+
+	models "github.com/Sea-Shell/gogear-api/pkg/models"
+
+	"github.com/gin-gonic/gin"
+)
+
+func TestCategorySpecs(t *testing.T) {
+	db, router := setupCatalogTest(t, 1)
+	seedCatalog(t, db)
+	seedCatalogGear(t, db, 1, 1, "Nallo", 2400)
+
+	isAdmin := false
+	v1 := router.Group("/api/v1", testAuthMiddleware(1), func(c *gin.Context) {
+		c.Set("user_is_admin", isAdmin)
+		c.Next()
+	})
+	v1.GET("/category/:category/spec/list", ListCategorySpecs)
+	v1.PUT("/category/:category/spec/insert", InsertCategorySpec)
+	v1.POST("/category/:category/spec/:attribute/update", UpdateCategorySpec)
+	v1.DELETE("/category/:category/spec/:attribute/delete", DeleteCategorySpec)
+	v1.PUT("/gear/insert", InsertGear)
+	v1.POST("/gear/:gear/update", UpdateGear)
+	v1.GET("/gear/:gear/get", GetGear)
+
+	seasons := `{"name":"season","label":"Season","type":"enum","options":["3-season","4-season"],"required":true}`
+	if w := authRequest(t, router, http.MethodPut, "/api/v1/category/1/spec/insert", seasons); w.Code != http.StatusForbidden {
+		t.Fatalf("InsertCategorySpec: expected 403 for non-admin, got %d", w.Code)
+	}
+
+	isAdmin = true
+	for _, attribute := range []string{
+		seasons,
+		`{"name":"hydrostatic_head","label":"Hydrostatic head","type":"number","unit":"mm"}`,
+		`{"name":"freestanding","label":"Freestanding","type":"bool"}`,
+	} {
+		if w := authRequest(t, router, http.MethodPut, "/api/v1/category/1/spec/insert", attribute); w.Code != http.StatusCreated {
+			t.Fatalf("InsertCategorySpec %s: %d %s", attribute, w.Code, w.Body.String())
+		}
+	}
+	if w := authRequest(t, router, http.MethodPut, "/api/v1/category/2/spec/insert", `{"name":"rvalue","label":"R-value","type":"number"}`); w.Code != http.StatusCreated {
+		t.Fatalf("InsertCategorySpec rvalue: %d %s", w.Code, w.Body.String())
+	}
+
+	for attribute, want := range map[string]int{
+		seasons: http.StatusConflict,
+		`{"name":"Bad Name","label":"x","type":"text"}`:      http.StatusBadRequest,
+		`{"name":"fabric","label":"Fabric","type":"colour"}`: http.StatusBadRequest,
+		`{"name":"doors","label":"Doors","type":"enum"}`:     http.StatusBadRequest,
+	} {
+		if w := authRequest(t, router, http.MethodPut, "/api/v1/category/1/spec/insert", attribute); w.Code != want {
+			t.Errorf("InsertCategorySpec %s: expected %d, got %d", attribute, want, w.Code)
+		}
+	}
+	if w := authRequest(t, router, http.MethodPut, "/api/v1/category/99/spec/insert", seasons); w.Code != http.StatusNotFound {
+		t.Errorf("InsertCategorySpec: expected 404 for a missing category, got %d", w.Code)
+	}
+
+	w := authRequest(t, router, http.MethodGet, "/api/v1/category/1/spec/list", "")
+	var schema []models.SpecAttribute
+	if err := json.Unmarshal(w.Body.Bytes(), &schema); err != nil || len(schema) != 3 {
+		t.Fatalf("ListCategorySpecs: %d %s", w.Code, w.Body.String())
+	}
+	names := []string{schema[0].Name, schema[1].Name, schema[2].Name}
+	if names[0] != "freestanding" || names[1] != "hydrostatic_head" || names[2] != "season" {
+		t.Errorf("ListCategorySpecs: expected attributes ordered by name, got %v", names)
+	}
+	season := schema[2]
+	if len(season.Options) != 2 || !season.Required || *schema[1].Unit != "mm" {
+		t.Errorf("ListCategorySpecs: unexpected attributes %+v", schema)
+	}
+
+	gear := func(name string, category int, specs string) string {
+		return `{"gear_top_category_id":1,"gear_category_id":` + itoa64(int64(category)) + `,"gear_manufacture_id":1,"gear_name":"` + name +
+			`","gear_weight":1000,"gear_status":true,"specs":` + specs + `}`
+	}
+	for body, want := range map[string]int{
+		gear("Unknown", 1, `{"season":"3-season","rvalue":4}`):            http.StatusBadRequest,
+		gear("Missing", 1, `{"freestanding":true}`):                       http.StatusBadRequest,
+		gear("Wrong type", 1, `{"season":"3-season","freestanding":"y"}`): http.StatusBadRequest,
+		gear("Bad option", 1, `{"season":"summer"}`):                      http.StatusBadRequest,
+		gear("Akto", 1, `{"season":"3-season","hydrostatic_head":5000}`):  http.StatusOK,
+		gear("Soulo", 1, `{"season":"4-season","freestanding":true}`):     http.StatusOK,
+		gear("Tarp", 2, `{"rvalue":4.2}`):                                 http.StatusOK,
+	} {
+		if w := authRequest(t, router, http.MethodPut, "/api/v1/gear/insert", body); w.Code != want {
+			t.Errorf("InsertGear %s: expected %d, got %d %s", body, want, w.Code, w.Body.String())
+		}
+	}
+
+	var aktoID int64
+	if err := db.QueryRow("SELECT gearId FROM gear WHERE gearName = 'Akto'").Scan(&aktoID); err != nil {
+		t.Fatal(err)
+	}
+	update := `{"gear_id":` + itoa64(aktoID) + `,"gear_top_category_id":1,"gear_category_id":1,"gear_manufacture_id":1,"gear_name":"Akto","gear_weight":1000,"gear_status":true,"specs":{"freestanding":false}}`
+	if w := authRequest(t, router, http.MethodPost, "/api/v1/gear/"+itoa64(aktoID)+"/update", update); w.Code != http.StatusOK {
+		t.Fatalf("UpdateGear: %d %s", w.Code, w.Body.String())
+	}
+
+	w = authRequest(t, router, http.MethodGet, "/api/v1/gear/"+itoa64(aktoID)+"/get", "")
+	var fullGear models.FullGear
+	if err := json.Unmarshal(w.Body.Bytes(), &fullGear); err != nil {
+		t.Fatalf("GetGear: %d %s", w.Code, w.Body.String())
+	}
+	if fullGear.Specs["season"] != "3-season" || fullGear.Specs["hydrostatic_head"] != float64(5000) || fullGear.Specs["freestanding"] != false {
+		t.Errorf("GetGear: update should keep stored specs, got %v", fullGear.Specs)
+	}
+
+	listNames := func(query string) []string {
+		t.Helper()
+		w := authRequest(t, router, http.MethodGet, "/api/v1/gear/list?"+query, "")
+		if w.Code != http.StatusOK {
+			t.Fatalf("ListGear %s: %d %s", query, w.Code, w.Body.String())
+		}
+		var names []string
+		for _, item := range decodeListPayload(t, w.Body.Bytes()).Items {
+			names = append(names, item["gear_name"].(string))
+		}
+		sort.Strings(names)
+		return names
+	}
+	for query, want := range map[string][]string{
+		"spec.rvalue>=4":                                      {"Tarp"},
+		"spec.rvalue>5":                                       nil,
+		"spec.season=4-season":                                {"Soulo"},
+		"spec.season=3-season&spec.season=4-season":           {"Akto", "Soulo"},
+		"spec.season!=4-season":                               {"Akto"},
+		"spec.freestanding=true":                              {"Soulo"},
+		"spec.hydrostatic_head>=3000&spec.freestanding=false": {"Akto"},
+		"spec.hydrostatic_head<3000":                          nil,
+	} {
+		got := listNames(query)
+		if len(got) != len(want) {
+			t.Errorf("ListGear %s = %v, want %v", query, got, want)
+			continue
+		}
+		for i := range want {
+			if got[i] != want[i] {
+				t.Errorf("ListGear %s = %v, want %v", query, got, want)
+				break
+			}
+		}
+	}
+	for _, query := range []string{"spec.unknown=1", "spec.rvalue>=warm", "spec.season>=3"} {
+		if w := authRequest(t, router, http.MethodGet, "/api/v1/gear/list?"+query, ""); w.Code != http.StatusBadRequest {
+			t.Errorf("ListGear %s: expected 400, got %d", query, w.Code)
+		}
+	}
+
+	seasonID := itoa64(*season.AttributeID)
+	if w := authRequest(t, router, http.MethodPost, "/api/v1/category/1/spec/"+seasonID+"/update", `{"name":"season","label":"Season","type":"text"}`); w.Code != http.StatusConflict {
+		t.Errorf("UpdateCategorySpec: expected 409 for a type change with values, got %d", w.Code)
+	}
+	if w := authRequest(t, router, http.MethodPost, "/api/v1/category/1/spec/"+seasonID+"/update", `{"name":"season","label":"Season","type":"enum","options":["3-season"]}`); w.Code != http.StatusConflict {
+		t.Errorf("UpdateCategorySpec: expected 409 for dropping an option in use, got %d", w.Code)
+	}
+	if w := authRequest(t, router, http.MethodPost, "/api/v1/category/1/spec/"+seasonID+"/update", `{"name":"season","label":"Seasons","type":"enum","options":["3-season","4-season","winter"]}`); w.Code != http.StatusOK {
+		t.Errorf("UpdateCategorySpec: expected 200, got %d %s", w.Code, w.Body.String())
+	}
+
+	if w := authRequest(t, router, http.MethodDelete, "/api/v1/category/1/spec/"+seasonID+"/delete", ""); w.Code != http.StatusOK {
+		t.Fatalf("DeleteCategorySpec: %d %s", w.Code, w.Body.String())
+	}
+	var remaining int
+	if err := db.QueryRow("SELECT COUNT(*) FROM gear_spec_values WHERE attributeId = ?", *season.AttributeID).Scan(&remaining); err != nil || remaining != 0 {
+		t.Errorf("DeleteCategorySpec: %d values left behind, %v", remaining, err)
+	}
+	if w := authRequest(t, router, http.MethodDelete, "/api/v1/category/2/spec/"+seasonID+"/delete", ""); w.Code != http.StatusNotFound {
+		t.Errorf("DeleteCategorySpec: expected 404 for another category's spec, got %d", w.Code)
+	}
+}
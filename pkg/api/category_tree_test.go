@@ -0,0 +1,121 @@
+package endpoints
+
+import (
+	"encoding/json"
+	"net/http"
+	"testing"
+
+	models "github.com/Sea-Shell/gogear-api/pkg/models"
+
+	"github.com/gin-gonic/gin"
+)
+
+func TestCategoryTree(t *testing.T) {
+	db, router := setupCatalogTest(t, 1)
+	seedCatalog(t, db)
+	if _, err := db.Exec(`INSERT INTO gear_top_category (topCategoryId, topCategoryName) VALUES (2, 'Sleep')`); err != nil {
+		t.Fatal(err)
+	}
+	seedCatalogGear(t, db, 1, 1, "Nallo", 2400)
+	seedCatalogGear(t, db, 2, 2, "Tarp", 500)
+
+	isAdmin := true
+	v1 := router.Group("/api/v1", testAuthMiddleware(1), func(c *gin.Context) {
+		c.Set("user_is_admin", isAdmin)
+		c.Next()
+	})
+	v1.GET("/category/tree", GetCategoryTree)
+	v1.POST("/category/reorder", ReorderCategories)
+	v1.PUT("/category/insert", InsertCategory)
+	v1.POST("/category/:category/update", UpdateCategory)
+	v1.POST("/category/:category/move", MoveCategory)
+	v1.GET("/category/:category/get", GetCategory)
+
+	w := authRequest(t, router, http.MethodPut, "/api/v1/category/insert", `{"category_name":"Backpacking tents","category_parent_id":1}`)
+	var created models.GearCategory
+	if err := json.Unmarshal(w.Body.Bytes(), &created); err != nil || w.Code != http.StatusOK {
+		t.Fatalf("InsertCategory: %d %s", w.Code, w.Body.String())
+	}
+	if created.CategoryTopCategoryID != 1 || *created.CategoryParentID != 1 {
+		t.Errorf("InsertCategory: nested category should inherit its top category: %+v", created)
+	}
+	nested := itoa64(*created.CategoryID)
+	if _, err := db.Exec(`INSERT INTO gear (gearId, gearTopCategoryId, gearCategoryId, gearManufactureId, gearName, gearWeight, gearHeight, gearLength, gearWidth, gearStatus)
+        VALUES (3, 2, ?, 1, 'Akto', 1600, 0, 0, 0, 1)`, *created.CategoryID); err != nil {
+		t.Fatal(err)
+	}
+	var gearTop int64
+	if err := db.QueryRow(`SELECT gearTopCategoryId FROM gear WHERE gearId = 3`).Scan(&gearTop); err != nil || gearTop != 1 {
+		t.Errorf("gear top category should follow its category, got %d (%v)", gearTop, err)
+	}
+
+	w = authRequest(t, router, http.MethodGet, "/api/v1/gear/list?category=1", "")
+	if payload := decodeListPayload(t, w.Body.Bytes()); len(payload.Items) != 2 {
+		t.Errorf("ListGear category=1: expected gear of descendants, got %s", w.Body.String())
+	}
+
+	if w := authRequest(t, router, http.MethodPost, "/api/v1/category/1/move", `{"parent_id":`+nested+`}`); w.Code != http.StatusBadRequest {
+		t.Errorf("MoveCategory below own descendant: expected 400, got %d", w.Code)
+	}
+	if w := authRequest(t, router, http.MethodPost, "/api/v1/category/99/move", `{"top_category_id":1}`); w.Code != http.StatusNotFound {
+		t.Errorf("MoveCategory missing category: expected 404, got %d", w.Code)
+	}
+	if w := authRequest(t, router, http.MethodPost, "/api/v1/category/1/move", `{"top_category_id":2,"position":0}`); w.Code != http.StatusOK {
+		t.Fatalf("MoveCategory: %d %s", w.Code, w.Body.String())
+	}
+	if err := db.QueryRow(`SELECT gearTopCategoryId FROM gear WHERE gearId = 3`).Scan(&gearTop); err != nil || gearTop != 2 {
+		t.Errorf("MoveCategory: gear below the moved category should follow it, got %d (%v)", gearTop, err)
+	}
+
+	if w := authRequest(t, router, http.MethodPost, "/api/v1/category/reorder", `{"top_category_id":1,"order":[3]}`); w.Code != http.StatusBadRequest {
+		t.Errorf("ReorderCategories with missing sibling: expected 400, got %d", w.Code)
+	}
+	if w := authRequest(t, router, http.MethodPost, "/api/v1/category/reorder", `{"top_category_id":1,"order":[3,2]}`); w.Code != http.StatusOK {
+		t.Fatalf("ReorderCategories: %d %s", w.Code, w.Body.String())
+	}
+	if w := authRequest(t, router, http.MethodPost, "/api/v1/category/reorder", `{"order":[2,1]}`); w.Code != http.StatusOK {
+		t.Fatalf("ReorderCategories top categories: %d %s", w.Code, w.Body.String())
+	}
+
+	w = authRequest(t, router, http.MethodGet, "/api/v1/category/tree", "")
+	var tree []models.CategoryTree
+	if err := json.Unmarshal(w.Body.Bytes(), &tree); err != nil || w.Code != http.StatusOK {
+		t.Fatalf("GetCategoryTree: %d %s", w.Code, w.Body.String())
+	}
+	if len(tree) != 2 || tree[0].TopCategoryName != "Sleep" || tree[0].GearCount != 2 || tree[1].GearCount != 1 {
+		t.Fatalf("GetCategoryTree: unexpected top categories %+v", tree)
+	}
+	if children := tree[0].Children; len(children) != 1 || children[0].CategoryName != "Tents" ||
+		len(children[0].Children) != 1 || children[0].Children[0].CategoryName != "Backpacking tents" || children[0].GearCount != 2 {
+		t.Errorf("GetCategoryTree: unexpected Sleep branch %+v", children)
+	}
+	if children := tree[1].Children; len(children) != 2 || children[0].CategoryName != "Bivys" || children[1].CategoryName != "Tarps" {
+		t.Errorf("GetCategoryTree: unexpected Shelter order %+v", children)
+	}
+
+	isAdmin = false
+	if w := authRequest(t, router, http.MethodPost, "/api/v1/category/2/move", `{"top_category_id":2}`); w.Code != http.StatusForbidden {
+		t.Errorf("MoveCategory: expected 403 for non-admin, got %d", w.Code)
+	}
+	isAdmin = true
+
+	// The flat update route keeps the parent unless told otherwise.
+	if w := authRequest(t, router, http.MethodPost, "/api/v1/category/"+nested+"/update", `{"category_id":`+nested+`,"category_name":"Backpacking"}`); w.Code != http.StatusOK {
+		t.Fatalf("UpdateCategory: %d %s", w.Code, w.Body.String())
+	}
+	w = authRequest(t, router, http.MethodGet, "/api/v1/category/"+nested+"/get", "")
+	var updated models.GearCategory
+	if err := json.Unmarshal(w.Body.Bytes(), &updated); err != nil {
+		t.Fatal(err)
+	}
+	if updated.CategoryName != "Backpacking" || updated.CategoryParentID == nil || updated.CategoryTopCategoryID != 2 {
+		t.Errorf("UpdateCategory: placement lost on rename: %+v", updated)
+	}
+
+	if w := authRequest(t, router, http.MethodPost, "/api/v1/category/"+nested+"/update", `{"category_id":`+nested+`,"category_top_category_id":1}`); w.Code != http.StatusOK {
+		t.Fatalf("UpdateCategory top category: %d %s", w.Code, w.Body.String())
+	}
+	if err := db.QueryRow(`SELECT gearTopCategoryId FROM gear WHERE gearId = 3`).Scan(&gearTop); err != nil || gearTop != 1 {
+		t.Errorf("UpdateCategory: gear should follow the new top category, got %d (%v)", gearTop, err)
+	}
+}
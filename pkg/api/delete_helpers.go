@@ -0,0 +1,59 @@
+package endpoints
+
+import (
+	"database/sql"
+	"errors"
+	"fmt"
+	"net/http"
+	"strconv"
+
+	models "github.com/Sea-Shell/gogear-api/pkg/models"
+	utils "github.com/Sea-Shell/gogear-api/pkg/utils"
+
+	gin "github.com/gin-gonic/gin"
+	zap "go.uber.org/zap"
+)
+
+// deleteReassigning deletes the row with the given ID, moving what references it to the
+// reassign_to query parameter when given. It writes the error response and returns false
+// when nothing was deleted: 409 with the dependent counts when the row is still in use.
+func deleteReassigning(c *gin.Context, log *zap.SugaredLogger, db *sql.DB, deletion utils.Deletion, id int64) (*models.DependentsError, bool) {
+	var reassignTo *int64
+	if value := c.Query("reassign_to"); value != "" {
+		target, err := strconv.ParseInt(value, 10, 64)
+		if err != nil {
+			c.JSON(http.StatusBadRequest, models.Error{Error: "invalid reassign_to"})
+			return nil, false
+		}
+		reassignTo = &target
+	}
+
+	moved, err := utils.DeleteReassigning(db, deletion, id, reassignTo)
+	var dependentsErr *utils.DependentsError
+	var reassignErr *utils.ReassignError
+	switch {
+	case errors.As(err, &dependentsErr):
+		log.Warnf("refused to delete %s %d: %s", deletion.Name, id, dependentsErr.Error())
+		c.JSON(http.StatusConflict, dependentsErr.Dependents)
+		return nil, false
+	case errors.As(err, &reassignErr):
+		c.JSON(http.StatusBadRequest, models.Error{Error: reassignErr.Message})
+		return nil, false
+	case errors.Is(err, sql.ErrNoRows):
+		c.JSON(http.StatusNotFound, models.Error{Error: fmt.Sprintf("%s not found", deletion.Name)})
+		return nil, false
+	case err != nil:
+		log.Error(err.Error())
+		c.JSON(http.StatusInternalServerError, models.Error{Error: err.Error()})
+		return nil, false
+	}
+	return moved, true
+}
+
+// reassignedSummary describes what a delete moved, for the status message.
+func reassignedSummary(moved *models.DependentsError) string {
+	if moved.GearCount == 0 && moved.CategoryCount == 0 {
+		return ""
+	}
+	return fmt.Sprintf(" after moving %d gear and %d categories", moved.GearCount, moved.CategoryCount)
+}
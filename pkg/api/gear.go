@@ -2,12 +2,10 @@ package endpoints
 
 import (
 	"database/sql"
+	"encoding/json"
 	"fmt"
 	"io"
-	"math"
 	"net/http"
-	"net/url"
-	"reflect"
 	"strconv"
 	"strings"
 
@@ -19,433 +17,246 @@ import (
 	zap "go.uber.org/zap"
 )
 
+const (
+	defaultSuggestLimit = 8
+	maxSuggestLimit     = 25
+)
+
 // ListGear lists gear in the database
 //
 //	@Summary		List gear
-//	@Description	Get a list of gear items
+//	@Description	Get a list of gear items. gear_rating_average and gear_review_count summarize the visible reviews; sort by gear_rating_average to list the best rated first with order desc.
 //	@Security		BearerAuth
 //	@Tags			Gear
 //	@Accept			json
 //	@Produce		json
 //	@Param			page			query		int			false	"Page number"				default(1)
 //	@Param			limit			query		int			false	"Number of items per page"	default(30)
-//	@Param			category		query		string		false	"Gear category"
+//	@Param			sort			query		string		false	"Field to sort by, json or db field name"
+//	@Param			order			query		string		false	"Sort direction, asc or desc"	default(asc)
+//	@Param			cursor			query		string		false	"Opaque cursor from next_cursor or prev_cursor, replaces page"
+//	@Param			count			query		bool		false	"Include total item count"	default(true)
+//	@Param			category		query		string		false	"Gear category, including the categories below it"
 //	@Param			topCategory		query		string		false	"Top gear category"
 //	@Param			manufacturer	query		string		false	"Gear manufacturer"
 //	@Param			collection		query		[]string	false	"string collection"	collectionFormat(multi)
 //	@Param			container		query		bool		false	"string collection"	default(false)
+//	@Param			spec.{name}		query		string		false	"Spec filter: spec.name=value (repeat for any of several values), spec.name!=value, or spec.name>=, <=, >, < for numbers"
+//	@Param			tag				query		[]string	false	"Catalog tags"								collectionFormat(multi)
+//	@Param			tag_match		query		string		false	"Match gear with any or all of the tags"	default(any)
 //	@Success		200				{object}	models.ResponsePayload{items=[]models.GearListItem}
 //	@Failure		default			{object}	models.Error
 //	@Router			/api/v1/gear/list [get]
 func ListGear(c *gin.Context) {
 	c.Header("Content-Type", "application/json")
 
-	currentQueryParameters := c.Request.URL.Query()
-
-	page := c.Query("page")
-	limit := c.Query("limit")
-	category := c.Query("category")
-	topCategory := c.Query("topCategory")
-	manufacturer := c.Query("manufacturer")
-	container, hasContainer := c.GetQuery("container")
-
 	log := c.MustGet("logger").(*zap.SugaredLogger)
-	//cache := c.MustGet("cache").(*cache.BigCache)
 	db := c.MustGet("db").(*sql.DB)
 
 	log.Debugf("Request parameters: %#v", c.Request.URL.Query())
 
-	if limit == "" {
-		limit = "30"
-	}
-
-	if page == "" || page == "0" {
-		page = "1"
-	}
+	query := utils.NewListQuery[models.GearListItem]("gear" + gearJoins)
+	visible, visibleArgs := utils.GearVisibleCondition(c.MustGet("user_id_int64").(int64))
+	query.Where(visible, visibleArgs...)
 
-	pageInt, err := strconv.Atoi(page)
-	if err != nil {
-		log.Errorf("Error setting page to int: %#v", err)
-		c.IndentedJSON(http.StatusInternalServerError, models.Error{Error: err.Error()})
+	if err := utils.FilterGear(query, c.Request.URL.Query()); err != nil {
+		respondListQueryError(c, log, err)
 		return
 	}
-
-	limitInt, err := strconv.Atoi(limit)
-	if err != nil {
-		log.Errorf("Error setting limit to int: %#v", err)
-		c.IndentedJSON(http.StatusInternalServerError, models.Error{Error: err.Error()})
+	if err := utils.FilterGearSpecs(query, db, c.Request.URL.Query()); err != nil {
+		respondSpecError(c, log, err)
 		return
 	}
-
-	if pageInt <= 0 {
-		log.Errorf("Error page is less than 0: %#v", err)
-		c.IndentedJSON(http.StatusBadRequest, models.Error{Error: "Invalid page number"})
+	if err := utils.FilterTags(query, utils.GearTagTarget, c.Request.URL.Query()); err != nil {
+		respondListQueryError(c, log, err)
 		return
 	}
 
-	if limitInt <= 0 {
-		log.Errorf("Error limit is less than 0: %#v", err)
-		c.IndentedJSON(http.StatusBadRequest, models.Error{Error: "Invalid limit number"})
-		return
-	}
-
-	conditions := []string{}
-	queryParams := []interface{}{}
-	if topCategory != "" {
-		conditions = append(conditions, "gear.gearTopCategoryId = ?")
-		queryParams = append(queryParams, topCategory)
-	}
-	if category != "" {
-		conditions = append(conditions, "gear.gearCategoryId = ?")
-		queryParams = append(queryParams, category)
-	}
-	if manufacturer != "" {
-		conditions = append(conditions, "gear.GearManufactureId = ?")
-		queryParams = append(queryParams, manufacturer)
-	}
-	if hasContainer {
-		containerBool, err := strconv.ParseBool(container)
-		if err != nil {
-			c.IndentedJSON(http.StatusBadRequest, models.Error{Error: "container must be true or false"})
-			return
-		}
-		if containerBool {
-			conditions = append(conditions, "gear.gearIsContainer = 1")
-		} else {
-			conditions = append(conditions, "gear.gearIsContainer = 0")
-		}
-	}
-
-	whereClause := ""
-	if len(conditions) > 0 {
-		whereClause = " WHERE " + strings.Join(conditions, " AND ")
-	}
-
-	baseCountQuery := "SELECT COUNT(*) FROM gear"
-	countQuery := baseCountQuery + whereClause
+	respondList(c, log, db, query)
+}
 
-	var totalCount int
-	err = db.QueryRow(countQuery, queryParams...).Scan(&totalCount)
-	if err != nil {
-		log.Errorf("Error getting GearCount database: %#v", err)
-		c.IndentedJSON(http.StatusInternalServerError, models.Error{Error: err.Error()})
-		return
+// gearSpecsFromBody validates the specs of a gear insert or update body against the
+// schema of the gear's category. current holds the values already stored; they are kept
+// unless the body overrides them and the new category still defines them.
+func gearSpecsFromBody(c *gin.Context, log *zap.SugaredLogger, db *sql.DB, data []byte, current models.Specs) ([]models.SpecAttribute, models.Specs, bool) {
+	var body struct {
+		GearCategoryID int64                      `json:"gear_category_id"`
+		Specs          map[string]json.RawMessage `json:"specs"`
 	}
-
-	start := strconv.Itoa((pageInt - 1) * limitInt)
-	totalPages := int(math.Ceil(float64(totalCount) / float64(limitInt)))
-
-	startInt, err := strconv.Atoi(start)
-	if err != nil {
-		c.IndentedJSON(http.StatusInternalServerError, models.Error{Error: err.Error()})
-		return
+	if err := json.Unmarshal(data, &body); err != nil {
+		c.JSON(http.StatusBadRequest, models.Error{Error: err.Error()})
+		return nil, nil, false
 	}
 
-	var paramGear models.GearListItem
-	fields := utils.GetDBFieldNames(reflect.TypeOf(paramGear))
-
-	baseQuery := fmt.Sprintf(`SELECT %s FROM gear
-        LEFT JOIN manufacture ON gear.gearManufactureId = manufacture.manufactureId
-        LEFT JOIN gear_top_category ON gear.gearTopCategoryId = gear_top_category.topCategoryId
-        LEFT JOIN gear_category ON gear.gearCategoryId = gear_category.categoryId`,
-		strings.Join(fields, ", "))
-
-	queryLimit := fmt.Sprintf(" LIMIT %v, %v", startInt, limitInt)
-
-	query := baseQuery + whereClause + queryLimit
-
-	log.Debugf("Query: %s", query)
-
-	rows, err := db.Query(query, queryParams...)
+	schema, err := utils.SpecSchema(db, body.GearCategoryID)
 	if err != nil {
-		log.Errorf("Query error: %#v", err.Error())
-		c.IndentedJSON(http.StatusInternalServerError, models.Error{Error: err.Error()})
-		return
+		respondSpecError(c, log, err)
+		return nil, nil, false
 	}
-	defer rows.Close()
 
-	dest, err := utils.GetScanFields(paramGear)
+	specs, err := utils.ParseSpecs(schema, current, body.Specs)
 	if err != nil {
-		log.Errorf("Error getting destination arguments: %#v", err)
-		c.IndentedJSON(http.StatusInternalServerError, models.Error{Error: err.Error()})
-		return
-	}
-
-	var gearList []models.GearListItem
-
-	for rows.Next() {
-		err = rows.Scan(dest...)
-		if err != nil {
-			if err == sql.ErrNoRows {
-				log.Errorf("No gear found")
-				c.IndentedJSON(http.StatusNotFound, models.Error{Error: "No results"})
-				return
-			}
-			log.Errorf("Scan error: %#v", err)
-			c.IndentedJSON(http.StatusInternalServerError, models.Error{Error: err.Error()})
-			return
-		}
-
-		for i := 0; i < reflect.TypeOf(paramGear).NumField(); i++ {
-			reflect.ValueOf(&paramGear).Elem().Field(i).Set(reflect.ValueOf(dest[i]).Elem())
-		}
-
-		gearList = append(gearList, paramGear)
+		respondSpecError(c, log, err)
+		return nil, nil, false
 	}
 
-	if err := rows.Err(); err != nil {
-		log.Errorf("Row iteration error: %#v", err)
-		c.IndentedJSON(http.StatusInternalServerError, models.Error{Error: err.Error()})
-		return
-	}
-
-	payload := models.ResponsePayload{
-		TotalItemCount: totalCount,
-		CurrentPage:    pageInt,
-		ItemLimit:      limitInt,
-		TotalPages:     totalPages,
-		Items:          gearList,
-	}
-
-	if pageInt < totalPages {
-		currentQueryParameters.Set("page", strconv.Itoa(pageInt+1))
-		nextPage := url.URL{
-			Path:     c.Request.URL.Path,
-			RawQuery: currentQueryParameters.Encode(),
-		}
-		payload.NextPage = new(string)
-		*payload.NextPage = nextPage.String()
-	}
-
-	if pageInt > 1 {
-		currentQueryParameters.Set("page", strconv.Itoa(pageInt-1))
-		prevPage := url.URL{
-			Path:     c.Request.URL.Path,
-			RawQuery: currentQueryParameters.Encode(),
-		}
-		payload.PrevPage = new(string)
-		*payload.PrevPage = prevPage.String()
-	}
-
-	log.Infof("successfully fetched gear with id: %s, gearName: %s", paramGear.GearID, paramGear.GearName)
-	c.IndentedJSON(http.StatusOK, payload)
+	return schema, specs, true
 }
 
 // SearchGear is a function to search for gear items
 //
 //	@Summary		Search for gear
-//	@Description	Get a list of gear items based on search patterns
+//	@Description	Get a list of gear items based on search patterns. The default fulltext search matches every word as a prefix of the gear, manufacturer, category or size names, ranked by relevance with the matches highlighted in search_snippet.
 //	@Security		BearerAuth
 //	@Tags			Gear
 //	@Accept			json
 //	@Produce		json
 //	@Param			page			query		int		false	"Page number"				default(1)
 //	@Param			limit			query		int		false	"Number of items per page"	default(30)
+//	@Param			sort			query		string	false	"Field to sort by, json or db field name. Fulltext searches default to search_rank"
+//	@Param			order			query		string	false	"Sort direction, asc or desc"	default(asc)
+//	@Param			cursor			query		string	false	"Opaque cursor from next_cursor or prev_cursor, replaces page"
+//	@Param			count			query		bool	false	"Include total item count"	default(true)
 //	@Param			searchString	query		string	true	"String to search for"
-//	@Param			searchType		query		string	true	"Type of search method. valid choices are: startswith, contains, endswith"
-//	@Success		200				{object}	models.ResponsePayload{items=[]models.GearListItem}
+//	@Param			searchType		query		string	false	"Type of search method. valid choices are: fulltext, startswith, contains, endswith"	default(fulltext)
+//	@Param			category		query		string	false	"Gear category, including the categories below it"
+//	@Param			topCategory		query		string	false	"Top gear category"
+//	@Param			manufacturer	query		string	false	"Gear manufacturer"
+//	@Param			container		query		bool	false	"Only containers, or only non-containers"
+//	@Success		200				{object}	models.ResponsePayload{items=[]models.GearSearchItem}
 //	@Failure		default			{object}	models.Error
 //	@Router			/api/v1/gear/search [get]
 func SearchGear(c *gin.Context) {
 	c.Header("Content-Type", "application/json")
 
-	currentQueryParameters := c.Request.URL.Query()
-
-	page := c.Query("page")
-	limit := c.Query("limit")
-	searchString, searchStringPressent := c.GetQuery("searchString")
-	searchType, searchTypePressent := c.GetQuery("searchType")
+	searchString := strings.TrimSpace(c.Query("searchString"))
+	searchType := strings.TrimSpace(strings.ToLower(c.Query("searchType")))
+	if searchType == "" {
+		searchType = "fulltext"
+	}
 
 	log := c.MustGet("logger").(*zap.SugaredLogger)
-	//cache := c.MustGet("cache").(*cache.BigCache)
 	db := c.MustGet("db").(*sql.DB)
 
 	log.Debugf("Request parameters: %#v", c.Request.URL.Query())
 
-	if limit == "" {
-		limit = "30"
-	}
-
-	if page == "" || page == "0" {
-		page = "1"
-	}
-
-	pageInt, err := strconv.Atoi(page)
-	if err != nil {
-		log.Errorf("Error setting page to int: %#v", err)
-		c.IndentedJSON(http.StatusInternalServerError, models.Error{Error: err.Error()})
-		return
-	}
-
-	limitInt, err := strconv.Atoi(limit)
-	if err != nil {
-		log.Errorf("Error setting limit to int: %#v", err)
-		c.IndentedJSON(http.StatusInternalServerError, models.Error{Error: err.Error()})
-		return
-	}
-
-	if pageInt <= 0 {
-		log.Errorf("Error page is less than 0: %#v", err)
-		c.IndentedJSON(http.StatusBadRequest, models.Error{Error: "Invalid page number"})
-		return
-	}
-
-	if limitInt <= 0 {
-		log.Errorf("Error limit is less than 0: %#v", err)
-		c.IndentedJSON(http.StatusBadRequest, models.Error{Error: "Invalid limit number"})
+	var likePattern string
+	switch searchType {
+	case "fulltext":
+	case "contains":
+		likePattern = "%" + searchString + "%"
+	case "startswith":
+		likePattern = searchString + "%"
+	case "endswith":
+		likePattern = "%" + searchString
+	default:
+		c.IndentedJSON(http.StatusBadRequest, models.Error{Error: "Invalid searchType. Use fulltext, contains, startswith, or endswith."})
 		return
 	}
 
-	conditions := []string{}
-	queryArgs := []interface{}{}
+	terms := utils.SearchTerms(searchString)
 
-	if searchStringPressent && strings.TrimSpace(searchString) != "" {
-		normalizedSearchType := strings.TrimSpace(strings.ToLower(searchType))
-		if !searchTypePressent || normalizedSearchType == "" {
-			normalizedSearchType = "contains"
-		}
-
-		var likePattern string
-		switch normalizedSearchType {
-		case "contains":
-			likePattern = "%" + searchString + "%"
-		case "startswith":
-			likePattern = searchString + "%"
-		case "endswith":
-			likePattern = "%" + searchString
-		default:
-			c.IndentedJSON(http.StatusBadRequest, models.Error{Error: "Invalid searchType. Use contains, startswith, or endswith."})
+	hasIndex := false
+	if searchType == "fulltext" && len(terms) > 0 {
+		var err error
+		hasIndex, err = utils.GearSearchIndexExists(db)
+		if err != nil {
+			log.Errorf("Unable to check for gear search index: %#v", err)
+			c.IndentedJSON(http.StatusInternalServerError, models.Error{Error: err.Error()})
 			return
 		}
-
-		conditions = append(conditions, "gear.gearName LIKE ?")
-		queryArgs = append(queryArgs, likePattern)
-	}
-
-	whereClause := ""
-	if len(conditions) > 0 {
-		whereClause = " WHERE " + strings.Join(conditions, " OR ")
 	}
 
-	baseCountQuery := "SELECT COUNT(*) FROM gear"
-	countQuery := baseCountQuery + whereClause
-
-	var totalCount int
-	err = db.QueryRow(countQuery, queryArgs...).Scan(&totalCount)
-	if err != nil {
-		log.Errorf("Error getting GearCount database: %#v", err)
-		c.IndentedJSON(http.StatusInternalServerError, models.Error{Error: err.Error()})
-		return
+	var query *utils.ListQuery[models.GearSearchItem]
+	switch {
+	case hasIndex:
+		query = utils.NewListQuery[models.GearSearchItem]("gear_search JOIN gear ON gear.gearId = gear_search.rowid"+gearJoins).
+			Select("search_rank", "gear_search.rank").
+			Select("search_snippet", "snippet(gear_search, -1, '<mark>', '</mark>', '…', 12)").
+			SortDefault("search_rank").
+			Where("gear_search MATCH ?", utils.FTSMatchQuery(searchString))
+	case searchType == "fulltext":
+		// Without the FTS5 index every word must appear in one of the indexed columns,
+		// and shorter gear names rank first.
+		query = utils.NewListQuery[models.GearSearchItem]("gear"+gearJoins).
+			Select("search_rank", "length(gear.gearName)").
+			Select("search_snippet", "gear.gearName")
+		if len(terms) > 0 {
+			query.SortDefault("search_rank")
+		}
+		for _, term := range terms {
+			pattern := "%" + term + "%"
+			query.WhereAny(gearSearchColumnMatches, pattern, pattern, pattern, pattern, pattern)
+		}
+	default:
+		query = utils.NewListQuery[models.GearSearchItem]("gear"+gearJoins).
+			Select("search_rank", "length(gear.gearName)").
+			Select("search_snippet", "gear.gearName")
+		if searchString != "" {
+			query.Where("gear.gearName LIKE ?", likePattern)
+		}
 	}
 
-	start := strconv.Itoa((pageInt - 1) * limitInt)
-	totalPages := int(math.Ceil(float64(totalCount) / float64(limitInt)))
-
-	startInt, err := strconv.Atoi(start)
-	if err != nil {
-		c.IndentedJSON(http.StatusInternalServerError, models.Error{Error: err.Error()})
+	if err := utils.FilterGear(query, c.Request.URL.Query()); err != nil {
+		respondListQueryError(c, log, err)
 		return
 	}
+	visible, visibleArgs := utils.GearVisibleCondition(c.MustGet("user_id_int64").(int64))
+	query.Where(visible, visibleArgs...)
 
-	var paramGear models.GearListItem
-	fields := utils.GetDBFieldNames(reflect.TypeOf(paramGear))
-
-	baseQuery := fmt.Sprintf(`SELECT %s FROM gear
-        LEFT JOIN manufacture ON gear.gearManufactureId = manufacture.manufactureId
-        LEFT JOIN gear_top_category ON gear.gearTopCategoryId = gear_top_category.topCategoryId
-        LEFT JOIN gear_category ON gear.gearCategoryId = gear_category.categoryId`,
-		strings.Join(fields, ", "))
-
-	queryLimit := fmt.Sprintf(" LIMIT %v, %v", startInt, limitInt)
-
-	query := baseQuery + whereClause + queryLimit
-
-	log.Debugf("Query: %s", query)
-
-	rows, err := db.Query(query, queryArgs...)
-	if err != nil {
-		log.Errorf("Query error: %#v", err.Error())
-		c.IndentedJSON(http.StatusInternalServerError, models.Error{Error: err.Error()})
-		return
-	}
-	defer rows.Close()
+	respondList(c, log, db, query)
+}
 
-	dest, err := utils.GetScanFields(paramGear)
-	if err != nil {
-		log.Errorf("Error getting destination arguments: %#v", err)
-		c.IndentedJSON(http.StatusInternalServerError, models.Error{Error: err.Error()})
-		return
-	}
+// SuggestGear suggests gear, manufacturers and categories while typing
+//
+//	@Summary		Suggest gear
+//	@Description	Get typo-tolerant autocomplete suggestions across gear, manufacturers and categories, best match first
+//	@Security		BearerAuth
+//	@Tags			Gear
+//	@Accept			json
+//	@Produce		json
+//	@Param			q		query		string	true	"Partial or misspelled name"
+//	@Param			limit	query		int		false	"Maximum number of suggestions"	default(8)	maximum(25)
+//	@Success		200		{array}		models.Suggestion
+//	@Failure		default	{object}	models.Error
+//	@Router			/api/v1/gear/suggest [get]
+func SuggestGear(c *gin.Context) {
+	c.Header("Content-Type", "application/json")
 
-	var gearList []models.GearListItem
+	log := c.MustGet("logger").(*zap.SugaredLogger)
+	db := c.MustGet("db").(*sql.DB)
+	index := c.MustGet("suggest_index").(*utils.SuggestIndex)
 
-	for rows.Next() {
-		err = rows.Scan(dest...)
-		if err != nil {
-			if err == sql.ErrNoRows {
-				log.Errorf("No gear found")
-				c.IndentedJSON(http.StatusNotFound, models.Error{Error: "No results"})
-				return
-			}
-			log.Errorf("Scan error: %#v", err)
-			c.IndentedJSON(http.StatusInternalServerError, models.Error{Error: err.Error()})
+	limit := defaultSuggestLimit
+	if limitQuery := c.Query("limit"); limitQuery != "" {
+		limitInt, err := strconv.Atoi(limitQuery)
+		if err != nil || limitInt <= 0 || limitInt > maxSuggestLimit {
+			c.IndentedJSON(http.StatusBadRequest, models.Error{Error: fmt.Sprintf("limit must be between 1 and %d", maxSuggestLimit)})
 			return
 		}
-
-		for i := 0; i < reflect.TypeOf(paramGear).NumField(); i++ {
-			reflect.ValueOf(&paramGear).Elem().Field(i).Set(reflect.ValueOf(dest[i]).Elem())
-		}
-
-		gearList = append(gearList, paramGear)
+		limit = limitInt
 	}
 
-	if err := rows.Err(); err != nil {
-		log.Errorf("Row iteration error: %#v", err)
+	suggestions, err := index.Suggest(db, c.Query("q"), limit)
+	if err != nil {
+		log.Errorf("Unable to load suggestion index: %#v", err)
 		c.IndentedJSON(http.StatusInternalServerError, models.Error{Error: err.Error()})
 		return
 	}
 
-	payload := models.ResponsePayload{
-		TotalItemCount: totalCount,
-		CurrentPage:    pageInt,
-		ItemLimit:      limitInt,
-		TotalPages:     totalPages,
-		Items:          gearList,
-	}
-
-	if pageInt < totalPages {
-		currentQueryParameters.Set("page", strconv.Itoa(pageInt+1))
-		nextPage := url.URL{
-			Path:     c.Request.URL.Path,
-			RawQuery: currentQueryParameters.Encode(),
-		}
-		payload.NextPage = new(string)
-		*payload.NextPage = nextPage.String()
-	}
-
-	if pageInt > 1 {
-		currentQueryParameters.Set("page", strconv.Itoa(pageInt-1))
-		prevPage := url.URL{
-			Path:     c.Request.URL.Path,
-			RawQuery: currentQueryParameters.Encode(),
-		}
-		payload.PrevPage = new(string)
-		*payload.PrevPage = prevPage.String()
-	}
-
-	log.Infof("successfully fetched gear with id: %s, gearName: %s", paramGear.GearID, paramGear.GearName)
-	c.IndentedJSON(http.StatusOK, payload)
+	c.IndentedJSON(http.StatusOK, suggestions)
 }
 
 // GetGear gets spessific gear based on ID
 //
 //	@Summary		Get gear with ID
-//	@Description	Get gear spessific to ID
+//	@Description	Get gear spessific to ID. The ID of gear merged into other gear gives the gear it was merged into.
 //	@Security		BearerAuth
 //	@Tags			Gear
 //	@Accept			json
 //	@Produce		json
 //	@Param			gear	path		int				true	"Unique ID of Gear you want to get"
+//	@Param			units	query		string			false	"Also give measurements in metric or imperial units, overriding the user's preference"
 //	@Success		200		{object}	models.FullGear	"desc"
 //	@Failure		default	{object}	models.Error
 //	@Router			/api/v1/gear/{gear}/get [get]
@@ -460,12 +271,24 @@ func GetGear(c *gin.Context) {
 	if err != nil {
 		log.Errorf("urlParamter is of wrong type: %#v", err)
 		c.IndentedJSON(http.StatusBadRequest, models.Error{Error: err.Error()})
+		return
+	}
+
+	gearID, ok := resolveGearRedirect(c, log, db, int64(urlParameter))
+	if !ok {
+		return
+	}
+	urlParameter = int(gearID)
+
+	if respondGearNotVisible(c, log, db, gearID) {
+		return
 	}
 
 	var extraSQL []string
 	extraSQL = append(extraSQL, " LEFT JOIN manufacture ON gear.gearManufactureId = manufacture.manufactureId ")
 	extraSQL = append(extraSQL, " LEFT JOIN gear_top_category ON gear.gearTopCategoryId = gear_top_category.topCategoryId ")
 	extraSQL = append(extraSQL, "  LEFT JOIN gear_category ON gear.gearCategoryId = gear_category.categoryId ")
+	extraSQL = append(extraSQL, utils.GearImageJoin)
 
 	results, err := utils.GenericGet[models.FullGear]("gear", urlParameter, extraSQL, db)
 	if err != nil {
@@ -474,6 +297,25 @@ func GetGear(c *gin.Context) {
 		return
 	}
 
+	results.Specs, err = utils.GearSpecs(db, results.GearID)
+	if err != nil {
+		log.Errorf("Unable to get specs of %s with id: %s. Error: %#v", function, urlParameter, err)
+		c.IndentedJSON(http.StatusInternalServerError, models.Error{Error: err.Error()})
+		return
+	}
+
+	results.Tags, err = utils.Tags(db, utils.GearTagTarget, results.GearID)
+	if err != nil {
+		log.Errorf("Unable to get tags of %s with id: %s. Error: %#v", function, urlParameter, err)
+		c.IndentedJSON(http.StatusInternalServerError, models.Error{Error: err.Error()})
+		return
+	}
+
+	if !measureItems(c, log, db, results) || !localizeItems(c, log, db, results) {
+		return
+	}
+	resolveImageURLs(c, results)
+
 	log.Infof("Successfully fetched %s with ID %s", function, urlParameter)
 	c.IndentedJSON(http.StatusOK, results)
 }
@@ -481,7 +323,7 @@ func GetGear(c *gin.Context) {
 // InsertGear insert new gear according to spessification
 //
 //	@Summary		Insert new gear
-//	@Description	Insert new gear with corresponding values
+//	@Description	Insert new gear with corresponding values. Weights are in grams and dimensions in millimetres, or given as {"value": 2.1, "unit": "kg"}. Gear inserted by non-admins is pending review and only visible to them until an admin approves it.
 //	@Security		BearerAuth
 //	@Tags			Gear
 //	@Accept			json
@@ -503,6 +345,32 @@ func InsertGear(c *gin.Context) {
 		return
 	}
 
+	data, ok := normalizeMeasurements[models.Gear](c, log, data)
+	if !ok {
+		return
+	}
+
+	schema, specs, ok := gearSpecsFromBody(c, log, db, data, nil)
+	if !ok {
+		return
+	}
+
+	// Admins add gear straight to the catalog unless they say otherwise; everybody
+	// else submits it for review.
+	isAdmin, _ := c.Get("user_is_admin")
+	adminFlag, _ := isAdmin.(bool)
+	var body map[string]json.RawMessage
+	if err := json.Unmarshal(data, &body); err != nil {
+		c.JSON(http.StatusBadRequest, models.Error{Error: err.Error()})
+		return
+	}
+	if _, hasStatus := body["gear_status"]; !adminFlag || !hasStatus {
+		if data, err = withGearStatus(data, adminFlag); err != nil {
+			c.JSON(http.StatusBadRequest, models.Error{Error: err.Error()})
+			return
+		}
+	}
+
 	createdObject, err := utils.GenericInsert[models.Gear]("gear", data, db)
 	if err != nil {
 		c.JSON(http.StatusInternalServerError, models.Error{Error: err.Error()})
@@ -510,13 +378,29 @@ func InsertGear(c *gin.Context) {
 		return
 	}
 
+	if !adminFlag {
+		if err := utils.SubmitGear(db, *createdObject.GearID, c.MustGet("user_id_int64").(int64)); err != nil {
+			c.JSON(http.StatusInternalServerError, models.Error{Error: err.Error()})
+			log.Error(err.Error())
+			return
+		}
+		log.Infof("Gear %d submitted for review", *createdObject.GearID)
+	}
+
+	if err := utils.SaveGearSpecs(db, *createdObject.GearID, schema, specs); err != nil {
+		c.JSON(http.StatusInternalServerError, models.Error{Error: err.Error()})
+		log.Error(err.Error())
+		return
+	}
+	createdObject.Specs = specs
+
 	c.JSON(http.StatusOK, createdObject)
 }
 
 // UpdateGear updates existing gear
 //
 //	@Summary		Update gear with ID
-//	@Description	Update gear identified by ID
+//	@Description	Update gear identified by ID. Weights are in grams and dimensions in millimetres, or given as {"value": 2.1, "unit": "kg"}. Non-admins can only edit their own submissions before approval, which sends them back for review. gear_status is kept unless an admin sets it.
 //	@Security		BearerAuth
 //	@Tags			Gear
 //	@Accept			json
@@ -539,6 +423,64 @@ func UpdateGear(c *gin.Context) {
 		return
 	}
 
+	data, ok := normalizeMeasurements[models.Gear](c, log, data)
+	if !ok {
+		return
+	}
+
+	var target struct {
+		GearID *int64 `json:"gear_id"`
+	}
+	if err := json.Unmarshal(data, &target); err != nil || target.GearID == nil {
+		c.JSON(http.StatusBadRequest, models.Error{Error: "gear_id is required"})
+		return
+	}
+
+	submission, err := utils.GearSubmissionByID(db, *target.GearID)
+	if err == sql.ErrNoRows {
+		c.JSON(http.StatusNotFound, models.Error{Error: "Gear not found"})
+		return
+	}
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, models.Error{Error: err.Error()})
+		log.Error(err.Error())
+		return
+	}
+
+	// Submitters may edit their own gear until it is approved, which sends it back for
+	// review. Only admins edit catalog gear or change gear_status.
+	isAdmin, _ := c.Get("user_is_admin")
+	adminFlag, _ := isAdmin.(bool)
+	userID := c.MustGet("user_id_int64").(int64)
+	if !adminFlag && (submission.GearSubmittedBy == nil || *submission.GearSubmittedBy != userID || submission.GearReviewStatus == utils.GearReviewApproved) {
+		log.Warnw("gear update attempted without admin privileges", "gear_id", *target.GearID)
+		c.JSON(http.StatusForbidden, models.Error{Error: "admin privileges required"})
+		return
+	}
+	var body map[string]json.RawMessage
+	if err := json.Unmarshal(data, &body); err != nil {
+		c.JSON(http.StatusBadRequest, models.Error{Error: err.Error()})
+		return
+	}
+	if _, hasStatus := body["gear_status"]; !adminFlag || !hasStatus {
+		if data, err = withGearStatus(data, submission.GearStatus); err != nil {
+			c.JSON(http.StatusBadRequest, models.Error{Error: err.Error()})
+			return
+		}
+	}
+
+	current, err := utils.GearSpecs(db, *target.GearID)
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, models.Error{Error: err.Error()})
+		log.Error(err.Error())
+		return
+	}
+
+	schema, specs, ok := gearSpecsFromBody(c, log, db, data, current)
+	if !ok {
+		return
+	}
+
 	err = utils.GenericUpdate[models.Gear]("gear", data, db)
 	if err != nil {
 		c.JSON(http.StatusInternalServerError, models.Error{Error: err.Error()})
@@ -546,6 +488,20 @@ func UpdateGear(c *gin.Context) {
 		return
 	}
 
+	if err := utils.SaveGearSpecs(db, *target.GearID, schema, specs); err != nil {
+		c.JSON(http.StatusInternalServerError, models.Error{Error: err.Error()})
+		log.Error(err.Error())
+		return
+	}
+
+	if !adminFlag {
+		if err := utils.SubmitGear(db, *target.GearID, userID); err != nil {
+			c.JSON(http.StatusInternalServerError, models.Error{Error: err.Error()})
+			log.Error(err.Error())
+			return
+		}
+	}
+
 	c.JSON(http.StatusOK, map[string]string{"status": "success"})
 }
 
@@ -575,12 +531,20 @@ func DeleteGear(c *gin.Context) {
 		return
 	}
 
+	imageKeys, err := imageFileKeys(db, "gearId = ?", urlParameter)
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, models.Error{Error: err.Error()})
+		log.Error(err.Error())
+		return
+	}
+
 	result, err := utils.GenericDelete[models.Gear]("gear", urlParameter, db)
 	if err != nil {
 		c.JSON(http.StatusInternalServerError, models.Error{Error: err.Error()})
 		log.Error(err.Error())
 		return
 	}
+	removeImageFiles(c, log, imageKeys...)
 
 	log.Infof("success! Gear with gear_id %v and gear_name %s was deleted", result.GearID, result.GearName)
 	c.JSON(http.StatusOK, map[string]string{
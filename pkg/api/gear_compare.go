@@ -0,0 +1,93 @@
+package endpoints
+
+import (
+	"database/sql"
+	"errors"
+	"fmt"
+	"net/http"
+	"strconv"
+	"strings"
+
+	models "github.com/Sea-Shell/gogear-api/pkg/models"
+	utils "github.com/Sea-Shell/gogear-api/pkg/utils"
+
+	gin "github.com/gin-gonic/gin"
+	zap "go.uber.org/zap"
+)
+
+const maxCompareGear = 10
+
+// CompareGear compares gear side by side
+//
+//	@Summary		Compare gear side by side
+//	@Description	Get the requested gear aligned field by field in the requested order: manufacturer, category, weight, dimensions, rating, ownership count and the spec attributes of their categories. Numeric values carry the delta against the lightest gear, and fields with a preferred direction mark the best values. Merged gear IDs resolve to the gear they were merged into.
+//	@Security		BearerAuth
+//	@Tags			Gear
+//	@Accept			json
+//	@Produce		json
+//	@Param			ids		query		string	true	"Comma separated gear IDs, between 2 and 10"
+//	@Success		200		{object}	models.GearComparison
+//	@Failure		default	{object}	models.Error
+//	@Router			/api/v1/gear/compare [get]
+func CompareGear(c *gin.Context) {
+	c.Header("Content-Type", "application/json")
+
+	log := c.MustGet("logger").(*zap.SugaredLogger)
+	db := c.MustGet("db").(*sql.DB)
+
+	var ids []int64
+	seen := make(map[int64]bool)
+	for _, param := range c.QueryArray("ids") {
+		for _, part := range strings.Split(param, ",") {
+			if part = strings.TrimSpace(part); part == "" {
+				continue
+			}
+			gearID, err := strconv.ParseInt(part, 10, 64)
+			if err != nil {
+				c.JSON(http.StatusBadRequest, models.Error{Error: fmt.Sprintf("invalid gear ID %q", part)})
+				return
+			}
+
+			gearID, ok := resolveGearRedirect(c, log, db, gearID)
+			if !ok {
+				return
+			}
+			if !seen[gearID] {
+				seen[gearID] = true
+				ids = append(ids, gearID)
+			}
+		}
+	}
+	if len(ids) < 2 || len(ids) > maxCompareGear {
+		c.JSON(http.StatusBadRequest, models.Error{Error: fmt.Sprintf("ids must list between 2 and %d different gear", maxCompareGear)})
+		return
+	}
+
+	for _, gearID := range ids {
+		if respondGearNotVisible(c, log, db, gearID) {
+			return
+		}
+	}
+
+	locale, ok := translationLocale(c, log, db)
+	if !ok {
+		return
+	}
+
+	comparison, err := utils.CompareGear(db, ids, locale)
+	if errors.Is(err, sql.ErrNoRows) {
+		c.JSON(http.StatusNotFound, models.Error{Error: "Gear not found"})
+		return
+	}
+	if err != nil {
+		log.Errorf("error comparing gear: %#v", err)
+		c.JSON(http.StatusInternalServerError, models.Error{Error: err.Error()})
+		return
+	}
+
+	for i := range comparison.Gear {
+		resolveImageURLs(c, &comparison.Gear[i])
+	}
+
+	c.JSON(http.StatusOK, comparison)
+}
@@ -0,0 +1,78 @@
+package endpoints
+
+import (
+	"encoding/json"
+	"net/http"
+	"testing"
+
+	models "github.com/Sea-Shell/gogear-api/pkg/models"
+)
+
+func TestCompareGear(t *testing.T) {
+	db, router := setupCatalogTest(t, 1)
+	seedCatalog(t, db)
+	seedUser(t, db, 2)
+	seedCatalogGear(t, db, 1, 1, "Nallo", 2400)
+	seedCatalogGear(t, db, 2, 1, "Akto", 1700)
+	seedCatalogGear(t, db, 3, 2, "Tarp 10", 700)
+	for _, statement := range []string{
+		`INSERT INTO category_spec_attributes (attributeId, categoryId, attributeName, attributeLabel, attributeType, attributeUnit)
+            VALUES (1, 1, 'hydrostatic_head', 'Hydrostatic head', 'number', 'mm'), (2, 2, 'rvalue', 'R-value', 'number', NULL)`,
+		`INSERT INTO gear_spec_values (gearId, attributeId, valueNumber) VALUES (1, 1, 5000), (2, 1, 3000)`,
+		`INSERT INTO user_gear_registrations (userGearRegistrationId, gearId, userId) VALUES (1, 1, 1), (2, 1, 2), (3, 2, 2)`,
+		`INSERT INTO gear_redirects (fromGearId, toGearId, mergedBy) VALUES (9, 3, 1)`,
+	} {
+		if _, err := db.Exec(statement); err != nil {
+			t.Fatal(err)
+		}
+	}
+
+	router.GET("/api/v1/gear/compare", testAuthMiddleware(1), CompareGear)
+
+	w := authRequest(t, router, http.MethodGet, "/api/v1/gear/compare?ids=1,2&ids=9", "")
+	var comparison models.GearComparison
+	if err := json.Unmarshal(w.Body.Bytes(), &comparison); err != nil || w.Code != http.StatusOK {
+		t.Fatalf("CompareGear: %d %s", w.Code, w.Body.String())
+	}
+	if len(comparison.Gear) != 3 || comparison.Gear[0].GearID != 1 || comparison.Gear[2].GearID != 3 {
+		t.Fatalf("CompareGear: expected the gear in request order with the redirect resolved, got %+v", comparison.Gear)
+	}
+	if comparison.LightestGearID == nil || *comparison.LightestGearID != 3 {
+		t.Errorf("CompareGear: expected gear 3 as the lightest, got %v", comparison.LightestGearID)
+	}
+
+	fields := make(map[string]models.GearComparisonField)
+	for _, field := range comparison.Fields {
+		fields[field.Key] = field
+	}
+	weight := fields["gear_weight"]
+	if !weight.Values[2].Best || weight.Values[0].Best || weight.Values[0].Delta == nil || *weight.Values[0].Delta != 1700 {
+		t.Errorf("CompareGear: unexpected weight row %+v", weight)
+	}
+	owners := fields["owner_count"]
+	if owners.Values[0].Value != float64(2) || !owners.Values[0].Best || owners.Values[2].Best {
+		t.Errorf("CompareGear: unexpected owner row %+v", owners)
+	}
+	head, ok := fields["spec.hydrostatic_head"]
+	if !ok || head.Values[0].Value != float64(5000) || head.Values[2].Value != nil || head.Values[0].Delta != nil {
+		t.Errorf("CompareGear: unexpected spec row %+v", head)
+	}
+	if _, ok := fields["spec.rvalue"]; !ok {
+		t.Errorf("CompareGear: expected the spec attributes of every compared category")
+	}
+	if category := fields["category_name"]; category.Values[2].Value != "Tarps" {
+		t.Errorf("CompareGear: unexpected category row %+v", category)
+	}
+
+	for query, want := range map[string]int{
+		"?ids=1":       http.StatusBadRequest,
+		"?ids=1,1":     http.StatusBadRequest,
+		"?ids=1,x":     http.StatusBadRequest,
+		"?ids=1,99":    http.StatusNotFound,
+		"?ids=1,2,3,4": http.StatusNotFound,
+	} {
+		if w := authRequest(t, router, http.MethodGet, "/api/v1/gear/compare"+query, ""); w.Code != want {
+			t.Errorf("CompareGear %s: expected %d, got %d", query, want, w.Code)
+		}
+	}
+}
@@ -0,0 +1,156 @@
+package endpoints
+
+import (
+	"database/sql"
+	"errors"
+	"fmt"
+	"net/http"
+	"strconv"
+
+	models "github.com/Sea-Shell/gogear-api/pkg/models"
+	utils "github.com/Sea-Shell/gogear-api/pkg/utils"
+
+	gin "github.com/gin-gonic/gin"
+	zap "go.uber.org/zap"
+)
+
+const (
+	defaultDuplicateLimit    = 50
+	maxDuplicateLimit        = 500
+	defaultDuplicateMinScore = 0.75
+)
+
+// ListGearDuplicates suggests gear that is likely duplicated in the catalog
+//
+//	@Summary		List likely duplicate gear
+//	@Description	Get pairs of gear that likely describe the same product, best match first. The score combines the similarity of the names with the manufacturer name and capacity spelling normalized away, a shared manufacturer and similar weight. The gear with more registrations is given first as the suggested merge winner. Requires a JWT issued with the admin audience.
+//	@Security		BearerAuth
+//	@Tags			Gear moderation
+//	@Accept			json
+//	@Produce		json
+//	@Param			min_score	query		number	false	"Lowest score to list, between 0 and 1"	default(0.75)
+//	@Param			limit		query		int		false	"Maximum number of pairs"				default(50)	maximum(500)
+//	@Success		200			{array}		models.GearDuplicate
+//	@Failure		default		{object}	models.Error
+//	@Router			/api/v1/gear/duplicate/list [get]
+func ListGearDuplicates(c *gin.Context) {
+	c.Header("Content-Type", "application/json")
+
+	log := c.MustGet("logger").(*zap.SugaredLogger)
+	db := c.MustGet("db").(*sql.DB)
+
+	isAdmin, _ := c.Get("user_is_admin")
+	if adminFlag, ok := isAdmin.(bool); !ok || !adminFlag {
+		log.Warn("unauthorized gear duplicate list access without admin privileges")
+		c.AbortWithStatusJSON(http.StatusForbidden, models.Error{Error: "admin privileges required"})
+		return
+	}
+
+	limit := defaultDuplicateLimit
+	if limitQuery := c.Query("limit"); limitQuery != "" {
+		limitInt, err := strconv.Atoi(limitQuery)
+		if err != nil || limitInt <= 0 || limitInt > maxDuplicateLimit {
+			c.JSON(http.StatusBadRequest, models.Error{Error: fmt.Sprintf("limit must be between 1 and %d", maxDuplicateLimit)})
+			return
+		}
+		limit = limitInt
+	}
+
+	minScore := defaultDuplicateMinScore
+	if scoreQuery := c.Query("min_score"); scoreQuery != "" {
+		score, err := strconv.ParseFloat(scoreQuery, 64)
+		if err != nil || score < 0 || score > 1 {
+			c.JSON(http.StatusBadRequest, models.Error{Error: "min_score must be between 0 and 1"})
+			return
+		}
+		minScore = score
+	}
+
+	duplicates, err := utils.GearDuplicates(db, minScore, limit)
+	if err != nil {
+		log.Errorf("error finding duplicate gear: %#v", err)
+		c.JSON(http.StatusInternalServerError, models.Error{Error: err.Error()})
+		return
+	}
+
+	c.JSON(http.StatusOK, duplicates)
+}
+
+// MergeGear merges duplicate gear into another
+//
+//	@Summary		Merge duplicate gear
+//	@Description	Fold the gear in the path into winner_id in one transaction. Registrations, loadout items, variants, images, tags, reviews and spec values move to the winner, the weights of affected loadouts are recalculated and the merged ID keeps resolving to the winner. Requires a JWT issued with the admin audience.
+//	@Security		BearerAuth
+//	@Tags			Gear moderation
+//	@Accept			json
+//	@Produce		json
+//	@Param			gear	path		int					true	"Unique ID of the duplicate gear to merge away"
+//	@Param			request	body		models.GearMerge	true	"Gear to keep"
+//	@Success		200		{object}	models.GearMergeResult
+//	@Failure		default	{object}	models.Error
+//	@Router			/api/v1/gear/{gear}/merge [post]
+func MergeGear(c *gin.Context) {
+	c.Header("Content-Type", "application/json")
+
+	log := c.MustGet("logger").(*zap.SugaredLogger)
+	db := c.MustGet("db").(*sql.DB)
+
+	isAdmin, _ := c.Get("user_is_admin")
+	if adminFlag, ok := isAdmin.(bool); !ok || !adminFlag {
+		log.Warn("unauthorized gear merge attempt without admin privileges")
+		c.AbortWithStatusJSON(http.StatusForbidden, models.Error{Error: "admin privileges required"})
+		return
+	}
+
+	gearID, err := strconv.ParseInt(c.Param("gear"), 10, 64)
+	if err != nil {
+		c.JSON(http.StatusBadRequest, models.Error{Error: "invalid gear ID"})
+		return
+	}
+
+	var merge models.GearMerge
+	if err := c.ShouldBindJSON(&merge); err != nil {
+		c.JSON(http.StatusBadRequest, models.Error{Error: err.Error()})
+		return
+	}
+
+	result, err := utils.MergeGear(db, gearID, merge.WinnerID, c.MustGet("user_id_int64").(int64))
+	var mergeErr *utils.GearMergeError
+	switch {
+	case errors.As(err, &mergeErr):
+		c.JSON(http.StatusBadRequest, models.Error{Error: mergeErr.Message})
+		return
+	case errors.Is(err, sql.ErrNoRows):
+		c.JSON(http.StatusNotFound, models.Error{Error: "Gear not found"})
+		return
+	case err != nil:
+		log.Errorf("error merging gear: %#v", err)
+		c.JSON(http.StatusInternalServerError, models.Error{Error: err.Error()})
+		return
+	}
+
+	for _, loadoutID := range result.LoadoutIDs {
+		if err := LoadoutRecalculateWeight(db, loadoutID); err != nil {
+			log.Errorf("error recalculating loadout weight after merge: %#v", err)
+			c.JSON(http.StatusInternalServerError, models.Error{Error: err.Error()})
+			return
+		}
+	}
+
+	log.Infof("Merged gear %d into %d", gearID, merge.WinnerID)
+	c.JSON(http.StatusOK, result)
+}
+
+// resolveGearRedirect returns the gear a merged gear ID was folded into, or gearID itself.
+func resolveGearRedirect(c *gin.Context, log *zap.SugaredLogger, db *sql.DB, gearID int64) (int64, bool) {
+	target, redirected, err := utils.ResolveGearRedirect(db, gearID)
+	if err != nil {
+		log.Errorf("error resolving gear redirect: %#v", err)
+		c.JSON(http.StatusInternalServerError, models.Error{Error: err.Error()})
+		return 0, false
+	}
+	if redirected {
+		log.Infof("Gear %d was merged into %d", gearID, target)
+	}
+	return target, true
+}
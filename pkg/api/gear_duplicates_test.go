@@ -0,0 +1,116 @@
+package endpoints
+
+import (
+	"encoding/json"
+	"net/http"
+	"testing"
+
+	models "github.com/Sea-Shell/gogear-api/pkg/models"
+
+	"github.com/gin-gonic/gin"
+)
+
+func TestGearDuplicatesAndMerge(t *testing.T) {
+	db, router := setupCatalogTest(t, 1)
+	seedCatalog(t, db)
+	seedUser(t, db, 2)
+	seedCatalogGear(t, db, 1, 1, "Nallo", 2400)
+	for _, statement := range []string{
+		`INSERT INTO manufacture (manufactureId, manufactureName) VALUES (2, 'Nemo')`,
+		`INSERT INTO gear (gearId, gearTopCategoryId, gearCategoryId, gearManufactureId, gearName, gearWeight, gearHeight, gearLength, gearWidth, gearStatus)
+            VALUES (10, 1, 1, 2, 'Nemo Hornet 2P', 907, 0, 0, 0, 1), (11, 1, 1, 2, 'NEMO Hornet 2 person', 910, 0, 0, 0, 1)`,
+		`INSERT INTO gear_variants (variantId, gearId, variantName, variantWeight) VALUES (1, 10, 'Elite', 800), (2, 11, 'Elite', 790), (3, 11, 'Osmo', 1000)`,
+		`INSERT INTO user_gear_registrations (userGearRegistrationId, gearId, userId) VALUES (1, 10, 2), (2, 10, 1), (3, 11, 2)`,
+		`INSERT INTO gear_tags (gearId, tagName) VALUES (10, 'ultralight'), (11, 'ultralight'), (11, 'freestanding')`,
+	} {
+		if _, err := db.Exec(statement); err != nil {
+			t.Fatal(err)
+		}
+	}
+	loadoutID := seedLoadout(t, db, 2, false, "hornet")
+	if _, err := db.Exec(`INSERT INTO loadout_items (loadoutId, gearId, quantity, notes, variantId) VALUES (?, 11, 1, '', 2), (?, 11, 1, '', 3)`, loadoutID, loadoutID); err != nil {
+		t.Fatal(err)
+	}
+
+	isAdmin := false
+	v1 := router.Group("/api/v1", testAuthMiddleware(1), func(c *gin.Context) {
+		c.Set("user_is_admin", isAdmin)
+		c.Next()
+	})
+	v1.GET("/gear/duplicate/list", ListGearDuplicates)
+	v1.POST("/gear/:gear/merge", MergeGear)
+	v1.GET("/gear/:gear/get", GetGear)
+	v1.GET("/gear/:gear/variant/list", ListGearVariants)
+
+	if w := authRequest(t, router, http.MethodGet, "/api/v1/gear/duplicate/list", ""); w.Code != http.StatusForbidden {
+		t.Errorf("ListGearDuplicates: expected 403 for non-admin, got %d", w.Code)
+	}
+	if w := authRequest(t, router, http.MethodPost, "/api/v1/gear/11/merge", `{"winner_id":10}`); w.Code != http.StatusForbidden {
+		t.Errorf("MergeGear: expected 403 for non-admin, got %d", w.Code)
+	}
+	isAdmin = true
+
+	w := authRequest(t, router, http.MethodGet, "/api/v1/gear/duplicate/list", "")
+	var duplicates []models.GearDuplicate
+	if err := json.Unmarshal(w.Body.Bytes(), &duplicates); err != nil || w.Code != http.StatusOK {
+		t.Fatalf("ListGearDuplicates: %d %s", w.Code, w.Body.String())
+	}
+	if len(duplicates) != 1 || duplicates[0].Gear.GearID != 10 || duplicates[0].Duplicate.GearID != 11 || duplicates[0].Score < 0.95 {
+		t.Fatalf("ListGearDuplicates: expected the Hornets with the most registered first, got %+v", duplicates)
+	}
+	if w := authRequest(t, router, http.MethodGet, "/api/v1/gear/duplicate/list?min_score=2", ""); w.Code != http.StatusBadRequest {
+		t.Errorf("ListGearDuplicates: expected 400 for min_score out of range, got %d", w.Code)
+	}
+
+	if w := authRequest(t, router, http.MethodPost, "/api/v1/gear/10/merge", `{"winner_id":10}`); w.Code != http.StatusBadRequest {
+		t.Errorf("MergeGear into itself: expected 400, got %d", w.Code)
+	}
+	if w := authRequest(t, router, http.MethodPost, "/api/v1/gear/11/merge", `{"winner_id":99}`); w.Code != http.StatusNotFound {
+		t.Errorf("MergeGear into missing gear: expected 404, got %d", w.Code)
+	}
+
+	w = authRequest(t, router, http.MethodPost, "/api/v1/gear/11/merge", `{"winner_id":10}`)
+	var result models.GearMergeResult
+	if err := json.Unmarshal(w.Body.Bytes(), &result); err != nil || w.Code != http.StatusOK {
+		t.Fatalf("MergeGear: %d %s", w.Code, w.Body.String())
+	}
+	if result.RegistrationsMoved != 1 || result.LoadoutItemsMoved != 2 || len(result.LoadoutIDs) != 1 || result.LoadoutIDs[0] != loadoutID {
+		t.Errorf("MergeGear: unexpected result %+v", result)
+	}
+
+	var remaining, tags int
+	if err := db.QueryRow(`SELECT COUNT(*) FROM user_gear_registrations WHERE gearId = 10`).Scan(&remaining); err != nil || remaining != 3 {
+		t.Errorf("MergeGear: expected all registrations on the winner, got %d (%v)", remaining, err)
+	}
+	if err := db.QueryRow(`SELECT COUNT(*) FROM gear_tags WHERE gearId = 10`).Scan(&tags); err != nil || tags != 2 {
+		t.Errorf("MergeGear: expected the tags combined, got %d (%v)", tags, err)
+	}
+	var eliteItems int
+	if err := db.QueryRow(`SELECT COUNT(*) FROM loadout_items WHERE gearId = 10 AND variantId = 1`).Scan(&eliteItems); err != nil || eliteItems != 1 {
+		t.Errorf("MergeGear: same-named variant should be folded into the winner's, got %d (%v)", eliteItems, err)
+	}
+	var totalWeight int64
+	if err := db.QueryRow(`SELECT totalWeight FROM loadouts WHERE loadoutId = ?`, loadoutID).Scan(&totalWeight); err != nil || totalWeight != 1800 {
+		t.Errorf("MergeGear: expected loadout weight 1800, got %d (%v)", totalWeight, err)
+	}
+
+	w = authRequest(t, router, http.MethodGet, "/api/v1/gear/11/variant/list", "")
+	var variants []models.GearVariant
+	if err := json.Unmarshal(w.Body.Bytes(), &variants); err != nil || w.Code != http.StatusOK || len(variants) != 2 {
+		t.Errorf("ListGearVariants: merged ID should list the winner's variants, got %d %s", w.Code, w.Body.String())
+	}
+
+	// Merging the winner on leaves the first redirect pointing at the final gear.
+	seedCatalogGear(t, db, 12, 1, "Hornet Elite", 850)
+	if w := authRequest(t, router, http.MethodPost, "/api/v1/gear/10/merge", `{"winner_id":12}`); w.Code != http.StatusOK {
+		t.Fatalf("MergeGear again: %d %s", w.Code, w.Body.String())
+	}
+	w = authRequest(t, router, http.MethodGet, "/api/v1/gear/11/get", "")
+	var resolved models.FullGear
+	if err := json.Unmarshal(w.Body.Bytes(), &resolved); err != nil || w.Code != http.StatusOK {
+		t.Fatalf("GetGear merged ID: %d %s", w.Code, w.Body.String())
+	}
+	if resolved.GearID != 12 {
+		t.Errorf("GetGear merged ID: expected gear 12, got %d", resolved.GearID)
+	}
+}
@@ -0,0 +1,82 @@
+package endpoints
+
+import (
+	"database/sql"
+	"errors"
+	"net/http"
+	"strings"
+
+	models "github.com/Sea-Shell/gogear-api/pkg/models"
+	utils "github.com/Sea-Shell/gogear-api/pkg/utils"
+
+	gin "github.com/gin-gonic/gin"
+	zap "go.uber.org/zap"
+)
+
+// ExportGear streams the gear catalog as CSV, JSON lines or YAML
+//
+//	@Summary		Export gear catalog
+//	@Description	Stream every gear item, with manufacturer and category names, in gear ID order. Takes the same filters as the gear list. The output can be sent back to the bulk import with the same format. Requires a JWT issued with the admin audience.
+//	@Security		BearerAuth
+//	@Tags			Gear
+//	@Produce		text/csv,application/x-ndjson,application/yaml
+//	@Param			format			query		string	false	"csv, jsonl or yaml"	default(jsonl)
+//	@Param			category		query		int		false	"Category ID"
+//	@Param			topCategory		query		int		false	"Top category ID"
+//	@Param			manufacturer	query		int		false	"Manufacturer ID"
+//	@Param			container		query		bool	false	"Only containers, or only non-containers"
+//	@Success		200				{array}		models.FullGear
+//	@Failure		default			{object}	models.Error
+//	@Router			/api/v1/gear/export [get]
+func ExportGear(c *gin.Context) {
+	log := c.MustGet("logger").(*zap.SugaredLogger)
+	db := c.MustGet("db").(*sql.DB)
+
+	isAdmin, _ := c.Get("user_is_admin")
+	if adminFlag, ok := isAdmin.(bool); !ok || !adminFlag {
+		log.Warn("unauthorized gear export attempt without admin privileges")
+		c.AbortWithStatusJSON(http.StatusForbidden, models.Error{Error: "admin privileges required"})
+		return
+	}
+
+	format := strings.ToLower(strings.TrimSpace(c.Query("format")))
+	if format == "" {
+		format = utils.ExportFormatJSONL
+	}
+
+	contentType, ok := utils.ExportContentTypes[format]
+	if !ok {
+		c.IndentedJSON(http.StatusBadRequest, models.Error{Error: "format must be csv, jsonl or yaml"})
+		return
+	}
+
+	c.Header("Content-Type", contentType)
+	c.Header("Content-Disposition", `attachment; filename="gear.`+format+`"`)
+
+	err := utils.ExportGear(db, c.Request.URL.Query(), format, c.Writer)
+	if err == nil {
+		log.Infof("Exported gear catalog as %s", format)
+		return
+	}
+
+	if c.Writer.Written() {
+		// The status is already sent; all that is left is to cut the stream short.
+		log.Errorf("Gear export failed mid-stream: %#v", err)
+		return
+	}
+
+	c.Header("Content-Type", "application/json")
+	c.Header("Content-Disposition", "")
+
+	var listErr *utils.ListQueryError
+	var inputErr *utils.ImportInputError
+	switch {
+	case errors.As(err, &listErr):
+		respondListQueryError(c, log, err)
+	case errors.As(err, &inputErr):
+		c.IndentedJSON(http.StatusBadRequest, models.Error{Error: inputErr.Message})
+	default:
+		log.Errorf("Gear export failed: %#v", err)
+		c.IndentedJSON(http.StatusInternalServerError, models.Error{Error: err.Error()})
+	}
+}
@@ -0,0 +1,100 @@
+package endpoints
+
+import (
+	"database/sql"
+	"errors"
+	"net/http"
+	"strconv"
+	"strings"
+
+	models "github.com/Sea-Shell/gogear-api/pkg/models"
+	utils "github.com/Sea-Shell/gogear-api/pkg/utils"
+
+	gin "github.com/gin-gonic/gin"
+	zap "go.uber.org/zap"
+)
+
+// ImportGear bulk imports gear from CSV, JSON, JSON lines or YAML
+//
+//	@Summary		Bulk import gear
+//	@Description	Import gear rows that reference categories and manufacturers by name, in one transaction. Send a JSON array of rows, JSON lines, a YAML sequence, or CSV with a header row of the same field names. The output of the gear export is accepted as is. Without gear_status, new gear goes into the catalog and existing gear keeps its status. Nothing is committed on a dry run or when any row fails; the per-row report says what was, or would be, created, updated or skipped. Requires a JWT issued with the admin audience.
+//	@Security		BearerAuth
+//	@Tags			Gear
+//	@Accept			json,text/csv,application/x-ndjson,application/yaml
+//	@Produce		json
+//	@Param			format					query		string					false	"csv, json, jsonl or yaml. Defaults from the Content-Type, json otherwise"
+//	@Param			dry_run					query		bool					false	"Validate and report without committing"							default(false)
+//	@Param			create_manufacturers	query		bool					false	"Create manufacturers that do not exist yet"						default(false)
+//	@Param			on_duplicate			query		string					false	"What to do with gear that already exists: skip, update or error"	default(skip)
+//	@Param			request					body		[]models.GearImportRow	true	"Rows to import"
+//	@Success		200						{object}	models.GearImportReport
+//	@Failure		422						{object}	models.GearImportReport	"Some rows failed and nothing was committed"
+//	@Failure		default					{object}	models.Error
+//	@Router			/api/v1/gear/import [post]
+func ImportGear(c *gin.Context) {
+	c.Header("Content-Type", "application/json")
+
+	log := c.MustGet("logger").(*zap.SugaredLogger)
+	db := c.MustGet("db").(*sql.DB)
+
+	isAdmin, _ := c.Get("user_is_admin")
+	if adminFlag, ok := isAdmin.(bool); !ok || !adminFlag {
+		log.Warn("unauthorized gear import attempt without admin privileges")
+		c.AbortWithStatusJSON(http.StatusForbidden, models.Error{Error: "admin privileges required"})
+		return
+	}
+
+	format := strings.ToLower(strings.TrimSpace(c.Query("format")))
+	if format == "" {
+		switch c.ContentType() {
+		case "text/csv":
+			format = utils.ImportFormatCSV
+		case "application/x-ndjson":
+			format = utils.ImportFormatJSONL
+		case "application/yaml":
+			format = utils.ImportFormatYAML
+		default:
+			format = utils.ImportFormatJSON
+		}
+	}
+
+	options := models.GearImportOptions{OnDuplicate: strings.ToLower(strings.TrimSpace(c.Query("on_duplicate")))}
+	for name, target := range map[string]*bool{
+		"dry_run":              &options.DryRun,
+		"create_manufacturers": &options.CreateManufacturers,
+	} {
+		value := c.Query(name)
+		if value == "" {
+			continue
+		}
+		parsed, err := strconv.ParseBool(value)
+		if err != nil {
+			c.IndentedJSON(http.StatusBadRequest, models.Error{Error: name + " must be true or false"})
+			return
+		}
+		*target = parsed
+	}
+
+	report, err := utils.ImportGear(db, c.Request.Body, format, options)
+	if err != nil {
+		var inputErr *utils.ImportInputError
+		if errors.As(err, &inputErr) {
+			log.Warnf("Invalid gear import: %s", inputErr.Message)
+			c.IndentedJSON(http.StatusBadRequest, models.Error{Error: inputErr.Message})
+			return
+		}
+		log.Errorf("Gear import failed: %#v", err)
+		c.IndentedJSON(http.StatusInternalServerError, models.Error{Error: err.Error()})
+		return
+	}
+
+	log.Infof("Gear import: %d created, %d updated, %d skipped, %d errors, dry run %t, committed %t",
+		report.Created, report.Updated, report.Skipped, report.Errors, report.DryRun, report.Committed)
+
+	if report.Errors > 0 {
+		c.IndentedJSON(http.StatusUnprocessableEntity, report)
+		return
+	}
+
+	c.IndentedJSON(http.StatusOK, report)
+}
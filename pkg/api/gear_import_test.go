@@ -0,0 +1,134 @@
+package endpoints
+
+import (
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	models "github.com/Sea-Shell/gogear-api/pkg/models"
+
+	"github.com/gin-gonic/gin"
+	"go.uber.org/zap"
+)
+
+func TestImportGear_AdminCSVDryRunThenImport(t *testing.T) {
+	gin.SetMode(gin.TestMode)
+	db := tempDB(t)
+	runMigrate(t, db)
+	seedCatalog(t, db)
+
+	isAdmin := false
+	router := gin.New()
+	router.Use(testMiddleware(db, zap.NewNop().Sugar()))
+	router.Use(func(c *gin.Context) {
+		c.Set("user_is_admin", isAdmin)
+		c.Next()
+	})
+	router.POST("/api/v1/gear/import", ImportGear)
+
+	importCSV := func(rawQuery string) *httptest.ResponseRecorder {
+		body := "gear_name,top_category,category,manufacturer,gear_weight\nNallo 2,Shelter,Tents,Hilleberg,2400\nAkto,Shelter,Tents,hilleberg,1500\n"
+		req := httptest.NewRequest(http.MethodPost, "/api/v1/gear/import?"+rawQuery, strings.NewReader(body))
+		req.Header.Set("Content-Type", "text/csv")
+		w := httptest.NewRecorder()
+		router.ServeHTTP(w, req)
+		return w
+	}
+
+	if w := importCSV(""); w.Code != http.StatusForbidden {
+		t.Fatalf("ImportGear: expected 403 for non-admin, got %d", w.Code)
+	}
+
+	isAdmin = true
+	w := importCSV("dry_run=true")
+	if w.Code != http.StatusOK {
+		t.Fatalf("ImportGear dry run: expected 200, got %d — body: %s", w.Code, w.Body.String())
+	}
+	var report models.GearImportReport
+	if err := json.Unmarshal(w.Body.Bytes(), &report); err != nil {
+		t.Fatalf("unmarshal report: %v", err)
+	}
+	if !report.DryRun || report.Committed || report.Created != 2 {
+		t.Errorf("ImportGear dry run: report = %+v", report)
+	}
+
+	if w := importCSV("create_manufacturers=maybe"); w.Code != http.StatusBadRequest {
+		t.Errorf("ImportGear: expected 400 for invalid flag, got %d", w.Code)
+	}
+
+	w = importCSV("create_manufacturers=true")
+	if w.Code != http.StatusOK {
+		t.Fatalf("ImportGear: expected 200, got %d — body: %s", w.Code, w.Body.String())
+	}
+
+	var count int
+	if err := db.QueryRow("SELECT COUNT(*) FROM gear WHERE gearName IN ('Nallo 2', 'Akto') AND gearStatus IS NOT 0").Scan(&count); err != nil || count != 2 {
+		t.Errorf("ImportGear: imported catalog gear = %d, %v", count, err)
+	}
+
+	w = importCSV("on_duplicate=error")
+	if w.Code != http.StatusUnprocessableEntity {
+		t.Errorf("ImportGear: expected 422 for existing gear with on_duplicate=error, got %d — body: %s", w.Code, w.Body.String())
+	}
+}
+
+func TestExportGear_AdminFormatsAndFilters(t *testing.T) {
+	gin.SetMode(gin.TestMode)
+	db := tempDB(t)
+	runMigrate(t, db)
+	seedCatalog(t, db)
+	seedCatalogGear(t, db, 1, 1, "Nallo 2", 2400)
+	seedCatalogGear(t, db, 2, 2, "Tarp 5", 500)
+
+	isAdmin := false
+	router := gin.New()
+	router.Use(testMiddleware(db, zap.NewNop().Sugar()))
+	router.Use(func(c *gin.Context) {
+		c.Set("user_is_admin", isAdmin)
+		c.Next()
+	})
+	router.GET("/api/v1/gear/export", ExportGear)
+
+	export := func(rawQuery string) *httptest.ResponseRecorder {
+		req := httptest.NewRequest(http.MethodGet, "/api/v1/gear/export?"+rawQuery, nil)
+		w := httptest.NewRecorder()
+		router.ServeHTTP(w, req)
+		return w
+	}
+
+	if w := export(""); w.Code != http.StatusForbidden {
+		t.Fatalf("ExportGear: expected 403 for non-admin, got %d", w.Code)
+	}
+
+	isAdmin = true
+	w := export("")
+	if w.Code != http.StatusOK || w.Header().Get("Content-Type") != "application/x-ndjson" {
+		t.Fatalf("ExportGear: got %d %q — body: %s", w.Code, w.Header().Get("Content-Type"), w.Body.String())
+	}
+
+	lines := strings.Split(strings.TrimSpace(w.Body.String()), "\n")
+	if len(lines) != 2 {
+		t.Fatalf("ExportGear: %d lines, want 2 — body: %s", len(lines), w.Body.String())
+	}
+	var first models.FullGear
+	if err := json.Unmarshal([]byte(lines[0]), &first); err != nil || first.ManufactureName == "" {
+		t.Errorf("ExportGear: first line %q, %v", lines[0], err)
+	}
+
+	w = export("format=csv&category=2")
+	if w.Code != http.StatusOK || w.Header().Get("Content-Type") != "text/csv" {
+		t.Fatalf("ExportGear csv: got %d %q", w.Code, w.Header().Get("Content-Type"))
+	}
+	if !strings.HasPrefix(w.Body.String(), "gear_id,") || !strings.Contains(w.Body.String(), "Tarp 5") || strings.Contains(w.Body.String(), "Nallo 2") {
+		t.Errorf("ExportGear csv: body %q", w.Body.String())
+	}
+
+	if w := export("format=xml"); w.Code != http.StatusBadRequest {
+		t.Errorf("ExportGear: expected 400 for unknown format, got %d", w.Code)
+	}
+	if w := export("container=maybe"); w.Code != http.StatusBadRequest || w.Header().Get("Content-Type") != "application/json" {
+		t.Errorf("ExportGear: expected JSON 400 for invalid filter, got %d %q", w.Code, w.Header().Get("Content-Type"))
+	}
+}
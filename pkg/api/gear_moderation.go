@@ -0,0 +1,196 @@
+package endpoints
+
+import (
+	"database/sql"
+	"encoding/json"
+	"errors"
+	"net/http"
+	"strconv"
+
+	models "github.com/Sea-Shell/gogear-api/pkg/models"
+	utils "github.com/Sea-Shell/gogear-api/pkg/utils"
+
+	gin "github.com/gin-gonic/gin"
+	zap "go.uber.org/zap"
+)
+
+// ListGearReviewQueue lists gear submissions for moderation
+//
+//	@Summary		List gear review queue
+//	@Description	Get the gear submitted by non-admins, oldest first. Only pending submissions are listed unless review_status says otherwise. Requires a JWT issued with the admin audience.
+//	@Security		BearerAuth
+//	@Tags			Gear moderation
+//	@Accept			json
+//	@Produce		json
+//	@Param			page			query		int		false	"Page number"								default(1)
+//	@Param			limit			query		int		false	"Number of items per page"					default(30)
+//	@Param			sort			query		string	false	"Field to sort by, json or db field name"	default(gear_submitted_at)
+//	@Param			order			query		string	false	"Sort direction, asc or desc"				default(asc)
+//	@Param			cursor			query		string	false	"Opaque cursor from next_cursor or prev_cursor, replaces page"
+//	@Param			count			query		bool	false	"Include total item count"				default(true)
+//	@Param			review_status	query		string	false	"pending, approved, rejected or all"	default(pending)
+//	@Success		200				{object}	models.ResponsePayload{items=[]models.GearSubmission}
+//	@Failure		default			{object}	models.Error
+//	@Router			/api/v1/gear/review/list [get]
+func ListGearReviewQueue(c *gin.Context) {
+	c.Header("Content-Type", "application/json")
+
+	log := c.MustGet("logger").(*zap.SugaredLogger)
+	db := c.MustGet("db").(*sql.DB)
+
+	isAdmin, _ := c.Get("user_is_admin")
+	if adminFlag, ok := isAdmin.(bool); !ok || !adminFlag {
+		log.Warn("unauthorized gear review queue access without admin privileges")
+		c.AbortWithStatusJSON(http.StatusForbidden, models.Error{Error: "admin privileges required"})
+		return
+	}
+
+	query := utils.NewListQuery[models.GearSubmission]("gear" + utils.GearSubmissionJoins).
+		SortDefault("gear_submitted_at").
+		Where("gear.gearSubmittedBy IS NOT NULL")
+	listGearSubmissions(c, log, db, query, utils.GearReviewPending)
+}
+
+// ListGearSubmissions lists the gear the current user submitted
+//
+//	@Summary		List own gear submissions
+//	@Description	Get the gear the current user submitted to the catalog with its review status and the reason given by the reviewer, oldest first
+//	@Security		BearerAuth
+//	@Tags			Gear moderation
+//	@Accept			json
+//	@Produce		json
+//	@Param			page			query		int		false	"Page number"								default(1)
+//	@Param			limit			query		int		false	"Number of items per page"					default(30)
+//	@Param			sort			query		string	false	"Field to sort by, json or db field name"	default(gear_submitted_at)
+//	@Param			order			query		string	false	"Sort direction, asc or desc"				default(asc)
+//	@Param			cursor			query		string	false	"Opaque cursor from next_cursor or prev_cursor, replaces page"
+//	@Param			count			query		bool	false	"Include total item count"				default(true)
+//	@Param			review_status	query		string	false	"pending, approved, rejected or all"	default(all)
+//	@Success		200				{object}	models.ResponsePayload{items=[]models.GearSubmission}
+//	@Failure		default			{object}	models.Error
+//	@Router			/api/v1/gear/submission/list [get]
+func ListGearSubmissions(c *gin.Context) {
+	c.Header("Content-Type", "application/json")
+
+	log := c.MustGet("logger").(*zap.SugaredLogger)
+	db := c.MustGet("db").(*sql.DB)
+
+	query := utils.NewListQuery[models.GearSubmission]("gear"+utils.GearSubmissionJoins).
+		SortDefault("gear_submitted_at").
+		Where("gear.gearSubmittedBy = ?", c.MustGet("user_id_int64").(int64))
+	listGearSubmissions(c, log, db, query, "all")
+}
+
+// listGearSubmissions filters a submission list by the review_status parameter, or by
+// defaultStatus when it is not given, and writes the page.
+func listGearSubmissions(c *gin.Context, log *zap.SugaredLogger, db *sql.DB, query *utils.ListQuery[models.GearSubmission], defaultStatus string) {
+	status := c.DefaultQuery("review_status", defaultStatus)
+	switch status {
+	case "all":
+	case utils.GearReviewPending, utils.GearReviewApproved, utils.GearReviewRejected:
+		query.Where("gear.gearReviewStatus = ?", status)
+	default:
+		respondListQueryError(c, log, &utils.ListQueryError{Message: "review_status must be pending, approved, rejected or all"})
+		return
+	}
+
+	if err := query.FilterFields(c.Request.URL.Query()); err != nil {
+		respondListQueryError(c, log, err)
+		return
+	}
+
+	respondList(c, log, db, query)
+}
+
+// ReviewGearSubmission approves or rejects gear
+//
+//	@Summary		Review gear submission
+//	@Description	Approve a gear submission into the catalog, or reject it with a reason shown to the submitter. Admins edit submissions with the gear update route before deciding. Requires a JWT issued with the admin audience.
+//	@Security		BearerAuth
+//	@Tags			Gear moderation
+//	@Accept			json
+//	@Produce		json
+//	@Param			gear	path		int							true	"Unique ID of gear you want to review"
+//	@Param			request	body		models.GearReviewDecision	true	"Decision: approve or reject"
+//	@Success		200		{object}	models.GearSubmission
+//	@Failure		default	{object}	models.Error
+//	@Router			/api/v1/gear/{gear}/review [post]
+func ReviewGearSubmission(c *gin.Context) {
+	c.Header("Content-Type", "application/json")
+
+	log := c.MustGet("logger").(*zap.SugaredLogger)
+	db := c.MustGet("db").(*sql.DB)
+
+	isAdmin, _ := c.Get("user_is_admin")
+	if adminFlag, ok := isAdmin.(bool); !ok || !adminFlag {
+		log.Warn("unauthorized gear review attempt without admin privileges")
+		c.AbortWithStatusJSON(http.StatusForbidden, models.Error{Error: "admin privileges required"})
+		return
+	}
+
+	gearID, err := strconv.ParseInt(c.Param("gear"), 10, 64)
+	if err != nil {
+		c.JSON(http.StatusBadRequest, models.Error{Error: "invalid gear ID"})
+		return
+	}
+
+	var decision models.GearReviewDecision
+	if err := c.ShouldBindJSON(&decision); err != nil {
+		c.JSON(http.StatusBadRequest, models.Error{Error: err.Error()})
+		return
+	}
+
+	err = utils.ReviewGear(db, gearID, c.MustGet("user_id_int64").(int64), decision)
+	var moderationErr *utils.ModerationError
+	switch {
+	case errors.As(err, &moderationErr):
+		c.JSON(http.StatusBadRequest, models.Error{Error: moderationErr.Message})
+		return
+	case errors.Is(err, sql.ErrNoRows):
+		c.JSON(http.StatusNotFound, models.Error{Error: "Gear not found"})
+		return
+	case err != nil:
+		log.Errorf("error reviewing gear: %#v", err)
+		c.JSON(http.StatusInternalServerError, models.Error{Error: err.Error()})
+		return
+	}
+
+	submission, err := utils.GearSubmissionByID(db, gearID)
+	if err != nil {
+		log.Errorf("error loading reviewed gear: %#v", err)
+		c.JSON(http.StatusInternalServerError, models.Error{Error: err.Error()})
+		return
+	}
+
+	log.Infof("Gear %d was %s", gearID, submission.GearReviewStatus)
+	c.JSON(http.StatusOK, submission)
+}
+
+// respondGearNotVisible writes 404 and returns true unless the current user can see the
+// gear: catalog gear, their own submissions, or any gear for admins.
+func respondGearNotVisible(c *gin.Context, log *zap.SugaredLogger, db *sql.DB, gearID int64) bool {
+	isAdmin, _ := c.Get("user_is_admin")
+	adminFlag, _ := isAdmin.(bool)
+
+	visible, err := utils.GearVisible(db, gearID, c.MustGet("user_id_int64").(int64), adminFlag)
+	if err != nil {
+		log.Errorf("error checking gear visibility: %#v", err)
+		c.JSON(http.StatusInternalServerError, models.Error{Error: err.Error()})
+		return true
+	}
+	if !visible {
+		c.JSON(http.StatusNotFound, models.Error{Error: "Gear not found"})
+		return true
+	}
+	return false
+}
+
+// withGearStatus sets gear_status in a gear body.
+func withGearStatus(data []byte, status bool) ([]byte, error) {
+	var body map[string]json.RawMessage
+	if err := json.Unmarshal(data, &body); err != nil {
+		return nil, err
+	}
+	body["gear_status"] = json.RawMessage(strconv.FormatBool(status))
+	return json.Marshal(body)
+}
@@ -0,0 +1,123 @@
+package endpoints
+
+import (
+	"encoding/json"
+	"net/http"
+	"testing"
+
+	models "github.com/Sea-Shell/gogear-api/pkg/models"
+
+	"github.com/gin-gonic/gin"
+)
+
+func TestGearModeration(t *testing.T) {
+	db, router := setupCatalogTest(t, 2)
+	seedCatalog(t, db)
+	seedCatalogGear(t, db, 1, 1, "Nallo", 2400)
+	seedUser(t, db, 1)
+	seedUser(t, db, 3)
+
+	userID, isAdmin := int64(2), false
+	v1 := router.Group("/api/v1", func(c *gin.Context) {
+		c.Set("user_id", itoa64(userID))
+		c.Set("user_id_int64", userID)
+		c.Set("user_is_admin", isAdmin)
+		c.Next()
+	})
+	v1.PUT("/gear/insert", InsertGear)
+	v1.POST("/gear/:gear/update", UpdateGear)
+	v1.GET("/gear/:gear/get", GetGear)
+	v1.GET("/gear/review/list", ListGearReviewQueue)
+	v1.GET("/gear/submission/list", ListGearSubmissions)
+	v1.POST("/gear/:gear/review", ReviewGearSubmission)
+	v1.PUT("/usergear/insert", InsertUserGear)
+	as := func(id int64, admin bool) { userID, isAdmin = id, admin }
+
+	soulo := `"gear_top_category_id":1,"gear_category_id":1,"gear_manufacture_id":1,"gear_name":"Soulo"`
+	w := authRequest(t, router, http.MethodPut, "/api/v1/gear/insert", `{`+soulo+`,"gear_weight":2000,"gear_status":true}`)
+	var created models.Gear
+	if err := json.Unmarshal(w.Body.Bytes(), &created); err != nil || w.Code != http.StatusOK {
+		t.Fatalf("InsertGear: %d %s", w.Code, w.Body.String())
+	}
+	id := itoa64(*created.GearID)
+
+	var status bool
+	var reviewStatus string
+	if err := db.QueryRow(`SELECT gearStatus, gearReviewStatus FROM gear WHERE gearId = ?`, *created.GearID).Scan(&status, &reviewStatus); err != nil {
+		t.Fatal(err)
+	}
+	if status || reviewStatus != "pending" {
+		t.Errorf("InsertGear by non-admin: expected pending submission, got status %v review %s", status, reviewStatus)
+	}
+
+	w = authRequest(t, router, http.MethodGet, "/api/v1/gear/list", "")
+	if payload := decodeListPayload(t, w.Body.Bytes()); len(payload.Items) != 2 {
+		t.Errorf("ListGear: submitter should see own pending gear, got %s", w.Body.String())
+	}
+	w = authRequest(t, router, http.MethodGet, "/api/v1/gear/suggest?q=Soulo", "")
+	if w.Code != http.StatusOK || w.Body.String() != "[]" {
+		t.Errorf("SuggestGear: pending gear should not be suggested: %s", w.Body.String())
+	}
+	if w := authRequest(t, router, http.MethodPut, "/api/v1/usergear/insert", `{"usergear_gear_id":`+id+`,"usergear_user_id":2}`); w.Code != http.StatusOK {
+		t.Errorf("InsertUserGear: submitter should register pending gear, got %d %s", w.Code, w.Body.String())
+	}
+
+	as(3, false)
+	if w := authRequest(t, router, http.MethodGet, "/api/v1/gear/"+id+"/get", ""); w.Code != http.StatusNotFound {
+		t.Errorf("GetGear: pending gear should be hidden from other users, got %d", w.Code)
+	}
+	if w := authRequest(t, router, http.MethodPut, "/api/v1/usergear/insert", `{"usergear_gear_id":`+id+`,"usergear_user_id":3}`); w.Code != http.StatusNotFound {
+		t.Errorf("InsertUserGear: other users should not register pending gear, got %d", w.Code)
+	}
+	if w := authRequest(t, router, http.MethodPost, "/api/v1/gear/1/update", `{"gear_id":1,`+soulo+`}`); w.Code != http.StatusForbidden {
+		t.Errorf("UpdateGear: non-admin should not edit catalog gear, got %d", w.Code)
+	}
+	if w := authRequest(t, router, http.MethodPost, "/api/v1/gear/"+id+"/review", `{"decision":"approve"}`); w.Code != http.StatusForbidden {
+		t.Errorf("ReviewGearSubmission: expected 403 for non-admin, got %d", w.Code)
+	}
+
+	as(1, true)
+	w = authRequest(t, router, http.MethodGet, "/api/v1/gear/review/list", "")
+	payload := decodeListPayload(t, w.Body.Bytes())
+	if len(payload.Items) != 1 || payload.Items[0]["gear_name"] != "Soulo" || payload.Items[0]["submitter_name"] != "Test User" {
+		t.Fatalf("ListGearReviewQueue: %s", w.Body.String())
+	}
+	if w := authRequest(t, router, http.MethodPost, "/api/v1/gear/"+id+"/review", `{"decision":"reject"}`); w.Code != http.StatusBadRequest {
+		t.Errorf("ReviewGearSubmission: rejecting without a reason should fail, got %d", w.Code)
+	}
+	if w := authRequest(t, router, http.MethodPost, "/api/v1/gear/"+id+"/review", `{"decision":"reject","reason":"Weight is missing the stakes"}`); w.Code != http.StatusOK {
+		t.Fatalf("ReviewGearSubmission reject: %d %s", w.Code, w.Body.String())
+	}
+
+	as(2, false)
+	w = authRequest(t, router, http.MethodGet, "/api/v1/gear/submission/list", "")
+	payload = decodeListPayload(t, w.Body.Bytes())
+	if len(payload.Items) != 1 || payload.Items[0]["gear_review_status"] != "rejected" || payload.Items[0]["gear_review_reason"] != "Weight is missing the stakes" {
+		t.Fatalf("ListGearSubmissions: %s", w.Body.String())
+	}
+	if w := authRequest(t, router, http.MethodPost, "/api/v1/gear/"+id+"/update", `{"gear_id":`+id+`,`+soulo+`,"gear_weight":2100}`); w.Code != http.StatusOK {
+		t.Fatalf("UpdateGear: submitter should fix rejected gear, got %d %s", w.Code, w.Body.String())
+	}
+	if err := db.QueryRow(`SELECT gearReviewStatus FROM gear WHERE gearId = ?`, *created.GearID).Scan(&reviewStatus); err != nil || reviewStatus != "pending" {
+		t.Errorf("UpdateGear: edited submission should be pending again, got %s (%v)", reviewStatus, err)
+	}
+
+	as(1, true)
+	w = authRequest(t, router, http.MethodPost, "/api/v1/gear/"+id+"/review", `{"decision":"approve"}`)
+	var reviewed models.GearSubmission
+	if err := json.Unmarshal(w.Body.Bytes(), &reviewed); err != nil || w.Code != http.StatusOK {
+		t.Fatalf("ReviewGearSubmission approve: %d %s", w.Code, w.Body.String())
+	}
+	if !reviewed.GearStatus || reviewed.GearReviewReason != nil || *reviewed.GearReviewedBy != 1 {
+		t.Errorf("ReviewGearSubmission approve: %+v", reviewed)
+	}
+
+	as(3, false)
+	if w := authRequest(t, router, http.MethodGet, "/api/v1/gear/"+id+"/get", ""); w.Code != http.StatusOK {
+		t.Errorf("GetGear: approved gear should be visible, got %d", w.Code)
+	}
+	as(2, false)
+	if w := authRequest(t, router, http.MethodPost, "/api/v1/gear/"+id+"/update", `{"gear_id":`+id+`,`+soulo+`}`); w.Code != http.StatusForbidden {
+		t.Errorf("UpdateGear: approved gear is catalog gear, expected 403, got %d", w.Code)
+	}
+}
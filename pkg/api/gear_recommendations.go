@@ -0,0 +1,102 @@
+package endpoints
+
+import (
+	"database/sql"
+	"net/http"
+
+	models "github.com/Sea-Shell/gogear-api/pkg/models"
+	utils "github.com/Sea-Shell/gogear-api/pkg/utils"
+
+	gin "github.com/gin-gonic/gin"
+	zap "go.uber.org/zap"
+)
+
+const defaultRecommendationLimit = 10
+
+// ListGearRecommendations recommends gear owned together with a gear
+//
+//	@Summary		List gear owned together with a gear
+//	@Description	Get "users who own this also own" recommendations: catalog gear registered by the same users or packed in the same loadouts as the gear, strongest first. Gear the caller already owns and gear in the same category as the gear are left out. The co-ownership counts are cached and recomputed every few minutes.
+//	@Security		BearerAuth
+//	@Tags			Gear statistics
+//	@Accept			json
+//	@Produce		json
+//	@Param			gear		path		int	true	"Unique ID of gear"
+//	@Param			category	query		int	false	"Gear category, including the categories below it"
+//	@Param			limit		query		int	false	"Maximum number of gear"	default(10)	maximum(50)
+//	@Success		200			{array}		models.GearRecommendation
+//	@Failure		default		{object}	models.Error
+//	@Router			/api/v1/gear/{gear}/recommendation/list [get]
+func ListGearRecommendations(c *gin.Context) {
+	c.Header("Content-Type", "application/json")
+
+	log := c.MustGet("logger").(*zap.SugaredLogger)
+	db := c.MustGet("db").(*sql.DB)
+	recommender := c.MustGet("gear_recommender").(*utils.GearRecommender)
+
+	gearID, ok := gearVariantGear(c, log, db)
+	if !ok || respondGearNotVisible(c, log, db, gearID) {
+		return
+	}
+
+	limit, category, ok := gearRankingParams(c, defaultRecommendationLimit, utils.MaxRecommendationLimit)
+	if !ok {
+		return
+	}
+
+	recommendations, err := recommender.ForGear(db, gearID, c.MustGet("user_id_int64").(int64), category, limit)
+	if err != nil {
+		log.Errorf("error recommending gear: %#v", err)
+		c.JSON(http.StatusInternalServerError, models.Error{Error: err.Error()})
+		return
+	}
+
+	respondGearRecommendations(c, log, db, recommendations)
+}
+
+// ListMyGearRecommendations recommends gear for the calling user
+//
+//	@Summary		List gear recommended for you
+//	@Description	Get catalog gear the caller does not own yet, scored by how often it is registered or packed together with the gear the caller registered, strongest first. because_gear_ids lists the caller's gear that led to each recommendation. Gear in a category the caller already owns gear in is only recommended when several of the caller's gear point to it. The co-ownership counts are cached and recomputed every few minutes.
+//	@Security		BearerAuth
+//	@Tags			Gear statistics
+//	@Accept			json
+//	@Produce		json
+//	@Param			category	query		int	false	"Gear category, including the categories below it"
+//	@Param			limit		query		int	false	"Maximum number of gear"	default(10)	maximum(50)
+//	@Success		200			{array}		models.GearRecommendation
+//	@Failure		default		{object}	models.Error
+//	@Router			/api/v1/gear/recommendation/list [get]
+func ListMyGearRecommendations(c *gin.Context) {
+	c.Header("Content-Type", "application/json")
+
+	log := c.MustGet("logger").(*zap.SugaredLogger)
+	db := c.MustGet("db").(*sql.DB)
+	recommender := c.MustGet("gear_recommender").(*utils.GearRecommender)
+
+	limit, category, ok := gearRankingParams(c, defaultRecommendationLimit, utils.MaxRecommendationLimit)
+	if !ok {
+		return
+	}
+
+	recommendations, err := recommender.ForUser(db, c.MustGet("user_id_int64").(int64), category, limit)
+	if err != nil {
+		log.Errorf("error recommending gear: %#v", err)
+		c.JSON(http.StatusInternalServerError, models.Error{Error: err.Error()})
+		return
+	}
+
+	respondGearRecommendations(c, log, db, recommendations)
+}
+
+func respondGearRecommendations(c *gin.Context, log *zap.SugaredLogger, db *sql.DB, recommendations []models.GearRecommendation) {
+	gear := make([]*models.GearListItem, len(recommendations))
+	for i := range recommendations {
+		gear[i] = &recommendations[i].Gear
+		resolveImageURLs(c, gear[i])
+	}
+	if !localizeItems(c, log, db, gear...) {
+		return
+	}
+	c.JSON(http.StatusOK, recommendations)
+}
@@ -0,0 +1,83 @@
+package endpoints
+
+import (
+	"encoding/json"
+	"net/http"
+	"testing"
+
+	models "github.com/Sea-Shell/gogear-api/pkg/models"
+	utils "github.com/Sea-Shell/gogear-api/pkg/utils"
+
+	"github.com/gin-gonic/gin"
+)
+
+func TestGearRecommendations(t *testing.T) {
+	db, router := setupCatalogTest(t, 1)
+	seedCatalog(t, db)
+	for _, id := range []int64{2, 3, 4} {
+		seedUser(t, db, id)
+	}
+	seedCatalogGear(t, db, 1, 1, "Nallo", 2400)
+	seedCatalogGear(t, db, 2, 1, "Akto", 1700)
+	seedCatalogGear(t, db, 3, 2, "Tarp 10", 700)
+	seedCatalogGear(t, db, 4, 3, "Bivanorak", 600)
+	seedCatalogGear(t, db, 5, 2, "Pending tarp", 500)
+	for _, statement := range []string{
+		`UPDATE gear SET gearStatus = 0 WHERE gearId = 5`,
+		`INSERT INTO user_gear_registrations (gearId, userId) VALUES (1, 1), (1, 2), (3, 2), (1, 3), (3, 3), (4, 3), (1, 4), (2, 4), (5, 2), (5, 3)`,
+	} {
+		if _, err := db.Exec(statement); err != nil {
+			t.Fatal(err)
+		}
+	}
+	loadoutID := seedLoadout(t, db, 4, true, "trip")
+	seedLoadoutItem(t, db, loadoutID, 1)
+	seedLoadoutItem(t, db, loadoutID, 4)
+
+	v1 := router.Group("/api/v1", testAuthMiddleware(1), func(c *gin.Context) {
+		c.Set("gear_recommender", utils.NewGearRecommender())
+		c.Next()
+	})
+	v1.GET("/gear/recommendation/list", ListMyGearRecommendations)
+	v1.GET("/gear/:gear/recommendation/list", ListGearRecommendations)
+
+	recommendations := func(path string) []int64 {
+		t.Helper()
+		w := authRequest(t, router, http.MethodGet, path, "")
+		var items []models.GearRecommendation
+		if err := json.Unmarshal(w.Body.Bytes(), &items); err != nil || w.Code != http.StatusOK {
+			t.Fatalf("%s: %d %s", path, w.Code, w.Body.String())
+		}
+		ids := make([]int64, len(items))
+		for i, item := range items {
+			ids[i] = item.Gear.GearID
+			if item.Score <= 0 || item.Count <= 0 || len(item.BecauseGearIDs) == 0 {
+				t.Errorf("%s: unexpected recommendation %+v", path, item)
+			}
+		}
+		return ids
+	}
+
+	// Gear 4 is owned or packed with gear 1 more often than gear 3. Gear 2 shares the category of
+	// gear 1 and gear 5 is not in the catalog.
+	if ids := recommendations("/api/v1/gear/1/recommendation/list"); len(ids) != 2 || ids[0] != 4 || ids[1] != 3 {
+		t.Errorf("ListGearRecommendations: expected gear 4 and then 3, got %v", ids)
+	}
+	if ids := recommendations("/api/v1/gear/1/recommendation/list?category=3"); len(ids) != 1 || ids[0] != 4 {
+		t.Errorf("ListGearRecommendations: expected only the bivy, got %v", ids)
+	}
+	// The caller owns gear 1, so it is never recommended to them.
+	if ids := recommendations("/api/v1/gear/3/recommendation/list"); len(ids) != 1 || ids[0] != 4 {
+		t.Errorf("ListGearRecommendations: expected gear 4 only, got %v", ids)
+	}
+	if ids := recommendations("/api/v1/gear/recommendation/list"); len(ids) != 2 || ids[0] != 4 || ids[1] != 3 {
+		t.Errorf("ListMyGearRecommendations: expected gear 4 and then 3 without a second tent, got %v", ids)
+	}
+
+	if w := authRequest(t, router, http.MethodGet, "/api/v1/gear/99/recommendation/list", ""); w.Code != http.StatusNotFound {
+		t.Errorf("ListGearRecommendations: expected 404 for missing gear, got %d", w.Code)
+	}
+	if w := authRequest(t, router, http.MethodGet, "/api/v1/gear/recommendation/list?limit=51", ""); w.Code != http.StatusBadRequest {
+		t.Errorf("ListMyGearRecommendations: expected 400 for limit out of range, got %d", w.Code)
+	}
+}
@@ -0,0 +1,324 @@
+package endpoints
+
+import (
+	"database/sql"
+	"errors"
+	"net/http"
+	"strconv"
+
+	models "github.com/Sea-Shell/gogear-api/pkg/models"
+	utils "github.com/Sea-Shell/gogear-api/pkg/utils"
+
+	gin "github.com/gin-gonic/gin"
+	sqlite3 "github.com/mattn/go-sqlite3"
+	zap "go.uber.org/zap"
+)
+
+// ListGearReviews lists the reviews of a piece of gear.
+//
+//	@Summary		List gear reviews
+//	@Description	List the reviews owners left on a piece of gear. Reviews hidden by an admin are only listed for admins and their author.
+//	@Security		BearerAuth
+//	@Tags			Gear reviews
+//	@Accept			json
+//	@Produce		json
+//	@Param			gear	path		int		true	"Gear ID"
+//	@Param			page	query		int		false	"Page number"								default(1)
+//	@Param			limit	query		int		false	"Number of items per page"					default(30)
+//	@Param			sort	query		string	false	"Field to sort by, json or db field name"	default(created_at)
+//	@Param			order	query		string	false	"Sort direction, asc or desc"				default(asc)
+//	@Param			cursor	query		string	false	"Opaque cursor from next_cursor or prev_cursor, replaces page"
+//	@Param			count	query		bool	false	"Include total item count"	default(true)
+//	@Success		200		{object}	models.ResponsePayload{items=[]models.GearReview}
+//	@Failure		default	{object}	models.Error
+//	@Router			/api/v1/gear/{gear}/review/list [get]
+func ListGearReviews(c *gin.Context) {
+	c.Header("Content-Type", "application/json")
+
+	log := c.MustGet("logger").(*zap.SugaredLogger)
+	db := c.MustGet("db").(*sql.DB)
+
+	gearID, ok := gearVariantGear(c, log, db)
+	if !ok || respondGearNotVisible(c, log, db, gearID) {
+		return
+	}
+
+	query := utils.NewListQuery[models.GearReview]("gear_reviews"+utils.GearReviewJoins).
+		SortDefault("created_at").
+		Where("gear_reviews.gearId = ?", gearID)
+
+	isAdmin, _ := c.Get("user_is_admin")
+	if adminFlag, _ := isAdmin.(bool); !adminFlag {
+		query.Where("(gear_reviews.reviewHidden = 0 OR gear_reviews.userId = ?)", c.MustGet("user_id_int64").(int64))
+	}
+
+	if err := query.FilterFields(c.Request.URL.Query()); err != nil {
+		respondListQueryError(c, log, err)
+		return
+	}
+
+	respondList(c, log, db, query)
+}
+
+// InsertGearReview reviews a piece of gear the user owns.
+//
+//	@Summary		Insert gear review
+//	@Description	Rate a piece of gear from 1 to 5, optionally with a text. Only users who have registered the gear can review it, once per gear.
+//	@Security		BearerAuth
+//	@Tags			Gear reviews
+//	@Accept			json
+//	@Produce		json
+//	@Param			gear	path		int						true	"Gear ID"
+//	@Param			body	body		models.GearReviewNoID	true	"Rating and text"
+//	@Success		201		{object}	models.GearReview
+//	@Failure		400		{object}	models.Error
+//	@Failure		403		{object}	models.Error
+//	@Failure		404		{object}	models.Error
+//	@Failure		409		{object}	models.Error
+//	@Failure		500		{object}	models.Error
+//	@Router			/api/v1/gear/{gear}/review/insert [put]
+func InsertGearReview(c *gin.Context) {
+	c.Header("Content-Type", "application/json")
+
+	log := c.MustGet("logger").(*zap.SugaredLogger)
+	db := c.MustGet("db").(*sql.DB)
+	userID := c.MustGet("user_id_int64").(int64)
+
+	gearID, ok := gearVariantGear(c, log, db)
+	if !ok || respondGearNotVisible(c, log, db, gearID) {
+		return
+	}
+
+	owns, err := utils.OwnsGear(db, userID, gearID)
+	if err != nil {
+		log.Errorf("error checking gear ownership: %#v", err)
+		c.JSON(http.StatusInternalServerError, models.Error{Error: err.Error()})
+		return
+	}
+	if !owns {
+		c.JSON(http.StatusForbidden, models.Error{Error: "only users who own the gear can review it"})
+		return
+	}
+
+	var input models.GearReviewNoID
+	if err := c.ShouldBindJSON(&input); err != nil {
+		c.JSON(http.StatusBadRequest, models.Error{Error: err.Error()})
+		return
+	}
+
+	reviewID, err := utils.InsertGearReview(db, gearID, userID, input)
+	if err != nil {
+		respondGearReviewWriteError(c, log, err)
+		return
+	}
+
+	review, err := utils.GearReviewByID(db, reviewID)
+	if err != nil {
+		log.Errorf("error loading gear review: %#v", err)
+		c.JSON(http.StatusInternalServerError, models.Error{Error: err.Error()})
+		return
+	}
+
+	log.Infof("User %d reviewed gear %d", userID, gearID)
+	c.JSON(http.StatusCreated, review)
+}
+
+// UpdateGearReview updates the user's own review.
+//
+//	@Summary		Update gear review
+//	@Description	Change the rating and text of your own review
+//	@Security		BearerAuth
+//	@Tags			Gear reviews
+//	@Accept			json
+//	@Produce		json
+//	@Param			gear	path		int						true	"Gear ID"
+//	@Param			review	path		int						true	"Review ID"
+//	@Param			body	body		models.GearReviewNoID	true	"Rating and text"
+//	@Success		200		{object}	models.GearReview
+//	@Failure		400		{object}	models.Error
+//	@Failure		403		{object}	models.Error
+//	@Failure		404		{object}	models.Error
+//	@Failure		500		{object}	models.Error
+//	@Router			/api/v1/gear/{gear}/review/{review}/update [post]
+func UpdateGearReview(c *gin.Context) {
+	c.Header("Content-Type", "application/json")
+
+	log := c.MustGet("logger").(*zap.SugaredLogger)
+	db := c.MustGet("db").(*sql.DB)
+
+	existing, ok := gearReviewFromRoute(c, log, db)
+	if !ok {
+		return
+	}
+	if existing.UserID != c.MustGet("user_id_int64").(int64) {
+		c.JSON(http.StatusForbidden, models.Error{Error: "you can only update your own review"})
+		return
+	}
+
+	var input models.GearReviewNoID
+	if err := c.ShouldBindJSON(&input); err != nil {
+		c.JSON(http.StatusBadRequest, models.Error{Error: err.Error()})
+		return
+	}
+
+	if err := utils.UpdateGearReview(db, existing.ReviewID, input); err != nil {
+		respondGearReviewWriteError(c, log, err)
+		return
+	}
+
+	review, err := utils.GearReviewByID(db, existing.ReviewID)
+	if err != nil {
+		log.Errorf("error loading gear review: %#v", err)
+		c.JSON(http.StatusInternalServerError, models.Error{Error: err.Error()})
+		return
+	}
+
+	c.JSON(http.StatusOK, review)
+}
+
+// DeleteGearReview deletes a review.
+//
+//	@Summary		Delete gear review
+//	@Description	Delete your own review. Admins can delete any review.
+//	@Security		BearerAuth
+//	@Tags			Gear reviews
+//	@Accept			json
+//	@Produce		json
+//	@Param			gear	path		int	true	"Gear ID"
+//	@Param			review	path		int	true	"Review ID"
+//	@Success		200		{object}	models.Status
+//	@Failure		400		{object}	models.Error
+//	@Failure		403		{object}	models.Error
+//	@Failure		404		{object}	models.Error
+//	@Failure		500		{object}	models.Error
+//	@Router			/api/v1/gear/{gear}/review/{review}/delete [delete]
+func DeleteGearReview(c *gin.Context) {
+	c.Header("Content-Type", "application/json")
+
+	log := c.MustGet("logger").(*zap.SugaredLogger)
+	db := c.MustGet("db").(*sql.DB)
+
+	existing, ok := gearReviewFromRoute(c, log, db)
+	if !ok {
+		return
+	}
+
+	isAdmin, _ := c.Get("user_is_admin")
+	if adminFlag, _ := isAdmin.(bool); !adminFlag && existing.UserID != c.MustGet("user_id_int64").(int64) {
+		c.JSON(http.StatusForbidden, models.Error{Error: "you can only delete your own review"})
+		return
+	}
+
+	if _, err := db.Exec("DELETE FROM gear_reviews WHERE reviewId = ?", existing.ReviewID); err != nil {
+		log.Errorf("error deleting gear review: %#v", err)
+		c.JSON(http.StatusInternalServerError, models.Error{Error: err.Error()})
+		return
+	}
+
+	log.Infof("Deleted review %d of gear %d", existing.ReviewID, existing.GearID)
+	c.JSON(http.StatusOK, models.Status{Status: "success"})
+}
+
+// HideGearReview hides an abusive review or shows it again.
+//
+//	@Summary		Hide gear review
+//	@Description	Hide a review from other users and from the gear's rating, or show it again with hidden false. The reason is shown to the author. Requires a JWT issued with the admin audience.
+//	@Security		BearerAuth
+//	@Tags			Gear reviews
+//	@Accept			json
+//	@Produce		json
+//	@Param			gear	path		int						true	"Gear ID"
+//	@Param			review	path		int						true	"Review ID"
+//	@Param			body	body		models.GearReviewHide	true	"Whether to hide the review, and why"
+//	@Success		200		{object}	models.GearReview
+//	@Failure		400		{object}	models.Error
+//	@Failure		403		{object}	models.Error
+//	@Failure		404		{object}	models.Error
+//	@Failure		500		{object}	models.Error
+//	@Router			/api/v1/gear/{gear}/review/{review}/hide [post]
+func HideGearReview(c *gin.Context) {
+	c.Header("Content-Type", "application/json")
+
+	log := c.MustGet("logger").(*zap.SugaredLogger)
+	db := c.MustGet("db").(*sql.DB)
+
+	isAdmin, _ := c.Get("user_is_admin")
+	if adminFlag, ok := isAdmin.(bool); !ok || !adminFlag {
+		log.Warn("unauthorized gear review hide attempt without admin privileges")
+		c.AbortWithStatusJSON(http.StatusForbidden, models.Error{Error: "admin privileges required"})
+		return
+	}
+
+	existing, ok := gearReviewFromRoute(c, log, db)
+	if !ok {
+		return
+	}
+
+	var hide models.GearReviewHide
+	if err := c.ShouldBindJSON(&hide); err != nil {
+		c.JSON(http.StatusBadRequest, models.Error{Error: err.Error()})
+		return
+	}
+
+	if err := utils.HideGearReview(db, existing.ReviewID, c.MustGet("user_id_int64").(int64), hide); err != nil {
+		respondGearReviewWriteError(c, log, err)
+		return
+	}
+
+	review, err := utils.GearReviewByID(db, existing.ReviewID)
+	if err != nil {
+		log.Errorf("error loading gear review: %#v", err)
+		c.JSON(http.StatusInternalServerError, models.Error{Error: err.Error()})
+		return
+	}
+
+	log.Infof("Review %d of gear %d hidden: %v", existing.ReviewID, existing.GearID, hide.Hidden)
+	c.JSON(http.StatusOK, review)
+}
+
+// gearReviewFromRoute loads the review route parameter, which must belong to the gear
+// route parameter.
+func gearReviewFromRoute(c *gin.Context, log *zap.SugaredLogger, db *sql.DB) (*models.GearReview, bool) {
+	gearID, ok := gearVariantGear(c, log, db)
+	if !ok {
+		return nil, false
+	}
+
+	reviewID, err := strconv.ParseInt(c.Param("review"), 10, 64)
+	if err != nil {
+		c.JSON(http.StatusBadRequest, models.Error{Error: "invalid review ID"})
+		return nil, false
+	}
+
+	review, err := utils.GearReviewByID(db, reviewID)
+	if err != nil && !errors.Is(err, sql.ErrNoRows) {
+		log.Errorf("error getting gear review: %#v", err)
+		c.JSON(http.StatusInternalServerError, models.Error{Error: err.Error()})
+		return nil, false
+	}
+	if err != nil || review.GearID != gearID {
+		c.JSON(http.StatusNotFound, models.Error{Error: "Review not found"})
+		return nil, false
+	}
+
+	return review, true
+}
+
+// respondGearReviewWriteError reports an invalid review as a bad request and a second
+// review of the same gear as a conflict.
+func respondGearReviewWriteError(c *gin.Context, log *zap.SugaredLogger, err error) {
+	var reviewErr *utils.ReviewError
+	if errors.As(err, &reviewErr) {
+		c.JSON(http.StatusBadRequest, models.Error{Error: reviewErr.Message})
+		return
+	}
+
+	var sqliteErr sqlite3.Error
+	if errors.As(err, &sqliteErr) && sqliteErr.ExtendedCode == sqlite3.ErrConstraintUnique {
+		c.JSON(http.StatusConflict, models.Error{Error: "you have already reviewed this gear"})
+		return
+	}
+
+	log.Errorf("error writing gear review: %#v", err)
+	c.JSON(http.StatusInternalServerError, models.Error{Error: err.Error()})
+}
@@ -0,0 +1,121 @@
+package endpoints
+
+import (
+	"encoding/json"
+	"net/http"
+	"testing"
+
+	models "github.com/Sea-Shell/gogear-api/pkg/models"
+
+	"github.com/gin-gonic/gin"
+)
+
+func TestGearReviews(t *testing.T) {
+	db, router := setupCatalogTest(t, 2)
+	seedCatalog(t, db)
+	seedCatalogGear(t, db, 1, 1, "Nallo", 2400)
+	seedCatalogGear(t, db, 2, 1, "Akto", 1600)
+	seedUser(t, db, 1)
+	seedUser(t, db, 3)
+	if _, err := db.Exec(`INSERT INTO user_gear_registrations (gearId, userId) VALUES (1, 2), (1, 3), (2, 3)`); err != nil {
+		t.Fatal(err)
+	}
+
+	userID, isAdmin := int64(2), false
+	v1 := router.Group("/api/v1", func(c *gin.Context) {
+		c.Set("user_id", itoa64(userID))
+		c.Set("user_id_int64", userID)
+		c.Set("user_is_admin", isAdmin)
+		c.Next()
+	})
+	v1.GET("/gear/:gear/get", GetGear)
+	v1.GET("/gear/:gear/review/list", ListGearReviews)
+	v1.PUT("/gear/:gear/review/insert", InsertGearReview)
+	v1.POST("/gear/:gear/review/:review/update", UpdateGearReview)
+	v1.DELETE("/gear/:gear/review/:review/delete", DeleteGearReview)
+	v1.POST("/gear/:gear/review/:review/hide", HideGearReview)
+	as := func(id int64, admin bool) { userID, isAdmin = id, admin }
+
+	w := authRequest(t, router, http.MethodPut, "/api/v1/gear/1/review/insert", `{"review_rating":5,"review_text":"  Bombproof  "}`)
+	var review models.GearReview
+	if err := json.Unmarshal(w.Body.Bytes(), &review); err != nil || w.Code != http.StatusCreated {
+		t.Fatalf("InsertGearReview: %d %s", w.Code, w.Body.String())
+	}
+	if *review.ReviewText != "Bombproof" || *review.UserName != "Test User" {
+		t.Errorf("InsertGearReview: unexpected review %+v", review)
+	}
+	reviewURL := "/api/v1/gear/1/review/" + itoa64(review.ReviewID)
+
+	if w := authRequest(t, router, http.MethodPut, "/api/v1/gear/1/review/insert", `{"review_rating":4}`); w.Code != http.StatusConflict {
+		t.Errorf("InsertGearReview twice: expected 409, got %d", w.Code)
+	}
+	if w := authRequest(t, router, http.MethodPut, "/api/v1/gear/2/review/insert", `{"review_rating":4}`); w.Code != http.StatusForbidden {
+		t.Errorf("InsertGearReview without owning the gear: expected 403, got %d", w.Code)
+	}
+
+	as(3, false)
+	if w := authRequest(t, router, http.MethodPut, "/api/v1/gear/1/review/insert", `{"review_rating":6}`); w.Code != http.StatusBadRequest {
+		t.Errorf("InsertGearReview rating 6: expected 400, got %d", w.Code)
+	}
+	w = authRequest(t, router, http.MethodPut, "/api/v1/gear/1/review/insert", `{"review_rating":1,"review_text":"Abusive"}`)
+	var abusive models.GearReview
+	if err := json.Unmarshal(w.Body.Bytes(), &abusive); err != nil || w.Code != http.StatusCreated {
+		t.Fatalf("InsertGearReview: %d %s", w.Code, w.Body.String())
+	}
+	if w := authRequest(t, router, http.MethodPut, "/api/v1/gear/2/review/insert", `{"review_rating":4}`); w.Code != http.StatusCreated {
+		t.Fatalf("InsertGearReview: %d %s", w.Code, w.Body.String())
+	}
+	if w := authRequest(t, router, http.MethodPost, reviewURL+"/update", `{"review_rating":1}`); w.Code != http.StatusForbidden {
+		t.Errorf("UpdateGearReview of another user's review: expected 403, got %d", w.Code)
+	}
+
+	w = authRequest(t, router, http.MethodGet, "/api/v1/gear/1/get", "")
+	var gear models.FullGear
+	if err := json.Unmarshal(w.Body.Bytes(), &gear); err != nil {
+		t.Fatal(err)
+	}
+	if gear.GearReviewCount != 2 || gear.GearRatingAverage == nil || *gear.GearRatingAverage != 3 {
+		t.Errorf("GetGear: expected 2 reviews averaging 3, got %d %v", gear.GearReviewCount, gear.GearRatingAverage)
+	}
+
+	hideURL := "/api/v1/gear/1/review/" + itoa64(abusive.ReviewID) + "/hide"
+	if w := authRequest(t, router, http.MethodPost, hideURL, `{"hidden":true}`); w.Code != http.StatusForbidden {
+		t.Errorf("HideGearReview: expected 403 for non-admin, got %d", w.Code)
+	}
+	as(1, true)
+	if w := authRequest(t, router, http.MethodPost, hideURL, `{"hidden":true,"reason":"Insults"}`); w.Code != http.StatusOK {
+		t.Fatalf("HideGearReview: %d %s", w.Code, w.Body.String())
+	}
+
+	as(2, false)
+	w = authRequest(t, router, http.MethodGet, "/api/v1/gear/1/review/list", "")
+	if payload := decodeListPayload(t, w.Body.Bytes()); len(payload.Items) != 1 || payload.Items[0]["review_rating"] != float64(5) {
+		t.Errorf("ListGearReviews: hidden review should be left out, got %s", w.Body.String())
+	}
+	w = authRequest(t, router, http.MethodGet, "/api/v1/gear/list?sort=gear_rating_average&order=desc", "")
+	payload := decodeListPayload(t, w.Body.Bytes())
+	if len(payload.Items) != 2 || payload.Items[0]["gear_id"] != float64(1) || payload.Items[0]["gear_rating_average"] != float64(5) {
+		t.Errorf("ListGear by rating: expected Nallo first with 5 after hiding, got %s", w.Body.String())
+	}
+	as(3, false)
+	w = authRequest(t, router, http.MethodGet, "/api/v1/gear/1/review/list", "")
+	if payload := decodeListPayload(t, w.Body.Bytes()); len(payload.Items) != 2 {
+		t.Errorf("ListGearReviews: author should still see their hidden review, got %s", w.Body.String())
+	}
+
+	as(2, false)
+	if w := authRequest(t, router, http.MethodPost, reviewURL+"/update", `{"review_rating":3}`); w.Code != http.StatusOK {
+		t.Fatalf("UpdateGearReview: %d %s", w.Code, w.Body.String())
+	}
+	if w := authRequest(t, router, http.MethodDelete, "/api/v1/gear/2/review/"+itoa64(review.ReviewID)+"/delete", ""); w.Code != http.StatusNotFound {
+		t.Errorf("DeleteGearReview through other gear: expected 404, got %d", w.Code)
+	}
+	if w := authRequest(t, router, http.MethodDelete, reviewURL+"/delete", ""); w.Code != http.StatusOK {
+		t.Fatalf("DeleteGearReview: %d %s", w.Code, w.Body.String())
+	}
+	var count int
+	var average *float64
+	if err := db.QueryRow(`SELECT gearReviewCount, gearRatingAverage FROM gear WHERE gearId = 1`).Scan(&count, &average); err != nil || count != 0 || average != nil {
+		t.Errorf("DeleteGearReview: expected no visible reviews left, got %d %v (%v)", count, average, err)
+	}
+}
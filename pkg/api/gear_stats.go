@@ -0,0 +1,133 @@
+package endpoints
+
+import (
+	"database/sql"
+	"fmt"
+	"net/http"
+	"strconv"
+
+	models "github.com/Sea-Shell/gogear-api/pkg/models"
+	utils "github.com/Sea-Shell/gogear-api/pkg/utils"
+
+	gin "github.com/gin-gonic/gin"
+	zap "go.uber.org/zap"
+)
+
+const defaultGearStatsLimit = 10
+
+// ListMostOwnedGear ranks gear by the number of owners
+//
+//	@Summary		List the most owned gear
+//	@Description	Get catalog gear ranked by the number of users who registered it, overall or in a category and the categories below it. count is the number of owners. The statistics are cached and refreshed every few minutes; Last-Modified tells when they were computed.
+//	@Security		BearerAuth
+//	@Tags			Gear statistics
+//	@Accept			json
+//	@Produce		json
+//	@Param			category	query		int	false	"Gear category, including the categories below it"
+//	@Param			limit		query		int	false	"Maximum number of gear"	default(10)	maximum(100)
+//	@Success		200			{array}		models.GearRanking
+//	@Failure		default		{object}	models.Error
+//	@Router			/api/v1/gear/stats/owned [get]
+func ListMostOwnedGear(c *gin.Context) {
+	listGearStats(c, "most owned gear", (*utils.GearStats).MostOwned, rankedGear)
+}
+
+// ListMostUsedGear ranks gear by its use in public loadouts
+//
+//	@Summary		List the gear most used in public loadouts
+//	@Description	Get catalog gear ranked by the number of public loadouts it is packed in, overall or in a category and the categories below it. count is the number of public loadouts. The statistics are cached and refreshed every few minutes; Last-Modified tells when they were computed.
+//	@Security		BearerAuth
+//	@Tags			Gear statistics
+//	@Accept			json
+//	@Produce		json
+//	@Param			category	query		int	false	"Gear category, including the categories below it"
+//	@Param			limit		query		int	false	"Maximum number of gear"	default(10)	maximum(100)
+//	@Success		200			{array}		models.GearRanking
+//	@Failure		default		{object}	models.Error
+//	@Router			/api/v1/gear/stats/used [get]
+func ListMostUsedGear(c *gin.Context) {
+	listGearStats(c, "most used gear", (*utils.GearStats).MostUsed, rankedGear)
+}
+
+// ListRecentlyAddedGear lists the gear most recently added to the catalog
+//
+//	@Summary		List recently added gear
+//	@Description	Get the gear most recently added to the catalog, newest first, overall or in a category and the categories below it. Approved submissions count from their approval. Gear added before addition times were recorded comes last, without added_at. The list is cached and refreshed every few minutes; Last-Modified tells when it was computed.
+//	@Security		BearerAuth
+//	@Tags			Gear statistics
+//	@Accept			json
+//	@Produce		json
+//	@Param			category	query		int	false	"Gear category, including the categories below it"
+//	@Param			limit		query		int	false	"Maximum number of gear"	default(10)	maximum(100)
+//	@Success		200			{array}		models.GearRecentlyAdded
+//	@Failure		default		{object}	models.Error
+//	@Router			/api/v1/gear/stats/recent [get]
+func ListRecentlyAddedGear(c *gin.Context) {
+	listGearStats(c, "recently added gear", (*utils.GearStats).RecentlyAdded, recentGear)
+}
+
+func rankedGear(ranking *models.GearRanking) *models.GearListItem {
+	return &ranking.Gear
+}
+
+func recentGear(added *models.GearRecentlyAdded) *models.GearListItem {
+	return &added.Gear
+}
+
+// listGearStats responds with one of the cached gear statistics lists.
+func listGearStats[item any](c *gin.Context, name string, list func(*utils.GearStats, *sql.DB, *int64, int) ([]item, error), gear func(*item) *models.GearListItem) {
+	c.Header("Content-Type", "application/json")
+
+	log := c.MustGet("logger").(*zap.SugaredLogger)
+	db := c.MustGet("db").(*sql.DB)
+	stats := c.MustGet("gear_stats").(*utils.GearStats)
+
+	limit, category, ok := gearRankingParams(c, defaultGearStatsLimit, utils.MaxGearStatsLimit)
+	if !ok {
+		return
+	}
+
+	items, err := list(stats, db, category, limit)
+	if err != nil {
+		log.Errorf("error listing %s: %#v", name, err)
+		c.JSON(http.StatusInternalServerError, models.Error{Error: err.Error()})
+		return
+	}
+
+	pointers := make([]*models.GearListItem, len(items))
+	for i := range items {
+		pointers[i] = gear(&items[i])
+		resolveImageURLs(c, pointers[i])
+	}
+	if !localizeItems(c, log, db, pointers...) {
+		return
+	}
+
+	c.Header("Last-Modified", stats.RefreshedAt().UTC().Format(http.TimeFormat))
+	c.JSON(http.StatusOK, items)
+}
+
+// gearRankingParams parses the limit and category query parameters of the gear rankings.
+func gearRankingParams(c *gin.Context, defaultLimit int, maxLimit int) (int, *int64, bool) {
+	limit := defaultLimit
+	if limitQuery := c.Query("limit"); limitQuery != "" {
+		limitInt, err := strconv.Atoi(limitQuery)
+		if err != nil || limitInt <= 0 || limitInt > maxLimit {
+			c.JSON(http.StatusBadRequest, models.Error{Error: fmt.Sprintf("limit must be between 1 and %d", maxLimit)})
+			return 0, nil, false
+		}
+		limit = limitInt
+	}
+
+	var category *int64
+	if categoryQuery := c.Query("category"); categoryQuery != "" {
+		categoryID, err := strconv.ParseInt(categoryQuery, 10, 64)
+		if err != nil {
+			c.JSON(http.StatusBadRequest, models.Error{Error: "invalid category ID"})
+			return 0, nil, false
+		}
+		category = &categoryID
+	}
+
+	return limit, category, true
+}
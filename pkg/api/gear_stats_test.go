@@ -0,0 +1,119 @@
+package endpoints
+
+import (
+	"encoding/json"
+	"net/http"
+	"testing"
+
+	models "github.com/Sea-Shell/gogear-api/pkg/models"
+	utils "github.com/Sea-Shell/gogear-api/pkg/utils"
+
+	"github.com/gin-gonic/gin"
+)
+
+func TestGearStats(t *testing.T) {
+	db, router := setupCatalogTest(t, 1)
+	seedCatalog(t, db)
+	seedUser(t, db, 2)
+	seedUser(t, db, 3)
+	if _, err := db.Exec(`INSERT INTO gear_category (categoryId, categoryTopCategoryId, categoryParentId, categoryName) VALUES (4, 1, 1, 'Tunnel tents')`); err != nil {
+		t.Fatal(err)
+	}
+	seedCatalogGear(t, db, 1, 1, "Nallo", 2400)
+	seedCatalogGear(t, db, 2, 4, "Keron", 3500)
+	seedCatalogGear(t, db, 3, 2, "Tarp 10", 700)
+	seedCatalogGear(t, db, 4, 1, "Pending tent", 1500)
+	for _, statement := range []string{
+		`UPDATE gear SET gearStatus = 0 WHERE gearId = 4`,
+		`UPDATE gear SET gearAddedAt = '2020-01-01T00:00:00.000Z' WHERE gearId = 1`,
+		`UPDATE gear SET gearAddedAt = NULL WHERE gearId = 3`,
+		`INSERT INTO user_gear_registrations (gearId, userId) VALUES (2, 1), (2, 2), (2, 3), (3, 1), (3, 2), (1, 3), (4, 1), (4, 2), (4, 3)`,
+	} {
+		if _, err := db.Exec(statement); err != nil {
+			t.Fatal(err)
+		}
+	}
+	public := seedLoadout(t, db, 1, true, "public")
+	other := seedLoadout(t, db, 2, true, "other")
+	private := seedLoadout(t, db, 3, false, "private")
+	seedLoadoutItem(t, db, public, 1)
+	seedLoadoutItem(t, db, public, 3)
+	seedLoadoutItem(t, db, other, 1)
+	seedLoadoutItem(t, db, private, 3)
+	seedLoadoutItem(t, db, private, 3)
+
+	stats := utils.NewGearStats()
+	v1 := router.Group("/api/v1", testAuthMiddleware(1), func(c *gin.Context) {
+		c.Set("gear_stats", stats)
+		c.Next()
+	})
+	v1.GET("/gear/stats/owned", ListMostOwnedGear)
+	v1.GET("/gear/stats/used", ListMostUsedGear)
+	v1.GET("/gear/stats/recent", ListRecentlyAddedGear)
+
+	rankings := func(path string) []models.GearRanking {
+		t.Helper()
+		w := authRequest(t, router, http.MethodGet, path, "")
+		var items []models.GearRanking
+		if err := json.Unmarshal(w.Body.Bytes(), &items); err != nil || w.Code != http.StatusOK {
+			t.Fatalf("%s: %d %s", path, w.Code, w.Body.String())
+		}
+		return items
+	}
+	gearIDs := func(items []models.GearRanking) []int64 {
+		ids := make([]int64, len(items))
+		for i, item := range items {
+			ids[i] = item.Gear.GearID
+		}
+		return ids
+	}
+
+	owned := rankings("/api/v1/gear/stats/owned")
+	if ids := gearIDs(owned); len(ids) != 3 || ids[0] != 2 || ids[1] != 3 || ids[2] != 1 {
+		t.Fatalf("ListMostOwnedGear: expected gear 2, 3, 1 without the pending gear, got %v", ids)
+	}
+	if owned[0].Rank != 1 || owned[0].Count != 3 || owned[0].Gear.CategoryName != "Tunnel tents" {
+		t.Errorf("ListMostOwnedGear: unexpected first ranking %+v", owned[0])
+	}
+	if ids := gearIDs(rankings("/api/v1/gear/stats/owned?category=1")); len(ids) != 2 || ids[0] != 2 || ids[1] != 1 {
+		t.Errorf("ListMostOwnedGear: expected the tents including the subcategory, got %v", ids)
+	}
+	if ids := gearIDs(rankings("/api/v1/gear/stats/owned?limit=1")); len(ids) != 1 {
+		t.Errorf("ListMostOwnedGear: expected the limit applied, got %v", ids)
+	}
+
+	used := rankings("/api/v1/gear/stats/used")
+	if ids := gearIDs(used); len(ids) != 2 || ids[0] != 1 || used[0].Count != 2 || used[1].Count != 1 {
+		t.Errorf("ListMostUsedGear: expected public loadouts counted only, got %+v", used)
+	}
+
+	w := authRequest(t, router, http.MethodGet, "/api/v1/gear/stats/recent", "")
+	var recent []models.GearRecentlyAdded
+	if err := json.Unmarshal(w.Body.Bytes(), &recent); err != nil || w.Code != http.StatusOK {
+		t.Fatalf("ListRecentlyAddedGear: %d %s", w.Code, w.Body.String())
+	}
+	if len(recent) != 3 || recent[0].Gear.GearID != 2 || recent[1].Gear.GearID != 1 || recent[2].Gear.GearID != 3 || recent[2].AddedAt != nil {
+		t.Errorf("ListRecentlyAddedGear: expected gear 2, 1 and then 3 without a time, got %+v", recent)
+	}
+	if w.Header().Get("Last-Modified") == "" {
+		t.Error("ListRecentlyAddedGear: expected Last-Modified to tell when the statistics were computed")
+	}
+
+	// The statistics are cached, so new registrations show up after the next refresh.
+	if _, err := db.Exec(`INSERT INTO user_gear_registrations (gearId, userId) VALUES (1, 1), (1, 2)`); err != nil {
+		t.Fatal(err)
+	}
+	if ids := gearIDs(rankings("/api/v1/gear/stats/owned")); ids[0] != 2 {
+		t.Errorf("ListMostOwnedGear: expected the cached ranking, got %v", ids)
+	}
+
+	for path, want := range map[string]int{
+		"/api/v1/gear/stats/owned?limit=0":     http.StatusBadRequest,
+		"/api/v1/gear/stats/used?limit=101":    http.StatusBadRequest,
+		"/api/v1/gear/stats/recent?category=x": http.StatusBadRequest,
+	} {
+		if w := authRequest(t, router, http.MethodGet, path, ""); w.Code != want {
+			t.Errorf("%s: expected %d, got %d", path, want, w.Code)
+		}
+	}
+}
@@ -0,0 +1,439 @@
+package endpoints
+
+import (
+	"database/sql"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"strconv"
+	"strings"
+
+	models "github.com/Sea-Shell/gogear-api/pkg/models"
+	utils "github.com/Sea-Shell/gogear-api/pkg/utils"
+
+	gin "github.com/gin-gonic/gin"
+	sqlite3 "github.com/mattn/go-sqlite3"
+	zap "go.uber.org/zap"
+)
+
+// ListGearVariants lists the size variants of a piece of gear.
+//
+//	@Summary		List gear variants
+//	@Description	List the size variants of a piece of gear, lightest first
+//	@Security		BearerAuth
+//	@Tags			Gear
+//	@Accept			json
+//	@Produce		json
+//	@Param			gear	path		int		true	"Gear ID"
+//	@Param			units	query		string	false	"Also give measurements in metric or imperial units, overriding the user's preference"
+//	@Success		200		{array}		models.GearVariant
+//	@Failure		400		{object}	models.Error
+//	@Failure		404		{object}	models.Error
+//	@Failure		500		{object}	models.Error
+//	@Router			/api/v1/gear/{gear}/variant/list [get]
+func ListGearVariants(c *gin.Context) {
+	log := c.MustGet("logger").(*zap.SugaredLogger)
+	db := c.MustGet("db").(*sql.DB)
+
+	gearID, ok := gearVariantGear(c, log, db)
+	if !ok {
+		return
+	}
+
+	variants, err := GearVariantsByGear(db, gearID)
+	if err != nil {
+		log.Errorf("error listing gear variants: %#v", err)
+		c.JSON(http.StatusInternalServerError, models.Error{Error: err.Error()})
+		return
+	}
+
+	measured := make([]*models.GearVariant, len(variants))
+	for i := range variants {
+		measured[i] = &variants[i]
+	}
+	if !measureItems(c, log, db, measured...) {
+		return
+	}
+
+	c.JSON(http.StatusOK, variants)
+}
+
+// GetGearVariant gets one size variant of a piece of gear.
+//
+//	@Summary		Get gear variant
+//	@Description	Get one size variant of a piece of gear
+//	@Security		BearerAuth
+//	@Tags			Gear
+//	@Accept			json
+//	@Produce		json
+//	@Param			gear	path		int		true	"Gear ID"
+//	@Param			variant	path		int		true	"Variant ID"
+//	@Param			units	query		string	false	"Also give measurements in metric or imperial units, overriding the user's preference"
+//	@Success		200		{object}	models.GearVariant
+//	@Failure		400		{object}	models.Error
+//	@Failure		404		{object}	models.Error
+//	@Router			/api/v1/gear/{gear}/variant/{variant}/get [get]
+func GetGearVariant(c *gin.Context) {
+	log := c.MustGet("logger").(*zap.SugaredLogger)
+	db := c.MustGet("db").(*sql.DB)
+
+	gearID, ok := gearVariantGear(c, log, db)
+	if !ok {
+		return
+	}
+
+	variant, ok := gearVariantFromRoute(c, log, db, gearID)
+	if !ok {
+		return
+	}
+
+	if !measureItems(c, log, db, variant) {
+		return
+	}
+
+	c.JSON(http.StatusOK, variant)
+}
+
+// InsertGearVariant adds a size variant to a piece of gear.
+//
+//	@Summary		Insert gear variant
+//	@Description	Add a size variant to a piece of gear. Variant names are unique per gear. Weights are in grams and dimensions in millimetres, or given as {"value": 2.1, "unit": "kg"}. Requires a JWT issued with the admin audience.
+//	@Security		BearerAuth
+//	@Tags			Gear
+//	@Accept			json
+//	@Produce		json
+//	@Param			gear	path		int						true	"Gear ID"
+//	@Param			body	body		models.GearVariantNoID	true	"Variant data"
+//	@Success		201		{object}	models.GearVariant
+//	@Failure		400		{object}	models.Error
+//	@Failure		403		{object}	models.Error
+//	@Failure		404		{object}	models.Error
+//	@Failure		409		{object}	models.Error
+//	@Failure		500		{object}	models.Error
+//	@Router			/api/v1/gear/{gear}/variant/insert [put]
+func InsertGearVariant(c *gin.Context) {
+	log := c.MustGet("logger").(*zap.SugaredLogger)
+	db := c.MustGet("db").(*sql.DB)
+
+	isAdmin, _ := c.Get("user_is_admin")
+	if adminFlag, ok := isAdmin.(bool); !ok || !adminFlag {
+		log.Warn("unauthorized gear variant insert attempt without admin privileges")
+		c.AbortWithStatusJSON(http.StatusForbidden, models.Error{Error: "admin privileges required"})
+		return
+	}
+
+	gearID, ok := gearVariantGear(c, log, db)
+	if !ok {
+		return
+	}
+
+	input, ok := gearVariantBody(c, log)
+	if !ok {
+		return
+	}
+
+	body, err := json.Marshal(models.GearVariant{
+		GearID:        gearID,
+		VariantName:   input.VariantName,
+		VariantWeight: input.VariantWeight,
+		VariantHeight: input.VariantHeight,
+		VariantLength: input.VariantLength,
+		VariantWidth:  input.VariantWidth,
+	})
+	if err != nil {
+		log.Errorf("error marshaling variant: %#v", err)
+		c.JSON(http.StatusInternalServerError, models.Error{Error: err.Error()})
+		return
+	}
+
+	createdObject, err := utils.GenericInsert[models.GearVariant]("gear_variants", body, db)
+	if err != nil {
+		respondGearVariantWriteError(c, log, err)
+		return
+	}
+
+	c.JSON(http.StatusCreated, createdObject)
+}
+
+// UpdateGearVariant updates a size variant and the weight of loadouts using it.
+//
+//	@Summary		Update gear variant
+//	@Description	Update a size variant of a piece of gear. Loadouts using the variant are reweighed. Requires a JWT issued with the admin audience.
+//	@Security		BearerAuth
+//	@Tags			Gear
+//	@Accept			json
+//	@Produce		json
+//	@Param			gear	path		int						true	"Gear ID"
+//	@Param			variant	path		int						true	"Variant ID"
+//	@Param			body	body		models.GearVariantNoID	true	"Variant data"
+//	@Success		200		{object}	models.Status
+//	@Failure		400		{object}	models.Error
+//	@Failure		403		{object}	models.Error
+//	@Failure		404		{object}	models.Error
+//	@Failure		409		{object}	models.Error
+//	@Failure		500		{object}	models.Error
+//	@Router			/api/v1/gear/{gear}/variant/{variant}/update [post]
+func UpdateGearVariant(c *gin.Context) {
+	log := c.MustGet("logger").(*zap.SugaredLogger)
+	db := c.MustGet("db").(*sql.DB)
+
+	isAdmin, _ := c.Get("user_is_admin")
+	if adminFlag, ok := isAdmin.(bool); !ok || !adminFlag {
+		log.Warn("unauthorized gear variant update attempt without admin privileges")
+		c.AbortWithStatusJSON(http.StatusForbidden, models.Error{Error: "admin privileges required"})
+		return
+	}
+
+	gearID, ok := gearVariantGear(c, log, db)
+	if !ok {
+		return
+	}
+
+	existing, ok := gearVariantFromRoute(c, log, db, gearID)
+	if !ok {
+		return
+	}
+
+	input, ok := gearVariantBody(c, log)
+	if !ok {
+		return
+	}
+
+	body, err := json.Marshal(models.GearVariant{
+		VariantID:     existing.VariantID,
+		GearID:        gearID,
+		VariantName:   input.VariantName,
+		VariantWeight: input.VariantWeight,
+		VariantHeight: input.VariantHeight,
+		VariantLength: input.VariantLength,
+		VariantWidth:  input.VariantWidth,
+	})
+	if err != nil {
+		log.Errorf("error marshaling variant: %#v", err)
+		c.JSON(http.StatusInternalServerError, models.Error{Error: err.Error()})
+		return
+	}
+
+	if err := utils.GenericUpdate[models.GearVariant]("gear_variants", body, db); err != nil {
+		respondGearVariantWriteError(c, log, err)
+		return
+	}
+
+	if err := LoadoutRecalculateWeightForVariant(db, *existing.VariantID); err != nil {
+		log.Errorf("error recalculating weight: %#v", err)
+	}
+
+	c.JSON(http.StatusOK, models.Status{Status: "success"})
+}
+
+// DeleteGearVariant removes a size variant. Registrations and loadout items using it
+// fall back to the gear's own weight and dimensions.
+//
+//	@Summary		Delete gear variant
+//	@Description	Remove a size variant of a piece of gear. Registrations and loadout items using it fall back to the gear itself, and loadouts are reweighed. Requires a JWT issued with the admin audience.
+//	@Security		BearerAuth
+//	@Tags			Gear
+//	@Accept			json
+//	@Produce		json
+//	@Param			gear	path		int	true	"Gear ID"
+//	@Param			variant	path		int	true	"Variant ID"
+//	@Success		200		{object}	models.Status
+//	@Failure		400		{object}	models.Error
+//	@Failure		403		{object}	models.Error
+//	@Failure		404		{object}	models.Error
+//	@Failure		500		{object}	models.Error
+//	@Router			/api/v1/gear/{gear}/variant/{variant}/delete [delete]
+func DeleteGearVariant(c *gin.Context) {
+	log := c.MustGet("logger").(*zap.SugaredLogger)
+	db := c.MustGet("db").(*sql.DB)
+
+	isAdmin, _ := c.Get("user_is_admin")
+	if adminFlag, ok := isAdmin.(bool); !ok || !adminFlag {
+		log.Warn("unauthorized gear variant delete attempt without admin privileges")
+		c.AbortWithStatusJSON(http.StatusForbidden, models.Error{Error: "admin privileges required"})
+		return
+	}
+
+	gearID, ok := gearVariantGear(c, log, db)
+	if !ok {
+		return
+	}
+
+	existing, ok := gearVariantFromRoute(c, log, db, gearID)
+	if !ok {
+		return
+	}
+
+	// The delete trigger clears the variant from loadout items, so find them first.
+	loadoutIDs, err := loadoutsUsingVariant(db, *existing.VariantID)
+	if err != nil {
+		log.Errorf("error finding loadouts using variant: %#v", err)
+		c.JSON(http.StatusInternalServerError, models.Error{Error: err.Error()})
+		return
+	}
+
+	if _, err := utils.GenericDelete[models.GearVariant]("gear_variants", int(*existing.VariantID), db); err != nil {
+		log.Errorf("error deleting gear variant: %#v", err)
+		c.JSON(http.StatusInternalServerError, models.Error{Error: err.Error()})
+		return
+	}
+
+	for _, loadoutID := range loadoutIDs {
+		if err := LoadoutRecalculateWeight(db, loadoutID); err != nil {
+			log.Errorf("error recalculating weight: %#v", err)
+		}
+	}
+
+	log.Infof("Deleted variant %s of gear %d", existing.VariantName, gearID)
+	c.JSON(http.StatusOK, models.Status{Status: "success"})
+}
+
+// gearVariantGear parses the gear route parameter, following merge redirects, and checks
+// the gear exists, responding with an error when it does not.
+func gearVariantGear(c *gin.Context, log *zap.SugaredLogger, db *sql.DB) (int64, bool) {
+	gearID, err := strconv.ParseInt(c.Param("gear"), 10, 64)
+	if err != nil {
+		log.Errorf("invalid gear ID: %#v", err)
+		c.JSON(http.StatusBadRequest, models.Error{Error: "invalid gear ID"})
+		return 0, false
+	}
+
+	gearID, ok := resolveGearRedirect(c, log, db, gearID)
+	if !ok {
+		return 0, false
+	}
+
+	var exists bool
+	if err := db.QueryRow("SELECT EXISTS (SELECT 1 FROM gear WHERE gearId = ?)", gearID).Scan(&exists); err != nil {
+		log.Errorf("error looking up gear: %#v", err)
+		c.JSON(http.StatusInternalServerError, models.Error{Error: err.Error()})
+		return 0, false
+	}
+	if !exists {
+		c.JSON(http.StatusNotFound, models.Error{Error: "Gear not found"})
+		return 0, false
+	}
+
+	return gearID, true
+}
+
+// gearVariantFromRoute loads the variant route parameter, which must belong to gearID.
+func gearVariantFromRoute(c *gin.Context, log *zap.SugaredLogger, db *sql.DB, gearID int64) (*models.GearVariant, bool) {
+	variantID, err := strconv.Atoi(c.Param("variant"))
+	if err != nil {
+		log.Errorf("invalid variant ID: %#v", err)
+		c.JSON(http.StatusBadRequest, models.Error{Error: "invalid variant ID"})
+		return nil, false
+	}
+
+	variant, err := utils.GenericGet[models.GearVariant]("gear_variants", variantID, nil, db)
+	if err != nil && !errors.Is(err, sql.ErrNoRows) {
+		log.Errorf("error getting gear variant: %#v", err)
+		c.JSON(http.StatusInternalServerError, models.Error{Error: err.Error()})
+		return nil, false
+	}
+	if err != nil || variant.GearID != gearID {
+		c.JSON(http.StatusNotFound, models.Error{Error: "Variant not found"})
+		return nil, false
+	}
+
+	return variant, true
+}
+
+// gearVariantBody reads and validates a variant request body.
+func gearVariantBody(c *gin.Context, log *zap.SugaredLogger) (*models.GearVariantNoID, bool) {
+	data, err := io.ReadAll(c.Request.Body)
+	if err != nil {
+		log.Errorf("error reading body: %#v", err)
+		c.JSON(http.StatusInternalServerError, models.Error{Error: err.Error()})
+		return nil, false
+	}
+
+	data, ok := normalizeMeasurements[models.GearVariantNoID](c, log, data)
+	if !ok {
+		return nil, false
+	}
+
+	var input models.GearVariantNoID
+	if err := json.Unmarshal(data, &input); err != nil {
+		log.Errorf("error unmarshaling body: %#v", err)
+		c.JSON(http.StatusBadRequest, models.Error{Error: err.Error()})
+		return nil, false
+	}
+
+	input.VariantName = strings.TrimSpace(input.VariantName)
+	if input.VariantName == "" {
+		c.JSON(http.StatusBadRequest, models.Error{Error: "variant_name is required"})
+		return nil, false
+	}
+	if input.VariantWeight < 0 || input.VariantHeight < 0 || input.VariantLength < 0 || input.VariantWidth < 0 {
+		c.JSON(http.StatusBadRequest, models.Error{Error: "variant weight and dimensions cannot be negative"})
+		return nil, false
+	}
+
+	return &input, true
+}
+
+// respondGearVariantWriteError reports a duplicate variant name as a conflict.
+func respondGearVariantWriteError(c *gin.Context, log *zap.SugaredLogger, err error) {
+	var sqliteErr sqlite3.Error
+	if errors.As(err, &sqliteErr) && sqliteErr.ExtendedCode == sqlite3.ErrConstraintUnique {
+		c.JSON(http.StatusConflict, models.Error{Error: "gear already has a variant with that name"})
+		return
+	}
+
+	log.Errorf("error writing gear variant: %#v", err)
+	c.JSON(http.StatusInternalServerError, models.Error{Error: err.Error()})
+}
+
+// respondGearVariantMismatch rejects a variant that is not a variant of the chosen gear.
+// It reports whether a response was sent.
+func respondGearVariantMismatch(c *gin.Context, log *zap.SugaredLogger, db *sql.DB, variantID *int64, gearID int64) bool {
+	matches, err := GearVariantMatches(db, variantID, gearID)
+	if err != nil {
+		log.Errorf("error checking gear variant: %#v", err)
+		c.JSON(http.StatusInternalServerError, models.Error{Error: err.Error()})
+		return true
+	}
+	if !matches {
+		c.JSON(http.StatusBadRequest, models.Error{Error: "variant_id is not a variant of this gear"})
+		return true
+	}
+	return false
+}
+
+// GearVariantsByGear returns the variants of a piece of gear, lightest first.
+func GearVariantsByGear(db *sql.DB, gearID int64) ([]models.GearVariant, error) {
+	const query = `SELECT variantId, gearId, variantName, variantWeight, variantHeight, variantLength, variantWidth
+        FROM gear_variants WHERE gearId = ? ORDER BY variantWeight, variantId`
+	rows, err := db.Query(query, gearID)
+	if err != nil {
+		return nil, fmt.Errorf("query gear variants: %w", err)
+	}
+	defer rows.Close()
+
+	variants, err := utils.ScanRows[models.GearVariant](rows)
+	if err != nil {
+		return nil, fmt.Errorf("scan gear variants: %w", err)
+	}
+	if variants == nil {
+		variants = []models.GearVariant{}
+	}
+	return variants, nil
+}
+
+// GearVariantMatches reports whether variantID is unset or is a variant of gearID.
+func GearVariantMatches(db *sql.DB, variantID *int64, gearID int64) (bool, error) {
+	if variantID == nil {
+		return true, nil
+	}
+
+	var matches bool
+	err := db.QueryRow("SELECT EXISTS (SELECT 1 FROM gear_variants WHERE variantId = ? AND gearId = ?)", *variantID, gearID).Scan(&matches)
+	if err != nil {
+		return false, fmt.Errorf("check gear variant %d: %w", *variantID, err)
+	}
+	return matches, nil
+}
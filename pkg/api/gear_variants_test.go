@@ -0,0 +1,242 @@
+package endpoints
+
+import (
+	"database/sql"
+	"encoding/json"
+	"net/http"
+	"testing"
+
+	models "github.com/Sea-Shell/gogear-api/pkg/models"
+
+	"github.com/gin-gonic/gin"
+)
+
+func setupVariantTest(t *testing.T, isAdmin *bool) (*gin.Engine, *sql.DB) {
+	t.Helper()
+	db, _, logger := setupTest(t)
+	seedCatalog(t, db)
+	seedCatalogGear(t, db, 1, 1, "Nallo", 100)
+	seedCatalogGear(t, db, 2, 1, "Akto", 100)
+
+	router := routerWithUser(db, logger, 1)
+	router.Use(func(c *gin.Context) {
+		c.Set("user_is_admin", *isAdmin)
+		c.Next()
+	})
+	gearGroup := router.Group("/api/v1/gear")
+	gearGroup.Use(testAuthMiddleware(1))
+	gearGroup.GET("/:gear/variant/list", ListGearVariants)
+	gearGroup.PUT("/:gear/variant/insert", InsertGearVariant)
+	gearGroup.GET("/:gear/variant/:variant/get", GetGearVariant)
+	gearGroup.POST("/:gear/variant/:variant/update", UpdateGearVariant)
+	gearGroup.DELETE("/:gear/variant/:variant/delete", DeleteGearVariant)
+	gearGroup.DELETE("/:gear/delete", DeleteGear)
+
+	usergearGroup := router.Group("/api/v1/usergear")
+	usergearGroup.Use(testAuthMiddleware(1))
+	usergearGroup.PUT("/insert", InsertUserGear)
+	usergearGroup.GET("/registration/:usergear/get", GetUserGear)
+	usergearGroup.POST("/registration/:usergear/update", UpdateUserGear)
+
+	return router, db
+}
+
+func TestGearVariants_AdminCRUD(t *testing.T) {
+	isAdmin := false
+	router, _ := setupVariantTest(t, &isAdmin)
+
+	insert := `{"variant_name":"3P","variant_weight":2100,"variant_length":230}`
+	if w := authRequest(t, router, http.MethodPut, "/api/v1/gear/1/variant/insert", insert); w.Code != http.StatusForbidden {
+		t.Fatalf("InsertGearVariant: expected 403 for non-admin, got %d", w.Code)
+	}
+
+	isAdmin = true
+	w := authRequest(t, router, http.MethodPut, "/api/v1/gear/1/variant/insert", insert)
+	if w.Code != http.StatusCreated {
+		t.Fatalf("InsertGearVariant: expected 201, got %d — body: %s", w.Code, w.Body.String())
+	}
+	var created models.GearVariant
+	if err := json.Unmarshal(w.Body.Bytes(), &created); err != nil || created.VariantID == nil || created.GearID != 1 || created.VariantWeight != 2100 {
+		t.Fatalf("InsertGearVariant: created %+v, %v", created, err)
+	}
+
+	if w := authRequest(t, router, http.MethodPut, "/api/v1/gear/1/variant/insert", `{"variant_name":"2P","variant_weight":1800}`); w.Code != http.StatusCreated {
+		t.Fatalf("InsertGearVariant 2P: expected 201, got %d", w.Code)
+	}
+	if w := authRequest(t, router, http.MethodPut, "/api/v1/gear/1/variant/insert", insert); w.Code != http.StatusConflict {
+		t.Errorf("InsertGearVariant: expected 409 for duplicate name, got %d", w.Code)
+	}
+	if w := authRequest(t, router, http.MethodPut, "/api/v1/gear/1/variant/insert", `{"variant_name":" "}`); w.Code != http.StatusBadRequest {
+		t.Errorf("InsertGearVariant: expected 400 for blank name, got %d", w.Code)
+	}
+	if w := authRequest(t, router, http.MethodPut, "/api/v1/gear/99/variant/insert", insert); w.Code != http.StatusNotFound {
+		t.Errorf("InsertGearVariant: expected 404 for unknown gear, got %d", w.Code)
+	}
+
+	w = authRequest(t, router, http.MethodGet, "/api/v1/gear/1/variant/list", "")
+	var variants []models.GearVariant
+	if err := json.Unmarshal(w.Body.Bytes(), &variants); err != nil || len(variants) != 2 || variants[0].VariantName != "2P" {
+		t.Fatalf("ListGearVariants: got %s, %v", w.Body.String(), err)
+	}
+
+	variantURL := "/api/v1/gear/1/variant/" + itoa64(*created.VariantID)
+	if w := authRequest(t, router, http.MethodGet, "/api/v1/gear/2/variant/"+itoa64(*created.VariantID)+"/get", ""); w.Code != http.StatusNotFound {
+		t.Errorf("GetGearVariant: expected 404 for another gear's variant, got %d", w.Code)
+	}
+	if w := authRequest(t, router, http.MethodPost, variantURL+"/update", `{"variant_name":"2P","variant_weight":1}`); w.Code != http.StatusConflict {
+		t.Errorf("UpdateGearVariant: expected 409 when renaming onto another variant, got %d", w.Code)
+	}
+	if w := authRequest(t, router, http.MethodPost, variantURL+"/update", `{"variant_name":"3P","variant_weight":2000}`); w.Code != http.StatusOK {
+		t.Errorf("UpdateGearVariant: expected 200, got %d — body: %s", w.Code, w.Body.String())
+	}
+
+	w = authRequest(t, router, http.MethodGet, variantURL+"/get", "")
+	var fetched models.GearVariant
+	if err := json.Unmarshal(w.Body.Bytes(), &fetched); err != nil || fetched.VariantWeight != 2000 || fetched.VariantLength != 0 {
+		t.Errorf("GetGearVariant: got %s, %v", w.Body.String(), err)
+	}
+
+	if w := authRequest(t, router, http.MethodDelete, variantURL+"/delete", ""); w.Code != http.StatusOK {
+		t.Errorf("DeleteGearVariant: expected 200, got %d", w.Code)
+	}
+	if w := authRequest(t, router, http.MethodGet, variantURL+"/get", ""); w.Code != http.StatusNotFound {
+		t.Errorf("GetGearVariant: expected 404 after delete, got %d", w.Code)
+	}
+}
+
+func TestGearVariants_LoadoutWeight(t *testing.T) {
+	isAdmin := true
+	router, _ := setupVariantTest(t, &isAdmin)
+
+	insertVariant := func(gear, body string) int64 {
+		t.Helper()
+		w := authRequest(t, router, http.MethodPut, "/api/v1/gear/"+gear+"/variant/insert", body)
+		var variant models.GearVariant
+		if err := json.Unmarshal(w.Body.Bytes(), &variant); err != nil || variant.VariantID == nil {
+			t.Fatalf("insert variant: %d %s", w.Code, w.Body.String())
+		}
+		return *variant.VariantID
+	}
+	large := insertVariant("1", `{"variant_name":"L","variant_weight":250}`)
+	otherGear := insertVariant("2", `{"variant_name":"L","variant_weight":900}`)
+
+	w := authRequest(t, router, http.MethodPut, "/api/v1/loadout/insert", `{"loadout_name":"Trip","loadout_slug":"variant-trip"}`)
+	var loadout models.Loadout
+	if err := json.Unmarshal(w.Body.Bytes(), &loadout); err != nil || loadout.LoadoutID == nil {
+		t.Fatalf("insert loadout: %d %s", w.Code, w.Body.String())
+	}
+	loadoutURL := "/api/v1/loadout/" + itoa64(*loadout.LoadoutID)
+
+	totalWeight := func() int64 {
+		t.Helper()
+		w := authRequest(t, router, http.MethodGet, loadoutURL+"/get", "")
+		var current models.Loadout
+		if err := json.Unmarshal(w.Body.Bytes(), &current); err != nil {
+			t.Fatalf("get loadout: %d %s", w.Code, w.Body.String())
+		}
+		return current.TotalWeight
+	}
+
+	if w := authRequest(t, router, http.MethodPut, loadoutURL+"/item/insert", `{"gear_id":1,"quantity":2,"variant_id":`+itoa64(otherGear)+`}`); w.Code != http.StatusBadRequest {
+		t.Errorf("InsertLoadoutItem: expected 400 for another gear's variant, got %d", w.Code)
+	}
+
+	w = authRequest(t, router, http.MethodPut, loadoutURL+"/item/insert", `{"gear_id":1,"quantity":2,"variant_id":`+itoa64(large)+`}`)
+	var item models.LoadoutItem
+	if err := json.Unmarshal(w.Body.Bytes(), &item); err != nil || item.VariantID == nil || *item.VariantID != large {
+		t.Fatalf("InsertLoadoutItem: %d %s", w.Code, w.Body.String())
+	}
+	if got := totalWeight(); got != 500 {
+		t.Errorf("total weight with variant = %d, want 500", got)
+	}
+
+	itemURL := loadoutURL + "/item/" + itoa64(*item.LoadoutItemID)
+	if w := authRequest(t, router, http.MethodPost, itemURL+"/update", `{"quantity":3,"notes":"spare"}`); w.Code != http.StatusOK {
+		t.Fatalf("UpdateLoadoutItem: %d %s", w.Code, w.Body.String())
+	}
+	if got := totalWeight(); got != 750 {
+		t.Errorf("total weight after update keeping variant = %d, want 750", got)
+	}
+
+	if w := authRequest(t, router, http.MethodPost, "/api/v1/gear/1/variant/"+itoa64(large)+"/update", `{"variant_name":"L","variant_weight":300}`); w.Code != http.StatusOK {
+		t.Fatalf("UpdateGearVariant: %d %s", w.Code, w.Body.String())
+	}
+	if got := totalWeight(); got != 900 {
+		t.Errorf("total weight after variant update = %d, want 900", got)
+	}
+
+	if w := authRequest(t, router, http.MethodDelete, "/api/v1/gear/1/variant/"+itoa64(large)+"/delete", ""); w.Code != http.StatusOK {
+		t.Fatalf("DeleteGearVariant: %d %s", w.Code, w.Body.String())
+	}
+	if got := totalWeight(); got != 300 {
+		t.Errorf("total weight after variant delete = %d, want gear weight 300", got)
+	}
+
+	w = authRequest(t, router, http.MethodGet, loadoutURL+"/item/list", "")
+	if items := decodeListPayload(t, w.Body.Bytes()).Items; len(items) != 1 || items[0]["variant_id"] != nil {
+		t.Errorf("ListLoadoutItems after variant delete: %s", w.Body.String())
+	}
+}
+
+func TestGearVariants_UserGearRegistration(t *testing.T) {
+	isAdmin := true
+	router, _ := setupVariantTest(t, &isAdmin)
+
+	w := authRequest(t, router, http.MethodPut, "/api/v1/gear/1/variant/insert", `{"variant_name":"M","variant_weight":120}`)
+	var variant models.GearVariant
+	if err := json.Unmarshal(w.Body.Bytes(), &variant); err != nil || variant.VariantID == nil {
+		t.Fatalf("insert variant: %d %s", w.Code, w.Body.String())
+	}
+	variantID := itoa64(*variant.VariantID)
+
+	if w := authRequest(t, router, http.MethodPut, "/api/v1/usergear/insert", `{"usergear_gear_id":2,"usergear_user_id":1,"variant_id":`+variantID+`}`); w.Code != http.StatusBadRequest {
+		t.Errorf("InsertUserGear: expected 400 for another gear's variant, got %d", w.Code)
+	}
+	if w := authRequest(t, router, http.MethodPut, "/api/v1/usergear/insert", `{"usergear_gear_id":1,"usergear_user_id":1,"variant_id":`+variantID+`}`); w.Code != http.StatusOK {
+		t.Fatalf("InsertUserGear: %d %s", w.Code, w.Body.String())
+	}
+
+	w = authRequest(t, router, http.MethodGet, "/api/v1/usergear/registration/1/get", "")
+	var registration models.UserGear
+	if err := json.Unmarshal(w.Body.Bytes(), &registration); err != nil {
+		t.Fatalf("GetUserGear: %d %s", w.Code, w.Body.String())
+	}
+	if registration.VariantName == nil || *registration.VariantName != "M" || registration.VariantWeight == nil || *registration.VariantWeight != 120 {
+		t.Errorf("GetUserGear: variant not joined: %s", w.Body.String())
+	}
+
+	if w := authRequest(t, router, http.MethodPost, "/api/v1/usergear/registration/1/update", `{"max_container_weight":5000}`); w.Code != http.StatusOK {
+		t.Fatalf("UpdateUserGear: %d %s", w.Code, w.Body.String())
+	}
+	w = authRequest(t, router, http.MethodGet, "/api/v1/usergear/registration/1/get", "")
+	registration = models.UserGear{}
+	if err := json.Unmarshal(w.Body.Bytes(), &registration); err != nil || registration.VariantID == nil {
+		t.Errorf("UpdateUserGear without variant_id should keep the variant: %s", w.Body.String())
+	}
+}
+
+func TestGearVariants_RemovedWithGear(t *testing.T) {
+	isAdmin := true
+	router, db := setupVariantTest(t, &isAdmin)
+
+	w := authRequest(t, router, http.MethodPut, "/api/v1/gear/1/variant/insert", `{"variant_name":"M","variant_weight":120}`)
+	var variant models.GearVariant
+	if err := json.Unmarshal(w.Body.Bytes(), &variant); err != nil || variant.VariantID == nil {
+		t.Fatalf("insert variant: %d %s", w.Code, w.Body.String())
+	}
+	if w := authRequest(t, router, http.MethodPut, "/api/v1/usergear/insert", `{"usergear_gear_id":1,"usergear_user_id":1,"variant_id":`+itoa64(*variant.VariantID)+`}`); w.Code != http.StatusOK {
+		t.Fatalf("InsertUserGear: %d %s", w.Code, w.Body.String())
+	}
+
+	if w := authRequest(t, router, http.MethodDelete, "/api/v1/gear/1/delete", ""); w.Code != http.StatusOK {
+		t.Fatalf("DeleteGear: %d %s", w.Code, w.Body.String())
+	}
+
+	var variants, registered int
+	if err := db.QueryRow("SELECT COUNT(*) FROM gear_variants WHERE gearId = 1").Scan(&variants); err != nil || variants != 0 {
+		t.Errorf("variants left after gear delete = %d, %v", variants, err)
+	}
+	if err := db.QueryRow("SELECT COUNT(*) FROM user_gear_registrations WHERE variantId IS NOT NULL").Scan(&registered); err != nil || registered != 0 {
+		t.Errorf("registrations still pointing at a variant = %d, %v", registered, err)
+	}
+}
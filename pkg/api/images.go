@@ -0,0 +1,480 @@
+package endpoints
+
+import (
+	"bytes"
+	"crypto/rand"
+	"database/sql"
+	"encoding/hex"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"strconv"
+
+	models "github.com/Sea-Shell/gogear-api/pkg/models"
+	utils "github.com/Sea-Shell/gogear-api/pkg/utils"
+
+	gin "github.com/gin-gonic/gin"
+	zap "go.uber.org/zap"
+)
+
+// imageFormField is the multipart form field uploads are read from.
+const imageFormField = "image"
+
+// imageOwner is the catalog gear or user gear registration an image belongs to.
+type imageOwner struct {
+	column    string
+	id        int64
+	keyPrefix string
+}
+
+// ListGearImages lists the images of a piece of gear.
+//
+//	@Summary		List gear images
+//	@Description	List the images of a piece of gear, oldest first. The first image is the one shown as image_url on the gear.
+//	@Security		BearerAuth
+//	@Tags			Gear
+//	@Produce		json
+//	@Param			gear	path		int	true	"Gear ID"
+//	@Success		200		{array}		models.Image
+//	@Failure		400		{object}	models.Error
+//	@Failure		404		{object}	models.Error
+//	@Failure		500		{object}	models.Error
+//	@Router			/api/v1/gear/{gear}/image/list [get]
+func ListGearImages(c *gin.Context) {
+	log := c.MustGet("logger").(*zap.SugaredLogger)
+	db := c.MustGet("db").(*sql.DB)
+
+	gearID, ok := gearVariantGear(c, log, db)
+	if !ok {
+		return
+	}
+
+	listImages(c, log, db, gearImageOwner(gearID))
+}
+
+// UploadGearImage stores an image of a piece of gear and its thumbnail.
+//
+//	@Summary		Upload gear image
+//	@Description	Upload a JPEG, PNG or GIF of a piece of gear as the multipart field image. The type is sniffed from the content and a thumbnail is generated. Uploads are limited to storage.max-upload-bytes, 10 MiB by default. Requires a JWT issued with the admin audience.
+//	@Security		BearerAuth
+//	@Tags			Gear
+//	@Accept			multipart/form-data
+//	@Produce		json
+//	@Param			gear	path		int		true	"Gear ID"
+//	@Param			image	formData	file	true	"Image file"
+//	@Success		201		{object}	models.Image
+//	@Failure		400		{object}	models.Error
+//	@Failure		403		{object}	models.Error
+//	@Failure		404		{object}	models.Error
+//	@Failure		413		{object}	models.Error
+//	@Failure		415		{object}	models.Error
+//	@Failure		500		{object}	models.Error
+//	@Router			/api/v1/gear/{gear}/image/upload [put]
+func UploadGearImage(c *gin.Context) {
+	log := c.MustGet("logger").(*zap.SugaredLogger)
+	db := c.MustGet("db").(*sql.DB)
+
+	isAdmin, _ := c.Get("user_is_admin")
+	if adminFlag, ok := isAdmin.(bool); !ok || !adminFlag {
+		log.Warn("unauthorized gear image upload attempt without admin privileges")
+		c.AbortWithStatusJSON(http.StatusForbidden, models.Error{Error: "admin privileges required"})
+		return
+	}
+
+	gearID, ok := gearVariantGear(c, log, db)
+	if !ok {
+		return
+	}
+
+	uploadImage(c, log, db, gearImageOwner(gearID))
+}
+
+// DeleteGearImage deletes an image of a piece of gear.
+//
+//	@Summary		Delete gear image
+//	@Description	Delete an image of a piece of gear and its files. Requires a JWT issued with the admin audience.
+//	@Security		BearerAuth
+//	@Tags			Gear
+//	@Produce		json
+//	@Param			gear	path		int	true	"Gear ID"
+//	@Param			image	path		int	true	"Image ID"
+//	@Success		200		{object}	models.Status
+//	@Failure		400		{object}	models.Error
+//	@Failure		403		{object}	models.Error
+//	@Failure		404		{object}	models.Error
+//	@Failure		500		{object}	models.Error
+//	@Router			/api/v1/gear/{gear}/image/{image}/delete [delete]
+func DeleteGearImage(c *gin.Context) {
+	log := c.MustGet("logger").(*zap.SugaredLogger)
+	db := c.MustGet("db").(*sql.DB)
+
+	isAdmin, _ := c.Get("user_is_admin")
+	if adminFlag, ok := isAdmin.(bool); !ok || !adminFlag {
+		log.Warn("unauthorized gear image delete attempt without admin privileges")
+		c.AbortWithStatusJSON(http.StatusForbidden, models.Error{Error: "admin privileges required"})
+		return
+	}
+
+	gearID, ok := gearVariantGear(c, log, db)
+	if !ok {
+		return
+	}
+
+	deleteImage(c, log, db, gearImageOwner(gearID))
+}
+
+// ListUserGearImages lists the images of a user gear registration.
+//
+//	@Summary		List user gear images
+//	@Description	List the photos of a registered item, oldest first. The first image is the one shown as image_url on the registration. Only the owner and admins can list them.
+//	@Security		BearerAuth
+//	@Tags			User gear
+//	@Produce		json
+//	@Param			usergear	path		int	true	"User gear registration ID"
+//	@Success		200			{array}		models.Image
+//	@Failure		400			{object}	models.Error
+//	@Failure		403			{object}	models.Error
+//	@Failure		404			{object}	models.Error
+//	@Failure		500			{object}	models.Error
+//	@Router			/api/v1/usergear/registration/{usergear}/image/list [get]
+func ListUserGearImages(c *gin.Context) {
+	log := c.MustGet("logger").(*zap.SugaredLogger)
+	db := c.MustGet("db").(*sql.DB)
+
+	owner, ok := registrationImageOwner(c, log, db)
+	if !ok {
+		return
+	}
+
+	listImages(c, log, db, owner)
+}
+
+// UploadUserGearImage stores a photo of a registered item and its thumbnail.
+//
+//	@Summary		Upload user gear image
+//	@Description	Upload a JPEG, PNG or GIF of a registered item as the multipart field image. The type is sniffed from the content and a thumbnail is generated. Uploads are limited to storage.max-upload-bytes, 10 MiB by default. Only the registration's owner or an admin may upload.
+//	@Security		BearerAuth
+//	@Tags			User gear
+//	@Accept			multipart/form-data
+//	@Produce		json
+//	@Param			usergear	path		int		true	"User gear registration ID"
+//	@Param			image		formData	file	true	"Image file"
+//	@Success		201			{object}	models.Image
+//	@Failure		400			{object}	models.Error
+//	@Failure		403			{object}	models.Error
+//	@Failure		404			{object}	models.Error
+//	@Failure		413			{object}	models.Error
+//	@Failure		415			{object}	models.Error
+//	@Failure		500			{object}	models.Error
+//	@Router			/api/v1/usergear/registration/{usergear}/image/upload [put]
+func UploadUserGearImage(c *gin.Context) {
+	log := c.MustGet("logger").(*zap.SugaredLogger)
+	db := c.MustGet("db").(*sql.DB)
+
+	owner, ok := registrationImageOwner(c, log, db)
+	if !ok {
+		return
+	}
+
+	uploadImage(c, log, db, owner)
+}
+
+// DeleteUserGearImage deletes a photo of a registered item.
+//
+//	@Summary		Delete user gear image
+//	@Description	Delete a photo of a registered item and its files. Only the registration's owner or an admin may delete.
+//	@Security		BearerAuth
+//	@Tags			User gear
+//	@Produce		json
+//	@Param			usergear	path		int	true	"User gear registration ID"
+//	@Param			image		path		int	true	"Image ID"
+//	@Success		200			{object}	models.Status
+//	@Failure		400			{object}	models.Error
+//	@Failure		403			{object}	models.Error
+//	@Failure		404			{object}	models.Error
+//	@Failure		500			{object}	models.Error
+//	@Router			/api/v1/usergear/registration/{usergear}/image/{image}/delete [delete]
+func DeleteUserGearImage(c *gin.Context) {
+	log := c.MustGet("logger").(*zap.SugaredLogger)
+	db := c.MustGet("db").(*sql.DB)
+
+	owner, ok := registrationImageOwner(c, log, db)
+	if !ok {
+		return
+	}
+
+	deleteImage(c, log, db, owner)
+}
+
+func gearImageOwner(gearID int64) imageOwner {
+	return imageOwner{column: "gearId", id: gearID, keyPrefix: fmt.Sprintf("gear/%d", gearID)}
+}
+
+// registrationImageOwner loads the usergear route parameter. Registration photos are
+// private, so only the registration's owner or an admin gets through.
+func registrationImageOwner(c *gin.Context, log *zap.SugaredLogger, db *sql.DB) (imageOwner, bool) {
+	registrationID, err := strconv.ParseInt(c.Param("usergear"), 10, 64)
+	if err != nil {
+		log.Errorf("invalid registration ID: %#v", err)
+		c.JSON(http.StatusBadRequest, models.Error{Error: "invalid registration ID"})
+		return imageOwner{}, false
+	}
+
+	var userID int64
+	err = db.QueryRow("SELECT userId FROM user_gear_registrations WHERE userGearRegistrationId = ?", registrationID).Scan(&userID)
+	if err == sql.ErrNoRows {
+		c.JSON(http.StatusNotFound, models.Error{Error: "registration not found"})
+		return imageOwner{}, false
+	}
+	if err != nil {
+		log.Errorf("error looking up registration: %#v", err)
+		c.JSON(http.StatusInternalServerError, models.Error{Error: err.Error()})
+		return imageOwner{}, false
+	}
+
+	isAdmin, _ := c.Get("user_is_admin")
+	adminFlag, _ := isAdmin.(bool)
+	if !adminFlag && userID != c.MustGet("user_id_int64").(int64) {
+		log.Warnw("image access attempted on another user's registration", "registration_id", registrationID)
+		c.JSON(http.StatusForbidden, models.Error{Error: "Access denied"})
+		return imageOwner{}, false
+	}
+
+	return imageOwner{
+		column:    "userGearRegistrationId",
+		id:        registrationID,
+		keyPrefix: fmt.Sprintf("usergear/%d", registrationID),
+	}, true
+}
+
+func listImages(c *gin.Context, log *zap.SugaredLogger, db *sql.DB, owner imageOwner) {
+	images, err := ImagesByOwner(db, owner.column, owner.id)
+	if err != nil {
+		log.Errorf("error listing images: %#v", err)
+		c.JSON(http.StatusInternalServerError, models.Error{Error: err.Error()})
+		return
+	}
+
+	pointers := make([]*models.Image, len(images))
+	for i := range images {
+		pointers[i] = &images[i]
+	}
+	resolveImageURLs(c, pointers...)
+
+	c.JSON(http.StatusOK, images)
+}
+
+// uploadImage reads the image form field, checks its size and type, and stores it with a
+// thumbnail for owner.
+func uploadImage(c *gin.Context, log *zap.SugaredLogger, db *sql.DB, owner imageOwner) {
+	storage := c.MustGet("storage").(utils.Storage)
+	limit := uploadLimit(c)
+
+	// Leave room for the multipart framing around the file itself.
+	c.Request.Body = http.MaxBytesReader(c.Writer, c.Request.Body, limit+64<<10)
+	file, _, err := c.Request.FormFile(imageFormField)
+	if err != nil {
+		var tooLarge *http.MaxBytesError
+		if errors.As(err, &tooLarge) {
+			c.JSON(http.StatusRequestEntityTooLarge, models.Error{Error: fmt.Sprintf("image is larger than the %d byte limit", limit)})
+			return
+		}
+		log.Warnf("image upload without an image file: %v", err)
+		c.JSON(http.StatusBadRequest, models.Error{Error: "multipart field image is required"})
+		return
+	}
+	defer file.Close()
+
+	data, err := io.ReadAll(io.LimitReader(file, limit+1))
+	if err != nil {
+		log.Errorf("error reading upload: %#v", err)
+		c.JSON(http.StatusInternalServerError, models.Error{Error: err.Error()})
+		return
+	}
+	if int64(len(data)) > limit {
+		c.JSON(http.StatusRequestEntityTooLarge, models.Error{Error: fmt.Sprintf("image is larger than the %d byte limit", limit)})
+		return
+	}
+
+	processed, err := utils.ProcessImage(data)
+	if err != nil {
+		var imageErr *utils.ImageError
+		if errors.As(err, &imageErr) {
+			log.Warnf("rejected image upload: %s", imageErr.Message)
+			c.JSON(http.StatusUnsupportedMediaType, models.Error{Error: imageErr.Message})
+			return
+		}
+		log.Errorf("error processing image: %#v", err)
+		c.JSON(http.StatusInternalServerError, models.Error{Error: err.Error()})
+		return
+	}
+
+	name, err := randomImageName()
+	if err != nil {
+		log.Errorf("error naming image: %#v", err)
+		c.JSON(http.StatusInternalServerError, models.Error{Error: err.Error()})
+		return
+	}
+	imageKey := owner.keyPrefix + "/" + name + processed.Extension
+	thumbnailKey := owner.keyPrefix + "/" + name + "_thumb" + processed.ThumbnailExtension
+
+	ctx := c.Request.Context()
+	if err := storage.Put(ctx, imageKey, bytes.NewReader(data), processed.ContentType); err != nil {
+		log.Errorf("error storing image: %#v", err)
+		c.JSON(http.StatusInternalServerError, models.Error{Error: err.Error()})
+		return
+	}
+	if err := storage.Put(ctx, thumbnailKey, bytes.NewReader(processed.Thumbnail), processed.ThumbnailContentType); err != nil {
+		log.Errorf("error storing thumbnail: %#v", err)
+		removeImageFiles(c, log, imageKey)
+		c.JSON(http.StatusInternalServerError, models.Error{Error: err.Error()})
+		return
+	}
+
+	result, err := db.Exec("INSERT INTO images ("+owner.column+`, imageKey, thumbnailKey, contentType, imageSize, imageWidth, imageHeight, uploadedBy)
+        VALUES (?, ?, ?, ?, ?, ?, ?, ?)`,
+		owner.id, imageKey, thumbnailKey, processed.ContentType, len(data), processed.Width, processed.Height, c.MustGet("user_id_int64").(int64))
+	if err != nil {
+		log.Errorf("error saving image: %#v", err)
+		removeImageFiles(c, log, imageKey, thumbnailKey)
+		c.JSON(http.StatusInternalServerError, models.Error{Error: err.Error()})
+		return
+	}
+	imageID, err := result.LastInsertId()
+	if err != nil {
+		log.Errorf("error reading image ID: %#v", err)
+		c.JSON(http.StatusInternalServerError, models.Error{Error: err.Error()})
+		return
+	}
+
+	image, err := utils.ScanRow[models.Image](db.QueryRow(imageSelect+" WHERE imageId = ?", imageID))
+	if err != nil {
+		log.Errorf("error loading image: %#v", err)
+		c.JSON(http.StatusInternalServerError, models.Error{Error: err.Error()})
+		return
+	}
+	resolveImageURLs(c, image)
+
+	log.Infow("image uploaded", owner.column, owner.id, "image_id", imageID, "size", len(data))
+	c.JSON(http.StatusCreated, image)
+}
+
+// deleteImage deletes the image route parameter, which must belong to owner, and its files.
+func deleteImage(c *gin.Context, log *zap.SugaredLogger, db *sql.DB, owner imageOwner) {
+	imageID, err := strconv.ParseInt(c.Param("image"), 10, 64)
+	if err != nil {
+		log.Errorf("invalid image ID: %#v", err)
+		c.JSON(http.StatusBadRequest, models.Error{Error: "invalid image ID"})
+		return
+	}
+
+	image, err := utils.ScanRow[models.Image](db.QueryRow(imageSelect+" WHERE imageId = ? AND "+owner.column+" = ?", imageID, owner.id))
+	if err == sql.ErrNoRows {
+		c.JSON(http.StatusNotFound, models.Error{Error: "Image not found"})
+		return
+	}
+	if err != nil {
+		log.Errorf("error loading image: %#v", err)
+		c.JSON(http.StatusInternalServerError, models.Error{Error: err.Error()})
+		return
+	}
+
+	if _, err := db.Exec("DELETE FROM images WHERE imageId = ?", imageID); err != nil {
+		log.Errorf("error deleting image: %#v", err)
+		c.JSON(http.StatusInternalServerError, models.Error{Error: err.Error()})
+		return
+	}
+	removeImageFiles(c, log, image.ImageKey, image.ThumbnailKey)
+
+	c.JSON(http.StatusOK, models.Status{Status: fmt.Sprintf("success! Image with image_id %d was deleted", imageID)})
+}
+
+// imageSelect selects the columns of models.Image.
+const imageSelect = `SELECT imageId, gearId, userGearRegistrationId, imageKey, thumbnailKey, contentType,
+        imageSize, imageWidth, imageHeight, uploadedBy, createdAt FROM images`
+
+// ImagesByOwner returns the images whose column (gearId or userGearRegistrationId) is id,
+// oldest first.
+func ImagesByOwner(db *sql.DB, column string, id int64) ([]models.Image, error) {
+	rows, err := db.Query(imageSelect+" WHERE "+column+" = ? ORDER BY imageId", id)
+	if err != nil {
+		return nil, fmt.Errorf("query images: %w", err)
+	}
+	defer rows.Close()
+
+	images, err := utils.ScanRows[models.Image](rows)
+	if err != nil {
+		return nil, fmt.Errorf("scan images: %w", err)
+	}
+	if images == nil {
+		images = []models.Image{}
+	}
+	return images, nil
+}
+
+// imageFileKeys returns the storage keys of the images matched by where, so their files can
+// be removed once the rows are gone.
+func imageFileKeys(db *sql.DB, where string, args ...interface{}) ([]string, error) {
+	rows, err := db.Query("SELECT imageKey, thumbnailKey FROM images WHERE "+where, args...)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	var keys []string
+	for rows.Next() {
+		var imageKey, thumbnailKey string
+		if err := rows.Scan(&imageKey, &thumbnailKey); err != nil {
+			return nil, err
+		}
+		keys = append(keys, imageKey, thumbnailKey)
+	}
+	return keys, rows.Err()
+}
+
+// removeImageFiles deletes keys from storage. Failures only leave orphaned files behind, so
+// they are logged rather than reported.
+func removeImageFiles(c *gin.Context, log *zap.SugaredLogger, keys ...string) {
+	value, ok := c.Get("storage")
+	if !ok {
+		return
+	}
+	storage := value.(utils.Storage)
+	for _, key := range keys {
+		if err := storage.Delete(c.Request.Context(), key); err != nil {
+			log.Warnw("unable to delete image file", "key", key, "error", err)
+		}
+	}
+}
+
+// resolveImageURLs fills the image URLs of items from their storage keys. It does nothing
+// when no storage is configured.
+func resolveImageURLs[model any](c *gin.Context, items ...*model) {
+	value, ok := c.Get("storage")
+	if !ok || !utils.HasStorageURLs[model]() {
+		return
+	}
+	storage := value.(utils.Storage)
+	for _, item := range items {
+		utils.ResolveURLs(item, storage)
+	}
+}
+
+// uploadLimit is the configured maximum upload size in bytes.
+func uploadLimit(c *gin.Context) int64 {
+	if value, ok := c.Get("config"); ok {
+		if limit := value.(*models.Config).Storage.MaxUploadBytes; limit > 0 {
+			return limit
+		}
+	}
+	return utils.DefaultMaxUploadBytes
+}
+
+func randomImageName() (string, error) {
+	name := make([]byte, 16)
+	if _, err := rand.Read(name); err != nil {
+		return "", err
+	}
+	return hex.EncodeToString(name), nil
+}
@@ -0,0 +1,264 @@
+package endpoints
+
+import (
+	"bytes"
+	"database/sql"
+	"encoding/json"
+	"image"
+	"image/color"
+	"image/png"
+	"mime/multipart"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	models "github.com/Sea-Shell/gogear-api/pkg/models"
+	utils "github.com/Sea-Shell/gogear-api/pkg/utils"
+
+	"github.com/gin-gonic/gin"
+)
+
+type imageTestAuth struct {
+	userID  int64
+	isAdmin bool
+}
+
+func setupImageTest(t *testing.T, auth *imageTestAuth) (*gin.Engine, *sql.DB, string) {
+	t.Helper()
+	db, _, logger := setupTest(t)
+	seedUser(t, db, 2)
+	seedCatalog(t, db)
+	seedCatalogGear(t, db, 1, 1, "Nallo", 2400)
+	if _, err := db.Exec(`INSERT INTO user_gear_registrations (userGearRegistrationId, gearId, userId) VALUES (1, 1, 1), (2, 1, 2)`); err != nil {
+		t.Fatalf("seed registrations: %v", err)
+	}
+
+	root := t.TempDir()
+	storage, err := utils.NewLocalStorage(root, "/media")
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	router := gin.New()
+	router.Use(testMiddleware(db, logger))
+	router.Use(func(c *gin.Context) {
+		c.Set("storage", storage)
+		c.Set("config", &models.Config{Storage: models.Storage{MaxUploadBytes: 64 << 10}})
+		c.Set("user_id", itoa64(auth.userID))
+		c.Set("user_id_int64", auth.userID)
+		c.Set("user_is_admin", auth.isAdmin)
+		c.Next()
+	})
+
+	gearGroup := router.Group("/api/v1/gear")
+	gearGroup.GET("/list", ListGear)
+	gearGroup.GET("/:gear/get", GetGear)
+	gearGroup.DELETE("/:gear/delete", DeleteGear)
+	gearGroup.GET("/:gear/image/list", ListGearImages)
+	gearGroup.PUT("/:gear/image/upload", UploadGearImage)
+	gearGroup.DELETE("/:gear/image/:image/delete", DeleteGearImage)
+
+	userGearGroup := router.Group("/api/v1/usergear")
+	userGearGroup.GET("/:user/list", ListUserGear)
+	userGearGroup.GET("/registration/:usergear/get", GetUserGear)
+	userGearGroup.DELETE("/registration/:usergear/delete", DeleteUserGearRegistration)
+	userGearGroup.GET("/registration/:usergear/image/list", ListUserGearImages)
+	userGearGroup.PUT("/registration/:usergear/image/upload", UploadUserGearImage)
+	userGearGroup.DELETE("/registration/:usergear/image/:image/delete", DeleteUserGearImage)
+
+	return router, db, root
+}
+
+func testPNG(t *testing.T, width, height int) []byte {
+	t.Helper()
+	img := image.NewNRGBA(image.Rect(0, 0, width, height))
+	for y := 0; y < height; y++ {
+		for x := 0; x < width; x++ {
+			img.Set(x, y, color.NRGBA{R: uint8(x), G: uint8(y), B: 200, A: 255})
+		}
+	}
+	var buf bytes.Buffer
+	if err := png.Encode(&buf, img); err != nil {
+		t.Fatal(err)
+	}
+	return buf.Bytes()
+}
+
+func uploadRequest(t *testing.T, router *gin.Engine, url string, data []byte) *httptest.ResponseRecorder {
+	t.Helper()
+	var body bytes.Buffer
+	writer := multipart.NewWriter(&body)
+	part, err := writer.CreateFormFile("image", "photo.png")
+	if err != nil {
+		t.Fatal(err)
+	}
+	part.Write(data)
+	writer.Close()
+
+	req := httptest.NewRequest(http.MethodPut, url, &body)
+	req.Header.Set("Content-Type", writer.FormDataContentType())
+	w := httptest.NewRecorder()
+	router.ServeHTTP(w, req)
+	return w
+}
+
+func storedFile(root string, url string) string {
+	return filepath.Join(root, filepath.FromSlash(strings.TrimPrefix(url, "/media/")))
+}
+
+func TestImages_GearUploadListAndDelete(t *testing.T) {
+	auth := &imageTestAuth{userID: 1}
+	router, _, root := setupImageTest(t, auth)
+
+	if w := uploadRequest(t, router, "/api/v1/gear/1/image/upload", testPNG(t, 640, 400)); w.Code != http.StatusForbidden {
+		t.Fatalf("UploadGearImage: expected 403 for non-admin, got %d", w.Code)
+	}
+
+	auth.isAdmin = true
+	w := uploadRequest(t, router, "/api/v1/gear/1/image/upload", testPNG(t, 640, 400))
+	if w.Code != http.StatusCreated {
+		t.Fatalf("UploadGearImage: expected 201, got %d — body: %s", w.Code, w.Body.String())
+	}
+	var uploaded models.Image
+	if err := json.Unmarshal(w.Body.Bytes(), &uploaded); err != nil || uploaded.ImageID == nil {
+		t.Fatalf("UploadGearImage: %s, %v", w.Body.String(), err)
+	}
+	if uploaded.ContentType != "image/png" || uploaded.ImageWidth != 640 || uploaded.ImageHeight != 400 || !strings.HasPrefix(uploaded.ImageURL, "/media/gear/1/") {
+		t.Errorf("UploadGearImage: got %+v", uploaded)
+	}
+
+	thumbnail, err := os.Open(storedFile(root, uploaded.ThumbnailURL))
+	if err != nil {
+		t.Fatalf("thumbnail not stored: %v", err)
+	}
+	config, _, err := image.DecodeConfig(thumbnail)
+	thumbnail.Close()
+	if err != nil || config.Width != utils.ThumbnailSize || config.Height != 200 {
+		t.Errorf("thumbnail is %dx%d, %v; want %dx200", config.Width, config.Height, err, utils.ThumbnailSize)
+	}
+
+	if w := uploadRequest(t, router, "/api/v1/gear/1/image/upload", testPNG(t, 20, 20)); w.Code != http.StatusCreated {
+		t.Fatalf("UploadGearImage second: expected 201, got %d", w.Code)
+	}
+	w = authRequest(t, router, http.MethodGet, "/api/v1/gear/1/image/list", "")
+	var images []models.Image
+	if err := json.Unmarshal(w.Body.Bytes(), &images); err != nil || len(images) != 2 || images[0].ImageURL != uploaded.ImageURL {
+		t.Fatalf("ListGearImages: %s, %v", w.Body.String(), err)
+	}
+
+	w = authRequest(t, router, http.MethodGet, "/api/v1/gear/1/get", "")
+	var gear models.FullGear
+	if err := json.Unmarshal(w.Body.Bytes(), &gear); err != nil || gear.ImageURL == nil || *gear.ImageURL != uploaded.ImageURL || *gear.ThumbnailURL != uploaded.ThumbnailURL {
+		t.Errorf("GetGear: image urls missing from %s", w.Body.String())
+	}
+
+	w = authRequest(t, router, http.MethodGet, "/api/v1/gear/list", "")
+	var list struct {
+		Items []models.GearListItem `json:"items"`
+	}
+	if err := json.Unmarshal(w.Body.Bytes(), &list); err != nil || len(list.Items) != 1 || list.Items[0].ImageURL == nil || *list.Items[0].ImageURL != uploaded.ImageURL {
+		t.Errorf("ListGear: image urls missing from %s", w.Body.String())
+	}
+
+	imageURL := "/api/v1/gear/1/image/" + itoa64(*uploaded.ImageID) + "/delete"
+	if w := authRequest(t, router, http.MethodDelete, imageURL, ""); w.Code != http.StatusOK {
+		t.Fatalf("DeleteGearImage: expected 200, got %d", w.Code)
+	}
+	if _, err := os.Stat(storedFile(root, uploaded.ImageURL)); !os.IsNotExist(err) {
+		t.Errorf("DeleteGearImage: image file still present: %v", err)
+	}
+	if w := authRequest(t, router, http.MethodDelete, imageURL, ""); w.Code != http.StatusNotFound {
+		t.Errorf("DeleteGearImage: expected 404 when repeated, got %d", w.Code)
+	}
+
+	if w := authRequest(t, router, http.MethodDelete, "/api/v1/gear/1/delete", ""); w.Code != http.StatusOK {
+		t.Fatalf("DeleteGear: expected 200, got %d", w.Code)
+	}
+	files, _ := filepath.Glob(filepath.Join(root, "gear", "1", "*"))
+	if len(files) != 0 {
+		t.Errorf("DeleteGear left image files behind: %v", files)
+	}
+}
+
+func TestImages_UploadValidation(t *testing.T) {
+	auth := &imageTestAuth{userID: 1, isAdmin: true}
+	router, _, _ := setupImageTest(t, auth)
+
+	if w := uploadRequest(t, router, "/api/v1/gear/1/image/upload", []byte("GIF89a but not really")); w.Code != http.StatusUnsupportedMediaType {
+		t.Errorf("expected 415 for an undecodable image, got %d", w.Code)
+	}
+	if w := uploadRequest(t, router, "/api/v1/gear/1/image/upload", []byte("<html><body>hello</body></html>")); w.Code != http.StatusUnsupportedMediaType {
+		t.Errorf("expected 415 for html, got %d", w.Code)
+	}
+	if w := uploadRequest(t, router, "/api/v1/gear/1/image/upload", bytes.Repeat([]byte{0}, 65<<10)); w.Code != http.StatusRequestEntityTooLarge {
+		t.Errorf("expected 413 over the upload limit, got %d", w.Code)
+	}
+	if w := authRequest(t, router, http.MethodPut, "/api/v1/gear/1/image/upload", `{}`); w.Code != http.StatusBadRequest {
+		t.Errorf("expected 400 without a multipart image, got %d", w.Code)
+	}
+	if w := uploadRequest(t, router, "/api/v1/gear/99/image/upload", testPNG(t, 4, 4)); w.Code != http.StatusNotFound {
+		t.Errorf("expected 404 for unknown gear, got %d", w.Code)
+	}
+}
+
+func TestImages_UserGearOwnership(t *testing.T) {
+	auth := &imageTestAuth{userID: 1}
+	router, db, root := setupImageTest(t, auth)
+
+	if w := uploadRequest(t, router, "/api/v1/usergear/registration/2/image/upload", testPNG(t, 8, 8)); w.Code != http.StatusForbidden {
+		t.Errorf("UploadUserGearImage: expected 403 for another user's registration, got %d", w.Code)
+	}
+
+	w := uploadRequest(t, router, "/api/v1/usergear/registration/1/image/upload", testPNG(t, 8, 8))
+	if w.Code != http.StatusCreated {
+		t.Fatalf("UploadUserGearImage: expected 201, got %d — body: %s", w.Code, w.Body.String())
+	}
+	var uploaded models.Image
+	if err := json.Unmarshal(w.Body.Bytes(), &uploaded); err != nil || uploaded.UserGearRegistrationID == nil || *uploaded.UserGearRegistrationID != 1 {
+		t.Fatalf("UploadUserGearImage: %s", w.Body.String())
+	}
+
+	w = authRequest(t, router, http.MethodGet, "/api/v1/usergear/registration/1/get", "")
+	var userGear models.UserGear
+	if err := json.Unmarshal(w.Body.Bytes(), &userGear); err != nil || userGear.ImageURL == nil || *userGear.ImageURL != uploaded.ImageURL || userGear.GearImageURL != nil {
+		t.Errorf("GetUserGear: got %s", w.Body.String())
+	}
+
+	w = authRequest(t, router, http.MethodGet, "/api/v1/usergear/1/list", "")
+	var list struct {
+		Items []models.UserGear `json:"items"`
+	}
+	if err := json.Unmarshal(w.Body.Bytes(), &list); err != nil || len(list.Items) != 1 || list.Items[0].ThumbnailURL == nil {
+		t.Errorf("ListUserGear: image urls missing from %s", w.Body.String())
+	}
+
+	w = authRequest(t, router, http.MethodGet, "/api/v1/usergear/registration/1/image/list", "")
+	var images []models.Image
+	if err := json.Unmarshal(w.Body.Bytes(), &images); err != nil || w.Code != http.StatusOK || len(images) != 1 {
+		t.Errorf("ListUserGearImages: got %d %s", w.Code, w.Body.String())
+	}
+
+	auth.userID = 2
+	deleteURL := "/api/v1/usergear/registration/1/image/" + itoa64(*uploaded.ImageID) + "/delete"
+	if w := authRequest(t, router, http.MethodDelete, deleteURL, ""); w.Code != http.StatusForbidden {
+		t.Errorf("DeleteUserGearImage: expected 403 for another user, got %d", w.Code)
+	}
+	if w := authRequest(t, router, http.MethodGet, "/api/v1/usergear/registration/1/image/list", ""); w.Code != http.StatusForbidden {
+		t.Errorf("ListUserGearImages: expected 403 for another user, got %d", w.Code)
+	}
+
+	auth.userID = 1
+	if w := authRequest(t, router, http.MethodDelete, "/api/v1/usergear/registration/1/delete", ""); w.Code != http.StatusOK {
+		t.Fatalf("DeleteUserGearRegistration: expected 200, got %d", w.Code)
+	}
+	var remaining int
+	if err := db.QueryRow("SELECT COUNT(*) FROM images").Scan(&remaining); err != nil || remaining != 0 {
+		t.Errorf("images left after deleting their registration: %d, %v", remaining, err)
+	}
+	if _, err := os.Stat(storedFile(root, uploaded.ImageURL)); !os.IsNotExist(err) {
+		t.Errorf("DeleteUserGearRegistration left the image file behind: %v", err)
+	}
+}
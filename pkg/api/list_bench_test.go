@@ -0,0 +1,35 @@
+package endpoints
+
+import (
+	"net/http"
+	"testing"
+)
+
+// benchmarkList seeds 500 gear items registered to user 1 and requests url repeatedly.
+func benchmarkList(b *testing.B, url string) {
+	db, router := setupCatalogTest(b, 1)
+	seedCatalog(b, db)
+	for id := int64(1); id <= 500; id++ {
+		seedCatalogGear(b, db, id, id%3+1, "gear "+itoa64(id), int(id))
+		if _, err := db.Exec(`INSERT INTO user_gear_registrations (gearId, userId) VALUES (?, 1)`, id); err != nil {
+			b.Fatalf("seed registration: %v", err)
+		}
+	}
+
+	b.ReportAllocs()
+	b.ResetTimer()
+	for i := 0; i < b.N; i++ {
+		w := authRequest(b, router, http.MethodGet, url, "")
+		if w.Code != http.StatusOK {
+			b.Fatalf("expected 200, got %d — body: %s", w.Code, w.Body.String())
+		}
+	}
+}
+
+func BenchmarkListGear(b *testing.B) {
+	benchmarkList(b, "/api/v1/gear/list?limit=100&sort=gear_name")
+}
+
+func BenchmarkListUserGear(b *testing.B) {
+	benchmarkList(b, "/api/v1/usergear/1/list?limit=100&sort=gear_name")
+}
@@ -0,0 +1,89 @@
+package endpoints
+
+import (
+	"database/sql"
+	"errors"
+	"net/http"
+	"strconv"
+
+	models "github.com/Sea-Shell/gogear-api/pkg/models"
+	utils "github.com/Sea-Shell/gogear-api/pkg/utils"
+
+	gin "github.com/gin-gonic/gin"
+	zap "go.uber.org/zap"
+)
+
+// Joins shared by the gear and user gear list queries.
+const (
+	gearJoins = utils.GearJoins
+
+	userGearJoins = ` LEFT JOIN user_container_registration ON user_container_registration.userGearRegistrationId = user_gear_registrations.userGearRegistrationId
+        LEFT JOIN gear ON user_gear_registrations.gearId = gear.gearId
+        LEFT JOIN users ON user_gear_registrations.userId = users.userId` + gearJoins + registrationVariantJoin + utils.RegistrationImageJoin
+
+	containerGearJoins = ` LEFT JOIN user_gear_registrations ON user_gear_registrations.userGearRegistrationId = user_container_registration.userGearRegistrationId
+        LEFT JOIN gear ON user_gear_registrations.gearId = gear.gearId
+        LEFT JOIN users ON user_gear_registrations.userId = users.userId` + gearJoins + registrationVariantJoin + utils.RegistrationImageJoin
+
+	registrationVariantJoin = `
+        LEFT JOIN gear_variants ON user_gear_registrations.variantId = gear_variants.variantId`
+)
+
+// gearSearchColumnMatches are the LIKE conditions, one per indexed column, used to match a
+// search word when the FTS5 gear index is unavailable.
+var gearSearchColumnMatches = []string{
+	"gear.gearName LIKE ?",
+	"manufacture.manufactureName LIKE ?",
+	"gear_category.categoryName LIKE ?",
+	"gear_top_category.topCategoryName LIKE ?",
+	"gear.gearSizeDefinition LIKE ?",
+}
+
+// respondList runs a list query and writes the paginated payload, mapping invalid
+// list parameters to 400 and everything else to 500.
+func respondList[model any](c *gin.Context, log *zap.SugaredLogger, db *sql.DB, query *utils.ListQuery[model]) {
+	payload, err := utils.ListResponse(c, db, query)
+	if err != nil {
+		var queryErr *utils.ListQueryError
+		if errors.As(err, &queryErr) {
+			log.Warnf("Invalid list parameters: %s", queryErr.Message)
+			c.IndentedJSON(http.StatusBadRequest, models.Error{Error: queryErr.Message})
+			return
+		}
+		log.Errorf("List query error: %#v", err)
+		c.IndentedJSON(http.StatusInternalServerError, models.Error{Error: err.Error()})
+		return
+	}
+
+	if items, ok := payload.Items.([]model); ok && (utils.HasMeasurements[model]() || utils.HasStorageURLs[model]() || utils.HasTranslations[model]()) {
+		pointers := make([]*model, len(items))
+		for i := range items {
+			pointers[i] = &items[i]
+		}
+		if !measureItems(c, log, db, pointers...) || !localizeItems(c, log, db, pointers...) {
+			return
+		}
+		resolveImageURLs(c, pointers...)
+	}
+
+	c.IndentedJSON(http.StatusOK, payload)
+}
+
+// intQueryValues returns the values that parse as integers, skipping the rest.
+func intQueryValues(values []string) []interface{} {
+	ints := make([]interface{}, 0, len(values))
+	for _, value := range values {
+		valueInt, err := strconv.Atoi(value)
+		if err != nil {
+			continue
+		}
+		ints = append(ints, valueInt)
+	}
+	return ints
+}
+
+// respondListQueryError writes a 400 for errors returned while building a list query.
+func respondListQueryError(c *gin.Context, log *zap.SugaredLogger, err error) {
+	log.Warnf("Invalid list filter: %s", err.Error())
+	c.IndentedJSON(http.StatusBadRequest, models.Error{Error: err.Error()})
+}
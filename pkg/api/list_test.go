@@ -0,0 +1,313 @@
+package endpoints
+
+import (
+	"database/sql"
+	"encoding/json"
+	"net/http"
+	"testing"
+
+	models "github.com/Sea-Shell/gogear-api/pkg/models"
+	utils "github.com/Sea-Shell/gogear-api/pkg/utils"
+
+	"github.com/gin-gonic/gin"
+	"go.uber.org/zap"
+)
+
+// setupCatalogTest creates a migrated :memory: DB and a router exposing the
+// catalog list endpoints, authenticated as userID.
+func setupCatalogTest(t testing.TB, userID int64) (*sql.DB, *gin.Engine) {
+	t.Helper()
+	gin.SetMode(gin.TestMode)
+	db := tempDB(t)
+	runMigrate(t, db)
+	seedUser(t, db, userID)
+
+	suggestIndex := utils.NewSuggestIndex()
+
+	router := gin.New()
+	router.Use(testMiddleware(db, zap.NewNop().Sugar()))
+	router.Use(func(c *gin.Context) {
+		c.Set("suggest_index", suggestIndex)
+		c.Next()
+	})
+
+	v1 := router.Group("/api/v1")
+	v1.Use(testAuthMiddleware(userID))
+
+	gearGroup := v1.Group("/gear")
+	gearGroup.Use(suggestIndex.InvalidateOnWrite())
+	gearGroup.GET("/list", ListGear)
+	gearGroup.GET("/search", SearchGear)
+	gearGroup.GET("/suggest", SuggestGear)
+	gearGroup.DELETE("/:gear/delete", DeleteGear)
+
+	v1.GET("/usergear/:user/list", ListUserGear)
+	v1.GET("/manufacture/list", ListManufacture)
+
+	return db, router
+}
+
+// seedCatalog inserts a top category, three categories and a manufacturer,
+// all referenced by the gear seeded with seedCatalogGear.
+func seedCatalog(t testing.TB, db *sql.DB) {
+	t.Helper()
+	statements := []string{
+		`INSERT INTO gear_top_category (topCategoryId, topCategoryName) VALUES (1, 'Shelter')`,
+		`INSERT INTO gear_category (categoryId, categoryTopCategoryId, categoryName) VALUES (1, 1, 'Tents'), (2, 1, 'Tarps'), (3, 1, 'Bivys')`,
+		`INSERT INTO manufacture (manufactureId, manufactureName) VALUES (1, 'Hilleberg')`,
+	}
+	for _, statement := range statements {
+		if _, err := db.Exec(statement); err != nil {
+			t.Fatalf("seed catalog: %v", err)
+		}
+	}
+}
+
+func seedCatalogGear(t testing.TB, db *sql.DB, id int64, categoryID int64, name string, weight int) {
+	t.Helper()
+	_, err := db.Exec(
+		`INSERT INTO gear (gearId, gearTopCategoryId, gearCategoryId, gearManufactureId, gearName, gearWeight, gearHeight, gearLength, gearWidth, gearStatus) VALUES (?, 1, ?, 1, ?, ?, 0, 0, 0, 1)`,
+		id, categoryID, name, weight,
+	)
+	if err != nil {
+		t.Fatalf("seed gear %d: %v", id, err)
+	}
+}
+
+type listTestPayload struct {
+	TotalItemCount int              `json:"total_item_count"`
+	Items          []map[string]any `json:"items"`
+	NextPage       *string          `json:"next_page"`
+	NextCursor     *string          `json:"next_cursor"`
+}
+
+func decodeListPayload(t *testing.T, body []byte) listTestPayload {
+	t.Helper()
+	var payload listTestPayload
+	if err := json.Unmarshal(body, &payload); err != nil {
+		t.Fatalf("unmarshal list payload: %v — body: %s", err, body)
+	}
+	return payload
+}
+
+func TestListGear_SortAndOrder(t *testing.T) {
+	db, router := setupCatalogTest(t, 1)
+	seedCatalog(t, db)
+	seedCatalogGear(t, db, 1, 1, "tent", 1200)
+	seedCatalogGear(t, db, 2, 1, "stove", 300)
+	seedCatalogGear(t, db, 3, 1, "mug", 80)
+
+	w := authRequest(t, router, http.MethodGet, "/api/v1/gear/list?sort=gear_name&order=desc", "")
+	if w.Code != http.StatusOK {
+		t.Fatalf("ListGear: expected 200, got %d — body: %s", w.Code, w.Body.String())
+	}
+
+	payload := decodeListPayload(t, w.Body.Bytes())
+	if payload.TotalItemCount != 3 || len(payload.Items) != 3 {
+		t.Fatalf("ListGear: expected 3 items, got %d/%d", payload.TotalItemCount, len(payload.Items))
+	}
+
+	want := []string{"tent", "stove", "mug"}
+	for i, name := range want {
+		if payload.Items[i]["gear_name"] != name {
+			t.Errorf("ListGear: item %d = %v, want %s", i, payload.Items[i]["gear_name"], name)
+		}
+	}
+}
+
+func TestListGear_InvalidSort(t *testing.T) {
+	_, router := setupCatalogTest(t, 1)
+
+	w := authRequest(t, router, http.MethodGet, "/api/v1/gear/list?sort=gearName%20DESC,%20gearId", "")
+	if w.Code != http.StatusBadRequest {
+		t.Errorf("ListGear_InvalidSort: expected 400, got %d — body: %s", w.Code, w.Body.String())
+	}
+}
+
+func TestListGear_FieldFilterAndPagination(t *testing.T) {
+	db, router := setupCatalogTest(t, 1)
+	seedCatalog(t, db)
+	seedCatalogGear(t, db, 1, 1, "tent", 1200)
+	seedCatalogGear(t, db, 2, 1, "tarp", 1200)
+	seedCatalogGear(t, db, 3, 1, "mug", 80)
+
+	w := authRequest(t, router, http.MethodGet, "/api/v1/gear/list?limit=1&gear_name=tent&gear_name=tarp", "")
+	if w.Code != http.StatusOK {
+		t.Fatalf("ListGear: expected 200, got %d — body: %s", w.Code, w.Body.String())
+	}
+
+	payload := decodeListPayload(t, w.Body.Bytes())
+	if payload.TotalItemCount != 2 || len(payload.Items) != 1 {
+		t.Errorf("ListGear: expected 2 total and 1 item, got %d/%d", payload.TotalItemCount, len(payload.Items))
+	}
+	if payload.NextPage == nil {
+		t.Error("ListGear: expected next_page link")
+	}
+}
+
+// seedSearchGear seeds gear for the search tests: two MSR stoves in different
+// categories and a tent from another manufacturer.
+func seedSearchGear(t *testing.T, db *sql.DB) {
+	t.Helper()
+	seedCatalog(t, db)
+	if _, err := db.Exec(`INSERT INTO manufacture (manufactureId, manufactureName) VALUES (2, 'MSR')`); err != nil {
+		t.Fatalf("seed manufacture: %v", err)
+	}
+	seedCatalogGear(t, db, 1, 1, "Nallo 2P", 2000)
+	seedCatalogGear(t, db, 2, 2, "PocketRocket Stove", 80)
+	seedCatalogGear(t, db, 3, 3, "WindBurner Stove System", 430)
+	if _, err := db.Exec(`UPDATE gear SET gearManufactureId = 2 WHERE gearId IN (2, 3)`); err != nil {
+		t.Fatalf("seed gear manufacturer: %v", err)
+	}
+}
+
+func TestSearchGear_MatchesEveryWordWithFilters(t *testing.T) {
+	db, router := setupCatalogTest(t, 1)
+	seedSearchGear(t, db)
+
+	w := authRequest(t, router, http.MethodGet, "/api/v1/gear/search?searchString=msr%20stove", "")
+	if w.Code != http.StatusOK {
+		t.Fatalf("SearchGear: expected 200, got %d — body: %s", w.Code, w.Body.String())
+	}
+	if payload := decodeListPayload(t, w.Body.Bytes()); len(payload.Items) != 2 {
+		t.Errorf("SearchGear: expected 2 stoves, got %v", payload.Items)
+	}
+
+	w = authRequest(t, router, http.MethodGet, "/api/v1/gear/search?searchString=msr%20stove&category=3", "")
+	if w.Code != http.StatusOK {
+		t.Fatalf("SearchGear: expected 200, got %d — body: %s", w.Code, w.Body.String())
+	}
+	payload := decodeListPayload(t, w.Body.Bytes())
+	if len(payload.Items) != 1 || payload.Items[0]["gear_id"].(float64) != 3 {
+		t.Errorf("SearchGear: expected only gear 3 in category 3, got %v", payload.Items)
+	}
+
+	w = authRequest(t, router, http.MethodGet, "/api/v1/gear/search?searchString=tent&container=maybe", "")
+	if w.Code != http.StatusBadRequest {
+		t.Errorf("SearchGear: expected 400 for invalid container, got %d", w.Code)
+	}
+}
+
+func TestSearchGear_FullTextIndex(t *testing.T) {
+	db, router := setupCatalogTest(t, 1)
+	seedSearchGear(t, db)
+
+	available, err := utils.EnsureGearSearchIndex(db)
+	if err != nil {
+		t.Fatalf("ensure gear search index: %v", err)
+	}
+	if !available {
+		t.Skip("SQLite built without FTS5; run with -tags sqlite_fts5")
+	}
+
+	w := authRequest(t, router, http.MethodGet, "/api/v1/gear/search?searchString=pocketr", "")
+	if w.Code != http.StatusOK {
+		t.Fatalf("SearchGear: expected 200, got %d — body: %s", w.Code, w.Body.String())
+	}
+
+	payload := decodeListPayload(t, w.Body.Bytes())
+	if len(payload.Items) != 1 {
+		t.Fatalf("SearchGear: expected 1 prefix match, got %v", payload.Items)
+	}
+	if snippet := payload.Items[0]["search_snippet"]; snippet != "<mark>PocketRocket</mark> Stove" {
+		t.Errorf("SearchGear: snippet = %v", snippet)
+	}
+}
+
+func TestSuggestGear_MisspellingsAndRefresh(t *testing.T) {
+	db, router := setupCatalogTest(t, 1)
+	seedSearchGear(t, db)
+
+	suggest := func(rawQuery string) []models.Suggestion {
+		t.Helper()
+		w := authRequest(t, router, http.MethodGet, "/api/v1/gear/suggest?"+rawQuery, "")
+		if w.Code != http.StatusOK {
+			t.Fatalf("SuggestGear: expected 200, got %d — body: %s", w.Code, w.Body.String())
+		}
+		var suggestions []models.Suggestion
+		if err := json.Unmarshal(w.Body.Bytes(), &suggestions); err != nil {
+			t.Fatalf("unmarshal suggestions: %v", err)
+		}
+		return suggestions
+	}
+
+	suggestions := suggest("q=windburnr&limit=3")
+	if len(suggestions) == 0 || suggestions[0].Type != "gear" || suggestions[0].ID != 3 {
+		t.Fatalf("SuggestGear: expected gear 3 first, got %+v", suggestions)
+	}
+	if len(suggestions) > 3 {
+		t.Errorf("SuggestGear: expected at most 3 suggestions, got %d", len(suggestions))
+	}
+
+	w := authRequest(t, router, http.MethodDelete, "/api/v1/gear/3/delete", "")
+	if w.Code != http.StatusOK {
+		t.Fatalf("DeleteGear: expected 200, got %d — body: %s", w.Code, w.Body.String())
+	}
+	for _, suggestion := range suggest("q=windburnr") {
+		if suggestion.Type == "gear" && suggestion.ID == 3 {
+			t.Errorf("SuggestGear: deleted gear still suggested: %+v", suggestion)
+		}
+	}
+
+	w = authRequest(t, router, http.MethodGet, "/api/v1/gear/suggest?q=tent&limit=100", "")
+	if w.Code != http.StatusBadRequest {
+		t.Errorf("SuggestGear: expected 400 for limit above maximum, got %d", w.Code)
+	}
+}
+
+func TestListGear_CursorPagination(t *testing.T) {
+	db, router := setupCatalogTest(t, 1)
+	seedCatalog(t, db)
+	seedCatalogGear(t, db, 1, 1, "tent", 1200)
+	seedCatalogGear(t, db, 2, 1, "stove", 300)
+	seedCatalogGear(t, db, 3, 1, "mug", 80)
+
+	w := authRequest(t, router, http.MethodGet, "/api/v1/gear/list?limit=2&sort=gear_name&count=false", "")
+	if w.Code != http.StatusOK {
+		t.Fatalf("ListGear: expected 200, got %d — body: %s", w.Code, w.Body.String())
+	}
+
+	first := decodeListPayload(t, w.Body.Bytes())
+	if first.TotalItemCount != 0 || len(first.Items) != 2 || first.NextCursor == nil {
+		t.Fatalf("ListGear: expected 2 items without count and a next cursor, got %d/%d/%v", first.TotalItemCount, len(first.Items), first.NextCursor)
+	}
+
+	w = authRequest(t, router, http.MethodGet, "/api/v1/gear/list?limit=2&sort=gear_name&count=false&cursor="+*first.NextCursor, "")
+	if w.Code != http.StatusOK {
+		t.Fatalf("ListGear: expected 200, got %d — body: %s", w.Code, w.Body.String())
+	}
+
+	second := decodeListPayload(t, w.Body.Bytes())
+	if len(second.Items) != 1 || second.Items[0]["gear_name"] != "tent" || second.NextCursor != nil {
+		t.Errorf("ListGear: expected only tent on the last page, got %v", second.Items)
+	}
+
+	w = authRequest(t, router, http.MethodGet, "/api/v1/gear/list?sort=gear_id&cursor="+*first.NextCursor, "")
+	if w.Code != http.StatusBadRequest {
+		t.Errorf("ListGear: expected 400 for cursor with different sort, got %d", w.Code)
+	}
+}
+
+func TestListUserGear_MultipleCategories(t *testing.T) {
+	db, router := setupCatalogTest(t, 1)
+	seedCatalog(t, db)
+	for id := int64(1); id <= 3; id++ {
+		seedCatalogGear(t, db, id, id, "item", 100)
+		if _, err := db.Exec(`INSERT INTO user_gear_registrations (gearId, userId) VALUES (?, 1)`, id); err != nil {
+			t.Fatalf("seed registration: %v", err)
+		}
+	}
+
+	w := authRequest(t, router, http.MethodGet, "/api/v1/usergear/1/list?category=1&category=3&sort=gear_id&order=desc", "")
+	if w.Code != http.StatusOK {
+		t.Fatalf("ListUserGear: expected 200, got %d — body: %s", w.Code, w.Body.String())
+	}
+
+	payload := decodeListPayload(t, w.Body.Bytes())
+	if len(payload.Items) != 2 {
+		t.Fatalf("ListUserGear: expected 2 items, got %d", len(payload.Items))
+	}
+	if payload.Items[0]["gear_id"].(float64) != 3 {
+		t.Errorf("ListUserGear: expected gear 3 first, got %v", payload.Items[0]["gear_id"])
+	}
+}
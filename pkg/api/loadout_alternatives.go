@@ -0,0 +1,199 @@
+package endpoints
+
+import (
+	"database/sql"
+	"fmt"
+	"net/http"
+	"strconv"
+
+	models "github.com/Sea-Shell/gogear-api/pkg/models"
+	utils "github.com/Sea-Shell/gogear-api/pkg/utils"
+
+	gin "github.com/gin-gonic/gin"
+	zap "go.uber.org/zap"
+)
+
+const (
+	defaultAlternativeLimit = 5
+	maxAlternativeLimit     = 20
+)
+
+// ListLoadoutAlternatives suggests lighter gear for the items of a loadout.
+//
+//	@Summary		List lighter alternatives for loadout items
+//	@Description	For each item in a loadout, suggest catalog gear in the same category that is lighter than the item, biggest saving first. Each suggestion has the saving for one of the item and for its whole quantity, and the loadout's total weight after swapping it in. Gear without a known weight is not suggested.
+//	@Security		BearerAuth
+//	@Tags			Loadouts
+//	@Accept			json
+//	@Produce		json
+//	@Param			loadout	path		int		true	"Loadout ID"
+//	@Param			limit	query		int		false	"Maximum number of suggestions per item"	default(5)	maximum(20)
+//	@Param			units	query		string	false	"Also give measurements in metric or imperial units, overriding the user's preference"
+//	@Success		200		{array}		models.LoadoutItemAlternatives
+//	@Failure		400		{object}	models.Error
+//	@Failure		404		{object}	models.Error
+//	@Failure		500		{object}	models.Error
+//	@Router			/api/v1/loadout/{loadout}/alternative/list [get]
+func ListLoadoutAlternatives(c *gin.Context) {
+	log := c.MustGet("logger").(*zap.SugaredLogger)
+	db := c.MustGet("db").(*sql.DB)
+
+	loadoutID, err := strconv.ParseInt(c.Param("loadout"), 10, 64)
+	if err != nil {
+		log.Errorf("invalid loadout ID: %#v", err)
+		c.JSON(http.StatusBadRequest, models.Error{Error: "invalid loadout ID"})
+		return
+	}
+
+	userID := c.MustGet("user_id_int64").(int64)
+	existing, err := utils.GenericGet[models.Loadout]("loadouts", int(loadoutID), nil, db)
+	if err != nil || existing.UserID != userID {
+		c.IndentedJSON(http.StatusNotFound, models.Error{Error: "Loadout not found"})
+		return
+	}
+
+	limit := defaultAlternativeLimit
+	if limitQuery := c.Query("limit"); limitQuery != "" {
+		limitInt, err := strconv.Atoi(limitQuery)
+		if err != nil || limitInt <= 0 || limitInt > maxAlternativeLimit {
+			c.JSON(http.StatusBadRequest, models.Error{Error: fmt.Sprintf("limit must be between 1 and %d", maxAlternativeLimit)})
+			return
+		}
+		limit = limitInt
+	}
+
+	items, err := LoadoutLighterAlternatives(db, loadoutID, userID, limit)
+	if err != nil {
+		log.Errorf("error listing lighter alternatives: %#v", err)
+		c.JSON(http.StatusInternalServerError, models.Error{Error: err.Error()})
+		return
+	}
+
+	measured := make([]*models.LoadoutItemAlternatives, len(items))
+	var alternatives []*models.GearAlternative
+	var gear []*models.GearListItem
+	for i := range items {
+		measured[i] = &items[i]
+		for j := range items[i].Alternatives {
+			resolveImageURLs(c, &items[i].Alternatives[j].Gear)
+			alternatives = append(alternatives, &items[i].Alternatives[j])
+			gear = append(gear, &items[i].Alternatives[j].Gear)
+		}
+	}
+	if !measureItems(c, log, db, measured...) || !measureItems(c, log, db, alternatives...) ||
+		!localizeItems(c, log, db, measured...) || !localizeItems(c, log, db, gear...) {
+		return
+	}
+
+	c.JSON(http.StatusOK, items)
+}
+
+// SwapLoadoutItem replaces the gear of a loadout item.
+//
+//	@Summary		Swap loadout item
+//	@Description	Replace the gear of a loadout item, keeping its quantity and notes, and recalculate the loadout's total weight. variant_id picks a variant of the new gear; leaving it out uses the gear itself.
+//	@Security		BearerAuth
+//	@Tags			Loadouts
+//	@Accept			json
+//	@Produce		json
+//	@Param			loadout	path		int						true	"Loadout ID"
+//	@Param			item	path		int						true	"Item ID"
+//	@Param			body	body		models.LoadoutItemSwap	true	"Gear to swap in"
+//	@Param			units	query		string					false	"Also give measurements in metric or imperial units, overriding the user's preference"
+//	@Success		200		{object}	models.LoadoutItemSwapResult
+//	@Failure		400		{object}	models.Error
+//	@Failure		403		{object}	models.Error
+//	@Failure		404		{object}	models.Error
+//	@Failure		500		{object}	models.Error
+//	@Router			/api/v1/loadout/{loadout}/item/{item}/swap [post]
+func SwapLoadoutItem(c *gin.Context) {
+	log := c.MustGet("logger").(*zap.SugaredLogger)
+	db := c.MustGet("db").(*sql.DB)
+
+	loadoutID, err := strconv.ParseInt(c.Param("loadout"), 10, 64)
+	if err != nil {
+		log.Errorf("invalid loadout ID: %#v", err)
+		c.JSON(http.StatusBadRequest, models.Error{Error: "invalid loadout ID"})
+		return
+	}
+
+	existing, err := utils.GenericGet[models.Loadout]("loadouts", int(loadoutID), nil, db)
+	if err != nil {
+		log.Errorf("Loadout not found: %#v", err)
+		c.IndentedJSON(http.StatusNotFound, models.Error{Error: "Loadout not found"})
+		return
+	}
+	if existing.UserID != c.MustGet("user_id_int64").(int64) {
+		c.IndentedJSON(http.StatusForbidden, models.Error{Error: "Access denied"})
+		return
+	}
+
+	itemID, err := strconv.ParseInt(c.Param("item"), 10, 64)
+	if err != nil {
+		log.Errorf("invalid item ID: %#v", err)
+		c.JSON(http.StatusBadRequest, models.Error{Error: "invalid item ID"})
+		return
+	}
+
+	var swap models.LoadoutItemSwap
+	if err := c.ShouldBindJSON(&swap); err != nil {
+		c.JSON(http.StatusBadRequest, models.Error{Error: err.Error()})
+		return
+	}
+	if swap.GearID <= 0 {
+		c.JSON(http.StatusBadRequest, models.Error{Error: "gear_id is required"})
+		return
+	}
+
+	var exists bool
+	if err := db.QueryRow("SELECT EXISTS (SELECT 1 FROM loadout_items WHERE loadoutItemId = ? AND loadoutId = ?)", itemID, loadoutID).Scan(&exists); err != nil {
+		log.Errorf("error getting loadout item: %#v", err)
+		c.JSON(http.StatusInternalServerError, models.Error{Error: err.Error()})
+		return
+	}
+	if !exists {
+		c.JSON(http.StatusNotFound, models.Error{Error: "Loadout item not found"})
+		return
+	}
+
+	gearID, ok := resolveGearRedirect(c, log, db, swap.GearID)
+	if !ok || respondGearNotVisible(c, log, db, gearID) {
+		return
+	}
+	if respondGearVariantMismatch(c, log, db, swap.VariantID, gearID) {
+		return
+	}
+
+	if _, err := db.Exec("UPDATE loadout_items SET gearId = ?, variantId = ? WHERE loadoutItemId = ? AND loadoutId = ?",
+		gearID, swap.VariantID, itemID, loadoutID); err != nil {
+		log.Errorf("error swapping loadout item: %#v", err)
+		c.JSON(http.StatusInternalServerError, models.Error{Error: err.Error()})
+		return
+	}
+
+	if err := LoadoutRecalculateWeight(db, loadoutID); err != nil {
+		log.Errorf("error recalculating weight: %#v", err)
+		c.JSON(http.StatusInternalServerError, models.Error{Error: err.Error()})
+		return
+	}
+
+	result := models.LoadoutItemSwapResult{
+		LoadoutItemID:       itemID,
+		GearID:              gearID,
+		VariantID:           swap.VariantID,
+		PreviousTotalWeight: existing.TotalWeight,
+	}
+	if err := db.QueryRow("SELECT totalWeight FROM loadouts WHERE loadoutId = ?", loadoutID).Scan(&result.TotalWeight); err != nil {
+		log.Errorf("error getting loadout weight: %#v", err)
+		c.JSON(http.StatusInternalServerError, models.Error{Error: err.Error()})
+		return
+	}
+	result.WeightChange = result.TotalWeight - result.PreviousTotalWeight
+
+	if !measureItems(c, log, db, &result) {
+		return
+	}
+
+	log.Infof("Swapped loadout item %d to gear %d", itemID, gearID)
+	c.JSON(http.StatusOK, result)
+}
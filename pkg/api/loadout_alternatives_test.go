@@ -0,0 +1,95 @@
+package endpoints
+
+import (
+	"encoding/json"
+	"net/http"
+	"testing"
+
+	models "github.com/Sea-Shell/gogear-api/pkg/models"
+)
+
+func TestLoadoutAlternativesAndSwap(t *testing.T) {
+	db, router := setupCatalogTest(t, 1)
+	seedCatalog(t, db)
+	seedUser(t, db, 2)
+	seedCatalogGear(t, db, 1, 1, "Nallo", 2400)
+	seedCatalogGear(t, db, 2, 1, "Akto", 1700)
+	seedCatalogGear(t, db, 3, 1, "Soulo", 1900)
+	seedCatalogGear(t, db, 4, 1, "Someone's pending tent", 900)
+	seedCatalogGear(t, db, 5, 2, "Tarp 10", 500)
+	seedCatalogGear(t, db, 6, 1, "Unweighed tent", 0)
+	for _, statement := range []string{
+		`UPDATE gear SET gearStatus = 0, gearSubmittedBy = 2 WHERE gearId = 4`,
+		`INSERT INTO gear_variants (variantId, gearId, variantName, variantWeight) VALUES (1, 3, 'Green', 1850), (2, 1, 'Black', 2300)`,
+	} {
+		if _, err := db.Exec(statement); err != nil {
+			t.Fatal(err)
+		}
+	}
+	loadoutID := seedLoadout(t, db, 1, false, "trip")
+	if _, err := db.Exec(`INSERT INTO loadout_items (loadoutItemId, loadoutId, gearId, quantity, notes) VALUES (1, ?, 1, 2, 'shared'), (2, ?, 5, 1, '')`, loadoutID, loadoutID); err != nil {
+		t.Fatal(err)
+	}
+	if err := LoadoutRecalculateWeight(db, loadoutID); err != nil {
+		t.Fatal(err)
+	}
+	otherLoadoutID := seedLoadout(t, db, 2, false, "other")
+
+	v1 := router.Group("/api/v1", testAuthMiddleware(1))
+	v1.GET("/loadout/:loadout/alternative/list", ListLoadoutAlternatives)
+	v1.POST("/loadout/:loadout/item/:item/swap", SwapLoadoutItem)
+
+	loadoutPath := "/api/v1/loadout/" + itoa64(loadoutID)
+	w := authRequest(t, router, http.MethodGet, loadoutPath+"/alternative/list", "")
+	var items []models.LoadoutItemAlternatives
+	if err := json.Unmarshal(w.Body.Bytes(), &items); err != nil || w.Code != http.StatusOK {
+		t.Fatalf("ListLoadoutAlternatives: %d %s", w.Code, w.Body.String())
+	}
+	if len(items) != 2 || items[0].ItemWeight != 2400 || len(items[1].Alternatives) != 0 {
+		t.Fatalf("ListLoadoutAlternatives: unexpected items %+v", items)
+	}
+	alternatives := items[0].Alternatives
+	if len(alternatives) != 2 || alternatives[0].Gear.GearID != 2 || alternatives[1].Gear.GearID != 3 {
+		t.Fatalf("ListLoadoutAlternatives: expected the visible, weighed lighter tents, got %+v", alternatives)
+	}
+	if first := alternatives[0]; first.Weight != 1700 || first.WeightSaving != 700 || first.TotalWeightSaving != 1400 || first.NewTotalWeight != 3900 {
+		t.Errorf("ListLoadoutAlternatives: unexpected savings %+v", first)
+	}
+	if w := authRequest(t, router, http.MethodGet, loadoutPath+"/alternative/list?limit=1", ""); w.Code != http.StatusOK {
+		t.Errorf("ListLoadoutAlternatives with limit: %d %s", w.Code, w.Body.String())
+	}
+	if w := authRequest(t, router, http.MethodGet, "/api/v1/loadout/"+itoa64(otherLoadoutID)+"/alternative/list", ""); w.Code != http.StatusNotFound {
+		t.Errorf("ListLoadoutAlternatives: expected 404 for another user's loadout, got %d", w.Code)
+	}
+
+	w = authRequest(t, router, http.MethodPost, loadoutPath+"/item/1/swap", `{"gear_id":3,"variant_id":1}`)
+	var result models.LoadoutItemSwapResult
+	if err := json.Unmarshal(w.Body.Bytes(), &result); err != nil || w.Code != http.StatusOK {
+		t.Fatalf("SwapLoadoutItem: %d %s", w.Code, w.Body.String())
+	}
+	if result.PreviousTotalWeight != 5300 || result.TotalWeight != 4200 || result.WeightChange != -1100 {
+		t.Errorf("SwapLoadoutItem: unexpected result %+v", result)
+	}
+	var gearID, quantity int64
+	var notes string
+	if err := db.QueryRow(`SELECT gearId, quantity, notes FROM loadout_items WHERE loadoutItemId = 1`).Scan(&gearID, &quantity, &notes); err != nil || gearID != 3 || quantity != 2 || notes != "shared" {
+		t.Errorf("SwapLoadoutItem: expected gear 3 with quantity and notes kept, got %d %d %q (%v)", gearID, quantity, notes, err)
+	}
+
+	for body, want := range map[string]int{
+		`{"gear_id":2,"variant_id":2}`: http.StatusBadRequest,
+		`{"variant_id":1}`:             http.StatusBadRequest,
+		`{"gear_id":4}`:                http.StatusNotFound,
+		`{"gear_id":99}`:               http.StatusNotFound,
+	} {
+		if w := authRequest(t, router, http.MethodPost, loadoutPath+"/item/1/swap", body); w.Code != want {
+			t.Errorf("SwapLoadoutItem %s: expected %d, got %d", body, want, w.Code)
+		}
+	}
+	if w := authRequest(t, router, http.MethodPost, loadoutPath+"/item/9/swap", `{"gear_id":2}`); w.Code != http.StatusNotFound {
+		t.Errorf("SwapLoadoutItem: expected 404 for a missing item, got %d", w.Code)
+	}
+	if w := authRequest(t, router, http.MethodPost, "/api/v1/loadout/"+itoa64(otherLoadoutID)+"/item/1/swap", `{"gear_id":2}`); w.Code != http.StatusForbidden {
+		t.Errorf("SwapLoadoutItem: expected 403 for another user's loadout, got %d", w.Code)
+	}
+}
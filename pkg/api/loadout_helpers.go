@@ -5,6 +5,7 @@ import (
 	"fmt"
 
 	"github.com/Sea-Shell/gogear-api/pkg/models"
+	"github.com/Sea-Shell/gogear-api/pkg/utils"
 )
 
 // LoadoutGetBySlug fetches a loadout by its slug.
@@ -32,42 +33,9 @@ func LoadoutGetBySlug(db *sql.DB, slug string) (*models.Loadout, error) {
 	return &l, nil
 }
 
-// LoadoutListByUser returns all loadouts for a given user ordered by most recent update.
-func LoadoutListByUser(db *sql.DB, userID int64) (*[]models.Loadout, error) {
-	const query = `SELECT loadoutId, userId, loadoutName, loadoutDescription, loadoutIsPublic, loadoutSlug, totalWeight, createdAt, updatedAt FROM loadouts WHERE userId = ? ORDER BY updatedAt DESC`
-	rows, err := db.Query(query, userID)
-	if err != nil {
-		return nil, fmt.Errorf("query loadouts by user: %w", err)
-	}
-	defer rows.Close()
-
-	var loadouts []models.Loadout
-	for rows.Next() {
-		var l models.Loadout
-		if err := rows.Scan(
-			&l.LoadoutID,
-			&l.UserID,
-			&l.LoadoutName,
-			&l.LoadoutDescription,
-			&l.LoadoutIsPublic,
-			&l.LoadoutSlug,
-			&l.TotalWeight,
-			&l.CreatedAt,
-			&l.UpdatedAt,
-		); err != nil {
-			return nil, fmt.Errorf("scan loadout: %w", err)
-		}
-		loadouts = append(loadouts, l)
-	}
-	if err := rows.Err(); err != nil {
-		return nil, fmt.Errorf("rows error: %w", err)
-	}
-	return &loadouts, nil
-}
-
 // LoadoutItemsByLoadout returns all items belonging to a loadout.
 func LoadoutItemsByLoadout(db *sql.DB, loadoutID int64) (*[]models.LoadoutItem, error) {
-	const query = `SELECT loadoutItemId, loadoutId, gearId, quantity, notes FROM loadout_items WHERE loadoutId = ?`
+	const query = `SELECT loadoutItemId, loadoutId, gearId, quantity, notes, variantId FROM loadout_items WHERE loadoutId = ?`
 	rows, err := db.Query(query, loadoutID)
 	if err != nil {
 		return nil, fmt.Errorf("query loadout items: %w", err)
@@ -83,6 +51,7 @@ func LoadoutItemsByLoadout(db *sql.DB, loadoutID int64) (*[]models.LoadoutItem,
 			&it.GearID,
 			&it.Quantity,
 			&it.Notes,
+			&it.VariantID,
 		); err != nil {
 			return nil, fmt.Errorf("scan loadout item: %w", err)
 		}
@@ -95,12 +64,13 @@ func LoadoutItemsByLoadout(db *sql.DB, loadoutID int64) (*[]models.LoadoutItem,
 }
 
 // LoadoutRecalculateWeight updates totalWeight based on gear weights and quantities.
+// Items with a variant count the variant's weight instead of the gear's.
 func LoadoutRecalculateWeight(db *sql.DB, loadoutID int64) error {
-	// Assume gear table has gearWeight column (int64).
 	const stmt = `UPDATE loadouts SET totalWeight = (
-        SELECT IFNULL(SUM(g.gearWeight * li.quantity), 0)
+        SELECT IFNULL(SUM(COALESCE(v.variantWeight, g.gearWeight) * li.quantity), 0)
         FROM loadout_items li
         JOIN gear g ON g.gearId = li.gearId
+        LEFT JOIN gear_variants v ON v.variantId = li.variantId
         WHERE li.loadoutId = ?
     ) WHERE loadoutId = ?`
 	_, err := db.Exec(stmt, loadoutID, loadoutID)
@@ -109,3 +79,133 @@ func LoadoutRecalculateWeight(db *sql.DB, loadoutID int64) error {
 	}
 	return nil
 }
+
+// LoadoutRecalculateWeightForVariant recalculates every loadout with an item using the variant.
+func LoadoutRecalculateWeightForVariant(db *sql.DB, variantID int64) error {
+	loadoutIDs, err := loadoutsUsingVariant(db, variantID)
+	if err != nil {
+		return err
+	}
+	for _, loadoutID := range loadoutIDs {
+		if err := LoadoutRecalculateWeight(db, loadoutID); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// loadoutsUsingVariant returns the IDs of loadouts with an item using the variant.
+func loadoutsUsingVariant(db *sql.DB, variantID int64) ([]int64, error) {
+	rows, err := db.Query(`SELECT DISTINCT loadoutId FROM loadout_items WHERE variantId = ?`, variantID)
+	if err != nil {
+		return nil, fmt.Errorf("query loadouts using variant %d: %w", variantID, err)
+	}
+	defer rows.Close()
+
+	var loadoutIDs []int64
+	for rows.Next() {
+		var loadoutID int64
+		if err := rows.Scan(&loadoutID); err != nil {
+			return nil, fmt.Errorf("scan loadout id: %w", err)
+		}
+		loadoutIDs = append(loadoutIDs, loadoutID)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, fmt.Errorf("rows error: %w", err)
+	}
+	return loadoutIDs, nil
+}
+
+// LoadoutLighterAlternatives returns every item of a loadout with up to limit lighter gear
+// from the item's category that userID can see, biggest saving first. Gear without a known
+// weight is never suggested, and items without one get no suggestions.
+func LoadoutLighterAlternatives(db *sql.DB, loadoutID int64, userID int64, limit int) ([]models.LoadoutItemAlternatives, error) {
+	var totalWeight int64
+	if err := db.QueryRow("SELECT totalWeight FROM loadouts WHERE loadoutId = ?", loadoutID).Scan(&totalWeight); err != nil {
+		return nil, fmt.Errorf("query loadout weight: %w", err)
+	}
+
+	rows, err := db.Query(`SELECT li.loadoutItemId, li.gearId, g.gearName, li.variantId, li.quantity,
+            COALESCE(v.variantWeight, g.gearWeight), g.gearCategoryId
+        FROM loadout_items li
+        JOIN gear g ON g.gearId = li.gearId
+        LEFT JOIN gear_variants v ON v.variantId = li.variantId
+        WHERE li.loadoutId = ? ORDER BY li.loadoutItemId`, loadoutID)
+	if err != nil {
+		return nil, fmt.Errorf("query loadout items: %w", err)
+	}
+	items := []models.LoadoutItemAlternatives{}
+	var categories []int64
+	for rows.Next() {
+		var item models.LoadoutItemAlternatives
+		var categoryID int64
+		if err := rows.Scan(&item.LoadoutItemID, &item.GearID, &item.GearName, &item.VariantID, &item.Quantity, &item.ItemWeight, &categoryID); err != nil {
+			rows.Close()
+			return nil, fmt.Errorf("scan loadout item: %w", err)
+		}
+		item.Alternatives = []models.GearAlternative{}
+		items = append(items, item)
+		categories = append(categories, categoryID)
+	}
+	rows.Close()
+	if err := rows.Err(); err != nil {
+		return nil, fmt.Errorf("rows error: %w", err)
+	}
+
+	visible, visibleArgs := utils.GearVisibleCondition(userID)
+	for i := range items {
+		item := &items[i]
+		if item.ItemWeight <= 0 {
+			continue
+		}
+
+		args := append([]interface{}{categories[i], item.ItemWeight, item.GearID}, visibleArgs...)
+		weights := make(map[int64]int64)
+		var lighter []interface{}
+		rows, err := db.Query(`SELECT gearId, gearWeight FROM gear
+            WHERE gearCategoryId = ? AND gearWeight > 0 AND gearWeight < ? AND gearId != ? AND `+visible+`
+            ORDER BY gearWeight, gearId LIMIT ?`, append(args, limit)...)
+		if err != nil {
+			return nil, fmt.Errorf("query lighter gear: %w", err)
+		}
+		for rows.Next() {
+			var gearID, weight int64
+			if err := rows.Scan(&gearID, &weight); err != nil {
+				rows.Close()
+				return nil, fmt.Errorf("scan lighter gear: %w", err)
+			}
+			weights[gearID] = weight
+			lighter = append(lighter, gearID)
+		}
+		rows.Close()
+		if err := rows.Err(); err != nil {
+			return nil, fmt.Errorf("rows error: %w", err)
+		}
+		if len(lighter) == 0 {
+			continue
+		}
+
+		gear := make(map[int64]models.GearListItem, len(lighter))
+		query := utils.NewListQuery[models.GearListItem]("gear"+gearJoins).CoalesceNulls().WhereIn("gear.gearId", lighter...)
+		if err := query.Each(db, func(listItem models.GearListItem) error {
+			gear[listItem.GearID] = listItem
+			return nil
+		}); err != nil {
+			return nil, fmt.Errorf("query lighter gear: %w", err)
+		}
+
+		for _, gearID := range lighter {
+			id := gearID.(int64)
+			saving := item.ItemWeight - weights[id]
+			item.Alternatives = append(item.Alternatives, models.GearAlternative{
+				Gear:              gear[id],
+				Weight:            weights[id],
+				WeightSaving:      saving,
+				TotalWeightSaving: saving * item.Quantity,
+				NewTotalWeight:    totalWeight - saving*item.Quantity,
+			})
+		}
+	}
+
+	return items, nil
+}
@@ -69,6 +69,13 @@ func InsertLoadoutItem(c *gin.Context) {
 	}
 	item.LoadoutID = loadoutID
 
+	if respondGearNotVisible(c, log, db, item.GearID) {
+		return
+	}
+	if respondGearVariantMismatch(c, log, db, item.VariantID, item.GearID) {
+		return
+	}
+
 	body, err := json.Marshal(item)
 	if err != nil {
 		log.Errorf("error marshaling item: %#v", err)
@@ -90,16 +97,22 @@ func InsertLoadoutItem(c *gin.Context) {
 	c.JSON(http.StatusCreated, createdObject)
 }
 
-// ListLoadoutItems lists all items in a loadout.
+// ListLoadoutItems lists the items in a loadout.
 //
 //	@Summary		List loadout items
-//	@Description	List all items in a loadout
+//	@Description	List the items in a loadout, in the order they were added unless another field is given
 //	@Security		BearerAuth
 //	@Tags			Loadouts
 //	@Accept			json
 //	@Produce		json
-//	@Param			loadout	path		int	true	"Loadout ID"
-//	@Success		200		{array}		models.LoadoutItem
+//	@Param			loadout	path		int		true	"Loadout ID"
+//	@Param			page	query		int		false	"Page number"								default(1)
+//	@Param			limit	query		int		false	"Number of items per page"					default(30)
+//	@Param			sort	query		string	false	"Field to sort by, json or db field name"	default(loadout_item_id)
+//	@Param			order	query		string	false	"Sort direction, asc or desc"				default(asc)
+//	@Param			cursor	query		string	false	"Opaque cursor from next_cursor or prev_cursor, replaces page"
+//	@Param			count	query		bool	false	"Include total item count"	default(true)
+//	@Success		200		{object}	models.ResponsePayload{items=[]models.LoadoutItem}
 //	@Failure		400		{object}	models.Error
 //	@Failure		404		{object}	models.Error
 //	@Failure		500		{object}	models.Error
@@ -127,24 +140,21 @@ func ListLoadoutItems(c *gin.Context) {
 		return
 	}
 
-	items, err := LoadoutItemsByLoadout(db, loadoutID)
-	if err != nil {
-		log.Errorf("error listing loadout items: %#v", err)
-		c.JSON(http.StatusInternalServerError, models.Error{Error: err.Error()})
-		return
-	}
+	query := utils.NewListQuery[models.LoadoutItem]("loadout_items")
+	query.Where("loadout_items.loadoutId = ?", loadoutID)
 
-	if items == nil {
-		items = &[]models.LoadoutItem{}
+	if err := query.FilterFields(c.Request.URL.Query()); err != nil {
+		respondListQueryError(c, log, err)
+		return
 	}
 
-	c.JSON(http.StatusOK, items)
+	respondList(c, log, db, query)
 }
 
 // UpdateLoadoutItem updates a loadout item.
 //
 //	@Summary		Update loadout item
-//	@Description	Update a loadout item
+//	@Description	Update a loadout item. Leaving out variant_id keeps the current variant; null clears it.
 //	@Security		BearerAuth
 //	@Tags			Loadouts
 //	@Accept			json
@@ -203,6 +213,32 @@ func UpdateLoadoutItem(c *gin.Context) {
 	}
 	update.LoadoutItemID = itemID
 
+	var gearID int64
+	var variantID *int64
+	err = db.QueryRow("SELECT gearId, variantId FROM loadout_items WHERE loadoutItemId = ? AND loadoutId = ?", itemID, loadoutID).Scan(&gearID, &variantID)
+	if err == sql.ErrNoRows {
+		c.JSON(http.StatusNotFound, models.Error{Error: "Loadout item not found"})
+		return
+	}
+	if err != nil {
+		log.Errorf("error getting loadout item: %#v", err)
+		c.JSON(http.StatusInternalServerError, models.Error{Error: err.Error()})
+		return
+	}
+
+	var rawUpdate map[string]json.RawMessage
+	if err := json.Unmarshal(data, &rawUpdate); err != nil {
+		c.JSON(http.StatusBadRequest, models.Error{Error: err.Error()})
+		return
+	}
+	if _, hasVariant := rawUpdate["variant_id"]; !hasVariant {
+		update.VariantID = variantID
+	}
+
+	if respondGearVariantMismatch(c, log, db, update.VariantID, gearID) {
+		return
+	}
+
 	body, err := json.Marshal(update)
 	if err != nil {
 		log.Errorf("error marshaling update: %#v", err)
@@ -36,6 +36,7 @@ func sanitizeLoadout(l *models.Loadout) *models.LoadoutPublic {
 //	@Accept			json
 //	@Produce		json
 //	@Param			slug	path		string	true	"Loadout slug"
+//	@Param			units	query		string	false	"Also give total_weight in metric or imperial units"
 //	@Success		200		{object}	models.LoadoutPublic
 //	@Failure		400		{object}	models.Error
 //	@Failure		404		{object}	models.Error
@@ -66,7 +67,12 @@ func GetPublicLoadout(c *gin.Context) {
 		return
 	}
 
-	c.IndentedJSON(http.StatusOK, sanitizeLoadout(l))
+	public := sanitizeLoadout(l)
+	if !measureItems(c, log, db, public) {
+		return
+	}
+
+	c.IndentedJSON(http.StatusOK, public)
 }
 
 // GetPublicLoadoutItems returns items for a public loadout by slug.
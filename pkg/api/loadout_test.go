@@ -22,7 +22,7 @@ import (
 // Helpers
 // ---------------------------------------------------------------------------
 
-func migrationsPath(t *testing.T) string {
+func migrationsPath(t testing.TB) string {
 	t.Helper()
 	dir, err := os.Getwd()
 	if err != nil {
@@ -35,7 +35,7 @@ func migrationsPath(t *testing.T) string {
 	return path
 }
 
-func runMigrate(t *testing.T, db *sql.DB) {
+func runMigrate(t testing.TB, db *sql.DB) {
 	t.Helper()
 	path := migrationsPath(t)
 	driver, err := sqlite3driver.WithInstance(db, &sqlite3driver.Config{})
@@ -51,7 +51,7 @@ func runMigrate(t *testing.T, db *sql.DB) {
 	}
 }
 
-func tempDB(t *testing.T) *sql.DB {
+func tempDB(t testing.TB) *sql.DB {
 	t.Helper()
 	db, err := sql.Open("sqlite3", ":memory:")
 	if err != nil {
@@ -61,7 +61,7 @@ func tempDB(t *testing.T) *sql.DB {
 	return db
 }
 
-func seedUser(t *testing.T, db *sql.DB, id int64) {
+func seedUser(t testing.TB, db *sql.DB, id int64) {
 	t.Helper()
 	_, err := db.Exec(
 		`INSERT INTO users (userId, userUsername, userPassword, userName, userEmail, userIsAdmin, userIsExternal) VALUES (?, ?, ?, ?, ?, 0, 0)`,
@@ -237,7 +237,7 @@ func routerWithUser(db *sql.DB, logger *zap.SugaredLogger, userID int64) *gin.En
 // Request helper
 // ---------------------------------------------------------------------------
 
-func authRequest(t *testing.T, router *gin.Engine, method, url, body string) *httptest.ResponseRecorder {
+func authRequest(t testing.TB, router *gin.Engine, method, url, body string) *httptest.ResponseRecorder {
 	t.Helper()
 	req := httptest.NewRequest(method, url, strings.NewReader(body))
 	req.Header.Set("Content-Type", "application/json")
@@ -283,21 +283,35 @@ func TestInsertLoadout_InvalidBody(t *testing.T) {
 func TestListLoadouts(t *testing.T) {
 	db, router, _ := setupTest(t)
 
-	// Seed two loadouts for user 1
+	// Seed two loadouts for user 1 and one for user 2
 	seedLoadout(t, db, 1, false, "pack-a")
 	seedLoadout(t, db, 1, true, "pack-b")
+	seedLoadout(t, db, 2, true, "pack-c")
 
 	w := authRequest(t, router, http.MethodGet, "/api/v1/loadout/list", "")
 	if w.Code != http.StatusOK {
 		t.Fatalf("ListLoadouts: expected 200, got %d", w.Code)
 	}
 
-	var list []map[string]interface{}
-	if err := json.Unmarshal(w.Body.Bytes(), &list); err != nil {
-		t.Fatalf("ListLoadouts: unmarshal error: %v", err)
+	payload := decodeListPayload(t, w.Body.Bytes())
+	if len(payload.Items) != 2 || payload.TotalItemCount != 2 {
+		t.Errorf("ListLoadouts: expected 2 loadouts, got %d of %d", len(payload.Items), payload.TotalItemCount)
 	}
-	if len(list) != 2 {
-		t.Errorf("ListLoadouts: expected 2 loadouts, got %d", len(list))
+
+	w = authRequest(t, router, http.MethodGet, "/api/v1/loadout/list?sort=loadout_slug&order=desc&limit=1", "")
+	payload = decodeListPayload(t, w.Body.Bytes())
+	if len(payload.Items) != 1 || payload.Items[0]["loadout_slug"] != "pack-b" || payload.NextCursor == nil {
+		t.Fatalf("ListLoadouts sorted: unexpected page %+v", payload)
+	}
+
+	w = authRequest(t, router, http.MethodGet, "/api/v1/loadout/list?sort=loadout_slug&order=desc&limit=1&cursor="+*payload.NextCursor, "")
+	payload = decodeListPayload(t, w.Body.Bytes())
+	if len(payload.Items) != 1 || payload.Items[0]["loadout_slug"] != "pack-a" {
+		t.Errorf("ListLoadouts next cursor: unexpected page %+v", payload)
+	}
+
+	if w := authRequest(t, router, http.MethodGet, "/api/v1/loadout/list?sort=nope", ""); w.Code != http.StatusBadRequest {
+		t.Errorf("ListLoadouts: expected 400 for an unknown sort field, got %d", w.Code)
 	}
 }
 
@@ -309,12 +323,8 @@ func TestListLoadouts_Empty(t *testing.T) {
 		t.Fatalf("ListLoadouts_Empty: expected 200, got %d", w.Code)
 	}
 
-	var list []interface{}
-	if err := json.Unmarshal(w.Body.Bytes(), &list); err != nil {
-		t.Fatalf("ListLoadouts_Empty: unmarshal error: %v", err)
-	}
-	if len(list) != 0 {
-		t.Errorf("ListLoadouts_Empty: expected empty array, got %d items", len(list))
+	if payload := decodeListPayload(t, w.Body.Bytes()); len(payload.Items) != 0 || payload.TotalItemCount != 0 {
+		t.Errorf("ListLoadouts_Empty: expected no loadouts, got %d", len(payload.Items))
 	}
 }
 
@@ -510,12 +520,14 @@ func TestListLoadoutItems(t *testing.T) {
 		t.Fatalf("TestListLoadoutItems: expected 200, got %d — body: %s", w.Code, w.Body.String())
 	}
 
-	var list []map[string]interface{}
-	if err := json.Unmarshal(w.Body.Bytes(), &list); err != nil {
-		t.Fatalf("TestListLoadoutItems: unmarshal error: %v", err)
+	payload := decodeListPayload(t, w.Body.Bytes())
+	if len(payload.Items) != 2 || payload.Items[0]["gear_id"] != float64(1) {
+		t.Errorf("TestListLoadoutItems: expected items for gear 1 and 2, got %+v", payload.Items)
 	}
-	if len(list) != 2 {
-		t.Errorf("TestListLoadoutItems: expected 2 items, got %d", len(list))
+
+	w = authRequest(t, router, http.MethodGet, "/api/v1/loadout/"+itoa64(loadoutID)+"/item/list?sort=gear_id&order=desc", "")
+	if payload := decodeListPayload(t, w.Body.Bytes()); len(payload.Items) != 2 || payload.Items[0]["gear_id"] != float64(2) {
+		t.Errorf("TestListLoadoutItems sorted: expected gear 2 first, got %+v", payload.Items)
 	}
 }
 
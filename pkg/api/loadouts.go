@@ -74,35 +74,46 @@ func InsertLoadout(c *gin.Context) {
 	c.IndentedJSON(http.StatusCreated, createdObject)
 }
 
-// ListLoadouts returns all loadouts for the authenticated user.
+// ListLoadouts returns the loadouts of the authenticated user.
 //
 //	@Summary		List loadouts
-//	@Description	Get all loadouts for the authenticated user
+//	@Description	Get the loadouts of the authenticated user, sorted by name unless another field is given
 //	@Security		BearerAuth
 //	@Tags			Loadouts
 //	@Accept			json
 //	@Produce		json
-//	@Success		200	{array}		models.Loadout
-//	@Failure		500	{object}	models.Error
+//	@Param			page		query		int			false	"Page number"								default(1)
+//	@Param			limit		query		int			false	"Number of items per page"					default(30)
+//	@Param			sort		query		string		false	"Field to sort by, json or db field name"	default(loadout_name)
+//	@Param			order		query		string		false	"Sort direction, asc or desc"				default(asc)
+//	@Param			cursor		query		string		false	"Opaque cursor from next_cursor or prev_cursor, replaces page"
+//	@Param			count		query		bool		false	"Include total item count"	default(true)
+//	@Param			units		query		string		false	"Also give total_weight in metric or imperial units, overriding the user's preference"
+//	@Param			tag			query		[]string	false	"Loadout tags"									collectionFormat(multi)
+//	@Param			tag_match	query		string		false	"Match loadouts with any or all of the tags"	default(any)
+//	@Success		200			{object}	models.ResponsePayload{items=[]models.Loadout}
+//	@Failure		400			{object}	models.Error
+//	@Failure		500			{object}	models.Error
 //	@Router			/api/v1/loadout/list [get]
 func ListLoadouts(c *gin.Context) {
 	log := c.MustGet("logger").(*zap.SugaredLogger)
 	db := c.MustGet("db").(*sql.DB)
 	userID := c.MustGet("user_id_int64").(int64)
 
-	results, err := LoadoutListByUser(db, userID)
-	if err != nil {
-		log.Errorf("Error listing loadouts: %#v", err)
-		c.IndentedJSON(http.StatusInternalServerError, models.Error{Error: err.Error()})
+	query := utils.NewListQuery[models.Loadout]("loadouts").SortDefault("loadout_name")
+	query.Where("loadouts.userId = ?", userID)
+
+	if err := utils.FilterTags(query, utils.LoadoutTagTarget, c.Request.URL.Query()); err != nil {
+		respondListQueryError(c, log, err)
 		return
 	}
 
-	if results == nil {
-		c.IndentedJSON(http.StatusOK, []models.Loadout{})
+	if err := query.FilterFields(c.Request.URL.Query()); err != nil {
+		respondListQueryError(c, log, err)
 		return
 	}
 
-	c.IndentedJSON(http.StatusOK, results)
+	respondList(c, log, db, query)
 }
 
 // GetLoadout returns a single loadout by ID.
@@ -113,7 +124,8 @@ func ListLoadouts(c *gin.Context) {
 //	@Tags			Loadouts
 //	@Accept			json
 //	@Produce		json
-//	@Param			loadout	path		int	true	"Loadout ID"
+//	@Param			loadout	path		int		true	"Loadout ID"
+//	@Param			units	query		string	false	"Also give total_weight in metric or imperial units, overriding the user's preference"
 //	@Success		200		{object}	models.Loadout
 //	@Failure		404		{object}	models.Error
 //	@Router			/api/v1/loadout/{loadout}/get [get]
@@ -137,6 +149,17 @@ func GetLoadout(c *gin.Context) {
 		return
 	}
 
+	if !measureItems(c, log, db, loadout) {
+		return
+	}
+
+	loadout.Tags, err = utils.Tags(db, utils.LoadoutTagTarget, *loadout.LoadoutID)
+	if err != nil {
+		log.Errorf("Error getting loadout tags: %#v", err)
+		c.IndentedJSON(http.StatusInternalServerError, models.Error{Error: err.Error()})
+		return
+	}
+
 	c.IndentedJSON(http.StatusOK, loadout)
 }
 
@@ -0,0 +1,95 @@
+package endpoints
+
+import (
+	"database/sql"
+	"net/http"
+
+	models "github.com/Sea-Shell/gogear-api/pkg/models"
+	utils "github.com/Sea-Shell/gogear-api/pkg/utils"
+
+	gin "github.com/gin-gonic/gin"
+	zap "go.uber.org/zap"
+)
+
+// localeSettings returns the configured default locale and the locales responses can be
+// translated to, which always include the default when any are configured.
+func localeSettings(c *gin.Context) (string, []string) {
+	defaultLocale := utils.DefaultLocale
+	var locales []string
+	if value, ok := c.Get("config"); ok {
+		general := value.(*models.Config).General
+		if locale, err := utils.NormalizeLocale(general.DefaultLocale); err == nil {
+			defaultLocale = locale
+		}
+		for _, configured := range general.Locales {
+			if locale, err := utils.NormalizeLocale(configured); err == nil {
+				locales = append(locales, locale)
+			}
+		}
+	}
+	if len(locales) > 0 {
+		locales = append(locales, defaultLocale)
+	}
+	return defaultLocale, locales
+}
+
+// requestLocale returns the locale a response is translated to: the authenticated user's
+// preference, else the best match of the Accept-Language header, else the default locale.
+func requestLocale(c *gin.Context, db *sql.DB) (string, error) {
+	defaultLocale, locales := localeSettings(c)
+
+	if userID, ok := c.Get("user_id_int64"); ok {
+		var preference sql.NullString
+		err := db.QueryRow("SELECT locale FROM user_preferences WHERE userId = ?", userID).Scan(&preference)
+		if err != nil && err != sql.ErrNoRows {
+			return "", err
+		}
+		if preference.Valid {
+			if locale := utils.MatchLocale(preference.String, locales); locale != "" {
+				return locale, nil
+			}
+		}
+	}
+
+	if locale := utils.MatchLocale(c.GetHeader("Accept-Language"), locales); locale != "" {
+		return locale, nil
+	}
+	return defaultLocale, nil
+}
+
+// translationLocale sets Content-Language to the request's locale and returns the locale
+// to translate the response to, which is empty when the catalog text is already in it. It
+// writes a 500 and returns false on failure.
+func translationLocale(c *gin.Context, log *zap.SugaredLogger, db *sql.DB) (string, bool) {
+	locale, err := requestLocale(c, db)
+	if err != nil {
+		log.Errorf("Unable to load locale preference: %#v", err)
+		c.IndentedJSON(http.StatusInternalServerError, models.Error{Error: err.Error()})
+		return "", false
+	}
+
+	c.Header("Content-Language", locale)
+	if defaultLocale, _ := localeSettings(c); utils.SameLanguage(locale, defaultLocale) {
+		return "", true
+	}
+	return locale, true
+}
+
+// localizeItems translates the catalog names of items to the request's locale. It writes a
+// 500 and returns false on failure.
+func localizeItems[model any](c *gin.Context, log *zap.SugaredLogger, db *sql.DB, items ...*model) bool {
+	if !utils.HasTranslations[model]() {
+		return true
+	}
+
+	locale, ok := translationLocale(c, log, db)
+	if !ok || locale == "" {
+		return ok
+	}
+	if err := utils.Translate(db, locale, items...); err != nil {
+		log.Errorf("Unable to translate response: %#v", err)
+		c.IndentedJSON(http.StatusInternalServerError, models.Error{Error: err.Error()})
+		return false
+	}
+	return true
+}
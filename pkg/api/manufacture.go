@@ -2,14 +2,14 @@ package endpoints
 
 import (
 	"database/sql"
+	"encoding/json"
 	"fmt"
 	"io"
-	"math"
 	"net/http"
 	"net/url"
-	"reflect"
 	"strconv"
 	"strings"
+	"time"
 
 	models "github.com/Sea-Shell/gogear-api/pkg/models"
 	utils "github.com/Sea-Shell/gogear-api/pkg/utils"
@@ -19,6 +19,12 @@ import (
 	zap "go.uber.org/zap"
 )
 
+const (
+	maxManufactureURLLength         = 500
+	maxManufactureDescriptionLength = 4000
+	minManufactureFoundedYear       = 1000
+)
+
 // @Summary		Get manufacture by ID
 // @Description	Get manufacture spessific to ID
 // @Security		BearerAuth
@@ -38,21 +44,14 @@ func GetManufacture(c *gin.Context) {
 
 	manufacture := c.Param(function)
 
-	var paramManufacturer models.Manufacture
-	fields := utils.GetDBFieldNames(reflect.TypeOf(paramManufacturer))
-
-	baseQuery := fmt.Sprintf("SELECT %s FROM manufacture WHERE manufactureID = ? LIMIT 1", strings.Join(fields, ", "))
-
-	row := db.QueryRow(baseQuery, manufacture)
-
-	dest, err := utils.GetScanFields(paramManufacturer)
+	manufactureID, err := strconv.Atoi(manufacture)
 	if err != nil {
-		log.Errorf("Error getting destination arguments: %#v", err)
-		c.IndentedJSON(http.StatusInternalServerError, models.Error{Error: err.Error()})
+		log.Errorf("urlParamter is of wrong type: %#v", err)
+		c.IndentedJSON(http.StatusBadRequest, models.Error{Error: err.Error()})
 		return
 	}
 
-	err = row.Scan(dest...)
+	result, err := utils.GenericGet[models.Manufacture]("manufacture", manufactureID, nil, db)
 	if err != nil {
 		if err == sql.ErrNoRows {
 			log.Errorf("gear with id: %v not found", manufacture)
@@ -63,35 +62,84 @@ func GetManufacture(c *gin.Context) {
 		c.IndentedJSON(http.StatusInternalServerError, models.Error{Error: err.Error()})
 		return
 	}
-
-	for i := 0; i < reflect.TypeOf(paramManufacturer).NumField(); i++ {
-		reflect.ValueOf(&paramManufacturer).Elem().Field(i).Set(reflect.ValueOf(dest[i]).Elem())
-	}
+	paramManufacturer := *result
 
 	log.Infof("successfully fetched ManufactureID: %s, ManufactureName: %s", paramManufacturer.ManufactureID, paramManufacturer.ManufactureName)
 	c.IndentedJSON(http.StatusOK, paramManufacturer)
 }
 
+// @Summary		Get manufacture details
+// @Description	Get a manufacturer profile with stats aggregated from the catalog: gear count per top category, the lightest and heaviest gear, and how many users own its gear
+// @Security		BearerAuth
+// @Tags			Manufacture
+// @Produce		json
+// @Param			manufacture	path		int	true	"Unique ID of manufacture you want to get"
+// @Success		200			{object}	models.ManufactureDetail
+// @Failure		400			{object}	models.Error
+// @Failure		404			{object}	models.Error
+// @Failure		500			{object}	models.Error
+// @Router			/api/v1/manufacture/{manufacture}/details [get]
+func GetManufactureDetails(c *gin.Context) {
+	c.Header("Content-Type", "application/json")
+
+	log := c.MustGet("logger").(*zap.SugaredLogger)
+	db := c.MustGet("db").(*sql.DB)
+
+	manufactureID, err := strconv.ParseInt(c.Param("manufacture"), 10, 64)
+	if err != nil {
+		log.Errorf("urlParamter is of wrong type: %#v", err)
+		c.IndentedJSON(http.StatusBadRequest, models.Error{Error: err.Error()})
+		return
+	}
+
+	detail, err := utils.ManufactureDetails(db, manufactureID)
+	if err == sql.ErrNoRows {
+		c.IndentedJSON(http.StatusNotFound, models.Error{Error: "Manufacture not found"})
+		return
+	}
+	if err != nil {
+		log.Errorf("Unable to get manufacture details: %#v", err)
+		c.IndentedJSON(http.StatusInternalServerError, models.Error{Error: err.Error()})
+		return
+	}
+
+	topCategories := make([]*models.ManufactureCategoryCount, len(detail.TopCategories))
+	for i := range detail.TopCategories {
+		topCategories[i] = &detail.TopCategories[i]
+	}
+	var gear []*models.ManufactureGear
+	for _, item := range []*models.ManufactureGear{detail.LightestGear, detail.HeaviestGear} {
+		if item != nil {
+			gear = append(gear, item)
+		}
+	}
+	if !localizeItems(c, log, db, topCategories...) || !localizeItems(c, log, db, gear...) {
+		return
+	}
+
+	c.IndentedJSON(http.StatusOK, detail)
+}
+
 // @Summary		List manufacture
-// @Description	Get a list of manufacturers
+// @Description	Get a list of manufacturers with their catalog stats. Besides the profile fields, the list can be sorted by gear_count, owner_count, lightest_weight and heaviest_weight.
 // @Security		BearerAuth
 // @Tags			Manufacture
 // @Accept			json
 // @Produce		json
 // @Param			page			query		int		false	"Page number"				default(1)
 // @Param			limit			query		int		false	"Number of items per page"	default(30)
+// @Param			sort			query		string	false	"Field to sort by, json or db field name"
+// @Param			order			query		string	false	"Sort direction, asc or desc"	default(asc)
+// @Param			cursor			query		string	false	"Opaque cursor from next_cursor or prev_cursor, replaces page"
+// @Param			count			query		bool	false	"Include total item count"	default(true)
 // @Param			manufacture		query		string	false	"search by manufacturename (this is case insensitive and wildcard)"
 // @Param			manufacturename	query		string	false	"search by manufactures full name (this is case insensitive and wildcard)"
-// @Success		200				{object}	models.ResponsePayload{items=[]models.Manufacture}
+// @Success		200				{object}	models.ResponsePayload{items=[]models.ManufactureListItem}
 // @Failure		default			{object}	models.Error
 // @Router			/api/v1/manufacture/list [get]
 func ListManufacture(c *gin.Context) {
 	c.Header("Content-Type", "application/json")
 
-	currentQueryParameters := c.Request.URL.Query()
-
-	page := c.Query("page")
-	limit := c.Query("limit")
 	manufacturers := c.QueryArray("manufacture")
 
 	log := c.MustGet("logger").(*zap.SugaredLogger)
@@ -99,39 +147,9 @@ func ListManufacture(c *gin.Context) {
 
 	log.Debugf("Request parameters: %#v", c.Request.URL.Query())
 
-	if limit == "" {
-		limit = "30"
-	}
-
-	if page == "" || page == "0" {
-		page = "1"
-	}
-
-	pageInt, err := strconv.Atoi(page)
-	if err != nil {
-		log.Errorf("Error setting page to int: %#v", err)
-		c.IndentedJSON(http.StatusInternalServerError, models.Error{Error: err.Error()})
-		return
-	}
-
-	limitInt, err := strconv.Atoi(limit)
-	if err != nil {
-		log.Errorf("Error setting limit to int: %#v", err)
-		c.IndentedJSON(http.StatusInternalServerError, models.Error{Error: err.Error()})
-		return
-	}
-
-	if pageInt <= 0 {
-		log.Errorf("Error page is less than 0: %#v", err)
-		c.IndentedJSON(http.StatusBadRequest, models.Error{Error: "Invalid page number"})
-		return
-	}
-
-	if limitInt <= 0 {
-		log.Errorf("Error limit is less than 0: %#v", err)
-		c.IndentedJSON(http.StatusBadRequest, models.Error{Error: "Invalid limit number"})
-		return
-	}
+	query := utils.NewListQuery[models.ManufactureListItem]("manufacture"+utils.ManufactureStatsJoins).
+		Select("gear_count", "COALESCE(manufacture_stats.gearCount, 0)").
+		Select("owner_count", "COALESCE(manufacture_owners.ownerCount, 0)")
 
 	conditions := []string{}
 	manufactureParams := []interface{}{}
@@ -146,118 +164,18 @@ func ListManufacture(c *gin.Context) {
 		}
 	}
 
-	whereClause := ""
-	if len(conditions) > 0 {
-		whereClause = " WHERE " + strings.Join(conditions, " OR ")
-	}
-
-	baseCountQuery := "SELECT COUNT(*) FROM manufacture"
-	countQuery := baseCountQuery + whereClause
-
-	var totalCount int
-	err = db.QueryRow(countQuery, manufactureParams...).Scan(&totalCount)
-	if err != nil {
-		log.Errorf("Error getting GearCount database: %#v", err)
-		c.IndentedJSON(http.StatusInternalServerError, models.Error{Error: err.Error()})
-		return
-	}
-
-	start := strconv.Itoa((pageInt - 1) * limitInt)
-	totalPages := int(math.Ceil(float64(totalCount) / float64(limitInt)))
+	query.WhereAny(conditions, manufactureParams...)
 
-	startInt, err := strconv.Atoi(start)
-	if err != nil {
-		c.IndentedJSON(http.StatusInternalServerError, models.Error{Error: err.Error()})
+	if err := query.FilterFields(c.Request.URL.Query()); err != nil {
+		respondListQueryError(c, log, err)
 		return
 	}
 
-	var paramManufacturer models.Manufacture
-	fields := utils.GetDBFieldNames(reflect.TypeOf(paramManufacturer))
-
-	baseQuery := fmt.Sprintf(`SELECT %s FROM manufacture`, strings.Join(fields, ", "))
-
-	queryLimit := " LIMIT ?, ?"
-
-	query := baseQuery + whereClause + queryLimit
-
-	log.Debugf("Query: %s | Params: %#v", query, append(manufactureParams, startInt, limitInt))
-
-	rows, err := db.Query(query, append(manufactureParams, startInt, limitInt)...)
-	if err != nil {
-		log.Errorf("Query error: %#v", err.Error())
-		c.IndentedJSON(http.StatusInternalServerError, models.Error{Error: err.Error()})
-		return
-	}
-	defer rows.Close()
-
-	dest, err := utils.GetScanFields(paramManufacturer)
-	if err != nil {
-		log.Errorf("Error getting destination arguments: %#v", err)
-		c.IndentedJSON(http.StatusInternalServerError, models.Error{Error: err.Error()})
-		return
-	}
-
-	var manufactureList []models.Manufacture
-
-	for rows.Next() {
-		err = rows.Scan(dest...)
-		if err != nil {
-			if err == sql.ErrNoRows {
-				log.Errorf("No gear found")
-				c.IndentedJSON(http.StatusNotFound, models.Error{Error: "No results"})
-				return
-			}
-			log.Errorf("Scan error: %#v", err)
-			c.IndentedJSON(http.StatusInternalServerError, models.Error{Error: err.Error()})
-			return
-		}
-
-		for i := 0; i < reflect.TypeOf(paramManufacturer).NumField(); i++ {
-			reflect.ValueOf(&paramManufacturer).Elem().Field(i).Set(reflect.ValueOf(dest[i]).Elem())
-		}
-
-		manufactureList = append(manufactureList, paramManufacturer)
-	}
-
-	if err := rows.Err(); err != nil {
-		log.Errorf("Row iteration error: %#v", err)
-		c.IndentedJSON(http.StatusInternalServerError, models.Error{Error: err.Error()})
-		return
-	}
-
-	payload := models.ResponsePayload{
-		TotalItemCount: totalCount,
-		CurrentPage:    pageInt,
-		ItemLimit:      limitInt,
-		TotalPages:     totalPages,
-		Items:          manufactureList,
-	}
-
-	if pageInt < totalPages {
-		currentQueryParameters.Set("page", strconv.Itoa(pageInt+1))
-		nextPage := url.URL{
-			Path:     c.Request.URL.Path,
-			RawQuery: currentQueryParameters.Encode(),
-		}
-		payload.NextPage = new(string)
-		*payload.NextPage = nextPage.String()
-	}
-
-	if pageInt > 1 {
-		currentQueryParameters.Set("page", strconv.Itoa(pageInt-1))
-		prevPage := url.URL{
-			Path:     c.Request.URL.Path,
-			RawQuery: currentQueryParameters.Encode(),
-		}
-		payload.PrevPage = new(string)
-		*payload.PrevPage = prevPage.String()
-	}
-
-	c.IndentedJSON(http.StatusOK, payload)
+	respondList(c, log, db, query)
 }
 
 // @Summary		Update manufacture with ID
-// @Description	Update manufacture identified by ID
+// @Description	Update manufacture identified by ID. Fields left out keep their value. manufacture_website and manufacture_logo_url are http(s) URLs and manufacture_country an ISO 3166-1 alpha-2 code.
 // @Security		BearerAuth
 // @Tags			Manufacture
 // @Accept			json
@@ -280,6 +198,30 @@ func UpdateManufacture(c *gin.Context) {
 		return
 	}
 
+	var target struct {
+		ManufactureID *int64 `json:"manufacture_id"`
+	}
+	if err := json.Unmarshal(data, &target); err != nil || target.ManufactureID == nil {
+		c.JSON(http.StatusBadRequest, models.Error{Error: "manufacture_id is required"})
+		return
+	}
+
+	manufacture, err := utils.GenericGet[models.Manufacture]("manufacture", int(*target.ManufactureID), nil, db)
+	if err == sql.ErrNoRows {
+		c.JSON(http.StatusNotFound, models.Error{Error: "Manufacture not found"})
+		return
+	}
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, models.Error{Error: err.Error()})
+		log.Error(err.Error())
+		return
+	}
+
+	data, ok := normalizeManufacture(c, log, data, manufacture)
+	if !ok {
+		return
+	}
+
 	err = utils.GenericUpdate[models.Manufacture]("manufacture", data, db)
 	if err != nil {
 		c.JSON(http.StatusInternalServerError, models.Error{Error: err.Error()})
@@ -291,7 +233,7 @@ func UpdateManufacture(c *gin.Context) {
 }
 
 // @Summary		Insert new manufacture
-// @Description	Insert new manufacture with corresponding values
+// @Description	Insert new manufacture with corresponding values. manufacture_website and manufacture_logo_url are http(s) URLs and manufacture_country an ISO 3166-1 alpha-2 code.
 // @Security		BearerAuth
 // @Tags			Manufacture
 // @Accept			json
@@ -313,6 +255,11 @@ func InsertManufacture(c *gin.Context) {
 		return
 	}
 
+	data, ok := normalizeManufacture(c, log, data, &models.Manufacture{})
+	if !ok {
+		return
+	}
+
 	createdObject, err := utils.GenericInsert[models.Manufacture]("manufacture", data, db)
 	if err != nil {
 		c.JSON(http.StatusInternalServerError, models.Error{Error: err.Error()})
@@ -324,13 +271,15 @@ func InsertManufacture(c *gin.Context) {
 }
 
 // @Summary		Delete manufacture with ID
-// @Description	Delete manufacture with corresponding ID value
+// @Description	Delete manufacture with corresponding ID value. Its gear must be moved first, or moved to reassign_to as part of the delete; otherwise the delete is refused with 409 and the gear count.
 // @Security		BearerAuth
 // @Tags			Manufacture
 // @Accept			json
 // @Produce		json
-// @Param			manufacture	path		int				true	"Unique ID of manufacture you want to update"
-// @Success		200			{object}	models.Status	"status: success when all goes well"
+// @Param			manufacture	path		int						true	"Unique ID of manufacture you want to update"
+// @Param			reassign_to	query		int						false	"Manufacture to move the gear to before deleting"
+// @Success		200			{object}	models.Status			"status: success when all goes well"
+// @Failure		409			{object}	models.DependentsError	"the manufacture is still in use"
 // @Failure		default		{object}	models.Error
 // @Router			/api/v1/manufacture/{manufacture}/delete [delete]
 func DeleteManufature(c *gin.Context) {
@@ -347,13 +296,108 @@ func DeleteManufature(c *gin.Context) {
 		return
 	}
 
-	result, err := utils.GenericDelete[models.Manufacture]("manufacture", urlParameter, db)
+	result, err := utils.GenericGet[models.Manufacture]("manufacture", urlParameter, nil, db)
+	if err == sql.ErrNoRows {
+		c.JSON(http.StatusNotFound, models.Error{Error: "Manufacture not found"})
+		return
+	}
 	if err != nil {
 		c.JSON(http.StatusInternalServerError, models.Error{Error: err.Error()})
 		log.Error(err.Error())
 		return
 	}
 
-	log.Infof("success! Manufacturer with manufacture_id %v and manufacture_name %s was deleted", result.ManufactureID, result.ManufactureName)
-	c.JSON(http.StatusOK, map[string]string{"status": fmt.Sprintf("success! Manufacturer with manufacture_id %v and manufacture_name %s was deleted", result.ManufactureID, result.ManufactureName)})
+	moved, ok := deleteReassigning(c, log, db, utils.ManufactureDeletion, int64(urlParameter))
+	if !ok {
+		return
+	}
+
+	log.Infof("success! Manufacturer with manufacture_id %v and manufacture_name %s was deleted%s", *result.ManufactureID, result.ManufactureName, reassignedSummary(moved))
+	c.JSON(http.StatusOK, map[string]string{"status": fmt.Sprintf("success! Manufacturer with manufacture_id %v and manufacture_name %s was deleted%s", *result.ManufactureID, result.ManufactureName, reassignedSummary(moved))})
+}
+
+// normalizeManufacture decodes a manufacture body over base, so fields left out keep
+// their value, checks the profile fields and returns the body to store.
+func normalizeManufacture(c *gin.Context, log *zap.SugaredLogger, data []byte, base *models.Manufacture) ([]byte, bool) {
+	if err := json.Unmarshal(data, base); err != nil {
+		c.JSON(http.StatusBadRequest, models.Error{Error: err.Error()})
+		return nil, false
+	}
+
+	if err := normalizeManufactureProfile(base); err != nil {
+		log.Warnf("Invalid manufacture profile: %s", err.Error())
+		c.JSON(http.StatusBadRequest, models.Error{Error: err.Error()})
+		return nil, false
+	}
+
+	data, err := json.Marshal(base)
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, models.Error{Error: err.Error()})
+		log.Error(err.Error())
+		return nil, false
+	}
+	return data, true
+}
+
+// normalizeManufactureProfile trims the profile fields, turning blank ones into NULL, and
+// checks the URLs, country code and founding year.
+func normalizeManufactureProfile(manufacture *models.Manufacture) error {
+	manufacture.ManufactureName = strings.TrimSpace(manufacture.ManufactureName)
+	if manufacture.ManufactureName == "" {
+		return fmt.Errorf("manufacture_name is required")
+	}
+
+	for _, field := range []struct {
+		name  string
+		value **string
+		limit int
+	}{
+		{"manufacture_website", &manufacture.ManufactureWebsite, maxManufactureURLLength},
+		{"manufacture_country", &manufacture.ManufactureCountry, maxManufactureURLLength},
+		{"manufacture_logo_url", &manufacture.ManufactureLogoURL, maxManufactureURLLength},
+		{"manufacture_description", &manufacture.ManufactureDescription, maxManufactureDescriptionLength},
+	} {
+		if *field.value == nil {
+			continue
+		}
+		trimmed := strings.TrimSpace(**field.value)
+		if trimmed == "" {
+			*field.value = nil
+			continue
+		}
+		if len(trimmed) > field.limit {
+			return fmt.Errorf("%s is longer than %d characters", field.name, field.limit)
+		}
+		*field.value = &trimmed
+	}
+
+	for _, field := range []struct {
+		name  string
+		value *string
+	}{
+		{"manufacture_website", manufacture.ManufactureWebsite},
+		{"manufacture_logo_url", manufacture.ManufactureLogoURL},
+	} {
+		if field.value == nil {
+			continue
+		}
+		parsed, err := url.Parse(*field.value)
+		if err != nil || (parsed.Scheme != "http" && parsed.Scheme != "https") || parsed.Host == "" {
+			return fmt.Errorf("%s %q is not an http or https URL", field.name, *field.value)
+		}
+	}
+
+	if manufacture.ManufactureCountry != nil {
+		country := strings.ToUpper(*manufacture.ManufactureCountry)
+		if len(country) != 2 || strings.Trim(country, "ABCDEFGHIJKLMNOPQRSTUVWXYZ") != "" {
+			return fmt.Errorf("manufacture_country %q is not a two letter ISO 3166-1 code", *manufacture.ManufactureCountry)
+		}
+		manufacture.ManufactureCountry = &country
+	}
+
+	if year := manufacture.ManufactureFoundedYear; year != nil && (*year < minManufactureFoundedYear || *year > int64(time.Now().Year())) {
+		return fmt.Errorf("manufacture_founded_year must be between %d and this year", minManufactureFoundedYear)
+	}
+
+	return nil
 }
@@ -0,0 +1,112 @@
+package endpoints
+
+import (
+	"encoding/json"
+	"net/http"
+	"testing"
+
+	models "github.com/Sea-Shell/gogear-api/pkg/models"
+)
+
+func TestManufactureProfiles(t *testing.T) {
+	db, router := setupCatalogTest(t, 1)
+	seedCatalog(t, db)
+	seedCatalogGear(t, db, 1, 1, "Nallo", 2400)
+	seedCatalogGear(t, db, 2, 1, "Akto", 1600)
+	seedCatalogGear(t, db, 3, 1, "Unweighed", 0)
+	seedUser(t, db, 2)
+
+	v1 := router.Group("/api/v1", testAuthMiddleware(1))
+	v1.PUT("/manufacture/insert", InsertManufacture)
+	v1.POST("/manufacture/:manufacture/update", UpdateManufacture)
+	v1.GET("/manufacture/:manufacture/get", GetManufacture)
+	v1.GET("/manufacture/:manufacture/details", GetManufactureDetails)
+
+	statements := []string{
+		`INSERT INTO gear_top_category (topCategoryId, topCategoryName) VALUES (2, 'Cooking')`,
+		`INSERT INTO gear_category (categoryId, categoryTopCategoryId, categoryName) VALUES (4, 2, 'Pots')`,
+		`INSERT INTO gear (gearId, gearTopCategoryId, gearCategoryId, gearManufactureId, gearName, gearWeight, gearHeight, gearLength, gearWidth, gearStatus)
+            VALUES (4, 2, 4, 1, 'Pot', 300, 0, 0, 0, 1)`,
+		`INSERT INTO user_gear_registrations (gearId, userId) VALUES (1, 1), (2, 1), (2, 2)`,
+	}
+	for _, statement := range statements {
+		if _, err := db.Exec(statement); err != nil {
+			t.Fatal(err)
+		}
+	}
+
+	insert := `{"manufacture_name":" MSR ","manufacture_website":"https://www.msrgear.com","manufacture_country":"us","manufacture_founded_year":1969}`
+	w := authRequest(t, router, http.MethodPut, "/api/v1/manufacture/insert", insert)
+	var created models.Manufacture
+	if err := json.Unmarshal(w.Body.Bytes(), &created); err != nil || w.Code != http.StatusOK {
+		t.Fatalf("InsertManufacture: %d %s", w.Code, w.Body.String())
+	}
+	if created.ManufactureName != "MSR" || *created.ManufactureCountry != "US" {
+		t.Errorf("InsertManufacture: profile not normalized: %s", w.Body.String())
+	}
+
+	for _, invalid := range []string{
+		`{"manufacture_name":"X","manufacture_website":"ftp://example.com"}`,
+		`{"manufacture_name":"X","manufacture_logo_url":"logo.png"}`,
+		`{"manufacture_name":"X","manufacture_country":"USA"}`,
+		`{"manufacture_name":"X","manufacture_founded_year":3000}`,
+		`{"manufacture_name":" "}`,
+	} {
+		if w := authRequest(t, router, http.MethodPut, "/api/v1/manufacture/insert", invalid); w.Code != http.StatusBadRequest {
+			t.Errorf("InsertManufacture %s: expected 400, got %d", invalid, w.Code)
+		}
+	}
+
+	update := `{"manufacture_id":` + itoa64(*created.ManufactureID) + `,"manufacture_name":"MSR","manufacture_description":"Stoves and tents"}`
+	if w := authRequest(t, router, http.MethodPost, "/api/v1/manufacture/"+itoa64(*created.ManufactureID)+"/update", update); w.Code != http.StatusOK {
+		t.Fatalf("UpdateManufacture: %d %s", w.Code, w.Body.String())
+	}
+	w = authRequest(t, router, http.MethodGet, "/api/v1/manufacture/"+itoa64(*created.ManufactureID)+"/get", "")
+	var updated models.Manufacture
+	if err := json.Unmarshal(w.Body.Bytes(), &updated); err != nil {
+		t.Fatal(err)
+	}
+	if updated.ManufactureWebsite == nil || *updated.ManufactureFoundedYear != 1969 || *updated.ManufactureDescription != "Stoves and tents" {
+		t.Errorf("UpdateManufacture: partial update lost profile fields: %s", w.Body.String())
+	}
+
+	w = authRequest(t, router, http.MethodGet, "/api/v1/manufacture/1/details", "")
+	var detail models.ManufactureDetail
+	if err := json.Unmarshal(w.Body.Bytes(), &detail); err != nil || w.Code != http.StatusOK {
+		t.Fatalf("GetManufactureDetails: %d %s", w.Code, w.Body.String())
+	}
+	if detail.ManufactureName != "Hilleberg" || detail.GearCount != 4 || detail.OwnerCount != 2 {
+		t.Errorf("GetManufactureDetails: unexpected counts %+v", detail)
+	}
+	if len(detail.TopCategories) != 2 || detail.TopCategories[0].TopCategoryName != "Shelter" || detail.TopCategories[0].GearCount != 3 {
+		t.Errorf("GetManufactureDetails: unexpected top categories %+v", detail.TopCategories)
+	}
+	if detail.LightestGear == nil || detail.LightestGear.GearName != "Pot" || detail.HeaviestGear == nil || detail.HeaviestGear.GearName != "Nallo" {
+		t.Errorf("GetManufactureDetails: unexpected extremes %+v / %+v", detail.LightestGear, detail.HeaviestGear)
+	}
+
+	w = authRequest(t, router, http.MethodGet, "/api/v1/manufacture/"+itoa64(*created.ManufactureID)+"/details", "")
+	detail = models.ManufactureDetail{}
+	if err := json.Unmarshal(w.Body.Bytes(), &detail); err != nil || detail.GearCount != 0 || detail.LightestGear != nil || detail.TopCategories == nil {
+		t.Errorf("GetManufactureDetails without gear: %d %s", w.Code, w.Body.String())
+	}
+	if w := authRequest(t, router, http.MethodGet, "/api/v1/manufacture/99/details", ""); w.Code != http.StatusNotFound {
+		t.Errorf("GetManufactureDetails: expected 404, got %d", w.Code)
+	}
+
+	w = authRequest(t, router, http.MethodGet, "/api/v1/manufacture/list?sort=gear_count&order=desc", "")
+	payload := decodeListPayload(t, w.Body.Bytes())
+	if len(payload.Items) != 2 || payload.Items[0]["manufacture_name"] != "Hilleberg" || payload.Items[0]["gear_count"].(float64) != 4 {
+		t.Fatalf("ListManufacture sort=gear_count: %s", w.Body.String())
+	}
+	if payload.Items[1]["gear_count"].(float64) != 0 || payload.Items[1]["lightest_weight"] != nil {
+		t.Errorf("ListManufacture: manufacturer without gear should have zero stats: %v", payload.Items[1])
+	}
+	if payload.Items[0]["lightest_weight"].(float64) != 300 || payload.Items[0]["owner_count"].(float64) != 2 {
+		t.Errorf("ListManufacture: unexpected stats %v", payload.Items[0])
+	}
+	w = authRequest(t, router, http.MethodGet, "/api/v1/manufacture/list?sort=owner_count", "")
+	if payload := decodeListPayload(t, w.Body.Bytes()); len(payload.Items) != 2 || payload.Items[0]["manufacture_name"] != "MSR" {
+		t.Errorf("ListManufacture sort=owner_count: %s", w.Body.String())
+	}
+}
@@ -0,0 +1,382 @@
+package endpoints
+
+import (
+	"database/sql"
+	"errors"
+	"net/http"
+	"strconv"
+
+	models "github.com/Sea-Shell/gogear-api/pkg/models"
+	utils "github.com/Sea-Shell/gogear-api/pkg/utils"
+
+	gin "github.com/gin-gonic/gin"
+	zap "go.uber.org/zap"
+)
+
+// revisionKindNames names the kinds of catalog entries with a revision history in error
+// messages.
+var revisionKindNames = map[string]string{
+	utils.RevisionKindGear:        "Gear",
+	utils.RevisionKindCategory:    "Category",
+	utils.RevisionKindTopCategory: "Top category",
+	utils.RevisionKindManufacture: "Manufacturer",
+}
+
+// ListGearRevisions lists the revisions of a gear
+//
+//	@Summary		List gear revisions
+//	@Description	Get every revision of a gear, newest first. Each holds the tracked values after a create, an update or a revert: gear_category_id, gear_manufacture_id, gear_is_container, gear_name, gear_size_definition and the weight and dimensions. Requires a JWT issued with the admin audience.
+//	@Security		BearerAuth
+//	@Tags			Revisions
+//	@Accept			json
+//	@Produce		json
+//	@Param			gear	path		int	true	"Unique ID of gear"
+//	@Success		200		{array}		models.CatalogRevision
+//	@Failure		default	{object}	models.Error
+//	@Router			/api/v1/gear/{gear}/revision/list [get]
+func ListGearRevisions(c *gin.Context) {
+	listRevisions(c, utils.RevisionKindGear, "gear")
+}
+
+// DiffGearRevisions compares two revisions of a gear
+//
+//	@Summary		Diff gear revisions
+//	@Description	Get the fields that differ from one revision of a gear to another, with their values in both. Without to, the revision is compared with the newest one. Requires a JWT issued with the admin audience.
+//	@Security		BearerAuth
+//	@Tags			Revisions
+//	@Accept			json
+//	@Produce		json
+//	@Param			gear	path		int	true	"Unique ID of gear"
+//	@Param			from	query		int	true	"Revision to compare from"
+//	@Param			to		query		int	false	"Revision to compare to, the newest by default"
+//	@Success		200		{object}	models.RevisionDiff
+//	@Failure		400		{object}	models.Error
+//	@Failure		403		{object}	models.Error
+//	@Failure		404		{object}	models.Error
+//	@Failure		500		{object}	models.Error
+//	@Router			/api/v1/gear/{gear}/revision/diff [get]
+func DiffGearRevisions(c *gin.Context) {
+	diffRevisions(c, utils.RevisionKindGear, "gear")
+}
+
+// RevertGearRevision restores an earlier revision of a gear
+//
+//	@Summary		Revert gear to revision
+//	@Description	Write the values of an earlier revision back to a gear. The revert is recorded as a new revision and the history in between is kept. The gear's category and manufacturer must still exist. Requires a JWT issued with the admin audience.
+//	@Security		BearerAuth
+//	@Tags			Revisions
+//	@Accept			json
+//	@Produce		json
+//	@Param			gear		path		int	true	"Unique ID of gear"
+//	@Param			revision	path		int	true	"Number of the revision to restore"
+//	@Success		200			{object}	models.CatalogRevision
+//	@Failure		400			{object}	models.Error
+//	@Failure		403			{object}	models.Error
+//	@Failure		404			{object}	models.Error
+//	@Failure		500			{object}	models.Error
+//	@Router			/api/v1/gear/{gear}/revision/{revision}/revert [post]
+func RevertGearRevision(c *gin.Context) {
+	revertRevision(c, utils.RevisionKindGear, "gear")
+}
+
+// ListCategoryRevisions lists the revisions of a category
+//
+//	@Summary		List category revisions
+//	@Description	Get every revision of a category, newest first. Each holds the tracked values after a create, an update or a revert: category_name, category_parent_id and category_top_category_id. Requires a JWT issued with the admin audience.
+//	@Security		BearerAuth
+//	@Tags			Revisions
+//	@Accept			json
+//	@Produce		json
+//	@Param			category	path		int	true	"Unique ID of category"
+//	@Success		200			{array}		models.CatalogRevision
+//	@Failure		default		{object}	models.Error
+//	@Router			/api/v1/category/{category}/revision/list [get]
+func ListCategoryRevisions(c *gin.Context) {
+	listRevisions(c, utils.RevisionKindCategory, "category")
+}
+
+// DiffCategoryRevisions compares two revisions of a category
+//
+//	@Summary		Diff category revisions
+//	@Description	Get the fields that differ from one revision of a category to another, with their values in both. Without to, the revision is compared with the newest one. Requires a JWT issued with the admin audience.
+//	@Security		BearerAuth
+//	@Tags			Revisions
+//	@Accept			json
+//	@Produce		json
+//	@Param			category	path		int	true	"Unique ID of category"
+//	@Param			from		query		int	true	"Revision to compare from"
+//	@Param			to			query		int	false	"Revision to compare to, the newest by default"
+//	@Success		200			{object}	models.RevisionDiff
+//	@Failure		400			{object}	models.Error
+//	@Failure		403			{object}	models.Error
+//	@Failure		404			{object}	models.Error
+//	@Failure		500			{object}	models.Error
+//	@Router			/api/v1/category/{category}/revision/diff [get]
+func DiffCategoryRevisions(c *gin.Context) {
+	diffRevisions(c, utils.RevisionKindCategory, "category")
+}
+
+// RevertCategoryRevision restores an earlier revision of a category
+//
+//	@Summary		Revert category to revision
+//	@Description	Write the values of an earlier revision back to a category. The revert is recorded as a new revision and the history in between is kept. A category that has moved returns to its former parent, or its former top category when it had none, along with everything below it. Requires a JWT issued with the admin audience.
+//	@Security		BearerAuth
+//	@Tags			Revisions
+//	@Accept			json
+//	@Produce		json
+//	@Param			category	path		int	true	"Unique ID of category"
+//	@Param			revision	path		int	true	"Number of the revision to restore"
+//	@Success		200			{object}	models.CatalogRevision
+//	@Failure		400			{object}	models.Error
+//	@Failure		403			{object}	models.Error
+//	@Failure		404			{object}	models.Error
+//	@Failure		500			{object}	models.Error
+//	@Router			/api/v1/category/{category}/revision/{revision}/revert [post]
+func RevertCategoryRevision(c *gin.Context) {
+	revertRevision(c, utils.RevisionKindCategory, "category")
+}
+
+// ListTopCategoryRevisions lists the revisions of a top category
+//
+//	@Summary		List top category revisions
+//	@Description	Get every revision of a top category, newest first. Each holds the tracked values after a create, an update or a revert: top_category_name and top_category_icon. Requires a JWT issued with the admin audience.
+//	@Security		BearerAuth
+//	@Tags			Revisions
+//	@Accept			json
+//	@Produce		json
+//	@Param			topCategory	path		int	true	"Unique ID of top category"
+//	@Success		200			{array}		models.CatalogRevision
+//	@Failure		default		{object}	models.Error
+//	@Router			/api/v1/topCategory/{topCategory}/revision/list [get]
+func ListTopCategoryRevisions(c *gin.Context) {
+	listRevisions(c, utils.RevisionKindTopCategory, "topCategory")
+}
+
+// DiffTopCategoryRevisions compares two revisions of a top category
+//
+//	@Summary		Diff top category revisions
+//	@Description	Get the fields that differ from one revision of a top category to another, with their values in both. Without to, the revision is compared with the newest one. Requires a JWT issued with the admin audience.
+//	@Security		BearerAuth
+//	@Tags			Revisions
+//	@Accept			json
+//	@Produce		json
+//	@Param			topCategory	path		int	true	"Unique ID of top category"
+//	@Param			from		query		int	true	"Revision to compare from"
+//	@Param			to			query		int	false	"Revision to compare to, the newest by default"
+//	@Success		200			{object}	models.RevisionDiff
+//	@Failure		400			{object}	models.Error
+//	@Failure		403			{object}	models.Error
+//	@Failure		404			{object}	models.Error
+//	@Failure		500			{object}	models.Error
+//	@Router			/api/v1/topCategory/{topCategory}/revision/diff [get]
+func DiffTopCategoryRevisions(c *gin.Context) {
+	diffRevisions(c, utils.RevisionKindTopCategory, "topCategory")
+}
+
+// RevertTopCategoryRevision restores an earlier revision of a top category
+//
+//	@Summary		Revert top category to revision
+//	@Description	Write the values of an earlier revision back to a top category. The revert is recorded as a new revision and the history in between is kept. Requires a JWT issued with the admin audience.
+//	@Security		BearerAuth
+//	@Tags			Revisions
+//	@Accept			json
+//	@Produce		json
+//	@Param			topCategory	path		int	true	"Unique ID of top category"
+//	@Param			revision	path		int	true	"Number of the revision to restore"
+//	@Success		200			{object}	models.CatalogRevision
+//	@Failure		400			{object}	models.Error
+//	@Failure		403			{object}	models.Error
+//	@Failure		404			{object}	models.Error
+//	@Failure		500			{object}	models.Error
+//	@Router			/api/v1/topCategory/{topCategory}/revision/{revision}/revert [post]
+func RevertTopCategoryRevision(c *gin.Context) {
+	revertRevision(c, utils.RevisionKindTopCategory, "topCategory")
+}
+
+// ListManufactureRevisions lists the revisions of a manufacturer
+//
+//	@Summary		List manufacturer revisions
+//	@Description	Get every revision of a manufacturer, newest first. Each holds the tracked values after a create, an update or a revert: the manufacturer's name and profile fields. Requires a JWT issued with the admin audience.
+//	@Security		BearerAuth
+//	@Tags			Revisions
+//	@Accept			json
+//	@Produce		json
+//	@Param			manufacture	path		int	true	"Unique ID of manufacturer"
+//	@Success		200			{array}		models.CatalogRevision
+//	@Failure		default		{object}	models.Error
+//	@Router			/api/v1/manufacture/{manufacture}/revision/list [get]
+func ListManufactureRevisions(c *gin.Context) {
+	listRevisions(c, utils.RevisionKindManufacture, "manufacture")
+}
+
+// DiffManufactureRevisions compares two revisions of a manufacturer
+//
+//	@Summary		Diff manufacturer revisions
+//	@Description	Get the fields that differ from one revision of a manufacturer to another, with their values in both. Without to, the revision is compared with the newest one. Requires a JWT issued with the admin audience.
+//	@Security		BearerAuth
+//	@Tags			Revisions
+//	@Accept			json
+//	@Produce		json
+//	@Param			manufacture	path		int	true	"Unique ID of manufacturer"
+//	@Param			from		query		int	true	"Revision to compare from"
+//	@Param			to			query		int	false	"Revision to compare to, the newest by default"
+//	@Success		200			{object}	models.RevisionDiff
+//	@Failure		400			{object}	models.Error
+//	@Failure		403			{object}	models.Error
+//	@Failure		404			{object}	models.Error
+//	@Failure		500			{object}	models.Error
+//	@Router			/api/v1/manufacture/{manufacture}/revision/diff [get]
+func DiffManufactureRevisions(c *gin.Context) {
+	diffRevisions(c, utils.RevisionKindManufacture, "manufacture")
+}
+
+// RevertManufactureRevision restores an earlier revision of a manufacturer
+//
+//	@Summary		Revert manufacturer to revision
+//	@Description	Write the values of an earlier revision back to a manufacturer. The revert is recorded as a new revision and the history in between is kept. Requires a JWT issued with the admin audience.
+//	@Security		BearerAuth
+//	@Tags			Revisions
+//	@Accept			json
+//	@Produce		json
+//	@Param			manufacture	path		int	true	"Unique ID of manufacturer"
+//	@Param			revision	path		int	true	"Number of the revision to restore"
+//	@Success		200			{object}	models.CatalogRevision
+//	@Failure		400			{object}	models.Error
+//	@Failure		403			{object}	models.Error
+//	@Failure		404			{object}	models.Error
+//	@Failure		500			{object}	models.Error
+//	@Router			/api/v1/manufacture/{manufacture}/revision/{revision}/revert [post]
+func RevertManufactureRevision(c *gin.Context) {
+	revertRevision(c, utils.RevisionKindManufacture, "manufacture")
+}
+
+// listRevisions responds with the revisions of the catalog entry in the route parameter
+// param.
+func listRevisions(c *gin.Context, kind string, param string) {
+	c.Header("Content-Type", "application/json")
+
+	log := c.MustGet("logger").(*zap.SugaredLogger)
+	db := c.MustGet("db").(*sql.DB)
+
+	if !requireRevisionAdmin(c, log) {
+		return
+	}
+	id, ok := revisionParam(c, param)
+	if !ok {
+		return
+	}
+
+	revisions, err := utils.Revisions(db, kind, id)
+	if err != nil {
+		respondRevisionError(c, log, err, revisionKindNames[kind]+" not found")
+		return
+	}
+
+	c.JSON(http.StatusOK, revisions)
+}
+
+// diffRevisions responds with the changes between the from and to revisions of the catalog
+// entry in the route parameter param.
+func diffRevisions(c *gin.Context, kind string, param string) {
+	c.Header("Content-Type", "application/json")
+
+	log := c.MustGet("logger").(*zap.SugaredLogger)
+	db := c.MustGet("db").(*sql.DB)
+
+	if !requireRevisionAdmin(c, log) {
+		return
+	}
+	id, ok := revisionParam(c, param)
+	if !ok {
+		return
+	}
+
+	from, err := strconv.ParseInt(c.Query("from"), 10, 64)
+	if err != nil || from < 1 {
+		c.JSON(http.StatusBadRequest, models.Error{Error: "from must be a revision number"})
+		return
+	}
+	var to int64
+	if value := c.Query("to"); value != "" {
+		if to, err = strconv.ParseInt(value, 10, 64); err != nil || to < 1 {
+			c.JSON(http.StatusBadRequest, models.Error{Error: "to must be a revision number"})
+			return
+		}
+	}
+
+	diff, err := utils.DiffRevisions(db, kind, id, from, to)
+	if err != nil {
+		respondRevisionError(c, log, err, revisionKindNames[kind]+" revision not found")
+		return
+	}
+
+	c.JSON(http.StatusOK, diff)
+}
+
+// revertRevision restores the revision in the revision route parameter of the catalog entry
+// in the route parameter param.
+func revertRevision(c *gin.Context, kind string, param string) {
+	c.Header("Content-Type", "application/json")
+
+	log := c.MustGet("logger").(*zap.SugaredLogger)
+	db := c.MustGet("db").(*sql.DB)
+
+	if !requireRevisionAdmin(c, log) {
+		return
+	}
+	id, ok := revisionParam(c, param)
+	if !ok {
+		return
+	}
+	number, ok := revisionParam(c, "revision")
+	if !ok {
+		return
+	}
+
+	revision, err := utils.RevertRevision(db, kind, id, number)
+	if err != nil {
+		respondRevisionError(c, log, err, revisionKindNames[kind]+" revision not found")
+		return
+	}
+
+	log.Infof("Reverted %s %d to revision %d as revision %d", kind, id, number, revision.Revision)
+	c.JSON(http.StatusOK, revision)
+}
+
+// requireRevisionAdmin writes a 403 and returns false unless the caller is an admin.
+func requireRevisionAdmin(c *gin.Context, log *zap.SugaredLogger) bool {
+	isAdmin, _ := c.Get("user_is_admin")
+	if adminFlag, ok := isAdmin.(bool); !ok || !adminFlag {
+		log.Warn("unauthorized revision access without admin privileges")
+		c.AbortWithStatusJSON(http.StatusForbidden, models.Error{Error: "admin privileges required"})
+		return false
+	}
+	return true
+}
+
+// revisionParam parses the ID in the route parameter param.
+func revisionParam(c *gin.Context, param string) (int64, bool) {
+	id, err := strconv.ParseInt(c.Param(param), 10, 64)
+	if err != nil {
+		c.JSON(http.StatusBadRequest, models.Error{Error: "invalid " + param + " ID"})
+		return 0, false
+	}
+	return id, true
+}
+
+// respondRevisionError writes a 400 for reverts that cannot be applied, a 404 with notFound
+// for sql.ErrNoRows and a 500 for everything else.
+func respondRevisionError(c *gin.Context, log *zap.SugaredLogger, err error, notFound string) {
+	var revisionErr *utils.RevisionError
+	if errors.As(err, &revisionErr) {
+		log.Warnf("Invalid revision request: %s", revisionErr.Message)
+		c.JSON(http.StatusBadRequest, models.Error{Error: revisionErr.Message})
+		return
+	}
+	if errors.Is(err, sql.ErrNoRows) {
+		c.JSON(http.StatusNotFound, models.Error{Error: notFound})
+		return
+	}
+	log.Errorf("error handling revision: %#v", err)
+	c.JSON(http.StatusInternalServerError, models.Error{Error: err.Error()})
+}
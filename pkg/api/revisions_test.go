@@ -0,0 +1,169 @@
+package endpoints
+
+import (
+	"encoding/json"
+	"net/http"
+	"testing"
+
+	models "github.com/Sea-Shell/gogear-api/pkg/models"
+
+	"github.com/gin-gonic/gin"
+)
+
+func TestCatalogRevisions(t *testing.T) {
+	db, router := setupCatalogTest(t, 1)
+	seedCatalog(t, db)
+	seedCatalogGear(t, db, 1, 1, "Tent", 2400)
+	if _, err := db.Exec(`INSERT INTO gear_top_category (topCategoryId, topCategoryName) VALUES (2, 'Packs')`); err != nil {
+		t.Fatal(err)
+	}
+
+	isAdmin := true
+	v1 := router.Group("/api/v1", testAuthMiddleware(1), func(c *gin.Context) {
+		c.Set("user_is_admin", isAdmin)
+		c.Next()
+	})
+	v1.POST("/gear/:gear/update", UpdateGear)
+	v1.GET("/gear/:gear/revision/list", ListGearRevisions)
+	v1.GET("/gear/:gear/revision/diff", DiffGearRevisions)
+	v1.POST("/gear/:gear/revision/:revision/revert", RevertGearRevision)
+	v1.POST("/category/:category/move", MoveCategory)
+	v1.GET("/category/:category/revision/list", ListCategoryRevisions)
+	v1.POST("/category/:category/revision/:revision/revert", RevertCategoryRevision)
+	v1.GET("/topCategory/:topCategory/revision/diff", DiffTopCategoryRevisions)
+
+	listRevisions := func(path string) []models.CatalogRevision {
+		t.Helper()
+		w := authRequest(t, router, http.MethodGet, path, "")
+		var revisions []models.CatalogRevision
+		if err := json.Unmarshal(w.Body.Bytes(), &revisions); err != nil || w.Code != http.StatusOK {
+			t.Fatalf("%s: %d %s", path, w.Code, w.Body.String())
+		}
+		return revisions
+	}
+
+	tent := `"gear_id":1,"gear_top_category_id":1,"gear_manufacture_id":1,"gear_name":"Tent"`
+	for _, body := range []string{`{` + tent + `,"gear_category_id":1,"gear_weight":2100}`, `{` + tent + `,"gear_category_id":2,"gear_weight":2100}`} {
+		if w := authRequest(t, router, http.MethodPost, "/api/v1/gear/1/update", body); w.Code != http.StatusOK {
+			t.Fatalf("UpdateGear: %d %s", w.Code, w.Body.String())
+		}
+	}
+	// An update that changes nothing is not a revision.
+	if w := authRequest(t, router, http.MethodPost, "/api/v1/gear/1/update", `{`+tent+`,"gear_category_id":2,"gear_weight":2100}`); w.Code != http.StatusOK {
+		t.Fatalf("UpdateGear: %d %s", w.Code, w.Body.String())
+	}
+
+	revisions := listRevisions("/api/v1/gear/1/revision/list")
+	if len(revisions) != 3 || revisions[0].Revision != 3 || revisions[0].Action != "update" || revisions[2].Action != "create" {
+		t.Fatalf("ListGearRevisions: unexpected revisions %+v", revisions)
+	}
+	if revisions[2].Data["gear_weight"] != float64(2400) || revisions[1].Data["gear_weight"] != float64(2100) || revisions[0].Data["gear_category_id"] != float64(2) {
+		t.Errorf("ListGearRevisions: unexpected values %+v", revisions)
+	}
+
+	w := authRequest(t, router, http.MethodGet, "/api/v1/gear/1/revision/diff?from=1", "")
+	var diff models.RevisionDiff
+	if err := json.Unmarshal(w.Body.Bytes(), &diff); err != nil || w.Code != http.StatusOK {
+		t.Fatalf("DiffGearRevisions: %d %s", w.Code, w.Body.String())
+	}
+	if diff.To != 3 || len(diff.Changes) != 2 || diff.Changes[0].Field != "gear_category_id" || diff.Changes[1].Field != "gear_weight" ||
+		diff.Changes[1].From != float64(2400) || diff.Changes[1].To != float64(2100) {
+		t.Errorf("DiffGearRevisions: unexpected diff %+v", diff)
+	}
+	if err := json.Unmarshal(authRequest(t, router, http.MethodGet, "/api/v1/gear/1/revision/diff?from=3&to=2", "").Body.Bytes(), &diff); err != nil ||
+		len(diff.Changes) != 1 || diff.Changes[0].From != float64(2) || diff.Changes[0].To != float64(1) {
+		t.Errorf("DiffGearRevisions from a newer revision: unexpected diff %+v (%v)", diff, err)
+	}
+
+	w = authRequest(t, router, http.MethodPost, "/api/v1/gear/1/revision/1/revert", "")
+	var reverted models.CatalogRevision
+	if err := json.Unmarshal(w.Body.Bytes(), &reverted); err != nil || w.Code != http.StatusOK {
+		t.Fatalf("RevertGearRevision: %d %s", w.Code, w.Body.String())
+	}
+	if reverted.Revision != 4 || reverted.Action != "revert" || reverted.RestoredRevision == nil || *reverted.RestoredRevision != 1 {
+		t.Errorf("RevertGearRevision: unexpected revision %+v", reverted)
+	}
+	var categoryID, weight int64
+	if err := db.QueryRow(`SELECT gearCategoryId, gearWeight FROM gear WHERE gearId = 1`).Scan(&categoryID, &weight); err != nil || categoryID != 1 || weight != 2400 {
+		t.Errorf("RevertGearRevision: expected category 1 and 2400 g, got %d and %d (%v)", categoryID, weight, err)
+	}
+	if revisions := listRevisions("/api/v1/gear/1/revision/list"); len(revisions) != 4 || revisions[1].Revision != 3 {
+		t.Errorf("RevertGearRevision: expected the earlier revisions to be kept, got %+v", revisions)
+	}
+
+	// Moving category 3 below category 1 is one revision; moving category 1 to another top
+	// category takes category 3 and the gear in category 1 along.
+	if w := authRequest(t, router, http.MethodPost, "/api/v1/category/3/move", `{"parent_id":1}`); w.Code != http.StatusOK {
+		t.Fatalf("MoveCategory: %d %s", w.Code, w.Body.String())
+	}
+	if w := authRequest(t, router, http.MethodPost, "/api/v1/category/1/move", `{"top_category_id":2}`); w.Code != http.StatusOK {
+		t.Fatalf("MoveCategory: %d %s", w.Code, w.Body.String())
+	}
+	if revisions := listRevisions("/api/v1/category/3/revision/list"); len(revisions) != 3 ||
+		revisions[1].Data["category_parent_id"] != float64(1) || revisions[0].Data["category_top_category_id"] != float64(2) {
+		t.Errorf("ListCategoryRevisions: unexpected revisions %+v", revisions)
+	}
+	if revisions := listRevisions("/api/v1/category/1/revision/list"); len(revisions) != 2 {
+		t.Errorf("ListCategoryRevisions: expected a move to be one revision, got %+v", revisions)
+	}
+
+	if w := authRequest(t, router, http.MethodPost, "/api/v1/category/1/revision/1/revert", ""); w.Code != http.StatusOK {
+		t.Fatalf("RevertCategoryRevision: %d %s", w.Code, w.Body.String())
+	}
+	var subtreeTop, gearTop int64
+	if err := db.QueryRow(`SELECT categoryTopCategoryId FROM gear_category WHERE categoryId = 3`).Scan(&subtreeTop); err != nil || subtreeTop != 1 {
+		t.Errorf("RevertCategoryRevision: expected category 3 back in top category 1, got %d (%v)", subtreeTop, err)
+	}
+	if err := db.QueryRow(`SELECT gearTopCategoryId FROM gear WHERE gearId = 1`).Scan(&gearTop); err != nil || gearTop != 1 {
+		t.Errorf("RevertCategoryRevision: expected gear 1 back in top category 1, got %d (%v)", gearTop, err)
+	}
+	if w := authRequest(t, router, http.MethodPost, "/api/v1/category/1/revision/1/revert", ""); w.Code != http.StatusBadRequest {
+		t.Errorf("RevertCategoryRevision: expected 400 when already at the revision, got %d", w.Code)
+	}
+
+	// Changes made outside the API are recorded too.
+	if _, err := db.Exec(`UPDATE gear_top_category SET topCategoryName = 'Sleep', topCategorySortOrder = 3 WHERE topCategoryId = 1`); err != nil {
+		t.Fatal(err)
+	}
+	if err := json.Unmarshal(authRequest(t, router, http.MethodGet, "/api/v1/topCategory/1/revision/diff?from=1", "").Body.Bytes(), &diff); err != nil ||
+		diff.To != 2 || len(diff.Changes) != 1 || diff.Changes[0].From != "Shelter" || diff.Changes[0].To != "Sleep" {
+		t.Errorf("DiffTopCategoryRevisions: unexpected diff %+v (%v)", diff, err)
+	}
+
+	if _, err := db.Exec(`DELETE FROM gear_category WHERE categoryId = 2`); err != nil {
+		t.Fatal(err)
+	}
+	for path, want := range map[string]int{
+		"/api/v1/gear/1/revision/3/revert":  http.StatusBadRequest,
+		"/api/v1/gear/1/revision/9/revert":  http.StatusNotFound,
+		"/api/v1/gear/99/revision/1/revert": http.StatusNotFound,
+		"/api/v1/gear/1/revision/x/revert":  http.StatusBadRequest,
+	} {
+		if w := authRequest(t, router, http.MethodPost, path, ""); w.Code != want {
+			t.Errorf("%s: expected %d, got %d %s", path, want, w.Code, w.Body.String())
+		}
+	}
+	for path, want := range map[string]int{
+		"/api/v1/gear/1/revision/diff":             http.StatusBadRequest,
+		"/api/v1/gear/1/revision/diff?from=1&to=9": http.StatusNotFound,
+		"/api/v1/gear/99/revision/list":            http.StatusNotFound,
+	} {
+		if w := authRequest(t, router, http.MethodGet, path, ""); w.Code != want {
+			t.Errorf("%s: expected %d, got %d %s", path, want, w.Code, w.Body.String())
+		}
+	}
+
+	// Revisions go with the gear.
+	if _, err := db.Exec(`DELETE FROM gear WHERE gearId = 1`); err != nil {
+		t.Fatal(err)
+	}
+	var count int
+	if err := db.QueryRow(`SELECT COUNT(*) FROM catalog_revisions WHERE entityKind = 'gear' AND entityId = 1`).Scan(&count); err != nil || count != 0 {
+		t.Errorf("expected the revisions of deleted gear to be removed, got %d (%v)", count, err)
+	}
+
+	isAdmin = false
+	if w := authRequest(t, router, http.MethodGet, "/api/v1/category/1/revision/list", ""); w.Code != http.StatusForbidden {
+		t.Errorf("ListCategoryRevisions: expected 403 for a non-admin, got %d", w.Code)
+	}
+}
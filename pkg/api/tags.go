@@ -0,0 +1,435 @@
+package endpoints
+
+import (
+	"database/sql"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net/http"
+	"strconv"
+
+	models "github.com/Sea-Shell/gogear-api/pkg/models"
+	utils "github.com/Sea-Shell/gogear-api/pkg/utils"
+
+	gin "github.com/gin-gonic/gin"
+	zap "go.uber.org/zap"
+)
+
+// tagSubject is the gear, registration or loadout a tag route refers to.
+type tagSubject struct {
+	target utils.TagTarget
+	id     int64
+}
+
+// ListGearTags lists the catalog tags of a piece of gear.
+//
+//	@Summary		List gear tags
+//	@Description	List the catalog tags of a piece of gear
+//	@Security		BearerAuth
+//	@Tags			Tags
+//	@Produce		json
+//	@Param			gear	path		int	true	"Gear ID"
+//	@Success		200		{array}		string
+//	@Failure		400		{object}	models.Error
+//	@Failure		404		{object}	models.Error
+//	@Failure		500		{object}	models.Error
+//	@Router			/api/v1/gear/{gear}/tag/list [get]
+func ListGearTags(c *gin.Context) {
+	log := c.MustGet("logger").(*zap.SugaredLogger)
+	db := c.MustGet("db").(*sql.DB)
+
+	gearID, ok := gearVariantGear(c, log, db)
+	if !ok {
+		return
+	}
+
+	listTags(c, log, db, tagSubject{target: utils.GearTagTarget, id: gearID})
+}
+
+// InsertGearTags adds catalog tags to a piece of gear.
+//
+//	@Summary		Add gear tags
+//	@Description	Add catalog tags to a piece of gear. Tags are lower-cased and may use letters, digits, spaces, - and _. Requires a JWT issued with the admin audience.
+//	@Security		BearerAuth
+//	@Tags			Tags
+//	@Accept			json
+//	@Produce		json
+//	@Param			gear	path		int				true	"Gear ID"
+//	@Param			body	body		models.TagInput	true	"Tags to add"
+//	@Success		200		{array}		string
+//	@Failure		400		{object}	models.Error
+//	@Failure		403		{object}	models.Error
+//	@Failure		404		{object}	models.Error
+//	@Failure		500		{object}	models.Error
+//	@Router			/api/v1/gear/{gear}/tag/insert [put]
+func InsertGearTags(c *gin.Context) {
+	log := c.MustGet("logger").(*zap.SugaredLogger)
+	db := c.MustGet("db").(*sql.DB)
+
+	isAdmin, _ := c.Get("user_is_admin")
+	if adminFlag, ok := isAdmin.(bool); !ok || !adminFlag {
+		log.Warn("unauthorized gear tag insert attempt without admin privileges")
+		c.AbortWithStatusJSON(http.StatusForbidden, models.Error{Error: "admin privileges required"})
+		return
+	}
+
+	gearID, ok := gearVariantGear(c, log, db)
+	if !ok {
+		return
+	}
+
+	addTags(c, log, db, tagSubject{target: utils.GearTagTarget, id: gearID})
+}
+
+// DeleteGearTag removes a catalog tag from a piece of gear.
+//
+//	@Summary		Remove gear tag
+//	@Description	Remove a catalog tag from a piece of gear. Requires a JWT issued with the admin audience.
+//	@Security		BearerAuth
+//	@Tags			Tags
+//	@Produce		json
+//	@Param			gear	path		int		true	"Gear ID"
+//	@Param			tag		path		string	true	"Tag"
+//	@Success		200		{object}	models.Status
+//	@Failure		400		{object}	models.Error
+//	@Failure		403		{object}	models.Error
+//	@Failure		404		{object}	models.Error
+//	@Failure		500		{object}	models.Error
+//	@Router			/api/v1/gear/{gear}/tag/{tag}/delete [delete]
+func DeleteGearTag(c *gin.Context) {
+	log := c.MustGet("logger").(*zap.SugaredLogger)
+	db := c.MustGet("db").(*sql.DB)
+
+	isAdmin, _ := c.Get("user_is_admin")
+	if adminFlag, ok := isAdmin.(bool); !ok || !adminFlag {
+		log.Warn("unauthorized gear tag delete attempt without admin privileges")
+		c.AbortWithStatusJSON(http.StatusForbidden, models.Error{Error: "admin privileges required"})
+		return
+	}
+
+	gearID, ok := gearVariantGear(c, log, db)
+	if !ok {
+		return
+	}
+
+	removeTag(c, log, db, tagSubject{target: utils.GearTagTarget, id: gearID})
+}
+
+// ListUserGearTags lists the private tags of a gear registration.
+//
+//	@Summary		List registration tags
+//	@Description	List the private tags of one of your gear registrations
+//	@Security		BearerAuth
+//	@Tags			Tags
+//	@Produce		json
+//	@Param			usergear	path		int	true	"Registration ID"
+//	@Success		200			{array}		string
+//	@Failure		400			{object}	models.Error
+//	@Failure		403			{object}	models.Error
+//	@Failure		404			{object}	models.Error
+//	@Failure		500			{object}	models.Error
+//	@Router			/api/v1/usergear/registration/{usergear}/tag/list [get]
+func ListUserGearTags(c *gin.Context) {
+	log := c.MustGet("logger").(*zap.SugaredLogger)
+	db := c.MustGet("db").(*sql.DB)
+
+	subject, ok := registrationTagSubject(c, log, db)
+	if !ok {
+		return
+	}
+
+	listTags(c, log, db, subject)
+}
+
+// InsertUserGearTags adds private tags to a gear registration.
+//
+//	@Summary		Add registration tags
+//	@Description	Add private tags to one of your gear registrations. Tags are lower-cased and may use letters, digits, spaces, - and _.
+//	@Security		BearerAuth
+//	@Tags			Tags
+//	@Accept			json
+//	@Produce		json
+//	@Param			usergear	path		int				true	"Registration ID"
+//	@Param			body		body		models.TagInput	true	"Tags to add"
+//	@Success		200			{array}		string
+//	@Failure		400			{object}	models.Error
+//	@Failure		403			{object}	models.Error
+//	@Failure		404			{object}	models.Error
+//	@Failure		500			{object}	models.Error
+//	@Router			/api/v1/usergear/registration/{usergear}/tag/insert [put]
+func InsertUserGearTags(c *gin.Context) {
+	log := c.MustGet("logger").(*zap.SugaredLogger)
+	db := c.MustGet("db").(*sql.DB)
+
+	subject, ok := registrationTagSubject(c, log, db)
+	if !ok {
+		return
+	}
+
+	addTags(c, log, db, subject)
+}
+
+// DeleteUserGearTag removes a private tag from a gear registration.
+//
+//	@Summary		Remove registration tag
+//	@Description	Remove a private tag from one of your gear registrations
+//	@Security		BearerAuth
+//	@Tags			Tags
+//	@Produce		json
+//	@Param			usergear	path		int		true	"Registration ID"
+//	@Param			tag			path		string	true	"Tag"
+//	@Success		200			{object}	models.Status
+//	@Failure		400			{object}	models.Error
+//	@Failure		403			{object}	models.Error
+//	@Failure		404			{object}	models.Error
+//	@Failure		500			{object}	models.Error
+//	@Router			/api/v1/usergear/registration/{usergear}/tag/{tag}/delete [delete]
+func DeleteUserGearTag(c *gin.Context) {
+	log := c.MustGet("logger").(*zap.SugaredLogger)
+	db := c.MustGet("db").(*sql.DB)
+
+	subject, ok := registrationTagSubject(c, log, db)
+	if !ok {
+		return
+	}
+
+	removeTag(c, log, db, subject)
+}
+
+// ListLoadoutTags lists the private tags of a loadout.
+//
+//	@Summary		List loadout tags
+//	@Description	List the private tags of one of your loadouts
+//	@Security		BearerAuth
+//	@Tags			Tags
+//	@Produce		json
+//	@Param			loadout	path		int	true	"Loadout ID"
+//	@Success		200		{array}		string
+//	@Failure		400		{object}	models.Error
+//	@Failure		404		{object}	models.Error
+//	@Failure		500		{object}	models.Error
+//	@Router			/api/v1/loadout/{loadout}/tag/list [get]
+func ListLoadoutTags(c *gin.Context) {
+	log := c.MustGet("logger").(*zap.SugaredLogger)
+	db := c.MustGet("db").(*sql.DB)
+
+	subject, ok := loadoutTagSubject(c, log, db)
+	if !ok {
+		return
+	}
+
+	listTags(c, log, db, subject)
+}
+
+// InsertLoadoutTags adds private tags to a loadout.
+//
+//	@Summary		Add loadout tags
+//	@Description	Add private tags to one of your loadouts. Tags are lower-cased and may use letters, digits, spaces, - and _.
+//	@Security		BearerAuth
+//	@Tags			Tags
+//	@Accept			json
+//	@Produce		json
+//	@Param			loadout	path		int				true	"Loadout ID"
+//	@Param			body	body		models.TagInput	true	"Tags to add"
+//	@Success		200		{array}		string
+//	@Failure		400		{object}	models.Error
+//	@Failure		404		{object}	models.Error
+//	@Failure		500		{object}	models.Error
+//	@Router			/api/v1/loadout/{loadout}/tag/insert [put]
+func InsertLoadoutTags(c *gin.Context) {
+	log := c.MustGet("logger").(*zap.SugaredLogger)
+	db := c.MustGet("db").(*sql.DB)
+
+	subject, ok := loadoutTagSubject(c, log, db)
+	if !ok {
+		return
+	}
+
+	addTags(c, log, db, subject)
+}
+
+// DeleteLoadoutTag removes a private tag from a loadout.
+//
+//	@Summary		Remove loadout tag
+//	@Description	Remove a private tag from one of your loadouts
+//	@Security		BearerAuth
+//	@Tags			Tags
+//	@Produce		json
+//	@Param			loadout	path		int		true	"Loadout ID"
+//	@Param			tag		path		string	true	"Tag"
+//	@Success		200		{object}	models.Status
+//	@Failure		400		{object}	models.Error
+//	@Failure		404		{object}	models.Error
+//	@Failure		500		{object}	models.Error
+//	@Router			/api/v1/loadout/{loadout}/tag/{tag}/delete [delete]
+func DeleteLoadoutTag(c *gin.Context) {
+	log := c.MustGet("logger").(*zap.SugaredLogger)
+	db := c.MustGet("db").(*sql.DB)
+
+	subject, ok := loadoutTagSubject(c, log, db)
+	if !ok {
+		return
+	}
+
+	removeTag(c, log, db, subject)
+}
+
+// SuggestTags autocompletes tags.
+//
+//	@Summary		Suggest tags
+//	@Description	Autocomplete a tag prefix from the catalog gear tags and your own registration and loadout tags, most used first
+//	@Security		BearerAuth
+//	@Tags			Tags
+//	@Produce		json
+//	@Param			q		query		string	false	"Tag prefix"
+//	@Param			limit	query		int		false	"Maximum number of suggestions"	default(8)
+//	@Success		200		{array}		models.TagSuggestion
+//	@Failure		400		{object}	models.Error
+//	@Failure		500		{object}	models.Error
+//	@Router			/api/v1/tag/suggest [get]
+func SuggestTags(c *gin.Context) {
+	log := c.MustGet("logger").(*zap.SugaredLogger)
+	db := c.MustGet("db").(*sql.DB)
+	userID := c.MustGet("user_id_int64").(int64)
+
+	limit := defaultSuggestLimit
+	if limitQuery := c.Query("limit"); limitQuery != "" {
+		limitInt, err := strconv.Atoi(limitQuery)
+		if err != nil || limitInt <= 0 || limitInt > maxSuggestLimit {
+			c.JSON(http.StatusBadRequest, models.Error{Error: fmt.Sprintf("limit must be between 1 and %d", maxSuggestLimit)})
+			return
+		}
+		limit = limitInt
+	}
+
+	suggestions, err := utils.SuggestTags(db, userID, c.Query("q"), limit)
+	if err != nil {
+		log.Errorf("error suggesting tags: %#v", err)
+		c.JSON(http.StatusInternalServerError, models.Error{Error: err.Error()})
+		return
+	}
+
+	c.JSON(http.StatusOK, suggestions)
+}
+
+// registrationTagSubject resolves the usergear route parameter. Registration tags are
+// private, so only the owner can see or change them.
+func registrationTagSubject(c *gin.Context, log *zap.SugaredLogger, db *sql.DB) (tagSubject, bool) {
+	registrationID, err := strconv.ParseInt(c.Param("usergear"), 10, 64)
+	if err != nil {
+		log.Errorf("invalid registration ID: %#v", err)
+		c.JSON(http.StatusBadRequest, models.Error{Error: "invalid registration ID"})
+		return tagSubject{}, false
+	}
+
+	var userID int64
+	err = db.QueryRow("SELECT userId FROM user_gear_registrations WHERE userGearRegistrationId = ?", registrationID).Scan(&userID)
+	if err == sql.ErrNoRows {
+		c.JSON(http.StatusNotFound, models.Error{Error: "registration not found"})
+		return tagSubject{}, false
+	}
+	if err != nil {
+		log.Errorf("error looking up registration: %#v", err)
+		c.JSON(http.StatusInternalServerError, models.Error{Error: err.Error()})
+		return tagSubject{}, false
+	}
+
+	if userID != c.MustGet("user_id_int64").(int64) {
+		log.Warnw("tag access attempted on another user's registration", "registration_id", registrationID)
+		c.JSON(http.StatusForbidden, models.Error{Error: "Access denied"})
+		return tagSubject{}, false
+	}
+
+	return tagSubject{target: utils.RegistrationTagTarget, id: registrationID}, true
+}
+
+// loadoutTagSubject resolves the loadout route parameter, which must be the caller's.
+func loadoutTagSubject(c *gin.Context, log *zap.SugaredLogger, db *sql.DB) (tagSubject, bool) {
+	loadoutID, err := strconv.ParseInt(c.Param("loadout"), 10, 64)
+	if err != nil {
+		log.Errorf("Invalid loadout id: %#v", err)
+		c.JSON(http.StatusBadRequest, models.Error{Error: "invalid loadout ID"})
+		return tagSubject{}, false
+	}
+
+	var userID int64
+	err = db.QueryRow("SELECT userId FROM loadouts WHERE loadoutId = ?", loadoutID).Scan(&userID)
+	if err != nil && err != sql.ErrNoRows {
+		log.Errorf("error looking up loadout: %#v", err)
+		c.JSON(http.StatusInternalServerError, models.Error{Error: err.Error()})
+		return tagSubject{}, false
+	}
+	if err == sql.ErrNoRows || userID != c.MustGet("user_id_int64").(int64) {
+		c.JSON(http.StatusNotFound, models.Error{Error: "Loadout not found"})
+		return tagSubject{}, false
+	}
+
+	return tagSubject{target: utils.LoadoutTagTarget, id: loadoutID}, true
+}
+
+func listTags(c *gin.Context, log *zap.SugaredLogger, db *sql.DB, subject tagSubject) {
+	tags, err := utils.Tags(db, subject.target, subject.id)
+	if err != nil {
+		log.Errorf("error listing tags: %#v", err)
+		c.JSON(http.StatusInternalServerError, models.Error{Error: err.Error()})
+		return
+	}
+
+	c.JSON(http.StatusOK, tags)
+}
+
+// addTags adds the tags in the request body and responds with all tags of the subject.
+func addTags(c *gin.Context, log *zap.SugaredLogger, db *sql.DB, subject tagSubject) {
+	var input models.TagInput
+	if err := json.NewDecoder(c.Request.Body).Decode(&input); err != nil {
+		c.JSON(http.StatusBadRequest, models.Error{Error: err.Error()})
+		return
+	}
+
+	tags, err := utils.NormalizeTags(input.Tags)
+	if err != nil {
+		respondTagError(c, log, err)
+		return
+	}
+
+	if err := utils.AddTags(db, subject.target, subject.id, tags); err != nil {
+		log.Errorf("error adding tags: %#v", err)
+		c.JSON(http.StatusInternalServerError, models.Error{Error: err.Error()})
+		return
+	}
+
+	listTags(c, log, db, subject)
+}
+
+func removeTag(c *gin.Context, log *zap.SugaredLogger, db *sql.DB, subject tagSubject) {
+	tag, err := utils.NormalizeTag(c.Param("tag"))
+	if err != nil {
+		respondTagError(c, log, err)
+		return
+	}
+
+	removed, err := utils.RemoveTag(db, subject.target, subject.id, tag)
+	if err != nil {
+		log.Errorf("error removing tag: %#v", err)
+		c.JSON(http.StatusInternalServerError, models.Error{Error: err.Error()})
+		return
+	}
+	if !removed {
+		c.JSON(http.StatusNotFound, models.Error{Error: "Tag not found"})
+		return
+	}
+
+	c.JSON(http.StatusOK, models.Status{Status: fmt.Sprintf("success! Tag %s was removed", tag)})
+}
+
+// respondTagError writes a 400 for tag errors and a 500 for everything else.
+func respondTagError(c *gin.Context, log *zap.SugaredLogger, err error) {
+	var tagErr *utils.TagError
+	if errors.As(err, &tagErr) {
+		log.Warnf("Invalid tags: %s", tagErr.Message)
+		c.JSON(http.StatusBadRequest, models.Error{Error: tagErr.Message})
+		return
+	}
+	log.Errorf("Unable to handle tags: %#v", err)
+	c.JSON(http.StatusInternalServerError, models.Error{Error: err.Error()})
+}
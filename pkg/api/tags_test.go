@@ -0,0 +1,222 @@
+package endpoints
+
+import (
+	"encoding/json"
+	"net/http"
+	"sort"
+	"testing"
+
+	models "github.com/Sea-Shell/gogear-api/pkg/models"
+
+	"github.com/gin-gonic/gin"
+)
+
+func TestTags(t *testing.T) {
+	db, router := setupCatalogTest(t, 1)
+	seedCatalog(t, db)
+	seedCatalogGear(t, db, 1, 1, "Nallo", 2400)
+	seedCatalogGear(t, db, 2, 1, "Akto", 1600)
+	seedCatalogGear(t, db, 3, 1, "Soulo", 2000)
+	seedUser(t, db, 2)
+
+	isAdmin := false
+	v1 := router.Group("/api/v1", testAuthMiddleware(1), func(c *gin.Context) {
+		c.Set("user_is_admin", isAdmin)
+		c.Next()
+	})
+	v1.GET("/gear/:gear/get", GetGear)
+	v1.GET("/gear/:gear/tag/list", ListGearTags)
+	v1.PUT("/gear/:gear/tag/insert", InsertGearTags)
+	v1.DELETE("/gear/:gear/tag/:tag/delete", DeleteGearTag)
+	v1.GET("/usergear/registration/:usergear/tag/list", ListUserGearTags)
+	v1.PUT("/usergear/registration/:usergear/tag/insert", InsertUserGearTags)
+	v1.DELETE("/usergear/registration/:usergear/tag/:tag/delete", DeleteUserGearTag)
+	v1.PUT("/loadout/insert", InsertLoadout)
+	v1.GET("/loadout/list", ListLoadouts)
+	v1.GET("/loadout/:loadout/get", GetLoadout)
+	v1.PUT("/loadout/:loadout/tag/insert", InsertLoadoutTags)
+	v1.DELETE("/loadout/:loadout/tag/:tag/delete", DeleteLoadoutTag)
+	v1.GET("/tag/suggest", SuggestTags)
+
+	if w := authRequest(t, router, http.MethodPut, "/api/v1/gear/1/tag/insert", `{"tags":["winter"]}`); w.Code != http.StatusForbidden {
+		t.Fatalf("InsertGearTags: expected 403 for non-admin, got %d", w.Code)
+	}
+
+	isAdmin = true
+	for gear, tags := range map[string]string{
+		"1": `{"tags":["Winter","  4 Season ","winter"]}`,
+		"2": `{"tags":["ultralight","winter"]}`,
+		"3": `{"tags":["ultralight"]}`,
+	} {
+		if w := authRequest(t, router, http.MethodPut, "/api/v1/gear/"+gear+"/tag/insert", tags); w.Code != http.StatusOK {
+			t.Fatalf("InsertGearTags %s: %d %s", gear, w.Code, w.Body.String())
+		}
+	}
+	for body, want := range map[string]int{
+		`{"tags":[]}`:            http.StatusBadRequest,
+		`{"tags":["tent/tarp"]}`: http.StatusBadRequest,
+		`{"tags":"winter"}`:      http.StatusBadRequest,
+		`{"tags":["  "]}`:        http.StatusBadRequest,
+	} {
+		if w := authRequest(t, router, http.MethodPut, "/api/v1/gear/1/tag/insert", body); w.Code != want {
+			t.Errorf("InsertGearTags %s: expected %d, got %d", body, want, w.Code)
+		}
+	}
+	if w := authRequest(t, router, http.MethodPut, "/api/v1/gear/99/tag/insert", `{"tags":["winter"]}`); w.Code != http.StatusNotFound {
+		t.Errorf("InsertGearTags: expected 404 for missing gear, got %d", w.Code)
+	}
+	isAdmin = false
+
+	w := authRequest(t, router, http.MethodGet, "/api/v1/gear/1/tag/list", "")
+	var tags []string
+	if err := json.Unmarshal(w.Body.Bytes(), &tags); err != nil || len(tags) != 2 || tags[0] != "4 season" || tags[1] != "winter" {
+		t.Fatalf("ListGearTags: %d %s", w.Code, w.Body.String())
+	}
+	w = authRequest(t, router, http.MethodGet, "/api/v1/gear/1/get", "")
+	var fullGear models.FullGear
+	if err := json.Unmarshal(w.Body.Bytes(), &fullGear); err != nil || len(fullGear.Tags) != 2 {
+		t.Errorf("GetGear: expected tags, got %d %s", w.Code, w.Body.String())
+	}
+
+	listGear := func(query string) []string {
+		t.Helper()
+		w := authRequest(t, router, http.MethodGet, "/api/v1/gear/list?"+query, "")
+		if w.Code != http.StatusOK {
+			t.Fatalf("ListGear %s: %d %s", query, w.Code, w.Body.String())
+		}
+		var names []string
+		for _, item := range decodeListPayload(t, w.Body.Bytes()).Items {
+			names = append(names, item["gear_name"].(string))
+		}
+		sort.Strings(names)
+		return names
+	}
+	for query, want := range map[string][]string{
+		"tag=winter":                              {"Akto", "Nallo"},
+		"tag=Winter&tag=ultralight":               {"Akto", "Nallo", "Soulo"},
+		"tag=winter&tag=ultralight&tag_match=all": {"Akto"},
+		"tag=winter&tag=4%20season&tag_match=all": {"Nallo"},
+		"tag=winter&tag=4%20season&tag_match=any": {"Akto", "Nallo"},
+		"tag=needs%20repair":                      nil,
+	} {
+		got := listGear(query)
+		if len(got) != len(want) {
+			t.Errorf("ListGear %s = %v, want %v", query, got, want)
+			continue
+		}
+		for i := range want {
+			if got[i] != want[i] {
+				t.Errorf("ListGear %s = %v, want %v", query, got, want)
+				break
+			}
+		}
+	}
+	if w := authRequest(t, router, http.MethodGet, "/api/v1/gear/list?tag=winter&tag_match=most", ""); w.Code != http.StatusBadRequest {
+		t.Errorf("ListGear: expected 400 for an unknown tag_match, got %d", w.Code)
+	}
+
+	isAdmin = true
+	if w := authRequest(t, router, http.MethodDelete, "/api/v1/gear/1/tag/4%20Season/delete", ""); w.Code != http.StatusOK {
+		t.Errorf("DeleteGearTag: %d %s", w.Code, w.Body.String())
+	}
+	if w := authRequest(t, router, http.MethodDelete, "/api/v1/gear/1/tag/4%20season/delete", ""); w.Code != http.StatusNotFound {
+		t.Errorf("DeleteGearTag: expected 404 for a removed tag, got %d", w.Code)
+	}
+	isAdmin = false
+
+	for _, statement := range []string{
+		`INSERT INTO user_gear_registrations (userGearRegistrationId, gearId, userId) VALUES (1, 1, 1), (2, 2, 1), (3, 3, 2)`,
+		`INSERT INTO user_gear_registration_tags (userGearRegistrationId, tagName) VALUES (3, 'borrowed')`,
+	} {
+		if _, err := db.Exec(statement); err != nil {
+			t.Fatal(err)
+		}
+	}
+	if w := authRequest(t, router, http.MethodPut, "/api/v1/usergear/registration/1/tag/insert", `{"tags":["Needs Repair","borrowed"]}`); w.Code != http.StatusOK {
+		t.Fatalf("InsertUserGearTags: %d %s", w.Code, w.Body.String())
+	}
+	if w := authRequest(t, router, http.MethodPut, "/api/v1/usergear/registration/2/tag/insert", `{"tags":["winter"]}`); w.Code != http.StatusOK {
+		t.Fatalf("InsertUserGearTags: %d %s", w.Code, w.Body.String())
+	}
+	if w := authRequest(t, router, http.MethodPut, "/api/v1/usergear/registration/3/tag/insert", `{"tags":["mine"]}`); w.Code != http.StatusForbidden {
+		t.Errorf("InsertUserGearTags: expected 403 on another user's registration, got %d", w.Code)
+	}
+	if w := authRequest(t, router, http.MethodGet, "/api/v1/usergear/registration/3/tag/list", ""); w.Code != http.StatusForbidden {
+		t.Errorf("ListUserGearTags: expected 403 on another user's registration, got %d", w.Code)
+	}
+
+	w = authRequest(t, router, http.MethodGet, "/api/v1/usergear/1/list?tag=needs%20repair", "")
+	payload := decodeListPayload(t, w.Body.Bytes())
+	if len(payload.Items) != 1 || payload.Items[0]["usergear_gear_id"].(float64) != 1 {
+		t.Errorf("ListUserGear tag=needs repair: %s", w.Body.String())
+	}
+	w = authRequest(t, router, http.MethodGet, "/api/v1/usergear/1/list?tag=borrowed&tag=winter", "")
+	if payload := decodeListPayload(t, w.Body.Bytes()); len(payload.Items) != 2 {
+		t.Errorf("ListUserGear tag=borrowed|winter: %s", w.Body.String())
+	}
+
+	for _, name := range []string{"Winter trip", "Summer trip"} {
+		if w := authRequest(t, router, http.MethodPut, "/api/v1/loadout/insert", `{"loadout_name":"`+name+`","loadout_slug":"`+name[:6]+`"}`); w.Code != http.StatusCreated {
+			t.Fatalf("InsertLoadout: %d %s", w.Code, w.Body.String())
+		}
+	}
+	if w := authRequest(t, router, http.MethodPut, "/api/v1/loadout/1/tag/insert", `{"tags":["winter","family"]}`); w.Code != http.StatusOK {
+		t.Fatalf("InsertLoadoutTags: %d %s", w.Code, w.Body.String())
+	}
+	if w := authRequest(t, router, http.MethodPut, "/api/v1/loadout/2/tag/insert", `{"tags":["family"]}`); w.Code != http.StatusOK {
+		t.Fatalf("InsertLoadoutTags: %d %s", w.Code, w.Body.String())
+	}
+	if w := authRequest(t, router, http.MethodPut, "/api/v1/loadout/9/tag/insert", `{"tags":["family"]}`); w.Code != http.StatusNotFound {
+		t.Errorf("InsertLoadoutTags: expected 404 for a missing loadout, got %d", w.Code)
+	}
+
+	listLoadouts := func(query string) int {
+		t.Helper()
+		w := authRequest(t, router, http.MethodGet, "/api/v1/loadout/list?"+query, "")
+		if w.Code != http.StatusOK {
+			t.Fatalf("ListLoadouts %s: %d %s", query, w.Code, w.Body.String())
+		}
+		return len(decodeListPayload(t, w.Body.Bytes()).Items)
+	}
+	for query, want := range map[string]int{
+		"":                                    2,
+		"tag=family":                          2,
+		"tag=winter&tag=family&tag_match=all": 1,
+		"tag=ultralight":                      0,
+	} {
+		if got := listLoadouts(query); got != want {
+			t.Errorf("ListLoadouts %s: got %d loadouts, want %d", query, got, want)
+		}
+	}
+
+	w = authRequest(t, router, http.MethodGet, "/api/v1/loadout/1/get", "")
+	var loadout models.Loadout
+	if err := json.Unmarshal(w.Body.Bytes(), &loadout); err != nil || len(loadout.Tags) != 2 {
+		t.Errorf("GetLoadout: expected tags, got %d %s", w.Code, w.Body.String())
+	}
+	if w := authRequest(t, router, http.MethodDelete, "/api/v1/loadout/1/tag/family/delete", ""); w.Code != http.StatusOK {
+		t.Errorf("DeleteLoadoutTag: %d %s", w.Code, w.Body.String())
+	}
+
+	w = authRequest(t, router, http.MethodGet, "/api/v1/tag/suggest?q=W", "")
+	var suggestions []models.TagSuggestion
+	if err := json.Unmarshal(w.Body.Bytes(), &suggestions); err != nil || len(suggestions) != 1 {
+		t.Fatalf("SuggestTags: %d %s", w.Code, w.Body.String())
+	}
+	if suggestions[0].Tag != "winter" || suggestions[0].Count != 4 {
+		t.Errorf("SuggestTags: expected winter used 4 times, got %+v", suggestions[0])
+	}
+	w = authRequest(t, router, http.MethodGet, "/api/v1/tag/suggest?q=b", "")
+	suggestions = nil
+	if err := json.Unmarshal(w.Body.Bytes(), &suggestions); err != nil || len(suggestions) != 1 || suggestions[0].Count != 1 {
+		t.Errorf("SuggestTags: another user's private tags should not count, got %s", w.Body.String())
+	}
+
+	if _, err := db.Exec("DELETE FROM loadouts WHERE loadoutId = 1"); err != nil {
+		t.Fatal(err)
+	}
+	var remaining int
+	if err := db.QueryRow("SELECT COUNT(*) FROM loadout_tags WHERE loadoutId = 1").Scan(&remaining); err != nil || remaining != 0 {
+		t.Errorf("loadout delete left %d tags behind, %v", remaining, err)
+	}
+}
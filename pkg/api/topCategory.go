@@ -5,10 +5,7 @@ import (
 	"encoding/json"
 	"fmt"
 	"io"
-	"math"
 	"net/http"
-	"net/url"
-	"reflect"
 	"strconv"
 	"strings"
 
@@ -65,6 +62,10 @@ func GetTopCategory(c *gin.Context) {
 		return
 	}
 
+	if !localizeItems(c, log, db, results) {
+		return
+	}
+
 	log.Infof("Successfully fetched %s with ID %s", function, urlParameter)
 	c.IndentedJSON(http.StatusOK, results)
 }
@@ -77,6 +78,10 @@ func GetTopCategory(c *gin.Context) {
 // @Produce		json
 // @Param			page		query		int		false	"Page number"				default(1)
 // @Param			limit		query		int		false	"Number of items per page"	default(30)
+// @Param			sort		query		string	false	"Field to sort by, json or db field name"
+// @Param			order		query		string	false	"Sort direction, asc or desc"	default(asc)
+// @Param			cursor		query		string	false	"Opaque cursor from next_cursor or prev_cursor, replaces page"
+// @Param			count		query		bool	false	"Include total item count"	default(true)
 // @Param			topCategory	query		[]int	false	"top categories"			collectionFormat(multi)
 // @Success		200			{object}	models.ResponsePayload{items=[]models.GearTopCategory}
 // @Failure		default		{object}	models.Error
@@ -84,10 +89,6 @@ func GetTopCategory(c *gin.Context) {
 func ListTopCategory(c *gin.Context) {
 	c.Header("Content-Type", "application/json")
 
-	currentQueryParameters := c.Request.URL.Query()
-
-	page := c.Query("page")
-	limit := c.Query("limit")
 	topCategories := c.QueryArray("topCategory")
 
 	log := c.MustGet("logger").(*zap.SugaredLogger)
@@ -95,39 +96,7 @@ func ListTopCategory(c *gin.Context) {
 
 	log.Debugf("Request parameters: %#v", c.Request.URL.Query())
 
-	if limit == "" {
-		limit = "30"
-	}
-
-	if page == "" || page == "0" {
-		page = "1"
-	}
-
-	pageInt, err := strconv.Atoi(page)
-	if err != nil {
-		log.Errorf("Error setting page to int: %#v", err)
-		c.IndentedJSON(http.StatusInternalServerError, models.Error{Error: err.Error()})
-		return
-	}
-
-	limitInt, err := strconv.Atoi(limit)
-	if err != nil {
-		log.Errorf("Error setting limit to int: %#v", err)
-		c.IndentedJSON(http.StatusInternalServerError, models.Error{Error: err.Error()})
-		return
-	}
-
-	if pageInt <= 0 {
-		log.Errorf("Error page is less than 0: %#v", err)
-		c.IndentedJSON(http.StatusBadRequest, models.Error{Error: "Invalid page number"})
-		return
-	}
-
-	if limitInt <= 0 {
-		log.Errorf("Error limit is less than 0: %#v", err)
-		c.IndentedJSON(http.StatusBadRequest, models.Error{Error: "Invalid limit number"})
-		return
-	}
+	query := utils.NewListQuery[models.GearTopCategory]("gear_top_category")
 
 	conditions := []string{}
 	params := []interface{}{}
@@ -137,114 +106,14 @@ func ListTopCategory(c *gin.Context) {
 		params = append(params, category)
 	}
 
-	whereClause := ""
-	if len(conditions) > 0 {
-		whereClause = " WHERE " + strings.Join(conditions, " OR ")
-	}
+	query.WhereAny(conditions, params...)
 
-	baseCountQuery := "SELECT COUNT(*) FROM gear_top_category"
-	countQuery := baseCountQuery + whereClause
-
-	var totalCount int
-	err = db.QueryRow(countQuery, params...).Scan(&totalCount)
-	if err != nil {
-		log.Errorf("Error getting GearCount database: %#v", err)
-		c.IndentedJSON(http.StatusInternalServerError, models.Error{Error: err.Error()})
+	if err := query.FilterFields(c.Request.URL.Query()); err != nil {
+		respondListQueryError(c, log, err)
 		return
 	}
 
-	start := strconv.Itoa((pageInt - 1) * limitInt)
-	totalPages := int(math.Ceil(float64(totalCount) / float64(limitInt)))
-
-	startInt, err := strconv.Atoi(start)
-	if err != nil {
-		c.IndentedJSON(http.StatusInternalServerError, models.Error{Error: err.Error()})
-		return
-	}
-
-	var paramTopCategory models.GearTopCategory
-	fields := utils.GetDBFieldNames(reflect.TypeOf(paramTopCategory))
-
-	baseQuery := fmt.Sprintf(`SELECT %s FROM gear_top_category`, strings.Join(fields, ", "))
-
-	queryLimit := fmt.Sprintf(" LIMIT %v, %v", startInt, limitInt)
-
-	query := baseQuery + whereClause + queryLimit
-
-	log.Debugf("Query: %s", query)
-
-	rows, err := db.Query(query, params...)
-	if err != nil {
-		log.Errorf("Query error: %#v", err.Error())
-		c.IndentedJSON(http.StatusInternalServerError, models.Error{Error: err.Error()})
-		return
-	}
-	defer rows.Close()
-
-	dest, err := utils.GetScanFields(paramTopCategory)
-	if err != nil {
-		log.Errorf("Error getting destination arguments: %#v", err)
-		c.IndentedJSON(http.StatusInternalServerError, models.Error{Error: err.Error()})
-		return
-	}
-
-	var gearTopCategoryList []models.GearTopCategory
-
-	for rows.Next() {
-		err = rows.Scan(dest...)
-		if err != nil {
-			if err == sql.ErrNoRows {
-				log.Errorf("No gear found")
-				c.IndentedJSON(http.StatusNotFound, models.Error{Error: "No results"})
-				return
-			}
-			log.Errorf("Scan error: %#v", err)
-			c.IndentedJSON(http.StatusInternalServerError, models.Error{Error: err.Error()})
-			return
-		}
-
-		for i := 0; i < reflect.TypeOf(paramTopCategory).NumField(); i++ {
-			reflect.ValueOf(&paramTopCategory).Elem().Field(i).Set(reflect.ValueOf(dest[i]).Elem())
-		}
-
-		gearTopCategoryList = append(gearTopCategoryList, paramTopCategory)
-	}
-
-	if err := rows.Err(); err != nil {
-		log.Errorf("Row iteration error: %#v", err)
-		c.IndentedJSON(http.StatusInternalServerError, models.Error{Error: err.Error()})
-		return
-	}
-
-	payload := models.ResponsePayload{
-		TotalItemCount: totalCount,
-		CurrentPage:    pageInt,
-		ItemLimit:      limitInt,
-		TotalPages:     totalPages,
-		Items:          gearTopCategoryList,
-	}
-
-	if pageInt < totalPages {
-		currentQueryParameters.Set("page", strconv.Itoa(pageInt+1))
-		nextPage := url.URL{
-			Path:     c.Request.URL.Path,
-			RawQuery: currentQueryParameters.Encode(),
-		}
-		payload.NextPage = new(string)
-		*payload.NextPage = nextPage.String()
-	}
-
-	if pageInt > 1 {
-		currentQueryParameters.Set("page", strconv.Itoa(pageInt-1))
-		prevPage := url.URL{
-			Path:     c.Request.URL.Path,
-			RawQuery: currentQueryParameters.Encode(),
-		}
-		payload.PrevPage = new(string)
-		*payload.PrevPage = prevPage.String()
-	}
-
-	c.IndentedJSON(http.StatusOK, payload)
+	respondList(c, log, db, query)
 }
 
 // @Summary		Update top category with ID
@@ -371,13 +240,15 @@ func InsertTopCategory(c *gin.Context) {
 }
 
 // @Summary		Delete topCategory with ID
-// @Description	Delete topCategory with corresponding ID value
+// @Description	Delete topCategory with corresponding ID value. Its categories and gear must be moved first, or moved to reassign_to as part of the delete; otherwise the delete is refused with 409 and their counts. Moved categories keep their subtrees.
 // @Security		BearerAuth
 // @Tags			Top Category
 // @Accept			json
 // @Produce		json
-// @Param			topCategory	path		int				true	"Unique ID of topCategory you want to update"
-// @Success		200			{object}	models.Status	"status: success when all goes well"
+// @Param			topCategory	path		int						true	"Unique ID of topCategory you want to update"
+// @Param			reassign_to	query		int						false	"Top category to move the categories and gear to before deleting"
+// @Success		200			{object}	models.Status			"status: success when all goes well"
+// @Failure		409			{object}	models.DependentsError	"the top category is still in use"
 // @Failure		default		{object}	models.Error
 // @Router			/api/v1/topCategory/{topCategory}/delete [delete]
 func DeleteTopCategory(c *gin.Context) {
@@ -394,13 +265,22 @@ func DeleteTopCategory(c *gin.Context) {
 		return
 	}
 
-	result, err := utils.GenericDelete[models.GearTopCategory]("gear_top_category", urlParameter, db)
+	result, err := utils.GenericGet[models.GearTopCategory]("gear_top_category", urlParameter, nil, db)
+	if err == sql.ErrNoRows {
+		c.JSON(http.StatusNotFound, models.Error{Error: "Top category not found"})
+		return
+	}
 	if err != nil {
 		c.JSON(http.StatusInternalServerError, models.Error{Error: err.Error()})
 		log.Error(err.Error())
 		return
 	}
 
-	log.Infof("success! Top category with top_category_id %v and top_category_name %s was deleted", result.TopCategoryID, result.TopCategoryName)
-	c.JSON(http.StatusOK, map[string]string{"status": fmt.Sprintf("success! Top category with top_category_id %v and top_category_name %s was deleted", result.TopCategoryID, result.TopCategoryName)})
+	moved, ok := deleteReassigning(c, log, db, utils.TopCategoryDeletion, int64(urlParameter))
+	if !ok {
+		return
+	}
+
+	log.Infof("success! Top category with top_category_id %v and top_category_name %s was deleted%s", *result.TopCategoryID, result.TopCategoryName, reassignedSummary(moved))
+	c.JSON(http.StatusOK, map[string]string{"status": fmt.Sprintf("success! Top category with top_category_id %v and top_category_name %s was deleted%s", *result.TopCategoryID, result.TopCategoryName, reassignedSummary(moved))})
 }
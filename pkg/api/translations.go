@@ -0,0 +1,358 @@
+package endpoints
+
+import (
+	"database/sql"
+	"errors"
+	"net/http"
+	"strconv"
+
+	models "github.com/Sea-Shell/gogear-api/pkg/models"
+	utils "github.com/Sea-Shell/gogear-api/pkg/utils"
+
+	gin "github.com/gin-gonic/gin"
+	zap "go.uber.org/zap"
+)
+
+// translationKindNames names the kinds of translated catalog entries in error messages.
+var translationKindNames = map[string]string{
+	utils.TranslationKindGear:        "Gear",
+	utils.TranslationKindCategory:    "Category",
+	utils.TranslationKindTopCategory: "Top category",
+}
+
+// ListGearTranslations lists the translations of a gear
+//
+//	@Summary		List gear translations
+//	@Description	Get the translations of a gear's name and size definition, one per locale. Requires a JWT issued with the admin audience.
+//	@Security		BearerAuth
+//	@Tags			Translations
+//	@Accept			json
+//	@Produce		json
+//	@Param			gear	path		int	true	"Unique ID of gear"
+//	@Success		200		{array}		models.Translation
+//	@Failure		default	{object}	models.Error
+//	@Router			/api/v1/gear/{gear}/translation/list [get]
+func ListGearTranslations(c *gin.Context) {
+	listTranslations(c, utils.TranslationKindGear, "gear")
+}
+
+// UpdateGearTranslation sets the translation of a gear in a locale
+//
+//	@Summary		Update gear translation
+//	@Description	Set the name and size definition of a gear in a locale, replacing an earlier translation. Fields are gear_name and gear_size_definition; a field left out falls back to the default locale. Requires a JWT issued with the admin audience.
+//	@Security		BearerAuth
+//	@Tags			Translations
+//	@Accept			json
+//	@Produce		json
+//	@Param			gear	path		int							true	"Unique ID of gear"
+//	@Param			locale	path		string						true	"Language tag such as nb"
+//	@Param			request	body		models.TranslationUpdate	true	"Translated fields"
+//	@Success		200		{object}	models.Translation
+//	@Failure		400		{object}	models.Error
+//	@Failure		403		{object}	models.Error
+//	@Failure		404		{object}	models.Error
+//	@Failure		500		{object}	models.Error
+//	@Router			/api/v1/gear/{gear}/translation/{locale}/update [post]
+func UpdateGearTranslation(c *gin.Context) {
+	updateTranslation(c, utils.TranslationKindGear, "gear")
+}
+
+// DeleteGearTranslation removes the translation of a gear in a locale
+//
+//	@Summary		Delete gear translation
+//	@Description	Remove the translation of a gear in a locale, so it falls back to the default locale. Requires a JWT issued with the admin audience.
+//	@Security		BearerAuth
+//	@Tags			Translations
+//	@Accept			json
+//	@Produce		json
+//	@Param			gear	path		int		true	"Unique ID of gear"
+//	@Param			locale	path		string	true	"Language tag such as nb"
+//	@Success		200		{object}	models.Status
+//	@Failure		default	{object}	models.Error
+//	@Router			/api/v1/gear/{gear}/translation/{locale}/delete [delete]
+func DeleteGearTranslation(c *gin.Context) {
+	deleteTranslation(c, utils.TranslationKindGear, "gear")
+}
+
+// ListCategoryTranslations lists the translations of a category
+//
+//	@Summary		List category translations
+//	@Description	Get the translations of a category's name, one per locale. Requires a JWT issued with the admin audience.
+//	@Security		BearerAuth
+//	@Tags			Translations
+//	@Accept			json
+//	@Produce		json
+//	@Param			category	path		int	true	"Unique ID of category"
+//	@Success		200			{array}		models.Translation
+//	@Failure		default		{object}	models.Error
+//	@Router			/api/v1/category/{category}/translation/list [get]
+func ListCategoryTranslations(c *gin.Context) {
+	listTranslations(c, utils.TranslationKindCategory, "category")
+}
+
+// UpdateCategoryTranslation sets the translation of a category in a locale
+//
+//	@Summary		Update category translation
+//	@Description	Set the name of a category in a locale, replacing an earlier translation. The only field is category_name, which is required. Requires a JWT issued with the admin audience.
+//	@Security		BearerAuth
+//	@Tags			Translations
+//	@Accept			json
+//	@Produce		json
+//	@Param			category	path		int							true	"Unique ID of category"
+//	@Param			locale		path		string						true	"Language tag such as nb"
+//	@Param			request		body		models.TranslationUpdate	true	"Translated fields"
+//	@Success		200			{object}	models.Translation
+//	@Failure		400			{object}	models.Error
+//	@Failure		403			{object}	models.Error
+//	@Failure		404			{object}	models.Error
+//	@Failure		500			{object}	models.Error
+//	@Router			/api/v1/category/{category}/translation/{locale}/update [post]
+func UpdateCategoryTranslation(c *gin.Context) {
+	updateTranslation(c, utils.TranslationKindCategory, "category")
+}
+
+// DeleteCategoryTranslation removes the translation of a category in a locale
+//
+//	@Summary		Delete category translation
+//	@Description	Remove the translation of a category in a locale, so it falls back to the default locale. Requires a JWT issued with the admin audience.
+//	@Security		BearerAuth
+//	@Tags			Translations
+//	@Accept			json
+//	@Produce		json
+//	@Param			category	path		int		true	"Unique ID of category"
+//	@Param			locale		path		string	true	"Language tag such as nb"
+//	@Success		200			{object}	models.Status
+//	@Failure		default		{object}	models.Error
+//	@Router			/api/v1/category/{category}/translation/{locale}/delete [delete]
+func DeleteCategoryTranslation(c *gin.Context) {
+	deleteTranslation(c, utils.TranslationKindCategory, "category")
+}
+
+// ListTopCategoryTranslations lists the translations of a top category
+//
+//	@Summary		List top category translations
+//	@Description	Get the translations of a top category's name, one per locale. Requires a JWT issued with the admin audience.
+//	@Security		BearerAuth
+//	@Tags			Translations
+//	@Accept			json
+//	@Produce		json
+//	@Param			topCategory	path		int	true	"Unique ID of top category"
+//	@Success		200			{array}		models.Translation
+//	@Failure		default		{object}	models.Error
+//	@Router			/api/v1/topCategory/{topCategory}/translation/list [get]
+func ListTopCategoryTranslations(c *gin.Context) {
+	listTranslations(c, utils.TranslationKindTopCategory, "topCategory")
+}
+
+// UpdateTopCategoryTranslation sets the translation of a top category in a locale
+//
+//	@Summary		Update top category translation
+//	@Description	Set the name of a top category in a locale, replacing an earlier translation. The only field is top_category_name, which is required. Requires a JWT issued with the admin audience.
+//	@Security		BearerAuth
+//	@Tags			Translations
+//	@Accept			json
+//	@Produce		json
+//	@Param			topCategory	path		int							true	"Unique ID of top category"
+//	@Param			locale		path		string						true	"Language tag such as nb"
+//	@Param			request		body		models.TranslationUpdate	true	"Translated fields"
+//	@Success		200			{object}	models.Translation
+//	@Failure		400			{object}	models.Error
+//	@Failure		403			{object}	models.Error
+//	@Failure		404			{object}	models.Error
+//	@Failure		500			{object}	models.Error
+//	@Router			/api/v1/topCategory/{topCategory}/translation/{locale}/update [post]
+func UpdateTopCategoryTranslation(c *gin.Context) {
+	updateTranslation(c, utils.TranslationKindTopCategory, "topCategory")
+}
+
+// DeleteTopCategoryTranslation removes the translation of a top category in a locale
+//
+//	@Summary		Delete top category translation
+//	@Description	Remove the translation of a top category in a locale, so it falls back to the default locale. Requires a JWT issued with the admin audience.
+//	@Security		BearerAuth
+//	@Tags			Translations
+//	@Accept			json
+//	@Produce		json
+//	@Param			topCategory	path		int		true	"Unique ID of top category"
+//	@Param			locale		path		string	true	"Language tag such as nb"
+//	@Success		200			{object}	models.Status
+//	@Failure		default		{object}	models.Error
+//	@Router			/api/v1/topCategory/{topCategory}/translation/{locale}/delete [delete]
+func DeleteTopCategoryTranslation(c *gin.Context) {
+	deleteTranslation(c, utils.TranslationKindTopCategory, "topCategory")
+}
+
+// ListMissingTranslations reports the catalog entries not yet translated
+//
+//	@Summary		List missing translations
+//	@Description	Get, for each locale, the gear, categories and top categories that have text in the default locale without a translation, with the fields that are missing. Gear is only reported once it is in the catalog. Without locale, every configured locale is reported, or every locale with translations when none are configured. Requires a JWT issued with the admin audience.
+//	@Security		BearerAuth
+//	@Tags			Translations
+//	@Accept			json
+//	@Produce		json
+//	@Param			locale	query		string		false	"Only report this locale"
+//	@Param			kind	query		[]string	false	"Only report these kinds: gear, category or top_category"	collectionFormat(multi)
+//	@Success		200		{array}		models.MissingTranslationReport
+//	@Failure		400		{object}	models.Error
+//	@Failure		403		{object}	models.Error
+//	@Failure		500		{object}	models.Error
+//	@Router			/api/v1/translation/missing/list [get]
+func ListMissingTranslations(c *gin.Context) {
+	c.Header("Content-Type", "application/json")
+
+	log := c.MustGet("logger").(*zap.SugaredLogger)
+	db := c.MustGet("db").(*sql.DB)
+
+	if !requireTranslationAdmin(c, log) {
+		return
+	}
+
+	defaultLocale, configured := localeSettings(c)
+	var locales []string
+	if locale := c.Query("locale"); locale != "" {
+		locales = []string{locale}
+	} else {
+		candidates := configured
+		if len(candidates) == 0 {
+			var err error
+			if candidates, err = utils.TranslatedLocales(db); err != nil {
+				log.Errorf("error listing translated locales: %#v", err)
+				c.JSON(http.StatusInternalServerError, models.Error{Error: err.Error()})
+				return
+			}
+		}
+		for _, locale := range candidates {
+			if !utils.SameLanguage(locale, defaultLocale) {
+				locales = append(locales, locale)
+			}
+		}
+	}
+
+	reports, err := utils.MissingTranslations(db, locales, c.QueryArray("kind"))
+	if err != nil {
+		respondTranslationError(c, log, err, "")
+		return
+	}
+
+	c.JSON(http.StatusOK, reports)
+}
+
+// listTranslations responds with the translations of the catalog entry in the route
+// parameter param.
+func listTranslations(c *gin.Context, kind string, param string) {
+	c.Header("Content-Type", "application/json")
+
+	log := c.MustGet("logger").(*zap.SugaredLogger)
+	db := c.MustGet("db").(*sql.DB)
+
+	if !requireTranslationAdmin(c, log) {
+		return
+	}
+	id, ok := translationEntryID(c, param)
+	if !ok {
+		return
+	}
+
+	translations, err := utils.Translations(db, kind, id)
+	if err != nil {
+		respondTranslationError(c, log, err, translationKindNames[kind]+" not found")
+		return
+	}
+
+	c.JSON(http.StatusOK, translations)
+}
+
+// updateTranslation sets the translation of the catalog entry in the route parameter
+// param to the locale route parameter.
+func updateTranslation(c *gin.Context, kind string, param string) {
+	c.Header("Content-Type", "application/json")
+
+	log := c.MustGet("logger").(*zap.SugaredLogger)
+	db := c.MustGet("db").(*sql.DB)
+
+	if !requireTranslationAdmin(c, log) {
+		return
+	}
+	id, ok := translationEntryID(c, param)
+	if !ok {
+		return
+	}
+
+	var update models.TranslationUpdate
+	if err := c.ShouldBindJSON(&update); err != nil {
+		c.JSON(http.StatusBadRequest, models.Error{Error: err.Error()})
+		return
+	}
+
+	translation, err := utils.SetTranslation(db, kind, id, c.Param("locale"), update.Fields)
+	if err != nil {
+		respondTranslationError(c, log, err, translationKindNames[kind]+" not found")
+		return
+	}
+
+	log.Infof("Translated %s %d to %s", kind, id, translation.Locale)
+	c.JSON(http.StatusOK, translation)
+}
+
+// deleteTranslation removes the translation of the catalog entry in the route parameter
+// param to the locale route parameter.
+func deleteTranslation(c *gin.Context, kind string, param string) {
+	c.Header("Content-Type", "application/json")
+
+	log := c.MustGet("logger").(*zap.SugaredLogger)
+	db := c.MustGet("db").(*sql.DB)
+
+	if !requireTranslationAdmin(c, log) {
+		return
+	}
+	id, ok := translationEntryID(c, param)
+	if !ok {
+		return
+	}
+
+	if err := utils.DeleteTranslation(db, kind, id, c.Param("locale")); err != nil {
+		respondTranslationError(c, log, err, "Translation not found")
+		return
+	}
+
+	log.Infof("Deleted %s translation of %s %d", c.Param("locale"), kind, id)
+	c.JSON(http.StatusOK, models.Status{Status: "success"})
+}
+
+// requireTranslationAdmin writes a 403 and returns false unless the caller is an admin.
+func requireTranslationAdmin(c *gin.Context, log *zap.SugaredLogger) bool {
+	isAdmin, _ := c.Get("user_is_admin")
+	if adminFlag, ok := isAdmin.(bool); !ok || !adminFlag {
+		log.Warn("unauthorized translation access without admin privileges")
+		c.AbortWithStatusJSON(http.StatusForbidden, models.Error{Error: "admin privileges required"})
+		return false
+	}
+	return true
+}
+
+// translationEntryID parses the catalog entry ID in the route parameter param.
+func translationEntryID(c *gin.Context, param string) (int64, bool) {
+	id, err := strconv.ParseInt(c.Param(param), 10, 64)
+	if err != nil {
+		c.JSON(http.StatusBadRequest, models.Error{Error: "invalid " + param + " ID"})
+		return 0, false
+	}
+	return id, true
+}
+
+// respondTranslationError writes a 400 for invalid translations and locales, a 404 with
+// notFound for sql.ErrNoRows and a 500 for everything else.
+func respondTranslationError(c *gin.Context, log *zap.SugaredLogger, err error, notFound string) {
+	var translationErr *utils.TranslationError
+	if errors.As(err, &translationErr) {
+		log.Warnf("Invalid translation: %s", translationErr.Message)
+		c.JSON(http.StatusBadRequest, models.Error{Error: translationErr.Message})
+		return
+	}
+	if errors.Is(err, sql.ErrNoRows) {
+		c.JSON(http.StatusNotFound, models.Error{Error: notFound})
+		return
+	}
+	log.Errorf("error writing translation: %#v", err)
+	c.JSON(http.StatusInternalServerError, models.Error{Error: err.Error()})
+}
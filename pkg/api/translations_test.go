@@ -0,0 +1,191 @@
+package endpoints
+
+import (
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"reflect"
+	"testing"
+
+	models "github.com/Sea-Shell/gogear-api/pkg/models"
+
+	"github.com/gin-gonic/gin"
+)
+
+func TestTranslations(t *testing.T) {
+	db, router := setupCatalogTest(t, 1)
+	seedCatalog(t, db)
+	seedCatalogGear(t, db, 1, 1, "Tent", 2400)
+	seedCatalogGear(t, db, 2, 2, "Tarp", 500)
+	seedCatalogGear(t, db, 3, 1, "Pending tent", 1900)
+	if _, err := db.Exec(`UPDATE gear SET gearStatus = 0 WHERE gearId = 3`); err != nil {
+		t.Fatal(err)
+	}
+
+	isAdmin := true
+	config := &models.Config{General: models.General{Locales: []string{"nb", "nb-no"}}}
+	v1 := router.Group("/api/v1", testAuthMiddleware(1), func(c *gin.Context) {
+		c.Set("user_is_admin", isAdmin)
+		c.Set("config", config)
+		c.Next()
+	})
+	v1.GET("/gear/:gear/get", GetGear)
+	v1.GET("/gear/:gear/translation/list", ListGearTranslations)
+	v1.POST("/gear/:gear/translation/:locale/update", UpdateGearTranslation)
+	v1.DELETE("/gear/:gear/translation/:locale/delete", DeleteGearTranslation)
+	v1.POST("/category/:category/translation/:locale/update", UpdateCategoryTranslation)
+	v1.POST("/topCategory/:topCategory/translation/:locale/update", UpdateTopCategoryTranslation)
+	v1.GET("/category/tree", GetCategoryTree)
+	v1.GET("/translation/missing/list", ListMissingTranslations)
+	v1.POST("/users/preferences/update", UpdateUserPreferences)
+
+	for path, body := range map[string]string{
+		"/api/v1/gear/1/translation/nb-NO/update":     `{"fields":{"gear_name":"Telt (no)"}}`,
+		"/api/v1/gear/1/translation/nb/update":        `{"fields":{"gear_name":"Telt","gear_size_definition":"To personer"}}`,
+		"/api/v1/category/1/translation/nb/update":    `{"fields":{"category_name":"Telt"}}`,
+		"/api/v1/topCategory/1/translation/no/update": `{"fields":{"top_category_name":"Ly"}}`,
+		"/api/v1/category/2/translation/sv/update":    `{"fields":{"category_name":"Presenningar"}}`,
+		"/api/v1/gear/2/translation/nb/update":        `{"fields":{"gear_size_definition":"3x3"}}`,
+		"/api/v1/topCategory/1/translation/nb/update": `{"fields":{"top_category_name":"Ly"}}`,
+		"/api/v1/category/3/translation/nb-no/update": `{"fields":{"category_name":"Bivysekker"}}`,
+	} {
+		if w := authRequest(t, router, http.MethodPost, path, body); w.Code != http.StatusOK {
+			t.Fatalf("%s: %d %s", path, w.Code, w.Body.String())
+		}
+	}
+
+	w := authRequest(t, router, http.MethodGet, "/api/v1/gear/1/translation/list", "")
+	var translations []models.Translation
+	if err := json.Unmarshal(w.Body.Bytes(), &translations); err != nil || w.Code != http.StatusOK {
+		t.Fatalf("ListGearTranslations: %d %s", w.Code, w.Body.String())
+	}
+	if len(translations) != 2 || translations[0].Locale != "nb" || translations[0].Fields["gear_size_definition"] != "To personer" || translations[1].Locale != "nb-no" {
+		t.Errorf("ListGearTranslations: unexpected translations %+v", translations)
+	}
+
+	request := func(path, acceptLanguage string) *httptest.ResponseRecorder {
+		t.Helper()
+		req := httptest.NewRequest(http.MethodGet, path, nil)
+		req.Header.Set("Accept-Language", acceptLanguage)
+		w := httptest.NewRecorder()
+		router.ServeHTTP(w, req)
+		if w.Code != http.StatusOK {
+			t.Fatalf("%s: %d %s", path, w.Code, w.Body.String())
+		}
+		return w
+	}
+
+	// nb-NO prefers the nb-no gear name, and takes the rest from nb.
+	var gear models.FullGear
+	w = request("/api/v1/gear/1/get", "nb-NO,nb;q=0.9,en;q=0.8")
+	if err := json.Unmarshal(w.Body.Bytes(), &gear); err != nil {
+		t.Fatal(err)
+	}
+	if gear.GearName != "Telt (no)" || gear.GearSizeDefinition != "To personer" || gear.CategoryName != "Telt" || gear.TopCategoryName != "Ly" {
+		t.Errorf("GetGear in nb-NO: unexpected names %+v", gear)
+	}
+	if language := w.Header().Get("Content-Language"); language != "nb-no" {
+		t.Errorf("GetGear in nb-NO: expected Content-Language nb-no, got %q", language)
+	}
+
+	w = request("/api/v1/gear/list?sort=gear_id", "nb")
+	payload := decodeListPayload(t, w.Body.Bytes())
+	if len(payload.Items) != 2 || payload.Items[0]["gear_name"] != "Telt" || payload.Items[1]["gear_name"] != "Tarp" || payload.Items[1]["gear_size_definition"] != "3x3" {
+		t.Errorf("ListGear in nb: expected translated names falling back to the default, got %s", w.Body.String())
+	}
+	// sv is not a configured locale, so the default is used.
+	w = request("/api/v1/gear/list?sort=gear_id", "sv, de;q=0.5")
+	if payload := decodeListPayload(t, w.Body.Bytes()); len(payload.Items) != 2 || payload.Items[0]["gear_name"] != "Tent" || payload.Items[0]["category_name"] != "Tents" {
+		t.Errorf("ListGear in sv: expected the default locale, got %s", w.Body.String())
+	}
+
+	var tree []models.CategoryTree
+	if err := json.Unmarshal(request("/api/v1/category/tree", "nb").Body.Bytes(), &tree); err != nil {
+		t.Fatal(err)
+	}
+	if len(tree) != 1 || tree[0].TopCategoryName != "Ly" || len(tree[0].Children) != 3 || tree[0].Children[1].CategoryName != "Tarps" || tree[0].Children[2].CategoryName != "Telt" {
+		t.Errorf("GetCategoryTree in nb: unexpected tree %+v", tree)
+	}
+
+	// The user's preference wins over Accept-Language.
+	if w := authRequest(t, router, http.MethodPost, "/api/v1/users/preferences/update", `{"locale":"en"}`); w.Code != http.StatusOK {
+		t.Fatalf("UpdateUserPreferences: %d %s", w.Code, w.Body.String())
+	}
+	if err := json.Unmarshal(request("/api/v1/gear/1/get", "nb").Body.Bytes(), &gear); err != nil || gear.GearName != "Tent" {
+		t.Errorf("GetGear with an en preference: expected the default name, got %q (%v)", gear.GearName, err)
+	}
+	if w := authRequest(t, router, http.MethodPost, "/api/v1/users/preferences/update", `{"locale":"de"}`); w.Code != http.StatusBadRequest {
+		t.Errorf("UpdateUserPreferences: expected 400 for a locale that is not configured, got %d", w.Code)
+	}
+
+	w = authRequest(t, router, http.MethodGet, "/api/v1/translation/missing/list", "")
+	var reports []models.MissingTranslationReport
+	if err := json.Unmarshal(w.Body.Bytes(), &reports); err != nil || w.Code != http.StatusOK {
+		t.Fatalf("ListMissingTranslations: %d %s", w.Code, w.Body.String())
+	}
+	// Pending gear 3 is not reported. Gear 2 has no name translation, category 2 only a
+	// Swedish one and category 3 only one for nb-no, which also has the nb translations.
+	if len(reports) != 2 || reports[0].Locale != "nb" || reports[1].Locale != "nb-no" || len(reports[1].Missing) != 2 {
+		t.Fatalf("ListMissingTranslations: unexpected reports %+v", reports)
+	}
+	if reports[0].Total != 6 || len(reports[0].Missing) != 3 {
+		t.Fatalf("ListMissingTranslations: unexpected nb report %+v", reports[0])
+	}
+	for i, want := range []models.MissingTranslation{
+		{Kind: "gear", ID: 2, Name: "Tarp", MissingFields: []string{"gear_name"}},
+		{Kind: "category", ID: 2, Name: "Tarps", MissingFields: []string{"category_name"}},
+		{Kind: "category", ID: 3, Name: "Bivys", MissingFields: []string{"category_name"}},
+	} {
+		if got := reports[0].Missing[i]; got.Kind != want.Kind || got.ID != want.ID || got.Name != want.Name || !reflect.DeepEqual(got.MissingFields, want.MissingFields) {
+			t.Errorf("ListMissingTranslations: entry %d is %+v, want %+v", i, got, want)
+		}
+	}
+
+	w = authRequest(t, router, http.MethodGet, "/api/v1/translation/missing/list?locale=sv&kind=category", "")
+	if err := json.Unmarshal(w.Body.Bytes(), &reports); err != nil || len(reports) != 1 || reports[0].Total != 3 || len(reports[0].Missing) != 2 {
+		t.Errorf("ListMissingTranslations for sv categories: unexpected report %s", w.Body.String())
+	}
+
+	for path, want := range map[string]int{
+		"/api/v1/gear/1/translation/nb/update":        http.StatusBadRequest,
+		"/api/v1/gear/99/translation/nb/update":       http.StatusNotFound,
+		"/api/v1/gear/1/translation/n_o_p/update":     http.StatusBadRequest,
+		"/api/v1/category/1/translation/nb/update":    http.StatusBadRequest,
+		"/api/v1/topCategory/1/translation/nb/update": http.StatusBadRequest,
+	} {
+		body := `{"fields":{}}`
+		if want == http.StatusNotFound {
+			body = `{"fields":{"gear_name":"Telt"}}`
+		}
+		if w := authRequest(t, router, http.MethodPost, path, body); w.Code != want {
+			t.Errorf("%s: expected %d, got %d %s", path, want, w.Code, w.Body.String())
+		}
+	}
+	if w := authRequest(t, router, http.MethodPost, "/api/v1/category/1/translation/nb/update", `{"fields":{"gear_name":"Telt"}}`); w.Code != http.StatusBadRequest {
+		t.Errorf("UpdateCategoryTranslation: expected 400 for a field of gear, got %d", w.Code)
+	}
+
+	if w := authRequest(t, router, http.MethodDelete, "/api/v1/gear/1/translation/nb-no/delete", ""); w.Code != http.StatusOK {
+		t.Errorf("DeleteGearTranslation: %d %s", w.Code, w.Body.String())
+	}
+	if w := authRequest(t, router, http.MethodDelete, "/api/v1/gear/1/translation/nb-no/delete", ""); w.Code != http.StatusNotFound {
+		t.Errorf("DeleteGearTranslation: expected 404 for a missing translation, got %d", w.Code)
+	}
+
+	// Translations go with the gear.
+	if _, err := db.Exec(`DELETE FROM gear WHERE gearId = 1`); err != nil {
+		t.Fatal(err)
+	}
+	var count int
+	if err := db.QueryRow(`SELECT COUNT(*) FROM gear_translations WHERE gearId = 1`).Scan(&count); err != nil || count != 0 {
+		t.Errorf("expected the translations of deleted gear to be removed, got %d (%v)", count, err)
+	}
+
+	isAdmin = false
+	if w := authRequest(t, router, http.MethodGet, "/api/v1/gear/2/translation/list", ""); w.Code != http.StatusForbidden {
+		t.Errorf("ListGearTranslations: expected 403 for a non-admin, got %d", w.Code)
+	}
+	if w := authRequest(t, router, http.MethodGet, "/api/v1/translation/missing/list", ""); w.Code != http.StatusForbidden {
+		t.Errorf("ListMissingTranslations: expected 403 for a non-admin, got %d", w.Code)
+	}
+}
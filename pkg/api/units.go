@@ -0,0 +1,81 @@
+package endpoints
+
+import (
+	"database/sql"
+	"errors"
+	"net/http"
+
+	models "github.com/Sea-Shell/gogear-api/pkg/models"
+	utils "github.com/Sea-Shell/gogear-api/pkg/utils"
+
+	gin "github.com/gin-gonic/gin"
+	zap "go.uber.org/zap"
+)
+
+// requestUnitSystem returns the unit system named by the units query parameter, or else
+// the authenticated user's preference. It is empty when neither is set.
+func requestUnitSystem(c *gin.Context, db *sql.DB) (string, error) {
+	if units := c.Query("units"); units != "" {
+		return utils.ParseUnitSystem(units)
+	}
+
+	userID, ok := c.Get("user_id_int64")
+	if !ok {
+		return "", nil
+	}
+
+	var system sql.NullString
+	err := db.QueryRow("SELECT unitSystem FROM user_preferences WHERE userId = ?", userID).Scan(&system)
+	if err == sql.ErrNoRows {
+		return "", nil
+	}
+	if err != nil {
+		return "", err
+	}
+	if !system.Valid {
+		return "", nil
+	}
+	return utils.ParseUnitSystem(system.String)
+}
+
+// measureItems adds the measurements of each item converted to the request's unit
+// system. It writes a 400 for an invalid units parameter and returns false on failure.
+func measureItems[model any](c *gin.Context, log *zap.SugaredLogger, db *sql.DB, items ...*model) bool {
+	if !utils.HasMeasurements[model]() || len(items) == 0 {
+		return true
+	}
+
+	system, err := requestUnitSystem(c, db)
+	if err != nil {
+		respondUnitError(c, log, err)
+		return false
+	}
+
+	for _, item := range items {
+		utils.ApplyUnits(item, system)
+	}
+	return true
+}
+
+// normalizeMeasurements converts measurements given with a unit in a request body to
+// grams and millimetres. It writes a 400 and returns false when a unit is invalid.
+func normalizeMeasurements[model any](c *gin.Context, log *zap.SugaredLogger, data []byte) ([]byte, bool) {
+	normalized, err := utils.NormalizeMeasurements[model](data)
+	if err != nil {
+		respondUnitError(c, log, err)
+		return nil, false
+	}
+	return normalized, true
+}
+
+// respondUnitError writes a 400 for unit errors and a 500 for everything else.
+func respondUnitError(c *gin.Context, log *zap.SugaredLogger, err error) {
+	var unitErr *utils.UnitError
+	if errors.As(err, &unitErr) {
+		log.Warnf("Invalid units: %s", unitErr.Message)
+		c.IndentedJSON(http.StatusBadRequest, models.Error{Error: unitErr.Message})
+		return
+	}
+	log.Errorf("Unable to load unit preference: %#v", err)
+	c.IndentedJSON(http.StatusInternalServerError, models.Error{Error: err.Error()})
+}
@@ -0,0 +1,111 @@
+package endpoints
+
+import (
+	"encoding/json"
+	"net/http"
+	"testing"
+
+	models "github.com/Sea-Shell/gogear-api/pkg/models"
+)
+
+func TestUnits_InputConversionAndResponseMeasurements(t *testing.T) {
+	db, router := setupCatalogTest(t, 1)
+	seedCatalog(t, db)
+
+	v1 := router.Group("/api/v1", testAuthMiddleware(1))
+	v1.GET("/gear/:gear/get", GetGear)
+	v1.PUT("/gear/insert", InsertGear)
+	v1.GET("/users/preferences/get", GetUserPreferences)
+	v1.POST("/users/preferences/update", UpdateUserPreferences)
+	v1.PUT("/loadout/insert", InsertLoadout)
+	v1.GET("/loadout/:loadout/get", GetLoadout)
+	v1.PUT("/loadout/:loadout/item/insert", InsertLoadoutItem)
+
+	insert := `{"gear_top_category_id":1,"gear_category_id":1,"gear_manufacture_id":1,"gear_name":"Nallo 2",
+        "gear_weight":{"value":2.4,"unit":"kg"},"gear_height":{"value":3.5,"unit":"feet"},"gear_length":2200,"gear_width":{"value":1,"unit":"m"}}`
+	w := authRequest(t, router, http.MethodPut, "/api/v1/gear/insert", insert)
+	var created models.Gear
+	if err := json.Unmarshal(w.Body.Bytes(), &created); err != nil || created.GearID == nil {
+		t.Fatalf("InsertGear: %d %s", w.Code, w.Body.String())
+	}
+	if created.GearWeight != 2400 || created.GearHeight != 1067 || created.GearLength != 2200 || created.GearWidth != 1000 {
+		t.Errorf("InsertGear stored %+v, want grams and millimetres", created)
+	}
+
+	if w := authRequest(t, router, http.MethodPut, "/api/v1/gear/insert", `{"gear_name":"x","gear_weight":{"value":2,"unit":"cm"}}`); w.Code != http.StatusBadRequest {
+		t.Errorf("InsertGear: expected 400 for a length unit on a weight, got %d", w.Code)
+	}
+
+	gearURL := "/api/v1/gear/" + itoa64(*created.GearID) + "/get"
+	var gear models.FullGear
+	w = authRequest(t, router, http.MethodGet, gearURL, "")
+	if err := json.Unmarshal(w.Body.Bytes(), &gear); err != nil || gear.Measurements != nil {
+		t.Errorf("GetGear without units: measurements = %v, %v", gear.Measurements, err)
+	}
+
+	w = authRequest(t, router, http.MethodGet, gearURL+"?units=imperial", "")
+	if err := json.Unmarshal(w.Body.Bytes(), &gear); err != nil {
+		t.Fatalf("GetGear imperial: %d %s", w.Code, w.Body.String())
+	}
+	if got := gear.Measurements["gear_weight"]; got != (models.Measurement{Value: 84.66, Unit: "oz"}) {
+		t.Errorf("imperial gear_weight = %+v", got)
+	}
+	if got := gear.Measurements["gear_length"]; got != (models.Measurement{Value: 86.61, Unit: "in"}) {
+		t.Errorf("imperial gear_length = %+v", got)
+	}
+	if gear.GearWeight != 2400 {
+		t.Errorf("canonical gear_weight = %d, want 2400", gear.GearWeight)
+	}
+
+	if w := authRequest(t, router, http.MethodGet, gearURL+"?units=cubits", ""); w.Code != http.StatusBadRequest {
+		t.Errorf("GetGear: expected 400 for unknown units, got %d", w.Code)
+	}
+
+	if w := authRequest(t, router, http.MethodPost, "/api/v1/users/preferences/update", `{"unit_system":"Metric"}`); w.Code != http.StatusOK {
+		t.Fatalf("UpdateUserPreferences: %d %s", w.Code, w.Body.String())
+	}
+	w = authRequest(t, router, http.MethodGet, "/api/v1/users/preferences/get", "")
+	var preferences models.UserPreferences
+	if err := json.Unmarshal(w.Body.Bytes(), &preferences); err != nil || preferences.UnitSystem == nil || *preferences.UnitSystem != "metric" {
+		t.Errorf("GetUserPreferences: %s", w.Body.String())
+	}
+
+	w = authRequest(t, router, http.MethodGet, gearURL, "")
+	gear = models.FullGear{}
+	if err := json.Unmarshal(w.Body.Bytes(), &gear); err != nil {
+		t.Fatalf("GetGear with preference: %d %s", w.Code, w.Body.String())
+	}
+	if got := gear.Measurements["gear_height"]; got != (models.Measurement{Value: 106.7, Unit: "cm"}) {
+		t.Errorf("preferred gear_height = %+v", got)
+	}
+
+	w = authRequest(t, router, http.MethodPut, "/api/v1/loadout/insert", `{"loadout_name":"Trip","loadout_slug":"units-trip"}`)
+	var loadout models.Loadout
+	if err := json.Unmarshal(w.Body.Bytes(), &loadout); err != nil || loadout.LoadoutID == nil {
+		t.Fatalf("InsertLoadout: %d %s", w.Code, w.Body.String())
+	}
+	loadoutURL := "/api/v1/loadout/" + itoa64(*loadout.LoadoutID)
+	if w := authRequest(t, router, http.MethodPut, loadoutURL+"/item/insert", `{"gear_id":`+itoa64(*created.GearID)+`,"quantity":2}`); w.Code != http.StatusCreated {
+		t.Fatalf("InsertLoadoutItem: %d %s", w.Code, w.Body.String())
+	}
+
+	w = authRequest(t, router, http.MethodGet, loadoutURL+"/get?units=imperial", "")
+	if err := json.Unmarshal(w.Body.Bytes(), &loadout); err != nil {
+		t.Fatalf("GetLoadout: %d %s", w.Code, w.Body.String())
+	}
+	if got := loadout.Measurements["total_weight"]; loadout.TotalWeight != 4800 || got != (models.Measurement{Value: 10.58, Unit: "lb"}) {
+		t.Errorf("loadout total %d, measurement %+v", loadout.TotalWeight, got)
+	}
+
+	if w := authRequest(t, router, http.MethodPost, "/api/v1/users/preferences/update", `{"unit_system":"furlongs"}`); w.Code != http.StatusBadRequest {
+		t.Errorf("UpdateUserPreferences: expected 400 for unknown system, got %d", w.Code)
+	}
+
+	// A failing preference lookup is an error rather than a silent fall back to metric.
+	if _, err := db.Exec(`ALTER TABLE user_preferences RENAME COLUMN unitSystem TO unitSystemOld`); err != nil {
+		t.Fatal(err)
+	}
+	if w := authRequest(t, router, http.MethodGet, gearURL, ""); w.Code != http.StatusInternalServerError {
+		t.Errorf("GetGear: expected 500 when the unit preference cannot be loaded, got %d %s", w.Code, w.Body.String())
+	}
+}
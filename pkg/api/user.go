@@ -4,12 +4,8 @@ import (
 	"database/sql"
 	"fmt"
 	"io"
-	"math"
 	"net/http"
-	"net/url"
-	"reflect"
 	"strconv"
-	"strings"
 
 	models "github.com/Sea-Shell/gogear-api/pkg/models"
 	utils "github.com/Sea-Shell/gogear-api/pkg/utils"
@@ -29,6 +25,10 @@ import (
 //	@Produce		json
 //	@Param			page		query		int		false	"Page number"				default(1)
 //	@Param			limit		query		int		false	"Number of items per page"	default(30)
+//	@Param			sort		query		string	false	"Field to sort by, json or db field name"
+//	@Param			order		query		string	false	"Sort direction, asc or desc"	default(asc)
+//	@Param			cursor		query		string	false	"Opaque cursor from next_cursor or prev_cursor, replaces page"
+//	@Param			count		query		bool	false	"Include total item count"	default(true)
 //	@Param			user		query		string	false	"search by user's username (this is case insensitive and wildcard)"
 //	@Param			username	query		string	false	"search by users full name (this is case insensitive and wildcard)"
 //	@Param			email		query		string	false	"search by users email (this is case insensitive and wildcard)"
@@ -38,10 +38,6 @@ import (
 func ListUser(c *gin.Context) {
 	c.Header("Content-Type", "application/json")
 
-	currentQueryParameters := c.Request.URL.Query()
-
-	page := c.Query("page")
-	limit := c.Query("limit")
 	qUserUsername := c.QueryArray("user")
 	qUserName := c.QueryArray("username")
 	qUserEmail := c.QueryArray("email")
@@ -49,43 +45,11 @@ func ListUser(c *gin.Context) {
 	log := c.MustGet("logger").(*zap.SugaredLogger)
 	db := c.MustGet("db").(*sql.DB)
 
-	if limit == "" {
-		limit = "30"
-	}
-
-	if page == "" || page == "0" {
-		page = "1"
-	}
-
-	pageInt, err := strconv.Atoi(page)
-	if err != nil {
-		log.Errorf("Error setting page to int: %#v", err)
-		c.IndentedJSON(http.StatusInternalServerError, models.Error{Error: err.Error()})
-		return
-	}
-
-	limitInt, err := strconv.Atoi(limit)
-	if err != nil {
-		log.Errorf("Error setting limit to int: %#v", err)
-		c.IndentedJSON(http.StatusInternalServerError, models.Error{Error: err.Error()})
-		return
-	}
-
-	if pageInt <= 0 {
-		log.Errorf("Error page is less than 0: %#v", err)
-		c.IndentedJSON(http.StatusBadRequest, models.Error{Error: "Invalid page number"})
-		return
-	}
-
-	if limitInt <= 0 {
-		log.Errorf("Error limit is less than 0: %#v", err)
-		c.IndentedJSON(http.StatusBadRequest, models.Error{Error: "Invalid limit number"})
-		return
-	}
+	query := utils.NewListQuery[models.User]("users")
 
 	conditions := []string{}
-
 	params := []interface{}{}
+
 	for _, username := range qUserUsername {
 		conditions = append(conditions, "userUsername LIKE ?")
 		params = append(params, "%"+username+"%")
@@ -101,114 +65,14 @@ func ListUser(c *gin.Context) {
 		params = append(params, "%"+email+"%")
 	}
 
-	whereClause := ""
-	if len(conditions) > 0 {
-		whereClause = " WHERE " + strings.Join(conditions, " OR ")
-	}
-
-	baseCountQuery := "SELECT COUNT(*) FROM users"
-	countQuery := baseCountQuery + whereClause
+	query.WhereAny(conditions, params...)
 
-	var totalCount int
-	err = db.QueryRow(countQuery, params...).Scan(&totalCount)
-	if err != nil {
-		log.Errorf("Error getting GearCount database: %#v", err)
-		c.IndentedJSON(http.StatusInternalServerError, models.Error{Error: err.Error()})
+	if err := query.FilterFields(c.Request.URL.Query()); err != nil {
+		respondListQueryError(c, log, err)
 		return
 	}
 
-	start := strconv.Itoa((pageInt - 1) * limitInt)
-	totalPages := int(math.Ceil(float64(totalCount) / float64(limitInt)))
-
-	startInt, err := strconv.Atoi(start)
-	if err != nil {
-		c.IndentedJSON(http.StatusInternalServerError, models.Error{Error: err.Error()})
-		return
-	}
-
-	var paramUser models.User
-	fields := utils.GetDBFieldNames(reflect.TypeOf(paramUser))
-
-	baseQuery := fmt.Sprintf(`SELECT %s FROM users`, strings.Join(fields, ", "))
-
-	queryLimit := fmt.Sprintf(" LIMIT %v, %v", startInt, limitInt) // validated beforehand
-
-	query := baseQuery + whereClause + queryLimit
-
-	log.Debugf("Query: %s Params: %#v", query, params)
-
-	rows, err := db.Query(query, params...)
-	if err != nil {
-		log.Errorf("Query error: %#v", err.Error())
-		c.IndentedJSON(http.StatusInternalServerError, models.Error{Error: err.Error()})
-		return
-	}
-	defer rows.Close()
-
-	dest, err := utils.GetScanFields(paramUser)
-	if err != nil {
-		log.Errorf("Error getting destination arguments: %#v", err)
-		c.IndentedJSON(http.StatusInternalServerError, models.Error{Error: err.Error()})
-		return
-	}
-
-	var users []models.User
-
-	for rows.Next() {
-		err = rows.Scan(dest...)
-		if err != nil {
-			if err == sql.ErrNoRows {
-				log.Errorf("No user found")
-				c.IndentedJSON(http.StatusNotFound, models.Error{Error: "No results"})
-				return
-			}
-			log.Errorf("Scan error: %#v", err)
-			c.IndentedJSON(http.StatusInternalServerError, models.Error{Error: err.Error()})
-			return
-		}
-
-		for i := 0; i < reflect.TypeOf(paramUser).NumField(); i++ {
-			reflect.ValueOf(&paramUser).Elem().Field(i).Set(reflect.ValueOf(dest[i]).Elem())
-		}
-
-		users = append(users, paramUser)
-	}
-
-	if err := rows.Err(); err != nil {
-		log.Errorf("Row iteration error: %#v", err)
-		c.IndentedJSON(http.StatusInternalServerError, models.Error{Error: err.Error()})
-		return
-	}
-
-	payload := models.ResponsePayload{
-		TotalItemCount: totalCount,
-		CurrentPage:    pageInt,
-		ItemLimit:      limitInt,
-		TotalPages:     totalPages,
-		Items:          users,
-	}
-
-	if pageInt < totalPages {
-		currentQueryParameters.Set("page", strconv.Itoa(pageInt+1))
-		nextPage := url.URL{
-			Path:     c.Request.URL.Path,
-			RawQuery: currentQueryParameters.Encode(),
-		}
-		payload.NextPage = new(string)
-		*payload.NextPage = nextPage.String()
-	}
-
-	if pageInt > 1 {
-		currentQueryParameters.Set("page", strconv.Itoa(pageInt-1))
-		prevPage := url.URL{
-			Path:     c.Request.URL.Path,
-			RawQuery: currentQueryParameters.Encode(),
-		}
-		payload.PrevPage = new(string)
-		*payload.PrevPage = prevPage.String()
-	}
-
-	c.IndentedJSON(http.StatusOK, payload)
+	respondList(c, log, db, query)
 }
 
 // GetUser gets spessific user based on ID
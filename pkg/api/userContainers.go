@@ -4,10 +4,7 @@ import (
 	"database/sql"
 	"fmt"
 	"io"
-	"math"
 	"net/http"
-	"net/url"
-	"reflect"
 	"strconv"
 	"strings"
 
@@ -27,18 +24,19 @@ import (
 //	@Tags			User container
 //	@Accept			json
 //	@Produce		json
-//	@Param			page		query		int	false	"Page number"				default(1)
-//	@Param			limit		query		int	false	"Number of items per page"	default(30)
-//	@Param			container	path		int	true	"Unique ID of userGear you want to update"
+//	@Param			page		query		int		false	"Page number"				default(1)
+//	@Param			limit		query		int		false	"Number of items per page"	default(30)
+//	@Param			sort		query		string	false	"Field to sort by, json or db field name"
+//	@Param			order		query		string	false	"Sort direction, asc or desc"	default(asc)
+//	@Param			cursor		query		string	false	"Opaque cursor from next_cursor or prev_cursor, replaces page"
+//	@Param			count		query		bool	false	"Include total item count"	default(true)
+//	@Param			container	path		int		true	"Unique ID of userGear you want to update"
+//	@Param			units		query		string	false	"Also give measurements in metric or imperial units, overriding the user's preference"
 //	@Success		200			{object}	models.ResponsePayload{items=[]models.FullGear}
 //	@Router			/api/v1/container/{container}/list [get]
 func ListUserGearInContainer(c *gin.Context) {
 	c.Header("Content-Type", "application/json")
 
-	currentQueryParameters := c.Request.URL.Query()
-
-	page := c.Query("page")
-	limit := c.Query("limit")
 	container := c.Param("container")
 
 	log := c.MustGet("logger").(*zap.SugaredLogger)
@@ -52,14 +50,6 @@ func ListUserGearInContainer(c *gin.Context) {
 		return
 	}
 
-	if limit == "" {
-		limit = "30"
-	}
-
-	if page == "" || page == "0" {
-		page = "1"
-	}
-
 	containerInt, err := strconv.Atoi(container)
 	if err != nil {
 		log.Errorf("Error setting container to int: %#v", err)
@@ -94,158 +84,15 @@ func ListUserGearInContainer(c *gin.Context) {
 	// 	}
 	// }
 
-	pageInt, err := strconv.Atoi(page)
-	if err != nil {
-		log.Errorf("Error setting page to int: %#v", err)
-		c.IndentedJSON(http.StatusInternalServerError, models.Error{Error: err.Error()})
-		return
-	}
-
-	limitInt, err := strconv.Atoi(limit)
-	if err != nil {
-		log.Errorf("Error setting limit to int: %#v", err)
-		c.IndentedJSON(http.StatusInternalServerError, models.Error{Error: err.Error()})
-		return
-	}
-
-	if pageInt <= 0 {
-		log.Errorf("Error page is less than 0: %#v", err)
-		c.IndentedJSON(http.StatusBadRequest, models.Error{Error: "Invalid page number"})
-		return
-	}
-
-	if limitInt <= 0 {
-		log.Errorf("Error limit is less than 0: %#v", err)
-		c.IndentedJSON(http.StatusBadRequest, models.Error{Error: "Invalid limit number"})
-		return
-	}
-
-	conditions := []string{}
-
-	if container != "" {
-		userIDQ := fmt.Sprintf("user_container_registration.userContainerId = %d", containerInt)
-		conditions = append(conditions, userIDQ)
-	}
-
-	whereClause := ""
-	if len(conditions) > 0 {
-		whereClause = " WHERE " + strings.Join(conditions, " AND ")
-	}
-
-	var extra []string
-	extra = append(extra, " LEFT JOIN user_container_registration ON user_container_registration.userGearRegistrationId = user_gear_registrations.userGearRegistrationId")
-	extra = append(extra, " LEFT JOIN gear ON user_gear_registrations.gearId = gear.gearId")
-	extra = append(extra, "LEFT JOIN users ON user_gear_registrations.userId = users.userId")
-	extra = append(extra, "LEFT JOIN manufacture ON gear.gearManufactureId = manufacture.manufactureId")
-	extra = append(extra, "LEFT JOIN gear_top_category ON gear.gearTopCategoryId = gear_top_category.topCategoryId")
-	extra = append(extra, "LEFT JOIN gear_category ON gear.gearCategoryId = gear_category.categoryId ")
-	extraSQL := strings.Join(extra, " ")
-
-	baseCountQuery := "SELECT COUNT(*) FROM user_container_registration"
-	countQuery := baseCountQuery + extraSQL + whereClause
-	log.Debugf("countQuery: %s", countQuery)
-
-	var totalCount int
-	err = db.QueryRow(countQuery).Scan(&totalCount)
-	if err != nil {
-		log.Errorf("Error getting GearCount database: %#v", err)
-		c.IndentedJSON(http.StatusInternalServerError, models.Error{Error: err.Error()})
-		return
-	}
-
-	start := strconv.Itoa((pageInt - 1) * limitInt)
-	totalPages := int(math.Ceil(float64(totalCount) / float64(limitInt)))
+	query := utils.NewListQuery[models.UserGear]("user_container_registration" + containerGearJoins)
+	query.Where("user_container_registration.userContainerId = ?", containerInt)
 
-	startInt, err := strconv.Atoi(start)
-	if err != nil {
-		c.IndentedJSON(http.StatusInternalServerError, models.Error{Error: err.Error()})
-		return
-	}
-
-	var paramTopCategory models.UserGear
-	fields := utils.GetDBFieldNames(reflect.TypeOf(paramTopCategory))
-
-	baseQuery := fmt.Sprintf(`SELECT %s FROM user_container_registration`, strings.Join(fields, ", "))
-
-	queryLimit := fmt.Sprintf(" LIMIT %v, %v", startInt, limitInt)
-
-	query := baseQuery + extraSQL + whereClause + queryLimit
-
-	log.Debugf("Query: %s", query)
-
-	rows, err := db.Query(query)
-	if err != nil {
-		log.Errorf("Query error: %#v", err.Error())
-		c.IndentedJSON(http.StatusInternalServerError, models.Error{Error: err.Error()})
-		return
-	}
-	defer rows.Close()
-
-	dest, err := utils.GetScanFields(paramTopCategory)
-	if err != nil {
-		log.Errorf("Error getting destination arguments: %#v", err)
-		c.IndentedJSON(http.StatusInternalServerError, models.Error{Error: err.Error()})
+	if err := query.FilterFields(c.Request.URL.Query()); err != nil {
+		respondListQueryError(c, log, err)
 		return
 	}
 
-	var gearTopCategoryList []models.UserGear
-
-	for rows.Next() {
-		err = rows.Scan(dest...)
-		if err != nil {
-			if err == sql.ErrNoRows {
-				log.Errorf("No gear found")
-				c.IndentedJSON(http.StatusNotFound, models.Error{Error: "No results"})
-				return
-			}
-			log.Errorf("Scan error: %#v", err)
-			c.IndentedJSON(http.StatusInternalServerError, models.Error{Error: err.Error()})
-			return
-		}
-
-		for i := 0; i < reflect.TypeOf(paramTopCategory).NumField(); i++ {
-			reflect.ValueOf(&paramTopCategory).Elem().Field(i).Set(reflect.ValueOf(dest[i]).Elem())
-		}
-
-		gearTopCategoryList = append(gearTopCategoryList, paramTopCategory)
-	}
-
-	if err := rows.Err(); err != nil {
-		log.Errorf("Row iteration error: %#v", err)
-		c.IndentedJSON(http.StatusInternalServerError, models.Error{Error: err.Error()})
-		return
-	}
-
-	payload := models.ResponsePayload{
-		TotalItemCount: totalCount,
-		CurrentPage:    pageInt,
-		ItemLimit:      limitInt,
-		TotalPages:     totalPages,
-		Items:          gearTopCategoryList,
-	}
-
-	if pageInt < totalPages {
-		currentQueryParameters.Set("page", strconv.Itoa(pageInt+1))
-		nextPage := url.URL{
-			Path:     c.Request.URL.Path,
-			RawQuery: currentQueryParameters.Encode(),
-		}
-		payload.NextPage = new(string)
-		*payload.NextPage = nextPage.String()
-	}
-
-	if pageInt > 1 {
-		currentQueryParameters.Set("page", strconv.Itoa(pageInt-1))
-		prevPage := url.URL{
-			Path:     c.Request.URL.Path,
-			RawQuery: currentQueryParameters.Encode(),
-		}
-		payload.PrevPage = new(string)
-		*payload.PrevPage = prevPage.String()
-	}
-
-	c.IndentedJSON(http.StatusOK, payload)
-
+	respondList(c, log, db, query)
 }
 
 // InsertContainer puts gear on the users gear list
@@ -0,0 +1,115 @@
+package endpoints
+
+import (
+	"database/sql"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"slices"
+	"strings"
+
+	models "github.com/Sea-Shell/gogear-api/pkg/models"
+	utils "github.com/Sea-Shell/gogear-api/pkg/utils"
+
+	gin "github.com/gin-gonic/gin"
+	_ "github.com/mattn/go-sqlite3"
+	zap "go.uber.org/zap"
+)
+
+// GetUserPreferences returns the authenticated user's preferences.
+//
+//	@Summary		Get user preferences
+//	@Description	Get the authenticated user's preferences. unit_system is metric, imperial or null for canonical grams and millimetres only. locale is the language catalog names are translated to, or null to follow the Accept-Language header.
+//	@Security		BearerAuth
+//	@Tags			User
+//	@Accept			json
+//	@Produce		json
+//	@Success		200		{object}	models.UserPreferences
+//	@Failure		default	{object}	models.Error
+//	@Router			/api/v1/users/preferences/get [get]
+func GetUserPreferences(c *gin.Context) {
+	log := c.MustGet("logger").(*zap.SugaredLogger)
+	db := c.MustGet("db").(*sql.DB)
+	userID := c.MustGet("user_id_int64").(int64)
+
+	preferences, err := utils.GenericGet[models.UserPreferences]("user_preferences", int(userID), nil, db)
+	if err == sql.ErrNoRows {
+		c.IndentedJSON(http.StatusOK, models.UserPreferences{UserID: userID})
+		return
+	}
+	if err != nil {
+		log.Errorf("Unable to get preferences for user %d: %#v", userID, err)
+		c.IndentedJSON(http.StatusInternalServerError, models.Error{Error: err.Error()})
+		return
+	}
+
+	c.IndentedJSON(http.StatusOK, preferences)
+}
+
+// UpdateUserPreferences sets the authenticated user's preferences.
+//
+//	@Summary		Update user preferences
+//	@Description	Set the authenticated user's preferences. unit_system is metric or imperial, or null to clear it; measured responses then include a measurements object in that system unless the request sets units. locale is a language tag such as nb; catalog names are then translated to it instead of following the Accept-Language header. It must be one of the configured locales, if any are.
+//	@Security		BearerAuth
+//	@Tags			User
+//	@Accept			json
+//	@Produce		json
+//	@Param			request	body		models.UserPreferences	true	"Preferences; user_id is taken from the token"
+//	@Success		200		{object}	models.UserPreferences
+//	@Failure		400		{object}	models.Error
+//	@Failure		default	{object}	models.Error
+//	@Router			/api/v1/users/preferences/update [post]
+func UpdateUserPreferences(c *gin.Context) {
+	log := c.MustGet("logger").(*zap.SugaredLogger)
+	db := c.MustGet("db").(*sql.DB)
+	userID := c.MustGet("user_id_int64").(int64)
+
+	data, err := io.ReadAll(c.Request.Body)
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, models.Error{Error: err.Error()})
+		log.Error(err.Error())
+		return
+	}
+
+	var preferences models.UserPreferences
+	if err := json.Unmarshal(data, &preferences); err != nil {
+		c.JSON(http.StatusBadRequest, models.Error{Error: err.Error()})
+		log.Error(err.Error())
+		return
+	}
+	preferences.UserID = userID
+
+	if preferences.UnitSystem != nil {
+		system, err := utils.ParseUnitSystem(*preferences.UnitSystem)
+		if err != nil {
+			respondUnitError(c, log, err)
+			return
+		}
+		preferences.UnitSystem = &system
+	}
+
+	if preferences.Locale != nil {
+		locale, err := utils.NormalizeLocale(*preferences.Locale)
+		if err != nil {
+			respondTranslationError(c, log, err, "")
+			return
+		}
+		if _, locales := localeSettings(c); len(locales) > 0 && !slices.Contains(locales, locale) {
+			c.JSON(http.StatusBadRequest, models.Error{Error: fmt.Sprintf("locale must be one of %s", strings.Join(locales, ", "))})
+			return
+		}
+		preferences.Locale = &locale
+	}
+
+	_, err = db.Exec(`INSERT INTO user_preferences (userId, unitSystem, locale) VALUES (?, ?, ?)
+        ON CONFLICT (userId) DO UPDATE SET unitSystem = excluded.unitSystem, locale = excluded.locale`,
+		preferences.UserID, preferences.UnitSystem, preferences.Locale)
+	if err != nil {
+		log.Errorf("Unable to save preferences for user %d: %#v", userID, err)
+		c.JSON(http.StatusInternalServerError, models.Error{Error: err.Error()})
+		return
+	}
+
+	c.IndentedJSON(http.StatusOK, preferences)
+}
@@ -5,12 +5,11 @@ import (
 	"encoding/json"
 	"fmt"
 	"io"
-	"math"
 	"net/http"
 	"net/url"
-	"reflect"
 	"strconv"
 	"strings"
+	"time"
 
 	models "github.com/Sea-Shell/gogear-api/pkg/models"
 	utils "github.com/Sea-Shell/gogear-api/pkg/utils"
@@ -23,28 +22,39 @@ import (
 // ListUserGear list the users registered gear
 //
 //	@Summary		List users gear
-//	@Description	Get a list a users gear
+//	@Description	Get a list a users gear. Any field can be sorted on, including purchase_date, purchase_price and condition.
 //	@Security		BearerAuth
 //	@Tags			User gear
 //	@Accept			json
 //	@Produce		json
-//	@Param			user		path		int		true	"Unique ID of user you want to get the Gear of"
-//	@Param			page		query		int		false	"Page number"													default(1)
-//	@Param			limit		query		int		false	"Number of items per page"										default(30)
-//	@Param			topCategory	query		[]int	false	"top categories"												collectionFormat(multi)
-//	@Param			category	query		[]int	false	"sub categories"												collectionFormat(multi)
-//	@Param			manufacture	query		[]int	false	"manufacturers"													collectionFormat(multi)
-//	@Param			container	query		string	false	"show container gear only. valid values are true, false, all"	default(all)
-//	@Success		200			{object}	models.ResponsePayload{items=[]models.UserGear}
-//	@Failure		default		{object}	models.Error
+//	@Param			user				path		int			true	"Unique ID of user you want to get the Gear of"
+//	@Param			page				query		int			false	"Page number"				default(1)
+//	@Param			limit				query		int			false	"Number of items per page"	default(30)
+//	@Param			sort				query		string		false	"Field to sort by, json or db field name"
+//	@Param			order				query		string		false	"Sort direction, asc or desc"	default(asc)
+//	@Param			cursor				query		string		false	"Opaque cursor from next_cursor or prev_cursor, replaces page"
+//	@Param			count				query		bool		false	"Include total item count"										default(true)
+//	@Param			topCategory			query		[]int		false	"top categories"												collectionFormat(multi)
+//	@Param			category			query		[]int		false	"sub categories, including the categories below them"			collectionFormat(multi)
+//	@Param			manufacture			query		[]int		false	"manufacturers"													collectionFormat(multi)
+//	@Param			container			query		string		false	"show container gear only. valid values are true, false, all"	default(all)
+//	@Param			condition			query		[]string	false	"Item conditions: new, like_new, good, fair or poor"			collectionFormat(multi)
+//	@Param			purchase_currency	query		[]string	false	"Purchase currencies"											collectionFormat(multi)
+//	@Param			retailer			query		[]string	false	"Retailers"														collectionFormat(multi)
+//	@Param			colour				query		[]string	false	"Colours"														collectionFormat(multi)
+//	@Param			purchased_after		query		string		false	"Purchased on or after this YYYY-MM-DD date"
+//	@Param			purchased_before	query		string		false	"Purchased on or before this YYYY-MM-DD date"
+//	@Param			min_price			query		number		false	"Minimum purchase price"
+//	@Param			max_price			query		number		false	"Maximum purchase price"
+//	@Param			tag					query		[]string	false	"Registration tags"									collectionFormat(multi)
+//	@Param			tag_match			query		string		false	"Match registrations with any or all of the tags"	default(any)
+//	@Param			units				query		string		false	"Also give measurements in metric or imperial units, overriding the user's preference"
+//	@Success		200					{object}	models.ResponsePayload{items=[]models.UserGear}
+//	@Failure		default				{object}	models.Error
 //	@Router			/api/v1/usergear/{user}/list [get]
 func ListUserGear(c *gin.Context) {
 	c.Header("Content-Type", "application/json")
 
-	currentQueryParameters := c.Request.URL.Query()
-
-	page := c.Query("page")
-	limit := c.Query("limit")
 	userID := c.Param("user")
 	topCategories := c.QueryArray("topCategory")
 	categories := c.QueryArray("category")
@@ -62,14 +72,6 @@ func ListUserGear(c *gin.Context) {
 		return
 	}
 
-	if limit == "" {
-		limit = "30"
-	}
-
-	if page == "" || page == "0" {
-		page = "1"
-	}
-
 	if !containerBool {
 		container = "all"
 	}
@@ -107,203 +109,91 @@ func ListUserGear(c *gin.Context) {
 		}
 	}
 
-	pageInt, err := strconv.Atoi(page)
-	if err != nil {
-		log.Errorf("Error setting page to int: %#v", err)
-		c.IndentedJSON(http.StatusInternalServerError, models.Error{Error: err.Error()})
-		return
-	}
-
-	limitInt, err := strconv.Atoi(limit)
-	if err != nil {
-		log.Errorf("Error setting limit to int: %#v", err)
-		c.IndentedJSON(http.StatusInternalServerError, models.Error{Error: err.Error()})
-		return
-	}
-
-	if pageInt <= 0 {
-		log.Errorf("Error page is less than 0: %#v", err)
-		c.IndentedJSON(http.StatusBadRequest, models.Error{Error: "Invalid page number"})
-		return
-	}
-
-	if limitInt <= 0 {
-		log.Errorf("Error limit is less than 0: %#v", err)
-		c.IndentedJSON(http.StatusBadRequest, models.Error{Error: "Invalid limit number"})
-		return
-	}
-
-	conditions := []string{}
-
-	if userID != "" {
-		userIDQ := fmt.Sprintf("user_gear_registrations.userId = %d", userIDInt)
-		conditions = append(conditions, userIDQ)
-	}
-
-	for _, topCategory := range topCategories {
-		topCategoryInt, err := strconv.Atoi(topCategory)
-		if err != nil {
-			continue
-		}
-		topcat := fmt.Sprintf("gear.gearTopCategoryId = %d", topCategoryInt)
-		conditions = append(conditions, topcat)
-	}
-
-	for _, category := range categories {
-		categoryInt, err := strconv.Atoi(category)
-		if err != nil {
-			continue
-		}
-		cat := fmt.Sprintf("gear.gearCategoryId = %d", categoryInt)
-		conditions = append(conditions, cat)
-	}
-
-	for _, manufacture := range manufacturers {
-		manufactureInt, err := strconv.Atoi(manufacture)
-		if err != nil {
-			continue
-		}
-		manufac := fmt.Sprintf("gear.gearManufactureId = %d", manufactureInt)
-		conditions = append(conditions, manufac)
+	query := utils.NewListQuery[models.UserGear]("user_gear_registrations" + userGearJoins)
+	query.Where("user_gear_registrations.userId = ?", userIDInt)
+	query.WhereIn("gear.gearTopCategoryId", intQueryValues(topCategories)...)
+	if categoryIDs := intQueryValues(categories); len(categoryIDs) > 0 {
+		condition, args := utils.CategorySubtreeCondition("gear.gearCategoryId", categoryIDs...)
+		query.Where(condition, args...)
 	}
+	query.WhereIn("gear.gearManufactureId", intQueryValues(manufacturers)...)
 
 	switch container {
 	case "true":
-		containerCond := "gear.gearIsContainer = 1"
-		conditions = append(conditions, containerCond)
+		query.Where("gear.gearIsContainer = 1")
 	case "false":
-		containerCond := "gear.gearIsContainer = 0"
-		conditions = append(conditions, containerCond)
-	}
-
-	whereClause := ""
-	if len(conditions) > 0 {
-		whereClause = " WHERE " + strings.Join(conditions, " AND ")
+		query.Where("gear.gearIsContainer = 0")
 	}
 
-	var extra []string
-	extra = append(extra, " LEFT JOIN user_container_registration ON user_container_registration.userGearRegistrationId = user_gear_registrations.userGearRegistrationId")
-	extra = append(extra, " LEFT JOIN gear ON user_gear_registrations.gearId = gear.gearId")
-	extra = append(extra, "LEFT JOIN users ON user_gear_registrations.userId = users.userId")
-	extra = append(extra, "LEFT JOIN manufacture ON gear.gearManufactureId = manufacture.manufactureId")
-	extra = append(extra, "LEFT JOIN gear_top_category ON gear.gearTopCategoryId = gear_top_category.topCategoryId")
-	extra = append(extra, "LEFT JOIN gear_category ON gear.gearCategoryId = gear_category.categoryId ")
-	extraSQL := strings.Join(extra, " ")
-
-	baseCountQuery := "SELECT COUNT(*) FROM user_gear_registrations"
-	countQuery := baseCountQuery + extraSQL + whereClause
-
-	var totalCount int
-	err = db.QueryRow(countQuery).Scan(&totalCount)
-	if err != nil {
-		log.Errorf("Error getting GearCount database: %#v", err)
-		c.IndentedJSON(http.StatusInternalServerError, models.Error{Error: err.Error()})
+	if err := filterRegistrationDetails(query, c.Request.URL.Query()); err != nil {
+		respondListQueryError(c, log, err)
 		return
 	}
 
-	start := strconv.Itoa((pageInt - 1) * limitInt)
-	totalPages := int(math.Ceil(float64(totalCount) / float64(limitInt)))
-
-	startInt, err := strconv.Atoi(start)
-	if err != nil {
-		c.IndentedJSON(http.StatusInternalServerError, models.Error{Error: err.Error()})
-		return
-	}
-
-	var paramTopCategory models.UserGear
-	fields := utils.GetDBFieldNames(reflect.TypeOf(paramTopCategory))
-
-	baseQuery := fmt.Sprintf(`SELECT %s FROM user_gear_registrations`, strings.Join(fields, ", "))
-
-	queryLimit := fmt.Sprintf(" LIMIT %v, %v", startInt, limitInt)
-
-	query := baseQuery + extraSQL + whereClause + queryLimit
-
-	log.Debugf("Query: %s", query)
-
-	rows, err := db.Query(query)
-	if err != nil {
-		log.Errorf("Query error: %#v", err.Error())
-		c.IndentedJSON(http.StatusInternalServerError, models.Error{Error: err.Error()})
+	if err := utils.FilterTags(query, utils.RegistrationTagTarget, c.Request.URL.Query()); err != nil {
+		respondListQueryError(c, log, err)
 		return
 	}
-	defer rows.Close()
 
-	dest, err := utils.GetScanFields(paramTopCategory)
-	if err != nil {
-		log.Errorf("Error getting destination arguments: %#v", err)
-		c.IndentedJSON(http.StatusInternalServerError, models.Error{Error: err.Error()})
+	if err := query.FilterFields(c.Request.URL.Query()); err != nil {
+		respondListQueryError(c, log, err)
 		return
 	}
 
-	var gearTopCategoryList []models.UserGear
+	respondList(c, log, db, query)
+}
 
-	for rows.Next() {
-		err = rows.Scan(dest...)
-		if err != nil {
-			if err == sql.ErrNoRows {
-				log.Errorf("No gear found")
-				c.IndentedJSON(http.StatusNotFound, models.Error{Error: "No results"})
-				return
-			}
-			log.Errorf("Scan error: %#v", err)
-			c.IndentedJSON(http.StatusInternalServerError, models.Error{Error: err.Error()})
-			return
+// filterRegistrationDetails adds the purchase date and price range filters of the user
+// gear list. Exact matches on the details go through FilterFields.
+func filterRegistrationDetails(query *utils.ListQuery[models.UserGear], values url.Values) error {
+	for _, bound := range []struct {
+		param     string
+		condition string
+	}{
+		{"purchased_after", "user_gear_registrations.purchaseDate >= ?"},
+		{"purchased_before", "user_gear_registrations.purchaseDate <= ?"},
+	} {
+		value := values.Get(bound.param)
+		if value == "" {
+			continue
 		}
-
-		for i := 0; i < reflect.TypeOf(paramTopCategory).NumField(); i++ {
-			reflect.ValueOf(&paramTopCategory).Elem().Field(i).Set(reflect.ValueOf(dest[i]).Elem())
+		if _, err := time.Parse(time.DateOnly, value); err != nil {
+			return fmt.Errorf("%s %q is not a YYYY-MM-DD date", bound.param, value)
 		}
-
-		gearTopCategoryList = append(gearTopCategoryList, paramTopCategory)
-	}
-
-	if err := rows.Err(); err != nil {
-		log.Errorf("Row iteration error: %#v", err)
-		c.IndentedJSON(http.StatusInternalServerError, models.Error{Error: err.Error()})
-		return
-	}
-
-	payload := models.ResponsePayload{
-		TotalItemCount: totalCount,
-		CurrentPage:    pageInt,
-		ItemLimit:      limitInt,
-		TotalPages:     totalPages,
-		Items:          gearTopCategoryList,
-	}
-
-	if pageInt < totalPages {
-		currentQueryParameters.Set("page", strconv.Itoa(pageInt+1))
-		nextPage := url.URL{
-			Path:     c.Request.URL.Path,
-			RawQuery: currentQueryParameters.Encode(),
+		query.Where(bound.condition, value)
+	}
+
+	for _, bound := range []struct {
+		param     string
+		condition string
+	}{
+		{"min_price", "user_gear_registrations.purchasePrice >= ?"},
+		{"max_price", "user_gear_registrations.purchasePrice <= ?"},
+	} {
+		value := values.Get(bound.param)
+		if value == "" {
+			continue
 		}
-		payload.NextPage = new(string)
-		*payload.NextPage = nextPage.String()
-	}
-
-	if pageInt > 1 {
-		currentQueryParameters.Set("page", strconv.Itoa(pageInt-1))
-		prevPage := url.URL{
-			Path:     c.Request.URL.Path,
-			RawQuery: currentQueryParameters.Encode(),
+		price, err := strconv.ParseFloat(value, 64)
+		if err != nil {
+			return fmt.Errorf("%s %q is not a number", bound.param, value)
 		}
-		payload.PrevPage = new(string)
-		*payload.PrevPage = prevPage.String()
+		query.Where(bound.condition, price)
 	}
 
-	c.IndentedJSON(http.StatusOK, payload)
+	return nil
 }
 
 // GetUserGear retrives the users full gear list
 //
 //	@Summary		Get user registered gear with ID
-//	@Description	Get user registeredgear spessific to ID
+//	@Description	Get user registeredgear spessific to ID. Only the owner and admins can see a registration.
 //	@Security		BearerAuth
 //	@Tags			User gear
 //	@Accept			json
 //	@Produce		json
 //	@Param			usergear	path		int				true	"Unique ID of user registered gear you want to get"
+//	@Param			units		query		string			false	"Also give measurements in metric or imperial units, overriding the user's preference"
 //	@Success		200			{object}	models.UserGear	"desc"
 //	@Router			/api/v1/usergear/registration/{usergear}/get [get]
 func GetUserGear(c *gin.Context) {
@@ -320,6 +210,7 @@ func GetUserGear(c *gin.Context) {
 	if err != nil {
 		log.Errorf("urlParamter is of wrong type: %#v", err)
 		c.IndentedJSON(http.StatusBadRequest, models.Error{Error: err.Error()})
+		return
 	}
 
 	conditions := []string{}
@@ -346,6 +237,9 @@ func GetUserGear(c *gin.Context) {
 	extra = append(extra, "LEFT JOIN manufacture ON gear.gearManufactureId = manufacture.manufactureId")
 	extra = append(extra, "LEFT JOIN gear_top_category ON gear.gearTopCategoryId = gear_top_category.topCategoryId")
 	extra = append(extra, "LEFT JOIN gear_category ON gear.gearCategoryId = gear_category.categoryId ")
+	extra = append(extra, "LEFT JOIN gear_variants ON user_gear_registrations.variantId = gear_variants.variantId ")
+	extra = append(extra, utils.GearImageJoin)
+	extra = append(extra, utils.RegistrationImageJoin)
 
 	extra = append(extra, whereClause)
 
@@ -356,6 +250,31 @@ func GetUserGear(c *gin.Context) {
 		return
 	}
 
+	// Purchase details, notes and tags are private, so only the owner and admins see a
+	// registration.
+	userID := c.MustGet("user_id_int64").(int64)
+	isAdmin, _ := c.Get("user_is_admin")
+	if adminFlag, _ := isAdmin.(bool); !adminFlag && results.UserGearUserID != userID {
+		log.Warnw("non-admin attempted to view other user's registration", "registration", urlParameter, "user", userID)
+		c.IndentedJSON(http.StatusForbidden, models.Error{Error: "not allowed to view this registration"})
+		return
+	}
+
+	if !measureItems(c, log, db, results) || !localizeItems(c, log, db, results) {
+		return
+	}
+	resolveImageURLs(c, results)
+
+	// Registration tags are private to the owner.
+	if results.UserGearUserID == userID {
+		results.Tags, err = utils.Tags(db, utils.RegistrationTagTarget, *results.UserGearRegistrationID)
+		if err != nil {
+			log.Errorf("Unable to get tags of %s with id: %s. Error: %#v", function, urlParameter, err)
+			c.IndentedJSON(http.StatusInternalServerError, models.Error{Error: err.Error()})
+			return
+		}
+	}
+
 	log.Infof("Successfully fetched %s with ID %s", function, urlParameter)
 	c.IndentedJSON(http.StatusOK, results)
 }
@@ -384,6 +303,44 @@ func InsertUserGear(c *gin.Context) {
 		return
 	}
 
+	data, ok := normalizeMeasurements[models.UserGearLinkNoID](c, log, data)
+	if !ok {
+		return
+	}
+
+	var registration models.UserGearLinkNoID
+	if err := json.Unmarshal(data, &registration); err != nil {
+		c.JSON(http.StatusBadRequest, models.Error{Error: err.Error()})
+		log.Error(err.Error())
+		return
+	}
+
+	if respondGearNotVisible(c, log, db, registration.UserGearGearID) {
+		return
+	}
+	if respondGearVariantMismatch(c, log, db, registration.VariantID, registration.UserGearGearID) {
+		return
+	}
+
+	var link models.UserGearLink
+	if err := json.Unmarshal(data, &link); err != nil {
+		c.JSON(http.StatusBadRequest, models.Error{Error: err.Error()})
+		log.Error(err.Error())
+		return
+	}
+	if err := normalizeRegistrationDetails(&link); err != nil {
+		log.Warnf("Invalid registration details: %s", err.Error())
+		c.JSON(http.StatusBadRequest, models.Error{Error: err.Error()})
+		return
+	}
+
+	data, err = json.Marshal(link)
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, models.Error{Error: err.Error()})
+		log.Error(err.Error())
+		return
+	}
+
 	_, err = utils.GenericInsert[models.UserGearLink]("user_gear_registrations", data, db)
 	if err != nil {
 		c.JSON(http.StatusInternalServerError, models.Error{Error: err.Error()})
@@ -397,7 +354,7 @@ func InsertUserGear(c *gin.Context) {
 // UpdateUserGear updates a record of user registered gear
 //
 //	@Summary		Update user registered gear with ID
-//	@Description	Update user registered gear identified by ID
+//	@Description	Update user registered gear identified by ID. Fields left out keep their value. purchase_date is YYYY-MM-DD, purchase_currency a three letter ISO 4217 code required with purchase_price, and condition one of new, like_new, good, fair or poor.
 //	@Security		BearerAuth
 //	@Tags			User gear
 //	@Accept			json
@@ -425,6 +382,11 @@ func UpdateUserGear(c *gin.Context) {
 		return
 	}
 
+	data, ok := normalizeMeasurements[models.UserGearLink](c, log, data)
+	if !ok {
+		return
+	}
+
 	var payload models.UserGearLink
 	if err := json.Unmarshal(data, &payload); err != nil {
 		c.JSON(http.StatusBadRequest, models.Error{Error: err.Error()})
@@ -488,6 +450,21 @@ func UpdateUserGear(c *gin.Context) {
 	if _, hasMaxContainerWeight := rawPayload["max_container_weight"]; !hasMaxContainerWeight {
 		payload.MaxContainerWeight = existing.MaxContainerWeight
 	}
+	if _, hasVariant := rawPayload["variant_id"]; !hasVariant {
+		payload.VariantID = existing.VariantID
+	}
+
+	keepRegistrationDetails(&payload, existing, rawPayload)
+
+	if respondGearVariantMismatch(c, log, db, payload.VariantID, payload.UserGearGearID) {
+		return
+	}
+
+	if err := normalizeRegistrationDetails(&payload); err != nil {
+		log.Warnf("Invalid registration details: %s", err.Error())
+		c.JSON(http.StatusBadRequest, models.Error{Error: err.Error()})
+		return
+	}
 
 	updatedData, err := json.Marshal(payload)
 	if err != nil {
@@ -554,6 +531,13 @@ func DeleteUserGearRegistration(c *gin.Context) {
 		return
 	}
 
+	imageKeys, err := imageFileKeys(db, "userGearRegistrationId = ?", urlParameter)
+	if err != nil {
+		log.Errorw("failed to look up registration images before deletion", "error", err, "registration_id", urlParameter)
+		c.JSON(http.StatusInternalServerError, models.Error{Error: err.Error()})
+		return
+	}
+
 	_, err = db.Exec("DELETE FROM user_container_registration WHERE userGearRegistrationId = ? OR userContainerId = ?", urlParameter, urlParameter)
 	if err != nil {
 		log.Errorw("failed to clear container links before deleting registration", "error", err, "registration_id", urlParameter)
@@ -581,6 +565,8 @@ func DeleteUserGearRegistration(c *gin.Context) {
 		return
 	}
 
+	removeImageFiles(c, log, imageKeys...)
+
 	gearLabel := gearName.String
 	if gearLabel == "" {
 		gearLabel = fmt.Sprintf("registration #%d", urlParameter)
@@ -599,3 +585,108 @@ func DeleteUserGearRegistration(c *gin.Context) {
 	log.Infow("user gear registration deleted", "registration_id", urlParameter, "gear", gearLabel, "user", userLabel)
 	c.JSON(http.StatusOK, map[string]string{"status": statusMessage})
 }
+
+// Item conditions a registration can record.
+var registrationConditions = map[string]bool{
+	"new":      true,
+	"like_new": true,
+	"good":     true,
+	"fair":     true,
+	"poor":     true,
+}
+
+// Longest accepted free-text registration details, in bytes.
+const (
+	maxRegistrationDetailLength = 200
+	maxRegistrationNotesLength  = 4000
+)
+
+// normalizeRegistrationDetails trims the ownership details of link, turning blank ones
+// into nil, and checks their format. Currencies are upper-cased and conditions lower-cased.
+func normalizeRegistrationDetails(link *models.UserGearLink) error {
+	for _, detail := range []struct {
+		name  string
+		value **string
+		limit int
+	}{
+		{"purchase_date", &link.PurchaseDate, maxRegistrationDetailLength},
+		{"purchase_currency", &link.PurchaseCurrency, maxRegistrationDetailLength},
+		{"retailer", &link.Retailer, maxRegistrationDetailLength},
+		{"condition", &link.Condition, maxRegistrationDetailLength},
+		{"serial_number", &link.SerialNumber, maxRegistrationDetailLength},
+		{"colour", &link.Colour, maxRegistrationDetailLength},
+		{"notes", &link.Notes, maxRegistrationNotesLength},
+	} {
+		if *detail.value == nil {
+			continue
+		}
+		trimmed := strings.TrimSpace(**detail.value)
+		if trimmed == "" {
+			*detail.value = nil
+			continue
+		}
+		if len(trimmed) > detail.limit {
+			return fmt.Errorf("%s is longer than %d characters", detail.name, detail.limit)
+		}
+		*detail.value = &trimmed
+	}
+
+	if link.PurchaseDate != nil {
+		if _, err := time.Parse(time.DateOnly, *link.PurchaseDate); err != nil {
+			return fmt.Errorf("purchase_date %q is not a YYYY-MM-DD date", *link.PurchaseDate)
+		}
+	}
+
+	if link.PurchasePrice != nil && *link.PurchasePrice < 0 {
+		return fmt.Errorf("purchase_price cannot be negative")
+	}
+	if link.PurchaseCurrency != nil {
+		currency := strings.ToUpper(*link.PurchaseCurrency)
+		if len(currency) != 3 || strings.Trim(currency, "ABCDEFGHIJKLMNOPQRSTUVWXYZ") != "" {
+			return fmt.Errorf("purchase_currency %q is not a three letter ISO 4217 code", *link.PurchaseCurrency)
+		}
+		link.PurchaseCurrency = &currency
+	}
+	if link.PurchasePrice != nil && link.PurchaseCurrency == nil {
+		return fmt.Errorf("purchase_currency is required with purchase_price")
+	}
+
+	if link.Condition != nil {
+		condition := strings.ToLower(*link.Condition)
+		if !registrationConditions[condition] {
+			return fmt.Errorf("condition %q is not one of new, like_new, good, fair or poor", *link.Condition)
+		}
+		link.Condition = &condition
+	}
+
+	return nil
+}
+
+// keepRegistrationDetails copies the ownership details an update leaves out from the
+// existing registration, so only the fields sent are changed.
+func keepRegistrationDetails(payload *models.UserGearLink, existing *models.UserGearLink, rawPayload map[string]json.RawMessage) {
+	if _, ok := rawPayload["purchase_date"]; !ok {
+		payload.PurchaseDate = existing.PurchaseDate
+	}
+	if _, ok := rawPayload["purchase_price"]; !ok {
+		payload.PurchasePrice = existing.PurchasePrice
+	}
+	if _, ok := rawPayload["purchase_currency"]; !ok {
+		payload.PurchaseCurrency = existing.PurchaseCurrency
+	}
+	if _, ok := rawPayload["retailer"]; !ok {
+		payload.Retailer = existing.Retailer
+	}
+	if _, ok := rawPayload["condition"]; !ok {
+		payload.Condition = existing.Condition
+	}
+	if _, ok := rawPayload["serial_number"]; !ok {
+		payload.SerialNumber = existing.SerialNumber
+	}
+	if _, ok := rawPayload["colour"]; !ok {
+		payload.Colour = existing.Colour
+	}
+	if _, ok := rawPayload["notes"]; !ok {
+		payload.Notes = existing.Notes
+	}
+}
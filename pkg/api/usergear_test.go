@@ -0,0 +1,122 @@
+package endpoints
+
+import (
+	"encoding/json"
+	"net/http"
+	"testing"
+
+	models "github.com/Sea-Shell/gogear-api/pkg/models"
+)
+
+func TestUserGear_RegistrationDetails(t *testing.T) {
+	db, router := setupCatalogTest(t, 1)
+	seedCatalog(t, db)
+	seedCatalogGear(t, db, 1, 1, "Nallo", 2400)
+	seedCatalogGear(t, db, 2, 1, "Akto", 1600)
+	seedCatalogGear(t, db, 3, 1, "Soulo", 2000)
+
+	v1 := router.Group("/api/v1", testAuthMiddleware(1))
+	v1.PUT("/usergear/insert", InsertUserGear)
+	v1.GET("/usergear/registration/:usergear/get", GetUserGear)
+	v1.POST("/usergear/registration/:usergear/update", UpdateUserGear)
+
+	inserts := []string{
+		`{"usergear_gear_id":1,"usergear_user_id":1,"purchase_date":"2023-05-01","purchase_price":899.5,"purchase_currency":"eur",
+          "retailer":" Bergans ","condition":"Like_New","serial_number":"HB-1","colour":"green","notes":"First tent"}`,
+		`{"usergear_gear_id":2,"usergear_user_id":1,"purchase_date":"2021-08-15","purchase_price":450,"purchase_currency":"NOK","condition":"fair"}`,
+		`{"usergear_gear_id":3,"usergear_user_id":1,"retailer":"  "}`,
+	}
+	for _, insert := range inserts {
+		if w := authRequest(t, router, http.MethodPut, "/api/v1/usergear/insert", insert); w.Code != http.StatusOK {
+			t.Fatalf("InsertUserGear: %d %s", w.Code, w.Body.String())
+		}
+	}
+
+	for _, invalid := range []string{
+		`{"usergear_gear_id":1,"usergear_user_id":1,"purchase_date":"01.05.2023"}`,
+		`{"usergear_gear_id":1,"usergear_user_id":1,"purchase_price":10}`,
+		`{"usergear_gear_id":1,"usergear_user_id":1,"purchase_price":-1,"purchase_currency":"EUR"}`,
+		`{"usergear_gear_id":1,"usergear_user_id":1,"purchase_currency":"euro"}`,
+		`{"usergear_gear_id":1,"usergear_user_id":1,"condition":"mint"}`,
+	} {
+		if w := authRequest(t, router, http.MethodPut, "/api/v1/usergear/insert", invalid); w.Code != http.StatusBadRequest {
+			t.Errorf("InsertUserGear %s: expected 400, got %d", invalid, w.Code)
+		}
+	}
+
+	w := authRequest(t, router, http.MethodGet, "/api/v1/usergear/registration/1/get", "")
+	var registration models.UserGear
+	if err := json.Unmarshal(w.Body.Bytes(), &registration); err != nil {
+		t.Fatalf("GetUserGear: %d %s", w.Code, w.Body.String())
+	}
+	if *registration.PurchaseCurrency != "EUR" || *registration.Condition != "like_new" || *registration.Retailer != "Bergans" || *registration.PurchasePrice != 899.5 {
+		t.Errorf("GetUserGear: details not normalized: %s", w.Body.String())
+	}
+
+	// Registration details are private to the owner.
+	other := router.Group("/other", testAuthMiddleware(2))
+	other.GET("/usergear/registration/:usergear/get", GetUserGear)
+	if w := authRequest(t, router, http.MethodGet, "/other/usergear/registration/1/get", ""); w.Code != http.StatusForbidden {
+		t.Errorf("GetUserGear: expected 403 for another user's registration, got %d %s", w.Code, w.Body.String())
+	}
+
+	update := `{"notes":"Repaired pole","condition":"good"}`
+	if w := authRequest(t, router, http.MethodPost, "/api/v1/usergear/registration/1/update", update); w.Code != http.StatusOK {
+		t.Fatalf("UpdateUserGear: %d %s", w.Code, w.Body.String())
+	}
+	w = authRequest(t, router, http.MethodGet, "/api/v1/usergear/registration/1/get", "")
+	registration = models.UserGear{}
+	if err := json.Unmarshal(w.Body.Bytes(), &registration); err != nil {
+		t.Fatal(err)
+	}
+	if *registration.Notes != "Repaired pole" || *registration.Condition != "good" || registration.SerialNumber == nil || *registration.SerialNumber != "HB-1" {
+		t.Errorf("UpdateUserGear: partial update lost details: %s", w.Body.String())
+	}
+	if w := authRequest(t, router, http.MethodPost, "/api/v1/usergear/registration/1/update", `{"purchase_price":5}`); w.Code != http.StatusOK {
+		t.Errorf("UpdateUserGear: price with the stored currency should be accepted, got %d %s", w.Code, w.Body.String())
+	}
+	if w := authRequest(t, router, http.MethodPost, "/api/v1/usergear/registration/1/update", `{"condition":"broken"}`); w.Code != http.StatusBadRequest {
+		t.Errorf("UpdateUserGear: expected 400 for unknown condition, got %d", w.Code)
+	}
+
+	listIDs := func(query string) []int64 {
+		t.Helper()
+		w := authRequest(t, router, http.MethodGet, "/api/v1/usergear/1/list?"+query, "")
+		var payload struct {
+			Items []models.UserGear `json:"items"`
+		}
+		if err := json.Unmarshal(w.Body.Bytes(), &payload); err != nil || w.Code != http.StatusOK {
+			t.Fatalf("ListUserGear %s: %d %s", query, w.Code, w.Body.String())
+		}
+		ids := make([]int64, len(payload.Items))
+		for i, item := range payload.Items {
+			ids[i] = item.UserGearGearID
+		}
+		return ids
+	}
+
+	for query, want := range map[string][]int64{
+		"condition=fair": {2},
+		"purchase_currency=NOK&purchase_currency=EUR":    {1, 2},
+		"purchased_after=2022-01-01":                     {1},
+		"purchased_before=2022-01-01":                    {2},
+		"min_price=100&max_price=500":                    {2},
+		"sort=purchase_date&order=desc&purchased_after=": {1, 2, 3},
+	} {
+		got := listIDs(query)
+		if len(got) != len(want) {
+			t.Errorf("ListUserGear %s = %v, want %v", query, got, want)
+			continue
+		}
+		for i := range want {
+			if got[i] != want[i] {
+				t.Errorf("ListUserGear %s = %v, want %v", query, got, want)
+				break
+			}
+		}
+	}
+
+	if w := authRequest(t, router, http.MethodGet, "/api/v1/usergear/1/list?purchased_after=last-year", ""); w.Code != http.StatusBadRequest {
+		t.Errorf("ListUserGear: expected 400 for an invalid date, got %d", w.Code)
+	}
+}
@@ -3,14 +3,56 @@ package models
 type GearCategory struct {
 	CategoryID            *int64 `json:"category_id" db:"categoryId"`
 	CategoryTopCategoryID int64  `json:"category_top_category_id" db:"categoryTopCategoryId"`
-	CategoryName          string `json:"category_name" db:"categoryName"`
+	CategoryName          string `json:"category_name" db:"categoryName" translate:"CategoryID"`
+	CategoryParentID      *int64 `json:"category_parent_id" db:"categoryParentId"`
 }
 
 type GearCategoryListItem struct {
 	CategoryID            *int64 `json:"category_id" db:"categoryId"`
 	CategoryTopCategoryID int64  `json:"category_top_category_id" db:"categoryTopCategoryId"`
-	CategoryName          string `json:"category_name" db:"categoryName"`
+	CategoryName          string `json:"category_name" db:"categoryName" translate:"CategoryID"`
+	CategoryParentID      *int64 `json:"category_parent_id" db:"categoryParentId"`
+	CategorySortOrder     int64  `json:"category_sort_order" db:"categorySortOrder"`
 	TopCategoryID         int64  `json:"top_category_id" db:"topCategoryId"`
-	TopCategoryName       string `json:"top_category_name" db:"topCategoryName"`
+	TopCategoryName       string `json:"top_category_name" db:"topCategoryName" translate:"TopCategoryID"`
 	TopCategoryIcon       string `json:"top_category_icon" db:"topCategoryIcon"`
 }
+
+// CategoryTree is a top category with the categories nested below it.
+type CategoryTree struct {
+	TopCategoryID        int64              `json:"top_category_id" db:"topCategoryId"`
+	TopCategoryName      string             `json:"top_category_name" db:"topCategoryName" translate:"TopCategoryID"`
+	TopCategoryIcon      string             `json:"top_category_icon" db:"topCategoryIcon"`
+	TopCategorySortOrder int64              `json:"top_category_sort_order" db:"topCategorySortOrder"`
+	GearCount            int64              `json:"gear_count" db:"gearCount"`
+	Children             []CategoryTreeNode `json:"children"`
+}
+
+// CategoryTreeNode is a category in the tree. GearCount includes the gear of all
+// descendants.
+type CategoryTreeNode struct {
+	CategoryID            int64              `json:"category_id" db:"categoryId"`
+	CategoryTopCategoryID int64              `json:"category_top_category_id" db:"categoryTopCategoryId"`
+	CategoryName          string             `json:"category_name" db:"categoryName" translate:"CategoryID"`
+	CategoryParentID      *int64             `json:"category_parent_id" db:"categoryParentId"`
+	CategorySortOrder     int64              `json:"category_sort_order" db:"categorySortOrder"`
+	GearCount             int64              `json:"gear_count" db:"gearCount"`
+	Children              []CategoryTreeNode `json:"children"`
+}
+
+// CategoryMove places a category below parent_id, or directly below top_category_id, at
+// position among its new siblings. Without position it is placed last.
+type CategoryMove struct {
+	ParentID      *int64 `json:"parent_id"`
+	TopCategoryID *int64 `json:"top_category_id"`
+	Position      *int   `json:"position"`
+}
+
+// CategoryReorder orders the children of parent_id, or the categories directly below
+// top_category_id, or the top categories when neither is given. order lists every one of
+// them exactly once.
+type CategoryReorder struct {
+	ParentID      *int64  `json:"parent_id"`
+	TopCategoryID *int64  `json:"top_category_id"`
+	Order         []int64 `json:"order"`
+}
@@ -0,0 +1,55 @@
+package models
+
+// GearComparisonItem is one of the compared pieces of gear, a column of the comparison.
+type GearComparisonItem struct {
+	GearID            int64    `json:"gear_id" db:"gear.gearId"`
+	GearName          string   `json:"gear_name" db:"gear.gearName" translate:"GearID"`
+	GearTopCategoryID int64    `json:"gear_top_category_id" db:"gear.gearTopCategoryId"`
+	GearCategoryID    int64    `json:"gear_category_id" db:"gear.gearCategoryId"`
+	ManufactureName   *string  `json:"manufacture_name" db:"manufacture.manufactureName"`
+	TopCategoryName   *string  `json:"top_category_name" db:"gear_top_category.topCategoryName" translate:"GearTopCategoryID"`
+	CategoryName      *string  `json:"category_name" db:"gear_category.categoryName" translate:"GearCategoryID"`
+	GearWeight        int32    `json:"gear_weight" db:"gear.gearWeight"`
+	GearHeight        int32    `json:"gear_height" db:"gear.gearHeight"`
+	GearLength        int32    `json:"gear_length" db:"gear.gearLength"`
+	GearWidth         int32    `json:"gear_width" db:"gear.gearWidth"`
+	GearRatingAverage *float64 `json:"gear_rating_average" db:"gear.gearRatingAverage"`
+	GearReviewCount   int64    `json:"gear_review_count" db:"gear.gearReviewCount"`
+	OwnerCount        int64    `json:"owner_count" db:"ownerCount"`
+
+	ImageKey     *string `json:"-" db:"gear_image.imageKey" url:"ImageURL"`
+	ThumbnailKey *string `json:"-" db:"gear_image.thumbnailKey" url:"ThumbnailURL"`
+	ImageURL     *string `json:"image_url,omitempty"`
+	ThumbnailURL *string `json:"thumbnail_url,omitempty"`
+
+	Specs Specs `json:"-"`
+}
+
+// GearComparisonValue is the value of a field for one compared item. Value is nil when the
+// item has none. Best marks the best value of fields with a preferred direction, and Delta
+// is the difference to the lightest item for numeric fields.
+type GearComparisonValue struct {
+	Value interface{} `json:"value"`
+	Best  bool        `json:"best"`
+	Delta *float64    `json:"delta,omitempty"`
+}
+
+// GearComparisonField is one row of the comparison with a value per item, in the order of
+// the compared gear. Type is number, text or bool; Better is lower or higher for numeric
+// fields where that makes a value better, and empty otherwise.
+type GearComparisonField struct {
+	Key    string                `json:"key"`
+	Label  string                `json:"label"`
+	Type   string                `json:"type"`
+	Unit   *string               `json:"unit"`
+	Better string                `json:"better,omitempty"`
+	Values []GearComparisonValue `json:"values"`
+}
+
+// GearComparison lines up pieces of gear field by field. Deltas are against
+// LightestGearID, the lightest item with a known weight.
+type GearComparison struct {
+	Gear           []GearComparisonItem  `json:"gear"`
+	LightestGearID *int64                `json:"lightest_gear_id"`
+	Fields         []GearComparisonField `json:"fields"`
+}
@@ -8,12 +8,15 @@ type ResponsePayload struct {
 	Items          interface{} `json:"items"`
 	NextPage       *string     `json:"next_page"`
 	PrevPage       *string     `json:"prev_page"`
+	NextCursor     *string     `json:"next_cursor"`
+	PrevCursor     *string     `json:"prev_cursor"`
 }
 
 type Config struct {
 	Database Database `yaml:"database" json:"database"`
 	General  General  `yaml:"general" json:"general"`
 	Auth     Auth     `yaml:"auth" json:"auth"`
+	Storage  Storage  `yaml:"storage" json:"storage"`
 }
 
 type Database struct {
@@ -23,11 +26,16 @@ type Database struct {
 	Password   string `yaml:"password" json:"password,omitempty"`
 }
 
+// General holds the server settings. DefaultLocale is the language the catalog is written
+// in, en unless set; Locales lists the locales responses can be translated to. With no
+// Locales every locale with translations can be requested.
 type General struct {
-	Hostname   string   `yaml:"hostname" json:"hostname"`
-	Schemes    []string `yaml:"schemes" json:"schemes"`
-	ListenPort string   `yaml:"listen-port" json:"listen_port"`
-	LogLevel   string   `yaml:"log-level" json:"log-level"`
+	Hostname      string   `yaml:"hostname" json:"hostname"`
+	Schemes       []string `yaml:"schemes" json:"schemes"`
+	ListenPort    string   `yaml:"listen-port" json:"listen_port"`
+	LogLevel      string   `yaml:"log-level" json:"log-level"`
+	DefaultLocale string   `yaml:"default-locale" json:"default_locale"`
+	Locales       []string `yaml:"locales" json:"locales"`
 }
 
 type Auth struct {
@@ -41,6 +49,15 @@ type Auth struct {
 	GoogleRedirectURL  string `yaml:"google-redirect-url" json:"google_redirect_url"`
 }
 
+// Storage configures where uploaded images are kept. Backend is local, the default, which
+// writes under Path and serves the files from BaseURL.
+type Storage struct {
+	Backend        string `yaml:"backend" json:"backend"`
+	Path           string `yaml:"path" json:"path"`
+	BaseURL        string `yaml:"base-url" json:"base_url"`
+	MaxUploadBytes int64  `yaml:"max-upload-bytes" json:"max_upload_bytes"`
+}
+
 type GoogleCreds struct {
 	Web struct {
 		ClientID                string   `yaml:"client_id" json:"client_id"`
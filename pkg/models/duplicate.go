@@ -0,0 +1,34 @@
+package models
+
+// GearDuplicateCandidate is one side of a suspected duplicate pair.
+type GearDuplicateCandidate struct {
+	GearID            int64   `json:"gear_id" db:"gear.gearId"`
+	GearName          string  `json:"gear_name" db:"gear.gearName"`
+	GearManufactureID int64   `json:"gear_manufacture_id" db:"gear.gearManufactureId"`
+	ManufactureName   *string `json:"manufacture_name" db:"manufacture.manufactureName"`
+	GearWeight        int32   `json:"gear_weight" db:"gear.gearWeight"`
+	GearStatus        bool    `json:"gear_status" db:"gear.gearStatus"`
+	RegistrationCount int64   `json:"registration_count" db:"registrationCount"`
+}
+
+// GearDuplicate is a pair of gear that likely describe the same product. Score is between
+// 0 and 1; gear is the side with more registrations and the suggested winner of a merge.
+type GearDuplicate struct {
+	Score     float64                `json:"score"`
+	Gear      GearDuplicateCandidate `json:"gear"`
+	Duplicate GearDuplicateCandidate `json:"duplicate"`
+}
+
+// GearMerge names the gear that the merged gear is folded into.
+type GearMerge struct {
+	WinnerID int64 `json:"winner_id"`
+}
+
+// GearMergeResult reports what a merge moved from the losing gear to the winner.
+type GearMergeResult struct {
+	WinnerID           int64   `json:"winner_id"`
+	LoserID            int64   `json:"loser_id"`
+	RegistrationsMoved int64   `json:"registrations_moved"`
+	LoadoutItemsMoved  int64   `json:"loadout_items_moved"`
+	LoadoutIDs         []int64 `json:"loadout_ids"`
+}
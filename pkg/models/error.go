@@ -7,3 +7,11 @@ type Status struct {
 type Error struct {
 	Error string `json:"error"`
 }
+
+// DependentsError is returned when a row cannot be deleted while gear or categories still
+// reference it, with the number of each.
+type DependentsError struct {
+	Error         string `json:"error"`
+	GearCount     int64  `json:"gear_count"`
+	CategoryCount int64  `json:"category_count"`
+}
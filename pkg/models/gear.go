@@ -12,11 +12,13 @@ type Gear struct {
 	GearIsContainer    bool   `json:"gear_is_container" db:"gearIsContainer"`
 	GearName           string `json:"gear_name" db:"gearName"`
 	GearSizeDefinition string `json:"gear_size_definition" db:"gearSizeDefinition"`
-	GearWeight         int32  `json:"gear_weight" db:"gearWeight"`
-	GearHeight         int32  `json:"gear_height" db:"gearHeight"`
-	GearLength         int32  `json:"gear_length" db:"gearLength"`
-	GearWidth          int32  `json:"gear_width" db:"gearWidth"`
+	GearWeight         int32  `json:"gear_weight" db:"gearWeight" unit:"weight"`
+	GearHeight         int32  `json:"gear_height" db:"gearHeight" unit:"length"`
+	GearLength         int32  `json:"gear_length" db:"gearLength" unit:"length"`
+	GearWidth          int32  `json:"gear_width" db:"gearWidth" unit:"length"`
 	GearStatus         bool   `json:"gear_status" db:"gearStatus"`
+
+	Specs Specs `json:"specs,omitempty"`
 }
 
 // GearNameList represents a list of gear names.
@@ -33,10 +35,10 @@ type GearNoID struct {
 	GearIsContainer    bool   `json:"gear_is_container" db:"gearIsContainer"`
 	GearName           string `json:"gear_name" db:"gearName"`
 	GearSizeDefinition string `json:"gear_size_definition" db:"gearSizeDefinition"`
-	GearWeight         int32  `json:"gear_weight" db:"gearWeight"`
-	GearHeight         int32  `json:"gear_height" db:"gearHeight"`
-	GearLength         int32  `json:"gear_length" db:"gearLength"`
-	GearWidth          int32  `json:"gear_width" db:"gearWidth"`
+	GearWeight         int32  `json:"gear_weight" db:"gearWeight" unit:"weight"`
+	GearHeight         int32  `json:"gear_height" db:"gearHeight" unit:"length"`
+	GearLength         int32  `json:"gear_length" db:"gearLength" unit:"length"`
+	GearWidth          int32  `json:"gear_width" db:"gearWidth" unit:"length"`
 	GearStatus         bool   `json:"gear_status" db:"gearStatus"`
 }
 
@@ -47,24 +49,36 @@ type FullGear struct {
 	GearCategoryID     int64  `json:"gear_category_id" db:"gear.gearCategoryId"`
 	GearManufactureID  int64  `json:"gear_manufacture_id" db:"gear.gearManufactureId"`
 	GearIsContainer    bool   `json:"gear_is_container" db:"gearIsContainer"`
-	GearName           string `json:"gear_name" db:"gear.gearName"`
-	GearSizeDefinition string `json:"gear_size_definition" db:"gearSizeDefinition"`
-	GearWeight         int32  `json:"gear_weight" db:"gear.gearWeight"`
-	GearHeight         int32  `json:"gear_height" db:"gear.gearHeight"`
-	GearLength         int32  `json:"gear_length" db:"gear.gearLength"`
-	GearWidth          int32  `json:"gear_width" db:"gear.gearWidth"`
+	GearName           string `json:"gear_name" db:"gear.gearName" translate:"GearID"`
+	GearSizeDefinition string `json:"gear_size_definition" db:"gearSizeDefinition" translate:"GearID"`
+	GearWeight         int32  `json:"gear_weight" db:"gear.gearWeight" unit:"weight"`
+	GearHeight         int32  `json:"gear_height" db:"gear.gearHeight" unit:"length"`
+	GearLength         int32  `json:"gear_length" db:"gear.gearLength" unit:"length"`
+	GearWidth          int32  `json:"gear_width" db:"gear.gearWidth" unit:"length"`
 	GearStatus         bool   `json:"gear_status" db:"gear.gearStatus"`
 
+	GearRatingAverage *float64 `json:"gear_rating_average" db:"gear.gearRatingAverage"`
+	GearReviewCount   int64    `json:"gear_review_count" db:"gear.gearReviewCount"`
+
 	ManufactureID   int64  `json:"manufacture_id" db:"manufacture.manufactureId"`
 	ManufactureName string `json:"manufacture_name" db:"manufacture.manufactureName"`
 
 	TopCategoryID   int64  `json:"top_category_id" db:"gear_top_category.topCategoryId"`
-	TopCategoryName string `json:"top_category_name" db:"gear_top_category.topCategoryName"`
+	TopCategoryName string `json:"top_category_name" db:"gear_top_category.topCategoryName" translate:"TopCategoryID"`
 	TopCategoryIcon string `json:"top_category_icon" db:"gear_top_category.topCategoryIcon"`
 
 	CategoryID            int64  `json:"category_id" db:"gear_category.categoryId"`
-	CategoryName          string `json:"category_name" db:"gear_category.categoryName"`
+	CategoryName          string `json:"category_name" db:"gear_category.categoryName" translate:"CategoryID"`
 	CategoryTopCategoryID int64  `json:"category_top_category_id" db:"gear_category.categoryTopCategoryId"`
+
+	ImageKey     *string `json:"-" db:"gear_image.imageKey" url:"ImageURL"`
+	ThumbnailKey *string `json:"-" db:"gear_image.thumbnailKey" url:"ThumbnailURL"`
+	ImageURL     *string `json:"image_url,omitempty"`
+	ThumbnailURL *string `json:"thumbnail_url,omitempty"`
+
+	Specs        Specs        `json:"specs,omitempty"`
+	Tags         []string     `json:"tags,omitempty"`
+	Measurements Measurements `json:"measurements,omitempty"`
 }
 
 // GearListItem represents a gear list item.
@@ -74,20 +88,55 @@ type GearListItem struct {
 	GearCategoryID        int64  `json:"gear_category_id" db:"gear.gearCategoryId"`
 	GearManufactureID     int64  `json:"gear_manufacture_id" db:"gear.gearManufactureId"`
 	GearIsContainer       bool   `json:"gear_is_container" db:"gearIsContainer"`
-	GearName              string `json:"gear_name" db:"gear.gearName"`
-	GearSizeDefinition    string `json:"gear_size_definition" db:"gearSizeDefinition"`
+	GearName              string `json:"gear_name" db:"gear.gearName" translate:"GearID"`
+	GearSizeDefinition    string `json:"gear_size_definition" db:"gearSizeDefinition" translate:"GearID"`
 	ManufactureID         int64  `json:"manufacture_id" db:"manufacture.manufactureId"`
 	ManufactureName       string `json:"manufacture_name" db:"manufacture.manufactureName"`
 	TopCategoryID         int64  `json:"top_category_id" db:"gear_top_category.topCategoryId"`
-	TopCategoryName       string `json:"top_category_name" db:"gear_top_category.topCategoryName"`
+	TopCategoryName       string `json:"top_category_name" db:"gear_top_category.topCategoryName" translate:"TopCategoryID"`
 	TopCategoryIcon       string `json:"top_category_icon" db:"gear_top_category.topCategoryIcon"`
 	CategoryID            int64  `json:"category_id" db:"gear_category.categoryId"`
-	CategoryName          string `json:"category_name" db:"gear_category.categoryName"`
+	CategoryName          string `json:"category_name" db:"gear_category.categoryName" translate:"CategoryID"`
 	CategoryTopCategoryID int64  `json:"category_top_category_id" db:"gear_category.categoryTopCategoryId"`
+
+	GearRatingAverage *float64 `json:"gear_rating_average" db:"gear.gearRatingAverage"`
+	GearReviewCount   int64    `json:"gear_review_count" db:"gear.gearReviewCount"`
+
+	ImageKey     *string `json:"-" db:"gear_image.imageKey" url:"ImageURL"`
+	ThumbnailKey *string `json:"-" db:"gear_image.thumbnailKey" url:"ThumbnailURL"`
+	ImageURL     *string `json:"image_url,omitempty"`
+	ThumbnailURL *string `json:"thumbnail_url,omitempty"`
 }
 
-// Measurement represents a measurement value with its unit.
+// GearSearchItem represents a gear list item matched by a search, with its relevance
+// (lower is better) and a snippet with the matched words highlighted.
+type GearSearchItem struct {
+	GearID                int64   `json:"gear_id" db:"gear.gearId"`
+	GearTopCategoryID     int64   `json:"gear_top_category_id" db:"gear.gearTopCategoryId"`
+	GearCategoryID        int64   `json:"gear_category_id" db:"gear.gearCategoryId"`
+	GearManufactureID     int64   `json:"gear_manufacture_id" db:"gear.gearManufactureId"`
+	GearIsContainer       bool    `json:"gear_is_container" db:"gearIsContainer"`
+	GearName              string  `json:"gear_name" db:"gear.gearName" translate:"GearID"`
+	GearSizeDefinition    string  `json:"gear_size_definition" db:"gearSizeDefinition" translate:"GearID"`
+	ManufactureID         int64   `json:"manufacture_id" db:"manufacture.manufactureId"`
+	ManufactureName       string  `json:"manufacture_name" db:"manufacture.manufactureName"`
+	TopCategoryID         int64   `json:"top_category_id" db:"gear_top_category.topCategoryId"`
+	TopCategoryName       string  `json:"top_category_name" db:"gear_top_category.topCategoryName" translate:"TopCategoryID"`
+	TopCategoryIcon       string  `json:"top_category_icon" db:"gear_top_category.topCategoryIcon"`
+	CategoryID            int64   `json:"category_id" db:"gear_category.categoryId"`
+	CategoryName          string  `json:"category_name" db:"gear_category.categoryName" translate:"CategoryID"`
+	CategoryTopCategoryID int64   `json:"category_top_category_id" db:"gear_category.categoryTopCategoryId"`
+	SearchRank            float64 `json:"search_rank" db:"searchRank"`
+	SearchSnippet         string  `json:"search_snippet" db:"searchSnippet"`
+}
+
+// Measurement is a weight or length converted for display, such as {"value": 2.35, "unit": "kg"}.
+// The same shape is accepted in request bodies in place of a number of grams or millimetres.
 type Measurement struct {
-	Value int16 `json:"value" db:"value"`
-	Unit  bool  `json:"unit" db:"unit" validate:"oneof=inches feet centimeter meter grams kilos pounds"`
+	Value float64 `json:"value"`
+	Unit  string  `json:"unit"`
 }
+
+// Measurements holds the measured fields of a response converted to the requested unit
+// system, keyed by json field name.
+type Measurements map[string]Measurement
@@ -0,0 +1,20 @@
+package models
+
+// Image is a photo of a catalog gear item or of a user's registered item, with a generated
+// thumbnail. Exactly one of GearID and UserGearRegistrationID is set.
+type Image struct {
+	ImageID                *int64 `json:"image_id" db:"imageId"`
+	GearID                 *int64 `json:"gear_id" db:"gearId"`
+	UserGearRegistrationID *int64 `json:"usergear_registration_id" db:"userGearRegistrationId"`
+	ImageKey               string `json:"-" db:"imageKey" url:"ImageURL"`
+	ThumbnailKey           string `json:"-" db:"thumbnailKey" url:"ThumbnailURL"`
+	ContentType            string `json:"content_type" db:"contentType"`
+	ImageSize              int64  `json:"image_size" db:"imageSize"`
+	ImageWidth             int    `json:"image_width" db:"imageWidth"`
+	ImageHeight            int    `json:"image_height" db:"imageHeight"`
+	UploadedBy             int64  `json:"uploaded_by" db:"uploadedBy"`
+	CreatedAt              string `json:"created_at" db:"createdAt"`
+
+	ImageURL     string `json:"image_url"`
+	ThumbnailURL string `json:"thumbnail_url"`
+}
@@ -0,0 +1,48 @@
+package models
+
+// GearImportRow is one gear item in a bulk import. Categories and the manufacturer
+// are referenced by name rather than ID. Without gear_status, new gear goes straight
+// into the catalog and existing gear keeps its status.
+type GearImportRow struct {
+	GearName           string `json:"gear_name"`
+	TopCategory        string `json:"top_category"`
+	Category           string `json:"category"`
+	Manufacturer       string `json:"manufacturer"`
+	GearIsContainer    bool   `json:"gear_is_container"`
+	GearSizeDefinition string `json:"gear_size_definition"`
+	GearWeight         int32  `json:"gear_weight"`
+	GearHeight         int32  `json:"gear_height"`
+	GearLength         int32  `json:"gear_length"`
+	GearWidth          int32  `json:"gear_width"`
+	GearStatus         *bool  `json:"gear_status"`
+}
+
+// GearImportOptions controls how a bulk import treats missing manufacturers and
+// gear that already exists.
+type GearImportOptions struct {
+	DryRun              bool   `json:"dry_run"`
+	CreateManufacturers bool   `json:"create_manufacturers"`
+	OnDuplicate         string `json:"on_duplicate" enums:"skip,update,error"`
+}
+
+// GearImportRowResult reports what the import did, or would do, with one row.
+type GearImportRowResult struct {
+	Row      int    `json:"row"`
+	GearName string `json:"gear_name"`
+	Action   string `json:"action" enums:"created,updated,skipped,error"`
+	GearID   *int64 `json:"gear_id,omitempty"`
+	Error    string `json:"error,omitempty"`
+}
+
+// GearImportReport is the per-row outcome of a bulk import. Nothing is committed
+// on a dry run or when any row fails.
+type GearImportReport struct {
+	DryRun               bool                  `json:"dry_run"`
+	Committed            bool                  `json:"committed"`
+	Created              int                   `json:"created"`
+	Updated              int                   `json:"updated"`
+	Skipped              int                   `json:"skipped"`
+	Errors               int                   `json:"errors"`
+	CreatedManufacturers []string              `json:"created_manufacturers"`
+	Rows                 []GearImportRowResult `json:"rows"`
+}
@@ -8,9 +8,12 @@ type Loadout struct {
 	LoadoutDescription string `json:"loadout_description" db:"loadoutDescription"`
 	LoadoutIsPublic    bool   `json:"loadout_is_public" db:"loadoutIsPublic"`
 	LoadoutSlug        string `json:"loadout_slug" db:"loadoutSlug"`
-	TotalWeight        int64  `json:"total_weight" db:"totalWeight"`
+	TotalWeight        int64  `json:"total_weight" db:"totalWeight" unit:"total_weight"`
 	CreatedAt          string `json:"created_at" db:"createdAt"`
 	UpdatedAt          string `json:"updated_at" db:"updatedAt"`
+
+	Tags         []string     `json:"tags,omitempty"`
+	Measurements Measurements `json:"measurements,omitempty"`
 }
 
 // LoadoutNoID is used for creating new loadouts.
@@ -37,9 +40,11 @@ type LoadoutPublic struct {
 	LoadoutName        string `json:"loadout_name" db:"loadoutName"`
 	LoadoutDescription string `json:"loadout_description" db:"loadoutDescription"`
 	LoadoutSlug        string `json:"loadout_slug" db:"loadoutSlug"`
-	TotalWeight        int64  `json:"total_weight" db:"totalWeight"`
+	TotalWeight        int64  `json:"total_weight" db:"totalWeight" unit:"total_weight"`
 	CreatedAt          string `json:"created_at" db:"createdAt"`
 	UpdatedAt          string `json:"updated_at" db:"updatedAt"`
+
+	Measurements Measurements `json:"measurements,omitempty"`
 }
 
 // LoadoutItem represents a single gear item within a loadout.
@@ -49,6 +54,7 @@ type LoadoutItem struct {
 	GearID        int64  `json:"gear_id" db:"gearId"`
 	Quantity      int64  `json:"quantity" db:"quantity"`
 	Notes         string `json:"notes" db:"notes"`
+	VariantID     *int64 `json:"variant_id" db:"variantId"`
 }
 
 // LoadoutItemNoID is used for adding gear to a loadout.
@@ -57,6 +63,7 @@ type LoadoutItemNoID struct {
 	GearID    int64  `json:"gear_id" db:"gearId"`
 	Quantity  int64  `json:"quantity" db:"quantity"`
 	Notes     string `json:"notes" db:"notes"`
+	VariantID *int64 `json:"variant_id" db:"variantId"`
 }
 
 // LoadoutItemUpdate carries updatable fields with the ID for WHERE clause.
@@ -64,4 +71,51 @@ type LoadoutItemUpdate struct {
 	LoadoutItemID int64  `json:"loadout_item_id" db:"loadoutItemId"`
 	Quantity      int64  `json:"quantity" db:"quantity"`
 	Notes         string `json:"notes" db:"notes"`
+	VariantID     *int64 `json:"variant_id" db:"variantId"`
+}
+
+// LoadoutItemAlternatives lists lighter catalog gear in the category of a loadout item.
+// ItemWeight is the weight of one of the item, counting its variant if it has one.
+type LoadoutItemAlternatives struct {
+	LoadoutItemID int64             `json:"loadout_item_id"`
+	GearID        int64             `json:"gear_id"`
+	GearName      string            `json:"gear_name" translate:"GearID"`
+	VariantID     *int64            `json:"variant_id"`
+	Quantity      int64             `json:"quantity"`
+	ItemWeight    int64             `json:"item_weight" unit:"weight"`
+	Alternatives  []GearAlternative `json:"alternatives"`
+
+	Measurements Measurements `json:"measurements,omitempty"`
+}
+
+// GearAlternative is lighter gear that could replace a loadout item. WeightSaving is the
+// saving for one of the item and TotalWeightSaving for its whole quantity, which takes
+// the loadout's total weight to NewTotalWeight.
+type GearAlternative struct {
+	Gear              GearListItem `json:"gear"`
+	Weight            int64        `json:"weight" unit:"weight"`
+	WeightSaving      int64        `json:"weight_saving" unit:"weight"`
+	TotalWeightSaving int64        `json:"total_weight_saving" unit:"total_weight"`
+	NewTotalWeight    int64        `json:"new_total_weight" unit:"total_weight"`
+
+	Measurements Measurements `json:"measurements,omitempty"`
+}
+
+// LoadoutItemSwap replaces the gear of a loadout item. VariantID is a variant of the new
+// gear, or null for the gear itself.
+type LoadoutItemSwap struct {
+	GearID    int64  `json:"gear_id"`
+	VariantID *int64 `json:"variant_id"`
+}
+
+// LoadoutItemSwapResult tells how swapping a loadout item changed the loadout's weight.
+type LoadoutItemSwapResult struct {
+	LoadoutItemID       int64  `json:"loadout_item_id"`
+	GearID              int64  `json:"gear_id"`
+	VariantID           *int64 `json:"variant_id"`
+	PreviousTotalWeight int64  `json:"previous_total_weight" unit:"total_weight"`
+	TotalWeight         int64  `json:"total_weight" unit:"total_weight"`
+	WeightChange        int64  `json:"weight_change" unit:"total_weight"`
+
+	Measurements Measurements `json:"measurements,omitempty"`
 }
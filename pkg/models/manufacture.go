@@ -2,6 +2,54 @@ package models
 
 // Manufacture represents a gear manufacture.
 type Manufacture struct {
-	ManufactureID   *int64 `json:"manufacture_id" db:"manufactureId"`
-	ManufactureName string `json:"manufacture_name" db:"manufactureName"`
+	ManufactureID          *int64  `json:"manufacture_id" db:"manufactureId"`
+	ManufactureName        string  `json:"manufacture_name" db:"manufactureName"`
+	ManufactureWebsite     *string `json:"manufacture_website" db:"manufactureWebsite"`
+	ManufactureCountry     *string `json:"manufacture_country" db:"manufactureCountry"`
+	ManufactureFoundedYear *int64  `json:"manufacture_founded_year" db:"manufactureFoundedYear"`
+	ManufactureLogoURL     *string `json:"manufacture_logo_url" db:"manufactureLogoUrl"`
+	ManufactureDescription *string `json:"manufacture_description" db:"manufactureDescription"`
+}
+
+// ManufactureListItem is a manufacturer with the catalog stats the list can sort by.
+type ManufactureListItem struct {
+	ManufactureID          *int64  `json:"manufacture_id" db:"manufacture.manufactureId"`
+	ManufactureName        string  `json:"manufacture_name" db:"manufacture.manufactureName"`
+	ManufactureWebsite     *string `json:"manufacture_website" db:"manufacture.manufactureWebsite"`
+	ManufactureCountry     *string `json:"manufacture_country" db:"manufacture.manufactureCountry"`
+	ManufactureFoundedYear *int64  `json:"manufacture_founded_year" db:"manufacture.manufactureFoundedYear"`
+	ManufactureLogoURL     *string `json:"manufacture_logo_url" db:"manufacture.manufactureLogoUrl"`
+	ManufactureDescription *string `json:"manufacture_description" db:"manufacture.manufactureDescription"`
+
+	GearCount      int64  `json:"gear_count" db:"manufacture_stats.gearCount"`
+	OwnerCount     int64  `json:"owner_count" db:"manufacture_owners.ownerCount"`
+	LightestWeight *int32 `json:"lightest_weight" db:"manufacture_stats.lightestWeight" unit:"weight"`
+	HeaviestWeight *int32 `json:"heaviest_weight" db:"manufacture_stats.heaviestWeight" unit:"weight"`
+
+	Measurements Measurements `json:"measurements,omitempty"`
+}
+
+// ManufactureDetail is a manufacturer profile with stats aggregated from its gear.
+type ManufactureDetail struct {
+	Manufacture
+
+	GearCount     int64                      `json:"gear_count"`
+	OwnerCount    int64                      `json:"owner_count"`
+	TopCategories []ManufactureCategoryCount `json:"top_categories"`
+	LightestGear  *ManufactureGear           `json:"lightest_gear"`
+	HeaviestGear  *ManufactureGear           `json:"heaviest_gear"`
+}
+
+// ManufactureCategoryCount is how much of a manufacturer's gear is in a top category.
+type ManufactureCategoryCount struct {
+	TopCategoryID   int64  `json:"top_category_id" db:"topCategoryId"`
+	TopCategoryName string `json:"top_category_name" db:"topCategoryName" translate:"TopCategoryID"`
+	GearCount       int64  `json:"gear_count" db:"gearCount"`
+}
+
+// ManufactureGear names a piece of a manufacturer's gear and its weight in grams.
+type ManufactureGear struct {
+	GearID     int64  `json:"gear_id" db:"gearId"`
+	GearName   string `json:"gear_name" db:"gearName" translate:"GearID"`
+	GearWeight int32  `json:"gear_weight" db:"gearWeight"`
 }
@@ -0,0 +1,27 @@
+package models
+
+// GearSubmission is a piece of gear in the moderation queue or in a user's submissions,
+// with its review state.
+type GearSubmission struct {
+	GearID            int64   `json:"gear_id" db:"gear.gearId"`
+	GearName          string  `json:"gear_name" db:"gear.gearName"`
+	GearManufactureID int64   `json:"gear_manufacture_id" db:"gear.gearManufactureId"`
+	ManufactureName   *string `json:"manufacture_name" db:"manufacture.manufactureName"`
+	GearCategoryID    int64   `json:"gear_category_id" db:"gear.gearCategoryId"`
+	CategoryName      *string `json:"category_name" db:"gear_category.categoryName"`
+	GearStatus        bool    `json:"gear_status" db:"gear.gearStatus"`
+	GearReviewStatus  string  `json:"gear_review_status" db:"gear.gearReviewStatus"`
+	GearReviewReason  *string `json:"gear_review_reason" db:"gear.gearReviewReason"`
+	GearSubmittedBy   *int64  `json:"gear_submitted_by" db:"gear.gearSubmittedBy"`
+	SubmitterName     *string `json:"submitter_name" db:"users.userName"`
+	GearSubmittedAt   *string `json:"gear_submitted_at" db:"gear.gearSubmittedAt"`
+	GearReviewedBy    *int64  `json:"gear_reviewed_by" db:"gear.gearReviewedBy"`
+	GearReviewedAt    *string `json:"gear_reviewed_at" db:"gear.gearReviewedAt"`
+}
+
+// GearReviewDecision is an admin's decision on a gear submission: approve or reject. A
+// reason is required when rejecting.
+type GearReviewDecision struct {
+	Decision string  `json:"decision"`
+	Reason   *string `json:"reason"`
+}
@@ -0,0 +1,11 @@
+package models
+
+// GearRecommendation is gear recommended from co-ownership. Score is the strength of the
+// co-ownership, higher is stronger; Count is how many users or loadouts hold the gear
+// together with the gear in BecauseGearIDs that led to the recommendation.
+type GearRecommendation struct {
+	Score          float64      `json:"score"`
+	Count          int64        `json:"count"`
+	BecauseGearIDs []int64      `json:"because_gear_ids"`
+	Gear           GearListItem `json:"gear"`
+}
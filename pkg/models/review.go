@@ -0,0 +1,29 @@
+package models
+
+// GearReview is a rating from 1 to 5 and an optional text left on catalog gear by a user
+// who owns it. Hidden reviews were hidden by an admin and do not count towards the rating.
+type GearReview struct {
+	ReviewID           int64   `json:"review_id" db:"gear_reviews.reviewId"`
+	GearID             int64   `json:"gear_id" db:"gear_reviews.gearId"`
+	UserID             int64   `json:"user_id" db:"gear_reviews.userId"`
+	UserName           *string `json:"user_name" db:"users.userName"`
+	ReviewRating       int     `json:"review_rating" db:"gear_reviews.reviewRating"`
+	ReviewText         *string `json:"review_text" db:"gear_reviews.reviewText"`
+	ReviewHidden       bool    `json:"review_hidden" db:"gear_reviews.reviewHidden"`
+	ReviewHiddenReason *string `json:"review_hidden_reason" db:"gear_reviews.reviewHiddenReason"`
+	CreatedAt          string  `json:"created_at" db:"gear_reviews.createdAt"`
+	UpdatedAt          string  `json:"updated_at" db:"gear_reviews.updatedAt"`
+}
+
+// GearReviewNoID is used for creating and updating reviews; the gear comes from the route
+// and the user from the token.
+type GearReviewNoID struct {
+	ReviewRating int     `json:"review_rating"`
+	ReviewText   *string `json:"review_text"`
+}
+
+// GearReviewHide hides or shows a review again. A reason is kept for the author.
+type GearReviewHide struct {
+	Hidden bool    `json:"hidden"`
+	Reason *string `json:"reason"`
+}
@@ -0,0 +1,31 @@
+package models
+
+// CatalogRevision is a numbered version of a gear, category, top category or manufacturer.
+// Data holds the entry's tracked values by json field name, such as gear_weight. Action is
+// create, update or revert; a revert holds the values of RestoredRevision again.
+type CatalogRevision struct {
+	Kind             string                 `json:"kind"`
+	EntityID         int64                  `json:"entity_id"`
+	Revision         int64                  `json:"revision"`
+	Action           string                 `json:"action"`
+	RestoredRevision *int64                 `json:"restored_revision,omitempty"`
+	Data             map[string]interface{} `json:"data"`
+	CreatedAt        string                 `json:"created_at"`
+}
+
+// RevisionChange is a field whose value differs between two revisions.
+type RevisionChange struct {
+	Field string      `json:"field"`
+	From  interface{} `json:"from"`
+	To    interface{} `json:"to"`
+}
+
+// RevisionDiff lists the fields that differ from revision From to revision To of a catalog
+// entry.
+type RevisionDiff struct {
+	Kind     string           `json:"kind"`
+	EntityID int64            `json:"entity_id"`
+	From     int64            `json:"from"`
+	To       int64            `json:"to"`
+	Changes  []RevisionChange `json:"changes"`
+}
@@ -0,0 +1,203 @@
+// Code generated by scangen. DO NOT EDIT.
+
+package models
+
+// ScanFields returns pointers to the CategoryTree fields in db column order.
+func (m *CategoryTree) ScanFields() []interface{} {
+	return []interface{}{&m.TopCategoryID, &m.TopCategoryName, &m.TopCategoryIcon, &m.TopCategorySortOrder, &m.GearCount}
+}
+
+// ScanFields returns pointers to the CategoryTreeNode fields in db column order.
+func (m *CategoryTreeNode) ScanFields() []interface{} {
+	return []interface{}{&m.CategoryID, &m.CategoryTopCategoryID, &m.CategoryName, &m.CategoryParentID, &m.CategorySortOrder, &m.GearCount}
+}
+
+// ScanFields returns pointers to the FullGear fields in db column order.
+func (m *FullGear) ScanFields() []interface{} {
+	return []interface{}{&m.GearID, &m.GearTopCategoryID, &m.GearCategoryID, &m.GearManufactureID, &m.GearIsContainer, &m.GearName, &m.GearSizeDefinition, &m.GearWeight, &m.GearHeight, &m.GearLength, &m.GearWidth, &m.GearStatus, &m.GearRatingAverage, &m.GearReviewCount, &m.ManufactureID, &m.ManufactureName, &m.TopCategoryID, &m.TopCategoryName, &m.TopCategoryIcon, &m.CategoryID, &m.CategoryName, &m.CategoryTopCategoryID, &m.ImageKey, &m.ThumbnailKey}
+}
+
+// ScanFields returns pointers to the Gear fields in db column order.
+func (m *Gear) ScanFields() []interface{} {
+	return []interface{}{&m.GearID, &m.GearTopCategoryID, &m.GearCategoryID, &m.GearManufactureID, &m.GearIsContainer, &m.GearName, &m.GearSizeDefinition, &m.GearWeight, &m.GearHeight, &m.GearLength, &m.GearWidth, &m.GearStatus}
+}
+
+// ScanFields returns pointers to the GearCategory fields in db column order.
+func (m *GearCategory) ScanFields() []interface{} {
+	return []interface{}{&m.CategoryID, &m.CategoryTopCategoryID, &m.CategoryName, &m.CategoryParentID}
+}
+
+// ScanFields returns pointers to the GearCategoryListItem fields in db column order.
+func (m *GearCategoryListItem) ScanFields() []interface{} {
+	return []interface{}{&m.CategoryID, &m.CategoryTopCategoryID, &m.CategoryName, &m.CategoryParentID, &m.CategorySortOrder, &m.TopCategoryID, &m.TopCategoryName, &m.TopCategoryIcon}
+}
+
+// ScanFields returns pointers to the GearComparisonItem fields in db column order.
+func (m *GearComparisonItem) ScanFields() []interface{} {
+	return []interface{}{&m.GearID, &m.GearName, &m.GearTopCategoryID, &m.GearCategoryID, &m.ManufactureName, &m.TopCategoryName, &m.CategoryName, &m.GearWeight, &m.GearHeight, &m.GearLength, &m.GearWidth, &m.GearRatingAverage, &m.GearReviewCount, &m.OwnerCount, &m.ImageKey, &m.ThumbnailKey}
+}
+
+// ScanFields returns pointers to the GearDuplicateCandidate fields in db column order.
+func (m *GearDuplicateCandidate) ScanFields() []interface{} {
+	return []interface{}{&m.GearID, &m.GearName, &m.GearManufactureID, &m.ManufactureName, &m.GearWeight, &m.GearStatus, &m.RegistrationCount}
+}
+
+// ScanFields returns pointers to the GearListItem fields in db column order.
+func (m *GearListItem) ScanFields() []interface{} {
+	return []interface{}{&m.GearID, &m.GearTopCategoryID, &m.GearCategoryID, &m.GearManufactureID, &m.GearIsContainer, &m.GearName, &m.GearSizeDefinition, &m.ManufactureID, &m.ManufactureName, &m.TopCategoryID, &m.TopCategoryName, &m.TopCategoryIcon, &m.CategoryID, &m.CategoryName, &m.CategoryTopCategoryID, &m.GearRatingAverage, &m.GearReviewCount, &m.ImageKey, &m.ThumbnailKey}
+}
+
+// ScanFields returns pointers to the GearNameList fields in db column order.
+func (m *GearNameList) ScanFields() []interface{} {
+	return []interface{}{&m.GearID, &m.GearName}
+}
+
+// ScanFields returns pointers to the GearNoID fields in db column order.
+func (m *GearNoID) ScanFields() []interface{} {
+	return []interface{}{&m.GearTopCategoryID, &m.GearCategoryID, &m.GearManufactureID, &m.GearIsContainer, &m.GearName, &m.GearSizeDefinition, &m.GearWeight, &m.GearHeight, &m.GearLength, &m.GearWidth, &m.GearStatus}
+}
+
+// ScanFields returns pointers to the GearReview fields in db column order.
+func (m *GearReview) ScanFields() []interface{} {
+	return []interface{}{&m.ReviewID, &m.GearID, &m.UserID, &m.UserName, &m.ReviewRating, &m.ReviewText, &m.ReviewHidden, &m.ReviewHiddenReason, &m.CreatedAt, &m.UpdatedAt}
+}
+
+// ScanFields returns pointers to the GearSearchItem fields in db column order.
+func (m *GearSearchItem) ScanFields() []interface{} {
+	return []interface{}{&m.GearID, &m.GearTopCategoryID, &m.GearCategoryID, &m.GearManufactureID, &m.GearIsContainer, &m.GearName, &m.GearSizeDefinition, &m.ManufactureID, &m.ManufactureName, &m.TopCategoryID, &m.TopCategoryName, &m.TopCategoryIcon, &m.CategoryID, &m.CategoryName, &m.CategoryTopCategoryID, &m.SearchRank, &m.SearchSnippet}
+}
+
+// ScanFields returns pointers to the GearSubmission fields in db column order.
+func (m *GearSubmission) ScanFields() []interface{} {
+	return []interface{}{&m.GearID, &m.GearName, &m.GearManufactureID, &m.ManufactureName, &m.GearCategoryID, &m.CategoryName, &m.GearStatus, &m.GearReviewStatus, &m.GearReviewReason, &m.GearSubmittedBy, &m.SubmitterName, &m.GearSubmittedAt, &m.GearReviewedBy, &m.GearReviewedAt}
+}
+
+// ScanFields returns pointers to the GearTopCategory fields in db column order.
+func (m *GearTopCategory) ScanFields() []interface{} {
+	return []interface{}{&m.TopCategoryID, &m.TopCategoryName, &m.TopCategoryIcon}
+}
+
+// ScanFields returns pointers to the GearVariant fields in db column order.
+func (m *GearVariant) ScanFields() []interface{} {
+	return []interface{}{&m.VariantID, &m.GearID, &m.VariantName, &m.VariantWeight, &m.VariantHeight, &m.VariantLength, &m.VariantWidth}
+}
+
+// ScanFields returns pointers to the GearVariantNoID fields in db column order.
+func (m *GearVariantNoID) ScanFields() []interface{} {
+	return []interface{}{&m.VariantName, &m.VariantWeight, &m.VariantHeight, &m.VariantLength, &m.VariantWidth}
+}
+
+// ScanFields returns pointers to the Image fields in db column order.
+func (m *Image) ScanFields() []interface{} {
+	return []interface{}{&m.ImageID, &m.GearID, &m.UserGearRegistrationID, &m.ImageKey, &m.ThumbnailKey, &m.ContentType, &m.ImageSize, &m.ImageWidth, &m.ImageHeight, &m.UploadedBy, &m.CreatedAt}
+}
+
+// ScanFields returns pointers to the Loadout fields in db column order.
+func (m *Loadout) ScanFields() []interface{} {
+	return []interface{}{&m.LoadoutID, &m.UserID, &m.LoadoutName, &m.LoadoutDescription, &m.LoadoutIsPublic, &m.LoadoutSlug, &m.TotalWeight, &m.CreatedAt, &m.UpdatedAt}
+}
+
+// ScanFields returns pointers to the LoadoutItem fields in db column order.
+func (m *LoadoutItem) ScanFields() []interface{} {
+	return []interface{}{&m.LoadoutItemID, &m.LoadoutID, &m.GearID, &m.Quantity, &m.Notes, &m.VariantID}
+}
+
+// ScanFields returns pointers to the LoadoutItemNoID fields in db column order.
+func (m *LoadoutItemNoID) ScanFields() []interface{} {
+	return []interface{}{&m.LoadoutID, &m.GearID, &m.Quantity, &m.Notes, &m.VariantID}
+}
+
+// ScanFields returns pointers to the LoadoutItemUpdate fields in db column order.
+func (m *LoadoutItemUpdate) ScanFields() []interface{} {
+	return []interface{}{&m.LoadoutItemID, &m.Quantity, &m.Notes, &m.VariantID}
+}
+
+// ScanFields returns pointers to the LoadoutNoID fields in db column order.
+func (m *LoadoutNoID) ScanFields() []interface{} {
+	return []interface{}{&m.UserID, &m.LoadoutName, &m.LoadoutDescription, &m.LoadoutIsPublic, &m.LoadoutSlug}
+}
+
+// ScanFields returns pointers to the LoadoutPublic fields in db column order.
+func (m *LoadoutPublic) ScanFields() []interface{} {
+	return []interface{}{&m.LoadoutID, &m.LoadoutName, &m.LoadoutDescription, &m.LoadoutSlug, &m.TotalWeight, &m.CreatedAt, &m.UpdatedAt}
+}
+
+// ScanFields returns pointers to the LoadoutUpdate fields in db column order.
+func (m *LoadoutUpdate) ScanFields() []interface{} {
+	return []interface{}{&m.LoadoutID, &m.LoadoutName, &m.LoadoutDescription, &m.LoadoutIsPublic, &m.LoadoutSlug}
+}
+
+// ScanFields returns pointers to the Manufacture fields in db column order.
+func (m *Manufacture) ScanFields() []interface{} {
+	return []interface{}{&m.ManufactureID, &m.ManufactureName, &m.ManufactureWebsite, &m.ManufactureCountry, &m.ManufactureFoundedYear, &m.ManufactureLogoURL, &m.ManufactureDescription}
+}
+
+// ScanFields returns pointers to the ManufactureCategoryCount fields in db column order.
+func (m *ManufactureCategoryCount) ScanFields() []interface{} {
+	return []interface{}{&m.TopCategoryID, &m.TopCategoryName, &m.GearCount}
+}
+
+// ScanFields returns pointers to the ManufactureGear fields in db column order.
+func (m *ManufactureGear) ScanFields() []interface{} {
+	return []interface{}{&m.GearID, &m.GearName, &m.GearWeight}
+}
+
+// ScanFields returns pointers to the ManufactureListItem fields in db column order.
+func (m *ManufactureListItem) ScanFields() []interface{} {
+	return []interface{}{&m.ManufactureID, &m.ManufactureName, &m.ManufactureWebsite, &m.ManufactureCountry, &m.ManufactureFoundedYear, &m.ManufactureLogoURL, &m.ManufactureDescription, &m.GearCount, &m.OwnerCount, &m.LightestWeight, &m.HeaviestWeight}
+}
+
+// ScanFields returns pointers to the SpecAttribute fields in db column order.
+func (m *SpecAttribute) ScanFields() []interface{} {
+	return []interface{}{&m.AttributeID, &m.CategoryID, &m.Name, &m.Label, &m.Type, &m.Unit, &m.OptionsJSON, &m.Required}
+}
+
+// ScanFields returns pointers to the TagSuggestion fields in db column order.
+func (m *TagSuggestion) ScanFields() []interface{} {
+	return []interface{}{&m.Tag, &m.Count}
+}
+
+// ScanFields returns pointers to the User fields in db column order.
+func (m *User) ScanFields() []interface{} {
+	return []interface{}{&m.UserID, &m.UserPassword, &m.UserUsername, &m.UserName, &m.UserEmail, &m.UserIsAdmin}
+}
+
+// ScanFields returns pointers to the UserContainer fields in db column order.
+func (m *UserContainer) ScanFields() []interface{} {
+	return []interface{}{&m.ContainerRegistrationID, &m.UserContainerID, &m.UserGearRegistrationID}
+}
+
+// ScanFields returns pointers to the UserContainerNoID fields in db column order.
+func (m *UserContainerNoID) ScanFields() []interface{} {
+	return []interface{}{&m.UserContainerID, &m.UserGearRegistrationID}
+}
+
+// ScanFields returns pointers to the UserGear fields in db column order.
+func (m *UserGear) ScanFields() []interface{} {
+	return []interface{}{&m.UserGearRegistrationID, &m.UserGearGearID, &m.UserGearUserID, &m.MaxContainerWeight, &m.VariantID, &m.PurchaseDate, &m.PurchasePrice, &m.PurchaseCurrency, &m.Retailer, &m.Condition, &m.SerialNumber, &m.Colour, &m.Notes, &m.UserID, &m.UserUsername, &m.UserName, &m.GearID, &m.GearTopCategoryID, &m.GearCategoryID, &m.GearManufactureID, &m.GearName, &m.GearWeight, &m.GearHeight, &m.GearLength, &m.GearWidth, &m.GearStatus, &m.GearIsContainer, &m.ContainerLinkID, &m.ContainerID, &m.VariantName, &m.VariantWeight, &m.VariantHeight, &m.VariantLength, &m.VariantWidth, &m.ManufactureID, &m.ManufactureName, &m.TopCategoryID, &m.TopCategoryName, &m.TopCategoryIcon, &m.CategoryID, &m.CategoryName, &m.CategoryTopCategoryID, &m.ImageKey, &m.ThumbnailKey, &m.GearImageKey, &m.GearThumbnailKey}
+}
+
+// ScanFields returns pointers to the UserGearLink fields in db column order.
+func (m *UserGearLink) ScanFields() []interface{} {
+	return []interface{}{&m.UserGearRegistrationID, &m.UserGearGearID, &m.UserGearUserID, &m.MaxContainerWeight, &m.VariantID, &m.PurchaseDate, &m.PurchasePrice, &m.PurchaseCurrency, &m.Retailer, &m.Condition, &m.SerialNumber, &m.Colour, &m.Notes}
+}
+
+// ScanFields returns pointers to the UserGearLinkNoID fields in db column order.
+func (m *UserGearLinkNoID) ScanFields() []interface{} {
+	return []interface{}{&m.UserGearGearID, &m.UserGearUserID, &m.MaxContainerWeight, &m.VariantID, &m.PurchaseDate, &m.PurchasePrice, &m.PurchaseCurrency, &m.Retailer, &m.Condition, &m.SerialNumber, &m.Colour, &m.Notes}
+}
+
+// ScanFields returns pointers to the UserInventory fields in db column order.
+func (m *UserInventory) ScanFields() []interface{} {
+	return []interface{}{&m.GearID, &m.CustomName}
+}
+
+// ScanFields returns pointers to the UserPreferences fields in db column order.
+func (m *UserPreferences) ScanFields() []interface{} {
+	return []interface{}{&m.UserID, &m.UnitSystem, &m.Locale}
+}
+
+// ScanFields returns pointers to the UserWithPass fields in db column order.
+func (m *UserWithPass) ScanFields() []interface{} {
+	return []interface{}{&m.UserID, &m.UserUsername, &m.UserPassword, &m.UserName, &m.UserEmail, &m.UserIsAdmin}
+}
@@ -0,0 +1,32 @@
+package models
+
+// SpecAttribute is one typed specification attribute in a category's schema, such as the
+// R-value of sleeping pads. Type is number, enum, bool or text; enum values must be one of
+// Options.
+type SpecAttribute struct {
+	AttributeID *int64  `json:"attribute_id" db:"attributeId"`
+	CategoryID  int64   `json:"category_id" db:"categoryId"`
+	Name        string  `json:"name" db:"attributeName"`
+	Label       string  `json:"label" db:"attributeLabel"`
+	Type        string  `json:"type" db:"attributeType"`
+	Unit        *string `json:"unit" db:"attributeUnit"`
+	OptionsJSON *string `json:"-" db:"attributeOptions"`
+	Required    bool    `json:"required" db:"attributeRequired"`
+
+	Options []string `json:"options,omitempty"`
+}
+
+// SpecAttributeNoID is used for creating and updating attributes; the category comes from
+// the route.
+type SpecAttributeNoID struct {
+	Name     string   `json:"name"`
+	Label    string   `json:"label"`
+	Type     string   `json:"type"`
+	Unit     *string  `json:"unit"`
+	Options  []string `json:"options"`
+	Required bool     `json:"required"`
+}
+
+// Specs holds the specification values of a piece of gear keyed by attribute name.
+// Values are numbers, booleans or strings depending on the attribute type.
+type Specs map[string]interface{}
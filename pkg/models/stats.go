@@ -0,0 +1,16 @@
+package models
+
+// GearRanking is a piece of gear in a popularity ranking. Count is the number of owners
+// or public loadouts, depending on the ranking; Rank starts at 1.
+type GearRanking struct {
+	Rank  int          `json:"rank"`
+	Count int64        `json:"count"`
+	Gear  GearListItem `json:"gear"`
+}
+
+// GearRecentlyAdded is a piece of gear recently added to the catalog. AddedAt is nil for
+// gear added before addition times were recorded.
+type GearRecentlyAdded struct {
+	AddedAt *string      `json:"added_at"`
+	Gear    GearListItem `json:"gear"`
+}
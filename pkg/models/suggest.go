@@ -0,0 +1,10 @@
+package models
+
+// Suggestion is a ranked autocomplete match for gear, a manufacturer or a category.
+type Suggestion struct {
+	Type   string  `json:"type"`
+	ID     int64   `json:"id"`
+	Name   string  `json:"name"`
+	Detail string  `json:"detail,omitempty"`
+	Score  float64 `json:"score"`
+}
@@ -0,0 +1,12 @@
+package models
+
+// TagInput is the body for adding tags to gear, a registration or a loadout.
+type TagInput struct {
+	Tags []string `json:"tags"`
+}
+
+// TagSuggestion is a tag matching an autocomplete prefix and how often it is used.
+type TagSuggestion struct {
+	Tag   string `json:"tag" db:"tagName"`
+	Count int64  `json:"count" db:"tagCount"`
+}
@@ -3,6 +3,6 @@ package models
 // GearTopCategory represents a gear top category.
 type GearTopCategory struct {
 	TopCategoryID   *int64 `json:"top_category_id" db:"topCategoryId"`
-	TopCategoryName string `json:"top_category_name" db:"topCategoryName"`
+	TopCategoryName string `json:"top_category_name" db:"topCategoryName" translate:"TopCategoryID"`
 	TopCategoryIcon string `json:"top_category_icon" db:"topCategoryIcon"`
 }
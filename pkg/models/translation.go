@@ -0,0 +1,31 @@
+package models
+
+// Translation is the text of a catalog entry in a locale. Fields holds the translated text
+// by json field name, such as gear_name; a field left out falls back to the default locale.
+type Translation struct {
+	Locale    string            `json:"locale"`
+	Fields    map[string]string `json:"fields"`
+	UpdatedAt string            `json:"updated_at"`
+}
+
+// TranslationUpdate replaces the translated text of a catalog entry in a locale.
+type TranslationUpdate struct {
+	Fields map[string]string `json:"fields"`
+}
+
+// MissingTranslation is a catalog entry that lacks translated text. Name is its text in the
+// default locale and MissingFields the json names of the fields without a translation.
+type MissingTranslation struct {
+	Kind          string   `json:"kind"`
+	ID            int64    `json:"id"`
+	Name          string   `json:"name"`
+	MissingFields []string `json:"missing_fields"`
+}
+
+// MissingTranslationReport lists the catalog entries not yet translated to a locale, out of
+// Total entries of the reported kinds.
+type MissingTranslationReport struct {
+	Locale  string               `json:"locale"`
+	Total   int64                `json:"total"`
+	Missing []MissingTranslation `json:"missing"`
+}
@@ -30,3 +30,10 @@ type UserInventory struct {
 	GearID     int64  `json:"gear_id" db:"gear_id"`
 	CustomName string `json:"custom_name" db:"custom_name"`
 }
+
+// UserPreferences holds the authenticated user's display settings.
+type UserPreferences struct {
+	UserID     int64   `json:"user_id" db:"userId"`
+	UnitSystem *string `json:"unit_system" db:"unitSystem"`
+	Locale     *string `json:"locale" db:"locale"`
+}
@@ -5,7 +5,17 @@ type UserGear struct {
 	UserGearRegistrationID *int64 `json:"usergear_registration_id" db:"user_gear_registrations.userGearRegistrationId"`
 	UserGearGearID         int64  `json:"usergear_gear_id" db:"user_gear_registrations.gearId"`
 	UserGearUserID         int64  `json:"usergear_user_id" db:"user_gear_registrations.userId"`
-	MaxContainerWeight     *int32 `json:"max_container_weight" db:"user_gear_registrations.maxContainerWeight"`
+	MaxContainerWeight     *int32 `json:"max_container_weight" db:"user_gear_registrations.maxContainerWeight" unit:"weight"`
+	VariantID              *int64 `json:"variant_id" db:"user_gear_registrations.variantId"`
+
+	PurchaseDate     *string  `json:"purchase_date" db:"user_gear_registrations.purchaseDate"`
+	PurchasePrice    *float64 `json:"purchase_price" db:"user_gear_registrations.purchasePrice"`
+	PurchaseCurrency *string  `json:"purchase_currency" db:"user_gear_registrations.purchaseCurrency"`
+	Retailer         *string  `json:"retailer" db:"user_gear_registrations.retailer"`
+	Condition        *string  `json:"condition" db:"user_gear_registrations.itemCondition"`
+	SerialNumber     *string  `json:"serial_number" db:"user_gear_registrations.serialNumber"`
+	Colour           *string  `json:"colour" db:"user_gear_registrations.colour"`
+	Notes            *string  `json:"notes" db:"user_gear_registrations.notes"`
 
 	UserID       int64  `json:"user_id" db:"users.userId"`
 	UserUsername string `json:"user_username" db:"users.userUsername"`
@@ -15,39 +25,75 @@ type UserGear struct {
 	GearTopCategoryID int64  `json:"gear_top_category_id" db:"gear.gearTopCategoryId"`
 	GearCategoryID    int64  `json:"gear_category_id" db:"gear.gearCategoryId"`
 	GearManufactureID int64  `json:"gear_manufacture_id" db:"gear.gearManufactureId"`
-	GearName          string `json:"gear_name" db:"gear.gearName"`
-	GearWeight        int32  `json:"gear_weight" db:"gear.gearWeight"`
-	GearHeight        int32  `json:"gear_height" db:"gear.gearHeight"`
-	GearLength        int32  `json:"gear_length" db:"gear.gearLength"`
-	GearWidth         int32  `json:"gear_width" db:"gear.gearWidth"`
+	GearName          string `json:"gear_name" db:"gear.gearName" translate:"GearID"`
+	GearWeight        int32  `json:"gear_weight" db:"gear.gearWeight" unit:"weight"`
+	GearHeight        int32  `json:"gear_height" db:"gear.gearHeight" unit:"length"`
+	GearLength        int32  `json:"gear_length" db:"gear.gearLength" unit:"length"`
+	GearWidth         int32  `json:"gear_width" db:"gear.gearWidth" unit:"length"`
 	GearStatus        bool   `json:"gear_status" db:"gear.gearStatus"`
 	GearIsContainer   bool   `json:"gear_is_container" db:"gear.gearIsContainer"`
 	ContainerLinkID   *int64 `json:"container_link_id" db:"user_container_registration.containerRegistrationId"`
 	ContainerID       *int64 `json:"container_registration_id" db:"user_container_registration.userContainerId"`
 
+	VariantName   *string `json:"variant_name" db:"gear_variants.variantName"`
+	VariantWeight *int32  `json:"variant_weight" db:"gear_variants.variantWeight" unit:"weight"`
+	VariantHeight *int32  `json:"variant_height" db:"gear_variants.variantHeight" unit:"length"`
+	VariantLength *int32  `json:"variant_length" db:"gear_variants.variantLength" unit:"length"`
+	VariantWidth  *int32  `json:"variant_width" db:"gear_variants.variantWidth" unit:"length"`
+
 	ManufactureID   int64  `json:"manufacture_id" db:"manufacture.manufactureId"`
 	ManufactureName string `json:"manufacture_name" db:"manufacture.manufactureName"`
 
 	TopCategoryID   int64  `json:"top_category_id" db:"gear_top_category.topCategoryId"`
-	TopCategoryName string `json:"top_category_name" db:"gear_top_category.topCategoryName"`
+	TopCategoryName string `json:"top_category_name" db:"gear_top_category.topCategoryName" translate:"TopCategoryID"`
 	TopCategoryIcon string `json:"top_category_icon" db:"gear_top_category.topCategoryIcon"`
 
 	CategoryID            int64  `json:"category_id" db:"gear_category.categoryId"`
-	CategoryName          string `json:"category_name" db:"gear_category.categoryName"`
+	CategoryName          string `json:"category_name" db:"gear_category.categoryName" translate:"CategoryID"`
 	CategoryTopCategoryID int64  `json:"category_top_category_id" db:"gear_category.categoryTopCategoryId"`
+
+	ImageKey         *string `json:"-" db:"registration_image.imageKey" url:"ImageURL"`
+	ThumbnailKey     *string `json:"-" db:"registration_image.thumbnailKey" url:"ThumbnailURL"`
+	GearImageKey     *string `json:"-" db:"gear_image.imageKey" url:"GearImageURL"`
+	GearThumbnailKey *string `json:"-" db:"gear_image.thumbnailKey" url:"GearThumbnailURL"`
+	ImageURL         *string `json:"image_url,omitempty"`
+	ThumbnailURL     *string `json:"thumbnail_url,omitempty"`
+	GearImageURL     *string `json:"gear_image_url,omitempty"`
+	GearThumbnailURL *string `json:"gear_thumbnail_url,omitempty"`
+
+	Tags         []string     `json:"tags,omitempty"`
+	Measurements Measurements `json:"measurements,omitempty"`
 }
 
 // UserGearLink represents the link between a user and their gear.
 type UserGearLink struct {
-	UserGearRegistrationID *int64 `json:"usergear_registration_id" db:"userGearRegistrationId"`
-	UserGearGearID         int64  `json:"usergear_gear_id" db:"gearId"`
-	UserGearUserID         int64  `json:"usergear_user_id" db:"userId"`
-	MaxContainerWeight     *int32 `json:"max_container_weight" db:"maxContainerWeight"`
+	UserGearRegistrationID *int64   `json:"usergear_registration_id" db:"userGearRegistrationId"`
+	UserGearGearID         int64    `json:"usergear_gear_id" db:"gearId"`
+	UserGearUserID         int64    `json:"usergear_user_id" db:"userId"`
+	MaxContainerWeight     *int32   `json:"max_container_weight" db:"maxContainerWeight" unit:"weight"`
+	VariantID              *int64   `json:"variant_id" db:"variantId"`
+	PurchaseDate           *string  `json:"purchase_date" db:"purchaseDate"`
+	PurchasePrice          *float64 `json:"purchase_price" db:"purchasePrice"`
+	PurchaseCurrency       *string  `json:"purchase_currency" db:"purchaseCurrency"`
+	Retailer               *string  `json:"retailer" db:"retailer"`
+	Condition              *string  `json:"condition" db:"itemCondition"`
+	SerialNumber           *string  `json:"serial_number" db:"serialNumber"`
+	Colour                 *string  `json:"colour" db:"colour"`
+	Notes                  *string  `json:"notes" db:"notes"`
 }
 
 // UserGearLinkNoID represents the link between a user and their gear without an ID.
 type UserGearLinkNoID struct {
-	UserGearGearID     int64  `json:"usergear_gear_id" db:"gearId"`
-	UserGearUserID     int64  `json:"usergear_user_id" db:"userId"`
-	MaxContainerWeight *int32 `json:"max_container_weight" db:"maxContainerWeight"`
+	UserGearGearID     int64    `json:"usergear_gear_id" db:"gearId"`
+	UserGearUserID     int64    `json:"usergear_user_id" db:"userId"`
+	MaxContainerWeight *int32   `json:"max_container_weight" db:"maxContainerWeight" unit:"weight"`
+	VariantID          *int64   `json:"variant_id" db:"variantId"`
+	PurchaseDate       *string  `json:"purchase_date" db:"purchaseDate"`
+	PurchasePrice      *float64 `json:"purchase_price" db:"purchasePrice"`
+	PurchaseCurrency   *string  `json:"purchase_currency" db:"purchaseCurrency"`
+	Retailer           *string  `json:"retailer" db:"retailer"`
+	Condition          *string  `json:"condition" db:"itemCondition"`
+	SerialNumber       *string  `json:"serial_number" db:"serialNumber"`
+	Colour             *string  `json:"colour" db:"colour"`
+	Notes              *string  `json:"notes" db:"notes"`
 }
@@ -0,0 +1,23 @@
+package models
+
+// GearVariant is one size of a piece of gear, with its own weight and dimensions.
+type GearVariant struct {
+	VariantID     *int64 `json:"variant_id" db:"variantId"`
+	GearID        int64  `json:"gear_id" db:"gearId"`
+	VariantName   string `json:"variant_name" db:"variantName"`
+	VariantWeight int32  `json:"variant_weight" db:"variantWeight" unit:"weight"`
+	VariantHeight int32  `json:"variant_height" db:"variantHeight" unit:"length"`
+	VariantLength int32  `json:"variant_length" db:"variantLength" unit:"length"`
+	VariantWidth  int32  `json:"variant_width" db:"variantWidth" unit:"length"`
+
+	Measurements Measurements `json:"measurements,omitempty"`
+}
+
+// GearVariantNoID is used for creating and updating variants; the gear comes from the route.
+type GearVariantNoID struct {
+	VariantName   string `json:"variant_name" db:"variantName"`
+	VariantWeight int32  `json:"variant_weight" db:"variantWeight" unit:"weight"`
+	VariantHeight int32  `json:"variant_height" db:"variantHeight" unit:"length"`
+	VariantLength int32  `json:"variant_length" db:"variantLength" unit:"length"`
+	VariantWidth  int32  `json:"variant_width" db:"variantWidth" unit:"length"`
+}
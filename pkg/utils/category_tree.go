@@ -0,0 +1,300 @@
+package utils
+
+import (
+	"database/sql"
+	"fmt"
+	"sort"
+	"strings"
+
+	models "github.com/Sea-Shell/gogear-api/pkg/models"
+)
+
+// CategoryTreeError reports an invalid move or reorder in the category tree.
+type CategoryTreeError struct {
+	Message string
+}
+
+func (e *CategoryTreeError) Error() string {
+	return e.Message
+}
+
+// categorySubtree selects the categories in %s and all of their descendants.
+const categorySubtree = `WITH RECURSIVE category_subtree(categoryId) AS (
+            SELECT categoryId FROM gear_category WHERE categoryId IN (%s)
+            UNION
+            SELECT gear_category.categoryId FROM gear_category
+            JOIN category_subtree ON gear_category.categoryParentId = category_subtree.categoryId)
+        SELECT categoryId FROM category_subtree`
+
+// CategorySubtreeCondition returns the SQL condition matching rows whose column holds one
+// of the categories in ids or any category below them.
+func CategorySubtreeCondition(column string, ids ...interface{}) (string, []interface{}) {
+	placeholders := strings.Repeat("?, ", len(ids)-1) + "?"
+	return fmt.Sprintf("%s IN ("+categorySubtree+")", column, placeholders), ids
+}
+
+type queryer interface {
+	QueryRow(query string, args ...interface{}) *sql.Row
+	Query(query string, args ...interface{}) (*sql.Rows, error)
+}
+
+// ResolveCategoryParent checks that a category can be placed below parentID, or directly
+// below topCategoryID when parentID is nil, and returns the top category it ends up in.
+// categoryID is 0 for a new category; an existing one cannot be placed below itself.
+func ResolveCategoryParent(db queryer, categoryID int64, parentID *int64, topCategoryID *int64) (int64, error) {
+	if parentID == nil {
+		if topCategoryID == nil {
+			return 0, &CategoryTreeError{Message: "parent_id or top_category_id is required"}
+		}
+		var exists bool
+		err := db.QueryRow("SELECT EXISTS (SELECT 1 FROM gear_top_category WHERE topCategoryId = ?)", *topCategoryID).Scan(&exists)
+		if err != nil {
+			return 0, fmt.Errorf("check top category: %w", err)
+		}
+		if !exists {
+			return 0, &CategoryTreeError{Message: fmt.Sprintf("top category %d does not exist", *topCategoryID)}
+		}
+		return *topCategoryID, nil
+	}
+
+	var parentTop int64
+	err := db.QueryRow("SELECT categoryTopCategoryId FROM gear_category WHERE categoryId = ?", *parentID).Scan(&parentTop)
+	if err == sql.ErrNoRows {
+		return 0, &CategoryTreeError{Message: fmt.Sprintf("parent category %d does not exist", *parentID)}
+	}
+	if err != nil {
+		return 0, fmt.Errorf("check parent category: %w", err)
+	}
+	if topCategoryID != nil && *topCategoryID != parentTop {
+		return 0, &CategoryTreeError{Message: fmt.Sprintf("parent category %d is not in top category %d", *parentID, *topCategoryID)}
+	}
+
+	if categoryID != 0 {
+		var cycle bool
+		err := db.QueryRow("SELECT EXISTS (SELECT 1 FROM ("+fmt.Sprintf(categorySubtree, "?")+") WHERE categoryId = ?)",
+			categoryID, *parentID).Scan(&cycle)
+		if err != nil {
+			return 0, fmt.Errorf("check category subtree: %w", err)
+		}
+		if cycle {
+			return 0, &CategoryTreeError{Message: "a category cannot be moved below itself"}
+		}
+	}
+	return parentTop, nil
+}
+
+// siblingCategories returns the categories below parentID, or directly below
+// topCategoryID when parentID is nil, in tree order.
+func siblingCategories(db queryer, parentID *int64, topCategoryID int64) ([]int64, error) {
+	order := " ORDER BY categorySortOrder, categoryName, categoryId"
+	if parentID == nil {
+		return queryIDs(db, "SELECT categoryId FROM gear_category WHERE categoryParentId IS NULL AND categoryTopCategoryId = ?"+order, topCategoryID)
+	}
+	return queryIDs(db, "SELECT categoryId FROM gear_category WHERE categoryParentId = ?"+order, *parentID)
+}
+
+func queryIDs(db queryer, query string, args ...interface{}) ([]int64, error) {
+	rows, err := db.Query(query, args...)
+	if err != nil {
+		return nil, fmt.Errorf("query categories: %w", err)
+	}
+	defer rows.Close()
+
+	ids := []int64{}
+	for rows.Next() {
+		var id int64
+		if err := rows.Scan(&id); err != nil {
+			return nil, fmt.Errorf("scan categories: %w", err)
+		}
+		ids = append(ids, id)
+	}
+	return ids, rows.Err()
+}
+
+// MoveCategory places a category and everything below it under a new parent, at position
+// among its new siblings or last when position is nil. The top category of the moved
+// categories and their gear follows the new parent. It returns sql.ErrNoRows when the
+// category does not exist.
+func MoveCategory(db *sql.DB, categoryID int64, move models.CategoryMove) error {
+	tx, err := db.Begin()
+	if err != nil {
+		return err
+	}
+	defer tx.Rollback()
+
+	if _, err := moveCategory(tx, categoryID, move); err != nil {
+		return err
+	}
+	return tx.Commit()
+}
+
+// moveCategory moves a category within tx and returns the top category it ends up in.
+func moveCategory(tx *sql.Tx, categoryID int64, move models.CategoryMove) (int64, error) {
+	var exists bool
+	if err := tx.QueryRow("SELECT EXISTS (SELECT 1 FROM gear_category WHERE categoryId = ?)", categoryID).Scan(&exists); err != nil {
+		return 0, fmt.Errorf("check category: %w", err)
+	}
+	if !exists {
+		return 0, sql.ErrNoRows
+	}
+
+	topCategoryID, err := ResolveCategoryParent(tx, categoryID, move.ParentID, move.TopCategoryID)
+	if err != nil {
+		return 0, err
+	}
+
+	siblings, err := siblingCategories(tx, move.ParentID, topCategoryID)
+	if err != nil {
+		return 0, err
+	}
+	order := make([]int64, 0, len(siblings)+1)
+	for _, id := range siblings {
+		if id != categoryID {
+			order = append(order, id)
+		}
+	}
+	position := len(order)
+	if move.Position != nil {
+		if *move.Position < 0 {
+			return 0, &CategoryTreeError{Message: "position cannot be negative"}
+		}
+		position = min(*move.Position, len(order))
+	}
+	order = append(order[:position], append([]int64{categoryID}, order[position:]...)...)
+
+	// The moved category takes its parent and top category in one statement, so its
+	// revision history records the move once.
+	if _, err := tx.Exec("UPDATE gear_category SET categoryParentId = ?, categoryTopCategoryId = ? WHERE categoryId = ?",
+		move.ParentID, topCategoryID, categoryID); err != nil {
+		return 0, fmt.Errorf("move category: %w", err)
+	}
+	subtree := fmt.Sprintf(categorySubtree, "?")
+	if _, err := tx.Exec("UPDATE gear_category SET categoryTopCategoryId = ? WHERE categoryId IN ("+subtree+")", topCategoryID, categoryID); err != nil {
+		return 0, fmt.Errorf("move category subtree: %w", err)
+	}
+	if _, err := tx.Exec("UPDATE gear SET gearTopCategoryId = ? WHERE gearCategoryId IN ("+subtree+")", topCategoryID, categoryID); err != nil {
+		return 0, fmt.Errorf("move category gear: %w", err)
+	}
+	if err := setSortOrder(tx, "gear_category", "categorySortOrder", "categoryId", order); err != nil {
+		return 0, err
+	}
+	return topCategoryID, nil
+}
+
+// ReorderCategories sorts the children of reorder.ParentID, the categories directly below
+// reorder.TopCategoryID, or the top categories, in the order given.
+func ReorderCategories(db *sql.DB, reorder models.CategoryReorder) error {
+	tx, err := db.Begin()
+	if err != nil {
+		return err
+	}
+	defer tx.Rollback()
+
+	table, column, key := "gear_category", "categorySortOrder", "categoryId"
+	var current []int64
+	if reorder.ParentID == nil && reorder.TopCategoryID == nil {
+		table, column, key = "gear_top_category", "topCategorySortOrder", "topCategoryId"
+		current, err = queryIDs(tx, "SELECT topCategoryId FROM gear_top_category")
+	} else {
+		var topCategoryID int64
+		topCategoryID, err = ResolveCategoryParent(tx, 0, reorder.ParentID, reorder.TopCategoryID)
+		if err != nil {
+			return err
+		}
+		current, err = siblingCategories(tx, reorder.ParentID, topCategoryID)
+	}
+	if err != nil {
+		return err
+	}
+
+	given := append([]int64(nil), reorder.Order...)
+	sort.Slice(given, func(i, j int) bool { return given[i] < given[j] })
+	sort.Slice(current, func(i, j int) bool { return current[i] < current[j] })
+	if len(given) != len(current) {
+		return &CategoryTreeError{Message: "order must list every sibling exactly once"}
+	}
+	for i := range given {
+		if given[i] != current[i] {
+			return &CategoryTreeError{Message: "order must list every sibling exactly once"}
+		}
+	}
+
+	if err := setSortOrder(tx, table, column, key, reorder.Order); err != nil {
+		return err
+	}
+	return tx.Commit()
+}
+
+func setSortOrder(tx *sql.Tx, table, column, key string, order []int64) error {
+	statement := fmt.Sprintf("UPDATE %s SET %s = ? WHERE %s = ?", table, column, key)
+	for position, id := range order {
+		if _, err := tx.Exec(statement, position, id); err != nil {
+			return fmt.Errorf("sort %s: %w", table, err)
+		}
+	}
+	return nil
+}
+
+// CategoryTree returns every top category with its categories nested below it, siblings
+// in their sort order.
+func CategoryTree(db *sql.DB) ([]models.CategoryTree, error) {
+	rows, err := db.Query(`SELECT topCategoryId, topCategoryName, topCategoryIcon, topCategorySortOrder, 0 AS gearCount
+        FROM gear_top_category ORDER BY topCategorySortOrder, topCategoryName, topCategoryId`)
+	if err != nil {
+		return nil, fmt.Errorf("query top categories: %w", err)
+	}
+	tops, err := ScanRows[models.CategoryTree](rows)
+	rows.Close()
+	if err != nil {
+		return nil, fmt.Errorf("scan top categories: %w", err)
+	}
+
+	rows, err = db.Query(`SELECT categoryId, categoryTopCategoryId, categoryName, categoryParentId, categorySortOrder,
+            (SELECT COUNT(*) FROM gear WHERE gear.gearCategoryId = gear_category.categoryId) AS gearCount
+        FROM gear_category ORDER BY categorySortOrder, categoryName, categoryId`)
+	if err != nil {
+		return nil, fmt.Errorf("query categories: %w", err)
+	}
+	categories, err := ScanRows[models.CategoryTreeNode](rows)
+	rows.Close()
+	if err != nil {
+		return nil, fmt.Errorf("scan categories: %w", err)
+	}
+
+	known := make(map[int64]bool, len(categories))
+	for _, category := range categories {
+		known[category.CategoryID] = true
+	}
+	children := make(map[int64][]models.CategoryTreeNode)
+	roots := make(map[int64][]models.CategoryTreeNode)
+	for _, category := range categories {
+		if category.CategoryParentID != nil && known[*category.CategoryParentID] {
+			children[*category.CategoryParentID] = append(children[*category.CategoryParentID], category)
+		} else {
+			roots[category.CategoryTopCategoryID] = append(roots[category.CategoryTopCategoryID], category)
+		}
+	}
+
+	var build func(nodes []models.CategoryTreeNode) ([]models.CategoryTreeNode, int64)
+	build = func(nodes []models.CategoryTreeNode) ([]models.CategoryTreeNode, int64) {
+		var total int64
+		for i := range nodes {
+			var count int64
+			nodes[i].Children, count = build(children[nodes[i].CategoryID])
+			nodes[i].GearCount += count
+			total += nodes[i].GearCount
+		}
+		if nodes == nil {
+			nodes = []models.CategoryTreeNode{}
+		}
+		return nodes, total
+	}
+
+	if tops == nil {
+		tops = []models.CategoryTree{}
+	}
+	for i := range tops {
+		tops[i].Children, tops[i].GearCount = build(roots[tops[i].TopCategoryID])
+	}
+	return tops, nil
+}
@@ -0,0 +1,191 @@
+package utils
+
+import (
+	"database/sql"
+	"fmt"
+	"strings"
+
+	models "github.com/Sea-Shell/gogear-api/pkg/models"
+)
+
+// Preferred directions of comparison fields.
+const (
+	CompareLower  = "lower"
+	CompareHigher = "higher"
+)
+
+var (
+	gramsUnit       = "g"
+	millimetresUnit = "mm"
+)
+
+// CompareGear lines up the gear with the given IDs, in that order, field by field: the
+// catalog fields, the ownership count and every spec attribute of their categories. Names
+// are translated to locale unless it is empty. It returns sql.ErrNoRows when any of the
+// gear does not exist.
+func CompareGear(db *sql.DB, ids []int64, locale string) (*models.GearComparison, error) {
+	placeholders := strings.Repeat("?, ", len(ids)-1) + "?"
+	args := make([]interface{}, len(ids))
+	for i, id := range ids {
+		args[i] = id
+	}
+
+	columns, err := columnsFor[models.GearComparisonItem]()
+	if err != nil {
+		return nil, err
+	}
+	selected := make([]string, len(columns))
+	for i, column := range columns {
+		selected[i] = column
+		if column == "ownerCount" {
+			selected[i] = `(SELECT COUNT(DISTINCT userId) FROM user_gear_registrations
+                WHERE user_gear_registrations.gearId = gear.gearId) AS ownerCount`
+		}
+	}
+	rows, err := db.Query("SELECT "+strings.Join(selected, ", ")+" FROM gear"+GearJoins+
+		" WHERE gear.gearId IN ("+placeholders+")", args...)
+	if err != nil {
+		return nil, fmt.Errorf("query compared gear: %w", err)
+	}
+	found, err := ScanRows[models.GearComparisonItem](rows)
+	rows.Close()
+	if err != nil {
+		return nil, fmt.Errorf("scan compared gear: %w", err)
+	}
+
+	if locale != "" {
+		items := make([]*models.GearComparisonItem, len(found))
+		for i := range found {
+			items[i] = &found[i]
+		}
+		if err := Translate(db, locale, items...); err != nil {
+			return nil, err
+		}
+	}
+
+	byID := make(map[int64]models.GearComparisonItem, len(found))
+	for _, item := range found {
+		byID[item.GearID] = item
+	}
+	comparison := &models.GearComparison{Gear: make([]models.GearComparisonItem, len(ids))}
+	for i, id := range ids {
+		item, ok := byID[id]
+		if !ok {
+			return nil, sql.ErrNoRows
+		}
+		if item.Specs, err = GearSpecs(db, id); err != nil {
+			return nil, err
+		}
+		comparison.Gear[i] = item
+	}
+
+	lightest := -1
+	for i, item := range comparison.Gear {
+		if item.GearWeight > 0 && (lightest < 0 || item.GearWeight < comparison.Gear[lightest].GearWeight) {
+			lightest = i
+		}
+	}
+	if lightest >= 0 {
+		comparison.LightestGearID = &comparison.Gear[lightest].GearID
+	}
+
+	field := func(key, label, kind string, unit *string, better string, value func(models.GearComparisonItem) interface{}) {
+		values := make([]interface{}, len(comparison.Gear))
+		for i, item := range comparison.Gear {
+			values[i] = value(item)
+		}
+		comparison.Fields = append(comparison.Fields, compareField(key, label, kind, unit, better, values, lightest))
+	}
+	optionalText := func(text *string) interface{} {
+		if text == nil {
+			return nil
+		}
+		return *text
+	}
+	positive := func(number int32) interface{} {
+		if number <= 0 {
+			return nil
+		}
+		return float64(number)
+	}
+
+	field("manufacture_name", "Manufacturer", SpecTypeText, nil, "", func(item models.GearComparisonItem) interface{} { return optionalText(item.ManufactureName) })
+	field("top_category_name", "Top category", SpecTypeText, nil, "", func(item models.GearComparisonItem) interface{} { return optionalText(item.TopCategoryName) })
+	field("category_name", "Category", SpecTypeText, nil, "", func(item models.GearComparisonItem) interface{} { return optionalText(item.CategoryName) })
+	field("gear_weight", "Weight", SpecTypeNumber, &gramsUnit, CompareLower, func(item models.GearComparisonItem) interface{} { return positive(item.GearWeight) })
+	field("gear_height", "Height", SpecTypeNumber, &millimetresUnit, "", func(item models.GearComparisonItem) interface{} { return positive(item.GearHeight) })
+	field("gear_length", "Length", SpecTypeNumber, &millimetresUnit, "", func(item models.GearComparisonItem) interface{} { return positive(item.GearLength) })
+	field("gear_width", "Width", SpecTypeNumber, &millimetresUnit, "", func(item models.GearComparisonItem) interface{} { return positive(item.GearWidth) })
+	field("gear_rating_average", "Rating", SpecTypeNumber, nil, CompareHigher, func(item models.GearComparisonItem) interface{} {
+		if item.GearRatingAverage == nil {
+			return nil
+		}
+		return *item.GearRatingAverage
+	})
+	field("gear_review_count", "Reviews", SpecTypeNumber, nil, CompareHigher, func(item models.GearComparisonItem) interface{} { return float64(item.GearReviewCount) })
+	field("owner_count", "Owners", SpecTypeNumber, nil, CompareHigher, func(item models.GearComparisonItem) interface{} { return float64(item.OwnerCount) })
+
+	// Spec attributes of every compared category, once per name, in schema order.
+	seen := make(map[string]bool)
+	seenCategory := make(map[int64]bool)
+	for _, item := range comparison.Gear {
+		if seenCategory[item.GearCategoryID] {
+			continue
+		}
+		seenCategory[item.GearCategoryID] = true
+
+		schema, err := SpecSchema(db, item.GearCategoryID)
+		if err != nil {
+			return nil, err
+		}
+		for _, attribute := range schema {
+			if seen[attribute.Name] {
+				continue
+			}
+			seen[attribute.Name] = true
+
+			kind := attribute.Type
+			if kind == SpecTypeEnum {
+				kind = SpecTypeText
+			}
+			name := attribute.Name
+			field("spec."+name, attribute.Label, kind, attribute.Unit, "", func(item models.GearComparisonItem) interface{} { return item.Specs[name] })
+		}
+	}
+
+	return comparison, nil
+}
+
+// compareField builds a comparison row from one value per item. Numeric rows get deltas
+// against the item at index lightest, and the best values marked when better is set.
+func compareField(key, label, kind string, unit *string, better string, values []interface{}, lightest int) models.GearComparisonField {
+	field := models.GearComparisonField{Key: key, Label: label, Type: kind, Unit: unit, Better: better,
+		Values: make([]models.GearComparisonValue, len(values))}
+
+	var best *float64
+	for i, value := range values {
+		field.Values[i].Value = value
+		number, ok := value.(float64)
+		if !ok {
+			continue
+		}
+		if lightest >= 0 {
+			if base, ok := values[lightest].(float64); ok {
+				delta := number - base
+				field.Values[i].Delta = &delta
+			}
+		}
+		if better != "" && (best == nil || (better == CompareLower && number < *best) || (better == CompareHigher && number > *best)) {
+			best = &number
+		}
+	}
+
+	if best != nil {
+		for i, value := range values {
+			if number, ok := value.(float64); ok && number == *best {
+				field.Values[i].Best = true
+			}
+		}
+	}
+	return field
+}
@@ -0,0 +1,117 @@
+package utils
+
+import (
+	"database/sql"
+	"fmt"
+
+	models "github.com/Sea-Shell/gogear-api/pkg/models"
+)
+
+// Deletion describes a catalog table that gear, and for top categories categories,
+// reference by ID.
+type Deletion struct {
+	Name           string
+	Table          string
+	Key            string
+	GearColumn     string
+	CategoryColumn string
+}
+
+// Catalog tables that refuse deletion while referenced.
+var (
+	CategoryDeletion    = Deletion{Name: "category", Table: "gear_category", Key: "categoryId", GearColumn: "gearCategoryId"}
+	TopCategoryDeletion = Deletion{Name: "top category", Table: "gear_top_category", Key: "topCategoryId", GearColumn: "gearTopCategoryId", CategoryColumn: "categoryTopCategoryId"}
+	ManufactureDeletion = Deletion{Name: "manufacture", Table: "manufacture", Key: "manufactureId", GearColumn: "gearManufactureId"}
+)
+
+// DependentsError reports a delete refused because rows still reference the target.
+type DependentsError struct {
+	Dependents models.DependentsError
+}
+
+func (e *DependentsError) Error() string {
+	return e.Dependents.Error
+}
+
+// ReassignError reports an invalid reassign_to target.
+type ReassignError struct {
+	Message string
+}
+
+func (e *ReassignError) Error() string {
+	return e.Message
+}
+
+// DeleteReassigning deletes a row of d.Table in one transaction. Without reassignTo the
+// delete is refused with a DependentsError while gear or categories reference the row;
+// with it they are moved to reassignTo first. Categories moved to another top category
+// keep their subtrees and are placed after its own. It returns the counts of what was
+// moved, or sql.ErrNoRows when the row does not exist.
+func DeleteReassigning(db *sql.DB, d Deletion, id int64, reassignTo *int64) (*models.DependentsError, error) {
+	tx, err := db.Begin()
+	if err != nil {
+		return nil, err
+	}
+	defer tx.Rollback()
+
+	exists := fmt.Sprintf("SELECT EXISTS (SELECT 1 FROM %s WHERE %s = ?)", d.Table, d.Key)
+	var found bool
+	if err := tx.QueryRow(exists, id).Scan(&found); err != nil {
+		return nil, fmt.Errorf("check %s: %w", d.Name, err)
+	}
+	if !found {
+		return nil, sql.ErrNoRows
+	}
+
+	dependents := &models.DependentsError{}
+	if err := tx.QueryRow(fmt.Sprintf("SELECT COUNT(*) FROM gear WHERE %s = ?", d.GearColumn), id).Scan(&dependents.GearCount); err != nil {
+		return nil, fmt.Errorf("count gear of %s: %w", d.Name, err)
+	}
+	if d.CategoryColumn != "" {
+		err := tx.QueryRow(fmt.Sprintf("SELECT COUNT(*) FROM gear_category WHERE %s = ?", d.CategoryColumn), id).Scan(&dependents.CategoryCount)
+		if err != nil {
+			return nil, fmt.Errorf("count categories of %s: %w", d.Name, err)
+		}
+	}
+
+	if reassignTo == nil {
+		if dependents.GearCount > 0 || dependents.CategoryCount > 0 {
+			dependents.Error = fmt.Sprintf("%s is still used by %d gear and %d categories; give reassign_to to move them",
+				d.Name, dependents.GearCount, dependents.CategoryCount)
+			return nil, &DependentsError{Dependents: *dependents}
+		}
+	} else {
+		if *reassignTo == id {
+			return nil, &ReassignError{Message: fmt.Sprintf("reassign_to cannot be the %s being deleted", d.Name)}
+		}
+		if err := tx.QueryRow(exists, *reassignTo).Scan(&found); err != nil {
+			return nil, fmt.Errorf("check %s: %w", d.Name, err)
+		}
+		if !found {
+			return nil, &ReassignError{Message: fmt.Sprintf("reassign_to %s %d does not exist", d.Name, *reassignTo)}
+		}
+
+		if d.CategoryColumn != "" {
+			if _, err := tx.Exec(`UPDATE gear_category SET categorySortOrder = categorySortOrder + (
+                    SELECT COALESCE(MAX(categorySortOrder) + 1, 0) FROM gear_category
+                    WHERE categoryParentId IS NULL AND categoryTopCategoryId = ?)
+                WHERE categoryParentId IS NULL AND categoryTopCategoryId = ?`, *reassignTo, id); err != nil {
+				return nil, fmt.Errorf("sort reassigned categories: %w", err)
+			}
+			if _, err := tx.Exec(fmt.Sprintf("UPDATE gear_category SET %s = ? WHERE %s = ?", d.CategoryColumn, d.CategoryColumn), *reassignTo, id); err != nil {
+				return nil, fmt.Errorf("reassign categories: %w", err)
+			}
+		}
+		if _, err := tx.Exec(fmt.Sprintf("UPDATE gear SET %s = ? WHERE %s = ?", d.GearColumn, d.GearColumn), *reassignTo, id); err != nil {
+			return nil, fmt.Errorf("reassign gear: %w", err)
+		}
+	}
+
+	if _, err := tx.Exec(fmt.Sprintf("DELETE FROM %s WHERE %s = ?", d.Table, d.Key), id); err != nil {
+		return nil, fmt.Errorf("delete %s: %w", d.Name, err)
+	}
+	if err := tx.Commit(); err != nil {
+		return nil, err
+	}
+	return dependents, nil
+}
@@ -0,0 +1,311 @@
+package utils
+
+import (
+	"database/sql"
+	"fmt"
+	"math"
+	"sort"
+	"strings"
+	"unicode"
+
+	models "github.com/Sea-Shell/gogear-api/pkg/models"
+)
+
+// Weights of the duplicate score. Name similarity dominates; a shared manufacturer and a
+// close weight each add the rest.
+const (
+	duplicateNameWeight         = 0.6
+	duplicateManufactureWeight  = 0.2
+	duplicateWeightWeight       = 0.2
+	duplicateMinBlockTokenRunes = 3
+)
+
+// GearMergeError reports a merge that cannot be done.
+type GearMergeError struct {
+	Message string
+}
+
+func (e *GearMergeError) Error() string {
+	return e.Message
+}
+
+// capacityWords are spellings of the capacity suffix in names like "Hornet 2P".
+var capacityWords = map[string]bool{"p": true, "person": true, "persons": true, "people": true, "personer": true}
+
+// NormalizeGearName reduces a gear name to comparable words: lowercase, punctuation
+// dropped, numbers split from letters, words of the manufacturer's name removed and
+// capacity spellings such as "2P" and "2 person" made equal.
+func NormalizeGearName(name string, manufacturer string) string {
+	drop := make(map[string]bool)
+	for _, word := range SearchTerms(manufacturer) {
+		drop[word] = true
+	}
+
+	var words []string
+	for _, term := range SearchTerms(name) {
+		for _, word := range splitDigits(term) {
+			if drop[word] {
+				continue
+			}
+			if capacityWords[word] {
+				word = "p"
+			}
+			words = append(words, word)
+		}
+	}
+	return strings.Join(words, " ")
+}
+
+// splitDigits splits a word where it changes between digits and letters.
+func splitDigits(word string) []string {
+	var parts []string
+	start := 0
+	runes := []rune(word)
+	for i := 1; i < len(runes); i++ {
+		if unicode.IsDigit(runes[i]) != unicode.IsDigit(runes[i-1]) {
+			parts = append(parts, string(runes[start:i]))
+			start = i
+		}
+	}
+	return append(parts, string(runes[start:]))
+}
+
+// nameSimilarity is the Dice coefficient of the trigrams of two normalized names.
+func nameSimilarity(a, b map[string]struct{}) float64 {
+	if len(a) == 0 || len(b) == 0 {
+		return 0
+	}
+	shared := 0
+	for trigram := range a {
+		if _, ok := b[trigram]; ok {
+			shared++
+		}
+	}
+	return 2 * float64(shared) / float64(len(a)+len(b))
+}
+
+// weightSimilarity is 1 for equal weights, falling to 0 as one doubles the other. Gear
+// without a weight says nothing either way.
+func weightSimilarity(a, b int32) float64 {
+	if a <= 0 || b <= 0 {
+		return 0
+	}
+	heavier := math.Max(float64(a), float64(b))
+	return math.Max(0, 1-math.Abs(float64(a)-float64(b))/heavier)
+}
+
+type duplicateCandidate struct {
+	gear     models.GearDuplicateCandidate
+	trigrams map[string]struct{}
+}
+
+// GearDuplicates suggests pairs of gear that likely describe the same product, best
+// match first. Only pairs sharing a manufacturer or a name word are compared, and only
+// those scoring at least minScore are returned, at most limit of them.
+func GearDuplicates(db *sql.DB, minScore float64, limit int) ([]models.GearDuplicate, error) {
+	rows, err := db.Query(`SELECT gear.gearId, gear.gearName, gear.gearManufactureId, manufacture.manufactureName,
+            gear.gearWeight, COALESCE(gear.gearStatus, 1),
+            (SELECT COUNT(*) FROM user_gear_registrations WHERE user_gear_registrations.gearId = gear.gearId) AS registrationCount
+        FROM gear LEFT JOIN manufacture ON gear.gearManufactureId = manufacture.manufactureId
+        ORDER BY gear.gearId`)
+	if err != nil {
+		return nil, fmt.Errorf("query gear: %w", err)
+	}
+	gear, err := ScanRows[models.GearDuplicateCandidate](rows)
+	rows.Close()
+	if err != nil {
+		return nil, fmt.Errorf("scan gear: %w", err)
+	}
+
+	candidates := make([]duplicateCandidate, len(gear))
+	blocks := make(map[string][]int)
+	for i, item := range gear {
+		manufacturer := ""
+		if item.ManufactureName != nil {
+			manufacturer = *item.ManufactureName
+		}
+		name := NormalizeGearName(item.GearName, manufacturer)
+		candidates[i] = duplicateCandidate{gear: item, trigrams: Trigrams(name)}
+
+		keys := map[string]bool{fmt.Sprintf("m:%d", item.GearManufactureID): true}
+		for _, word := range strings.Fields(name) {
+			if len([]rune(word)) >= duplicateMinBlockTokenRunes {
+				keys["w:"+word] = true
+			}
+		}
+		for key := range keys {
+			blocks[key] = append(blocks[key], i)
+		}
+	}
+
+	compared := make(map[[2]int]bool)
+	duplicates := []models.GearDuplicate{}
+	for _, block := range blocks {
+		for x := 0; x < len(block); x++ {
+			for y := x + 1; y < len(block); y++ {
+				pair := [2]int{block[x], block[y]}
+				if compared[pair] {
+					continue
+				}
+				compared[pair] = true
+
+				a, b := candidates[pair[0]], candidates[pair[1]]
+				score := duplicateNameWeight*nameSimilarity(a.trigrams, b.trigrams) +
+					duplicateWeightWeight*weightSimilarity(a.gear.GearWeight, b.gear.GearWeight)
+				if a.gear.GearManufactureID == b.gear.GearManufactureID {
+					score += duplicateManufactureWeight
+				}
+				score = math.Round(score*1000) / 1000
+				if score < minScore {
+					continue
+				}
+
+				if b.gear.RegistrationCount > a.gear.RegistrationCount {
+					a, b = b, a
+				}
+				duplicates = append(duplicates, models.GearDuplicate{Score: score, Gear: a.gear, Duplicate: b.gear})
+			}
+		}
+	}
+
+	sort.Slice(duplicates, func(i, j int) bool {
+		if duplicates[i].Score != duplicates[j].Score {
+			return duplicates[i].Score > duplicates[j].Score
+		}
+		if duplicates[i].Gear.GearID != duplicates[j].Gear.GearID {
+			return duplicates[i].Gear.GearID < duplicates[j].Gear.GearID
+		}
+		return duplicates[i].Duplicate.GearID < duplicates[j].Duplicate.GearID
+	})
+	if len(duplicates) > limit {
+		duplicates = duplicates[:limit]
+	}
+	return duplicates, nil
+}
+
+// MergeGear folds loserID into winnerID in one transaction. Registrations, loadout items,
+// variants, images, tags, reviews and spec values of the loser move to the winner, the loser is
+// deleted and a redirect is left so its ID resolves to the winner. Variants named like
+// one of the winner's are folded into that variant. Loadout weights are not recalculated;
+// the result lists the loadouts that need it. It returns sql.ErrNoRows when either gear
+// does not exist.
+func MergeGear(db *sql.DB, loserID int64, winnerID int64, mergedBy int64) (*models.GearMergeResult, error) {
+	if loserID == winnerID {
+		return nil, &GearMergeError{Message: "gear cannot be merged into itself"}
+	}
+
+	tx, err := db.Begin()
+	if err != nil {
+		return nil, err
+	}
+	defer tx.Rollback()
+
+	var found int
+	if err := tx.QueryRow("SELECT COUNT(*) FROM gear WHERE gearId IN (?, ?)", loserID, winnerID).Scan(&found); err != nil {
+		return nil, fmt.Errorf("check gear: %w", err)
+	}
+	if found != 2 {
+		return nil, sql.ErrNoRows
+	}
+
+	loadoutIDs, err := queryIDs(tx, "SELECT DISTINCT loadoutId FROM loadout_items WHERE gearId = ? ORDER BY loadoutId", loserID)
+	if err != nil {
+		return nil, err
+	}
+
+	// Variants sharing a name with one of the winner's are replaced by it; the rest move.
+	statements := []string{
+		`UPDATE user_gear_registrations SET variantId = (
+                SELECT winner.variantId FROM gear_variants loser
+                JOIN gear_variants winner ON winner.gearId = ? AND winner.variantName = loser.variantName
+                WHERE loser.variantId = user_gear_registrations.variantId)
+            WHERE gearId = ? AND variantId IN (SELECT loser.variantId FROM gear_variants loser
+                JOIN gear_variants winner ON winner.gearId = ? AND winner.variantName = loser.variantName
+                WHERE loser.gearId = ?)`,
+		`UPDATE loadout_items SET variantId = (
+                SELECT winner.variantId FROM gear_variants loser
+                JOIN gear_variants winner ON winner.gearId = ? AND winner.variantName = loser.variantName
+                WHERE loser.variantId = loadout_items.variantId)
+            WHERE gearId = ? AND variantId IN (SELECT loser.variantId FROM gear_variants loser
+                JOIN gear_variants winner ON winner.gearId = ? AND winner.variantName = loser.variantName
+                WHERE loser.gearId = ?)`,
+	}
+	for _, statement := range statements {
+		if _, err := tx.Exec(statement, winnerID, loserID, winnerID, loserID); err != nil {
+			return nil, fmt.Errorf("merge variants: %w", err)
+		}
+	}
+	if _, err := tx.Exec(`UPDATE gear_variants SET gearId = ? WHERE gearId = ? AND variantName NOT IN (
+            SELECT variantName FROM gear_variants WHERE gearId = ?)`, winnerID, loserID, winnerID); err != nil {
+		return nil, fmt.Errorf("move variants: %w", err)
+	}
+
+	result := &models.GearMergeResult{WinnerID: winnerID, LoserID: loserID, LoadoutIDs: loadoutIDs}
+	moved, err := tx.Exec("UPDATE user_gear_registrations SET gearId = ? WHERE gearId = ?", winnerID, loserID)
+	if err != nil {
+		return nil, fmt.Errorf("move registrations: %w", err)
+	}
+	if result.RegistrationsMoved, err = moved.RowsAffected(); err != nil {
+		return nil, err
+	}
+	moved, err = tx.Exec("UPDATE loadout_items SET gearId = ? WHERE gearId = ?", winnerID, loserID)
+	if err != nil {
+		return nil, fmt.Errorf("move loadout items: %w", err)
+	}
+	if result.LoadoutItemsMoved, err = moved.RowsAffected(); err != nil {
+		return nil, err
+	}
+
+	for _, statement := range []string{
+		"UPDATE images SET gearId = ? WHERE gearId = ?",
+		"INSERT OR IGNORE INTO gear_tags (gearId, tagName, createdAt) SELECT ?, tagName, createdAt FROM gear_tags WHERE gearId = ?",
+		"UPDATE gear_redirects SET toGearId = ? WHERE toGearId = ?",
+		// A user who reviewed both keeps the review of the winner.
+		"UPDATE OR IGNORE gear_reviews SET gearId = ? WHERE gearId = ?",
+		// Translations fill in locales the winner is not translated to yet.
+		"UPDATE OR IGNORE gear_translations SET gearId = ? WHERE gearId = ?",
+	} {
+		if _, err := tx.Exec(statement, winnerID, loserID); err != nil {
+			return nil, fmt.Errorf("merge gear: %w", err)
+		}
+	}
+
+	// Spec values only carry over where the attribute also applies to the winner's category.
+	if _, err := tx.Exec(`INSERT OR IGNORE INTO gear_spec_values (gearId, attributeId, valueNumber, valueText)
+            SELECT winner.gearId, gear_spec_values.attributeId, gear_spec_values.valueNumber, gear_spec_values.valueText
+            FROM gear_spec_values
+            JOIN category_spec_attributes ON category_spec_attributes.attributeId = gear_spec_values.attributeId
+            JOIN gear winner ON winner.gearId = ? AND winner.gearCategoryId = category_spec_attributes.categoryId
+            WHERE gear_spec_values.gearId = ?`, winnerID, loserID); err != nil {
+		return nil, fmt.Errorf("merge spec values: %w", err)
+	}
+
+	// The delete triggers clean up what was not moved: folded variants, tags, reviews,
+	// translations and spec values.
+	if _, err := tx.Exec("DELETE FROM gear WHERE gearId = ?", loserID); err != nil {
+		return nil, fmt.Errorf("delete merged gear: %w", err)
+	}
+	if _, err := tx.Exec("INSERT OR REPLACE INTO gear_redirects (fromGearId, toGearId, mergedBy) VALUES (?, ?, ?)",
+		loserID, winnerID, mergedBy); err != nil {
+		return nil, fmt.Errorf("insert gear redirect: %w", err)
+	}
+
+	if err := tx.Commit(); err != nil {
+		return nil, err
+	}
+	return result, nil
+}
+
+// ResolveGearRedirect returns the gear a merged gear ID now points to, and false when
+// gearID was never merged.
+func ResolveGearRedirect(db *sql.DB, gearID int64) (int64, bool, error) {
+	var target int64
+	err := db.QueryRow("SELECT toGearId FROM gear_redirects WHERE fromGearId = ?", gearID).Scan(&target)
+	if err == sql.ErrNoRows {
+		return gearID, false, nil
+	}
+	if err != nil {
+		return 0, false, fmt.Errorf("resolve gear redirect: %w", err)
+	}
+	return target, true, nil
+}
@@ -0,0 +1,21 @@
+package utils
+
+import "testing"
+
+func TestNormalizeGearName(t *testing.T) {
+	cases := []struct {
+		name         string
+		manufacturer string
+		want         string
+	}{
+		{"Nemo Hornet 2P", "Nemo", "hornet 2 p"},
+		{"NEMO Hornet 2 person", "Nemo", "hornet 2 p"},
+		{"Hornet OSMO 2-Persons", "NEMO Equipment", "hornet osmo 2 p"},
+		{"PocketRocket2", "MSR", "pocketrocket 2"},
+	}
+	for _, tc := range cases {
+		if got := NormalizeGearName(tc.name, tc.manufacturer); got != tc.want {
+			t.Errorf("NormalizeGearName(%q, %q) = %q, want %q", tc.name, tc.manufacturer, got, tc.want)
+		}
+	}
+}
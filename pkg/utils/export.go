@@ -0,0 +1,135 @@
+package utils
+
+import (
+	"bufio"
+	"database/sql"
+	"encoding/csv"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/url"
+	"reflect"
+	"strings"
+
+	models "github.com/Sea-Shell/gogear-api/pkg/models"
+
+	yaml "github.com/goccy/go-yaml"
+)
+
+// Export formats.
+const (
+	ExportFormatCSV   = "csv"
+	ExportFormatJSONL = "jsonl"
+	ExportFormatYAML  = "yaml"
+)
+
+// ExportContentTypes maps each export format to the Content-Type it is served with.
+var ExportContentTypes = map[string]string{
+	ExportFormatCSV:   "text/csv",
+	ExportFormatJSONL: "application/x-ndjson",
+	ExportFormatYAML:  "application/yaml",
+}
+
+// ExportGear streams the gear catalog, joined with manufacturer and category names and
+// filtered as by FilterGear, to w. The output can be fed back to ImportGear.
+func ExportGear(db *sql.DB, values url.Values, format string, w io.Writer) error {
+	if _, ok := ExportContentTypes[format]; !ok {
+		return importInputErrorf("Invalid format %q. Use csv, jsonl or yaml.", format)
+	}
+
+	// Gear columns may be NULL and joined rows may be missing; FullGear has no pointers.
+	query := NewListQuery[models.FullGear]("gear" + GearJoins).CoalesceNulls()
+	if err := FilterGear(query, values); err != nil {
+		return err
+	}
+
+	return ExportRows(db, query, format, w)
+}
+
+// ExportRows streams every row of query in primary key order to w as CSV with a header
+// of json field names, JSON lines, or a YAML sequence.
+func ExportRows[model any](db *sql.DB, query *ListQuery[model], format string, w io.Writer) error {
+	buffered := bufio.NewWriter(w)
+	flush := buffered.Flush
+
+	var write func(item model) error
+	switch format {
+	case ExportFormatCSV:
+		csvWriter := csv.NewWriter(buffered)
+		names := exportFieldNames[model]()
+		if err := csvWriter.Write(names); err != nil {
+			return err
+		}
+		write = func(item model) error {
+			return csvWriter.Write(exportRecord(item, len(names)))
+		}
+		flush = func() error {
+			csvWriter.Flush()
+			if err := csvWriter.Error(); err != nil {
+				return err
+			}
+			return buffered.Flush()
+		}
+	case ExportFormatJSONL:
+		encoder := json.NewEncoder(buffered)
+		write = func(item model) error {
+			return encoder.Encode(item)
+		}
+	case ExportFormatYAML:
+		write = func(item model) error {
+			document, err := yaml.Marshal(item)
+			if err != nil {
+				return err
+			}
+			// Indent each mapping under a sequence entry.
+			entry := "- " + strings.ReplaceAll(strings.TrimRight(string(document), "\n"), "\n", "\n  ") + "\n"
+			_, err = buffered.WriteString(entry)
+			return err
+		}
+	default:
+		return importInputErrorf("Invalid format %q. Use csv, jsonl or yaml.", format)
+	}
+
+	if err := query.Each(db, write); err != nil {
+		return err
+	}
+	return flush()
+}
+
+// exportFieldNames returns the json names of the exported, db-backed fields of model.
+func exportFieldNames[model any]() []string {
+	var params model
+	modelType := reflect.TypeOf(params)
+
+	var names []string
+	for i := 0; i < modelType.NumField(); i++ {
+		name := strings.Split(modelType.Field(i).Tag.Get("json"), ",")[0]
+		if name == "" || name == "-" || modelType.Field(i).Tag.Get("db") == "" || !modelType.Field(i).IsExported() {
+			continue
+		}
+		names = append(names, name)
+	}
+	return names
+}
+
+// exportRecord formats the exported fields of item as CSV cells; nil pointers are empty.
+func exportRecord[model any](item model, size int) []string {
+	itemValue := reflect.ValueOf(item)
+	itemType := itemValue.Type()
+
+	record := make([]string, 0, size)
+	for i := 0; i < itemType.NumField(); i++ {
+		name := strings.Split(itemType.Field(i).Tag.Get("json"), ",")[0]
+		if name == "" || name == "-" || itemType.Field(i).Tag.Get("db") == "" || !itemType.Field(i).IsExported() {
+			continue
+		}
+
+		value := fieldValue(itemValue.Field(i))
+		if value == nil {
+			record = append(record, "")
+			continue
+		}
+		record = append(record, fmt.Sprint(value))
+	}
+	return record
+}
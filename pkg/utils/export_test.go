@@ -0,0 +1,100 @@
+package utils
+
+import (
+	"bytes"
+	"database/sql"
+	"encoding/json"
+	"net/url"
+	"strings"
+	"testing"
+
+	models "github.com/Sea-Shell/gogear-api/pkg/models"
+)
+
+func exportTestDB(t *testing.T) *sql.DB {
+	t.Helper()
+	db := importTestDB(t)
+	execTestStatements(t, db,
+		`INSERT INTO gear (gearId, gearTopCategoryId, gearCategoryId, gearManufactureId, gearIsContainer, gearName, gearSizeDefinition,
+            gearWeight, gearHeight, gearLength, gearWidth, gearStatus) VALUES (2, 1, 1, 1, 1, 'Hubba, "Hubba"', '42', 1500, 100, 200, 300, 0)`,
+	)
+	return db
+}
+
+func TestExportGearRoundTripsThroughImport(t *testing.T) {
+	for _, format := range []string{ExportFormatCSV, ExportFormatJSONL, ExportFormatYAML} {
+		t.Run(format, func(t *testing.T) {
+			source := exportTestDB(t)
+
+			var exported bytes.Buffer
+			if err := ExportGear(source, url.Values{}, format, &exported); err != nil {
+				t.Fatalf("export: %v", err)
+			}
+
+			target := exportTestDB(t)
+			// Reset the AUTOINCREMENT sequence too, so the target hands out the same IDs as an empty catalog.
+			execTestStatements(t, target, "DELETE FROM gear", "DELETE FROM sqlite_sequence WHERE name = 'gear'")
+
+			report, err := ImportGear(target, bytes.NewReader(exported.Bytes()), format, models.GearImportOptions{})
+			if err != nil {
+				t.Fatalf("import: %v\n%s", err, exported.String())
+			}
+			if !report.Committed || report.Created != 2 {
+				t.Fatalf("report = %+v\n%s", report, exported.String())
+			}
+
+			var reexported bytes.Buffer
+			if err := ExportGear(target, url.Values{}, format, &reexported); err != nil {
+				t.Fatalf("re-export: %v", err)
+			}
+			if reexported.String() != exported.String() {
+				t.Errorf("re-export differs:\n%s\nwant:\n%s", reexported.String(), exported.String())
+			}
+
+			report, err = ImportGear(source, bytes.NewReader(exported.Bytes()), format, models.GearImportOptions{OnDuplicate: OnDuplicateUpdate})
+			if err != nil || report.Updated != 2 || report.Errors != 0 {
+				t.Errorf("import into source = %+v, %v", report, err)
+			}
+		})
+	}
+}
+
+func TestExportGearFiltersAndValidates(t *testing.T) {
+	db := exportTestDB(t)
+
+	var exported bytes.Buffer
+	if err := ExportGear(db, url.Values{"container": {"true"}}, ExportFormatCSV, &exported); err != nil {
+		t.Fatalf("export: %v", err)
+	}
+	lines := strings.Split(strings.TrimSpace(exported.String()), "\n")
+	if len(lines) != 2 || !strings.HasPrefix(lines[0], "gear_id,") || !strings.Contains(lines[1], `"Hubba, ""Hubba"""`) {
+		t.Errorf("filtered export = %q", exported.String())
+	}
+
+	if err := ExportGear(db, url.Values{}, "xml", &exported); err == nil {
+		t.Error("expected error for unknown format")
+	}
+	if err := ExportGear(db, url.Values{"container": {"maybe"}}, ExportFormatJSONL, &exported); err == nil {
+		t.Error("expected error for invalid filter")
+	}
+}
+
+func TestExportGearWritesZeroValuesForNullColumnsAndMissingJoins(t *testing.T) {
+	db := exportTestDB(t)
+	execTestStatements(t, db,
+		`INSERT INTO gear (gearId, gearTopCategoryId, gearCategoryId, gearManufactureId, gearName) VALUES (3, 1, 1, 99, 'Orphan')`,
+	)
+
+	var exported bytes.Buffer
+	if err := ExportGear(db, url.Values{"gear_id": {"3"}}, ExportFormatJSONL, &exported); err != nil {
+		t.Fatalf("export: %v", err)
+	}
+
+	var gear models.FullGear
+	if err := json.Unmarshal(exported.Bytes(), &gear); err != nil {
+		t.Fatalf("decode %q: %v", exported.String(), err)
+	}
+	if gear.GearName != "Orphan" || gear.GearWeight != 0 || gear.ManufactureID != 0 || gear.ManufactureName != "" || gear.CategoryName != "Tents" {
+		t.Errorf("exported gear = %+v", gear)
+	}
+}
@@ -0,0 +1,45 @@
+package utils
+
+import (
+	"net/url"
+	"strconv"
+)
+
+// GearJoins joins gear with its manufacturer, top category, category and first image.
+const GearJoins = ` LEFT JOIN manufacture ON gear.gearManufactureId = manufacture.manufactureId
+        LEFT JOIN gear_top_category ON gear.gearTopCategoryId = gear_top_category.topCategoryId
+        LEFT JOIN gear_category ON gear.gearCategoryId = gear_category.categoryId` + GearImageJoin
+
+// GearImageJoin joins gear with the first image uploaded for it, as gear_image.
+const GearImageJoin = `
+        LEFT JOIN images AS gear_image ON gear_image.imageId = (SELECT MIN(imageId) FROM images WHERE images.gearId = gear.gearId)`
+
+// RegistrationImageJoin joins user gear registrations with the first image uploaded for
+// them, as registration_image.
+const RegistrationImageJoin = `
+        LEFT JOIN images AS registration_image ON registration_image.imageId = (SELECT MIN(imageId) FROM images WHERE images.userGearRegistrationId = user_gear_registrations.userGearRegistrationId)`
+
+// FilterGear adds the category, topCategory, manufacturer and container filters shared
+// by the gear list, search and export, and the per-field filters. category matches gear
+// in the category and all categories below it.
+func FilterGear[model any](query *ListQuery[model], values url.Values) error {
+	if topCategory := values.Get("topCategory"); topCategory != "" {
+		query.Where("gear.gearTopCategoryId = ?", topCategory)
+	}
+	if category := values.Get("category"); category != "" {
+		condition, args := CategorySubtreeCondition("gear.gearCategoryId", category)
+		query.Where(condition, args...)
+	}
+	if manufacturer := values.Get("manufacturer"); manufacturer != "" {
+		query.Where("gear.gearManufactureId = ?", manufacturer)
+	}
+	if container, hasContainer := values["container"]; hasContainer {
+		containerBool, err := strconv.ParseBool(container[0])
+		if err != nil {
+			return &ListQueryError{Message: "container must be true or false"}
+		}
+		query.Where("gear.gearIsContainer = ?", containerBool)
+	}
+
+	return query.FilterFields(values)
+}
@@ -0,0 +1,131 @@
+package utils
+
+import (
+	"bytes"
+	"fmt"
+	"image"
+	"image/color"
+	_ "image/gif" // registers the GIF decoder
+	"image/jpeg"
+	"image/png"
+	"net/http"
+)
+
+// Limits applied to uploaded images.
+const (
+	ThumbnailSize    = 320
+	maxImagePixels   = 40_000_000
+	thumbnailQuality = 85
+)
+
+// imageExtensions are the accepted image content types, as sniffed from the upload, and the
+// extension their files are stored with. Decoders for each are registered by the imports.
+var imageExtensions = map[string]string{
+	"image/jpeg": ".jpg",
+	"image/png":  ".png",
+	"image/gif":  ".gif",
+}
+
+// ImageError reports an upload that is not an image this API accepts.
+type ImageError struct {
+	Message string
+}
+
+func (e *ImageError) Error() string {
+	return e.Message
+}
+
+// ProcessedImage describes an accepted upload and holds its generated thumbnail.
+type ProcessedImage struct {
+	ContentType          string
+	Extension            string
+	Width                int
+	Height               int
+	Thumbnail            []byte
+	ThumbnailContentType string
+	ThumbnailExtension   string
+}
+
+// ProcessImage sniffs the content type of data, which must be a JPEG, PNG or GIF, and
+// renders a thumbnail at most ThumbnailSize pixels on its longest side. JPEGs get a JPEG
+// thumbnail and the rest PNG, which keeps transparency.
+func ProcessImage(data []byte) (*ProcessedImage, error) {
+	contentType := http.DetectContentType(data)
+	extension, ok := imageExtensions[contentType]
+	if !ok {
+		return nil, &ImageError{Message: fmt.Sprintf("unsupported image type %s; upload a JPEG, PNG or GIF", contentType)}
+	}
+
+	config, _, err := image.DecodeConfig(bytes.NewReader(data))
+	if err != nil {
+		return nil, &ImageError{Message: "image could not be decoded: " + err.Error()}
+	}
+	if config.Width*config.Height > maxImagePixels {
+		return nil, &ImageError{Message: fmt.Sprintf("image is %dx%d; the limit is %d megapixels", config.Width, config.Height, maxImagePixels/1_000_000)}
+	}
+
+	decoded, _, err := image.Decode(bytes.NewReader(data))
+	if err != nil {
+		return nil, &ImageError{Message: "image could not be decoded: " + err.Error()}
+	}
+
+	processed := &ProcessedImage{
+		ContentType: contentType,
+		Extension:   extension,
+		Width:       config.Width,
+		Height:      config.Height,
+	}
+
+	var thumbnail bytes.Buffer
+	scaled := Thumbnail(decoded, ThumbnailSize)
+	if contentType == "image/jpeg" {
+		err = jpeg.Encode(&thumbnail, scaled, &jpeg.Options{Quality: thumbnailQuality})
+		processed.ThumbnailContentType, processed.ThumbnailExtension = "image/jpeg", ".jpg"
+	} else {
+		err = png.Encode(&thumbnail, scaled)
+		processed.ThumbnailContentType, processed.ThumbnailExtension = "image/png", ".png"
+	}
+	if err != nil {
+		return nil, fmt.Errorf("encode thumbnail: %w", err)
+	}
+	processed.Thumbnail = thumbnail.Bytes()
+
+	return processed, nil
+}
+
+// Thumbnail scales src down to fit in a size by size square, averaging the source pixels
+// that fall into each thumbnail pixel. Images that already fit are copied unscaled.
+func Thumbnail(src image.Image, size int) *image.NRGBA {
+	bounds := src.Bounds()
+	width, height := bounds.Dx(), bounds.Dy()
+
+	thumbWidth, thumbHeight := width, height
+	if width > size || height > size {
+		if width >= height {
+			thumbWidth, thumbHeight = size, max(1, height*size/width)
+		} else {
+			thumbWidth, thumbHeight = max(1, width*size/height), size
+		}
+	}
+
+	dst := image.NewNRGBA(image.Rect(0, 0, thumbWidth, thumbHeight))
+	for y := 0; y < thumbHeight; y++ {
+		y0 := bounds.Min.Y + y*height/thumbHeight
+		y1 := max(y0+1, bounds.Min.Y+(y+1)*height/thumbHeight)
+		for x := 0; x < thumbWidth; x++ {
+			x0 := bounds.Min.X + x*width/thumbWidth
+			x1 := max(x0+1, bounds.Min.X+(x+1)*width/thumbWidth)
+
+			var r, g, b, a, n uint64
+			for sy := y0; sy < y1; sy++ {
+				for sx := x0; sx < x1; sx++ {
+					pr, pg, pb, pa := src.At(sx, sy).RGBA()
+					r, g, b, a = r+uint64(pr), g+uint64(pg), b+uint64(pb), a+uint64(pa)
+					n++
+				}
+			}
+			dst.Set(x, y, color.RGBA64{R: uint16(r / n), G: uint16(g / n), B: uint16(b / n), A: uint16(a / n)})
+		}
+	}
+	return dst
+}
@@ -0,0 +1,569 @@
+package utils
+
+import (
+	"bufio"
+	"bytes"
+	"database/sql"
+	"encoding/csv"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"reflect"
+	"strings"
+
+	models "github.com/Sea-Shell/gogear-api/pkg/models"
+
+	yaml "github.com/goccy/go-yaml"
+)
+
+// Bulk import formats and duplicate handling modes.
+const (
+	ImportFormatCSV   = "csv"
+	ImportFormatJSON  = "json"
+	ImportFormatJSONL = "jsonl"
+	ImportFormatYAML  = "yaml"
+
+	OnDuplicateSkip   = "skip"
+	OnDuplicateUpdate = "update"
+	OnDuplicateError  = "error"
+)
+
+// ImportInputError reports an import document or option the client got wrong.
+type ImportInputError struct {
+	Message string
+}
+
+func (e *ImportInputError) Error() string {
+	return e.Message
+}
+
+func importInputErrorf(format string, args ...interface{}) error {
+	return &ImportInputError{Message: fmt.Sprintf(format, args...)}
+}
+
+// importFieldAliases maps the names used by ExportGear to GearImportRow fields, so an
+// export can be imported again as is.
+var importFieldAliases = map[string]string{
+	"manufacture_name":  "manufacturer",
+	"top_category_name": "top_category",
+	"category_name":     "category",
+}
+
+// importIgnoredFields are exported IDs and display fields; gear is matched by name instead
+// and ratings come from reviews.
+var importIgnoredFields = map[string]bool{
+	"gear_id":                  true,
+	"gear_top_category_id":     true,
+	"gear_category_id":         true,
+	"gear_manufacture_id":      true,
+	"manufacture_id":           true,
+	"top_category_id":          true,
+	"top_category_icon":        true,
+	"category_id":              true,
+	"category_top_category_id": true,
+	"gear_rating_average":      true,
+	"gear_review_count":        true,
+}
+
+// gearImportInput is a parsed row, or the reason the row could not be parsed.
+type gearImportInput struct {
+	row models.GearImportRow
+	err error
+}
+
+// gearKey identifies gear for duplicate detection: same manufacturer and name,
+// ignoring case.
+type gearKey struct {
+	manufactureID int64
+	name          string
+}
+
+func normalizeName(name string) string {
+	return strings.ToLower(strings.TrimSpace(name))
+}
+
+// ImportGear validates and imports gear rows from a CSV, JSON, JSON lines or YAML document in a single
+// transaction. Rows reference categories and manufacturers by name. The transaction is
+// only committed when no row fails and options.DryRun is false, so a dry run reports
+// exactly what a real import would do.
+func ImportGear(db *sql.DB, reader io.Reader, format string, options models.GearImportOptions) (*models.GearImportReport, error) {
+	switch options.OnDuplicate {
+	case "":
+		options.OnDuplicate = OnDuplicateSkip
+	case OnDuplicateSkip, OnDuplicateUpdate, OnDuplicateError:
+	default:
+		return nil, importInputErrorf("Invalid on_duplicate %q. Use skip, update or error.", options.OnDuplicate)
+	}
+
+	inputs, err := parseGearImport(reader, format)
+	if err != nil {
+		return nil, err
+	}
+
+	tx, err := db.Begin()
+	if err != nil {
+		return nil, err
+	}
+	defer tx.Rollback()
+
+	importer, err := newGearImporter(tx, options)
+	if err != nil {
+		return nil, err
+	}
+
+	report := &models.GearImportReport{
+		DryRun:               options.DryRun,
+		CreatedManufacturers: []string{},
+		Rows:                 make([]models.GearImportRowResult, 0, len(inputs)),
+	}
+
+	for i, input := range inputs {
+		result := models.GearImportRowResult{Row: i + 1, GearName: strings.TrimSpace(input.row.GearName)}
+
+		if input.err != nil {
+			result.Action, result.Error = "error", input.err.Error()
+		} else if err := importer.importRow(&result, input.row, report); err != nil {
+			result.Action, result.Error = "error", err.Error()
+		}
+
+		switch result.Action {
+		case "created":
+			report.Created++
+			if options.DryRun {
+				// IDs assigned inside a rolled back transaction are meaningless.
+				result.GearID = nil
+			}
+		case "updated":
+			report.Updated++
+		case "skipped":
+			report.Skipped++
+		case "error":
+			report.Errors++
+		}
+
+		report.Rows = append(report.Rows, result)
+	}
+
+	if options.DryRun || report.Errors > 0 {
+		return report, nil
+	}
+
+	if err := tx.Commit(); err != nil {
+		return nil, err
+	}
+	report.Committed = true
+
+	return report, nil
+}
+
+// gearImporter resolves names to IDs and writes rows within the import transaction.
+type gearImporter struct {
+	tx            *sql.Tx
+	options       models.GearImportOptions
+	topCategories map[string]int64
+	categories    map[int64]map[string]int64
+	manufacturers map[string]int64
+	existing      map[gearKey]int64
+	seen          map[gearKey]int
+}
+
+func newGearImporter(tx *sql.Tx, options models.GearImportOptions) (*gearImporter, error) {
+	importer := &gearImporter{
+		tx:            tx,
+		options:       options,
+		topCategories: make(map[string]int64),
+		categories:    make(map[int64]map[string]int64),
+		manufacturers: make(map[string]int64),
+		existing:      make(map[gearKey]int64),
+		seen:          make(map[gearKey]int),
+	}
+
+	err := importer.load("SELECT topCategoryId, topCategoryName FROM gear_top_category", func(rows *sql.Rows) error {
+		var id int64
+		var name string
+		if err := rows.Scan(&id, &name); err != nil {
+			return err
+		}
+		importer.topCategories[normalizeName(name)] = id
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	err = importer.load("SELECT categoryId, categoryTopCategoryId, categoryName FROM gear_category", func(rows *sql.Rows) error {
+		var id, topCategoryID int64
+		var name string
+		if err := rows.Scan(&id, &topCategoryID, &name); err != nil {
+			return err
+		}
+		if importer.categories[topCategoryID] == nil {
+			importer.categories[topCategoryID] = make(map[string]int64)
+		}
+		importer.categories[topCategoryID][normalizeName(name)] = id
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	err = importer.load("SELECT manufactureId, manufactureName FROM manufacture", func(rows *sql.Rows) error {
+		var id int64
+		var name string
+		if err := rows.Scan(&id, &name); err != nil {
+			return err
+		}
+		importer.manufacturers[normalizeName(name)] = id
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	err = importer.load("SELECT gearId, gearManufactureId, gearName FROM gear", func(rows *sql.Rows) error {
+		var id, manufactureID int64
+		var name string
+		if err := rows.Scan(&id, &manufactureID, &name); err != nil {
+			return err
+		}
+		importer.existing[gearKey{manufactureID, normalizeName(name)}] = id
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	return importer, nil
+}
+
+func (importer *gearImporter) load(query string, scan func(*sql.Rows) error) error {
+	rows, err := importer.tx.Query(query)
+	if err != nil {
+		return err
+	}
+	defer rows.Close()
+
+	for rows.Next() {
+		if err := scan(rows); err != nil {
+			return err
+		}
+	}
+	return rows.Err()
+}
+
+// importRow resolves and writes one row, filling in the result's action and gear ID.
+func (importer *gearImporter) importRow(result *models.GearImportRowResult, row models.GearImportRow, report *models.GearImportReport) error {
+	required := []struct{ name, value string }{
+		{"gear_name", row.GearName},
+		{"top_category", row.TopCategory},
+		{"category", row.Category},
+		{"manufacturer", row.Manufacturer},
+	}
+	for _, field := range required {
+		if strings.TrimSpace(field.value) == "" {
+			return fmt.Errorf("%s is required", field.name)
+		}
+	}
+
+	topCategoryID, ok := importer.topCategories[normalizeName(row.TopCategory)]
+	if !ok {
+		return fmt.Errorf("unknown top category %q", row.TopCategory)
+	}
+
+	categoryID, ok := importer.categories[topCategoryID][normalizeName(row.Category)]
+	if !ok {
+		return fmt.Errorf("unknown category %q in top category %q", row.Category, row.TopCategory)
+	}
+
+	manufactureID, err := importer.manufacturer(row.Manufacturer, report)
+	if err != nil {
+		return err
+	}
+
+	key := gearKey{manufactureID, normalizeName(row.GearName)}
+	if previous, ok := importer.seen[key]; ok {
+		return fmt.Errorf("duplicates row %d", previous)
+	}
+	importer.seen[key] = result.Row
+
+	values := []interface{}{
+		topCategoryID, categoryID, manufactureID, row.GearIsContainer, strings.TrimSpace(row.GearName),
+		row.GearSizeDefinition, row.GearWeight, row.GearHeight, row.GearLength, row.GearWidth,
+	}
+
+	if gearID, ok := importer.existing[key]; ok {
+		result.GearID = &gearID
+
+		switch importer.options.OnDuplicate {
+		case OnDuplicateError:
+			return fmt.Errorf("gear already exists with id %d", gearID)
+		case OnDuplicateSkip:
+			result.Action = "skipped"
+			return nil
+		}
+
+		_, err := importer.tx.Exec(`UPDATE gear SET gearTopCategoryId = ?, gearCategoryId = ?, gearManufactureId = ?,
+            gearIsContainer = ?, gearName = ?, gearSizeDefinition = ?, gearWeight = ?, gearHeight = ?, gearLength = ?,
+            gearWidth = ?, gearStatus = COALESCE(?, gearStatus) WHERE gearId = ?`, append(values, row.GearStatus, gearID)...)
+		if err != nil {
+			return err
+		}
+		result.Action = "updated"
+		return nil
+	}
+
+	inserted, err := importer.tx.Exec(`INSERT INTO gear (gearTopCategoryId, gearCategoryId, gearManufactureId,
+        gearIsContainer, gearName, gearSizeDefinition, gearWeight, gearHeight, gearLength, gearWidth, gearStatus)
+        VALUES (?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?)`, append(values, row.GearStatus == nil || *row.GearStatus)...)
+	if err != nil {
+		return err
+	}
+
+	gearID, err := inserted.LastInsertId()
+	if err != nil {
+		return err
+	}
+	importer.existing[key] = gearID
+
+	result.GearID = &gearID
+	result.Action = "created"
+	return nil
+}
+
+// manufacturer returns the ID of the named manufacturer, creating it when allowed.
+func (importer *gearImporter) manufacturer(name string, report *models.GearImportReport) (int64, error) {
+	if id, ok := importer.manufacturers[normalizeName(name)]; ok {
+		return id, nil
+	}
+
+	if !importer.options.CreateManufacturers {
+		return 0, fmt.Errorf("unknown manufacturer %q; set create_manufacturers to add it", name)
+	}
+
+	inserted, err := importer.tx.Exec("INSERT INTO manufacture (manufactureName) VALUES (?)", strings.TrimSpace(name))
+	if err != nil {
+		return 0, err
+	}
+
+	id, err := inserted.LastInsertId()
+	if err != nil {
+		return 0, err
+	}
+
+	importer.manufacturers[normalizeName(name)] = id
+	report.CreatedManufacturers = append(report.CreatedManufacturers, strings.TrimSpace(name))
+	return id, nil
+}
+
+func parseGearImport(reader io.Reader, format string) ([]gearImportInput, error) {
+	switch format {
+	case ImportFormatCSV:
+		return parseGearImportCSV(reader)
+	case ImportFormatJSON:
+		return parseGearImportJSON(reader)
+	case ImportFormatJSONL:
+		return parseGearImportJSONL(reader)
+	case ImportFormatYAML:
+		return parseGearImportYAML(reader)
+	default:
+		return nil, importInputErrorf("Invalid format %q. Use csv, json, jsonl or yaml.", format)
+	}
+}
+
+// decodeImportRow strictly decodes one JSON object into a row, after renaming export
+// aliases and dropping ignored fields.
+func decodeImportRow(document []byte) (models.GearImportRow, error) {
+	var row models.GearImportRow
+
+	var fields map[string]json.RawMessage
+	if err := json.Unmarshal(document, &fields); err != nil {
+		return row, err
+	}
+	for name, value := range fields {
+		if importIgnoredFields[name] {
+			delete(fields, name)
+			continue
+		}
+		if alias, ok := importFieldAliases[name]; ok {
+			if _, exists := fields[alias]; !exists {
+				fields[alias] = value
+			}
+			delete(fields, name)
+		}
+	}
+
+	normalized, err := json.Marshal(fields)
+	if err != nil {
+		return row, err
+	}
+
+	decoder := json.NewDecoder(bytes.NewReader(normalized))
+	decoder.DisallowUnknownFields()
+	err = decoder.Decode(&row)
+	return row, err
+}
+
+// parseGearImportJSON reads a JSON array of rows. A row with unknown or mistyped
+// fields is reported on that row.
+func parseGearImportJSON(reader io.Reader) ([]gearImportInput, error) {
+	var documents []json.RawMessage
+	if err := json.NewDecoder(reader).Decode(&documents); err != nil {
+		return nil, importInputErrorf("Invalid JSON document, expected an array of rows: %s", err.Error())
+	}
+
+	inputs := make([]gearImportInput, 0, len(documents))
+	for _, document := range documents {
+		var input gearImportInput
+		input.row, input.err = decodeImportRow(document)
+		inputs = append(inputs, input)
+	}
+
+	return inputs, nil
+}
+
+// parseGearImportJSONL reads one JSON row per line, skipping blank lines. A line that
+// is not valid JSON is reported on that row.
+func parseGearImportJSONL(reader io.Reader) ([]gearImportInput, error) {
+	scanner := bufio.NewScanner(reader)
+	scanner.Buffer(make([]byte, 0, 64*1024), 1024*1024)
+
+	var inputs []gearImportInput
+	for scanner.Scan() {
+		line := bytes.TrimSpace(scanner.Bytes())
+		if len(line) == 0 {
+			continue
+		}
+
+		var input gearImportInput
+		input.row, input.err = decodeImportRow(line)
+		inputs = append(inputs, input)
+	}
+	if err := scanner.Err(); err != nil {
+		return nil, importInputErrorf("Invalid JSON lines document: %s", err.Error())
+	}
+
+	return inputs, nil
+}
+
+// parseGearImportYAML reads a YAML sequence of rows with the same field names as JSON.
+func parseGearImportYAML(reader io.Reader) ([]gearImportInput, error) {
+	data, err := io.ReadAll(reader)
+	if err != nil {
+		return nil, err
+	}
+
+	var documents []map[string]interface{}
+	if err := yaml.Unmarshal(data, &documents); err != nil {
+		return nil, importInputErrorf("Invalid YAML document, expected a sequence of rows: %s", err.Error())
+	}
+
+	inputs := make([]gearImportInput, 0, len(documents))
+	for _, document := range documents {
+		var input gearImportInput
+
+		encoded, err := json.Marshal(document)
+		if err != nil {
+			input.err = err
+		} else {
+			input.row, input.err = decodeImportRow(encoded)
+		}
+		inputs = append(inputs, input)
+	}
+
+	return inputs, nil
+}
+
+// parseGearImportCSV reads rows from CSV whose header names GearImportRow json fields,
+// export aliases or ignored fields. Empty cells keep the field's zero value.
+func parseGearImportCSV(reader io.Reader) ([]gearImportInput, error) {
+	csvReader := csv.NewReader(reader)
+	csvReader.FieldsPerRecord = -1
+	csvReader.TrimLeadingSpace = true
+
+	header, err := csvReader.Read()
+	if err == io.EOF {
+		return nil, importInputErrorf("CSV document is empty")
+	}
+	if err != nil {
+		return nil, importInputErrorf("Invalid CSV document: %s", err.Error())
+	}
+
+	rowType := reflect.TypeOf(models.GearImportRow{})
+	fieldIndexes := make(map[string]int)
+	for i := 0; i < rowType.NumField(); i++ {
+		fieldIndexes[strings.Split(rowType.Field(i).Tag.Get("json"), ",")[0]] = i
+	}
+
+	columns := make([]int, len(header))
+	for i, name := range header {
+		name = strings.TrimSpace(name)
+		if importIgnoredFields[name] {
+			columns[i] = -1
+			continue
+		}
+		if alias, ok := importFieldAliases[name]; ok {
+			name = alias
+		}
+
+		index, ok := fieldIndexes[name]
+		if !ok {
+			return nil, importInputErrorf("Unknown CSV column %q", name)
+		}
+		columns[i] = index
+	}
+
+	var inputs []gearImportInput
+	for {
+		record, err := csvReader.Read()
+		if err == io.EOF {
+			break
+		}
+		if err != nil {
+			var parseErr *csv.ParseError
+			if errors.As(err, &parseErr) {
+				return nil, importInputErrorf("Invalid CSV document: %s", err.Error())
+			}
+			return nil, err
+		}
+
+		var input gearImportInput
+		if len(record) != len(header) {
+			input.err = fmt.Errorf("expected %d columns, got %d", len(header), len(record))
+		} else {
+			input.err = setImportFields(&input.row, header, columns, record)
+		}
+		inputs = append(inputs, input)
+	}
+
+	return inputs, nil
+}
+
+func setImportFields(row *models.GearImportRow, header []string, columns []int, record []string) error {
+	rowValue := reflect.ValueOf(row).Elem()
+
+	for i, raw := range record {
+		raw = strings.TrimSpace(raw)
+		if raw == "" || columns[i] < 0 {
+			continue
+		}
+
+		field := rowValue.Field(columns[i])
+		if field.Kind() == reflect.Pointer {
+			field.Set(reflect.New(field.Type().Elem()))
+			field = field.Elem()
+		}
+		value, err := convertFilterValue(field.Kind(), raw)
+		if err != nil {
+			return fmt.Errorf("invalid %s %q", strings.TrimSpace(header[i]), raw)
+		}
+
+		converted := reflect.ValueOf(value)
+		if field.Kind() == reflect.Int32 && field.OverflowInt(converted.Int()) {
+			return fmt.Errorf("invalid %s %q", strings.TrimSpace(header[i]), raw)
+		}
+		field.Set(converted.Convert(field.Type()))
+	}
+
+	return nil
+}
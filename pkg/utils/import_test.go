@@ -0,0 +1,157 @@
+package utils
+
+import (
+	"database/sql"
+	"strings"
+	"testing"
+
+	models "github.com/Sea-Shell/gogear-api/pkg/models"
+)
+
+func importTestDB(t *testing.T) *sql.DB {
+	t.Helper()
+	db := migratedTestDB(t)
+	execTestStatements(t, db,
+		`INSERT INTO gear_top_category (topCategoryId, topCategoryName) VALUES (1, 'Shelter'), (2, 'Kitchen')`,
+		`INSERT INTO gear_category (categoryId, categoryTopCategoryId, categoryName) VALUES (1, 1, 'Tents'), (2, 2, 'Stoves')`,
+		`INSERT INTO manufacture (manufactureId, manufactureName) VALUES (1, 'MSR')`,
+		`INSERT INTO gear (gearId, gearTopCategoryId, gearCategoryId, gearManufactureId, gearIsContainer, gearName, gearSizeDefinition,
+            gearWeight, gearHeight, gearLength, gearWidth, gearStatus) VALUES (1, 2, 2, 1, 0, 'PocketRocket', '', 73, 0, 0, 0, 1)`,
+	)
+	return db
+}
+
+func gearCount(t *testing.T, db *sql.DB) int {
+	t.Helper()
+	var count int
+	if err := db.QueryRow("SELECT COUNT(*) FROM gear").Scan(&count); err != nil {
+		t.Fatalf("count gear: %v", err)
+	}
+	return count
+}
+
+const importTestCSV = `gear_name,top_category,category,manufacturer,gear_weight
+Nallo 2,shelter,tents,Hilleberg,2400
+pocketrocket,Kitchen,Stoves,msr,83
+`
+
+func TestImportGearDryRunReportsWithoutCommitting(t *testing.T) {
+	db := importTestDB(t)
+
+	report, err := ImportGear(db, strings.NewReader(importTestCSV), ImportFormatCSV, models.GearImportOptions{DryRun: true, CreateManufacturers: true})
+	if err != nil {
+		t.Fatalf("import: %v", err)
+	}
+
+	if report.Committed || report.Created != 1 || report.Skipped != 1 || report.Errors != 0 {
+		t.Errorf("report = %+v", report)
+	}
+	if len(report.CreatedManufacturers) != 1 || report.CreatedManufacturers[0] != "Hilleberg" {
+		t.Errorf("created manufacturers = %v", report.CreatedManufacturers)
+	}
+	if report.Rows[0].GearID != nil || report.Rows[1].GearID == nil || *report.Rows[1].GearID != 1 {
+		t.Errorf("rows = %+v", report.Rows)
+	}
+	if count := gearCount(t, db); count != 1 {
+		t.Errorf("gear count after dry run = %d, want 1", count)
+	}
+}
+
+func TestImportGearCommitsAndUpdatesDuplicates(t *testing.T) {
+	db := importTestDB(t)
+
+	report, err := ImportGear(db, strings.NewReader(importTestCSV), ImportFormatCSV, models.GearImportOptions{CreateManufacturers: true, OnDuplicate: OnDuplicateUpdate})
+	if err != nil {
+		t.Fatalf("import: %v", err)
+	}
+	if !report.Committed || report.Created != 1 || report.Updated != 1 {
+		t.Fatalf("report = %+v", report)
+	}
+
+	var weight int
+	var manufacturer string
+	err = db.QueryRow(`SELECT gear.gearWeight, manufacture.manufactureName FROM gear
+        JOIN manufacture ON gear.gearManufactureId = manufacture.manufactureId WHERE gear.gearId = ?`, *report.Rows[0].GearID).Scan(&weight, &manufacturer)
+	if err != nil || weight != 2400 || manufacturer != "Hilleberg" {
+		t.Errorf("imported gear = %d/%q, %v", weight, manufacturer, err)
+	}
+	if err := db.QueryRow("SELECT gearWeight FROM gear WHERE gearId = 1").Scan(&weight); err != nil || weight != 83 {
+		t.Errorf("updated gear weight = %d, %v", weight, err)
+	}
+}
+
+func TestImportGearStatusDefaultsToCatalog(t *testing.T) {
+	db := importTestDB(t)
+	execTestStatements(t, db, `UPDATE gear SET gearStatus = 0 WHERE gearId = 1`)
+
+	document := "gear_name,top_category,category,manufacturer,gear_status\n" +
+		"Nallo 2,Shelter,Tents,MSR,\nAkto,Shelter,Tents,MSR,false\nPocketRocket,Kitchen,Stoves,MSR,\n"
+	report, err := ImportGear(db, strings.NewReader(document), ImportFormatCSV, models.GearImportOptions{OnDuplicate: OnDuplicateUpdate})
+	if err != nil || !report.Committed {
+		t.Fatalf("import: %+v, %v", report, err)
+	}
+
+	// New gear without a status is in the catalog; existing gear keeps its status.
+	for i, want := range []bool{true, false, false} {
+		var status bool
+		if err := db.QueryRow("SELECT gearStatus FROM gear WHERE gearId = ?", *report.Rows[i].GearID).Scan(&status); err != nil || status != want {
+			t.Errorf("row %d: gearStatus = %v, want %v (%v)", i+1, status, want, err)
+		}
+	}
+}
+
+func TestImportGearRowErrorsRollBackEverything(t *testing.T) {
+	db := importTestDB(t)
+
+	document := `[
+		{"gear_name": "Nallo 2", "top_category": "Shelter", "category": "Tents", "manufacturer": "MSR"},
+		{"gear_name": "Nallo 2", "top_category": "Shelter", "category": "Tents", "manufacturer": "MSR"},
+		{"gear_name": "Tarp", "top_category": "Shelter", "category": "Tarps", "manufacturer": "MSR"},
+		{"gear_name": "Stove", "top_category": "Kitchen", "category": "Stoves", "manufacturer": "Primus"},
+		{"gear_name": "Mug", "weight": 80}
+	]`
+	report, err := ImportGear(db, strings.NewReader(document), ImportFormatJSON, models.GearImportOptions{})
+	if err != nil {
+		t.Fatalf("import: %v", err)
+	}
+
+	if report.Committed || report.Created != 1 || report.Errors != 4 {
+		t.Fatalf("report = %+v", report)
+	}
+	wantErrors := []string{"duplicates row 1", "unknown category", "unknown manufacturer", "unknown field"}
+	for i, want := range wantErrors {
+		if row := report.Rows[i+1]; row.Action != "error" || !strings.Contains(row.Error, want) {
+			t.Errorf("row %d = %+v, want error containing %q", row.Row, row, want)
+		}
+	}
+	if count := gearCount(t, db); count != 1 {
+		t.Errorf("gear count after failed import = %d, want 1", count)
+	}
+}
+
+func TestImportGearRejectsInvalidDocuments(t *testing.T) {
+	db := importTestDB(t)
+
+	cases := []struct {
+		format   string
+		document string
+	}{
+		{ImportFormatCSV, "gear_name,colour\nTent,green\n"},
+		{ImportFormatJSON, `{"gear_name": "Tent"}`},
+		{"xml", "<gear/>"},
+	}
+	for _, tc := range cases {
+		_, err := ImportGear(db, strings.NewReader(tc.document), tc.format, models.GearImportOptions{})
+		if _, ok := err.(*ImportInputError); !ok {
+			t.Errorf("%s %q: err = %v, want ImportInputError", tc.format, tc.document, err)
+		}
+	}
+
+	rowErrors, err := ImportGear(db, strings.NewReader("gear_name,gear_weight\nTent,heavy\nTarp\n"), ImportFormatCSV, models.GearImportOptions{})
+	if err != nil {
+		t.Fatalf("import: %v", err)
+	}
+	if rowErrors.Rows[0].Error != `invalid gear_weight "heavy"` || !strings.Contains(rowErrors.Rows[1].Error, "expected 2 columns") {
+		t.Errorf("rows = %+v", rowErrors.Rows)
+	}
+}
@@ -0,0 +1,627 @@
+package utils
+
+import (
+	"bytes"
+	"database/sql"
+	"encoding/base64"
+	"encoding/json"
+	"fmt"
+	"math"
+	"net/url"
+	"reflect"
+	"strconv"
+	"strings"
+
+	models "github.com/Sea-Shell/gogear-api/pkg/models"
+
+	gin "github.com/gin-gonic/gin"
+)
+
+const defaultListLimit = 30
+
+// ListQueryError reports list query parameters the client got wrong.
+type ListQueryError struct {
+	Message string
+}
+
+func (e *ListQueryError) Error() string {
+	return e.Message
+}
+
+func listQueryErrorf(format string, args ...interface{}) error {
+	return &ListQueryError{Message: fmt.Sprintf(format, args...)}
+}
+
+// ListParams holds the pagination and ordering parameters shared by all list endpoints.
+// When Cursor is set it takes precedence over Page.
+type ListParams struct {
+	Page   int
+	Limit  int
+	Sort   string
+	Order  string
+	Cursor string
+	Count  bool
+}
+
+// ParseListParams reads page, limit, sort, order, cursor and count from the request query string.
+func ParseListParams(c *gin.Context) (ListParams, error) {
+	params := ListParams{
+		Page:   1,
+		Limit:  defaultListLimit,
+		Sort:   strings.TrimSpace(c.Query("sort")),
+		Order:  strings.ToLower(strings.TrimSpace(c.Query("order"))),
+		Cursor: strings.TrimSpace(c.Query("cursor")),
+		Count:  true,
+	}
+
+	if page := c.Query("page"); page != "" && page != "0" {
+		pageInt, err := strconv.Atoi(page)
+		if err != nil || pageInt <= 0 {
+			return params, listQueryErrorf("Invalid page number")
+		}
+		params.Page = pageInt
+	}
+
+	if limit := c.Query("limit"); limit != "" {
+		limitInt, err := strconv.Atoi(limit)
+		if err != nil || limitInt <= 0 {
+			return params, listQueryErrorf("Invalid limit number")
+		}
+		params.Limit = limitInt
+	}
+
+	switch params.Order {
+	case "":
+		params.Order = "asc"
+	case "asc", "desc":
+	default:
+		return params, listQueryErrorf("Invalid order %q. Use asc or desc.", params.Order)
+	}
+
+	if count := c.Query("count"); count != "" {
+		countBool, err := strconv.ParseBool(count)
+		if err != nil {
+			return params, listQueryErrorf("count must be true or false")
+		}
+		params.Count = countBool
+	}
+
+	return params, nil
+}
+
+// listCursor is the decoded form of an opaque keyset pagination token. It holds the
+// sort key and primary key of the row the next page starts after.
+type listCursor struct {
+	Sort       string      `json:"s"`
+	Descending bool        `json:"d,omitempty"`
+	Value      interface{} `json:"v"`
+	ID         interface{} `json:"i"`
+	Backward   bool        `json:"b,omitempty"`
+}
+
+func (cursor listCursor) encode() string {
+	data, _ := json.Marshal(cursor)
+	return base64.RawURLEncoding.EncodeToString(data)
+}
+
+func decodeListCursor(token string) (*listCursor, error) {
+	data, err := base64.RawURLEncoding.DecodeString(token)
+	if err != nil {
+		return nil, listQueryErrorf("Invalid cursor")
+	}
+
+	decoder := json.NewDecoder(bytes.NewReader(data))
+	decoder.UseNumber()
+
+	var cursor listCursor
+	if err := decoder.Decode(&cursor); err != nil {
+		return nil, listQueryErrorf("Invalid cursor")
+	}
+
+	cursor.Value = cursorNumber(cursor.Value)
+	cursor.ID = cursorNumber(cursor.ID)
+	return &cursor, nil
+}
+
+// cursorNumber turns json.Number values back into int64 or float64 so SQLite
+// compares them numerically.
+func cursorNumber(value interface{}) interface{} {
+	number, ok := value.(json.Number)
+	if !ok {
+		return value
+	}
+	if intValue, err := number.Int64(); err == nil {
+		return intValue
+	}
+	floatValue, _ := number.Float64()
+	return floatValue
+}
+
+// listField is a model field that may be used for filtering and sorting.
+type listField struct {
+	column string
+	index  int
+	kind   reflect.Kind
+}
+
+// ListQuery builds parameterized SELECT and COUNT statements for a paginated list of model.
+// Filterable and sortable fields are whitelisted from the model's db tags.
+type ListQuery[model any] struct {
+	from        string
+	columns     []string
+	primary     listField
+	defaultSort string
+	fields      map[string]listField
+	conditions  []string
+	args        []interface{}
+}
+
+// NewListQuery returns a query selecting every db-tagged field of model from the given
+// FROM clause, which may include joins.
+func NewListQuery[model any](from string) *ListQuery[model] {
+	var params model
+	modelType := reflect.TypeOf(params)
+
+	columns, _ := columnsFor[model]()
+	q := &ListQuery[model]{
+		from:    from,
+		columns: append([]string(nil), columns...),
+		fields:  make(map[string]listField),
+	}
+
+	for i := 0; i < modelType.NumField(); i++ {
+		structField := modelType.Field(i)
+		column := structField.Tag.Get("db")
+		jsonName := strings.Split(structField.Tag.Get("json"), ",")[0]
+		if column == "" || jsonName == "-" {
+			continue
+		}
+
+		kind := structField.Type.Kind()
+		if kind == reflect.Ptr {
+			kind = structField.Type.Elem().Kind()
+		}
+
+		field := listField{column: column, index: i, kind: kind}
+		if q.primary.column == "" {
+			q.primary = field
+		}
+
+		names := []string{jsonName, column}
+		if dot := strings.LastIndex(column, "."); dot >= 0 {
+			names = append(names, column[dot+1:])
+		}
+		for _, name := range names {
+			if _, exists := q.fields[name]; name != "" && !exists {
+				q.fields[name] = field
+			}
+		}
+	}
+
+	return q
+}
+
+// Column resolves a json field name or db column name to its whitelisted column.
+func (q *ListQuery[model]) Column(name string) (string, bool) {
+	field, ok := q.fields[name]
+	return field.column, ok
+}
+
+// Select replaces the SQL expression selected, filtered and sorted for the named field,
+// for fields whose value is computed rather than read from a column.
+func (q *ListQuery[model]) Select(name string, expression string) *ListQuery[model] {
+	field, ok := q.fields[name]
+	if !ok {
+		return q
+	}
+
+	for i, column := range q.columns {
+		if column == field.column {
+			q.columns[i] = expression
+		}
+	}
+	for key, existing := range q.fields {
+		if existing.column == field.column {
+			existing.column = expression
+			q.fields[key] = existing
+		}
+	}
+	if q.primary.column == field.column {
+		q.primary.column = expression
+	}
+
+	return q
+}
+
+// CoalesceNulls selects the zero value in place of NULL for every field that is not a
+// pointer, so rows with missing values or missing joined rows still scan into model.
+// Filters and sorting keep using the plain columns.
+func (q *ListQuery[model]) CoalesceNulls() *ListQuery[model] {
+	var params model
+	modelType := reflect.TypeOf(params)
+
+	for i := 0; i < modelType.NumField(); i++ {
+		structField := modelType.Field(i)
+		column := structField.Tag.Get("db")
+		if column == "" {
+			continue
+		}
+
+		var zero string
+		switch structField.Type.Kind() {
+		case reflect.String:
+			zero = "''"
+		case reflect.Bool, reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64,
+			reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64, reflect.Float32, reflect.Float64:
+			zero = "0"
+		default:
+			continue
+		}
+
+		for j, selected := range q.columns {
+			if selected == column {
+				q.columns[j] = fmt.Sprintf("COALESCE(%s, %s)", column, zero)
+			}
+		}
+	}
+
+	return q
+}
+
+// SortDefault sets the field sorted by when the request does not name one.
+func (q *ListQuery[model]) SortDefault(name string) *ListQuery[model] {
+	q.defaultSort = name
+	return q
+}
+
+// Where adds a condition with its placeholder arguments. Conditions are combined with AND.
+func (q *ListQuery[model]) Where(condition string, args ...interface{}) *ListQuery[model] {
+	q.conditions = append(q.conditions, condition)
+	q.args = append(q.args, args...)
+	return q
+}
+
+// WhereIn adds a "column IN (...)" condition. It is a no-op when values is empty.
+func (q *ListQuery[model]) WhereIn(column string, values ...interface{}) *ListQuery[model] {
+	if len(values) == 0 {
+		return q
+	}
+	placeholders := strings.Repeat("?, ", len(values)-1) + "?"
+	return q.Where(fmt.Sprintf("%s IN (%s)", column, placeholders), values...)
+}
+
+// WhereAny adds conditions combined with OR as a single condition.
+// It is a no-op when conditions is empty.
+func (q *ListQuery[model]) WhereAny(conditions []string, args ...interface{}) *ListQuery[model] {
+	if len(conditions) == 0 {
+		return q
+	}
+	return q.Where("("+strings.Join(conditions, " OR ")+")", args...)
+}
+
+// FilterFields adds an equality filter for every query parameter named after a model
+// field's json tag. Repeated parameters match any of the given values.
+func (q *ListQuery[model]) FilterFields(values url.Values) error {
+	var params model
+	modelType := reflect.TypeOf(params)
+
+	for i := 0; i < modelType.NumField(); i++ {
+		jsonName := strings.Split(modelType.Field(i).Tag.Get("json"), ",")[0]
+		field, ok := q.fields[jsonName]
+		if !ok {
+			continue
+		}
+
+		rawValues, present := values[jsonName]
+		if !present {
+			continue
+		}
+
+		filterValues := make([]interface{}, 0, len(rawValues))
+		for _, raw := range rawValues {
+			value, err := convertFilterValue(field.kind, raw)
+			if err != nil {
+				return listQueryErrorf("Invalid value %q for %s", raw, jsonName)
+			}
+			filterValues = append(filterValues, value)
+		}
+
+		q.WhereIn(field.column, filterValues...)
+	}
+
+	return nil
+}
+
+func convertFilterValue(kind reflect.Kind, raw string) (interface{}, error) {
+	switch kind {
+	case reflect.Bool:
+		return strconv.ParseBool(raw)
+	case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64:
+		return strconv.ParseInt(raw, 10, 64)
+	case reflect.Float32, reflect.Float64:
+		return strconv.ParseFloat(raw, 64)
+	default:
+		return raw, nil
+	}
+}
+
+func (q *ListQuery[model]) whereClause() string {
+	if len(q.conditions) == 0 {
+		return ""
+	}
+	return " WHERE " + strings.Join(q.conditions, " AND ")
+}
+
+// sortField resolves the requested sort field, defaulting to the query's default sort
+// and then the primary key.
+func (q *ListQuery[model]) sortField(sort string) (listField, error) {
+	if sort == "" {
+		sort = q.defaultSort
+	}
+	if sort == "" {
+		return q.primary, nil
+	}
+
+	field, ok := q.fields[sort]
+	if !ok {
+		return listField{}, listQueryErrorf("Invalid sort field %q", sort)
+	}
+	return field, nil
+}
+
+func (q *ListQuery[model]) orderClause(sort listField, descending bool) string {
+	direction := "ASC"
+	if descending {
+		direction = "DESC"
+	}
+
+	if sort.column == q.primary.column {
+		return fmt.Sprintf(" ORDER BY %s %s", sort.column, direction)
+	}
+
+	// The primary key is a tie-breaker so pages stay stable across equal sort values.
+	return fmt.Sprintf(" ORDER BY %s %s, %s %s", sort.column, direction, q.primary.column, direction)
+}
+
+// keysetCondition selects the rows ordered after (value, id). SQLite sorts NULL
+// before any other value, which the NULL branches account for.
+func (q *ListQuery[model]) keysetCondition(sort listField, descending bool, value interface{}, id interface{}) (string, []interface{}) {
+	column, primary := sort.column, q.primary.column
+
+	comparison := ">"
+	if descending {
+		comparison = "<"
+	}
+
+	if column == primary {
+		return fmt.Sprintf("%s %s ?", primary, comparison), []interface{}{id}
+	}
+
+	switch {
+	case value == nil && !descending:
+		return fmt.Sprintf("((%s IS NULL AND %s > ?) OR %s IS NOT NULL)", column, primary, column), []interface{}{id}
+	case value == nil:
+		return fmt.Sprintf("(%s IS NULL AND %s < ?)", column, primary), []interface{}{id}
+	case !descending:
+		return fmt.Sprintf("(%s > ? OR (%s = ? AND %s > ?))", column, column, primary), []interface{}{value, value, id}
+	default:
+		return fmt.Sprintf("(%s < ? OR (%s = ? AND %s < ?) OR %s IS NULL)", column, column, primary, column), []interface{}{value, value, id}
+	}
+}
+
+// Count returns the number of rows matching the query conditions.
+func (q *ListQuery[model]) Count(db *sql.DB) (int, error) {
+	var totalCount int
+	countQuery := "SELECT COUNT(*) FROM " + q.from + q.whereClause()
+	if err := cachedQueryRow(db, countQuery, q.args...).Scan(&totalCount); err != nil {
+		return 0, err
+	}
+	return totalCount, nil
+}
+
+// Fetch returns a single page of rows ordered according to params, using the cursor
+// when one is set and the page offset otherwise. The boolean reports whether more
+// rows follow in the direction the page was read.
+func (q *ListQuery[model]) Fetch(db *sql.DB, params ListParams) ([]model, bool, error) {
+	sort, err := q.sortField(params.Sort)
+	if err != nil {
+		return nil, false, err
+	}
+	descending := params.Order == "desc"
+
+	conditions := append([]string{}, q.conditions...)
+	args := append([]interface{}{}, q.args...)
+	offset := (params.Page - 1) * params.Limit
+	backward := false
+
+	if params.Cursor != "" {
+		cursor, err := decodeListCursor(params.Cursor)
+		if err != nil {
+			return nil, false, err
+		}
+		if cursor.Sort != sort.column || cursor.Descending != descending {
+			return nil, false, listQueryErrorf("Cursor does not match the requested sort and order")
+		}
+
+		backward = cursor.Backward
+		condition, conditionArgs := q.keysetCondition(sort, descending != backward, cursor.Value, cursor.ID)
+		conditions = append(conditions, condition)
+		args = append(args, conditionArgs...)
+		offset = 0
+	}
+
+	whereClause := ""
+	if len(conditions) > 0 {
+		whereClause = " WHERE " + strings.Join(conditions, " AND ")
+	}
+
+	// One extra row tells whether another page follows without a COUNT query.
+	query := fmt.Sprintf("SELECT %s FROM %s", strings.Join(q.columns, ", "), q.from) +
+		whereClause + q.orderClause(sort, descending != backward) + " LIMIT ?, ?"
+	args = append(args, offset, params.Limit+1)
+
+	rows, err := cachedQuery(db, query, args...)
+	if err != nil {
+		return nil, false, err
+	}
+	defer rows.Close()
+
+	items, err := ScanRows[model](rows)
+	if err != nil {
+		return nil, false, err
+	}
+
+	hasMore := len(items) > params.Limit
+	if hasMore {
+		items = items[:params.Limit]
+	}
+
+	if backward {
+		for i, j := 0, len(items)-1; i < j; i, j = i+1, j-1 {
+			items[i], items[j] = items[j], items[i]
+		}
+	}
+
+	return items, hasMore, nil
+}
+
+// Each streams every row matching the query to fn in primary key order, without paging.
+func (q *ListQuery[model]) Each(db *sql.DB, fn func(item model) error) error {
+	plan, err := planFor[model]()
+	if err != nil {
+		return err
+	}
+
+	query := fmt.Sprintf("SELECT %s FROM %s", strings.Join(q.columns, ", "), q.from) +
+		q.whereClause() + q.orderClause(q.primary, false)
+
+	rows, err := db.Query(query, q.args...)
+	if err != nil {
+		return err
+	}
+	defer rows.Close()
+
+	var item model
+	dest := scanDest(plan, &item)
+	for rows.Next() {
+		if err := rows.Scan(dest...); err != nil {
+			return err
+		}
+		if err := fn(item); err != nil {
+			return err
+		}
+	}
+
+	return rows.Err()
+}
+
+// cursorFor builds the token that continues listing after item in the given direction.
+func (q *ListQuery[model]) cursorFor(item model, params ListParams, backward bool) *string {
+	sort, err := q.sortField(params.Sort)
+	if err != nil {
+		return nil
+	}
+
+	itemValue := reflect.ValueOf(item)
+	token := listCursor{
+		Sort:       sort.column,
+		Descending: params.Order == "desc",
+		Value:      fieldValue(itemValue.Field(sort.index)),
+		ID:         fieldValue(itemValue.Field(q.primary.index)),
+		Backward:   backward,
+	}.encode()
+	return &token
+}
+
+func fieldValue(value reflect.Value) interface{} {
+	if value.Kind() == reflect.Ptr {
+		if value.IsNil() {
+			return nil
+		}
+		value = value.Elem()
+	}
+	return value.Interface()
+}
+
+// ListResponse runs q using the request's list parameters and builds the paginated payload,
+// including page links and keyset cursors. The total count is skipped when count=false.
+func ListResponse[model any](c *gin.Context, db *sql.DB, q *ListQuery[model]) (*models.ResponsePayload, error) {
+	params, err := ParseListParams(c)
+	if err != nil {
+		return nil, err
+	}
+
+	payload := models.ResponsePayload{
+		ItemLimit: params.Limit,
+	}
+
+	if params.Count {
+		totalCount, err := q.Count(db)
+		if err != nil {
+			return nil, err
+		}
+		payload.TotalItemCount = totalCount
+		payload.TotalPages = int(math.Ceil(float64(totalCount) / float64(params.Limit)))
+	}
+
+	items, hasMore, err := q.Fetch(db, params)
+	if err != nil {
+		return nil, err
+	}
+	payload.Items = items
+
+	hasNext, hasPrev := hasMore, params.Page > 1
+	if params.Cursor != "" {
+		cursor, err := decodeListCursor(params.Cursor)
+		if err != nil {
+			return nil, err
+		}
+		// A page read backwards always has rows after it, and one read forwards always has rows before it.
+		if cursor.Backward {
+			hasNext, hasPrev = true, hasMore
+		} else {
+			hasNext, hasPrev = hasMore, true
+		}
+	} else {
+		payload.CurrentPage = params.Page
+		if hasNext {
+			payload.NextPage = pageURL(c.Request.URL, "page", strconv.Itoa(params.Page+1))
+		}
+		if hasPrev {
+			payload.PrevPage = pageURL(c.Request.URL, "page", strconv.Itoa(params.Page-1))
+		}
+	}
+
+	if len(items) == 0 {
+		return &payload, nil
+	}
+
+	if hasNext {
+		payload.NextCursor = q.cursorFor(items[len(items)-1], params, false)
+	}
+	if hasPrev {
+		payload.PrevCursor = q.cursorFor(items[0], params, true)
+	}
+
+	if params.Cursor != "" {
+		if payload.NextCursor != nil {
+			payload.NextPage = pageURL(c.Request.URL, "cursor", *payload.NextCursor)
+		}
+		if payload.PrevCursor != nil {
+			payload.PrevPage = pageURL(c.Request.URL, "cursor", *payload.PrevCursor)
+		}
+	}
+
+	return &payload, nil
+}
+
+func pageURL(requestURL *url.URL, key string, value string) *string {
+	query := requestURL.Query()
+	query.Set(key, value)
+	link := url.URL{
+		Path:     requestURL.Path,
+		RawQuery: query.Encode(),
+	}
+	linkString := link.String()
+	return &linkString
+}
@@ -0,0 +1,245 @@
+package utils
+
+import (
+	"database/sql"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"testing"
+
+	models "github.com/Sea-Shell/gogear-api/pkg/models"
+
+	"github.com/gin-gonic/gin"
+	"github.com/golang-migrate/migrate/v4"
+	sqlite3driver "github.com/golang-migrate/migrate/v4/database/sqlite3"
+	_ "github.com/golang-migrate/migrate/v4/source/file"
+	_ "github.com/mattn/go-sqlite3"
+)
+
+type listTestItem struct {
+	ItemID     *int64 `json:"item_id" db:"gear.gearId"`
+	ItemName   string `json:"item_name" db:"gear.gearName"`
+	ItemWeight int64  `json:"item_weight" db:"gear.gearWeight"`
+	ItemSecret string `json:"-" db:"gear.gearSizeDefinition"`
+}
+
+// migratedTestDB opens an in-memory database with the schema from the real migrations.
+func migratedTestDB(t *testing.T) *sql.DB {
+	t.Helper()
+	db, err := sql.Open("sqlite3", ":memory:")
+	if err != nil {
+		t.Fatalf("open :memory: %v", err)
+	}
+	t.Cleanup(func() { db.Close() })
+	db.SetMaxOpenConns(1)
+
+	driver, err := sqlite3driver.WithInstance(db, &sqlite3driver.Config{})
+	if err != nil {
+		t.Fatalf("driver init: %v", err)
+	}
+	m, err := migrate.NewWithDatabaseInstance("file://../../migrations", "sqlite3", driver)
+	if err != nil {
+		t.Fatalf("migrate instance: %v", err)
+	}
+	if err := m.Up(); err != nil && err != migrate.ErrNoChange {
+		t.Fatalf("migrate up: %v", err)
+	}
+	return db
+}
+
+func execTestStatements(t *testing.T, db *sql.DB, statements ...string) {
+	t.Helper()
+	for _, statement := range statements {
+		if _, err := db.Exec(statement); err != nil {
+			t.Fatalf("exec %q: %v", statement, err)
+		}
+	}
+}
+
+func listTestDB(t *testing.T) *sql.DB {
+	t.Helper()
+	db := migratedTestDB(t)
+	execTestStatements(t, db,
+		`INSERT INTO gear_top_category (topCategoryId, topCategoryName) VALUES (1, 'Shelter')`,
+		`INSERT INTO gear_category (categoryId, categoryTopCategoryId, categoryName) VALUES (1, 1, 'Tents')`,
+		`INSERT INTO manufacture (manufactureId, manufactureName) VALUES (1, 'Hilleberg')`,
+		`INSERT INTO gear (gearId, gearTopCategoryId, gearCategoryId, gearManufactureId, gearName, gearWeight, gearSizeDefinition)
+            VALUES (1, 1, 1, 1, 'tent', 1200, 'a'), (2, 1, 1, 1, 'stove', 300, 'b'), (3, 1, 1, 1, 'pack', 1200, 'c'), (4, 1, 1, 1, 'mug', 80, 'd')`,
+	)
+	return db
+}
+
+func listTestContext(rawQuery string) *gin.Context {
+	gin.SetMode(gin.TestMode)
+	c, _ := gin.CreateTestContext(httptest.NewRecorder())
+	c.Request = httptest.NewRequest(http.MethodGet, "/items?"+rawQuery, nil)
+	return c
+}
+
+func itemIDs(items []listTestItem) []int64 {
+	ids := make([]int64, 0, len(items))
+	for _, item := range items {
+		ids = append(ids, *item.ItemID)
+	}
+	return ids
+}
+
+func TestListQuerySortsWithPrimaryKeyTieBreaker(t *testing.T) {
+	db := listTestDB(t)
+
+	query := NewListQuery[listTestItem]("gear")
+	items, _, err := query.Fetch(db, ListParams{Page: 1, Limit: 10, Sort: "item_weight", Order: "desc"})
+	if err != nil {
+		t.Fatalf("fetch: %v", err)
+	}
+
+	got := itemIDs(items)
+	want := []int64{3, 1, 2, 4}
+	if len(got) != len(want) {
+		t.Fatalf("ids = %v, want %v", got, want)
+	}
+	for i := range want {
+		if got[i] != want[i] {
+			t.Fatalf("ids = %v, want %v", got, want)
+		}
+	}
+}
+
+func TestListQueryRejectsUnknownAndHiddenSortFields(t *testing.T) {
+	db := listTestDB(t)
+
+	for _, sort := range []string{"gearPrice", "gear.gearSizeDefinition; DROP TABLE gear", "gearSizeDefinition"} {
+		query := NewListQuery[listTestItem]("gear")
+		_, _, err := query.Fetch(db, ListParams{Page: 1, Limit: 10, Sort: sort, Order: "asc"})
+
+		var queryErr *ListQueryError
+		if !errors.As(err, &queryErr) {
+			t.Errorf("sort %q: err = %v, want ListQueryError", sort, err)
+		}
+	}
+}
+
+func TestListQueryFilterFields(t *testing.T) {
+	db := listTestDB(t)
+
+	query := NewListQuery[listTestItem]("gear")
+	if err := query.FilterFields(url.Values{"item_weight": {"1200", "80"}, "item_secret": {"a"}}); err != nil {
+		t.Fatalf("filter: %v", err)
+	}
+
+	count, err := query.Count(db)
+	if err != nil {
+		t.Fatalf("count: %v", err)
+	}
+	if count != 3 {
+		t.Errorf("count = %d, want 3", count)
+	}
+
+	if err := NewListQuery[listTestItem]("gear").FilterFields(url.Values{"item_weight": {"heavy"}}); err == nil {
+		t.Error("expected error for non-numeric item_weight filter")
+	}
+}
+
+func TestListResponsePagination(t *testing.T) {
+	db := listTestDB(t)
+
+	c := listTestContext("page=2&limit=1&sort=item_name")
+	payload, err := ListResponse(c, db, NewListQuery[listTestItem]("gear"))
+	if err != nil {
+		t.Fatalf("list response: %v", err)
+	}
+
+	if payload.TotalItemCount != 4 || payload.TotalPages != 4 || payload.CurrentPage != 2 {
+		t.Errorf("payload counts = %d/%d/%d, want 4/4/2", payload.TotalItemCount, payload.TotalPages, payload.CurrentPage)
+	}
+
+	items := payload.Items.([]listTestItem)
+	if len(items) != 1 || items[0].ItemName != "pack" {
+		t.Errorf("items = %#v, want [pack]", items)
+	}
+
+	if payload.NextPage == nil || *payload.NextPage != "/items?limit=1&page=3&sort=item_name" {
+		t.Errorf("next page = %v", payload.NextPage)
+	}
+	if payload.PrevPage == nil || *payload.PrevPage != "/items?limit=1&page=1&sort=item_name" {
+		t.Errorf("prev page = %v", payload.PrevPage)
+	}
+}
+
+func TestListResponseCursorWalksAllRows(t *testing.T) {
+	db := listTestDB(t)
+
+	var forward []int64
+	var lastPayload *models.ResponsePayload
+	rawQuery := "limit=1&sort=item_weight&order=desc&count=false"
+	for page := 0; page < 10; page++ {
+		payload, err := ListResponse(listTestContext(rawQuery), db, NewListQuery[listTestItem]("gear"))
+		if err != nil {
+			t.Fatalf("list response: %v", err)
+		}
+		if payload.TotalItemCount != 0 {
+			t.Errorf("count=false: total = %d, want 0", payload.TotalItemCount)
+		}
+		forward = append(forward, itemIDs(payload.Items.([]listTestItem))...)
+		lastPayload = payload
+		if payload.NextCursor == nil {
+			break
+		}
+		rawQuery = "limit=1&sort=item_weight&order=desc&count=false&cursor=" + *payload.NextCursor
+	}
+
+	want := []int64{3, 1, 2, 4}
+	if len(forward) != len(want) {
+		t.Fatalf("forward ids = %v, want %v", forward, want)
+	}
+	for i := range want {
+		if forward[i] != want[i] {
+			t.Fatalf("forward ids = %v, want %v", forward, want)
+		}
+	}
+
+	if lastPayload.PrevCursor == nil {
+		t.Fatal("expected prev cursor on last page")
+	}
+	payload, err := ListResponse(listTestContext("limit=2&sort=item_weight&order=desc&cursor="+*lastPayload.PrevCursor), db, NewListQuery[listTestItem]("gear"))
+	if err != nil {
+		t.Fatalf("list response: %v", err)
+	}
+	backward := itemIDs(payload.Items.([]listTestItem))
+	if len(backward) != 2 || backward[0] != 1 || backward[1] != 2 {
+		t.Errorf("backward ids = %v, want [1 2]", backward)
+	}
+	if payload.NextCursor == nil || payload.PrevCursor == nil {
+		t.Errorf("backward page cursors = %v/%v, want both set", payload.NextCursor, payload.PrevCursor)
+	}
+}
+
+func TestListResponseRejectsMismatchedCursor(t *testing.T) {
+	db := listTestDB(t)
+
+	payload, err := ListResponse(listTestContext("limit=1&sort=item_name"), db, NewListQuery[listTestItem]("gear"))
+	if err != nil || payload.NextCursor == nil {
+		t.Fatalf("list response: %v, next cursor %v", err, payload)
+	}
+
+	for _, rawQuery := range []string{
+		"limit=1&sort=item_weight&cursor=" + *payload.NextCursor,
+		"limit=1&sort=item_name&order=desc&cursor=" + *payload.NextCursor,
+		"limit=1&cursor=not-a-cursor",
+	} {
+		_, err := ListResponse(listTestContext(rawQuery), db, NewListQuery[listTestItem]("gear"))
+		var queryErr *ListQueryError
+		if !errors.As(err, &queryErr) {
+			t.Errorf("%s: err = %v, want ListQueryError", rawQuery, err)
+		}
+	}
+}
+
+func TestParseListParamsRejectsInvalidValues(t *testing.T) {
+	for _, rawQuery := range []string{"page=-1", "limit=abc", "order=sideways", "count=maybe"} {
+		if _, err := ParseListParams(listTestContext(rawQuery)); err == nil {
+			t.Errorf("%s: expected error", rawQuery)
+		}
+	}
+}
@@ -58,37 +58,24 @@ func GetDBFieldNames(structType reflect.Type) []string {
 	return fieldNames
 }
 
+// GetStructFieldValues returns the values of the db-tagged fields of s, in the order of
+// GetDBFieldNames.
 func GetStructFieldValues(s interface{}) []interface{} {
 	structValue := reflect.ValueOf(s)
+	structType := structValue.Type()
 
 	values := make([]interface{}, 0, structValue.NumField())
 
 	for i := 0; i < structValue.NumField(); i++ {
-		field := structValue.Field(i)
-		values = append(values, field.Interface())
+		if structType.Field(i).Tag.Get("db") == "" {
+			continue
+		}
+		values = append(values, structValue.Field(i).Interface())
 	}
 
 	return values
 }
 
-func GetScanFields(v interface{}) ([]interface{}, error) {
-	valueType := reflect.TypeOf(v)
-
-	if valueType.Kind() != reflect.Struct {
-		return nil, errors.New("input must be a struct")
-	}
-
-	dest := make([]interface{}, valueType.NumField())
-
-	for i := 0; i < valueType.NumField(); i++ {
-		field := valueType.Field(i)
-
-		dest[i] = reflect.New(field.Type).Interface()
-	}
-
-	return dest, nil
-}
-
 func GenericUpdate[model any](table string, data []byte, db *sql.DB) error {
 	var body model
 
@@ -106,6 +93,7 @@ func GenericUpdate[model any](table string, data []byte, db *sql.DB) error {
 	idValue := reflect.Indirect(reflect.ValueOf(fieldValue)).Interface()
 
 	var fields []string = GetDBFieldNames(reflect.TypeOf(body))
+	values := GetStructFieldValues(body)
 	var updateFields []string
 	var updateValues []interface{}
 
@@ -115,7 +103,7 @@ func GenericUpdate[model any](table string, data []byte, db *sql.DB) error {
 			continue
 		}
 		updateFields = append(updateFields, field+" = ?")
-		updateValues = append(updateValues, v.Field(i).Interface())
+		updateValues = append(updateValues, values[i])
 	}
 
 	updateFieldsClause := strings.Join(updateFields, ", ")
@@ -179,10 +167,10 @@ func GenericInsert[model any](table string, data []byte, db *sql.DB) (*model, er
 }
 
 func GenericGet[model any](table string, id int, sql []string, db *sql.DB) (*model, error) {
-
-	var params model
-
-	fields := GetDBFieldNames(reflect.TypeOf(params))
+	fields, err := columnsFor[model]()
+	if err != nil {
+		return nil, err
+	}
 
 	extraSql := ""
 	if len(sql) > 0 {
@@ -197,24 +185,7 @@ func GenericGet[model any](table string, id int, sql []string, db *sql.DB) (*mod
 
 	query := baseQuery + extraSql + whereClause + queryLimit
 
-	row := db.QueryRow(query, id)
-
-	dest, err := GetScanFields(params)
-	if err != nil {
-		return nil, err
-	}
-
-	err = row.Scan(dest...)
-	if err != nil {
-		return nil, err
-	}
-
-	for i := 0; i < reflect.TypeOf(params).NumField(); i++ {
-
-		reflect.ValueOf(&params).Elem().Field(i).Set(reflect.ValueOf(dest[i]).Elem())
-	}
-
-	return &params, nil
+	return ScanRow[model](cachedQueryRow(db, query, id))
 }
 
 func EnsureColumn(db *sql.DB, table string, column string, definition string) error {
@@ -252,10 +223,10 @@ func EnsureColumn(db *sql.DB, table string, column string, definition string) er
 }
 
 func GenericDelete[model any](table string, id int, db *sql.DB) (*model, error) {
-
-	var params model
-
-	fields := GetDBFieldNames(reflect.TypeOf(params))
+	fields, err := columnsFor[model]()
+	if err != nil {
+		return nil, err
+	}
 
 	if !strings.Contains(strings.ToLower(fields[0]), "id") {
 		return nil, errors.New("Invalid field order in struct. first field must be id")
@@ -290,9 +261,10 @@ func GenericDelete[model any](table string, id int, db *sql.DB) (*model, error)
 }
 
 func GenericList[model any](table string, field string, id int, db *sql.DB) (*[]model, error) {
-	var params model
-
-	fields := GetDBFieldNames(reflect.TypeOf(params))
+	fields, err := columnsFor[model]()
+	if err != nil {
+		return nil, err
+	}
 
 	var modelFieldCount int = 0
 	var fieldIndexNumber int
@@ -321,11 +293,14 @@ func GenericList[model any](table string, field string, id int, db *sql.DB) (*[]
 	}
 	defer rows.Close()
 
-	dest, err := GetScanFields(params)
+	plan, err := planFor[model]()
 	if err != nil {
 		return nil, err
 	}
 
+	var params model
+	dest := scanDest(plan, &params)
+
 	var genericObjectList []model
 
 	for rows.Next() {
@@ -338,10 +313,6 @@ func GenericList[model any](table string, field string, id int, db *sql.DB) (*[]
 			return nil, errors.New(errorMsg)
 		}
 
-		for i := 0; i < reflect.TypeOf(params).NumField(); i++ {
-			reflect.ValueOf(&params).Elem().Field(i).Set(reflect.ValueOf(dest[i]).Elem())
-		}
-
 		genericObjectList = append(genericObjectList, params)
 	}
 
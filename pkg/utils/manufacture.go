@@ -0,0 +1,81 @@
+package utils
+
+import (
+	"database/sql"
+	"fmt"
+
+	models "github.com/Sea-Shell/gogear-api/pkg/models"
+)
+
+// ManufactureStatsJoins joins manufacturers with their gear count, lightest and heaviest
+// weight as manufacture_stats, and the number of users owning their gear as
+// manufacture_owners. Gear without a weight (0) is left out of the weights.
+const ManufactureStatsJoins = `
+        LEFT JOIN (SELECT gearManufactureId, COUNT(*) AS gearCount,
+                MIN(NULLIF(gearWeight, 0)) AS lightestWeight, MAX(NULLIF(gearWeight, 0)) AS heaviestWeight
+            FROM gear GROUP BY gearManufactureId) AS manufacture_stats
+            ON manufacture_stats.gearManufactureId = manufacture.manufactureId
+        LEFT JOIN (SELECT gear.gearManufactureId, COUNT(DISTINCT user_gear_registrations.userId) AS ownerCount
+            FROM user_gear_registrations JOIN gear ON gear.gearId = user_gear_registrations.gearId
+            GROUP BY gear.gearManufactureId) AS manufacture_owners
+            ON manufacture_owners.gearManufactureId = manufacture.manufactureId`
+
+// ManufactureDetails aggregates the catalog stats of a manufacturer. It returns
+// sql.ErrNoRows when the manufacturer does not exist.
+func ManufactureDetails(db *sql.DB, manufactureID int64) (*models.ManufactureDetail, error) {
+	manufacture, err := GenericGet[models.Manufacture]("manufacture", int(manufactureID), nil, db)
+	if err != nil {
+		return nil, err
+	}
+	detail := &models.ManufactureDetail{Manufacture: *manufacture}
+
+	err = db.QueryRow(`SELECT
+            (SELECT COUNT(*) FROM gear WHERE gearManufactureId = ?),
+            (SELECT COUNT(DISTINCT user_gear_registrations.userId) FROM user_gear_registrations
+                JOIN gear ON gear.gearId = user_gear_registrations.gearId WHERE gear.gearManufactureId = ?)`,
+		manufactureID, manufactureID).Scan(&detail.GearCount, &detail.OwnerCount)
+	if err != nil {
+		return nil, fmt.Errorf("count manufacture gear: %w", err)
+	}
+
+	rows, err := db.Query(`SELECT gear_top_category.topCategoryId, gear_top_category.topCategoryName, COUNT(*) AS gearCount
+        FROM gear JOIN gear_top_category ON gear_top_category.topCategoryId = gear.gearTopCategoryId
+        WHERE gear.gearManufactureId = ?
+        GROUP BY gear_top_category.topCategoryId
+        ORDER BY gearCount DESC, gear_top_category.topCategoryName`, manufactureID)
+	if err != nil {
+		return nil, fmt.Errorf("query manufacture top categories: %w", err)
+	}
+	defer rows.Close()
+
+	categories, err := ScanRows[models.ManufactureCategoryCount](rows)
+	if err != nil {
+		return nil, fmt.Errorf("scan manufacture top categories: %w", err)
+	}
+	detail.TopCategories = categories
+	if detail.TopCategories == nil {
+		detail.TopCategories = []models.ManufactureCategoryCount{}
+	}
+
+	for _, extreme := range []struct {
+		order  string
+		target **models.ManufactureGear
+	}{
+		{"ASC", &detail.LightestGear},
+		{"DESC", &detail.HeaviestGear},
+	} {
+		row := db.QueryRow(`SELECT gearId, gearName, gearWeight FROM gear
+            WHERE gearManufactureId = ? AND gearWeight > 0
+            ORDER BY gearWeight `+extreme.order+`, gearId LIMIT 1`, manufactureID)
+		gear, err := ScanRow[models.ManufactureGear](row)
+		if err == sql.ErrNoRows {
+			continue
+		}
+		if err != nil {
+			return nil, fmt.Errorf("query manufacture gear weight: %w", err)
+		}
+		*extreme.target = gear
+	}
+
+	return detail, nil
+}
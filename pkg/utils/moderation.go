@@ -0,0 +1,126 @@
+package utils
+
+import (
+	"database/sql"
+	"fmt"
+	"strings"
+
+	models "github.com/Sea-Shell/gogear-api/pkg/models"
+)
+
+// Review states of gear submissions.
+const (
+	GearReviewPending  = "pending"
+	GearReviewApproved = "approved"
+	GearReviewRejected = "rejected"
+)
+
+const maxReviewReasonLength = 1000
+
+// ModerationError reports an invalid review decision.
+type ModerationError struct {
+	Message string
+}
+
+func (e *ModerationError) Error() string {
+	return e.Message
+}
+
+// GearSubmissionJoins joins gear with the names shown in the moderation queue.
+const GearSubmissionJoins = ` LEFT JOIN manufacture ON gear.gearManufactureId = manufacture.manufactureId
+        LEFT JOIN gear_category ON gear.gearCategoryId = gear_category.categoryId
+        LEFT JOIN users ON gear.gearSubmittedBy = users.userId`
+
+// GearInCatalog matches gear in the shared catalog. Gear written without a status counts as
+// catalog gear; only a false gearStatus takes it out.
+const GearInCatalog = "gear.gearStatus IS NOT 0"
+
+// GearVisibleCondition returns the SQL condition matching gear a user can see: gear in the
+// catalog and the user's own submissions.
+func GearVisibleCondition(userID int64) (string, []interface{}) {
+	return "(" + GearInCatalog + " OR gear.gearSubmittedBy = ?)", []interface{}{userID}
+}
+
+// GearVisible reports whether gear exists and is visible to a user. Admins see all gear.
+func GearVisible(db *sql.DB, gearID int64, userID int64, isAdmin bool) (bool, error) {
+	query := "SELECT EXISTS (SELECT 1 FROM gear WHERE gearId = ?)"
+	args := []interface{}{gearID}
+	if !isAdmin {
+		condition, visibleArgs := GearVisibleCondition(userID)
+		query = "SELECT EXISTS (SELECT 1 FROM gear WHERE gearId = ? AND " + condition + ")"
+		args = append(args, visibleArgs...)
+	}
+
+	var visible bool
+	if err := db.QueryRow(query, args...).Scan(&visible); err != nil {
+		return false, fmt.Errorf("check gear visibility: %w", err)
+	}
+	return visible, nil
+}
+
+// GearSubmissionByID returns the review state of a piece of gear, or sql.ErrNoRows.
+func GearSubmissionByID(db *sql.DB, gearID int64) (*models.GearSubmission, error) {
+	return GenericGet[models.GearSubmission]("gear", int(gearID), []string{GearSubmissionJoins + " "}, db)
+}
+
+// SubmitGear puts gear in the moderation queue as a pending submission by userID, out of
+// the catalog until an admin approves it. Resubmitting clears the previous review.
+func SubmitGear(db *sql.DB, gearID int64, userID int64) error {
+	_, err := db.Exec(`UPDATE gear SET gearStatus = 0, gearReviewStatus = ?, gearSubmittedBy = ?,
+            gearSubmittedAt = strftime('%Y-%m-%dT%H:%M:%fZ', 'now'),
+            gearReviewReason = NULL, gearReviewedBy = NULL, gearReviewedAt = NULL
+        WHERE gearId = ?`, GearReviewPending, userID, gearID)
+	if err != nil {
+		return fmt.Errorf("submit gear: %w", err)
+	}
+	return nil
+}
+
+// SetGearStatus puts gear in or takes it out of the catalog without changing its review.
+func SetGearStatus(db *sql.DB, gearID int64, status bool) error {
+	if _, err := db.Exec("UPDATE gear SET gearStatus = ? WHERE gearId = ?", status, gearID); err != nil {
+		return fmt.Errorf("set gear status: %w", err)
+	}
+	return nil
+}
+
+// ReviewGear records an admin's decision on a piece of gear. Approving puts it in the
+// catalog and rejecting takes it out. It returns sql.ErrNoRows when the gear does not exist.
+func ReviewGear(db *sql.DB, gearID int64, reviewerID int64, decision models.GearReviewDecision) error {
+	var reason *string
+	if decision.Reason != nil {
+		trimmed := strings.TrimSpace(*decision.Reason)
+		if len([]rune(trimmed)) > maxReviewReasonLength {
+			return &ModerationError{Message: fmt.Sprintf("reason can be at most %d characters", maxReviewReasonLength)}
+		}
+		if trimmed != "" {
+			reason = &trimmed
+		}
+	}
+
+	var status string
+	switch decision.Decision {
+	case "approve":
+		status = GearReviewApproved
+	case "reject":
+		status = GearReviewRejected
+		if reason == nil {
+			return &ModerationError{Message: "a reason is required when rejecting gear"}
+		}
+	default:
+		return &ModerationError{Message: "decision must be approve or reject"}
+	}
+
+	result, err := db.Exec(`UPDATE gear SET gearStatus = ?, gearReviewStatus = ?, gearReviewReason = ?, gearReviewedBy = ?,
+            gearReviewedAt = strftime('%Y-%m-%dT%H:%M:%fZ', 'now')
+        WHERE gearId = ?`, status == GearReviewApproved, status, reason, reviewerID, gearID)
+	if err != nil {
+		return fmt.Errorf("review gear: %w", err)
+	}
+	if updated, err := result.RowsAffected(); err != nil {
+		return err
+	} else if updated == 0 {
+		return sql.ErrNoRows
+	}
+	return nil
+}
@@ -0,0 +1,242 @@
+package utils
+
+import (
+	"database/sql"
+	"fmt"
+	"math"
+	"sort"
+	"time"
+
+	models "github.com/Sea-Shell/gogear-api/pkg/models"
+)
+
+const (
+	// recommendationMaxAge is how long the co-ownership counts are used before they are
+	// recomputed.
+	recommendationMaxAge = 15 * time.Minute
+	// maxGearNeighbours is how many of the most related gear are kept for each gear.
+	maxGearNeighbours = 100
+	// MaxRecommendationLimit is the most gear a recommendation returns.
+	MaxRecommendationLimit = 50
+)
+
+// gearBasketsQuery lists the gear that belongs together: the gear each user registered or
+// packed in a loadout, and the gear packed together in each loadout.
+const gearBasketsQuery = `SELECT 'user:' || userId, gearId FROM user_gear_registrations
+    UNION
+    SELECT 'user:' || loadouts.userId, loadout_items.gearId FROM loadout_items
+        JOIN loadouts ON loadouts.loadoutId = loadout_items.loadoutId
+    UNION
+    SELECT 'loadout:' || loadoutId, gearId FROM loadout_items
+    ORDER BY 1`
+
+type gearNeighbour struct {
+	gearID int64
+	count  int64
+	score  float64
+}
+
+// recommendationSnapshot holds, for each gear, the gear most often owned or packed with it.
+type recommendationSnapshot struct {
+	catalogGear
+	neighbours map[int64][]gearNeighbour
+}
+
+// GearRecommender recommends gear from co-ownership: gear that is registered by the same
+// users or packed in the same loadouts. The co-ownership counts are computed in-process
+// from the database and recomputed periodically, like GearStats.
+type GearRecommender struct {
+	cache periodicCache[recommendationSnapshot]
+}
+
+// NewGearRecommender returns a recommender that loads from the database on first use.
+func NewGearRecommender() *GearRecommender {
+	return &GearRecommender{cache: periodicCache[recommendationSnapshot]{maxAge: recommendationMaxAge, build: buildRecommendationSnapshot}}
+}
+
+// ForGear recommends gear owned together with gearID that userID does not own yet, leaving
+// out gear in the same category as gearID. category limits the recommendations to a
+// category and the categories below it.
+func (recommender *GearRecommender) ForGear(db *sql.DB, gearID int64, userID int64, category *int64, limit int) ([]models.GearRecommendation, error) {
+	snapshot, err := recommender.cache.load(db)
+	if err != nil {
+		return nil, err
+	}
+	owned, err := ownedGear(db, userID)
+	if err != nil {
+		return nil, err
+	}
+
+	var sourceCategory *int64
+	if source, ok := snapshot.gear[gearID]; ok {
+		sourceCategory = &source.GearCategoryID
+	}
+
+	recommendations := []models.GearRecommendation{}
+	for _, neighbour := range snapshot.neighbours[gearID] {
+		if len(recommendations) == limit {
+			break
+		}
+		gear, ok := snapshot.lookup(neighbour.gearID, category)
+		if !ok || owned[neighbour.gearID] || (sourceCategory != nil && gear.GearCategoryID == *sourceCategory) {
+			continue
+		}
+		recommendations = append(recommendations, models.GearRecommendation{
+			Score: neighbour.score, Count: neighbour.count, BecauseGearIDs: []int64{gearID}, Gear: gear,
+		})
+	}
+	return recommendations, nil
+}
+
+// ForUser recommends gear that userID does not own yet, scored by how strongly it is owned
+// together with the gear the user registered. Gear in categories the user already owns
+// gear in is only recommended when it is owned together with several of the user's gear.
+// category limits the recommendations to a category and the categories below it.
+func (recommender *GearRecommender) ForUser(db *sql.DB, userID int64, category *int64, limit int) ([]models.GearRecommendation, error) {
+	snapshot, err := recommender.cache.load(db)
+	if err != nil {
+		return nil, err
+	}
+	owned, err := ownedGear(db, userID)
+	if err != nil {
+		return nil, err
+	}
+
+	ownedCategories := make(map[int64]bool)
+	for gearID := range owned {
+		if gear, ok := snapshot.gear[gearID]; ok {
+			ownedCategories[gear.GearCategoryID] = true
+		}
+	}
+
+	candidates := make(map[int64]*models.GearRecommendation)
+	for gearID := range owned {
+		for _, neighbour := range snapshot.neighbours[gearID] {
+			if owned[neighbour.gearID] {
+				continue
+			}
+			gear, ok := snapshot.lookup(neighbour.gearID, category)
+			if !ok {
+				continue
+			}
+			candidate, ok := candidates[neighbour.gearID]
+			if !ok {
+				candidate = &models.GearRecommendation{Gear: gear}
+				candidates[neighbour.gearID] = candidate
+			}
+			candidate.Score += neighbour.score
+			candidate.Count += neighbour.count
+			candidate.BecauseGearIDs = append(candidate.BecauseGearIDs, gearID)
+		}
+	}
+
+	recommendations := []models.GearRecommendation{}
+	for _, candidate := range candidates {
+		// A second tent is rarely what someone with a tent is missing.
+		if ownedCategories[candidate.Gear.GearCategoryID] && len(candidate.BecauseGearIDs) < 2 {
+			continue
+		}
+		sort.Slice(candidate.BecauseGearIDs, func(i, j int) bool { return candidate.BecauseGearIDs[i] < candidate.BecauseGearIDs[j] })
+		recommendations = append(recommendations, *candidate)
+	}
+	sort.Slice(recommendations, func(i, j int) bool {
+		if recommendations[i].Score != recommendations[j].Score {
+			return recommendations[i].Score > recommendations[j].Score
+		}
+		return recommendations[i].Gear.GearID < recommendations[j].Gear.GearID
+	})
+	if len(recommendations) > limit {
+		recommendations = recommendations[:limit]
+	}
+	return recommendations, nil
+}
+
+// ownedGear returns the IDs of the gear userID registered.
+func ownedGear(db *sql.DB, userID int64) (map[int64]bool, error) {
+	ids, err := queryIDs(db, "SELECT DISTINCT gearId FROM user_gear_registrations WHERE userId = ?", userID)
+	if err != nil {
+		return nil, fmt.Errorf("query owned gear: %w", err)
+	}
+	owned := make(map[int64]bool, len(ids))
+	for _, id := range ids {
+		owned[id] = true
+	}
+	return owned, nil
+}
+
+// buildRecommendationSnapshot counts in how many baskets each pair of gear occurs together
+// and keeps the strongest pairs of each gear. The strength is the cosine similarity of the
+// baskets holding either, so gear owned by everyone does not dominate every list.
+func buildRecommendationSnapshot(db *sql.DB) (*recommendationSnapshot, error) {
+	snapshot := &recommendationSnapshot{neighbours: make(map[int64][]gearNeighbour)}
+
+	var err error
+	if snapshot.catalogGear, err = loadCatalogGear(db); err != nil {
+		return nil, err
+	}
+
+	rows, err := db.Query(gearBasketsQuery)
+	if err != nil {
+		return nil, fmt.Errorf("query gear baskets: %w", err)
+	}
+	defer rows.Close()
+
+	baskets := make(map[int64]int64)
+	pairs := make(map[[2]int64]int64)
+	var basketKey string
+	var basket []int64
+	countBasket := func() {
+		for i, a := range basket {
+			baskets[a]++
+			for _, b := range basket[i+1:] {
+				if a > b {
+					pairs[[2]int64{b, a}]++
+				} else {
+					pairs[[2]int64{a, b}]++
+				}
+			}
+		}
+	}
+	for rows.Next() {
+		var key string
+		var gearID int64
+		if err := rows.Scan(&key, &gearID); err != nil {
+			return nil, fmt.Errorf("scan gear baskets: %w", err)
+		}
+		if key != basketKey {
+			countBasket()
+			basketKey, basket = key, basket[:0]
+		}
+		// Only catalog gear is counted; user's own unapproved gear is never recommended.
+		if _, ok := snapshot.gear[gearID]; ok {
+			basket = append(basket, gearID)
+		}
+	}
+	if err := rows.Err(); err != nil {
+		return nil, fmt.Errorf("query gear baskets: %w", err)
+	}
+	countBasket()
+
+	for pair, count := range pairs {
+		score := float64(count) / math.Sqrt(float64(baskets[pair[0]]*baskets[pair[1]]))
+		snapshot.neighbours[pair[0]] = append(snapshot.neighbours[pair[0]], gearNeighbour{gearID: pair[1], count: count, score: score})
+		snapshot.neighbours[pair[1]] = append(snapshot.neighbours[pair[1]], gearNeighbour{gearID: pair[0], count: count, score: score})
+	}
+	for gearID, neighbours := range snapshot.neighbours {
+		sort.Slice(neighbours, func(i, j int) bool {
+			if neighbours[i].score != neighbours[j].score {
+				return neighbours[i].score > neighbours[j].score
+			}
+			if neighbours[i].count != neighbours[j].count {
+				return neighbours[i].count > neighbours[j].count
+			}
+			return neighbours[i].gearID < neighbours[j].gearID
+		})
+		if len(neighbours) > maxGearNeighbours {
+			neighbours = neighbours[:maxGearNeighbours]
+		}
+		snapshot.neighbours[gearID] = neighbours
+	}
+
+	return snapshot, nil
+}
@@ -0,0 +1,112 @@
+package utils
+
+import (
+	"database/sql"
+	"fmt"
+	"strings"
+
+	models "github.com/Sea-Shell/gogear-api/pkg/models"
+)
+
+const maxReviewTextLength = 5000
+
+// ReviewError reports an invalid review.
+type ReviewError struct {
+	Message string
+}
+
+func (e *ReviewError) Error() string {
+	return e.Message
+}
+
+// GearReviewJoins joins reviews with the name of their author.
+const GearReviewJoins = ` LEFT JOIN users ON gear_reviews.userId = users.userId`
+
+// GearReviewByID returns a review with its author's name, or sql.ErrNoRows.
+func GearReviewByID(db *sql.DB, reviewID int64) (*models.GearReview, error) {
+	return GenericGet[models.GearReview]("gear_reviews", int(reviewID), []string{GearReviewJoins + " "}, db)
+}
+
+// OwnsGear reports whether a user has registered a piece of gear.
+func OwnsGear(db *sql.DB, userID int64, gearID int64) (bool, error) {
+	var owns bool
+	err := db.QueryRow("SELECT EXISTS (SELECT 1 FROM user_gear_registrations WHERE userId = ? AND gearId = ?)", userID, gearID).Scan(&owns)
+	if err != nil {
+		return false, fmt.Errorf("check gear ownership: %w", err)
+	}
+	return owns, nil
+}
+
+// normalizeReview checks the rating and trims the text of a review, dropping empty text.
+func normalizeReview(review *models.GearReviewNoID) error {
+	if review.ReviewRating < 1 || review.ReviewRating > 5 {
+		return &ReviewError{Message: "review_rating must be between 1 and 5"}
+	}
+	if review.ReviewText != nil {
+		text := strings.TrimSpace(*review.ReviewText)
+		if len([]rune(text)) > maxReviewTextLength {
+			return &ReviewError{Message: fmt.Sprintf("review_text can be at most %d characters", maxReviewTextLength)}
+		}
+		review.ReviewText = &text
+		if text == "" {
+			review.ReviewText = nil
+		}
+	}
+	return nil
+}
+
+// InsertGearReview stores a user's review of a piece of gear and returns its ID. A second
+// review by the same user fails on the unique index.
+func InsertGearReview(db *sql.DB, gearID int64, userID int64, review models.GearReviewNoID) (int64, error) {
+	if err := normalizeReview(&review); err != nil {
+		return 0, err
+	}
+
+	result, err := db.Exec("INSERT INTO gear_reviews (gearId, userId, reviewRating, reviewText) VALUES (?, ?, ?, ?)",
+		gearID, userID, review.ReviewRating, review.ReviewText)
+	if err != nil {
+		return 0, err
+	}
+	return result.LastInsertId()
+}
+
+// UpdateGearReview replaces the rating and text of a review.
+func UpdateGearReview(db *sql.DB, reviewID int64, review models.GearReviewNoID) error {
+	if err := normalizeReview(&review); err != nil {
+		return err
+	}
+
+	_, err := db.Exec(`UPDATE gear_reviews SET reviewRating = ?, reviewText = ?,
+            updatedAt = strftime('%Y-%m-%dT%H:%M:%fZ', 'now')
+        WHERE reviewId = ?`, review.ReviewRating, review.ReviewText, reviewID)
+	if err != nil {
+		return fmt.Errorf("update review: %w", err)
+	}
+	return nil
+}
+
+// HideGearReview hides a review from other users and from the gear's rating, or shows it
+// again. The reason is kept while the review is hidden.
+func HideGearReview(db *sql.DB, reviewID int64, adminID int64, hide models.GearReviewHide) error {
+	var reason *string
+	if hide.Hidden && hide.Reason != nil {
+		trimmed := strings.TrimSpace(*hide.Reason)
+		if len([]rune(trimmed)) > maxReviewReasonLength {
+			return &ReviewError{Message: fmt.Sprintf("reason can be at most %d characters", maxReviewReasonLength)}
+		}
+		if trimmed != "" {
+			reason = &trimmed
+		}
+	}
+
+	var hiddenBy *int64
+	if hide.Hidden {
+		hiddenBy = &adminID
+	}
+	_, err := db.Exec("UPDATE gear_reviews SET reviewHidden = ?, reviewHiddenReason = ?, reviewHiddenBy = ? WHERE reviewId = ?",
+		hide.Hidden, reason, hiddenBy, reviewID)
+	if err != nil {
+		return fmt.Errorf("hide review: %w", err)
+	}
+	return nil
+}
@@ -0,0 +1,367 @@
+package utils
+
+import (
+	"database/sql"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"reflect"
+	"strings"
+
+	models "github.com/Sea-Shell/gogear-api/pkg/models"
+)
+
+// Kinds of catalog entries with a revision history.
+const (
+	RevisionKindGear        = "gear"
+	RevisionKindCategory    = "category"
+	RevisionKindTopCategory = "top_category"
+	RevisionKindManufacture = "manufacture"
+)
+
+// Actions that create a revision.
+const (
+	RevisionCreate = "create"
+	RevisionUpdate = "update"
+	RevisionRevert = "revert"
+)
+
+// RevisionError reports a revision request that cannot be carried out.
+type RevisionError struct {
+	Message string
+}
+
+func (e *RevisionError) Error() string {
+	return e.Message
+}
+
+// revisionColumn is a tracked catalog column and its json field name in revisionData.
+type revisionColumn struct {
+	column   string
+	jsonName string
+}
+
+// revisionTable is a catalog table with a revision history. Its triggers store the tracked
+// columns as revisions; a revert writes them back in this order.
+type revisionTable struct {
+	kind     string
+	table    string
+	idColumn string
+	name     string
+	columns  []revisionColumn
+}
+
+var revisionTables = []*revisionTable{
+	{
+		kind: RevisionKindGear, table: "gear", idColumn: "gearId", name: "gear",
+		columns: []revisionColumn{
+			{column: "gearCategoryId", jsonName: "gear_category_id"},
+			{column: "gearManufactureId", jsonName: "gear_manufacture_id"},
+			{column: "gearIsContainer", jsonName: "gear_is_container"},
+			{column: "gearName", jsonName: "gear_name"},
+			{column: "gearSizeDefinition", jsonName: "gear_size_definition"},
+			{column: "gearWeight", jsonName: "gear_weight"},
+			{column: "gearHeight", jsonName: "gear_height"},
+			{column: "gearLength", jsonName: "gear_length"},
+			{column: "gearWidth", jsonName: "gear_width"},
+		},
+	},
+	{
+		kind: RevisionKindCategory, table: "gear_category", idColumn: "categoryId", name: "category",
+		columns: []revisionColumn{
+			{column: "categoryTopCategoryId", jsonName: "category_top_category_id"},
+			{column: "categoryParentId", jsonName: "category_parent_id"},
+			{column: "categoryName", jsonName: "category_name"},
+		},
+	},
+	{
+		kind: RevisionKindTopCategory, table: "gear_top_category", idColumn: "topCategoryId", name: "top category",
+		columns: []revisionColumn{
+			{column: "topCategoryName", jsonName: "top_category_name"},
+			{column: "topCategoryIcon", jsonName: "top_category_icon"},
+		},
+	},
+	{
+		kind: RevisionKindManufacture, table: "manufacture", idColumn: "manufactureId", name: "manufacturer",
+		columns: []revisionColumn{
+			{column: "manufactureName", jsonName: "manufacture_name"},
+			{column: "manufactureWebsite", jsonName: "manufacture_website"},
+			{column: "manufactureCountry", jsonName: "manufacture_country"},
+			{column: "manufactureFoundedYear", jsonName: "manufacture_founded_year"},
+			{column: "manufactureLogoUrl", jsonName: "manufacture_logo_url"},
+			{column: "manufactureDescription", jsonName: "manufacture_description"},
+		},
+	},
+}
+
+// revisionTableFor returns the revision table of kind.
+func revisionTableFor(kind string) (*revisionTable, error) {
+	for _, table := range revisionTables {
+		if table.kind == kind {
+			return table, nil
+		}
+	}
+	return nil, &RevisionError{Message: fmt.Sprintf("unknown revision kind %q", kind)}
+}
+
+// revisionEntry returns the revision table of kind and sql.ErrNoRows when the entry with
+// id does not exist.
+func revisionEntry(db queryer, kind string, id int64) (*revisionTable, error) {
+	table, err := revisionTableFor(kind)
+	if err != nil {
+		return nil, err
+	}
+	var exists bool
+	query := fmt.Sprintf("SELECT EXISTS (SELECT 1 FROM %s WHERE %s = ?)", table.table, table.idColumn)
+	if err := db.QueryRow(query, id).Scan(&exists); err != nil {
+		return nil, fmt.Errorf("check %s: %w", table.name, err)
+	}
+	if !exists {
+		return nil, sql.ErrNoRows
+	}
+	return table, nil
+}
+
+const revisionSelect = `SELECT revisionNumber, revisionAction, revisionData, revisionRestored, revisionCreatedAt
+        FROM catalog_revisions WHERE entityKind = ? AND entityId = ?`
+
+type rowScanner interface {
+	Scan(dest ...interface{}) error
+}
+
+func scanRevision(row rowScanner, kind string, id int64) (*models.CatalogRevision, error) {
+	revision := models.CatalogRevision{Kind: kind, EntityID: id}
+	var data string
+	if err := row.Scan(&revision.Revision, &revision.Action, &data, &revision.RestoredRevision, &revision.CreatedAt); err != nil {
+		return nil, err
+	}
+	// Numbers stay json.Number so revisions compare and restore without float rounding.
+	decoder := json.NewDecoder(strings.NewReader(data))
+	decoder.UseNumber()
+	if err := decoder.Decode(&revision.Data); err != nil {
+		return nil, fmt.Errorf("decode revision %d of %s %d: %w", revision.Revision, kind, id, err)
+	}
+	return &revision, nil
+}
+
+// revisionByNumber returns revision number of an entry, or sql.ErrNoRows.
+func revisionByNumber(db queryer, kind string, id int64, number int64) (*models.CatalogRevision, error) {
+	return scanRevision(db.QueryRow(revisionSelect+" AND revisionNumber = ?", kind, id, number), kind, id)
+}
+
+// latestRevision returns the number of the newest revision of an entry, 0 without any.
+func latestRevision(db queryer, kind string, id int64) (int64, error) {
+	var number int64
+	err := db.QueryRow("SELECT COALESCE(MAX(revisionNumber), 0) FROM catalog_revisions WHERE entityKind = ? AND entityId = ?",
+		kind, id).Scan(&number)
+	if err != nil {
+		return 0, fmt.Errorf("query latest revision: %w", err)
+	}
+	return number, nil
+}
+
+// Revisions lists the revisions of a catalog entry, newest first. It returns sql.ErrNoRows
+// when the entry does not exist.
+func Revisions(db *sql.DB, kind string, id int64) ([]models.CatalogRevision, error) {
+	if _, err := revisionEntry(db, kind, id); err != nil {
+		return nil, err
+	}
+
+	rows, err := db.Query(revisionSelect+" ORDER BY revisionNumber DESC", kind, id)
+	if err != nil {
+		return nil, fmt.Errorf("query revisions: %w", err)
+	}
+	defer rows.Close()
+
+	revisions := []models.CatalogRevision{}
+	for rows.Next() {
+		revision, err := scanRevision(rows, kind, id)
+		if err != nil {
+			return nil, err
+		}
+		revisions = append(revisions, *revision)
+	}
+	return revisions, rows.Err()
+}
+
+// DiffRevisions lists the tracked fields that differ from revision from to revision to of a
+// catalog entry, in column order. to is the newest revision when 0. It returns
+// sql.ErrNoRows when the entry or either revision does not exist.
+func DiffRevisions(db *sql.DB, kind string, id int64, from int64, to int64) (*models.RevisionDiff, error) {
+	table, err := revisionEntry(db, kind, id)
+	if err != nil {
+		return nil, err
+	}
+	if to == 0 {
+		if to, err = latestRevision(db, kind, id); err != nil {
+			return nil, err
+		}
+	}
+
+	older, err := revisionByNumber(db, kind, id, from)
+	if err != nil {
+		return nil, err
+	}
+	newer, err := revisionByNumber(db, kind, id, to)
+	if err != nil {
+		return nil, err
+	}
+
+	diff := models.RevisionDiff{Kind: kind, EntityID: id, From: from, To: to, Changes: []models.RevisionChange{}}
+	for _, column := range table.columns {
+		before, after := older.Data[column.jsonName], newer.Data[column.jsonName]
+		if !reflect.DeepEqual(before, after) {
+			diff.Changes = append(diff.Changes, models.RevisionChange{Field: column.jsonName, From: before, To: after})
+		}
+	}
+	return &diff, nil
+}
+
+// RevertRevision writes the values of revision number back to a catalog entry and records
+// them as a new revert revision, which it returns; earlier revisions are kept. A category
+// returns to its former parent, or to its former top category when it had none, along with
+// everything below it. It returns sql.ErrNoRows when the entry or the revision does not
+// exist, and a RevisionError when the entry already holds those values or refers to a
+// category or manufacturer that no longer exists.
+func RevertRevision(db *sql.DB, kind string, id int64, number int64) (*models.CatalogRevision, error) {
+	tx, err := db.Begin()
+	if err != nil {
+		return nil, err
+	}
+	defer tx.Rollback()
+
+	table, err := revisionEntry(tx, kind, id)
+	if err != nil {
+		return nil, err
+	}
+	target, err := revisionByNumber(tx, kind, id, number)
+	if err != nil {
+		return nil, err
+	}
+	latest, err := latestRevision(tx, kind, id)
+	if err != nil {
+		return nil, err
+	}
+
+	values := make(map[string]interface{}, len(table.columns))
+	for _, column := range table.columns {
+		values[column.jsonName] = revisionValue(target.Data[column.jsonName])
+	}
+
+	switch kind {
+	case RevisionKindGear:
+		if err := checkRevisionReference(tx, "gear_category", "categoryId", "category", values["gear_category_id"]); err != nil {
+			return nil, err
+		}
+		if err := checkRevisionReference(tx, "manufacture", "manufactureId", "manufacturer", values["gear_manufacture_id"]); err != nil {
+			return nil, err
+		}
+	case RevisionKindCategory:
+		topCategoryID, err := revertCategoryParent(tx, id, values)
+		if err != nil {
+			return nil, err
+		}
+		values["category_top_category_id"] = topCategoryID
+	}
+
+	assignments := make([]string, len(table.columns))
+	args := make([]interface{}, 0, len(table.columns)+1)
+	for i, column := range table.columns {
+		assignments[i] = column.column + " = ?"
+		args = append(args, values[column.jsonName])
+	}
+	query := fmt.Sprintf("UPDATE %s SET %s WHERE %s = ?", table.table, strings.Join(assignments, ", "), table.idColumn)
+	if _, err := tx.Exec(query, append(args, id)...); err != nil {
+		return nil, fmt.Errorf("revert %s: %w", table.name, err)
+	}
+
+	// The triggers record each statement that changed the entry; a revert keeps the last
+	// of them, which holds the final values, as the one revision after latest.
+	newest, err := latestRevision(tx, kind, id)
+	if err != nil {
+		return nil, err
+	}
+	if newest == latest {
+		return nil, &RevisionError{Message: fmt.Sprintf("%s %d already matches revision %d", table.name, id, number)}
+	}
+	if _, err := tx.Exec("DELETE FROM catalog_revisions WHERE entityKind = ? AND entityId = ? AND revisionNumber > ? AND revisionNumber < ?",
+		kind, id, latest, newest); err != nil {
+		return nil, fmt.Errorf("collapse revert revisions: %w", err)
+	}
+	if _, err := tx.Exec("UPDATE catalog_revisions SET revisionNumber = ?, revisionAction = ?, revisionRestored = ? WHERE entityKind = ? AND entityId = ? AND revisionNumber = ?",
+		latest+1, RevisionRevert, number, kind, id, newest); err != nil {
+		return nil, fmt.Errorf("record revert revision: %w", err)
+	}
+
+	revision, err := revisionByNumber(tx, kind, id, latest+1)
+	if err != nil {
+		return nil, err
+	}
+	if err := tx.Commit(); err != nil {
+		return nil, err
+	}
+	return revision, nil
+}
+
+// revertCategoryParent moves a category back to the parent, or the top category, in values
+// when it has moved since, and returns the top category it ends up in.
+func revertCategoryParent(tx *sql.Tx, categoryID int64, values map[string]interface{}) (int64, error) {
+	var currentParent sql.NullInt64
+	var currentTop int64
+	err := tx.QueryRow("SELECT categoryParentId, categoryTopCategoryId FROM gear_category WHERE categoryId = ?",
+		categoryID).Scan(&currentParent, &currentTop)
+	if err != nil {
+		return 0, fmt.Errorf("query category: %w", err)
+	}
+
+	parentID, _ := values["category_parent_id"].(int64)
+	topCategoryID, _ := values["category_top_category_id"].(int64)
+	move := models.CategoryMove{}
+	if values["category_parent_id"] != nil {
+		if currentParent.Valid && currentParent.Int64 == parentID {
+			return currentTop, nil
+		}
+		move.ParentID = &parentID
+	} else {
+		if !currentParent.Valid && currentTop == topCategoryID {
+			return currentTop, nil
+		}
+		move.TopCategoryID = &topCategoryID
+	}
+
+	topCategoryID, err = moveCategory(tx, categoryID, move)
+	var treeErr *CategoryTreeError
+	if errors.As(err, &treeErr) {
+		return 0, &RevisionError{Message: treeErr.Message}
+	}
+	return topCategoryID, err
+}
+
+// checkRevisionReference returns a RevisionError when the row of table with id, which a
+// revision refers to, no longer exists.
+func checkRevisionReference(tx *sql.Tx, table string, idColumn string, name string, id interface{}) error {
+	var exists bool
+	query := fmt.Sprintf("SELECT EXISTS (SELECT 1 FROM %s WHERE %s = ?)", table, idColumn)
+	if err := tx.QueryRow(query, id).Scan(&exists); err != nil {
+		return fmt.Errorf("check %s: %w", name, err)
+	}
+	if !exists {
+		return &RevisionError{Message: fmt.Sprintf("%s %v no longer exists", name, id)}
+	}
+	return nil
+}
+
+// revisionValue converts a value decoded from revisionData to a query argument.
+func revisionValue(value interface{}) interface{} {
+	number, ok := value.(json.Number)
+	if !ok {
+		return value
+	}
+	if i, err := number.Int64(); err == nil {
+		return i
+	}
+	if f, err := number.Float64(); err == nil {
+		return f
+	}
+	return number.String()
+}
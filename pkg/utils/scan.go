@@ -0,0 +1,118 @@
+package utils
+
+import (
+	"database/sql"
+	"errors"
+	"reflect"
+	"sync"
+)
+
+// RowScanner is implemented by models with generated scan functions (see cmd/scangen),
+// which the scan helpers use instead of reflection.
+type RowScanner interface {
+	ScanFields() []interface{}
+}
+
+// rowPlan is the cached column layout of a model: its db columns and the index of
+// the struct field each one scans into.
+type rowPlan struct {
+	columns []string
+	fields  []int
+}
+
+var rowPlans sync.Map
+
+// planFor returns the cached row plan of model, building it on first use.
+func planFor[model any]() (*rowPlan, error) {
+	var params model
+	modelType := reflect.TypeOf(params)
+
+	if plan, ok := rowPlans.Load(modelType); ok {
+		return plan.(*rowPlan), nil
+	}
+
+	if modelType == nil || modelType.Kind() != reflect.Struct {
+		return nil, errors.New("input must be a struct")
+	}
+
+	plan := &rowPlan{}
+	for i := 0; i < modelType.NumField(); i++ {
+		if column := modelType.Field(i).Tag.Get("db"); column != "" {
+			plan.columns = append(plan.columns, column)
+			plan.fields = append(plan.fields, i)
+		}
+	}
+	if len(plan.columns) == 0 {
+		return nil, errors.New("model has no db-tagged fields")
+	}
+
+	actual, _ := rowPlans.LoadOrStore(modelType, plan)
+	return actual.(*rowPlan), nil
+}
+
+// columnsFor returns the db columns of model in field order. The slice is shared
+// and must not be modified.
+func columnsFor[model any]() ([]string, error) {
+	plan, err := planFor[model]()
+	if err != nil {
+		return nil, err
+	}
+	return plan.columns, nil
+}
+
+// scanDest returns pointers to the fields of item in column order, from its generated
+// ScanFields when available.
+func scanDest[model any](plan *rowPlan, item *model) []interface{} {
+	if scanner, ok := any(item).(RowScanner); ok {
+		return scanner.ScanFields()
+	}
+
+	itemValue := reflect.ValueOf(item).Elem()
+	dest := make([]interface{}, len(plan.fields))
+	for i, field := range plan.fields {
+		dest[i] = itemValue.Field(field).Addr().Interface()
+	}
+	return dest
+}
+
+// ScanRow scans a single row, such as the result of QueryRow, into a model.
+func ScanRow[model any](row interface{ Scan(...interface{}) error }) (*model, error) {
+	plan, err := planFor[model]()
+	if err != nil {
+		return nil, err
+	}
+
+	var item model
+	if err := row.Scan(scanDest(plan, &item)...); err != nil {
+		return nil, err
+	}
+	return &item, nil
+}
+
+// ScanRows scans every remaining row into a model, matching columns to db-tagged
+// fields by position.
+func ScanRows[model any](rows *sql.Rows) ([]model, error) {
+	plan, err := planFor[model]()
+	if err != nil {
+		return nil, err
+	}
+
+	// Scanning replaces every field, pointers included, so one destination is reused
+	// and copied for each row.
+	var item model
+	dest := scanDest(plan, &item)
+
+	var list []model
+	for rows.Next() {
+		if err := rows.Scan(dest...); err != nil {
+			return nil, err
+		}
+		list = append(list, item)
+	}
+
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+
+	return list, nil
+}
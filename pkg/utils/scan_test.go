@@ -0,0 +1,152 @@
+package utils
+
+import (
+	"database/sql"
+	"fmt"
+	"reflect"
+	"testing"
+
+	models "github.com/Sea-Shell/gogear-api/pkg/models"
+)
+
+type scanTestItem struct {
+	ItemID   *int64 `db:"gearId"`
+	Computed string
+	ItemName string  `db:"gearName"`
+	ItemNote *string `db:"gearSizeDefinition"`
+}
+
+func TestScanRowsSkipsUntaggedFieldsAndKeepsPointersPerRow(t *testing.T) {
+	db := listTestDB(t)
+
+	rows, err := db.Query("SELECT gearId, gearName, CASE WHEN gearId % 2 = 0 THEN gearSizeDefinition END FROM gear ORDER BY gearId")
+	if err != nil {
+		t.Fatalf("query: %v", err)
+	}
+	defer rows.Close()
+
+	items, err := ScanRows[scanTestItem](rows)
+	if err != nil {
+		t.Fatalf("scan: %v", err)
+	}
+	if len(items) != 4 {
+		t.Fatalf("items = %d, want 4", len(items))
+	}
+
+	for i, item := range items {
+		if *item.ItemID != int64(i+1) {
+			t.Errorf("item %d: id = %d", i, *item.ItemID)
+		}
+		if (item.ItemNote != nil) != (i%2 == 1) {
+			t.Errorf("item %d: note = %v", i, item.ItemNote)
+		}
+	}
+	if *items[1].ItemNote != "b" || *items[3].ItemNote != "d" {
+		t.Errorf("notes = %q/%q, want b/d", *items[1].ItemNote, *items[3].ItemNote)
+	}
+}
+
+func TestScanRowUsesGeneratedScanFields(t *testing.T) {
+	db := listTestDB(t)
+
+	manufacture, err := ScanRow[models.Manufacture](db.QueryRow("SELECT gearId, gearName, NULL, NULL, NULL, NULL, NULL FROM gear WHERE gearId = 2"))
+	if err != nil {
+		t.Fatalf("scan: %v", err)
+	}
+	if *manufacture.ManufactureID != 2 || manufacture.ManufactureName != "stove" {
+		t.Errorf("manufacture = %+v", manufacture)
+	}
+
+	if _, err := ScanRow[models.Manufacture](db.QueryRow("SELECT gearId, gearName, NULL, NULL, NULL, NULL, NULL FROM gear WHERE gearId = 99")); err != sql.ErrNoRows {
+		t.Errorf("missing row: err = %v, want sql.ErrNoRows", err)
+	}
+}
+
+func benchmarkGearRows(b *testing.B) *sql.DB {
+	b.Helper()
+	db, err := sql.Open("sqlite3", ":memory:")
+	if err != nil {
+		b.Fatalf("open :memory: %v", err)
+	}
+	b.Cleanup(func() { db.Close() })
+	db.SetMaxOpenConns(1)
+
+	if _, err := db.Exec(`CREATE TABLE gear (gearId INTEGER PRIMARY KEY, gearTopCategoryId INTEGER, gearCategoryId INTEGER,
+        gearManufactureId INTEGER, gearIsContainer INTEGER, gearName TEXT, gearSizeDefinition TEXT)`); err != nil {
+		b.Fatalf("create: %v", err)
+	}
+	for i := 1; i <= 500; i++ {
+		if _, err := db.Exec(`INSERT INTO gear VALUES (?, 1, 2, 3, 0, ?, 'M')`, i, fmt.Sprintf("gear %d", i)); err != nil {
+			b.Fatalf("insert: %v", err)
+		}
+	}
+	return db
+}
+
+// gearScanQuery selects the GearListItem columns, reusing gear columns for the joined ones.
+const gearScanQuery = `SELECT gearId, gearTopCategoryId, gearCategoryId, gearManufactureId, gearIsContainer, gearName,
+    gearSizeDefinition, gearManufactureId, gearName, gearTopCategoryId, gearName, gearName, gearCategoryId, gearName, gearTopCategoryId FROM gear`
+
+// plainGearListItem has the GearListItem layout without its generated ScanFields.
+type plainGearListItem models.GearListItem
+
+// scanRowsReflectCopy is the previous scan loop: fresh reflect.New destinations
+// copied into the model field by field.
+func scanRowsReflectCopy[model any](rows *sql.Rows) ([]model, error) {
+	var params model
+	modelType := reflect.TypeOf(params)
+	dest := make([]interface{}, modelType.NumField())
+	for i := range dest {
+		dest[i] = reflect.New(modelType.Field(i).Type).Interface()
+	}
+
+	var list []model
+	for rows.Next() {
+		if err := rows.Scan(dest...); err != nil {
+			return nil, err
+		}
+		for i := 0; i < reflect.TypeOf(params).NumField(); i++ {
+			reflect.ValueOf(&params).Elem().Field(i).Set(reflect.ValueOf(dest[i]).Elem())
+		}
+		list = append(list, params)
+	}
+	return list, rows.Err()
+}
+
+func BenchmarkScanRows(b *testing.B) {
+	db := benchmarkGearRows(b)
+
+	run := func(b *testing.B, scan func(*sql.Rows) (int, error)) {
+		b.ReportAllocs()
+		for i := 0; i < b.N; i++ {
+			rows, err := db.Query(gearScanQuery)
+			if err != nil {
+				b.Fatalf("query: %v", err)
+			}
+			count, err := scan(rows)
+			rows.Close()
+			if err != nil || count != 500 {
+				b.Fatalf("scan: %d rows, %v", count, err)
+			}
+		}
+	}
+
+	b.Run("reflect-copy", func(b *testing.B) {
+		run(b, func(rows *sql.Rows) (int, error) {
+			items, err := scanRowsReflectCopy[models.GearListItem](rows)
+			return len(items), err
+		})
+	})
+	b.Run("cached-plan", func(b *testing.B) {
+		run(b, func(rows *sql.Rows) (int, error) {
+			items, err := ScanRows[plainGearListItem](rows)
+			return len(items), err
+		})
+	})
+	b.Run("generated", func(b *testing.B) {
+		run(b, func(rows *sql.Rows) (int, error) {
+			items, err := ScanRows[models.GearListItem](rows)
+			return len(items), err
+		})
+	})
+}
@@ -0,0 +1,115 @@
+package utils
+
+import (
+	"database/sql"
+	"strings"
+	"unicode"
+)
+
+// GearSearchTable is the FTS5 table indexing gear together with its manufacturer and category names.
+const GearSearchTable = "gear_search"
+
+// gearSearchSelect returns the indexed columns of every gear row matching where, keyed by gearId.
+func gearSearchSelect(where string) string {
+	return `INSERT INTO gear_search (rowid, name, manufacturer, category, topCategory, sizeDefinition)
+        SELECT gear.gearId, gear.gearName, manufacture.manufactureName, gear_category.categoryName, gear_top_category.topCategoryName, gear.gearSizeDefinition
+        FROM gear
+        LEFT JOIN manufacture ON gear.gearManufactureId = manufacture.manufactureId
+        LEFT JOIN gear_top_category ON gear.gearTopCategoryId = gear_top_category.topCategoryId
+        LEFT JOIN gear_category ON gear.gearCategoryId = gear_category.categoryId
+        WHERE ` + where + `;`
+}
+
+// gearSearchSchema creates the FTS5 table and the triggers keeping it in sync with
+// gear and the names it is joined with.
+var gearSearchSchema = []string{
+	`CREATE VIRTUAL TABLE IF NOT EXISTS gear_search USING fts5(
+        name, manufacturer, category, topCategory, sizeDefinition,
+        tokenize = 'unicode61 remove_diacritics 2', prefix = '2 3'
+    )`,
+	`CREATE TRIGGER IF NOT EXISTS gear_search_gear_insert AFTER INSERT ON gear BEGIN
+        ` + gearSearchSelect("gear.gearId = NEW.gearId") + `
+    END`,
+	`CREATE TRIGGER IF NOT EXISTS gear_search_gear_update AFTER UPDATE ON gear BEGIN
+        DELETE FROM gear_search WHERE rowid = OLD.gearId;
+        ` + gearSearchSelect("gear.gearId = NEW.gearId") + `
+    END`,
+	`CREATE TRIGGER IF NOT EXISTS gear_search_gear_delete AFTER DELETE ON gear BEGIN
+        DELETE FROM gear_search WHERE rowid = OLD.gearId;
+    END`,
+	`CREATE TRIGGER IF NOT EXISTS gear_search_manufacture_update AFTER UPDATE OF manufactureName ON manufacture BEGIN
+        DELETE FROM gear_search WHERE rowid IN (SELECT gearId FROM gear WHERE gearManufactureId = NEW.manufactureId);
+        ` + gearSearchSelect("gear.gearManufactureId = NEW.manufactureId") + `
+    END`,
+	`CREATE TRIGGER IF NOT EXISTS gear_search_category_update AFTER UPDATE OF categoryName ON gear_category BEGIN
+        DELETE FROM gear_search WHERE rowid IN (SELECT gearId FROM gear WHERE gearCategoryId = NEW.categoryId);
+        ` + gearSearchSelect("gear.gearCategoryId = NEW.categoryId") + `
+    END`,
+	`CREATE TRIGGER IF NOT EXISTS gear_search_top_category_update AFTER UPDATE OF topCategoryName ON gear_top_category BEGIN
+        DELETE FROM gear_search WHERE rowid IN (SELECT gearId FROM gear WHERE gearTopCategoryId = NEW.topCategoryId);
+        ` + gearSearchSelect("gear.gearTopCategoryId = NEW.topCategoryId") + `
+    END`,
+}
+
+// EnsureGearSearchIndex creates the gear full-text index and its triggers, filling it
+// from existing gear the first time. It is kept out of the migrations because FTS5 is only
+// compiled into go-sqlite3 with the sqlite_fts5 build tag; without it the index is skipped
+// and false is returned so search can fall back to LIKE matching.
+func EnsureGearSearchIndex(db *sql.DB) (bool, error) {
+	var compiled bool
+	if err := db.QueryRow("SELECT sqlite_compileoption_used('ENABLE_FTS5')").Scan(&compiled); err != nil {
+		return false, err
+	}
+	if !compiled {
+		return false, nil
+	}
+
+	exists, err := GearSearchIndexExists(db)
+	if err != nil {
+		return false, err
+	}
+
+	tx, err := db.Begin()
+	if err != nil {
+		return false, err
+	}
+	defer tx.Rollback()
+
+	for _, statement := range gearSearchSchema {
+		if _, err := tx.Exec(statement); err != nil {
+			return false, err
+		}
+	}
+
+	if !exists {
+		if _, err := tx.Exec(gearSearchSelect("1 = 1")); err != nil {
+			return false, err
+		}
+	}
+
+	return true, tx.Commit()
+}
+
+// GearSearchIndexExists reports whether the gear full-text index has been created.
+func GearSearchIndexExists(db *sql.DB) (bool, error) {
+	var count int
+	err := db.QueryRow("SELECT COUNT(*) FROM sqlite_master WHERE type = 'table' AND name = ?", GearSearchTable).Scan(&count)
+	return count > 0, err
+}
+
+// SearchTerms splits free text into lowercase words, dropping punctuation.
+func SearchTerms(input string) []string {
+	return strings.FieldsFunc(strings.ToLower(input), func(r rune) bool {
+		return !unicode.IsLetter(r) && !unicode.IsNumber(r)
+	})
+}
+
+// FTSMatchQuery turns free text into an FTS5 MATCH expression requiring every word,
+// each matched as a prefix. Words are quoted so FTS5 operators in the input are literal.
+func FTSMatchQuery(input string) string {
+	terms := SearchTerms(input)
+	for i, term := range terms {
+		terms[i] = `"` + term + `"*`
+	}
+	return strings.Join(terms, " ")
+}
@@ -0,0 +1,105 @@
+package utils
+
+import (
+	"database/sql"
+	"testing"
+)
+
+func TestFTSMatchQuery(t *testing.T) {
+	cases := map[string]string{
+		"msr stove":           `"msr"* "stove"*`,
+		`Tent 2P "OR" -ultra`: `"tent"* "2p"* "or"* "ultra"*`,
+		"  ,; ":               "",
+	}
+	for input, want := range cases {
+		if got := FTSMatchQuery(input); got != want {
+			t.Errorf("FTSMatchQuery(%q) = %q, want %q", input, got, want)
+		}
+	}
+}
+
+func TestEnsureGearSearchIndexKeepsIndexInSync(t *testing.T) {
+	db, err := sql.Open("sqlite3", ":memory:")
+	if err != nil {
+		t.Fatalf("open :memory: %v", err)
+	}
+	t.Cleanup(func() { db.Close() })
+	db.SetMaxOpenConns(1)
+
+	statements := []string{
+		`CREATE TABLE gear_top_category (topCategoryId INTEGER PRIMARY KEY, topCategoryName TEXT)`,
+		`CREATE TABLE gear_category (categoryId INTEGER PRIMARY KEY, categoryName TEXT)`,
+		`CREATE TABLE manufacture (manufactureId INTEGER PRIMARY KEY, manufactureName TEXT)`,
+		`CREATE TABLE gear (gearId INTEGER PRIMARY KEY, gearTopCategoryId INTEGER, gearCategoryId INTEGER, gearManufactureId INTEGER, gearName TEXT, gearSizeDefinition TEXT)`,
+		`INSERT INTO gear_top_category VALUES (1, 'Kitchen')`,
+		`INSERT INTO gear_category VALUES (1, 'Stoves')`,
+		`INSERT INTO manufacture VALUES (1, 'MSR')`,
+		`INSERT INTO gear VALUES (1, 1, 1, 1, 'PocketRocket', '')`,
+	}
+	for _, statement := range statements {
+		if _, err := db.Exec(statement); err != nil {
+			t.Fatalf("exec %q: %v", statement, err)
+		}
+	}
+
+	available, err := EnsureGearSearchIndex(db)
+	if err != nil {
+		t.Fatalf("ensure index: %v", err)
+	}
+	if !available {
+		t.Skip("SQLite built without FTS5; run with -tags sqlite_fts5")
+	}
+
+	matches := func(query string) []int64 {
+		t.Helper()
+		rows, err := db.Query("SELECT rowid FROM gear_search WHERE gear_search MATCH ? ORDER BY rank", FTSMatchQuery(query))
+		if err != nil {
+			t.Fatalf("match %q: %v", query, err)
+		}
+		defer rows.Close()
+		var ids []int64
+		for rows.Next() {
+			var id int64
+			if err := rows.Scan(&id); err != nil {
+				t.Fatalf("scan: %v", err)
+			}
+			ids = append(ids, id)
+		}
+		return ids
+	}
+
+	if ids := matches("msr stove"); len(ids) != 1 || ids[0] != 1 {
+		t.Errorf("existing gear: ids = %v, want [1]", ids)
+	}
+
+	if _, err := db.Exec(`INSERT INTO gear VALUES (2, 1, 1, 1, 'WindBurner', '')`); err != nil {
+		t.Fatalf("insert gear: %v", err)
+	}
+	if ids := matches("windb"); len(ids) != 1 || ids[0] != 2 {
+		t.Errorf("inserted gear: ids = %v, want [2]", ids)
+	}
+
+	if _, err := db.Exec(`UPDATE manufacture SET manufactureName = 'Mountain Safety Research'`); err != nil {
+		t.Fatalf("rename manufacturer: %v", err)
+	}
+	if ids := matches("msr"); len(ids) != 0 {
+		t.Errorf("renamed manufacturer: ids = %v, want none", ids)
+	}
+	if ids := matches("safety"); len(ids) != 2 {
+		t.Errorf("renamed manufacturer: ids = %v, want 2 matches", ids)
+	}
+
+	if _, err := db.Exec(`DELETE FROM gear WHERE gearId = 1`); err != nil {
+		t.Fatalf("delete gear: %v", err)
+	}
+	if ids := matches("pocket"); len(ids) != 0 {
+		t.Errorf("deleted gear: ids = %v, want none", ids)
+	}
+
+	if _, err := EnsureGearSearchIndex(db); err != nil {
+		t.Fatalf("ensure index again: %v", err)
+	}
+	if ids := matches("windburner"); len(ids) != 1 {
+		t.Errorf("second ensure: ids = %v, want 1 match", ids)
+	}
+}
@@ -0,0 +1,433 @@
+package utils
+
+import (
+	"database/sql"
+	"encoding/json"
+	"fmt"
+	"net/url"
+	"regexp"
+	"sort"
+	"strconv"
+	"strings"
+
+	models "github.com/Sea-Shell/gogear-api/pkg/models"
+)
+
+// Specification attribute types.
+const (
+	SpecTypeNumber = "number"
+	SpecTypeEnum   = "enum"
+	SpecTypeBool   = "bool"
+	SpecTypeText   = "text"
+)
+
+const (
+	maxSpecTextLength = 500
+	specFilterPrefix  = "spec."
+)
+
+var specAttributeName = regexp.MustCompile(`^[a-z][a-z0-9_]{0,63}$`)
+
+// SpecError reports an invalid schema attribute, spec value or spec filter.
+type SpecError struct {
+	Message string
+}
+
+func (e *SpecError) Error() string {
+	return e.Message
+}
+
+func specErrorf(format string, args ...interface{}) error {
+	return &SpecError{Message: fmt.Sprintf(format, args...)}
+}
+
+// specAttributeSelect selects the columns of models.SpecAttribute.
+const specAttributeSelect = `SELECT attributeId, categoryId, attributeName, attributeLabel, attributeType,
+        attributeUnit, attributeOptions, attributeRequired FROM category_spec_attributes`
+
+// ValidateSpecAttribute normalizes and checks an attribute definition. Names are lower
+// case identifiers, only enums have options and the label defaults to the name.
+func ValidateSpecAttribute(attribute *models.SpecAttributeNoID) error {
+	attribute.Name = strings.TrimSpace(attribute.Name)
+	attribute.Label = strings.TrimSpace(attribute.Label)
+	attribute.Type = strings.ToLower(strings.TrimSpace(attribute.Type))
+
+	if !specAttributeName.MatchString(attribute.Name) {
+		return specErrorf("name %q must start with a lower case letter and use only a-z, 0-9 and _", attribute.Name)
+	}
+	if attribute.Label == "" {
+		attribute.Label = attribute.Name
+	}
+	if attribute.Unit != nil {
+		unit := strings.TrimSpace(*attribute.Unit)
+		attribute.Unit = &unit
+		if unit == "" {
+			attribute.Unit = nil
+		}
+	}
+
+	switch attribute.Type {
+	case SpecTypeEnum:
+		seen := make(map[string]bool, len(attribute.Options))
+		options := make([]string, 0, len(attribute.Options))
+		for _, option := range attribute.Options {
+			option = strings.TrimSpace(option)
+			if option == "" || seen[option] {
+				continue
+			}
+			seen[option] = true
+			options = append(options, option)
+		}
+		if len(options) == 0 {
+			return specErrorf("enum attribute %s needs at least one option", attribute.Name)
+		}
+		attribute.Options = options
+	case SpecTypeNumber, SpecTypeBool, SpecTypeText:
+		if len(attribute.Options) > 0 {
+			return specErrorf("only enum attributes have options")
+		}
+		attribute.Options = nil
+	default:
+		return specErrorf("type %q must be number, enum, bool or text", attribute.Type)
+	}
+	return nil
+}
+
+// SpecSchema returns the attributes of a category, ordered by name.
+func SpecSchema(db *sql.DB, categoryID int64) ([]models.SpecAttribute, error) {
+	rows, err := db.Query(specAttributeSelect+" WHERE categoryId = ? ORDER BY attributeName", categoryID)
+	if err != nil {
+		return nil, fmt.Errorf("query spec schema: %w", err)
+	}
+	defer rows.Close()
+
+	attributes, err := ScanRows[models.SpecAttribute](rows)
+	if err != nil {
+		return nil, fmt.Errorf("scan spec schema: %w", err)
+	}
+	if attributes == nil {
+		attributes = []models.SpecAttribute{}
+	}
+	for i := range attributes {
+		if err := decodeSpecOptions(&attributes[i]); err != nil {
+			return nil, err
+		}
+	}
+	return attributes, nil
+}
+
+// GetSpecAttribute returns one attribute of a category, or sql.ErrNoRows.
+func GetSpecAttribute(db *sql.DB, categoryID int64, attributeID int64) (*models.SpecAttribute, error) {
+	attribute, err := ScanRow[models.SpecAttribute](db.QueryRow(specAttributeSelect+" WHERE categoryId = ? AND attributeId = ?", categoryID, attributeID))
+	if err != nil {
+		return nil, err
+	}
+	return attribute, decodeSpecOptions(attribute)
+}
+
+func decodeSpecOptions(attribute *models.SpecAttribute) error {
+	if attribute.OptionsJSON == nil || *attribute.OptionsJSON == "" {
+		return nil
+	}
+	if err := json.Unmarshal([]byte(*attribute.OptionsJSON), &attribute.Options); err != nil {
+		return fmt.Errorf("decode options of spec attribute %s: %w", attribute.Name, err)
+	}
+	return nil
+}
+
+// EncodeSpecOptions returns the stored form of enum options, nil for other types.
+func EncodeSpecOptions(options []string) (*string, error) {
+	if len(options) == 0 {
+		return nil, nil
+	}
+	encoded, err := json.Marshal(options)
+	if err != nil {
+		return nil, err
+	}
+	text := string(encoded)
+	return &text, nil
+}
+
+// ParseSpecs validates raw spec values from a request body against schema. A null value
+// removes the attribute from current, which holds the values already stored, and the
+// result must have every required attribute.
+func ParseSpecs(schema []models.SpecAttribute, current models.Specs, raw map[string]json.RawMessage) (models.Specs, error) {
+	byName := make(map[string]models.SpecAttribute, len(schema))
+	for _, attribute := range schema {
+		byName[attribute.Name] = attribute
+	}
+
+	specs := make(models.Specs, len(current)+len(raw))
+	for name, value := range current {
+		if _, ok := byName[name]; ok {
+			specs[name] = value
+		}
+	}
+
+	names := make([]string, 0, len(raw))
+	for name := range raw {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+
+	for _, name := range names {
+		attribute, ok := byName[name]
+		if !ok {
+			return nil, specErrorf("unknown spec %q for this category", name)
+		}
+		value := raw[name]
+		if string(value) == "null" {
+			delete(specs, name)
+			continue
+		}
+
+		parsed, err := parseSpecValue(attribute, value)
+		if err != nil {
+			return nil, err
+		}
+		specs[name] = parsed
+	}
+
+	for _, attribute := range schema {
+		if _, ok := specs[attribute.Name]; attribute.Required && !ok {
+			return nil, specErrorf("spec %s is required for this category", attribute.Name)
+		}
+	}
+	return specs, nil
+}
+
+func parseSpecValue(attribute models.SpecAttribute, raw json.RawMessage) (interface{}, error) {
+	switch attribute.Type {
+	case SpecTypeNumber:
+		var number float64
+		if err := json.Unmarshal(raw, &number); err != nil {
+			return nil, specErrorf("spec %s must be a number", attribute.Name)
+		}
+		return number, nil
+	case SpecTypeBool:
+		var flag bool
+		if err := json.Unmarshal(raw, &flag); err != nil {
+			return nil, specErrorf("spec %s must be true or false", attribute.Name)
+		}
+		return flag, nil
+	default:
+		var text string
+		if err := json.Unmarshal(raw, &text); err != nil {
+			return nil, specErrorf("spec %s must be a string", attribute.Name)
+		}
+		text = strings.TrimSpace(text)
+		if attribute.Type == SpecTypeEnum {
+			for _, option := range attribute.Options {
+				if option == text {
+					return text, nil
+				}
+			}
+			return nil, specErrorf("spec %s must be one of %s", attribute.Name, strings.Join(attribute.Options, ", "))
+		}
+		if text == "" || len(text) > maxSpecTextLength {
+			return nil, specErrorf("spec %s must be between 1 and %d characters", attribute.Name, maxSpecTextLength)
+		}
+		return text, nil
+	}
+}
+
+// Execer is satisfied by *sql.DB and *sql.Tx.
+type Execer interface {
+	Exec(query string, args ...interface{}) (sql.Result, error)
+}
+
+// SaveGearSpecs replaces the stored spec values of a piece of gear with specs, which must
+// have been checked against schema by ParseSpecs.
+func SaveGearSpecs(db Execer, gearID int64, schema []models.SpecAttribute, specs models.Specs) error {
+	if _, err := db.Exec("DELETE FROM gear_spec_values WHERE gearId = ?", gearID); err != nil {
+		return fmt.Errorf("clear gear specs: %w", err)
+	}
+
+	for _, attribute := range schema {
+		value, ok := specs[attribute.Name]
+		if !ok {
+			continue
+		}
+
+		var number, text interface{}
+		switch typed := value.(type) {
+		case float64:
+			number = typed
+		case bool:
+			number = 0
+			if typed {
+				number = 1
+			}
+		case string:
+			text = typed
+		}
+
+		_, err := db.Exec("INSERT INTO gear_spec_values (gearId, attributeId, valueNumber, valueText) VALUES (?, ?, ?, ?)",
+			gearID, *attribute.AttributeID, number, text)
+		if err != nil {
+			return fmt.Errorf("save spec %s: %w", attribute.Name, err)
+		}
+	}
+	return nil
+}
+
+// GearSpecs returns the stored spec values of a piece of gear for the attributes of its
+// current category.
+func GearSpecs(db *sql.DB, gearID int64) (models.Specs, error) {
+	rows, err := db.Query(`SELECT a.attributeName, a.attributeType, v.valueNumber, v.valueText
+        FROM gear_spec_values v
+        JOIN category_spec_attributes a ON a.attributeId = v.attributeId
+        JOIN gear ON gear.gearId = v.gearId AND gear.gearCategoryId = a.categoryId
+        WHERE v.gearId = ?`, gearID)
+	if err != nil {
+		return nil, fmt.Errorf("query gear specs: %w", err)
+	}
+	defer rows.Close()
+
+	specs := models.Specs{}
+	for rows.Next() {
+		var name, kind string
+		var number sql.NullFloat64
+		var text sql.NullString
+		if err := rows.Scan(&name, &kind, &number, &text); err != nil {
+			return nil, fmt.Errorf("scan gear specs: %w", err)
+		}
+		switch kind {
+		case SpecTypeNumber:
+			specs[name] = number.Float64
+		case SpecTypeBool:
+			specs[name] = number.Float64 != 0
+		default:
+			specs[name] = text.String
+		}
+	}
+	return specs, rows.Err()
+}
+
+// specFilterOperators are the comparisons a spec filter can use, longest first so >= is
+// not read as >.
+var specFilterOperators = []string{">=", "<=", "!=", ">", "<", "="}
+
+// SpecFilter is one parsed spec.* filter on the gear list. Equality filters on the same
+// attribute are merged, matching any of their values.
+type SpecFilter struct {
+	Name     string
+	Operator string
+	Values   []string
+}
+
+// ParseSpecFilters reads the spec.* parameters of values: spec.name=value (repeatable),
+// spec.name!=value, and spec.name>=, <=, > and < for numbers. In a query string
+// spec.rvalue>=4 arrives as the key "spec.rvalue>" with the value 4, and spec.rvalue>4 as
+// the key "spec.rvalue>4" with no value, so each key and value are joined back up first.
+func ParseSpecFilters(values url.Values) ([]SpecFilter, error) {
+	keys := make([]string, 0)
+	for key := range values {
+		if strings.HasPrefix(key, specFilterPrefix) {
+			keys = append(keys, key)
+		}
+	}
+	sort.Strings(keys)
+
+	var filters []SpecFilter
+	equality := make(map[string]int)
+	for _, key := range keys {
+		for _, value := range values[key] {
+			expression := strings.TrimPrefix(key, specFilterPrefix)
+			if value != "" {
+				expression += "=" + value
+			}
+
+			filter, err := parseSpecFilter(expression)
+			if err != nil {
+				return nil, err
+			}
+			if filter.Operator == "=" {
+				if index, ok := equality[filter.Name]; ok {
+					filters[index].Values = append(filters[index].Values, filter.Values...)
+					continue
+				}
+				equality[filter.Name] = len(filters)
+			}
+			filters = append(filters, filter)
+		}
+	}
+	return filters, nil
+}
+
+func parseSpecFilter(expression string) (SpecFilter, error) {
+	index := strings.IndexAny(expression, "!<>=")
+	if index > 0 {
+		name, rest := expression[:index], expression[index:]
+		for _, operator := range specFilterOperators {
+			value, ok := strings.CutPrefix(rest, operator)
+			if ok && value != "" && specAttributeName.MatchString(name) {
+				return SpecFilter{Name: name, Operator: operator, Values: []string{value}}, nil
+			}
+			if ok {
+				break
+			}
+		}
+	}
+	return SpecFilter{}, specErrorf("invalid spec filter %q; use spec.name=value or spec.name>=number", specFilterPrefix+expression)
+}
+
+// FilterGearSpecs narrows a gear query to gear whose spec values match every spec.*
+// filter in values. Attributes are looked up by name across categories, so a filter only
+// matches gear in categories defining that attribute.
+func FilterGearSpecs[model any](query *ListQuery[model], db *sql.DB, values url.Values) error {
+	filters, err := ParseSpecFilters(values)
+	if err != nil {
+		return err
+	}
+
+	for _, filter := range filters {
+		var kind string
+		err := db.QueryRow("SELECT attributeType FROM category_spec_attributes WHERE attributeName = ? LIMIT 1", filter.Name).Scan(&kind)
+		if err == sql.ErrNoRows {
+			return specErrorf("unknown spec %q", filter.Name)
+		}
+		if err != nil {
+			return err
+		}
+
+		column := "v.valueText"
+		args := []interface{}{filter.Name}
+		for _, raw := range filter.Values {
+			switch kind {
+			case SpecTypeNumber:
+				number, err := strconv.ParseFloat(raw, 64)
+				if err != nil {
+					return specErrorf("spec.%s needs a number, not %q", filter.Name, raw)
+				}
+				column = "v.valueNumber"
+				args = append(args, number)
+			case SpecTypeBool:
+				flag, err := strconv.ParseBool(raw)
+				if err != nil {
+					return specErrorf("spec.%s needs true or false, not %q", filter.Name, raw)
+				}
+				column = "v.valueNumber"
+				if flag {
+					args = append(args, 1)
+				} else {
+					args = append(args, 0)
+				}
+			default:
+				args = append(args, raw)
+			}
+		}
+		if kind != SpecTypeNumber && filter.Operator != "=" && filter.Operator != "!=" {
+			return specErrorf("spec.%s is a %s; only = and != apply", filter.Name, kind)
+		}
+
+		comparison := column + " " + filter.Operator + " ?"
+		if len(filter.Values) > 1 {
+			comparison = column + " IN (" + strings.TrimSuffix(strings.Repeat("?, ", len(filter.Values)), ", ") + ")"
+		}
+		query.Where(`EXISTS (SELECT 1 FROM gear_spec_values v
+            JOIN category_spec_attributes a ON a.attributeId = v.attributeId AND a.categoryId = gear.gearCategoryId
+            WHERE v.gearId = gear.gearId AND a.attributeName = ? AND `+comparison+")", args...)
+	}
+	return nil
+}
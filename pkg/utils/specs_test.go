@@ -0,0 +1,35 @@
+package utils
+
+import (
+	"net/url"
+	"reflect"
+	"testing"
+)
+
+func TestParseSpecFiltersRejoinsOperators(t *testing.T) {
+	values, err := url.ParseQuery("spec.rvalue>=4&spec.weight<900&spec.season=3-season&spec.season=4-season&spec.fabric!=nylon&page=2")
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	filters, err := ParseSpecFilters(values)
+	if err != nil {
+		t.Fatal(err)
+	}
+	want := []SpecFilter{
+		{Name: "fabric", Operator: "!=", Values: []string{"nylon"}},
+		{Name: "rvalue", Operator: ">=", Values: []string{"4"}},
+		{Name: "season", Operator: "=", Values: []string{"3-season", "4-season"}},
+		{Name: "weight", Operator: "<", Values: []string{"900"}},
+	}
+	if !reflect.DeepEqual(filters, want) {
+		t.Errorf("ParseSpecFilters = %+v, want %+v", filters, want)
+	}
+
+	for _, query := range []string{"spec.rvalue", "spec.=4", "spec.Bad-Name=1", "spec.rvalue>="} {
+		values, _ := url.ParseQuery(query)
+		if _, err := ParseSpecFilters(values); err == nil {
+			t.Errorf("ParseSpecFilters(%q) should fail", query)
+		}
+	}
+}
@@ -0,0 +1,68 @@
+package utils
+
+import (
+	"database/sql"
+	"sync"
+	"sync/atomic"
+)
+
+// maxCachedStatements bounds the statement cache. List queries vary with the filters
+// in use, so past this many distinct statements new ones are run unprepared.
+const maxCachedStatements = 512
+
+type statementKey struct {
+	db    *sql.DB
+	query string
+}
+
+var (
+	statements      sync.Map
+	statementsCount atomic.Int64
+)
+
+// prepared returns a cached prepared statement for query on db, preparing it on first
+// use. It returns nil when the cache is full, and the caller runs the query directly.
+func prepared(db *sql.DB, query string) (*sql.Stmt, error) {
+	key := statementKey{db: db, query: query}
+	if stmt, ok := statements.Load(key); ok {
+		return stmt.(*sql.Stmt), nil
+	}
+
+	if statementsCount.Load() >= maxCachedStatements {
+		return nil, nil
+	}
+
+	stmt, err := db.Prepare(query)
+	if err != nil {
+		return nil, err
+	}
+
+	actual, loaded := statements.LoadOrStore(key, stmt)
+	if loaded {
+		stmt.Close()
+	} else {
+		statementsCount.Add(1)
+	}
+	return actual.(*sql.Stmt), nil
+}
+
+// cachedQuery runs query through the statement cache.
+func cachedQuery(db *sql.DB, query string, args ...interface{}) (*sql.Rows, error) {
+	stmt, err := prepared(db, query)
+	if err != nil {
+		return nil, err
+	}
+	if stmt == nil {
+		return db.Query(query, args...)
+	}
+	return stmt.Query(args...)
+}
+
+// cachedQueryRow runs a single-row query through the statement cache.
+func cachedQueryRow(db *sql.DB, query string, args ...interface{}) *sql.Row {
+	stmt, err := prepared(db, query)
+	if err != nil || stmt == nil {
+		return db.QueryRow(query, args...)
+	}
+	return stmt.QueryRow(args...)
+}
@@ -0,0 +1,36 @@
+package utils
+
+import (
+	"database/sql"
+	"testing"
+)
+
+func TestPreparedStatementsAreCachedPerDatabase(t *testing.T) {
+	open := func() *sql.DB {
+		db, err := sql.Open("sqlite3", ":memory:")
+		if err != nil {
+			t.Fatalf("open :memory: %v", err)
+		}
+		t.Cleanup(func() { db.Close() })
+		db.SetMaxOpenConns(1)
+		return db
+	}
+	first, second := open(), open()
+
+	const query = "SELECT ? + 1"
+	stmt, err := prepared(first, query)
+	if err != nil || stmt == nil {
+		t.Fatalf("prepare: %v", err)
+	}
+	if again, _ := prepared(first, query); again != stmt {
+		t.Error("expected the cached statement on the second call")
+	}
+	if other, _ := prepared(second, query); other == stmt {
+		t.Error("statements must not be shared between databases")
+	}
+
+	var result int
+	if err := cachedQueryRow(second, query, 41).Scan(&result); err != nil || result != 42 {
+		t.Errorf("cachedQueryRow = %d, %v", result, err)
+	}
+}
@@ -0,0 +1,288 @@
+package utils
+
+import (
+	"database/sql"
+	"fmt"
+	"sync"
+	"sync/atomic"
+	"time"
+
+	models "github.com/Sea-Shell/gogear-api/pkg/models"
+)
+
+const (
+	// gearStatsMaxAge is how long the statistics are served before they are recomputed.
+	gearStatsMaxAge = 5 * time.Minute
+	// MaxGearStatsLimit is the most gear a ranking or list returns.
+	MaxGearStatsLimit = 100
+)
+
+type gearTally struct {
+	gearID int64
+	count  int64
+}
+
+type gearAdded struct {
+	gearID  int64
+	addedAt *string
+}
+
+// gearStatsSnapshot holds the aggregates of the catalog at one point in time.
+type gearStatsSnapshot struct {
+	catalogGear
+	owned  []gearTally
+	used   []gearTally
+	recent []gearAdded
+}
+
+// catalogGear is the gear in the catalog with the category tree, as loaded by the caches.
+type catalogGear struct {
+	gear    map[int64]models.GearListItem
+	parents map[int64]int64
+}
+
+// GearStats caches the popularity rankings of catalog gear, so the aggregate queries run
+// once per refresh instead of on every request. It is computed on the first lookup and
+// recomputed by the first lookup after it is older than its maximum age.
+type GearStats struct {
+	cache periodicCache[gearStatsSnapshot]
+}
+
+// NewGearStats returns empty statistics that load from the database on first use.
+func NewGearStats() *GearStats {
+	return &GearStats{cache: periodicCache[gearStatsSnapshot]{maxAge: gearStatsMaxAge, build: buildGearStatsSnapshot}}
+}
+
+// MostOwned ranks catalog gear by the number of users who registered it, optionally only
+// gear in category or the categories below it.
+func (stats *GearStats) MostOwned(db *sql.DB, category *int64, limit int) ([]models.GearRanking, error) {
+	snapshot, err := stats.cache.load(db)
+	if err != nil {
+		return nil, err
+	}
+	return snapshot.rank(snapshot.owned, category, limit), nil
+}
+
+// MostUsed ranks catalog gear by the number of public loadouts it is packed in,
+// optionally only gear in category or the categories below it.
+func (stats *GearStats) MostUsed(db *sql.DB, category *int64, limit int) ([]models.GearRanking, error) {
+	snapshot, err := stats.cache.load(db)
+	if err != nil {
+		return nil, err
+	}
+	return snapshot.rank(snapshot.used, category, limit), nil
+}
+
+// RecentlyAdded lists the gear most recently added to the catalog, newest first,
+// optionally only gear in category or the categories below it.
+func (stats *GearStats) RecentlyAdded(db *sql.DB, category *int64, limit int) ([]models.GearRecentlyAdded, error) {
+	snapshot, err := stats.cache.load(db)
+	if err != nil {
+		return nil, err
+	}
+
+	items := []models.GearRecentlyAdded{}
+	for _, added := range snapshot.recent {
+		if len(items) == limit {
+			break
+		}
+		gear, ok := snapshot.lookup(added.gearID, category)
+		if !ok {
+			continue
+		}
+		items = append(items, models.GearRecentlyAdded{AddedAt: added.addedAt, Gear: gear})
+	}
+	return items, nil
+}
+
+// RefreshedAt returns when the cached statistics were computed, or the zero time.
+func (stats *GearStats) RefreshedAt() time.Time {
+	return stats.cache.computedAt()
+}
+
+// periodicCache holds a value computed from the database. It is computed on the first
+// lookup and recomputed by the first lookup after it is older than maxAge.
+type periodicCache[value any] struct {
+	mu      sync.Mutex
+	current atomic.Pointer[cachedValue[value]]
+	maxAge  time.Duration
+	build   func(db *sql.DB) (*value, error)
+}
+
+type cachedValue[value any] struct {
+	value    *value
+	computed time.Time
+}
+
+func (cache *periodicCache[value]) load(db *sql.DB) (*value, error) {
+	if current := cache.current.Load(); current != nil && time.Since(current.computed) <= cache.maxAge {
+		return current.value, nil
+	}
+
+	cache.mu.Lock()
+	defer cache.mu.Unlock()
+
+	if current := cache.current.Load(); current != nil && time.Since(current.computed) <= cache.maxAge {
+		return current.value, nil
+	}
+
+	computed := time.Now()
+	built, err := cache.build(db)
+	if err != nil {
+		// Serving a slightly stale value beats failing the lookup.
+		if previous := cache.current.Load(); previous != nil {
+			return previous.value, nil
+		}
+		return nil, err
+	}
+
+	cache.current.Store(&cachedValue[value]{value: built, computed: computed})
+	return built, nil
+}
+
+// computedAt returns when the cached value was computed, or the zero time.
+func (cache *periodicCache[value]) computedAt() time.Time {
+	if current := cache.current.Load(); current != nil {
+		return current.computed
+	}
+	return time.Time{}
+}
+
+func (snapshot *gearStatsSnapshot) rank(counts []gearTally, category *int64, limit int) []models.GearRanking {
+	rankings := []models.GearRanking{}
+	for _, counted := range counts {
+		if len(rankings) == limit {
+			break
+		}
+		gear, ok := snapshot.lookup(counted.gearID, category)
+		if !ok {
+			continue
+		}
+		rankings = append(rankings, models.GearRanking{Rank: len(rankings) + 1, Count: counted.count, Gear: gear})
+	}
+	return rankings
+}
+
+// lookup returns catalog gear, if it is in category or a category below it when given.
+func (catalog catalogGear) lookup(gearID int64, category *int64) (models.GearListItem, bool) {
+	gear, ok := catalog.gear[gearID]
+	if !ok || (category != nil && !catalog.inCategory(gear.GearCategoryID, *category)) {
+		return models.GearListItem{}, false
+	}
+	return gear, true
+}
+
+// inCategory reports whether categoryID is root or one of the categories below it.
+func (catalog catalogGear) inCategory(categoryID int64, root int64) bool {
+	// The depth bound guards against a cycle in the stored tree.
+	for depth := 0; depth <= len(catalog.parents); depth++ {
+		if categoryID == root {
+			return true
+		}
+		parent, ok := catalog.parents[categoryID]
+		if !ok {
+			return false
+		}
+		categoryID = parent
+	}
+	return false
+}
+
+const (
+	gearOwnedQuery = `SELECT gearId, COUNT(DISTINCT userId) AS owners FROM user_gear_registrations
+        GROUP BY gearId ORDER BY owners DESC, gearId`
+	gearUsedQuery = `SELECT loadout_items.gearId, COUNT(DISTINCT loadouts.loadoutId) AS loadoutCount FROM loadout_items
+        JOIN loadouts ON loadouts.loadoutId = loadout_items.loadoutId
+        WHERE loadouts.loadoutIsPublic = 1
+        GROUP BY loadout_items.gearId ORDER BY loadoutCount DESC, loadout_items.gearId`
+	gearRecentQuery = `SELECT gearId, gearAddedAt FROM gear WHERE ` + GearInCatalog + `
+        ORDER BY gearAddedAt IS NULL, gearAddedAt DESC, gearId DESC`
+)
+
+func buildGearStatsSnapshot(db *sql.DB) (*gearStatsSnapshot, error) {
+	snapshot := &gearStatsSnapshot{}
+
+	var err error
+	if snapshot.catalogGear, err = loadCatalogGear(db); err != nil {
+		return nil, err
+	}
+	if snapshot.owned, err = queryGearCounts(db, gearOwnedQuery); err != nil {
+		return nil, fmt.Errorf("count gear owners: %w", err)
+	}
+	if snapshot.used, err = queryGearCounts(db, gearUsedQuery); err != nil {
+		return nil, fmt.Errorf("count gear in public loadouts: %w", err)
+	}
+
+	rows, err := db.Query(gearRecentQuery)
+	if err != nil {
+		return nil, fmt.Errorf("query recently added gear: %w", err)
+	}
+	for rows.Next() {
+		var added gearAdded
+		if err := rows.Scan(&added.gearID, &added.addedAt); err != nil {
+			rows.Close()
+			return nil, fmt.Errorf("scan recently added gear: %w", err)
+		}
+		snapshot.recent = append(snapshot.recent, added)
+	}
+	rows.Close()
+	if err := rows.Err(); err != nil {
+		return nil, fmt.Errorf("query recently added gear: %w", err)
+	}
+
+	return snapshot, nil
+}
+
+// loadCatalogGear loads every gear in the catalog and the category tree.
+func loadCatalogGear(db *sql.DB) (catalogGear, error) {
+	catalog := catalogGear{
+		gear:    make(map[int64]models.GearListItem),
+		parents: make(map[int64]int64),
+	}
+
+	rows, err := db.Query("SELECT categoryId, categoryParentId FROM gear_category WHERE categoryParentId IS NOT NULL")
+	if err != nil {
+		return catalog, fmt.Errorf("query category tree: %w", err)
+	}
+	for rows.Next() {
+		var categoryID, parentID int64
+		if err := rows.Scan(&categoryID, &parentID); err != nil {
+			rows.Close()
+			return catalog, fmt.Errorf("scan category tree: %w", err)
+		}
+		catalog.parents[categoryID] = parentID
+	}
+	rows.Close()
+	if err := rows.Err(); err != nil {
+		return catalog, fmt.Errorf("query category tree: %w", err)
+	}
+
+	// The whole catalog is kept so lists filtered by category are complete.
+	query := NewListQuery[models.GearListItem]("gear" + GearJoins).CoalesceNulls().Where(GearInCatalog)
+	if err := query.Each(db, func(item models.GearListItem) error {
+		catalog.gear[item.GearID] = item
+		return nil
+	}); err != nil {
+		return catalog, fmt.Errorf("query catalog gear: %w", err)
+	}
+
+	return catalog, nil
+}
+
+func queryGearCounts(db *sql.DB, query string) ([]gearTally, error) {
+	rows, err := db.Query(query)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	var counts []gearTally
+	for rows.Next() {
+		var counted gearTally
+		if err := rows.Scan(&counted.gearID, &counted.count); err != nil {
+			return nil, err
+		}
+		counts = append(counts, counted)
+	}
+	return counts, rows.Err()
+}
@@ -0,0 +1,190 @@
+package utils
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"os"
+	"path"
+	"path/filepath"
+	"reflect"
+	"strings"
+	"sync"
+
+	models "github.com/Sea-Shell/gogear-api/pkg/models"
+)
+
+// Storage defaults used when the storage section of the config leaves them out.
+const (
+	DefaultStoragePath     = "uploads"
+	DefaultStorageBaseURL  = "/media"
+	DefaultMaxUploadBytes  = 10 << 20
+	storageBackendLocal    = "local"
+	storageTempFilePattern = ".upload-*"
+)
+
+// ErrInvalidStorageKey is returned for keys that are empty, absolute or leave the storage root.
+var ErrInvalidStorageKey = errors.New("invalid storage key")
+
+// Storage keeps uploaded files under slash separated keys such as gear/12/ab34.jpg and
+// tells clients where to fetch them. LocalStorage is the only backend so far; an
+// S3-compatible one only needs to implement the same three methods.
+type Storage interface {
+	Put(ctx context.Context, key string, body io.Reader, contentType string) error
+	Delete(ctx context.Context, key string) error
+	URL(key string) string
+}
+
+// NewStorage returns the backend named in config, filling in the defaults.
+func NewStorage(config models.Storage) (Storage, error) {
+	switch strings.ToLower(config.Backend) {
+	case "", storageBackendLocal:
+		root := config.Path
+		if root == "" {
+			root = DefaultStoragePath
+		}
+		baseURL := config.BaseURL
+		if baseURL == "" {
+			baseURL = DefaultStorageBaseURL
+		}
+		return NewLocalStorage(root, baseURL)
+	default:
+		return nil, fmt.Errorf("unknown storage backend %q", config.Backend)
+	}
+}
+
+// LocalStorage keeps files in a directory on disk. The files are expected to be served
+// from BaseURL, for example with gin's StaticFS.
+type LocalStorage struct {
+	root    string
+	baseURL string
+}
+
+// NewLocalStorage creates root if needed and returns a LocalStorage writing under it.
+func NewLocalStorage(root string, baseURL string) (*LocalStorage, error) {
+	if err := os.MkdirAll(root, 0o755); err != nil {
+		return nil, fmt.Errorf("create storage directory: %w", err)
+	}
+	return &LocalStorage{root: root, baseURL: strings.TrimRight(baseURL, "/")}, nil
+}
+
+// Root is the directory files are written to.
+func (s *LocalStorage) Root() string {
+	return s.root
+}
+
+// BaseURL is the URL path the files are served from.
+func (s *LocalStorage) BaseURL() string {
+	return s.baseURL
+}
+
+// path maps key to a file under the root, refusing keys that would leave it.
+func (s *LocalStorage) path(key string) (string, error) {
+	if key == "" || strings.HasPrefix(key, "/") || strings.Contains(key, "\\") || path.Clean(key) != key || strings.HasPrefix(key, "../") || key == ".." {
+		return "", ErrInvalidStorageKey
+	}
+	return filepath.Join(s.root, filepath.FromSlash(key)), nil
+}
+
+// Put writes body to key through a temporary file, so readers never see a partial file.
+func (s *LocalStorage) Put(_ context.Context, key string, body io.Reader, _ string) error {
+	target, err := s.path(key)
+	if err != nil {
+		return err
+	}
+	if err := os.MkdirAll(filepath.Dir(target), 0o755); err != nil {
+		return err
+	}
+
+	file, err := os.CreateTemp(filepath.Dir(target), storageTempFilePattern)
+	if err != nil {
+		return err
+	}
+	defer os.Remove(file.Name())
+
+	if _, err := io.Copy(file, body); err != nil {
+		file.Close()
+		return err
+	}
+	if err := file.Close(); err != nil {
+		return err
+	}
+	if err := os.Chmod(file.Name(), 0o644); err != nil {
+		return err
+	}
+	return os.Rename(file.Name(), target)
+}
+
+// Delete removes key. Deleting a key that does not exist is not an error.
+func (s *LocalStorage) Delete(_ context.Context, key string) error {
+	target, err := s.path(key)
+	if err != nil {
+		return err
+	}
+	if err := os.Remove(target); err != nil && !errors.Is(err, os.ErrNotExist) {
+		return err
+	}
+	return nil
+}
+
+// URL is where clients fetch key from.
+func (s *LocalStorage) URL(key string) string {
+	return s.baseURL + "/" + key
+}
+
+// urlField is a storage key field tagged with the field its URL goes into.
+type urlField struct {
+	key    int
+	target int
+}
+
+var urlPlans sync.Map
+
+func urlPlanFor(modelType reflect.Type) []urlField {
+	if cached, ok := urlPlans.Load(modelType); ok {
+		return cached.([]urlField)
+	}
+
+	var plan []urlField
+	for i := 0; i < modelType.NumField(); i++ {
+		targetName := modelType.Field(i).Tag.Get("url")
+		if targetName == "" {
+			continue
+		}
+		target, ok := modelType.FieldByName(targetName)
+		if !ok {
+			panic(fmt.Sprintf("%s.%s: url tag names missing field %s", modelType.Name(), modelType.Field(i).Name, targetName))
+		}
+		plan = append(plan, urlField{key: i, target: target.Index[0]})
+	}
+
+	urlPlans.Store(modelType, plan)
+	return plan
+}
+
+// HasStorageURLs reports whether model has storage key fields tagged with url.
+func HasStorageURLs[model any]() bool {
+	var params model
+	return len(urlPlanFor(reflect.TypeOf(params))) > 0
+}
+
+// ResolveURLs sets the field named by the url tag of each storage key field of item to the
+// key's URL in storage. Empty and nil keys leave the URL unset.
+func ResolveURLs[model any](item *model, storage Storage) {
+	itemValue := reflect.ValueOf(item).Elem()
+	for _, field := range urlPlanFor(itemValue.Type()) {
+		key := fieldValue(itemValue.Field(field.key))
+		if key == nil || key.(string) == "" {
+			continue
+		}
+
+		url := storage.URL(key.(string))
+		target := itemValue.Field(field.target)
+		if target.Kind() == reflect.Ptr {
+			target.Set(reflect.ValueOf(&url))
+		} else {
+			target.SetString(url)
+		}
+	}
+}
@@ -0,0 +1,48 @@
+package utils
+
+import (
+	"context"
+	"errors"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	models "github.com/Sea-Shell/gogear-api/pkg/models"
+)
+
+func TestLocalStorageRejectsKeysOutsideRoot(t *testing.T) {
+	storage, err := NewLocalStorage(t.TempDir(), "/media/")
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	for _, key := range []string{"", "/etc/passwd", "../escape.jpg", "gear/../../escape.jpg", "gear//1.jpg", "gear\\1.jpg", "./gear/1.jpg"} {
+		if err := storage.Put(context.Background(), key, strings.NewReader("x"), "image/png"); !errors.Is(err, ErrInvalidStorageKey) {
+			t.Errorf("Put(%q) = %v, want ErrInvalidStorageKey", key, err)
+		}
+	}
+
+	if err := storage.Put(context.Background(), "gear/1/a.png", strings.NewReader("png"), "image/png"); err != nil {
+		t.Fatalf("Put: %v", err)
+	}
+	if data, err := os.ReadFile(filepath.Join(storage.Root(), "gear", "1", "a.png")); err != nil || string(data) != "png" {
+		t.Errorf("stored file = %q, %v", data, err)
+	}
+	if got := storage.URL("gear/1/a.png"); got != "/media/gear/1/a.png" {
+		t.Errorf("URL = %q", got)
+	}
+
+	if err := storage.Delete(context.Background(), "gear/1/a.png"); err != nil {
+		t.Errorf("Delete: %v", err)
+	}
+	if err := storage.Delete(context.Background(), "gear/1/a.png"); err != nil {
+		t.Errorf("Delete of a missing key: %v", err)
+	}
+}
+
+func TestNewStorageRejectsUnknownBackend(t *testing.T) {
+	if _, err := NewStorage(models.Storage{Backend: "ftp"}); err == nil {
+		t.Error("expected an error for an unknown backend")
+	}
+}
@@ -0,0 +1,257 @@
+package utils
+
+import (
+	"database/sql"
+	"net/http"
+	"sort"
+	"strings"
+	"sync"
+	"sync/atomic"
+	"time"
+
+	models "github.com/Sea-Shell/gogear-api/pkg/models"
+
+	gin "github.com/gin-gonic/gin"
+)
+
+// minSuggestScore drops matches sharing too few trigrams with the query to be useful.
+const minSuggestScore = 0.3
+
+const (
+	// suggestCheckInterval throttles the catalog change check between lookups.
+	suggestCheckInterval = time.Second
+	// suggestMaxAge rebuilds the index periodically, for in-place edits the change check misses.
+	suggestMaxAge = 10 * time.Minute
+)
+
+// suggestFingerprintQuery summarizes the indexed tables cheaply. It changes on inserts
+// and deletes, and on nearly all renames, including writes made by other processes
+// such as the import command, which never pass through InvalidateOnWrite.
+const suggestFingerprintQuery = `SELECT
+    (SELECT COUNT(*) || ':' || IFNULL(MAX(gearId), 0) || ':' || TOTAL(LENGTH(gearName)) || ':' || TOTAL(gearManufactureId) || ':' || TOTAL(gearStatus) FROM gear) || '/' ||
+    (SELECT COUNT(*) || ':' || IFNULL(MAX(manufactureId), 0) || ':' || TOTAL(LENGTH(manufactureName)) FROM manufacture) || '/' ||
+    (SELECT COUNT(*) || ':' || IFNULL(MAX(categoryId), 0) || ':' || TOTAL(LENGTH(categoryName)) FROM gear_category)`
+
+type suggestEntry struct {
+	suggestion models.Suggestion
+	text       string
+	trigrams   int
+}
+
+// suggestSnapshot is an immutable trigram index over the catalog names.
+type suggestSnapshot struct {
+	entries     []suggestEntry
+	postings    map[string][]int
+	fingerprint string
+	built       time.Time
+}
+
+// SuggestIndex is an in-memory trigram index over gear, manufacturer and category
+// names for typo-tolerant autocomplete. It is loaded lazily and rebuilt on the first
+// lookup after Invalidate, or after the catalog changed underneath it.
+type SuggestIndex struct {
+	mu            sync.Mutex
+	snapshot      atomic.Pointer[suggestSnapshot]
+	stale         atomic.Bool
+	checked       atomic.Int64
+	checkInterval time.Duration
+	maxAge        time.Duration
+}
+
+// NewSuggestIndex returns an empty index that loads from the database on first use.
+func NewSuggestIndex() *SuggestIndex {
+	index := &SuggestIndex{checkInterval: suggestCheckInterval, maxAge: suggestMaxAge}
+	index.stale.Store(true)
+	return index
+}
+
+// Invalidate marks the index out of date so the next lookup reloads the catalog.
+func (index *SuggestIndex) Invalidate() {
+	index.stale.Store(true)
+}
+
+// InvalidateOnWrite returns middleware that invalidates the index after every
+// successful non-GET request, for route groups that modify the catalog.
+func (index *SuggestIndex) InvalidateOnWrite() gin.HandlerFunc {
+	return func(c *gin.Context) {
+		c.Next()
+		if c.Request.Method != http.MethodGet && c.Writer.Status() < http.StatusBadRequest {
+			index.Invalidate()
+		}
+	}
+}
+
+// Suggest returns up to limit catalog names most similar to query, best first.
+func (index *SuggestIndex) Suggest(db *sql.DB, query string, limit int) ([]models.Suggestion, error) {
+	snapshot, err := index.load(db)
+	if err != nil {
+		return nil, err
+	}
+	return snapshot.search(query, limit), nil
+}
+
+func (index *SuggestIndex) load(db *sql.DB) (*suggestSnapshot, error) {
+	if snapshot := index.snapshot.Load(); snapshot != nil && !index.stale.Load() {
+		if !index.changed(db, snapshot) {
+			return snapshot, nil
+		}
+		index.Invalidate()
+	}
+
+	index.mu.Lock()
+	defer index.mu.Unlock()
+
+	if snapshot := index.snapshot.Load(); snapshot != nil && !index.stale.Load() {
+		return snapshot, nil
+	}
+
+	// Clear the flag before reading so writes during the rebuild invalidate it again.
+	index.stale.Store(false)
+	snapshot, err := buildSuggestSnapshot(db)
+	if err != nil {
+		index.stale.Store(true)
+		return nil, err
+	}
+
+	index.snapshot.Store(snapshot)
+	index.checked.Store(snapshot.built.UnixNano())
+	return snapshot, nil
+}
+
+// changed reports whether snapshot is too old or the catalog fingerprint moved on. At most
+// one lookup per check interval queries the fingerprint; the others keep the snapshot.
+func (index *SuggestIndex) changed(db *sql.DB, snapshot *suggestSnapshot) bool {
+	now := time.Now()
+	if now.Sub(snapshot.built) > index.maxAge {
+		return true
+	}
+
+	last := index.checked.Load()
+	if now.UnixNano()-last < int64(index.checkInterval) || !index.checked.CompareAndSwap(last, now.UnixNano()) {
+		return false
+	}
+
+	var fingerprint string
+	if err := db.QueryRow(suggestFingerprintQuery).Scan(&fingerprint); err != nil {
+		// Serving slightly stale suggestions beats failing the lookup.
+		return false
+	}
+	return fingerprint != snapshot.fingerprint
+}
+
+var suggestSources = []struct {
+	kind  string
+	query string
+}{
+	{"gear", `SELECT gear.gearId, gear.gearName, COALESCE(manufacture.manufactureName, '') FROM gear
+        LEFT JOIN manufacture ON gear.gearManufactureId = manufacture.manufactureId
+        WHERE ` + GearInCatalog},
+	{"manufacture", `SELECT manufactureId, manufactureName, '' FROM manufacture`},
+	{"category", `SELECT categoryId, categoryName, '' FROM gear_category`},
+}
+
+func buildSuggestSnapshot(db *sql.DB) (*suggestSnapshot, error) {
+	snapshot := &suggestSnapshot{postings: make(map[string][]int), built: time.Now()}
+
+	// Taken before reading, so a write during the build shows up as a change later.
+	if err := db.QueryRow(suggestFingerprintQuery).Scan(&snapshot.fingerprint); err != nil {
+		return nil, err
+	}
+
+	for _, source := range suggestSources {
+		rows, err := db.Query(source.query)
+		if err != nil {
+			return nil, err
+		}
+
+		for rows.Next() {
+			var suggestion models.Suggestion
+			if err := rows.Scan(&suggestion.ID, &suggestion.Name, &suggestion.Detail); err != nil {
+				rows.Close()
+				return nil, err
+			}
+			suggestion.Type = source.kind
+
+			// Gear is also found by its manufacturer, as in "osprey exos".
+			text := strings.Join(SearchTerms(suggestion.Detail+" "+suggestion.Name), " ")
+			trigrams := Trigrams(text)
+
+			position := len(snapshot.entries)
+			snapshot.entries = append(snapshot.entries, suggestEntry{suggestion: suggestion, text: text, trigrams: len(trigrams)})
+			for trigram := range trigrams {
+				snapshot.postings[trigram] = append(snapshot.postings[trigram], position)
+			}
+		}
+
+		err = rows.Err()
+		rows.Close()
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	return snapshot, nil
+}
+
+// search scores every entry sharing a trigram with query by how much of the query it
+// covers, with a smaller weight on overall similarity so shorter names win ties, and a
+// bonus for entries starting with the query as typed.
+func (snapshot *suggestSnapshot) search(query string, limit int) []models.Suggestion {
+	text := strings.Join(SearchTerms(query), " ")
+	queryTrigrams := Trigrams(text)
+	if len(queryTrigrams) == 0 {
+		return []models.Suggestion{}
+	}
+
+	shared := make(map[int]int)
+	for trigram := range queryTrigrams {
+		for _, position := range snapshot.postings[trigram] {
+			shared[position]++
+		}
+	}
+
+	suggestions := make([]models.Suggestion, 0, len(shared))
+	for position, count := range shared {
+		entry := snapshot.entries[position]
+		coverage := float64(count) / float64(len(queryTrigrams))
+		similarity := float64(count) / float64(len(queryTrigrams)+entry.trigrams-count)
+
+		score := 0.8*coverage + 0.2*similarity
+		if strings.HasPrefix(entry.text, text) || strings.Contains(entry.text, " "+text) {
+			score += 0.1
+		}
+		if score < minSuggestScore {
+			continue
+		}
+
+		suggestion := entry.suggestion
+		suggestion.Score = score
+		suggestions = append(suggestions, suggestion)
+	}
+
+	sort.Slice(suggestions, func(i, j int) bool {
+		if suggestions[i].Score != suggestions[j].Score {
+			return suggestions[i].Score > suggestions[j].Score
+		}
+		return suggestions[i].Name < suggestions[j].Name
+	})
+
+	if len(suggestions) > limit {
+		suggestions = suggestions[:limit]
+	}
+	return suggestions
+}
+
+// Trigrams returns the set of three-letter sequences in each word of text, with
+// words padded by two leading spaces and one trailing so short words and word
+// starts weigh more.
+func Trigrams(text string) map[string]struct{} {
+	trigrams := make(map[string]struct{})
+	for _, word := range strings.Fields(text) {
+		padded := []rune("  " + word + " ")
+		for i := 0; i+3 <= len(padded); i++ {
+			trigrams[string(padded[i:i+3])] = struct{}{}
+		}
+	}
+	return trigrams
+}
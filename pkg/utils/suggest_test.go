@@ -0,0 +1,103 @@
+package utils
+
+import (
+	"database/sql"
+	"testing"
+)
+
+func suggestTestDB(t *testing.T) *sql.DB {
+	t.Helper()
+	db := migratedTestDB(t)
+	execTestStatements(t, db,
+		`INSERT INTO gear_top_category (topCategoryId, topCategoryName) VALUES (1, 'Outdoor')`,
+		`INSERT INTO manufacture (manufactureId, manufactureName) VALUES (1, 'Osprey'), (2, 'MSR')`,
+		`INSERT INTO gear_category (categoryId, categoryTopCategoryId, categoryName) VALUES (1, 1, 'Backpacks'), (2, 1, 'Stoves')`,
+		`INSERT INTO gear (gearId, gearTopCategoryId, gearCategoryId, gearManufactureId, gearName)
+            VALUES (1, 1, 1, 1, 'Exos 58'), (2, 1, 1, 1, 'Atmos AG 65'), (3, 1, 2, 2, 'PocketRocket 2')`,
+	)
+	return db
+}
+
+func TestSuggestIndexToleratesMisspellings(t *testing.T) {
+	db := suggestTestDB(t)
+	index := NewSuggestIndex()
+
+	cases := []struct {
+		query    string
+		wantType string
+		wantID   int64
+	}{
+		{"osprey exsos", "gear", 1},
+		{"pocket roket", "gear", 3},
+		{"backpak", "category", 1},
+		{"ospr", "manufacture", 1},
+	}
+	for _, tc := range cases {
+		suggestions, err := index.Suggest(db, tc.query, 5)
+		if err != nil {
+			t.Fatalf("suggest %q: %v", tc.query, err)
+		}
+		if len(suggestions) == 0 || suggestions[0].Type != tc.wantType || suggestions[0].ID != tc.wantID {
+			t.Errorf("suggest %q: got %+v, want %s %d first", tc.query, suggestions, tc.wantType, tc.wantID)
+		}
+	}
+
+	suggestions, err := index.Suggest(db, "zzzz", 5)
+	if err != nil || len(suggestions) != 0 {
+		t.Errorf("suggest unrelated text: got %+v, %v", suggestions, err)
+	}
+}
+
+func TestSuggestIndexReloadsAfterInvalidate(t *testing.T) {
+	db := suggestTestDB(t)
+	index := NewSuggestIndex()
+
+	if suggestions, _ := index.Suggest(db, "whisperlite", 5); len(suggestions) != 0 {
+		t.Fatalf("unexpected suggestions before insert: %+v", suggestions)
+	}
+
+	if _, err := db.Exec(`INSERT INTO gear (gearId, gearTopCategoryId, gearCategoryId, gearManufactureId, gearName) VALUES (4, 1, 2, 2, 'WhisperLite')`); err != nil {
+		t.Fatalf("insert gear: %v", err)
+	}
+	if suggestions, _ := index.Suggest(db, "whisperlite", 5); len(suggestions) != 0 {
+		t.Errorf("index reloaded without invalidation: %+v", suggestions)
+	}
+
+	index.Invalidate()
+	suggestions, err := index.Suggest(db, "whisperlite", 5)
+	if err != nil || len(suggestions) != 1 || suggestions[0].ID != 4 {
+		t.Errorf("after invalidate: got %+v, %v", suggestions, err)
+	}
+}
+
+func TestSuggestIndexNoticesWritesFromOtherProcesses(t *testing.T) {
+	db := suggestTestDB(t)
+	index := NewSuggestIndex()
+	index.checkInterval = 0
+
+	if suggestions, _ := index.Suggest(db, "whisperlite", 5); len(suggestions) != 0 {
+		t.Fatalf("unexpected suggestions before insert: %+v", suggestions)
+	}
+
+	// Written behind the index's back, as the import command does.
+	if _, err := db.Exec(`INSERT INTO gear (gearId, gearTopCategoryId, gearCategoryId, gearManufactureId, gearName) VALUES (4, 1, 2, 2, 'WhisperLite')`); err != nil {
+		t.Fatalf("insert gear: %v", err)
+	}
+	suggestions, err := index.Suggest(db, "whisperlite", 5)
+	if err != nil || len(suggestions) != 1 || suggestions[0].ID != 4 {
+		t.Fatalf("after insert: got %+v, %v", suggestions, err)
+	}
+
+	if _, err := db.Exec(`UPDATE manufacture SET manufactureName = 'Mountain Safety Research' WHERE manufactureId = 2`); err != nil {
+		t.Fatalf("rename manufacturer: %v", err)
+	}
+	suggestions, err = index.Suggest(db, "mountain safety", 5)
+	if err != nil || len(suggestions) == 0 || suggestions[0].Type != "manufacture" || suggestions[0].ID != 2 {
+		t.Errorf("after rename: got %+v, %v", suggestions, err)
+	}
+
+	index.maxAge = 0
+	if _, err := index.Suggest(db, "exos", 5); err != nil {
+		t.Errorf("rebuild after max age: %v", err)
+	}
+}
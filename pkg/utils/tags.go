@@ -0,0 +1,209 @@
+package utils
+
+import (
+	"database/sql"
+	"fmt"
+	"net/url"
+	"strings"
+	"unicode"
+
+	models "github.com/Sea-Shell/gogear-api/pkg/models"
+)
+
+const (
+	maxTagLength      = 50
+	maxTagsPerRequest = 20
+)
+
+// TagError reports an invalid tag or tag filter.
+type TagError struct {
+	Message string
+}
+
+func (e *TagError) Error() string {
+	return e.Message
+}
+
+// TagTarget describes the table holding the tags of one kind of object and the column of
+// the list queries it filters.
+type TagTarget struct {
+	Table     string
+	KeyColumn string
+	Outer     string
+}
+
+// Tag targets: shared catalog tags on gear and private tags on registrations and loadouts.
+var (
+	GearTagTarget         = TagTarget{Table: "gear_tags", KeyColumn: "gearId", Outer: "gear.gearId"}
+	RegistrationTagTarget = TagTarget{Table: "user_gear_registration_tags", KeyColumn: "userGearRegistrationId", Outer: "user_gear_registrations.userGearRegistrationId"}
+	LoadoutTagTarget      = TagTarget{Table: "loadout_tags", KeyColumn: "loadoutId", Outer: "loadouts.loadoutId"}
+)
+
+// NormalizeTag lower-cases a tag and collapses its whitespace, so "Needs  Repair" and
+// "needs repair" are the same tag. Tags use letters, digits, spaces, - and _.
+func NormalizeTag(tag string) (string, error) {
+	tag = strings.ToLower(strings.Join(strings.Fields(tag), " "))
+	if tag == "" || len([]rune(tag)) > maxTagLength {
+		return "", &TagError{Message: fmt.Sprintf("tags must be between 1 and %d characters", maxTagLength)}
+	}
+	for _, r := range tag {
+		if !unicode.IsLetter(r) && !unicode.IsDigit(r) && r != ' ' && r != '-' && r != '_' {
+			return "", &TagError{Message: fmt.Sprintf("tag %q may only use letters, digits, spaces, - and _", tag)}
+		}
+	}
+	return tag, nil
+}
+
+// NormalizeTags normalizes a list of tags and drops duplicates, keeping the first order.
+func NormalizeTags(tags []string) ([]string, error) {
+	if len(tags) == 0 {
+		return nil, &TagError{Message: "at least one tag is required"}
+	}
+	if len(tags) > maxTagsPerRequest {
+		return nil, &TagError{Message: fmt.Sprintf("at most %d tags can be given at once", maxTagsPerRequest)}
+	}
+
+	seen := make(map[string]bool, len(tags))
+	normalized := make([]string, 0, len(tags))
+	for _, tag := range tags {
+		tag, err := NormalizeTag(tag)
+		if err != nil {
+			return nil, err
+		}
+		if !seen[tag] {
+			seen[tag] = true
+			normalized = append(normalized, tag)
+		}
+	}
+	return normalized, nil
+}
+
+// Tags returns the tags of one object, in alphabetical order.
+func Tags(db *sql.DB, target TagTarget, id int64) ([]string, error) {
+	rows, err := db.Query(fmt.Sprintf("SELECT tagName FROM %s WHERE %s = ? ORDER BY tagName", target.Table, target.KeyColumn), id)
+	if err != nil {
+		return nil, fmt.Errorf("query tags: %w", err)
+	}
+	defer rows.Close()
+
+	tags := []string{}
+	for rows.Next() {
+		var tag string
+		if err := rows.Scan(&tag); err != nil {
+			return nil, fmt.Errorf("scan tags: %w", err)
+		}
+		tags = append(tags, tag)
+	}
+	return tags, rows.Err()
+}
+
+// AddTags tags one object with normalized tags, ignoring tags it already has.
+func AddTags(db *sql.DB, target TagTarget, id int64, tags []string) error {
+	tx, err := db.Begin()
+	if err != nil {
+		return err
+	}
+	defer tx.Rollback()
+
+	statement := fmt.Sprintf("INSERT OR IGNORE INTO %s (%s, tagName) VALUES (?, ?)", target.Table, target.KeyColumn)
+	for _, tag := range tags {
+		if _, err := tx.Exec(statement, id, tag); err != nil {
+			return fmt.Errorf("add tag %s: %w", tag, err)
+		}
+	}
+	return tx.Commit()
+}
+
+// RemoveTag removes a normalized tag from one object and reports whether it had it.
+func RemoveTag(db *sql.DB, target TagTarget, id int64, tag string) (bool, error) {
+	result, err := db.Exec(fmt.Sprintf("DELETE FROM %s WHERE %s = ? AND tagName = ?", target.Table, target.KeyColumn), id, tag)
+	if err != nil {
+		return false, fmt.Errorf("remove tag: %w", err)
+	}
+	removed, err := result.RowsAffected()
+	return removed > 0, err
+}
+
+// ParseTagFilter reads the tag parameters of values. tag can be repeated and matches
+// objects with any of the tags, or with all of them when tag_match is all.
+func ParseTagFilter(values url.Values) ([]string, bool, error) {
+	var matchAll bool
+	switch values.Get("tag_match") {
+	case "", "any":
+	case "all":
+		matchAll = true
+	default:
+		return nil, false, &ListQueryError{Message: "tag_match must be any or all"}
+	}
+
+	raw, ok := values["tag"]
+	if !ok {
+		return nil, matchAll, nil
+	}
+	tags, err := NormalizeTags(raw)
+	if err != nil {
+		return nil, false, &ListQueryError{Message: err.Error()}
+	}
+	return tags, matchAll, nil
+}
+
+// TagCondition returns the SQL condition matching objects of target tagged with any, or
+// with all, of tags.
+func TagCondition(target TagTarget, tags []string, matchAll bool) (string, []interface{}) {
+	args := make([]interface{}, len(tags))
+	for i, tag := range tags {
+		args[i] = tag
+	}
+	placeholders := strings.Repeat("?, ", len(tags)-1) + "?"
+
+	if matchAll {
+		args = append(args, len(tags))
+		return fmt.Sprintf("(SELECT COUNT(*) FROM %s AS t WHERE t.%s = %s AND t.tagName IN (%s)) = ?",
+			target.Table, target.KeyColumn, target.Outer, placeholders), args
+	}
+	return fmt.Sprintf("EXISTS (SELECT 1 FROM %s AS t WHERE t.%s = %s AND t.tagName IN (%s))",
+		target.Table, target.KeyColumn, target.Outer, placeholders), args
+}
+
+// FilterTags narrows a list query to objects of target matching the tag filter in values.
+func FilterTags[model any](query *ListQuery[model], target TagTarget, values url.Values) error {
+	tags, matchAll, err := ParseTagFilter(values)
+	if err != nil || len(tags) == 0 {
+		return err
+	}
+	condition, args := TagCondition(target, tags, matchAll)
+	query.Where(condition, args...)
+	return nil
+}
+
+// SuggestTags autocompletes a tag prefix for a user from the catalog gear tags and the
+// user's own registration and loadout tags, most used first.
+func SuggestTags(db *sql.DB, userID int64, prefix string, limit int) ([]models.TagSuggestion, error) {
+	prefix = strings.ToLower(strings.Join(strings.Fields(prefix), " "))
+
+	rows, err := db.Query(`SELECT tagName, COUNT(*) AS tagCount FROM (
+            SELECT tagName FROM gear_tags
+            UNION ALL
+            SELECT t.tagName FROM user_gear_registration_tags t
+            JOIN user_gear_registrations r ON r.userGearRegistrationId = t.userGearRegistrationId
+            WHERE r.userId = ?
+            UNION ALL
+            SELECT t.tagName FROM loadout_tags t
+            JOIN loadouts l ON l.loadoutId = t.loadoutId
+            WHERE l.userId = ?
+        ) WHERE substr(tagName, 1, length(?)) = ?
+        GROUP BY tagName ORDER BY tagCount DESC, tagName LIMIT ?`, userID, userID, prefix, prefix, limit)
+	if err != nil {
+		return nil, fmt.Errorf("query tag suggestions: %w", err)
+	}
+	defer rows.Close()
+
+	suggestions, err := ScanRows[models.TagSuggestion](rows)
+	if err != nil {
+		return nil, fmt.Errorf("scan tag suggestions: %w", err)
+	}
+	if suggestions == nil {
+		suggestions = []models.TagSuggestion{}
+	}
+	return suggestions, nil
+}
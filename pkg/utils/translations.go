@@ -0,0 +1,643 @@
+package utils
+
+import (
+	"database/sql"
+	"fmt"
+	"reflect"
+	"regexp"
+	"sort"
+	"strconv"
+	"strings"
+	"sync"
+
+	models "github.com/Sea-Shell/gogear-api/pkg/models"
+)
+
+// Kinds of catalog entries with translated text.
+const (
+	TranslationKindGear        = "gear"
+	TranslationKindCategory    = "category"
+	TranslationKindTopCategory = "top_category"
+)
+
+// DefaultLocale is the locale of the catalog columns when the config does not name one.
+const DefaultLocale = "en"
+
+const (
+	// translationBatchSize is how many entries are looked up per translation query.
+	translationBatchSize = 500
+	maxTranslationLength = 200
+)
+
+// localePattern matches a lowercase BCP 47 language tag such as nb or nb-no.
+var localePattern = regexp.MustCompile(`^[a-z]{2,3}(-[a-z0-9]{2,8})*$`)
+
+// TranslationError reports an invalid locale or translation.
+type TranslationError struct {
+	Message string
+}
+
+func (e *TranslationError) Error() string {
+	return e.Message
+}
+
+// translatedColumn is a catalog column with translations and its json field name.
+// Required columns must be given in every translation.
+type translatedColumn struct {
+	column   string
+	jsonName string
+	required bool
+}
+
+// translationTable is where the translations of a kind of catalog entry are kept. The
+// translations table and the catalog table share idColumn. condition limits the entries
+// that are reported as missing a translation.
+type translationTable struct {
+	kind      string
+	table     string
+	catalog   string
+	idColumn  string
+	condition string
+	columns   []translatedColumn
+}
+
+var translationTables = []*translationTable{
+	{
+		kind: TranslationKindGear, table: "gear_translations", catalog: "gear", idColumn: "gearId",
+		condition: "gearStatus IS NOT 0",
+		columns: []translatedColumn{
+			{column: "gearName", jsonName: "gear_name"},
+			{column: "gearSizeDefinition", jsonName: "gear_size_definition"},
+		},
+	},
+	{
+		kind: TranslationKindCategory, table: "category_translations", catalog: "gear_category", idColumn: "categoryId",
+		columns: []translatedColumn{{column: "categoryName", jsonName: "category_name", required: true}},
+	},
+	{
+		kind: TranslationKindTopCategory, table: "top_category_translations", catalog: "gear_top_category", idColumn: "topCategoryId",
+		columns: []translatedColumn{{column: "topCategoryName", jsonName: "top_category_name", required: true}},
+	},
+}
+
+// translationTableFor returns the translation table of kind.
+func translationTableFor(kind string) (*translationTable, error) {
+	for _, table := range translationTables {
+		if table.kind == kind {
+			return table, nil
+		}
+	}
+	return nil, &TranslationError{Message: fmt.Sprintf("unknown translation kind %q", kind)}
+}
+
+// translatedColumnFor returns the translated column with a json field name and its table.
+func translatedColumnFor(jsonName string) (*translationTable, string) {
+	for _, table := range translationTables {
+		for _, translated := range table.columns {
+			if translated.jsonName == jsonName {
+				return table, translated.column
+			}
+		}
+	}
+	return nil, ""
+}
+
+// NormalizeLocale lowercases a BCP 47 language tag and separates its subtags with -. no,
+// the code for Norwegian in general, is taken as Bokmål, nb, which the catalog is
+// translated to.
+func NormalizeLocale(tag string) (string, error) {
+	locale := strings.ToLower(strings.ReplaceAll(strings.TrimSpace(tag), "_", "-"))
+	if !localePattern.MatchString(locale) {
+		return "", &TranslationError{Message: fmt.Sprintf("invalid locale %q", tag)}
+	}
+	if locale == "no" || strings.HasPrefix(locale, "no-") {
+		locale = "nb" + strings.TrimPrefix(locale, "no")
+	}
+	return locale, nil
+}
+
+// primaryLanguage returns the language subtag of a normalized locale, nb for nb-no.
+func primaryLanguage(locale string) string {
+	language, _, _ := strings.Cut(locale, "-")
+	return language
+}
+
+// localeFallbacks lists the locales whose translations are used for locale, best first.
+func localeFallbacks(locale string) []string {
+	if language := primaryLanguage(locale); language != locale {
+		return []string{locale, language}
+	}
+	return []string{locale}
+}
+
+// SameLanguage reports whether text in locale b is used for locale a, as for nb-no and nb.
+func SameLanguage(a string, b string) bool {
+	return a == b || primaryLanguage(a) == b
+}
+
+// MatchLocale returns the supported locale that best matches an Accept-Language header, or
+// an empty string when none does. A tag matches a supported locale that is the same, its
+// primary language, or in the same language. With no supported locales every valid tag
+// matches as is.
+func MatchLocale(header string, supported []string) string {
+	type weighted struct {
+		locale  string
+		quality float64
+	}
+	var tags []weighted
+	for _, part := range strings.Split(header, ",") {
+		tag, params, _ := strings.Cut(part, ";")
+		quality := 1.0
+		if value, ok := strings.CutPrefix(strings.TrimSpace(params), "q="); ok {
+			parsed, err := strconv.ParseFloat(value, 64)
+			if err != nil {
+				continue
+			}
+			quality = parsed
+		}
+		locale, err := NormalizeLocale(tag)
+		if err != nil || quality <= 0 {
+			continue
+		}
+		tags = append(tags, weighted{locale: locale, quality: quality})
+	}
+	sort.SliceStable(tags, func(i, j int) bool { return tags[i].quality > tags[j].quality })
+
+	for _, tag := range tags {
+		if len(supported) == 0 {
+			return tag.locale
+		}
+		for _, match := range []func(string) bool{
+			func(locale string) bool { return locale == tag.locale },
+			func(locale string) bool { return locale == primaryLanguage(tag.locale) },
+			func(locale string) bool { return primaryLanguage(locale) == primaryLanguage(tag.locale) },
+		} {
+			for _, locale := range supported {
+				if match(locale) {
+					return locale
+				}
+			}
+		}
+	}
+	return ""
+}
+
+// translatedField is a translated string field of a model and the field holding the ID of
+// the catalog entry it belongs to.
+type translatedField struct {
+	index  int
+	id     int
+	table  *translationTable
+	column string
+}
+
+var translationPlans sync.Map
+
+func translationPlanFor(modelType reflect.Type) []translatedField {
+	if cached, ok := translationPlans.Load(modelType); ok {
+		return cached.([]translatedField)
+	}
+
+	var plan []translatedField
+	for i := 0; i < modelType.NumField(); i++ {
+		structField := modelType.Field(i)
+		idName := structField.Tag.Get("translate")
+		if idName == "" {
+			continue
+		}
+		id, ok := modelType.FieldByName(idName)
+		if !ok {
+			panic(fmt.Sprintf("%s.%s: translate tag names missing field %s", modelType.Name(), structField.Name, idName))
+		}
+		jsonName := strings.Split(structField.Tag.Get("json"), ",")[0]
+		table, column := translatedColumnFor(jsonName)
+		if table == nil {
+			panic(fmt.Sprintf("%s.%s: %s has no translations", modelType.Name(), structField.Name, jsonName))
+		}
+		plan = append(plan, translatedField{index: i, id: id.Index[0], table: table, column: column})
+	}
+
+	translationPlans.Store(modelType, plan)
+	return plan
+}
+
+// HasTranslations reports whether model has fields tagged with translate.
+func HasTranslations[model any]() bool {
+	var params model
+	return len(translationPlanFor(reflect.TypeOf(params))) > 0
+}
+
+// Translate replaces the translated fields of items with their text in locale, or else in
+// its primary language. Fields without a translation keep the text of the default locale.
+// The translate tag of a field names the field holding the ID of its catalog entry, and its
+// json name, such as gear_name, the translated text.
+func Translate[model any](db queryer, locale string, items ...*model) error {
+	var params model
+	plan := translationPlanFor(reflect.TypeOf(params))
+	if len(plan) == 0 || len(items) == 0 {
+		return nil
+	}
+
+	ids := make(map[*translationTable]map[int64]bool)
+	for _, item := range items {
+		itemValue := reflect.ValueOf(item).Elem()
+		for _, field := range plan {
+			id := fieldValue(itemValue.Field(field.id))
+			if id == nil {
+				continue
+			}
+			if ids[field.table] == nil {
+				ids[field.table] = make(map[int64]bool)
+			}
+			ids[field.table][reflect.ValueOf(id).Int()] = true
+		}
+	}
+
+	texts := make(map[*translationTable]map[int64]map[string]string, len(ids))
+	for table, set := range ids {
+		var err error
+		if texts[table], err = loadTranslations(db, table, localeFallbacks(locale), set); err != nil {
+			return err
+		}
+	}
+
+	for _, item := range items {
+		itemValue := reflect.ValueOf(item).Elem()
+		for _, field := range plan {
+			id := fieldValue(itemValue.Field(field.id))
+			if id == nil {
+				continue
+			}
+			text, ok := texts[field.table][reflect.ValueOf(id).Int()][field.column]
+			if !ok {
+				continue
+			}
+			target := itemValue.Field(field.index)
+			if target.Kind() == reflect.Ptr {
+				target.Set(reflect.ValueOf(&text))
+			} else {
+				target.SetString(text)
+			}
+		}
+	}
+	return nil
+}
+
+// loadTranslations returns the translated text of the entries in ids by ID and column,
+// taking each column from the first of locales that translates it.
+func loadTranslations(db queryer, table *translationTable, locales []string, ids map[int64]bool) (map[int64]map[string]string, error) {
+	columns := make([]string, len(table.columns))
+	for i, translated := range table.columns {
+		columns[i] = translated.column
+	}
+	rank := make(map[string]int, len(locales))
+	for i, locale := range locales {
+		rank[locale] = i
+	}
+
+	sorted := make([]int64, 0, len(ids))
+	for id := range ids {
+		sorted = append(sorted, id)
+	}
+	sort.Slice(sorted, func(i, j int) bool { return sorted[i] < sorted[j] })
+
+	texts := make(map[int64]map[string]string)
+	ranks := make(map[int64]map[string]int)
+	for start := 0; start < len(sorted); start += translationBatchSize {
+		batch := sorted[start:min(start+translationBatchSize, len(sorted))]
+		args := make([]interface{}, 0, len(locales)+len(batch))
+		for _, locale := range locales {
+			args = append(args, locale)
+		}
+		for _, id := range batch {
+			args = append(args, id)
+		}
+
+		query := fmt.Sprintf("SELECT %s, locale, %s FROM %s WHERE locale IN (%s) AND %s IN (%s)",
+			table.idColumn, strings.Join(columns, ", "), table.table,
+			strings.TrimSuffix(strings.Repeat("?, ", len(locales)), ", "),
+			table.idColumn, strings.TrimSuffix(strings.Repeat("?, ", len(batch)), ", "))
+		rows, err := db.Query(query, args...)
+		if err != nil {
+			return nil, fmt.Errorf("query %s: %w", table.table, err)
+		}
+		for rows.Next() {
+			var id int64
+			var locale string
+			values := make([]sql.NullString, len(columns))
+			dest := []interface{}{&id, &locale}
+			for i := range values {
+				dest = append(dest, &values[i])
+			}
+			if err := rows.Scan(dest...); err != nil {
+				rows.Close()
+				return nil, fmt.Errorf("scan %s: %w", table.table, err)
+			}
+			if texts[id] == nil {
+				texts[id] = make(map[string]string)
+				ranks[id] = make(map[string]int)
+			}
+			for i, value := range values {
+				if !value.Valid || value.String == "" {
+					continue
+				}
+				if best, ok := ranks[id][columns[i]]; ok && best <= rank[locale] {
+					continue
+				}
+				texts[id][columns[i]] = value.String
+				ranks[id][columns[i]] = rank[locale]
+			}
+		}
+		err = rows.Err()
+		rows.Close()
+		if err != nil {
+			return nil, fmt.Errorf("query %s: %w", table.table, err)
+		}
+	}
+	return texts, nil
+}
+
+// Translations lists the translations of a catalog entry, ordered by locale. It returns
+// sql.ErrNoRows when the entry does not exist.
+func Translations(db *sql.DB, kind string, id int64) ([]models.Translation, error) {
+	table, err := translationTableFor(kind)
+	if err != nil {
+		return nil, err
+	}
+	if err := catalogEntryExists(db, table, id); err != nil {
+		return nil, err
+	}
+	return queryTranslations(db, table, table.idColumn+" = ?", id)
+}
+
+// SetTranslation replaces the translated text of a catalog entry in locale and returns the
+// stored translation. fields is keyed by json field name; fields left out or empty fall
+// back to the default locale. It returns sql.ErrNoRows when the entry does not exist.
+func SetTranslation(db *sql.DB, kind string, id int64, locale string, fields map[string]string) (*models.Translation, error) {
+	table, err := translationTableFor(kind)
+	if err != nil {
+		return nil, err
+	}
+	if locale, err = NormalizeLocale(locale); err != nil {
+		return nil, err
+	}
+
+	known := make(map[string]bool, len(table.columns))
+	for _, translated := range table.columns {
+		known[translated.jsonName] = true
+	}
+	for name := range fields {
+		if !known[name] {
+			return nil, &TranslationError{Message: fmt.Sprintf("%s has no translated field %s", kind, name)}
+		}
+	}
+
+	columns := make([]string, len(table.columns))
+	updates := make([]string, len(table.columns))
+	args := []interface{}{id, locale}
+	given := false
+	for i, translated := range table.columns {
+		columns[i] = translated.column
+		updates[i] = fmt.Sprintf("%s = excluded.%s", translated.column, translated.column)
+
+		text := strings.TrimSpace(fields[translated.jsonName])
+		if len([]rune(text)) > maxTranslationLength {
+			return nil, &TranslationError{Message: fmt.Sprintf("%s can be at most %d characters", translated.jsonName, maxTranslationLength)}
+		}
+		if text == "" {
+			if translated.required {
+				return nil, &TranslationError{Message: fmt.Sprintf("%s is required", translated.jsonName)}
+			}
+			args = append(args, nil)
+			continue
+		}
+		args = append(args, text)
+		given = true
+	}
+	if !given {
+		return nil, &TranslationError{Message: "a translation needs at least one field"}
+	}
+
+	if err := catalogEntryExists(db, table, id); err != nil {
+		return nil, err
+	}
+
+	_, err = db.Exec(fmt.Sprintf(`INSERT INTO %s (%s, locale, %s) VALUES (?, ?%s)
+        ON CONFLICT (%s, locale) DO UPDATE SET %s, updatedAt = strftime('%%Y-%%m-%%dT%%H:%%M:%%fZ', 'now')`,
+		table.table, table.idColumn, strings.Join(columns, ", "), strings.Repeat(", ?", len(columns)),
+		table.idColumn, strings.Join(updates, ", ")), args...)
+	if err != nil {
+		return nil, fmt.Errorf("save %s: %w", table.table, err)
+	}
+
+	translations, err := queryTranslations(db, table, table.idColumn+" = ? AND locale = ?", id, locale)
+	if err != nil {
+		return nil, err
+	}
+	if len(translations) == 0 {
+		return nil, sql.ErrNoRows
+	}
+	return &translations[0], nil
+}
+
+// DeleteTranslation removes the translation of a catalog entry in locale. It returns
+// sql.ErrNoRows when there is none.
+func DeleteTranslation(db *sql.DB, kind string, id int64, locale string) error {
+	table, err := translationTableFor(kind)
+	if err != nil {
+		return err
+	}
+	if locale, err = NormalizeLocale(locale); err != nil {
+		return err
+	}
+
+	result, err := db.Exec(fmt.Sprintf("DELETE FROM %s WHERE %s = ? AND locale = ?", table.table, table.idColumn), id, locale)
+	if err != nil {
+		return fmt.Errorf("delete %s: %w", table.table, err)
+	}
+	deleted, err := result.RowsAffected()
+	if err != nil {
+		return err
+	}
+	if deleted == 0 {
+		return sql.ErrNoRows
+	}
+	return nil
+}
+
+// catalogEntryExists returns sql.ErrNoRows when the catalog has no entry id of table's kind.
+func catalogEntryExists(db *sql.DB, table *translationTable, id int64) error {
+	var exists bool
+	err := db.QueryRow(fmt.Sprintf("SELECT EXISTS (SELECT 1 FROM %s WHERE %s = ?)", table.catalog, table.idColumn), id).Scan(&exists)
+	if err != nil {
+		return fmt.Errorf("query %s: %w", table.catalog, err)
+	}
+	if !exists {
+		return sql.ErrNoRows
+	}
+	return nil
+}
+
+// queryTranslations returns the translations of table matching condition, ordered by
+// locale.
+func queryTranslations(db *sql.DB, table *translationTable, condition string, args ...interface{}) ([]models.Translation, error) {
+	columns := make([]string, len(table.columns))
+	for i, translated := range table.columns {
+		columns[i] = translated.column
+	}
+
+	rows, err := db.Query(fmt.Sprintf("SELECT locale, updatedAt, %s FROM %s WHERE %s ORDER BY locale",
+		strings.Join(columns, ", "), table.table, condition), args...)
+	if err != nil {
+		return nil, fmt.Errorf("query %s: %w", table.table, err)
+	}
+	defer rows.Close()
+
+	translations := []models.Translation{}
+	for rows.Next() {
+		translation := models.Translation{Fields: make(map[string]string)}
+		values := make([]sql.NullString, len(columns))
+		dest := []interface{}{&translation.Locale, &translation.UpdatedAt}
+		for i := range values {
+			dest = append(dest, &values[i])
+		}
+		if err := rows.Scan(dest...); err != nil {
+			return nil, fmt.Errorf("scan %s: %w", table.table, err)
+		}
+		for i, value := range values {
+			if value.Valid {
+				translation.Fields[table.columns[i].jsonName] = value.String
+			}
+		}
+		translations = append(translations, translation)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, fmt.Errorf("query %s: %w", table.table, err)
+	}
+	return translations, nil
+}
+
+// TranslatedLocales returns the locales that have at least one translation, sorted.
+func TranslatedLocales(db *sql.DB) ([]string, error) {
+	queries := make([]string, len(translationTables))
+	for i, table := range translationTables {
+		queries[i] = "SELECT locale FROM " + table.table
+	}
+	rows, err := db.Query(strings.Join(queries, " UNION ") + " ORDER BY locale")
+	if err != nil {
+		return nil, fmt.Errorf("query translated locales: %w", err)
+	}
+	defer rows.Close()
+
+	locales := []string{}
+	for rows.Next() {
+		var locale string
+		if err := rows.Scan(&locale); err != nil {
+			return nil, fmt.Errorf("scan translated locales: %w", err)
+		}
+		locales = append(locales, locale)
+	}
+	return locales, rows.Err()
+}
+
+// MissingTranslations reports, for each locale, the catalog entries of the given kinds, or
+// of every kind when kinds is empty, that have text in the default locale without a
+// translation, counting translations to the locale's primary language. Gear is only
+// reported once it is in the catalog.
+func MissingTranslations(db *sql.DB, locales []string, kinds []string) ([]models.MissingTranslationReport, error) {
+	tables := translationTables
+	if len(kinds) > 0 {
+		tables = nil
+		for _, kind := range kinds {
+			table, err := translationTableFor(kind)
+			if err != nil {
+				return nil, err
+			}
+			tables = append(tables, table)
+		}
+	}
+
+	reports := make([]models.MissingTranslationReport, 0, len(locales))
+	for _, locale := range locales {
+		locale, err := NormalizeLocale(locale)
+		if err != nil {
+			return nil, err
+		}
+		report := models.MissingTranslationReport{Locale: locale, Missing: []models.MissingTranslation{}}
+		for _, table := range tables {
+			total, missing, err := missingTranslations(db, table, locale)
+			if err != nil {
+				return nil, err
+			}
+			report.Total += total
+			report.Missing = append(report.Missing, missing...)
+		}
+		reports = append(reports, report)
+	}
+	return reports, nil
+}
+
+// missingTranslations returns how many entries of table are reported on and those missing a
+// translation to locale. Text translated to the primary language of locale counts.
+func missingTranslations(db *sql.DB, table *translationTable, locale string) (int64, []models.MissingTranslation, error) {
+	locales := localeFallbacks(locale)
+	localeList := strings.TrimSuffix(strings.Repeat("?, ", len(locales)), ", ")
+
+	var args []interface{}
+	selected := make([]string, 0, 2*len(table.columns))
+	for _, translated := range table.columns {
+		selected = append(selected, "entry."+translated.column)
+	}
+	for _, translated := range table.columns {
+		selected = append(selected, fmt.Sprintf(`EXISTS (SELECT 1 FROM %s translation
+            WHERE translation.%s = entry.%s AND translation.locale IN (%s) AND translation.%s != '')`,
+			table.table, table.idColumn, table.idColumn, localeList, translated.column))
+		for _, fallback := range locales {
+			args = append(args, fallback)
+		}
+	}
+	condition := ""
+	if table.condition != "" {
+		condition = " WHERE entry." + table.condition
+	}
+
+	rows, err := db.Query(fmt.Sprintf("SELECT entry.%s, %s FROM %s entry%s ORDER BY entry.%s",
+		table.idColumn, strings.Join(selected, ", "), table.catalog, condition, table.idColumn), args...)
+	if err != nil {
+		return 0, nil, fmt.Errorf("query missing %s: %w", table.table, err)
+	}
+	defer rows.Close()
+
+	var total int64
+	missing := []models.MissingTranslation{}
+	for rows.Next() {
+		var id int64
+		originals := make([]sql.NullString, len(table.columns))
+		translatedColumns := make([]bool, len(table.columns))
+		dest := []interface{}{&id}
+		for i := range originals {
+			dest = append(dest, &originals[i])
+		}
+		for i := range translatedColumns {
+			dest = append(dest, &translatedColumns[i])
+		}
+		if err := rows.Scan(dest...); err != nil {
+			return 0, nil, fmt.Errorf("scan missing %s: %w", table.table, err)
+		}
+		total++
+
+		entry := models.MissingTranslation{Kind: table.kind, ID: id, Name: originals[0].String}
+		for i, translated := range table.columns {
+			if originals[i].String != "" && !translatedColumns[i] {
+				entry.MissingFields = append(entry.MissingFields, translated.jsonName)
+			}
+		}
+		if len(entry.MissingFields) > 0 {
+			missing = append(missing, entry)
+		}
+	}
+	if err := rows.Err(); err != nil {
+		return 0, nil, fmt.Errorf("query missing %s: %w", table.table, err)
+	}
+	return total, missing, nil
+}
@@ -0,0 +1,41 @@
+package utils
+
+import "testing"
+
+func TestMatchLocale(t *testing.T) {
+	supported := []string{"nb", "en"}
+	cases := []struct {
+		header    string
+		supported []string
+		want      string
+	}{
+		{"nb-NO,nb;q=0.9,en;q=0.8", supported, "nb"},
+		{"no", supported, "nb"},
+		{"de;q=0.9, en;q=0.5", supported, "en"},
+		{"en;q=0.2, nb;q=0.8", supported, "nb"},
+		{"de, fr;q=0.5", supported, ""},
+		{"*", supported, ""},
+		{"nb;q=0, en", supported, "en"},
+		{"sv-SE", nil, "sv-se"},
+		{"nb", []string{"nb-no", "en"}, "nb-no"},
+		{"", supported, ""},
+	}
+	for _, tc := range cases {
+		if got := MatchLocale(tc.header, tc.supported); got != tc.want {
+			t.Errorf("MatchLocale(%q, %v) = %q, want %q", tc.header, tc.supported, got, tc.want)
+		}
+	}
+}
+
+func TestNormalizeLocale(t *testing.T) {
+	for tag, want := range map[string]string{"nb_NO": "nb-no", " EN ": "en", "no-NO": "nb-no"} {
+		if got, err := NormalizeLocale(tag); err != nil || got != want {
+			t.Errorf("NormalizeLocale(%q) = %q, %v, want %q", tag, got, err, want)
+		}
+	}
+	for _, tag := range []string{"", "norsk", "nb-", "n"} {
+		if _, err := NormalizeLocale(tag); err == nil {
+			t.Errorf("NormalizeLocale(%q): expected error", tag)
+		}
+	}
+}
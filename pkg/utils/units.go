@@ -0,0 +1,233 @@
+package utils
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"math"
+	"reflect"
+	"strings"
+	"sync"
+
+	models "github.com/Sea-Shell/gogear-api/pkg/models"
+)
+
+// Unit systems a response can be converted to.
+const (
+	UnitSystemMetric   = "metric"
+	UnitSystemImperial = "imperial"
+)
+
+// Kinds of measured fields, set with the unit struct tag. Weights are stored in grams and
+// lengths in millimetres.
+const (
+	unitKindWeight      = "weight"
+	unitKindLength      = "length"
+	unitKindTotalWeight = "total_weight"
+)
+
+// UnitError reports an unknown unit system or a measurement that cannot be converted.
+type UnitError struct {
+	Message string
+}
+
+func (e *UnitError) Error() string {
+	return e.Message
+}
+
+func unitErrorf(format string, args ...interface{}) error {
+	return &UnitError{Message: fmt.Sprintf(format, args...)}
+}
+
+// unit is a supported input or output unit and its size in the canonical unit.
+type unit struct {
+	symbol    string
+	weight    bool
+	canonical float64
+}
+
+var units = map[string]unit{
+	"g":  {symbol: "g", weight: true, canonical: 1},
+	"kg": {symbol: "kg", weight: true, canonical: 1000},
+	"oz": {symbol: "oz", weight: true, canonical: 28.349523125},
+	"lb": {symbol: "lb", weight: true, canonical: 453.59237},
+	"mm": {symbol: "mm", canonical: 1},
+	"cm": {symbol: "cm", canonical: 10},
+	"m":  {symbol: "m", canonical: 1000},
+	"in": {symbol: "in", canonical: 25.4},
+	"ft": {symbol: "ft", canonical: 304.8},
+}
+
+// unitAliases maps spelled out unit names to their symbol.
+var unitAliases = map[string]string{
+	"gram": "g", "grams": "g",
+	"kilo": "kg", "kilos": "kg", "kilogram": "kg", "kilograms": "kg",
+	"ounce": "oz", "ounces": "oz",
+	"pound": "lb", "pounds": "lb", "lbs": "lb",
+	"millimeter": "mm", "millimeters": "mm", "millimetre": "mm", "millimetres": "mm",
+	"centimeter": "cm", "centimeters": "cm", "centimetre": "cm", "centimetres": "cm",
+	"meter": "m", "meters": "m", "metre": "m", "metres": "m",
+	"inch": "in", "inches": "in",
+	"foot": "ft", "feet": "ft",
+}
+
+// displayUnits is the unit each kind of field is shown in, per unit system.
+var displayUnits = map[string]map[string]string{
+	UnitSystemMetric:   {unitKindWeight: "g", unitKindLength: "cm", unitKindTotalWeight: "kg"},
+	UnitSystemImperial: {unitKindWeight: "oz", unitKindLength: "in", unitKindTotalWeight: "lb"},
+}
+
+// ParseUnitSystem validates a units= value or stored preference.
+func ParseUnitSystem(value string) (string, error) {
+	system := strings.ToLower(strings.TrimSpace(value))
+	if _, ok := displayUnits[system]; !ok {
+		return "", unitErrorf("Invalid units %q. Use metric or imperial.", value)
+	}
+	return system, nil
+}
+
+func lookupUnit(name string) (unit, bool) {
+	name = strings.ToLower(strings.TrimSpace(name))
+	if symbol, ok := unitAliases[name]; ok {
+		name = symbol
+	}
+	u, ok := units[name]
+	return u, ok
+}
+
+// ToCanonical converts value in the named unit to grams or millimetres, depending on kind.
+func ToCanonical(value float64, unitName string, kind string) (int64, error) {
+	u, ok := lookupUnit(unitName)
+	if !ok {
+		return 0, unitErrorf("Unknown unit %q", unitName)
+	}
+	if u.weight != (kind != unitKindLength) {
+		return 0, unitErrorf("Unit %q cannot be used for a %s", unitName, kind)
+	}
+	if value < 0 || math.IsNaN(value) || math.IsInf(value, 0) {
+		return 0, unitErrorf("Measurement cannot be negative")
+	}
+	return int64(math.Round(value * u.canonical)), nil
+}
+
+// FromCanonical converts grams or millimetres to the unit kind is shown in under system,
+// rounded to two decimals.
+func FromCanonical(value int64, kind string, system string) models.Measurement {
+	u := units[displayUnits[system][kind]]
+	return models.Measurement{
+		Value: math.Round(float64(value)/u.canonical*100) / 100,
+		Unit:  u.symbol,
+	}
+}
+
+// measuredField is a model field tagged with a unit kind.
+type measuredField struct {
+	index    int
+	jsonName string
+	kind     string
+}
+
+// unitPlan lists the measured fields of a model and where to put their conversions.
+type unitPlan struct {
+	fields       []measuredField
+	measurements int
+}
+
+var unitPlans sync.Map
+
+func unitPlanFor(modelType reflect.Type) *unitPlan {
+	if cached, ok := unitPlans.Load(modelType); ok {
+		return cached.(*unitPlan)
+	}
+
+	plan := &unitPlan{measurements: -1}
+	measurementsType := reflect.TypeOf(models.Measurements{})
+	for i := 0; i < modelType.NumField(); i++ {
+		structField := modelType.Field(i)
+		if structField.Type == measurementsType {
+			plan.measurements = i
+			continue
+		}
+		kind := structField.Tag.Get("unit")
+		if kind == "" {
+			continue
+		}
+		jsonName := strings.Split(structField.Tag.Get("json"), ",")[0]
+		plan.fields = append(plan.fields, measuredField{index: i, jsonName: jsonName, kind: kind})
+	}
+
+	unitPlans.Store(modelType, plan)
+	return plan
+}
+
+// HasMeasurements reports whether model has a Measurements field to convert into.
+func HasMeasurements[model any]() bool {
+	var params model
+	plan := unitPlanFor(reflect.TypeOf(params))
+	return plan.measurements >= 0 && len(plan.fields) > 0
+}
+
+// ApplyUnits fills the Measurements field of item with its unit-tagged fields converted to
+// system, keyed by json field name. Nil fields are left out. It is a no-op when system is
+// empty or the model has no Measurements field.
+func ApplyUnits[model any](item *model, system string) {
+	if system == "" {
+		return
+	}
+	itemValue := reflect.ValueOf(item).Elem()
+	plan := unitPlanFor(itemValue.Type())
+	if plan.measurements < 0 || len(plan.fields) == 0 {
+		return
+	}
+
+	measurements := make(models.Measurements, len(plan.fields))
+	for _, field := range plan.fields {
+		value := fieldValue(itemValue.Field(field.index))
+		if value == nil {
+			continue
+		}
+		measurements[field.jsonName] = FromCanonical(reflect.ValueOf(value).Int(), field.kind, system)
+	}
+	itemValue.Field(plan.measurements).Set(reflect.ValueOf(measurements))
+}
+
+// NormalizeMeasurements rewrites measurements in a JSON request body for model given as
+// {"value": 2.1, "unit": "kg"} objects to whole grams or millimetres. Plain numbers are
+// taken as grams or millimetres already.
+func NormalizeMeasurements[model any](data []byte) ([]byte, error) {
+	var params model
+	plan := unitPlanFor(reflect.TypeOf(params))
+	if len(plan.fields) == 0 {
+		return data, nil
+	}
+
+	var body map[string]json.RawMessage
+	if err := json.Unmarshal(data, &body); err != nil {
+		// Left for the caller's own decoding to report.
+		return data, nil
+	}
+
+	changed := false
+	for _, field := range plan.fields {
+		raw, ok := body[field.jsonName]
+		if !ok || !bytes.HasPrefix(bytes.TrimSpace(raw), []byte("{")) {
+			continue
+		}
+
+		var measurement models.Measurement
+		if err := json.Unmarshal(raw, &measurement); err != nil {
+			return nil, unitErrorf("%s: %s", field.jsonName, err.Error())
+		}
+		canonical, err := ToCanonical(measurement.Value, measurement.Unit, field.kind)
+		if err != nil {
+			return nil, unitErrorf("%s: %s", field.jsonName, err.Error())
+		}
+		body[field.jsonName] = json.RawMessage(fmt.Sprint(canonical))
+		changed = true
+	}
+	if !changed {
+		return data, nil
+	}
+
+	return json.Marshal(body)
+}
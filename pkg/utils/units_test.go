@@ -0,0 +1,68 @@
+package utils
+
+import (
+	"encoding/json"
+	"testing"
+
+	models "github.com/Sea-Shell/gogear-api/pkg/models"
+)
+
+func TestToCanonicalAcceptsAliasesAndRejectsMismatchedUnits(t *testing.T) {
+	cases := []struct {
+		value float64
+		unit  string
+		kind  string
+		want  int64
+	}{
+		{1.5, "Pounds", unitKindWeight, 680},
+		{3, "oz", unitKindWeight, 85},
+		{12, "inches", unitKindLength, 305},
+		{2.1, "kg", unitKindTotalWeight, 2100},
+	}
+	for _, tc := range cases {
+		got, err := ToCanonical(tc.value, tc.unit, tc.kind)
+		if err != nil || got != tc.want {
+			t.Errorf("ToCanonical(%v, %q) = %d, %v, want %d", tc.value, tc.unit, got, err, tc.want)
+		}
+	}
+
+	for _, tc := range []struct{ unit, kind string }{{"cm", unitKindWeight}, {"lb", unitKindLength}, {"stone", unitKindWeight}} {
+		if _, err := ToCanonical(1, tc.unit, tc.kind); err == nil {
+			t.Errorf("ToCanonical(1, %q) for a %s: expected error", tc.unit, tc.kind)
+		}
+	}
+}
+
+func TestNormalizeMeasurementsKeepsPlainNumbers(t *testing.T) {
+	data, err := NormalizeMeasurements[models.GearVariantNoID]([]byte(`{"variant_name":"L","variant_weight":{"value":1.2,"unit":"lb"},"variant_length":2300}`))
+	if err != nil {
+		t.Fatalf("normalize: %v", err)
+	}
+
+	var variant models.GearVariantNoID
+	if err := json.Unmarshal(data, &variant); err != nil {
+		t.Fatalf("decode %s: %v", data, err)
+	}
+	if variant.VariantName != "L" || variant.VariantWeight != 544 || variant.VariantLength != 2300 {
+		t.Errorf("normalized variant = %+v", variant)
+	}
+
+	if _, err := NormalizeMeasurements[models.GearVariantNoID]([]byte(`{"variant_weight":{"value":-1,"unit":"g"}}`)); err == nil {
+		t.Error("expected error for negative measurement")
+	}
+}
+
+func TestApplyUnitsSkipsNilFields(t *testing.T) {
+	item := models.UserGear{GearWeight: 1000, GearLength: 254}
+	ApplyUnits(&item, UnitSystemImperial)
+
+	if got := item.Measurements["gear_weight"]; got != (models.Measurement{Value: 35.27, Unit: "oz"}) {
+		t.Errorf("gear_weight = %+v", got)
+	}
+	if got := item.Measurements["gear_length"]; got != (models.Measurement{Value: 10, Unit: "in"}) {
+		t.Errorf("gear_length = %+v", got)
+	}
+	if _, ok := item.Measurements["variant_weight"]; ok {
+		t.Error("nil variant_weight should be left out")
+	}
+}